@@ -143,6 +143,20 @@ type RXInfo struct {
 	Latitude  float64 `protobuf:"fixed64,6,opt,name=latitude" json:"latitude,omitempty"`
 	Longitude float64 `protobuf:"fixed64,7,opt,name=longitude" json:"longitude,omitempty"`
 	Altitude  float64 `protobuf:"fixed64,8,opt,name=altitude" json:"altitude,omitempty"`
+	// Concentrator board used for RX.
+	Board uint32 `protobuf:"varint,9,opt,name=board" json:"board,omitempty"`
+	// Antenna used for RX.
+	Antenna uint32 `protobuf:"varint,10,opt,name=antenna" json:"antenna,omitempty"`
+	// Type of the fine-timestamp (PLAIN or ENCRYPTED, empty when not available).
+	FineTimestampType string `protobuf:"bytes,11,opt,name=fineTimestampType" json:"fineTimestampType,omitempty"`
+	// Plain fine-timestamp (nanoseconds since the last GPS second).
+	FineTimestamp uint32 `protobuf:"varint,12,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// Encrypted fine-timestamp.
+	EncryptedFineTimestamp []byte `protobuf:"bytes,13,opt,name=encryptedFineTimestamp,proto3" json:"encryptedFineTimestamp,omitempty"`
+	// Index of the key used to encrypt the fine-timestamp.
+	FineTimestampKeyID uint32 `protobuf:"varint,14,opt,name=fineTimestampKeyID" json:"fineTimestampKeyID,omitempty"`
+	// Signal RSSI (0 when not reported by the gateway).
+	SignalRSSI int32 `protobuf:"varint,15,opt,name=signalRSSI" json:"signalRSSI,omitempty"`
 }
 
 func (m *RXInfo) Reset()                    { *m = RXInfo{} }
@@ -206,6 +220,55 @@ func (m *RXInfo) GetAltitude() float64 {
 	return 0
 }
 
+func (m *RXInfo) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *RXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *RXInfo) GetFineTimestampType() string {
+	if m != nil {
+		return m.FineTimestampType
+	}
+	return ""
+}
+
+func (m *RXInfo) GetFineTimestamp() uint32 {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return 0
+}
+
+func (m *RXInfo) GetEncryptedFineTimestamp() []byte {
+	if m != nil {
+		return m.EncryptedFineTimestamp
+	}
+	return nil
+}
+
+func (m *RXInfo) GetFineTimestampKeyID() uint32 {
+	if m != nil {
+		return m.FineTimestampKeyID
+	}
+	return 0
+}
+
+func (m *RXInfo) GetSignalRSSI() int32 {
+	if m != nil {
+		return m.SignalRSSI
+	}
+	return 0
+}
+
 type TXInfo struct {
 	Frequency int64     `protobuf:"varint,1,opt,name=frequency" json:"frequency,omitempty"`
 	DataRate  *DataRate `protobuf:"bytes,2,opt,name=dataRate" json:"dataRate,omitempty"`
@@ -391,6 +454,10 @@ type HandleDataUpRequest struct {
 	RxInfo              []*RXInfo `protobuf:"bytes,7,rep,name=rxInfo" json:"rxInfo,omitempty"`
 	DeviceStatusBattery uint32    `protobuf:"varint,9,opt,name=deviceStatusBattery" json:"deviceStatusBattery,omitempty"`
 	DeviceStatusMargin  int32     `protobuf:"varint,10,opt,name=deviceStatusMargin" json:"deviceStatusMargin,omitempty"`
+	// SessionKeyID returned by the join-server on the activation of the
+	// device. It must be used by the application-server to request the
+	// AppSKey from the join-server (AppSKeyReq).
+	SessionKeyID []byte `protobuf:"bytes,11,opt,name=sessionKeyID,proto3" json:"sessionKeyID,omitempty"`
 }
 
 func (m *HandleDataUpRequest) Reset()                    { *m = HandleDataUpRequest{} }
@@ -461,6 +528,13 @@ func (m *HandleDataUpRequest) GetDeviceStatusMargin() int32 {
 	return 0
 }
 
+func (m *HandleDataUpRequest) GetSessionKeyID() []byte {
+	if m != nil {
+		return m.SessionKeyID
+	}
+	return nil
+}
+
 type HandleProprietaryUpRequest struct {
 	// MACPayload of the proprietary LoRaWAN frame.
 	MacPayload []byte `protobuf:"bytes,1,opt,name=macPayload,proto3" json:"macPayload,omitempty"`
@@ -926,69 +1000,76 @@ var _ApplicationServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("as.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1016 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0x36, 0x45, 0x59, 0xa2, 0x46, 0xb2, 0xab, 0xac, 0x53, 0x9b, 0x55, 0x94, 0x54, 0xe5, 0xa1,
-	0x30, 0x7c, 0x30, 0x1a, 0xf5, 0x05, 0xaa, 0x4a, 0x76, 0xaa, 0xa6, 0x49, 0x8c, 0x95, 0x8c, 0xf8,
-	0xd4, 0x60, 0x4d, 0xae, 0x62, 0x22, 0xd4, 0x92, 0x5d, 0xae, 0x65, 0xb1, 0x68, 0x8b, 0x9e, 0x7a,
-	0xe8, 0x03, 0xf4, 0x2d, 0xfa, 0x1a, 0x05, 0xfa, 0x56, 0xc5, 0xfe, 0x50, 0xa4, 0x22, 0x19, 0x08,
-	0x82, 0x9e, 0xb4, 0xf3, 0xcd, 0x72, 0x7e, 0xbe, 0xf9, 0x66, 0x21, 0x70, 0x48, 0x7a, 0x9a, 0xf0,
-	0x58, 0xc4, 0xa8, 0x42, 0x52, 0xef, 0x0f, 0x0b, 0x9c, 0x11, 0x11, 0x04, 0x13, 0x41, 0xd1, 0x13,
-	0x80, 0x79, 0x1c, 0xdc, 0x46, 0x44, 0x84, 0x31, 0x73, 0xad, 0x9e, 0x75, 0xdc, 0xc0, 0x25, 0x04,
-	0x75, 0xa1, 0x71, 0x4d, 0x58, 0xf0, 0x3a, 0x0c, 0xc4, 0x8d, 0x5b, 0xe9, 0x59, 0xc7, 0x7b, 0xb8,
-	0x00, 0x90, 0x07, 0xad, 0x34, 0xe1, 0x94, 0x04, 0xe7, 0xc4, 0x17, 0x31, 0x77, 0x6d, 0x75, 0x61,
-	0x0d, 0x43, 0x2e, 0xd4, 0xaf, 0x43, 0xc1, 0x89, 0xa0, 0x6e, 0x55, 0xb9, 0x73, 0xd3, 0xfb, 0xc7,
-	0x82, 0x1a, 0xbe, 0x1a, 0xb3, 0x59, 0x8c, 0xda, 0x60, 0xcf, 0x89, 0xaf, 0xf2, 0xb7, 0xb0, 0x3c,
-	0x22, 0x04, 0x55, 0x11, 0xce, 0xa9, 0xca, 0xd9, 0xc0, 0xea, 0x2c, 0x31, 0x9e, 0xa6, 0xa1, 0x4a,
-	0xb3, 0x8b, 0xd5, 0x59, 0x86, 0x8f, 0x62, 0x4c, 0x26, 0x2f, 0xb1, 0x0a, 0x6f, 0xe1, 0xdc, 0x94,
-	0xb7, 0x19, 0x99, 0x53, 0x77, 0x57, 0x47, 0x90, 0x67, 0xd4, 0x01, 0x47, 0x36, 0x26, 0x6e, 0x03,
-	0xea, 0xd6, 0xd4, 0xf5, 0x95, 0x2d, 0x5b, 0x8d, 0x62, 0xf6, 0x56, 0x3b, 0xeb, 0xca, 0x59, 0x00,
-	0xf2, 0x4b, 0x12, 0x99, 0x2f, 0x1d, 0xfd, 0x65, 0x6e, 0x7b, 0xbf, 0x41, 0x6d, 0xaa, 0xfb, 0xe8,
-	0x42, 0x63, 0xc6, 0xe9, 0x4f, 0xb7, 0x94, 0xf9, 0x99, 0xea, 0xc6, 0xc6, 0x05, 0x80, 0x8e, 0xc1,
-	0x09, 0x0c, 0xf1, 0xaa, 0xaf, 0x66, 0xbf, 0x75, 0x4a, 0xd2, 0xd3, 0x7c, 0x18, 0x78, 0xe5, 0x95,
-	0x7c, 0x90, 0x40, 0xf3, 0xe9, 0x60, 0x79, 0x94, 0xf9, 0xfd, 0x38, 0xa0, 0x38, 0xe7, 0xb1, 0x81,
-	0x57, 0xb6, 0xf7, 0x0b, 0xa0, 0xef, 0xe3, 0x90, 0x61, 0x99, 0x27, 0x15, 0xe6, 0x47, 0x8e, 0x36,
-	0xb9, 0xc9, 0x2e, 0x48, 0x16, 0xc5, 0x24, 0x30, 0xd4, 0x96, 0x10, 0xc9, 0x5c, 0x40, 0x17, 0x83,
-	0x20, 0xe0, 0xaa, 0x98, 0x16, 0xce, 0x4d, 0xf4, 0x10, 0x76, 0x19, 0x15, 0xe3, 0x91, 0xca, 0xdf,
-	0xc2, 0xda, 0x40, 0x87, 0x50, 0xf3, 0xcf, 0x7f, 0x08, 0x53, 0xe1, 0x56, 0x7b, 0xf6, 0xf1, 0x1e,
-	0x36, 0x96, 0xf7, 0x6f, 0x05, 0x0e, 0xd6, 0xd2, 0xa7, 0x49, 0xcc, 0x52, 0xfa, 0x21, 0xf9, 0xd9,
-	0xdd, 0xbb, 0xc9, 0x73, 0x9a, 0xe5, 0xf9, 0x8d, 0x29, 0x3d, 0x7c, 0x39, 0xa2, 0x11, 0xc9, 0x8c,
-	0xa2, 0x72, 0x13, 0xf5, 0xa0, 0xc9, 0x97, 0x4f, 0x47, 0xf8, 0xd5, 0x6c, 0x96, 0x52, 0x61, 0x04,
-	0x55, 0x86, 0x24, 0xc7, 0x7c, 0xf9, 0x3a, 0x64, 0x41, 0x7c, 0xa7, 0x26, 0xbc, 0xaf, 0x39, 0xc6,
-	0x57, 0x1a, 0xc3, 0x2b, 0xaf, 0xec, 0x92, 0x2f, 0xfb, 0x23, 0xac, 0x66, 0xbd, 0x87, 0xb5, 0x81,
-	0x4e, 0xa0, 0x1d, 0x84, 0x29, 0xb9, 0x8e, 0xe8, 0xf9, 0x90, 0x89, 0xe1, 0x0d, 0xf5, 0xdf, 0xa9,
-	0x79, 0x3b, 0x78, 0x03, 0x97, 0xd5, 0x90, 0x80, 0x8f, 0x99, 0xa0, 0x7c, 0x41, 0x22, 0xb7, 0xa1,
-	0xab, 0x29, 0x41, 0xe8, 0x14, 0x50, 0xc8, 0x52, 0x41, 0x22, 0xbd, 0x4e, 0x2f, 0x08, 0x7f, 0x1b,
-	0x32, 0x17, 0x94, 0x7e, 0xb6, 0x78, 0xbc, 0xbf, 0x2b, 0x70, 0xf0, 0x1d, 0x61, 0x41, 0x44, 0xa5,
-	0x28, 0x2e, 0x93, 0x7c, 0x96, 0x87, 0x50, 0x0b, 0xe8, 0xe2, 0xec, 0x72, 0x6c, 0x78, 0x34, 0x96,
-	0xc4, 0x49, 0x92, 0x48, 0x5c, 0x53, 0x68, 0x2c, 0xa9, 0xfd, 0xd9, 0x90, 0x09, 0x43, 0x9f, 0x3a,
-	0xcb, 0x7e, 0x67, 0x17, 0x31, 0xcf, 0x59, 0xd3, 0x86, 0xbc, 0x29, 0x55, 0xa7, 0xb6, 0xa4, 0x85,
-	0xd5, 0x19, 0x79, 0x50, 0x13, 0x4b, 0xa9, 0x67, 0xc5, 0x60, 0xb3, 0x0f, 0x92, 0x41, 0xad, 0x70,
-	0x6c, 0x3c, 0xf2, 0x0e, 0xd7, 0x77, 0xea, 0x3d, 0x3b, 0xbf, 0x83, 0xcd, 0x1d, 0xed, 0x41, 0x5f,
-	0xc1, 0x41, 0x40, 0x17, 0xa1, 0x4f, 0x27, 0x82, 0x88, 0xdb, 0xf4, 0x5b, 0x22, 0x04, 0xe5, 0x99,
-	0xe1, 0x69, 0x9b, 0x4b, 0xf2, 0x55, 0x86, 0x4b, 0x7c, 0xed, 0xe2, 0x2d, 0x1e, 0xef, 0x2f, 0x0b,
-	0x3a, 0x9a, 0xaf, 0x0b, 0x1e, 0x27, 0x3c, 0xa4, 0x82, 0xf0, 0xac, 0xa0, 0x4d, 0xbe, 0x6e, 0xc4,
-	0x7f, 0x4f, 0x82, 0x05, 0xa2, 0x9e, 0x9d, 0xd0, 0x37, 0xdc, 0xc9, 0x63, 0xa9, 0x75, 0xfb, 0x03,
-	0x5a, 0xaf, 0xde, 0xd7, 0xba, 0xf7, 0x18, 0x1e, 0x6d, 0xad, 0x4b, 0xef, 0x86, 0xf7, 0xbb, 0x05,
-	0xe8, 0x19, 0x15, 0x72, 0xc8, 0xa3, 0xf8, 0x8e, 0x7d, 0xec, 0x98, 0xbf, 0x84, 0xfd, 0x39, 0x59,
-	0x9a, 0x6e, 0x26, 0xe1, 0xcf, 0xd4, 0x0c, 0xfc, 0x3d, 0x74, 0x25, 0x87, 0x6a, 0x21, 0x07, 0x2f,
-	0x83, 0x83, 0xb5, 0x0a, 0xcc, 0xd6, 0xe6, 0x7a, 0xb0, 0x4a, 0x7a, 0xe8, 0x42, 0xc3, 0x8f, 0xd9,
-	0x2c, 0xe4, 0x73, 0x1a, 0xa8, 0x0a, 0x1c, 0x5c, 0x00, 0x85, 0xae, 0xec, 0xb2, 0xae, 0x3a, 0xe0,
-	0xcc, 0x63, 0xae, 0x64, 0xac, 0xd2, 0x3a, 0x78, 0x65, 0x7b, 0x87, 0xf0, 0x70, 0x5d, 0xe4, 0x86,
-	0x95, 0x1f, 0xc1, 0x2d, 0x70, 0x59, 0xd5, 0x60, 0xf8, 0xfc, 0x7f, 0xdc, 0x00, 0xef, 0x11, 0x7c,
-	0xb6, 0x25, 0xbe, 0x49, 0xfe, 0x2b, 0x20, 0xed, 0x3c, 0xe3, 0x3c, 0xe6, 0x1f, 0x9b, 0xf6, 0x0b,
-	0xa8, 0x8a, 0x2c, 0xd1, 0x73, 0xd8, 0xef, 0xef, 0x49, 0x65, 0xa8, 0x78, 0xd3, 0x2c, 0xa1, 0x58,
-	0xb9, 0x24, 0x5f, 0x54, 0x42, 0xe6, 0x19, 0xd7, 0x86, 0xf7, 0x69, 0xbe, 0xf8, 0x26, 0xbd, 0xae,
-	0xea, 0xa4, 0x0b, 0x4e, 0xfe, 0x74, 0xa1, 0x3a, 0xd8, 0xf8, 0xea, 0x69, 0x7b, 0x47, 0x1f, 0xfa,
-	0x6d, 0xeb, 0xe4, 0x0c, 0x1a, 0xab, 0xe8, 0xa8, 0x09, 0xf5, 0x67, 0x94, 0x51, 0x1e, 0xfa, 0xed,
-	0x1d, 0xe4, 0x40, 0xf5, 0xd5, 0x74, 0x30, 0x68, 0x5b, 0xa8, 0x0d, 0xad, 0xd1, 0x60, 0x3a, 0x78,
-	0x73, 0x79, 0xf1, 0xe6, 0x7c, 0xf8, 0x72, 0xda, 0xae, 0xa0, 0x4f, 0xa0, 0x99, 0x23, 0x2f, 0xc6,
-	0xc3, 0xb6, 0xdd, 0xff, 0xd3, 0x86, 0x07, 0x83, 0x24, 0x89, 0x42, 0x5f, 0xbd, 0x45, 0x13, 0xca,
-	0x17, 0x94, 0xa3, 0x21, 0xb4, 0xca, 0x53, 0x42, 0x47, 0xb2, 0x99, 0x2d, 0x8f, 0x53, 0xc7, 0xdd,
-	0x74, 0x18, 0x4e, 0x77, 0xd0, 0x55, 0xde, 0xd6, 0xda, 0x1e, 0xa0, 0x27, 0xc5, 0x27, 0xdb, 0x16,
-	0xb7, 0xf3, 0xf9, 0xbd, 0xfe, 0x55, 0xe4, 0x6f, 0xa0, 0x59, 0xd2, 0x2f, 0x3a, 0x94, 0x5f, 0x6c,
-	0xae, 0x54, 0xe7, 0x68, 0x03, 0x5f, 0x45, 0xc0, 0xf0, 0x60, 0x43, 0x0e, 0xa8, 0xbb, 0xde, 0xcc,
-	0xba, 0x0a, 0x3b, 0x8f, 0xef, 0xf1, 0x96, 0xab, 0x2a, 0x8d, 0x51, 0x57, 0xb5, 0x29, 0xab, 0xce,
-	0xd1, 0x06, 0x9e, 0x47, 0xb8, 0xae, 0xa9, 0x7f, 0x6a, 0x5f, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff,
-	0xd8, 0x4e, 0xc8, 0x9f, 0xb5, 0x09, 0x00, 0x00,
+	// 1128 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x51, 0x6f, 0x1b, 0x45,
+	0x10, 0xae, 0x73, 0x8e, 0x73, 0x1e, 0xdb, 0xad, 0xbb, 0x29, 0xc9, 0xe1, 0xa6, 0x25, 0x9c, 0x10,
+	0x8a, 0x22, 0x14, 0xd1, 0x20, 0xf1, 0x8c, 0xb1, 0x93, 0x62, 0x42, 0xdb, 0x68, 0xed, 0xa8, 0x79,
+	0xa2, 0xda, 0xdc, 0xad, 0x93, 0x53, 0xcf, 0x7b, 0xc7, 0xde, 0x26, 0xb1, 0x11, 0x20, 0x9e, 0x78,
+	0xe0, 0x07, 0xf0, 0x9f, 0x78, 0x84, 0x5f, 0x84, 0x66, 0x77, 0xcf, 0xbe, 0xab, 0x1d, 0xa9, 0xaa,
+	0x78, 0xca, 0xce, 0x37, 0x7b, 0x33, 0xb3, 0xdf, 0x7c, 0x33, 0x0e, 0xb8, 0x2c, 0x3b, 0x48, 0x65,
+	0xa2, 0x12, 0xb2, 0xc6, 0x32, 0xff, 0x8f, 0x0a, 0xb8, 0x7d, 0xa6, 0x18, 0x65, 0x8a, 0x93, 0xa7,
+	0x00, 0x93, 0x24, 0xbc, 0x8e, 0x99, 0x8a, 0x12, 0xe1, 0x55, 0x76, 0x2b, 0x7b, 0x75, 0x5a, 0x40,
+	0xc8, 0x0e, 0xd4, 0x2f, 0x98, 0x08, 0x5f, 0x47, 0xa1, 0xba, 0xf2, 0xd6, 0x76, 0x2b, 0x7b, 0x2d,
+	0xba, 0x00, 0x88, 0x0f, 0xcd, 0x2c, 0x95, 0x9c, 0x85, 0xc7, 0x2c, 0x50, 0x89, 0xf4, 0x1c, 0x7d,
+	0xa1, 0x84, 0x11, 0x0f, 0x36, 0x2e, 0x22, 0x25, 0x99, 0xe2, 0x5e, 0x55, 0xbb, 0x73, 0xd3, 0xff,
+	0xd7, 0x81, 0x1a, 0x3d, 0x1f, 0x88, 0x71, 0x42, 0xda, 0xe0, 0x4c, 0x58, 0xa0, 0xf3, 0x37, 0x29,
+	0x1e, 0x09, 0x81, 0xaa, 0x8a, 0x26, 0x5c, 0xe7, 0xac, 0x53, 0x7d, 0x46, 0x4c, 0x66, 0x59, 0xa4,
+	0xd3, 0xac, 0x53, 0x7d, 0xc6, 0xf0, 0x71, 0x42, 0xd9, 0xf0, 0x25, 0xd5, 0xe1, 0x2b, 0x34, 0x37,
+	0xf1, 0xb6, 0x60, 0x13, 0xee, 0xad, 0x9b, 0x08, 0x78, 0x26, 0x1d, 0x70, 0xf1, 0x61, 0xea, 0x3a,
+	0xe4, 0x5e, 0x4d, 0x5f, 0x9f, 0xdb, 0xf8, 0xd4, 0x38, 0x11, 0x97, 0xc6, 0xb9, 0xa1, 0x9d, 0x0b,
+	0x00, 0xbf, 0x64, 0xb1, 0xfd, 0xd2, 0x35, 0x5f, 0xe6, 0x36, 0x79, 0x04, 0xeb, 0x17, 0x09, 0x93,
+	0xa1, 0x57, 0xd7, 0x0f, 0x34, 0x06, 0x56, 0xc6, 0x84, 0xe2, 0x42, 0x30, 0x0f, 0xcc, 0xc3, 0xad,
+	0x49, 0xbe, 0x80, 0x87, 0xe3, 0x48, 0xf0, 0x51, 0x34, 0xe1, 0x99, 0x62, 0x93, 0x74, 0x34, 0x4b,
+	0xb9, 0xd7, 0xd0, 0x65, 0x2e, 0x3b, 0xc8, 0x67, 0xd0, 0x2a, 0x81, 0x5e, 0x53, 0x47, 0x2b, 0x83,
+	0xe4, 0x6b, 0xd8, 0xe2, 0x22, 0x90, 0xb3, 0x54, 0xf1, 0xf0, 0xb8, 0x74, 0xbd, 0xa5, 0x49, 0xbd,
+	0xc3, 0x4b, 0x0e, 0x80, 0x94, 0x02, 0x9d, 0xf0, 0xd9, 0xa0, 0xef, 0xdd, 0xd7, 0x29, 0x56, 0x78,
+	0x50, 0x30, 0x59, 0x74, 0x29, 0x58, 0x4c, 0x87, 0xc3, 0x81, 0xf7, 0x40, 0x77, 0xa2, 0x80, 0xf8,
+	0xbf, 0x41, 0x6d, 0x64, 0x7a, 0xba, 0x03, 0xf5, 0xb1, 0xe4, 0x3f, 0x5d, 0x73, 0x11, 0xcc, 0x74,
+	0x67, 0x1d, 0xba, 0x00, 0xc8, 0x1e, 0xb8, 0xa1, 0x15, 0xa1, 0xee, 0x71, 0xe3, 0xb0, 0x79, 0xc0,
+	0xb2, 0x83, 0x5c, 0x98, 0x74, 0xee, 0x45, 0x6d, 0xb0, 0xd0, 0x68, 0xcb, 0xa5, 0x78, 0xc4, 0x5e,
+	0x04, 0x49, 0xc8, 0x69, 0xae, 0xa9, 0x3a, 0x9d, 0xdb, 0xfe, 0x2f, 0x40, 0xbe, 0x4f, 0x22, 0x41,
+	0x31, 0x4f, 0xa6, 0xec, 0x1f, 0xac, 0x3a, 0xbd, 0x9a, 0x9d, 0xb2, 0x59, 0x9c, 0xb0, 0xd0, 0xca,
+	0xac, 0x80, 0x60, 0xaf, 0x42, 0x7e, 0xd3, 0x0d, 0x43, 0xa9, 0x8b, 0x69, 0xd2, 0xdc, 0xc4, 0xde,
+	0x0a, 0xae, 0x06, 0x7d, 0x9d, 0xbf, 0x49, 0x8d, 0x41, 0xb6, 0xa0, 0x16, 0x1c, 0xff, 0x10, 0x65,
+	0xca, 0xab, 0xee, 0x3a, 0x7b, 0x2d, 0x6a, 0x2d, 0xff, 0xef, 0x35, 0xd8, 0x2c, 0xa5, 0xcf, 0xd2,
+	0x44, 0x64, 0xfc, 0x7d, 0xf2, 0x8b, 0xdb, 0xb7, 0xc3, 0x13, 0x3e, 0xcb, 0xf3, 0x5b, 0x13, 0x3d,
+	0x72, 0xda, 0xe7, 0x31, 0x9b, 0xd9, 0xe9, 0xca, 0x4d, 0xb2, 0x0b, 0x0d, 0x39, 0x7d, 0xd6, 0xa7,
+	0xaf, 0xc6, 0xe3, 0x8c, 0x2b, 0x3b, 0x5c, 0x45, 0x08, 0x39, 0x96, 0xd3, 0xd7, 0x91, 0x08, 0x93,
+	0x5b, 0xad, 0xf6, 0xfb, 0x86, 0x63, 0x7a, 0x6e, 0x30, 0x3a, 0xf7, 0xe2, 0x2b, 0xe5, 0xf4, 0xb0,
+	0x4f, 0xb5, 0xee, 0x5b, 0xd4, 0x18, 0x64, 0x1f, 0xda, 0x61, 0x94, 0xb1, 0x8b, 0x98, 0x1f, 0xf7,
+	0x84, 0xea, 0x5d, 0xf1, 0xe0, 0xad, 0xd6, 0xbe, 0x4b, 0x97, 0x70, 0xac, 0x86, 0x85, 0x72, 0x20,
+	0x14, 0x97, 0x37, 0x2c, 0xb6, 0x93, 0x50, 0x84, 0x50, 0x69, 0x91, 0xc8, 0x14, 0x8b, 0xcd, 0x6a,
+	0x79, 0xc1, 0xe4, 0x65, 0x24, 0xf4, 0x68, 0x54, 0xe8, 0x0a, 0x8f, 0xff, 0xcf, 0x1a, 0x6c, 0x7e,
+	0xc7, 0x44, 0x18, 0x73, 0x14, 0xc5, 0x59, 0x9a, 0xf7, 0x72, 0x0b, 0x6a, 0x21, 0xbf, 0x39, 0x3a,
+	0x1b, 0x58, 0x1e, 0xad, 0x85, 0x38, 0x4b, 0x53, 0xc4, 0x0d, 0x85, 0xd6, 0xc2, 0x3d, 0x30, 0xee,
+	0x09, 0x65, 0xe9, 0xd3, 0x67, 0x7c, 0xef, 0xf8, 0x34, 0x91, 0x39, 0x6b, 0xc6, 0xc0, 0x9b, 0xa8,
+	0x3a, 0xbd, 0x31, 0x9a, 0x54, 0x9f, 0x89, 0x0f, 0x35, 0x35, 0x45, 0x3d, 0x6b, 0x06, 0x1b, 0x87,
+	0x80, 0x0c, 0x1a, 0x85, 0x53, 0xeb, 0xc1, 0x3b, 0xd2, 0xdc, 0xd9, 0xd8, 0x75, 0xf2, 0x3b, 0xd4,
+	0xde, 0x31, 0x1e, 0xf2, 0x25, 0x6c, 0x86, 0xfc, 0x26, 0x0a, 0xf8, 0x50, 0x31, 0x75, 0x9d, 0x7d,
+	0xcb, 0x94, 0xe2, 0x72, 0x66, 0x79, 0x5a, 0xe5, 0x42, 0xbe, 0x8a, 0x70, 0x81, 0xaf, 0x75, 0xba,
+	0xc2, 0xa3, 0x97, 0x31, 0xcf, 0xb2, 0x28, 0x11, 0x66, 0x86, 0x1b, 0xfa, 0x15, 0x25, 0xcc, 0xff,
+	0xab, 0x02, 0x1d, 0xc3, 0xe9, 0xa9, 0x4c, 0x52, 0x19, 0x71, 0xc5, 0xe4, 0x6c, 0x41, 0x2d, 0xfe,
+	0x1a, 0xb0, 0xe0, 0x1d, 0x99, 0x2e, 0x10, 0xbd, 0xa6, 0xa3, 0xc0, 0xf2, 0x8b, 0xc7, 0x02, 0x3d,
+	0xce, 0x7b, 0xd0, 0x53, 0xbd, 0x8b, 0x1e, 0xff, 0x09, 0x3c, 0x5e, 0x59, 0x97, 0x99, 0x1f, 0xff,
+	0xf7, 0x0a, 0x90, 0xe7, 0x5c, 0xa1, 0x10, 0xfa, 0xc9, 0xad, 0xf8, 0x50, 0x29, 0x7c, 0x0e, 0xf7,
+	0x27, 0x6c, 0x6a, 0x5f, 0x33, 0x8c, 0x7e, 0xe6, 0x56, 0x14, 0xef, 0xa0, 0x73, 0xc9, 0x54, 0x17,
+	0x92, 0xf1, 0x67, 0xb0, 0x59, 0xaa, 0xc0, 0x4e, 0x76, 0xae, 0x99, 0x4a, 0x41, 0x33, 0x3b, 0x50,
+	0x0f, 0x12, 0x31, 0x8e, 0xe4, 0x84, 0x87, 0xba, 0x02, 0x97, 0x2e, 0x80, 0x85, 0xf6, 0x9c, 0xa2,
+	0xf6, 0x3a, 0xe0, 0x4e, 0x12, 0xa9, 0xa5, 0xae, 0xd3, 0xba, 0x74, 0x6e, 0xfb, 0x5b, 0xf0, 0xa8,
+	0x3c, 0x08, 0x96, 0x95, 0x1f, 0xc1, 0x5b, 0xe0, 0x58, 0x55, 0xb7, 0x77, 0xf2, 0x3f, 0x4e, 0x89,
+	0xff, 0x18, 0x3e, 0x5e, 0x11, 0xdf, 0x26, 0xff, 0x15, 0x88, 0x71, 0x1e, 0x49, 0x99, 0xc8, 0x0f,
+	0x4d, 0xfb, 0x29, 0x54, 0x15, 0xfe, 0xfa, 0x39, 0x7a, 0x3d, 0xb5, 0x50, 0x19, 0x3a, 0x1e, 0xfe,
+	0xf2, 0x51, 0xed, 0x42, 0xbe, 0x38, 0x42, 0x76, 0xd5, 0x1b, 0xc3, 0xff, 0x28, 0x5f, 0x0e, 0x36,
+	0xbd, 0xa9, 0x6a, 0x7f, 0x07, 0xdc, 0x7c, 0xbd, 0x91, 0x0d, 0x70, 0xe8, 0xf9, 0xb3, 0xf6, 0x3d,
+	0x73, 0x38, 0x6c, 0x57, 0xf6, 0x8f, 0xa0, 0x3e, 0x8f, 0x4e, 0x1a, 0xb0, 0xf1, 0x9c, 0x0b, 0x2e,
+	0xa3, 0xa0, 0x7d, 0x8f, 0xb8, 0x50, 0x7d, 0x35, 0xea, 0x76, 0xdb, 0x15, 0xd2, 0x86, 0x66, 0xbf,
+	0x3b, 0xea, 0xbe, 0x39, 0x3b, 0x7d, 0x73, 0xdc, 0x7b, 0x39, 0x6a, 0xaf, 0x91, 0x07, 0xd0, 0xc8,
+	0x91, 0x17, 0x83, 0x5e, 0xdb, 0x39, 0xfc, 0xd3, 0x81, 0x87, 0xdd, 0x34, 0x8d, 0xa3, 0x40, 0xef,
+	0xab, 0x21, 0x97, 0x37, 0x5c, 0x92, 0x1e, 0x34, 0x8b, 0x5d, 0x22, 0xdb, 0xf8, 0x98, 0x15, 0x0b,
+	0xac, 0xe3, 0x2d, 0x3b, 0x2c, 0xa7, 0xf7, 0xc8, 0x39, 0x6c, 0xae, 0x98, 0x03, 0xf2, 0x74, 0xf1,
+	0xc9, 0xaa, 0xc1, 0xed, 0x7c, 0x72, 0xa7, 0x7f, 0x1e, 0xf9, 0x1b, 0x68, 0x14, 0xf4, 0x4b, 0xb6,
+	0xf0, 0x8b, 0xe5, 0x91, 0xea, 0x6c, 0x2f, 0xe1, 0xf3, 0x08, 0x14, 0x1e, 0x2e, 0xc9, 0x81, 0xec,
+	0x94, 0x1f, 0x53, 0x56, 0x61, 0xe7, 0xc9, 0x1d, 0xde, 0x62, 0x55, 0x85, 0x36, 0x9a, 0xaa, 0x96,
+	0x65, 0xd5, 0xd9, 0x5e, 0xc2, 0xf3, 0x08, 0x17, 0x35, 0xfd, 0x9f, 0xed, 0x57, 0xff, 0x0d, 0x00,
+	0x48, 0xba, 0xf0, 0xcb, 0xe5, 0x0a, 0x00, 0x00,
 }
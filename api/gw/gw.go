@@ -21,20 +21,48 @@ type RXPacketBytes struct {
 	PHYPayload []byte `json:"phyPayload"`
 }
 
-// RXInfo contains the RX information.
+// Fine-timestamp types (see RXInfo.FineTimestampType).
+const (
+	FineTimestampPlain     = "PLAIN"
+	FineTimestampEncrypted = "ENCRYPTED"
+)
+
+// CRC status values (see RXInfo.CRCStatus).
+const (
+	CRCStatusOK    = 1
+	CRCStatusNoCRC = 0
+	CRCStatusError = -1
+)
+
+// Receive windows of a class-a downlink (see TXInfo.RXWindow).
+const (
+	RXWindow1 = 0
+	RXWindow2 = 1
+)
+
+// RXInfo contains the RX information. When the gateway has multiple
+// antennas, an RXInfo is reported for each antenna which received the
+// packet.
 type RXInfo struct {
-	MAC       lorawan.EUI64 `json:"mac"`            // MAC address of the gateway
-	Time      time.Time     `json:"time,omitempty"` // receive time
-	Timestamp uint32        `json:"timestamp"`      // gateway internal receive timestamp with microsecond precision, will rollover every ~ 72 minutes
-	Frequency int           `json:"frequency"`      // frequency in Hz
-	Channel   int           `json:"channel"`        // concentrator IF channel used for RX
-	RFChain   int           `json:"rfChain"`        // RF chain used for RX
-	CRCStatus int           `json:"crcStatus"`      // 1 = OK, -1 = fail, 0 = no CRC
-	CodeRate  string        `json:"codeRate"`       // ECC code rate
-	RSSI      int           `json:"rssi"`           // RSSI in dBm
-	LoRaSNR   float64       `json:"loRaSNR"`        // LoRa signal-to-noise ratio in dB
-	Size      int           `json:"size"`           // packet payload size
-	DataRate  band.DataRate `json:"dataRate"`       // RX datarate (either LoRa or FSK)
+	MAC                    lorawan.EUI64  `json:"mac"`                              // MAC address of the gateway
+	Time                   time.Time      `json:"time,omitempty"`                   // receive time
+	Timestamp              uint32         `json:"timestamp"`                        // gateway internal receive timestamp with microsecond precision, will rollover every ~ 72 minutes
+	Frequency              int            `json:"frequency"`                        // frequency in Hz
+	Channel                int            `json:"channel"`                          // concentrator IF channel used for RX
+	RFChain                int            `json:"rfChain"`                          // RF chain used for RX
+	CRCStatus              int            `json:"crcStatus"`                        // 1 = OK, -1 = fail, 0 = no CRC
+	CodeRate               string         `json:"codeRate"`                         // ECC code rate
+	RSSI                   int            `json:"rssi"`                             // RSSI of the channel in dBm
+	SignalRSSI             *int           `json:"signalRSSI,omitempty"`             // RSSI of the signal in dBm (when reported by the gateway)
+	LoRaSNR                float64        `json:"loRaSNR"`                          // LoRa signal-to-noise ratio in dB
+	Size                   int            `json:"size"`                             // packet payload size
+	DataRate               band.DataRate  `json:"dataRate"`                         // RX datarate (either LoRa or FSK)
+	Board                  int            `json:"board"`                            // concentrator board used for RX
+	Antenna                int            `json:"antenna"`                          // antenna used for RX
+	FineTimestampType      string         `json:"fineTimestampType,omitempty"`      // type of the fine-timestamp (empty when not available)
+	FineTimestamp          *time.Duration `json:"fineTimestamp,omitempty"`          // plain fine-timestamp (nanoseconds since the last GPS second)
+	EncryptedFineTimestamp []byte         `json:"encryptedFineTimestamp,omitempty"` // encrypted fine-timestamp
+	FineTimestampKeyID     int            `json:"fineTimestampKeyID,omitempty"`     // index of the key used to encrypt the fine-timestamp
 }
 
 // TXPacket contains the PHYPayload which should be send to the
@@ -61,6 +89,14 @@ type TXInfo struct {
 	DataRate    band.DataRate `json:"dataRate"`    // TX datarate (either LoRa or FSK)
 	CodeRate    string        `json:"codeRate"`    // ECC code rate
 	IPol        *bool         `json:"iPol"`        // when left nil, the gateway-bridge will use the default (true for LoRa modulation)
+	RXWindow    int           `json:"-"`           // receive window of a class-a downlink (RXWindow1 or RXWindow2), used by the embedded gateway backends
+}
+
+// TXAck contains the acknowledgement of the gateway for a TXPacket. In case
+// the gateway could not transmit the packet, Error contains the reason.
+type TXAck struct {
+	MAC   lorawan.EUI64 `json:"mac"`   // MAC address of the gateway
+	Error string        `json:"error"` // error (empty on success)
 }
 
 // GatewayStatsPacket contains the information of a gateway.
@@ -74,5 +110,24 @@ type GatewayStatsPacket struct {
 	RXPacketsReceivedOK int                    `json:"rxPacketsReceivedOK"`
 	TXPacketsReceived   int                    `json:"txPacketsReceived"`
 	TXPacketsEmitted    int                    `json:"txPacketsEmitted"`
-	CustomData          map[string]interface{} `json:"customData"` // custom fields defined by alternative packet_forwarder versions (e.g. TTN sends platform, contactEmail, and description)
+	CustomData          map[string]interface{} `json:"customData"`              // custom fields defined by alternative packet_forwarder versions (e.g. TTN sends platform, contactEmail, and description)
+	ConfigVersion       string                 `json:"configVersion,omitempty"` // version of the configuration applied by the gateway
+}
+
+// GatewayConfigPacket contains the configuration to be applied by the
+// gateway. After applying the configuration, the gateway reports the
+// Version in its stats.
+type GatewayConfigPacket struct {
+	MAC      lorawan.EUI64   `json:"mac"`
+	Version  string          `json:"version"`
+	Channels []ConfigChannel `json:"channels"`
+}
+
+// ConfigChannel contains the configuration of a channel.
+type ConfigChannel struct {
+	Modulation    band.Modulation `json:"modulation"`
+	Frequency     int             `json:"frequency"`               // frequency in Hz
+	Bandwidth     int             `json:"bandwidth"`               // bandwidth in kHz
+	BitRate       int             `json:"bitRate,omitempty"`       // bit rate (FSK modulation only)
+	SpreadFactors []int           `json:"spreadFactors,omitempty"` // spreading-factors (LoRa modulation only)
 }
@@ -11,6 +11,15 @@ It has these top-level messages:
 	Channel
 	GetConfigurationRequest
 	GetConfigurationResponse
+	DataRate
+	UplinkRXInfo
+	UplinkFrame
+	DownlinkTXInfo
+	DownlinkFrame
+	DownlinkTXAck
+	Location
+	GatewayStats
+	GatewayConfiguration
 */
 package gw
 
@@ -57,6 +66,60 @@ func (x Modulation) String() string {
 }
 func (Modulation) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type FineTimestampType int32
+
+const (
+	// No fine-timestamp available.
+	FineTimestampType_NONE FineTimestampType = 0
+	// Encrypted fine-timestamp.
+	FineTimestampType_ENCRYPTED FineTimestampType = 1
+	// Plain fine-timestamp.
+	FineTimestampType_PLAIN FineTimestampType = 2
+)
+
+var FineTimestampType_name = map[int32]string{
+	0: "NONE",
+	1: "ENCRYPTED",
+	2: "PLAIN",
+}
+var FineTimestampType_value = map[string]int32{
+	"NONE":      0,
+	"ENCRYPTED": 1,
+	"PLAIN":     2,
+}
+
+func (x FineTimestampType) String() string {
+	return proto.EnumName(FineTimestampType_name, int32(x))
+}
+func (FineTimestampType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+type Polarization int32
+
+const (
+	// Use the default polarization (inverted for LoRa downlinks).
+	Polarization_DEFAULT_POLARIZATION Polarization = 0
+	// Inverted polarization.
+	Polarization_INVERTED Polarization = 1
+	// Non-inverted polarization.
+	Polarization_NON_INVERTED Polarization = 2
+)
+
+var Polarization_name = map[int32]string{
+	0: "DEFAULT_POLARIZATION",
+	1: "INVERTED",
+	2: "NON_INVERTED",
+}
+var Polarization_value = map[string]int32{
+	"DEFAULT_POLARIZATION": 0,
+	"INVERTED":             1,
+	"NON_INVERTED":         2,
+}
+
+func (x Polarization) String() string {
+	return proto.EnumName(Polarization_name, int32(x))
+}
+func (Polarization) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
 type Channel struct {
 	// Modulation of the channel.
 	Modulation Modulation `protobuf:"varint,1,opt,name=modulation,enum=gw.Modulation" json:"modulation,omitempty"`
@@ -153,11 +216,553 @@ func (m *GetConfigurationResponse) GetUpdatedAt() string {
 	return ""
 }
 
+type DataRate struct {
+	// Modulation.
+	Modulation Modulation `protobuf:"varint,1,opt,name=modulation,enum=gw.Modulation" json:"modulation,omitempty"`
+	// Spread-factor (LoRa modulation only).
+	SpreadFactor uint32 `protobuf:"varint,2,opt,name=spreadFactor" json:"spreadFactor,omitempty"`
+	// Bandwidth in kHz (LoRa modulation only).
+	Bandwidth uint32 `protobuf:"varint,3,opt,name=bandwidth" json:"bandwidth,omitempty"`
+	// Bit rate (FSK modulation only).
+	BitRate uint32 `protobuf:"varint,4,opt,name=bitRate" json:"bitRate,omitempty"`
+}
+
+func (m *DataRate) Reset()                    { *m = DataRate{} }
+func (m *DataRate) String() string            { return proto.CompactTextString(m) }
+func (*DataRate) ProtoMessage()               {}
+func (*DataRate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *DataRate) GetModulation() Modulation {
+	if m != nil {
+		return m.Modulation
+	}
+	return Modulation_LORA
+}
+
+func (m *DataRate) GetSpreadFactor() uint32 {
+	if m != nil {
+		return m.SpreadFactor
+	}
+	return 0
+}
+
+func (m *DataRate) GetBandwidth() uint32 {
+	if m != nil {
+		return m.Bandwidth
+	}
+	return 0
+}
+
+func (m *DataRate) GetBitRate() uint32 {
+	if m != nil {
+		return m.BitRate
+	}
+	return 0
+}
+
+type UplinkRXInfo struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Receive time (RFC3339Nano, when the gateway has a GPS).
+	Time string `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+	// Gateway internal timestamp.
+	Timestamp uint32 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Frequency in Hz.
+	Frequency uint32 `protobuf:"varint,4,opt,name=frequency" json:"frequency,omitempty"`
+	// Concentrator IF channel used for RX.
+	Channel uint32 `protobuf:"varint,5,opt,name=channel" json:"channel,omitempty"`
+	// RF chain used for RX.
+	RfChain uint32 `protobuf:"varint,6,opt,name=rfChain" json:"rfChain,omitempty"`
+	// CRC status (1 = OK, -1 = fail, 0 = no CRC).
+	CrcStatus int32 `protobuf:"varint,7,opt,name=crcStatus" json:"crcStatus,omitempty"`
+	// Code-rate.
+	CodeRate string `protobuf:"bytes,8,opt,name=codeRate" json:"codeRate,omitempty"`
+	// RSSI in dBm.
+	Rssi int32 `protobuf:"varint,9,opt,name=rssi" json:"rssi,omitempty"`
+	// LoRa SNR in dB.
+	LoRaSNR float64 `protobuf:"fixed64,10,opt,name=loRaSNR" json:"loRaSNR,omitempty"`
+	// Payload size.
+	Size uint32 `protobuf:"varint,11,opt,name=size" json:"size,omitempty"`
+	// Data-rate.
+	DataRate *DataRate `protobuf:"bytes,12,opt,name=dataRate" json:"dataRate,omitempty"`
+	// Concentrator board used for RX.
+	Board uint32 `protobuf:"varint,13,opt,name=board" json:"board,omitempty"`
+	// Antenna used for RX.
+	Antenna uint32 `protobuf:"varint,14,opt,name=antenna" json:"antenna,omitempty"`
+	// Type of the fine-timestamp.
+	FineTimestampType FineTimestampType `protobuf:"varint,15,opt,name=fineTimestampType,enum=gw.FineTimestampType" json:"fineTimestampType,omitempty"`
+	// Plain fine-timestamp (nanoseconds since the last GPS second).
+	FineTimestamp uint32 `protobuf:"varint,16,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// Encrypted fine-timestamp.
+	EncryptedFineTimestamp []byte `protobuf:"bytes,17,opt,name=encryptedFineTimestamp,proto3" json:"encryptedFineTimestamp,omitempty"`
+	// Index of the key used to encrypt the fine-timestamp.
+	FineTimestampKeyID uint32 `protobuf:"varint,18,opt,name=fineTimestampKeyID" json:"fineTimestampKeyID,omitempty"`
+	// Signal RSSI in dBm (0 when not reported).
+	SignalRSSI int32 `protobuf:"varint,19,opt,name=signalRSSI" json:"signalRSSI,omitempty"`
+}
+
+func (m *UplinkRXInfo) Reset()                    { *m = UplinkRXInfo{} }
+func (m *UplinkRXInfo) String() string            { return proto.CompactTextString(m) }
+func (*UplinkRXInfo) ProtoMessage()               {}
+func (*UplinkRXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *UplinkRXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *UplinkRXInfo) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *UplinkRXInfo) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetChannel() uint32 {
+	if m != nil {
+		return m.Channel
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetRfChain() uint32 {
+	if m != nil {
+		return m.RfChain
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetCrcStatus() int32 {
+	if m != nil {
+		return m.CrcStatus
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *UplinkRXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetLoRaSNR() float64 {
+	if m != nil {
+		return m.LoRaSNR
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetDataRate() *DataRate {
+	if m != nil {
+		return m.DataRate
+	}
+	return nil
+}
+
+func (m *UplinkRXInfo) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetFineTimestampType() FineTimestampType {
+	if m != nil {
+		return m.FineTimestampType
+	}
+	return FineTimestampType_NONE
+}
+
+func (m *UplinkRXInfo) GetFineTimestamp() uint32 {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetEncryptedFineTimestamp() []byte {
+	if m != nil {
+		return m.EncryptedFineTimestamp
+	}
+	return nil
+}
+
+func (m *UplinkRXInfo) GetFineTimestampKeyID() uint32 {
+	if m != nil {
+		return m.FineTimestampKeyID
+	}
+	return 0
+}
+
+func (m *UplinkRXInfo) GetSignalRSSI() int32 {
+	if m != nil {
+		return m.SignalRSSI
+	}
+	return 0
+}
+
+type UplinkFrame struct {
+	// RX information.
+	RxInfo *UplinkRXInfo `protobuf:"bytes,1,opt,name=rxInfo" json:"rxInfo,omitempty"`
+	// LoRaWAN PHYPayload.
+	PhyPayload []byte `protobuf:"bytes,2,opt,name=phyPayload,proto3" json:"phyPayload,omitempty"`
+}
+
+func (m *UplinkFrame) Reset()                    { *m = UplinkFrame{} }
+func (m *UplinkFrame) String() string            { return proto.CompactTextString(m) }
+func (*UplinkFrame) ProtoMessage()               {}
+func (*UplinkFrame) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *UplinkFrame) GetRxInfo() *UplinkRXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+func (m *UplinkFrame) GetPhyPayload() []byte {
+	if m != nil {
+		return m.PhyPayload
+	}
+	return nil
+}
+
+type DownlinkTXInfo struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Send the frame immediately (ignoring the timestamp).
+	Immediately bool `protobuf:"varint,2,opt,name=immediately" json:"immediately,omitempty"`
+	// Gateway internal timestamp.
+	Timestamp uint32 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Frequency in Hz.
+	Frequency uint32 `protobuf:"varint,4,opt,name=frequency" json:"frequency,omitempty"`
+	// TX power in dBm.
+	Power int32 `protobuf:"varint,5,opt,name=power" json:"power,omitempty"`
+	// Data-rate.
+	DataRate *DataRate `protobuf:"bytes,6,opt,name=dataRate" json:"dataRate,omitempty"`
+	// Code-rate.
+	CodeRate string `protobuf:"bytes,7,opt,name=codeRate" json:"codeRate,omitempty"`
+	// Polarization.
+	Polarization Polarization `protobuf:"varint,8,opt,name=polarization,enum=gw.Polarization" json:"polarization,omitempty"`
+}
+
+func (m *DownlinkTXInfo) Reset()                    { *m = DownlinkTXInfo{} }
+func (m *DownlinkTXInfo) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkTXInfo) ProtoMessage()               {}
+func (*DownlinkTXInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *DownlinkTXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *DownlinkTXInfo) GetImmediately() bool {
+	if m != nil {
+		return m.Immediately
+	}
+	return false
+}
+
+func (m *DownlinkTXInfo) GetTimestamp() uint32 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *DownlinkTXInfo) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *DownlinkTXInfo) GetPower() int32 {
+	if m != nil {
+		return m.Power
+	}
+	return 0
+}
+
+func (m *DownlinkTXInfo) GetDataRate() *DataRate {
+	if m != nil {
+		return m.DataRate
+	}
+	return nil
+}
+
+func (m *DownlinkTXInfo) GetCodeRate() string {
+	if m != nil {
+		return m.CodeRate
+	}
+	return ""
+}
+
+func (m *DownlinkTXInfo) GetPolarization() Polarization {
+	if m != nil {
+		return m.Polarization
+	}
+	return Polarization_DEFAULT_POLARIZATION
+}
+
+type DownlinkFrame struct {
+	// TX information.
+	TxInfo *DownlinkTXInfo `protobuf:"bytes,1,opt,name=txInfo" json:"txInfo,omitempty"`
+	// LoRaWAN PHYPayload.
+	PhyPayload []byte `protobuf:"bytes,2,opt,name=phyPayload,proto3" json:"phyPayload,omitempty"`
+}
+
+func (m *DownlinkFrame) Reset()                    { *m = DownlinkFrame{} }
+func (m *DownlinkFrame) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkFrame) ProtoMessage()               {}
+func (*DownlinkFrame) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DownlinkFrame) GetTxInfo() *DownlinkTXInfo {
+	if m != nil {
+		return m.TxInfo
+	}
+	return nil
+}
+
+func (m *DownlinkFrame) GetPhyPayload() []byte {
+	if m != nil {
+		return m.PhyPayload
+	}
+	return nil
+}
+
+type DownlinkTXAck struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Error (empty when the frame has been transmitted).
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *DownlinkTXAck) Reset()                    { *m = DownlinkTXAck{} }
+func (m *DownlinkTXAck) String() string            { return proto.CompactTextString(m) }
+func (*DownlinkTXAck) ProtoMessage()               {}
+func (*DownlinkTXAck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *DownlinkTXAck) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *DownlinkTXAck) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Location struct {
+	// Latitude.
+	Latitude float64 `protobuf:"fixed64,1,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude.
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude" json:"longitude,omitempty"`
+	// Altitude.
+	Altitude float64 `protobuf:"fixed64,3,opt,name=altitude" json:"altitude,omitempty"`
+}
+
+func (m *Location) Reset()                    { *m = Location{} }
+func (m *Location) String() string            { return proto.CompactTextString(m) }
+func (*Location) ProtoMessage()               {}
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *Location) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *Location) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *Location) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+type GatewayStats struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Gateway time (RFC3339Nano).
+	Time string `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
+	// Gateway location (when the gateway has a GPS).
+	Location *Location `protobuf:"bytes,3,opt,name=location" json:"location,omitempty"`
+	// Number of radio packets received.
+	RxPacketsReceived uint32 `protobuf:"varint,4,opt,name=rxPacketsReceived" json:"rxPacketsReceived,omitempty"`
+	// Number of radio packets received with a valid CRC.
+	RxPacketsReceivedOK uint32 `protobuf:"varint,5,opt,name=rxPacketsReceivedOK" json:"rxPacketsReceivedOK,omitempty"`
+	// Number of downlink packets received.
+	TxPacketsReceived uint32 `protobuf:"varint,6,opt,name=txPacketsReceived" json:"txPacketsReceived,omitempty"`
+	// Number of downlink packets emitted.
+	TxPacketsEmitted uint32 `protobuf:"varint,7,opt,name=txPacketsEmitted" json:"txPacketsEmitted,omitempty"`
+	// Version of the configuration applied by the gateway.
+	ConfigVersion string `protobuf:"bytes,8,opt,name=configVersion" json:"configVersion,omitempty"`
+}
+
+func (m *GatewayStats) Reset()                    { *m = GatewayStats{} }
+func (m *GatewayStats) String() string            { return proto.CompactTextString(m) }
+func (*GatewayStats) ProtoMessage()               {}
+func (*GatewayStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *GatewayStats) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
+func (m *GatewayStats) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *GatewayStats) GetRxPacketsReceived() uint32 {
+	if m != nil {
+		return m.RxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetRxPacketsReceivedOK() uint32 {
+	if m != nil {
+		return m.RxPacketsReceivedOK
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsReceived() uint32 {
+	if m != nil {
+		return m.TxPacketsReceived
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxPacketsEmitted() uint32 {
+	if m != nil {
+		return m.TxPacketsEmitted
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetConfigVersion() string {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return ""
+}
+
+type GatewayConfiguration struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Version of the configuration.
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+	// Channels to configure on the gateway.
+	Channels []*Channel `protobuf:"bytes,3,rep,name=channels" json:"channels,omitempty"`
+}
+
+func (m *GatewayConfiguration) Reset()                    { *m = GatewayConfiguration{} }
+func (m *GatewayConfiguration) String() string            { return proto.CompactTextString(m) }
+func (*GatewayConfiguration) ProtoMessage()               {}
+func (*GatewayConfiguration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *GatewayConfiguration) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GatewayConfiguration) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GatewayConfiguration) GetChannels() []*Channel {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Channel)(nil), "gw.Channel")
 	proto.RegisterType((*GetConfigurationRequest)(nil), "gw.GetConfigurationRequest")
 	proto.RegisterType((*GetConfigurationResponse)(nil), "gw.GetConfigurationResponse")
+	proto.RegisterType((*DataRate)(nil), "gw.DataRate")
+	proto.RegisterType((*UplinkRXInfo)(nil), "gw.UplinkRXInfo")
+	proto.RegisterType((*UplinkFrame)(nil), "gw.UplinkFrame")
+	proto.RegisterType((*DownlinkTXInfo)(nil), "gw.DownlinkTXInfo")
+	proto.RegisterType((*DownlinkFrame)(nil), "gw.DownlinkFrame")
+	proto.RegisterType((*DownlinkTXAck)(nil), "gw.DownlinkTXAck")
+	proto.RegisterType((*Location)(nil), "gw.Location")
+	proto.RegisterType((*GatewayStats)(nil), "gw.GatewayStats")
+	proto.RegisterType((*GatewayConfiguration)(nil), "gw.GatewayConfiguration")
 	proto.RegisterEnum("gw.Modulation", Modulation_name, Modulation_value)
+	proto.RegisterEnum("gw.FineTimestampType", FineTimestampType_name, FineTimestampType_value)
+	proto.RegisterEnum("gw.Polarization", Polarization_name, Polarization_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -237,24 +842,68 @@ var _Gateway_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gw.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 302 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x5f, 0x4b, 0xf3, 0x30,
-	0x14, 0xc6, 0x97, 0x75, 0x7b, 0xbb, 0x9d, 0xbd, 0x8e, 0x92, 0x1b, 0x83, 0x0e, 0x2c, 0x45, 0xb0,
-	0x28, 0xf4, 0x62, 0x7e, 0x82, 0x31, 0xd8, 0x2e, 0x54, 0x06, 0x11, 0x6f, 0xbc, 0x4b, 0x9b, 0xac,
-	0x2b, 0x6c, 0x49, 0x6d, 0x52, 0xca, 0x3e, 0x98, 0xdf, 0x4f, 0xd2, 0x6a, 0xeb, 0xdf, 0xbb, 0xf6,
-	0xf9, 0x1d, 0x38, 0xcf, 0xef, 0x04, 0x46, 0x69, 0x15, 0xe5, 0x85, 0x32, 0x0a, 0xf7, 0xd3, 0x2a,
-	0x78, 0x45, 0xe0, 0x2e, 0x77, 0x4c, 0x4a, 0xb1, 0xc7, 0x11, 0xc0, 0x41, 0xf1, 0x72, 0xcf, 0x4c,
-	0xa6, 0x24, 0x41, 0x3e, 0x0a, 0xa7, 0xf3, 0x69, 0x94, 0x56, 0xd1, 0x43, 0x9b, 0xd2, 0x4f, 0x13,
-	0x78, 0x06, 0xe3, 0x6d, 0x21, 0x5e, 0x4a, 0x21, 0x93, 0x23, 0xe9, 0xfb, 0x28, 0x1c, 0xd2, 0x2e,
-	0xb0, 0x34, 0x66, 0x92, 0x57, 0x19, 0x37, 0x3b, 0xe2, 0x34, 0xb4, 0x0d, 0x30, 0x01, 0x37, 0xce,
-	0x0c, 0x65, 0x46, 0x90, 0x41, 0xcd, 0x3e, 0x7e, 0xf1, 0x25, 0x9c, 0xe8, 0xbc, 0x10, 0x8c, 0xaf,
-	0x58, 0x62, 0x54, 0xa1, 0xc9, 0xd0, 0x77, 0xc2, 0x21, 0xfd, 0x1a, 0x06, 0x37, 0x70, 0xba, 0x16,
-	0x66, 0xa9, 0xe4, 0x36, 0x4b, 0xcb, 0xa2, 0xe9, 0x66, 0x37, 0x6b, 0x83, 0x3d, 0x70, 0x0e, 0x2c,
-	0xa9, 0xfb, 0xff, 0xa7, 0xf6, 0x33, 0x60, 0x40, 0x7e, 0x0e, 0xeb, 0x5c, 0x49, 0x2d, 0xf0, 0x15,
-	0x8c, 0x92, 0xc6, 0x5f, 0x13, 0xe4, 0x3b, 0xe1, 0x64, 0x3e, 0xb1, 0xca, 0xef, 0x37, 0xa1, 0x2d,
-	0xb4, 0x3e, 0x4f, 0x39, 0x67, 0x46, 0xf0, 0x85, 0xa9, 0x6d, 0xc7, 0xb4, 0x0b, 0xae, 0x2f, 0x00,
-	0xba, 0x2b, 0xe1, 0x11, 0x0c, 0xee, 0x37, 0x74, 0xe1, 0xf5, 0xb0, 0x0b, 0xce, 0xea, 0xf1, 0xce,
-	0x43, 0xf3, 0x67, 0x70, 0xd7, 0xcc, 0x88, 0x8a, 0x1d, 0xf1, 0x06, 0xbc, 0xef, 0x75, 0xf0, 0xb9,
-	0x5d, 0xfa, 0x87, 0xd1, 0xd9, 0xec, 0x77, 0xd8, 0x18, 0x04, 0xbd, 0xf8, 0x5f, 0xfd, 0x9e, 0xb7,
-	0x6f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x54, 0x80, 0x3d, 0xec, 0xdb, 0x01, 0x00, 0x00,
+	// 1002 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xd1, 0x6e, 0xe3, 0x44,
+	0x14, 0xad, 0x93, 0x26, 0x71, 0x6e, 0x9c, 0xe2, 0xce, 0x16, 0x18, 0x2d, 0x2b, 0x88, 0x2c, 0x24,
+	0xa2, 0x82, 0x2a, 0x54, 0x10, 0xfb, 0x1c, 0xb5, 0xc9, 0x12, 0x35, 0x24, 0xd1, 0x24, 0x5d, 0x96,
+	0xe5, 0x61, 0x99, 0xda, 0x93, 0xd4, 0xaa, 0x63, 0x1b, 0x7b, 0xb2, 0xd9, 0xec, 0x67, 0xf0, 0xc0,
+	0x9f, 0xf0, 0xca, 0x1f, 0xf1, 0x0f, 0x68, 0x66, 0x6c, 0xc7, 0xae, 0xd3, 0x6a, 0x11, 0x4f, 0xf1,
+	0x3d, 0x67, 0xe6, 0xde, 0xb9, 0x67, 0x4e, 0xae, 0x0d, 0xfa, 0x72, 0x73, 0x16, 0x46, 0x01, 0x0f,
+	0x50, 0x65, 0xb9, 0xb1, 0xfe, 0xd2, 0xa0, 0x71, 0x71, 0x4b, 0x7d, 0x9f, 0x79, 0xe8, 0x0c, 0x60,
+	0x15, 0x38, 0x6b, 0x8f, 0x72, 0x37, 0xf0, 0xb1, 0xd6, 0xd1, 0xba, 0x47, 0xe7, 0x47, 0x67, 0xcb,
+	0xcd, 0xd9, 0x4f, 0x19, 0x4a, 0x72, 0x2b, 0xd0, 0x33, 0x68, 0x2e, 0x22, 0xf6, 0xfb, 0x9a, 0xf9,
+	0xf6, 0x16, 0x57, 0x3a, 0x5a, 0xb7, 0x46, 0x76, 0x80, 0x60, 0x6f, 0xa8, 0xef, 0x6c, 0x5c, 0x87,
+	0xdf, 0xe2, 0xaa, 0x62, 0x33, 0x00, 0x61, 0x68, 0xdc, 0xb8, 0x9c, 0x50, 0xce, 0xf0, 0xa1, 0xe4,
+	0xd2, 0x10, 0x7d, 0x09, 0xed, 0x38, 0x8c, 0x18, 0x75, 0x06, 0xd4, 0xe6, 0x41, 0x14, 0xe3, 0x5a,
+	0xa7, 0xda, 0xad, 0x91, 0x22, 0x68, 0x7d, 0x0d, 0x9f, 0xbe, 0x60, 0xfc, 0x22, 0xf0, 0x17, 0xee,
+	0x72, 0x1d, 0xa9, 0xb3, 0x89, 0xca, 0x31, 0x47, 0x26, 0x54, 0x57, 0xd4, 0x96, 0xe7, 0x37, 0x88,
+	0x78, 0xb4, 0x28, 0xe0, 0xf2, 0xe2, 0x38, 0x0c, 0xfc, 0x98, 0xa1, 0xaf, 0x40, 0xb7, 0x55, 0xff,
+	0x31, 0xd6, 0x3a, 0xd5, 0x6e, 0xeb, 0xbc, 0x25, 0x5a, 0x4e, 0x34, 0x21, 0x19, 0x29, 0xfa, 0xb9,
+	0x0e, 0x1d, 0xca, 0x99, 0xd3, 0xe3, 0xb2, 0xdb, 0x26, 0xd9, 0x01, 0xd6, 0x9f, 0x1a, 0xe8, 0x97,
+	0x94, 0x53, 0xd9, 0xc2, 0x7f, 0x15, 0xd2, 0x02, 0x23, 0xdf, 0x9d, 0xcc, 0xde, 0x26, 0x05, 0xac,
+	0x2c, 0x67, 0xfb, 0x11, 0x39, 0xdb, 0x99, 0x9c, 0xd6, 0x3f, 0x87, 0x60, 0x5c, 0x87, 0x9e, 0xeb,
+	0xdf, 0x91, 0x57, 0x43, 0x7f, 0x11, 0x94, 0xe5, 0x41, 0x08, 0x0e, 0xb9, 0xbb, 0x62, 0x49, 0x53,
+	0xf2, 0x59, 0x94, 0x13, 0xbf, 0x31, 0xa7, 0xab, 0x30, 0x2d, 0x97, 0x01, 0xc5, 0x9b, 0x57, 0x05,
+	0x77, 0x80, 0x38, 0x4c, 0xa2, 0x1a, 0xae, 0xa9, 0xc3, 0x24, 0xa1, 0x60, 0xa2, 0xc5, 0xc5, 0x2d,
+	0x75, 0x7d, 0x5c, 0x57, 0x4c, 0x12, 0x8a, 0x8c, 0x76, 0x64, 0xcf, 0x38, 0xe5, 0xeb, 0x18, 0x37,
+	0x94, 0x5b, 0x32, 0x00, 0x3d, 0x05, 0xdd, 0x0e, 0x1c, 0x26, 0xfb, 0xd3, 0xe5, 0x29, 0xb3, 0x58,
+	0x9c, 0x3e, 0x8a, 0x63, 0x17, 0x37, 0xe5, 0x26, 0xf9, 0x2c, 0xea, 0x78, 0x01, 0xa1, 0xb3, 0x31,
+	0xc1, 0xd0, 0xd1, 0xba, 0x1a, 0x49, 0x43, 0xb1, 0x3a, 0x76, 0xdf, 0x33, 0xdc, 0x92, 0xe5, 0xe5,
+	0x33, 0xea, 0x82, 0xee, 0x24, 0x57, 0x87, 0x8d, 0x8e, 0xd6, 0x6d, 0x9d, 0x1b, 0xe2, 0xb2, 0xd2,
+	0xeb, 0x24, 0x19, 0x8b, 0x4e, 0xa0, 0x76, 0x13, 0xd0, 0xc8, 0xc1, 0x6d, 0xb9, 0x5d, 0x05, 0xa2,
+	0x1a, 0xf5, 0x39, 0xf3, 0x7d, 0x8a, 0x8f, 0x54, 0x57, 0x49, 0x88, 0x2e, 0xe0, 0x78, 0xe1, 0xfa,
+	0x6c, 0x9e, 0x0a, 0x37, 0xdf, 0x86, 0x0c, 0x7f, 0x24, 0xfd, 0xf0, 0xb1, 0x28, 0x31, 0xb8, 0x4f,
+	0x92, 0xf2, 0x7a, 0xf1, 0x87, 0x28, 0x80, 0xd8, 0x94, 0x45, 0x8a, 0x20, 0xfa, 0x01, 0x3e, 0x61,
+	0xbe, 0x1d, 0x6d, 0x43, 0xce, 0x9c, 0x42, 0x5a, 0x7c, 0x2c, 0x6f, 0xfa, 0x01, 0x16, 0x9d, 0x01,
+	0x2a, 0x24, 0xba, 0x62, 0xdb, 0xe1, 0x25, 0x46, 0xb2, 0xc4, 0x1e, 0x06, 0x7d, 0x0e, 0x10, 0xbb,
+	0x4b, 0x9f, 0x7a, 0x64, 0x36, 0x1b, 0xe2, 0x27, 0x52, 0xf4, 0x1c, 0x62, 0xfd, 0x0c, 0x2d, 0x65,
+	0xb7, 0x41, 0x44, 0x57, 0x42, 0xdb, 0x7a, 0xf4, 0x4e, 0xf8, 0x4e, 0x1a, 0xae, 0x75, 0x6e, 0x8a,
+	0xb6, 0xf3, 0x7e, 0x24, 0x09, 0x2f, 0x12, 0x87, 0xb7, 0xdb, 0x29, 0xdd, 0x7a, 0x01, 0x75, 0xa4,
+	0x17, 0x0d, 0x92, 0x43, 0xac, 0x3f, 0x2a, 0x70, 0x74, 0x19, 0x6c, 0x7c, 0xb1, 0x75, 0xfe, 0x90,
+	0x95, 0x3b, 0xd0, 0x72, 0x57, 0x2b, 0xe6, 0xb8, 0x94, 0x33, 0x4f, 0x0d, 0x25, 0x9d, 0xe4, 0xa1,
+	0xff, 0x65, 0xec, 0x13, 0xa8, 0x85, 0xc1, 0x86, 0x45, 0xd2, 0xd6, 0x35, 0xa2, 0x82, 0x82, 0x7d,
+	0xea, 0x8f, 0xda, 0x27, 0x6f, 0xe3, 0xc6, 0x3d, 0x1b, 0x7f, 0x0f, 0x46, 0x18, 0x78, 0x34, 0x72,
+	0xdf, 0xab, 0xa9, 0xa1, 0x4b, 0x97, 0x48, 0xb9, 0xa6, 0x39, 0x9c, 0x14, 0x56, 0x59, 0xbf, 0x42,
+	0x3b, 0xd5, 0x44, 0xe9, 0x7d, 0x0a, 0x75, 0x9e, 0xd7, 0x1b, 0xc9, 0xa3, 0x14, 0x64, 0x23, 0x75,
+	0xfe, 0x61, 0x8a, 0x3f, 0xdf, 0x25, 0x9f, 0xbf, 0xea, 0xd9, 0x77, 0x7b, 0xf4, 0x3e, 0x81, 0x1a,
+	0x8b, 0xa2, 0x64, 0x64, 0x35, 0x89, 0x0a, 0xac, 0xdf, 0x40, 0x1f, 0x05, 0xb6, 0x9a, 0x6d, 0x4f,
+	0x41, 0x17, 0x53, 0x8e, 0xaf, 0x1d, 0x26, 0x37, 0x6a, 0x24, 0x8b, 0x85, 0xda, 0x5e, 0xe0, 0x2f,
+	0x15, 0x59, 0x91, 0xe4, 0x0e, 0x10, 0x3b, 0xa9, 0x97, 0xec, 0xac, 0xaa, 0x9d, 0x69, 0x6c, 0xfd,
+	0x5d, 0x01, 0xe3, 0x05, 0xe5, 0x6c, 0x43, 0xb7, 0x62, 0x44, 0xc4, 0x1f, 0x38, 0xd5, 0xba, 0xa0,
+	0x7b, 0xc9, 0xc1, 0x70, 0x75, 0x77, 0x55, 0xe9, 0x61, 0x49, 0xc6, 0xa2, 0x6f, 0xe0, 0x38, 0x7a,
+	0x37, 0xa5, 0xf6, 0x1d, 0xe3, 0x31, 0x61, 0x36, 0x73, 0xdf, 0x32, 0x27, 0x31, 0x44, 0x99, 0x40,
+	0xdf, 0xc2, 0x93, 0x12, 0x38, 0xb9, 0x4a, 0xa6, 0xdf, 0x3e, 0x4a, 0xe4, 0xe7, 0xa5, 0xfc, 0x6a,
+	0x26, 0x96, 0x09, 0x74, 0x0a, 0x66, 0x06, 0xf6, 0x57, 0x2e, 0xe7, 0xcc, 0x91, 0x06, 0x6a, 0x93,
+	0x12, 0x2e, 0xc6, 0x85, 0x2d, 0xdf, 0x74, 0x2f, 0x59, 0x14, 0xa7, 0x4e, 0x6a, 0x92, 0x22, 0x68,
+	0xdd, 0xc1, 0x49, 0xa2, 0x5f, 0xe1, 0xb5, 0xb8, 0x47, 0x47, 0x0c, 0x8d, 0xb7, 0x49, 0x26, 0x25,
+	0x65, 0x1a, 0x16, 0x5e, 0x9d, 0xd5, 0x47, 0x5e, 0x9d, 0xa7, 0x5f, 0x00, 0xec, 0xde, 0x7c, 0x48,
+	0x87, 0xc3, 0xd1, 0x84, 0xf4, 0xcc, 0x03, 0xd4, 0x80, 0xea, 0x60, 0x76, 0x65, 0x6a, 0xa7, 0xcf,
+	0xe1, 0xb8, 0x34, 0x0a, 0xc5, 0xba, 0xf1, 0x64, 0xdc, 0x37, 0x0f, 0x50, 0x1b, 0x9a, 0xfd, 0xf1,
+	0x05, 0xf9, 0x65, 0x3a, 0xef, 0x5f, 0x9a, 0x1a, 0x6a, 0x42, 0x6d, 0x3a, 0xea, 0x0d, 0xc7, 0x66,
+	0xe5, 0xf4, 0x47, 0x30, 0xf2, 0xff, 0x0e, 0x84, 0xe1, 0xe4, 0xb2, 0x3f, 0xe8, 0x5d, 0x8f, 0xe6,
+	0x6f, 0xa6, 0x93, 0x51, 0x8f, 0x0c, 0x5f, 0xf7, 0xe6, 0xc3, 0xc9, 0xd8, 0x3c, 0x40, 0x06, 0xe8,
+	0xc3, 0xf1, 0xcb, 0x3e, 0x51, 0x29, 0x4c, 0x30, 0xc6, 0x93, 0xf1, 0x9b, 0x0c, 0xa9, 0x9c, 0xbf,
+	0x86, 0x46, 0x22, 0x08, 0x9a, 0x80, 0x79, 0xff, 0x73, 0x01, 0x7d, 0x26, 0x3a, 0x7b, 0xe0, 0x8b,
+	0xe3, 0xe9, 0xb3, 0xfd, 0xa4, 0xfa, 0xc2, 0xb0, 0x0e, 0x6e, 0xea, 0xf2, 0x7b, 0xeb, 0xbb, 0x7f,
+	0x07, 0x00, 0x96, 0x8b, 0xdf, 0x3f, 0x7b, 0x09, 0x00, 0x00,
 }
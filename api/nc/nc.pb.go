@@ -17,6 +17,8 @@ It has these top-level messages:
 	HandleDataUpMACCommandResponse
 	HandleErrorRequest
 	HandleErrorResponse
+	HandleGatewayStateChangeRequest
+	HandleGatewayStateChangeResponse
 */
 package nc
 
@@ -40,6 +42,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type GatewayState int32
+
+const (
+	// The gateway has not been seen yet.
+	GatewayState_NEVER_SEEN GatewayState = 0
+	// The gateway is sending its stats.
+	GatewayState_ONLINE GatewayState = 1
+	// The gateway stopped sending its stats.
+	GatewayState_OFFLINE GatewayState = 2
+)
+
+var GatewayState_name = map[int32]string{
+	0: "NEVER_SEEN",
+	1: "ONLINE",
+	2: "OFFLINE",
+}
+var GatewayState_value = map[string]int32{
+	"NEVER_SEEN": 0,
+	"ONLINE":     1,
+	"OFFLINE":    2,
+}
+
+func (x GatewayState) String() string {
+	return proto.EnumName(GatewayState_name, int32(x))
+}
+func (GatewayState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type DataRate struct {
 	Modulation   string `protobuf:"bytes,1,opt,name=modulation" json:"modulation,omitempty"`
 	BandWidth    uint32 `protobuf:"varint,2,opt,name=bandWidth" json:"bandWidth,omitempty"`
@@ -85,6 +114,20 @@ type RXInfo struct {
 	Time    string  `protobuf:"bytes,2,opt,name=time" json:"time,omitempty"`
 	Rssi    int32   `protobuf:"varint,3,opt,name=rssi" json:"rssi,omitempty"`
 	LoRaSNR float64 `protobuf:"fixed64,4,opt,name=loRaSNR" json:"loRaSNR,omitempty"`
+	// Concentrator board used for RX.
+	Board uint32 `protobuf:"varint,5,opt,name=board" json:"board,omitempty"`
+	// Antenna used for RX.
+	Antenna uint32 `protobuf:"varint,6,opt,name=antenna" json:"antenna,omitempty"`
+	// Type of the fine-timestamp (PLAIN or ENCRYPTED, empty when not available).
+	FineTimestampType string `protobuf:"bytes,7,opt,name=fineTimestampType" json:"fineTimestampType,omitempty"`
+	// Plain fine-timestamp (nanoseconds since the last GPS second).
+	FineTimestamp uint32 `protobuf:"varint,8,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// Encrypted fine-timestamp.
+	EncryptedFineTimestamp []byte `protobuf:"bytes,9,opt,name=encryptedFineTimestamp,proto3" json:"encryptedFineTimestamp,omitempty"`
+	// Index of the key used to encrypt the fine-timestamp.
+	FineTimestampKeyID uint32 `protobuf:"varint,10,opt,name=fineTimestampKeyID" json:"fineTimestampKeyID,omitempty"`
+	// Signal RSSI (0 when not reported by the gateway).
+	SignalRSSI int32 `protobuf:"varint,11,opt,name=signalRSSI" json:"signalRSSI,omitempty"`
 }
 
 func (m *RXInfo) Reset()                    { *m = RXInfo{} }
@@ -120,6 +163,55 @@ func (m *RXInfo) GetLoRaSNR() float64 {
 	return 0
 }
 
+func (m *RXInfo) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *RXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *RXInfo) GetFineTimestampType() string {
+	if m != nil {
+		return m.FineTimestampType
+	}
+	return ""
+}
+
+func (m *RXInfo) GetFineTimestamp() uint32 {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return 0
+}
+
+func (m *RXInfo) GetEncryptedFineTimestamp() []byte {
+	if m != nil {
+		return m.EncryptedFineTimestamp
+	}
+	return nil
+}
+
+func (m *RXInfo) GetFineTimestampKeyID() uint32 {
+	if m != nil {
+		return m.FineTimestampKeyID
+	}
+	return 0
+}
+
+func (m *RXInfo) GetSignalRSSI() int32 {
+	if m != nil {
+		return m.SignalRSSI
+	}
+	return 0
+}
+
 type TXInfo struct {
 	Frequency int64     `protobuf:"varint,1,opt,name=frequency" json:"frequency,omitempty"`
 	DataRate  *DataRate `protobuf:"bytes,2,opt,name=dataRate" json:"dataRate,omitempty"`
@@ -284,6 +376,62 @@ func (m *HandleErrorResponse) String() string            { return proto.CompactT
 func (*HandleErrorResponse) ProtoMessage()               {}
 func (*HandleErrorResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
+type HandleGatewayStateChangeRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// New state of the gateway.
+	State GatewayState `protobuf:"varint,2,opt,name=state,enum=nc.GatewayState" json:"state,omitempty"`
+	// The timestamp of the state change.
+	StateChangedAt string `protobuf:"bytes,3,opt,name=stateChangedAt" json:"stateChangedAt,omitempty"`
+	// The timestamp when the gateway was last seen.
+	LastSeenAt string `protobuf:"bytes,4,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+}
+
+func (m *HandleGatewayStateChangeRequest) Reset()         { *m = HandleGatewayStateChangeRequest{} }
+func (m *HandleGatewayStateChangeRequest) String() string { return proto.CompactTextString(m) }
+func (*HandleGatewayStateChangeRequest) ProtoMessage()    {}
+func (*HandleGatewayStateChangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9}
+}
+
+func (m *HandleGatewayStateChangeRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *HandleGatewayStateChangeRequest) GetState() GatewayState {
+	if m != nil {
+		return m.State
+	}
+	return GatewayState_NEVER_SEEN
+}
+
+func (m *HandleGatewayStateChangeRequest) GetStateChangedAt() string {
+	if m != nil {
+		return m.StateChangedAt
+	}
+	return ""
+}
+
+func (m *HandleGatewayStateChangeRequest) GetLastSeenAt() string {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return ""
+}
+
+type HandleGatewayStateChangeResponse struct {
+}
+
+func (m *HandleGatewayStateChangeResponse) Reset()         { *m = HandleGatewayStateChangeResponse{} }
+func (m *HandleGatewayStateChangeResponse) String() string { return proto.CompactTextString(m) }
+func (*HandleGatewayStateChangeResponse) ProtoMessage()    {}
+func (*HandleGatewayStateChangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{10}
+}
+
 func init() {
 	proto.RegisterType((*DataRate)(nil), "nc.DataRate")
 	proto.RegisterType((*RXInfo)(nil), "nc.RXInfo")
@@ -294,6 +442,9 @@ func init() {
 	proto.RegisterType((*HandleDataUpMACCommandResponse)(nil), "nc.HandleDataUpMACCommandResponse")
 	proto.RegisterType((*HandleErrorRequest)(nil), "nc.HandleErrorRequest")
 	proto.RegisterType((*HandleErrorResponse)(nil), "nc.HandleErrorResponse")
+	proto.RegisterType((*HandleGatewayStateChangeRequest)(nil), "nc.HandleGatewayStateChangeRequest")
+	proto.RegisterType((*HandleGatewayStateChangeResponse)(nil), "nc.HandleGatewayStateChangeResponse")
+	proto.RegisterEnum("nc.GatewayState", GatewayState_name, GatewayState_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -314,6 +465,8 @@ type NetworkControllerClient interface {
 	// enqueued throught the API or when the CID is >= 0x80 (proprietary
 	// mac-command range).
 	HandleDataUpMACCommand(ctx context.Context, in *HandleDataUpMACCommandRequest, opts ...grpc.CallOption) (*HandleDataUpMACCommandResponse, error)
+	// HandleGatewayStateChange publishes a gateway going online or offline.
+	HandleGatewayStateChange(ctx context.Context, in *HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*HandleGatewayStateChangeResponse, error)
 }
 
 type networkControllerClient struct {
@@ -342,6 +495,15 @@ func (c *networkControllerClient) HandleDataUpMACCommand(ctx context.Context, in
 	return out, nil
 }
 
+func (c *networkControllerClient) HandleGatewayStateChange(ctx context.Context, in *HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*HandleGatewayStateChangeResponse, error) {
+	out := new(HandleGatewayStateChangeResponse)
+	err := grpc.Invoke(ctx, "/nc.NetworkController/HandleGatewayStateChange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for NetworkController service
 
 type NetworkControllerServer interface {
@@ -352,6 +514,8 @@ type NetworkControllerServer interface {
 	// enqueued throught the API or when the CID is >= 0x80 (proprietary
 	// mac-command range).
 	HandleDataUpMACCommand(context.Context, *HandleDataUpMACCommandRequest) (*HandleDataUpMACCommandResponse, error)
+	// HandleGatewayStateChange publishes a gateway going online or offline.
+	HandleGatewayStateChange(context.Context, *HandleGatewayStateChangeRequest) (*HandleGatewayStateChangeResponse, error)
 }
 
 func RegisterNetworkControllerServer(s *grpc.Server, srv NetworkControllerServer) {
@@ -394,6 +558,24 @@ func _NetworkController_HandleDataUpMACCommand_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkController_HandleGatewayStateChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandleGatewayStateChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkControllerServer).HandleGatewayStateChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nc.NetworkController/HandleGatewayStateChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkControllerServer).HandleGatewayStateChange(ctx, req.(*HandleGatewayStateChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkController_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nc.NetworkController",
 	HandlerType: (*NetworkControllerServer)(nil),
@@ -406,6 +588,10 @@ var _NetworkController_serviceDesc = grpc.ServiceDesc{
 			MethodName: "HandleDataUpMACCommand",
 			Handler:    _NetworkController_HandleDataUpMACCommand_Handler,
 		},
+		{
+			MethodName: "HandleGatewayStateChange",
+			Handler:    _NetworkController_HandleGatewayStateChange_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "nc.proto",
@@ -414,35 +600,50 @@ var _NetworkController_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("nc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 470 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4d, 0x6f, 0xd3, 0x30,
-	0x18, 0x26, 0x4b, 0x1b, 0x92, 0x77, 0x41, 0x02, 0x33, 0x4a, 0x54, 0x8d, 0x2a, 0xf8, 0xd4, 0x53,
-	0x0f, 0xe5, 0x17, 0x40, 0x19, 0x62, 0x07, 0x26, 0xf4, 0xc2, 0x04, 0x07, 0x2e, 0x6e, 0xec, 0x8a,
-	0x88, 0xc4, 0x2e, 0x8e, 0x0b, 0xec, 0xc2, 0x0d, 0xfe, 0x14, 0x7f, 0x0e, 0xf9, 0x23, 0x6d, 0x27,
-	0x36, 0x7a, 0x7b, 0xbf, 0xf2, 0x7c, 0x59, 0x81, 0x54, 0x56, 0xb3, 0xb5, 0x56, 0x46, 0x91, 0x23,
-	0x59, 0xd1, 0xdf, 0x11, 0xa4, 0x2f, 0x99, 0x61, 0xc8, 0x8c, 0x20, 0x13, 0x80, 0x56, 0xf1, 0x4d,
-	0xc3, 0x4c, 0xad, 0x64, 0x11, 0x95, 0xd1, 0x34, 0xc3, 0xbd, 0x09, 0x39, 0x85, 0x6c, 0xc9, 0x24,
-	0xff, 0x50, 0x73, 0xf3, 0xb9, 0x38, 0x2a, 0xa3, 0xe9, 0x3d, 0xdc, 0x0d, 0x08, 0x85, 0xbc, 0x5b,
-	0x6b, 0xc1, 0xf8, 0x2b, 0x56, 0x19, 0xa5, 0x8b, 0xd8, 0x1d, 0x5c, 0x9b, 0x91, 0x02, 0xee, 0x2e,
-	0x6b, 0xa3, 0x99, 0x11, 0xc5, 0xc0, 0xad, 0xfb, 0x96, 0x7e, 0x82, 0x04, 0x3f, 0x9e, 0xcb, 0x95,
-	0x22, 0xf7, 0x21, 0x6e, 0x59, 0xe5, 0xe8, 0x73, 0xb4, 0x25, 0x21, 0x30, 0x30, 0x75, 0x2b, 0x1c,
-	0x65, 0x86, 0xae, 0xb6, 0x33, 0xdd, 0x75, 0xb5, 0x63, 0x19, 0xa2, 0xab, 0x2d, 0x7a, 0xa3, 0x90,
-	0xbd, 0xbb, 0x40, 0x87, 0x1e, 0x61, 0xdf, 0xd2, 0x9f, 0x90, 0xbc, 0xf7, 0xe8, 0xa7, 0x90, 0xad,
-	0xb4, 0xf8, 0xba, 0x11, 0xb2, 0xba, 0x72, 0x1c, 0x31, 0xee, 0x06, 0x64, 0x0a, 0x29, 0x0f, 0x69,
-	0x38, 0xb6, 0xe3, 0x79, 0x3e, 0x93, 0xd5, 0xac, 0x4f, 0x08, 0xb7, 0x5b, 0xab, 0x92, 0x71, 0x6f,
-	0x32, 0x45, 0x5b, 0x92, 0x31, 0xa4, 0x95, 0xe2, 0x02, 0x7b, 0x73, 0x19, 0x6e, 0x7b, 0xba, 0x81,
-	0x87, 0xaf, 0x99, 0xe4, 0x8d, 0xf0, 0x1e, 0xd1, 0xf2, 0x75, 0x86, 0x8c, 0x20, 0xe1, 0xe2, 0xdb,
-	0xd9, 0xe5, 0x79, 0x70, 0x1b, 0x3a, 0x42, 0x21, 0x31, 0x3f, 0xec, 0xa1, 0xc3, 0x3f, 0x9e, 0x83,
-	0x15, 0xe1, 0x0d, 0x60, 0xd8, 0xd8, 0x1b, 0xed, 0x6f, 0x06, 0x65, 0xdc, 0xdf, 0x04, 0xf8, 0xb0,
-	0xa1, 0x23, 0x38, 0xb9, 0x4e, 0xdb, 0xad, 0x95, 0xec, 0x04, 0xfd, 0x15, 0xc1, 0x13, 0xbf, 0xb0,
-	0xce, 0x2e, 0xd7, 0x6f, 0x9e, 0x2f, 0x16, 0xaa, 0x6d, 0x99, 0xe4, 0x87, 0x94, 0x4d, 0x00, 0x56,
-	0xba, 0x7d, 0xcb, 0xae, 0x1a, 0xc5, 0x78, 0x70, 0xbf, 0x37, 0xb1, 0xb1, 0x54, 0x35, 0x2f, 0x86,
-	0xee, 0x71, 0x6d, 0xe9, 0x63, 0x71, 0xd8, 0x5d, 0x91, 0x94, 0xf1, 0x34, 0xc7, 0x6d, 0x4f, 0x4b,
-	0x98, 0xdc, 0x26, 0x23, 0x28, 0x7d, 0x01, 0xc4, 0x5f, 0x9c, 0x69, 0xad, 0xf4, 0x21, 0x75, 0x27,
-	0x30, 0x14, 0xf6, 0xce, 0x09, 0xcb, 0xd0, 0x37, 0xf4, 0x51, 0x1f, 0x7e, 0xc0, 0xf0, 0xd0, 0xf3,
-	0x3f, 0x11, 0x3c, 0xb8, 0x10, 0xe6, 0xbb, 0xd2, 0x5f, 0x16, 0x4a, 0x1a, 0xad, 0x9a, 0x46, 0x68,
-	0xb2, 0x80, 0x7c, 0x3f, 0x32, 0xf2, 0xd8, 0xc6, 0x7a, 0xc3, 0xdb, 0x8d, 0x8b, 0x7f, 0x17, 0x41,
-	0xf3, 0x1d, 0xc2, 0x60, 0x74, 0xb3, 0x2f, 0xf2, 0x74, 0xf7, 0xd5, 0x2d, 0xd1, 0x8f, 0xe9, 0xff,
-	0x4e, 0x7a, 0x8a, 0x65, 0xe2, 0xfe, 0xe1, 0x67, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xdb, 0xc0,
-	0xae, 0xe8, 0xcf, 0x03, 0x00, 0x00,
+	// 706 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0x5d, 0x6f, 0x12, 0x41,
+	0x14, 0xed, 0x42, 0xd9, 0xc2, 0x85, 0x36, 0xf4, 0xb6, 0xe2, 0x86, 0xd4, 0x8a, 0x6b, 0xd3, 0x10,
+	0x63, 0x78, 0xc0, 0x44, 0x9f, 0x2b, 0x05, 0x25, 0x2a, 0x35, 0x43, 0xab, 0xbe, 0x99, 0x61, 0x77,
+	0x68, 0x37, 0xee, 0xce, 0xe0, 0xec, 0xd4, 0xca, 0x8b, 0x6f, 0xfa, 0x47, 0xf4, 0xc7, 0xf9, 0x33,
+	0xcc, 0xcc, 0xee, 0xf2, 0x61, 0x4b, 0xfb, 0x36, 0xf7, 0xdc, 0xb3, 0xe7, 0xdc, 0x2f, 0x02, 0x14,
+	0xb9, 0xd7, 0x9a, 0x48, 0xa1, 0x04, 0xe6, 0xb8, 0xe7, 0xfe, 0xb2, 0xa0, 0x78, 0x4c, 0x15, 0x25,
+	0x54, 0x31, 0xdc, 0x07, 0x88, 0x84, 0x7f, 0x19, 0x52, 0x15, 0x08, 0xee, 0x58, 0x0d, 0xab, 0x59,
+	0x22, 0x0b, 0x08, 0xee, 0x41, 0x69, 0x44, 0xb9, 0xff, 0x31, 0xf0, 0xd5, 0x85, 0x93, 0x6b, 0x58,
+	0xcd, 0x4d, 0x32, 0x07, 0xd0, 0x85, 0x4a, 0x3c, 0x91, 0x8c, 0xfa, 0x3d, 0xea, 0x29, 0x21, 0x9d,
+	0xbc, 0x21, 0x2c, 0x61, 0xe8, 0xc0, 0xc6, 0x28, 0x50, 0x92, 0x2a, 0xe6, 0xac, 0x9b, 0x74, 0x16,
+	0xba, 0x7f, 0x73, 0x60, 0x93, 0x4f, 0x7d, 0x3e, 0x16, 0x58, 0x85, 0x7c, 0x44, 0x3d, 0xe3, 0x5f,
+	0x21, 0xfa, 0x89, 0x08, 0xeb, 0x2a, 0x88, 0x98, 0xf1, 0x2c, 0x11, 0xf3, 0xd6, 0x98, 0x8c, 0xe3,
+	0xc0, 0xd8, 0x14, 0x88, 0x79, 0x6b, 0xf9, 0x50, 0x10, 0x3a, 0x1c, 0x10, 0x23, 0x6f, 0x91, 0x2c,
+	0xc4, 0x5d, 0x28, 0x8c, 0x04, 0x95, 0xbe, 0x53, 0x30, 0xb6, 0x49, 0xa0, 0xf9, 0x94, 0x2b, 0xc6,
+	0x39, 0x75, 0xec, 0xa4, 0x9c, 0x34, 0xc4, 0xa7, 0xb0, 0x3d, 0x0e, 0x38, 0x3b, 0x0d, 0x22, 0x16,
+	0x2b, 0x1a, 0x4d, 0x4e, 0xa7, 0x13, 0xe6, 0x6c, 0x18, 0xfb, 0xeb, 0x09, 0x3c, 0x80, 0xcd, 0x25,
+	0xd0, 0x29, 0x1a, 0xb5, 0x65, 0x10, 0x9f, 0x43, 0x8d, 0x71, 0x4f, 0x4e, 0x27, 0x8a, 0xf9, 0xbd,
+	0x25, 0x7a, 0xc9, 0xb4, 0xba, 0x22, 0x8b, 0x2d, 0xc0, 0x25, 0xa1, 0x37, 0x6c, 0xda, 0x3f, 0x76,
+	0xc0, 0x58, 0xdc, 0x90, 0xd1, 0x6b, 0x8c, 0x83, 0x73, 0x4e, 0x43, 0x32, 0x1c, 0xf6, 0x9d, 0xb2,
+	0x99, 0xcf, 0x02, 0xe2, 0xfe, 0x00, 0xfb, 0x34, 0x99, 0xf4, 0x1e, 0x94, 0xc6, 0x92, 0x7d, 0xbd,
+	0x64, 0xdc, 0x9b, 0x9a, 0x79, 0xe7, 0xc9, 0x1c, 0xc0, 0x26, 0x14, 0xfd, 0xf4, 0x34, 0xcc, 0xe4,
+	0xcb, 0xed, 0x4a, 0x8b, 0x7b, 0xad, 0xec, 0x5c, 0xc8, 0x2c, 0xab, 0x37, 0x46, 0xfd, 0x64, 0xe3,
+	0x45, 0xa2, 0x9f, 0x58, 0x87, 0xa2, 0x27, 0x7c, 0x46, 0xb2, 0x4d, 0x97, 0xc8, 0x2c, 0x76, 0x2f,
+	0x61, 0xe7, 0x35, 0xe5, 0x7e, 0xc8, 0x92, 0x7d, 0x13, 0xed, 0x17, 0x2b, 0xac, 0x81, 0xed, 0xb3,
+	0x6f, 0xdd, 0xb3, 0x7e, 0xba, 0xf9, 0x34, 0x42, 0x17, 0x6c, 0xf5, 0x5d, 0x13, 0x8d, 0x7e, 0xb9,
+	0x0d, 0xba, 0x88, 0xa4, 0x01, 0x92, 0x66, 0x34, 0x47, 0x26, 0x9c, 0xf5, 0x46, 0x3e, 0xe3, 0xa4,
+	0xf2, 0x69, 0xc6, 0xad, 0xc1, 0xee, 0xb2, 0x6d, 0x3c, 0x11, 0x3c, 0x66, 0xee, 0x4f, 0x0b, 0x1e,
+	0x24, 0x09, 0xdd, 0xd9, 0xd9, 0xe4, 0xdd, 0x51, 0xa7, 0x23, 0xa2, 0x88, 0x72, 0xff, 0xae, 0xca,
+	0xf6, 0x01, 0xc6, 0x32, 0x7a, 0x4f, 0xa7, 0xa1, 0xa0, 0x7e, 0xda, 0xfd, 0x02, 0xa2, 0xc7, 0xe2,
+	0x05, 0xd9, 0xc9, 0xe9, 0x67, 0x32, 0x16, 0xa3, 0x1d, 0x3b, 0x76, 0x23, 0xdf, 0xac, 0x90, 0x59,
+	0xec, 0x36, 0x60, 0x7f, 0x55, 0x19, 0x69, 0xa5, 0x2f, 0x01, 0x13, 0x46, 0x57, 0x4a, 0x21, 0xef,
+	0xaa, 0x6e, 0x17, 0x0a, 0x4c, 0xf3, 0x4c, 0x61, 0x25, 0x92, 0x04, 0xee, 0x3d, 0xd8, 0x59, 0xd2,
+	0x48, 0xa5, 0x7f, 0x5b, 0xf0, 0x30, 0xc1, 0x5f, 0x51, 0xc5, 0xae, 0xe8, 0x74, 0xa8, 0xa8, 0x62,
+	0x9d, 0x0b, 0xca, 0xcf, 0x59, 0x66, 0x74, 0xfd, 0x77, 0x79, 0x08, 0x85, 0x58, 0x65, 0xe7, 0xb1,
+	0xd5, 0xae, 0xea, 0xa9, 0x2f, 0x7e, 0x4f, 0x92, 0x34, 0x1e, 0xc2, 0x56, 0x3c, 0xd7, 0xf3, 0x8f,
+	0x54, 0x5a, 0xd3, 0x7f, 0xa8, 0x1e, 0x68, 0x48, 0x63, 0x35, 0x64, 0x8c, 0x1f, 0xa9, 0xf4, 0x6e,
+	0x16, 0x10, 0xd7, 0x85, 0xc6, 0xea, 0x22, 0x93, 0x4e, 0x9e, 0xbc, 0x80, 0xca, 0x62, 0x16, 0xb7,
+	0x00, 0x06, 0xdd, 0x0f, 0x5d, 0xf2, 0x79, 0xd8, 0xed, 0x0e, 0xaa, 0x6b, 0x08, 0x60, 0x9f, 0x0c,
+	0xde, 0xf6, 0x07, 0xdd, 0xaa, 0x85, 0x65, 0xd8, 0x38, 0xe9, 0xf5, 0x4c, 0x90, 0x6b, 0xff, 0xc9,
+	0xc1, 0xf6, 0x80, 0xa9, 0x2b, 0x21, 0xbf, 0x74, 0x04, 0x57, 0x52, 0x84, 0x21, 0x93, 0xd8, 0x81,
+	0xca, 0xe2, 0xd5, 0xe0, 0x7d, 0xdd, 0xe3, 0x0d, 0xe7, 0x5b, 0x77, 0xae, 0x27, 0xd2, 0xd9, 0xae,
+	0x21, 0x85, 0xda, 0xcd, 0xab, 0xc5, 0x47, 0xf3, 0xaf, 0x56, 0x5c, 0x5f, 0xdd, 0xbd, 0x8d, 0x32,
+	0xb3, 0x38, 0x07, 0x67, 0xd5, 0x68, 0xf0, 0xf1, 0x5c, 0x61, 0xe5, 0x76, 0xeb, 0x07, 0xb7, 0x93,
+	0x32, 0xa3, 0x91, 0x6d, 0xfe, 0x3c, 0x9e, 0xfd, 0x1b, 0x00, 0xe9, 0xd9, 0x96, 0x73, 0x48, 0x06,
+	0x00, 0x00,
 }
@@ -40,6 +40,33 @@ func (x RXWindow) String() string {
 }
 func (RXWindow) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
 
+type GatewayState int32
+
+const (
+	// The gateway has not been seen yet.
+	GatewayState_NEVER_SEEN GatewayState = 0
+	// The gateway is sending its stats.
+	GatewayState_ONLINE GatewayState = 1
+	// The gateway stopped sending its stats.
+	GatewayState_OFFLINE GatewayState = 2
+)
+
+var GatewayState_name = map[int32]string{
+	0: "NEVER_SEEN",
+	1: "ONLINE",
+	2: "OFFLINE",
+}
+var GatewayState_value = map[string]int32{
+	"NEVER_SEEN": 0,
+	"ONLINE":     1,
+	"OFFLINE":    2,
+}
+
+func (x GatewayState) String() string {
+	return proto.EnumName(GatewayState_name, int32(x))
+}
+func (GatewayState) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
 type Modulation int32
 
 const (
@@ -61,7 +88,46 @@ var Modulation_value = map[string]int32{
 func (x Modulation) String() string {
 	return proto.EnumName(Modulation_name, int32(x))
 }
-func (Modulation) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+func (Modulation) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+
+type ListGatewayOrderBy int32
+
+const (
+	// Order by MAC.
+	ListGatewayOrderBy_MAC ListGatewayOrderBy = 0
+	// Order by name.
+	ListGatewayOrderBy_NAME ListGatewayOrderBy = 1
+	// Order by created at timestamp.
+	ListGatewayOrderBy_CREATED_AT ListGatewayOrderBy = 2
+	// Order by last seen at timestamp (gateways never seen are returned last).
+	ListGatewayOrderBy_LAST_SEEN_AT ListGatewayOrderBy = 3
+	// Order by gateway state.
+	ListGatewayOrderBy_STATE ListGatewayOrderBy = 4
+	// Order by distance to the center of the radius filter (requires radius).
+	ListGatewayOrderBy_DISTANCE ListGatewayOrderBy = 5
+)
+
+var ListGatewayOrderBy_name = map[int32]string{
+	0: "MAC",
+	1: "NAME",
+	2: "CREATED_AT",
+	3: "LAST_SEEN_AT",
+	4: "STATE",
+	5: "DISTANCE",
+}
+var ListGatewayOrderBy_value = map[string]int32{
+	"MAC":          0,
+	"NAME":         1,
+	"CREATED_AT":   2,
+	"LAST_SEEN_AT": 3,
+	"STATE":        4,
+	"DISTANCE":     5,
+}
+
+func (x ListGatewayOrderBy) String() string {
+	return proto.EnumName(ListGatewayOrderBy_name, int32(x))
+}
+func (ListGatewayOrderBy) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
 
 type AggregationInterval int32
 
@@ -100,7 +166,7 @@ var AggregationInterval_value = map[string]int32{
 func (x AggregationInterval) String() string {
 	return proto.EnumName(AggregationInterval_name, int32(x))
 }
-func (AggregationInterval) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+func (AggregationInterval) EnumDescriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
 
 type CreateServiceProfileRequest struct {
 	ServiceProfile *ServiceProfile `protobuf:"bytes,1,opt,name=serviceProfile" json:"serviceProfile,omitempty"`
@@ -836,6 +902,9 @@ func (m *GetDeviceActivationResponse) GetSkipFCntCheck() bool {
 }
 
 type GetRandomDevAddrRequest struct {
+	// ID of the service-profile of which the DevAddr ranges must be used
+	// (optional, the network-server ranges are used when not set).
+	ServiceProfileID string `protobuf:"bytes,1,opt,name=serviceProfileID" json:"serviceProfileID,omitempty"`
 }
 
 func (m *GetRandomDevAddrRequest) Reset()                    { *m = GetRandomDevAddrRequest{} }
@@ -843,6 +912,13 @@ func (m *GetRandomDevAddrRequest) String() string            { return proto.Comp
 func (*GetRandomDevAddrRequest) ProtoMessage()               {}
 func (*GetRandomDevAddrRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{40} }
 
+func (m *GetRandomDevAddrRequest) GetServiceProfileID() string {
+	if m != nil {
+		return m.ServiceProfileID
+	}
+	return ""
+}
+
 type GetRandomDevAddrResponse struct {
 	DevAddr []byte `protobuf:"bytes,1,opt,name=devAddr,proto3" json:"devAddr,omitempty"`
 }
@@ -1044,10 +1120,12 @@ func (m *SendProprietaryPayloadRequest) GetDr() uint32 {
 type SendProprietaryPayloadResponse struct {
 }
 
-func (m *SendProprietaryPayloadResponse) Reset()                    { *m = SendProprietaryPayloadResponse{} }
-func (m *SendProprietaryPayloadResponse) String() string            { return proto.CompactTextString(m) }
-func (*SendProprietaryPayloadResponse) ProtoMessage()               {}
-func (*SendProprietaryPayloadResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{47} }
+func (m *SendProprietaryPayloadResponse) Reset()         { *m = SendProprietaryPayloadResponse{} }
+func (m *SendProprietaryPayloadResponse) String() string { return proto.CompactTextString(m) }
+func (*SendProprietaryPayloadResponse) ProtoMessage()    {}
+func (*SendProprietaryPayloadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{47}
+}
 
 type CreateGatewayRequest struct {
 	// MAC address of the gateway.
@@ -1168,6 +1246,15 @@ type GetGatewayResponse struct {
 	LastSeenAt string `protobuf:"bytes,10,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
 	// ID of the channel-configuration (optional).
 	ChannelConfigurationID int64 `protobuf:"varint,11,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// Version of the configuration acknowledged by the gateway.
+	ConfigVersion string `protobuf:"bytes,12,opt,name=configVersion" json:"configVersion,omitempty"`
+	// Version of the configuration the gateway should have (empty when
+	// the gateway does not have a channel-configuration).
+	DesiredConfigVersion string `protobuf:"bytes,13,opt,name=desiredConfigVersion" json:"desiredConfigVersion,omitempty"`
+	// State of the gateway.
+	State GatewayState `protobuf:"varint,14,opt,name=state,enum=ns.GatewayState" json:"state,omitempty"`
+	// The timestamp when the state of the gateway last changed.
+	StateChangedAt string `protobuf:"bytes,15,opt,name=stateChangedAt" json:"stateChangedAt,omitempty"`
 }
 
 func (m *GetGatewayResponse) Reset()                    { *m = GetGatewayResponse{} }
@@ -1252,6 +1339,34 @@ func (m *GetGatewayResponse) GetChannelConfigurationID() int64 {
 	return 0
 }
 
+func (m *GetGatewayResponse) GetConfigVersion() string {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetDesiredConfigVersion() string {
+	if m != nil {
+		return m.DesiredConfigVersion
+	}
+	return ""
+}
+
+func (m *GetGatewayResponse) GetState() GatewayState {
+	if m != nil {
+		return m.State
+	}
+	return GatewayState_NEVER_SEEN
+}
+
+func (m *GetGatewayResponse) GetStateChangedAt() string {
+	if m != nil {
+		return m.StateChangedAt
+	}
+	return ""
+}
+
 type UpdateGatewayRequest struct {
 	// MAC address of the gateway.
 	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
@@ -1331,17 +1446,117 @@ func (m *UpdateGatewayResponse) String() string            { return proto.Compac
 func (*UpdateGatewayResponse) ProtoMessage()               {}
 func (*UpdateGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{53} }
 
+type GatewayBoundingBox struct {
+	// Min. latitude of the bounding box.
+	MinLatitude float64 `protobuf:"fixed64,1,opt,name=minLatitude" json:"minLatitude,omitempty"`
+	// Min. longitude of the bounding box.
+	MinLongitude float64 `protobuf:"fixed64,2,opt,name=minLongitude" json:"minLongitude,omitempty"`
+	// Max. latitude of the bounding box.
+	MaxLatitude float64 `protobuf:"fixed64,3,opt,name=maxLatitude" json:"maxLatitude,omitempty"`
+	// Max. longitude of the bounding box.
+	MaxLongitude float64 `protobuf:"fixed64,4,opt,name=maxLongitude" json:"maxLongitude,omitempty"`
+}
+
+func (m *GatewayBoundingBox) Reset()                    { *m = GatewayBoundingBox{} }
+func (m *GatewayBoundingBox) String() string            { return proto.CompactTextString(m) }
+func (*GatewayBoundingBox) ProtoMessage()               {}
+func (*GatewayBoundingBox) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{54} }
+
+func (m *GatewayBoundingBox) GetMinLatitude() float64 {
+	if m != nil {
+		return m.MinLatitude
+	}
+	return 0
+}
+
+func (m *GatewayBoundingBox) GetMinLongitude() float64 {
+	if m != nil {
+		return m.MinLongitude
+	}
+	return 0
+}
+
+func (m *GatewayBoundingBox) GetMaxLatitude() float64 {
+	if m != nil {
+		return m.MaxLatitude
+	}
+	return 0
+}
+
+func (m *GatewayBoundingBox) GetMaxLongitude() float64 {
+	if m != nil {
+		return m.MaxLongitude
+	}
+	return 0
+}
+
+type GatewayRadius struct {
+	// Latitude of the center.
+	Latitude float64 `protobuf:"fixed64,1,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the center.
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude" json:"longitude,omitempty"`
+	// Radius in meters.
+	Radius float64 `protobuf:"fixed64,3,opt,name=radius" json:"radius,omitempty"`
+}
+
+func (m *GatewayRadius) Reset()                    { *m = GatewayRadius{} }
+func (m *GatewayRadius) String() string            { return proto.CompactTextString(m) }
+func (*GatewayRadius) ProtoMessage()               {}
+func (*GatewayRadius) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{55} }
+
+func (m *GatewayRadius) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *GatewayRadius) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *GatewayRadius) GetRadius() float64 {
+	if m != nil {
+		return m.Radius
+	}
+	return 0
+}
+
 type ListGatewayRequest struct {
 	// Max number of gateways to return in the result-set.
 	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
 	// Offset in the result-set (for pagination).
 	Offset int32 `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	// Only return gateways of which the name or description contains the
+	// given string (case-insensitive, optional).
+	Search string `protobuf:"bytes,3,opt,name=search" json:"search,omitempty"`
+	// Only return gateways using the given channel-configuration (optional).
+	ChannelConfigurationID int64 `protobuf:"varint,4,opt,name=channelConfigurationID" json:"channelConfigurationID,omitempty"`
+	// Only return gateways in one of the given states (optional).
+	States []GatewayState `protobuf:"varint,5,rep,packed,name=states,enum=ns.GatewayState" json:"states,omitempty"`
+	// Only return gateways last seen at or after the given timestamp
+	// (RFC3339, optional).
+	LastSeenAtFrom string `protobuf:"bytes,6,opt,name=lastSeenAtFrom" json:"lastSeenAtFrom,omitempty"`
+	// Only return gateways last seen before the given timestamp
+	// (RFC3339, optional).
+	LastSeenAtTo string `protobuf:"bytes,7,opt,name=lastSeenAtTo" json:"lastSeenAtTo,omitempty"`
+	// Only return gateways within the given bounding box (optional).
+	BoundingBox *GatewayBoundingBox `protobuf:"bytes,8,opt,name=boundingBox" json:"boundingBox,omitempty"`
+	// Only return gateways within the given radius (optional).
+	Radius *GatewayRadius `protobuf:"bytes,9,opt,name=radius" json:"radius,omitempty"`
+	// Order of the result-set.
+	OrderBy ListGatewayOrderBy `protobuf:"varint,10,opt,name=orderBy,enum=ns.ListGatewayOrderBy" json:"orderBy,omitempty"`
+	// Order the result-set descending.
+	OrderDesc bool `protobuf:"varint,11,opt,name=orderDesc" json:"orderDesc,omitempty"`
 }
 
 func (m *ListGatewayRequest) Reset()                    { *m = ListGatewayRequest{} }
 func (m *ListGatewayRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListGatewayRequest) ProtoMessage()               {}
-func (*ListGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{54} }
+func (*ListGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{56} }
 
 func (m *ListGatewayRequest) GetLimit() int32 {
 	if m != nil {
@@ -1357,8 +1572,71 @@ func (m *ListGatewayRequest) GetOffset() int32 {
 	return 0
 }
 
+func (m *ListGatewayRequest) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
+}
+
+func (m *ListGatewayRequest) GetChannelConfigurationID() int64 {
+	if m != nil {
+		return m.ChannelConfigurationID
+	}
+	return 0
+}
+
+func (m *ListGatewayRequest) GetStates() []GatewayState {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+func (m *ListGatewayRequest) GetLastSeenAtFrom() string {
+	if m != nil {
+		return m.LastSeenAtFrom
+	}
+	return ""
+}
+
+func (m *ListGatewayRequest) GetLastSeenAtTo() string {
+	if m != nil {
+		return m.LastSeenAtTo
+	}
+	return ""
+}
+
+func (m *ListGatewayRequest) GetBoundingBox() *GatewayBoundingBox {
+	if m != nil {
+		return m.BoundingBox
+	}
+	return nil
+}
+
+func (m *ListGatewayRequest) GetRadius() *GatewayRadius {
+	if m != nil {
+		return m.Radius
+	}
+	return nil
+}
+
+func (m *ListGatewayRequest) GetOrderBy() ListGatewayOrderBy {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ListGatewayOrderBy_MAC
+}
+
+func (m *ListGatewayRequest) GetOrderDesc() bool {
+	if m != nil {
+		return m.OrderDesc
+	}
+	return false
+}
+
 type ListGatewayResponse struct {
-	// Total number of gateways.
+	// Total number of gateways (matching the filters).
 	TotalCount int32 `protobuf:"varint,1,opt,name=totalCount" json:"totalCount,omitempty"`
 	// Result-set.
 	Result []*GetGatewayResponse `protobuf:"bytes,2,rep,name=result" json:"result,omitempty"`
@@ -1367,7 +1645,7 @@ type ListGatewayResponse struct {
 func (m *ListGatewayResponse) Reset()                    { *m = ListGatewayResponse{} }
 func (m *ListGatewayResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListGatewayResponse) ProtoMessage()               {}
-func (*ListGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{55} }
+func (*ListGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{57} }
 
 func (m *ListGatewayResponse) GetTotalCount() int32 {
 	if m != nil {
@@ -1391,7 +1669,7 @@ type DeleteGatewayRequest struct {
 func (m *DeleteGatewayRequest) Reset()                    { *m = DeleteGatewayRequest{} }
 func (m *DeleteGatewayRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteGatewayRequest) ProtoMessage()               {}
-func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{56} }
+func (*DeleteGatewayRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{58} }
 
 func (m *DeleteGatewayRequest) GetMac() []byte {
 	if m != nil {
@@ -1403,12 +1681,15 @@ func (m *DeleteGatewayRequest) GetMac() []byte {
 type GenerateGatewayTokenRequest struct {
 	// MAC address of the gateway.
 	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Time-to-live of the token in seconds (optional, when 0 the
+	// configured default is used).
+	Ttl int64 `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
 }
 
 func (m *GenerateGatewayTokenRequest) Reset()                    { *m = GenerateGatewayTokenRequest{} }
 func (m *GenerateGatewayTokenRequest) String() string            { return proto.CompactTextString(m) }
 func (*GenerateGatewayTokenRequest) ProtoMessage()               {}
-func (*GenerateGatewayTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{57} }
+func (*GenerateGatewayTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{59} }
 
 func (m *GenerateGatewayTokenRequest) GetMac() []byte {
 	if m != nil {
@@ -1417,15 +1698,27 @@ func (m *GenerateGatewayTokenRequest) GetMac() []byte {
 	return nil
 }
 
+func (m *GenerateGatewayTokenRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
 type GenerateGatewayTokenResponse struct {
 	// Generated token.
 	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// ID of the generated token.
+	Id string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	// Timestamp until which the token is valid (RFC3339, empty when the
+	// token does not expire).
+	ExpiresAt string `protobuf:"bytes,3,opt,name=expiresAt" json:"expiresAt,omitempty"`
 }
 
 func (m *GenerateGatewayTokenResponse) Reset()                    { *m = GenerateGatewayTokenResponse{} }
 func (m *GenerateGatewayTokenResponse) String() string            { return proto.CompactTextString(m) }
 func (*GenerateGatewayTokenResponse) ProtoMessage()               {}
-func (*GenerateGatewayTokenResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{58} }
+func (*GenerateGatewayTokenResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{60} }
 
 func (m *GenerateGatewayTokenResponse) GetToken() string {
 	if m != nil {
@@ -1434,13 +1727,150 @@ func (m *GenerateGatewayTokenResponse) GetToken() string {
 	return ""
 }
 
+func (m *GenerateGatewayTokenResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GenerateGatewayTokenResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+type GatewayToken struct {
+	// ID of the token.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// ID of the key used for signing the token (empty when signed with
+	// the default secret).
+	KeyID string `protobuf:"bytes,2,opt,name=keyID" json:"keyID,omitempty"`
+	// Created at timestamp (RFC3339).
+	CreatedAt string `protobuf:"bytes,3,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Timestamp until which the token is valid (RFC3339, empty when the
+	// token does not expire).
+	ExpiresAt string `protobuf:"bytes,4,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Timestamp when the token was revoked (RFC3339, empty when not revoked).
+	RevokedAt string `protobuf:"bytes,5,opt,name=revokedAt" json:"revokedAt,omitempty"`
+}
+
+func (m *GatewayToken) Reset()                    { *m = GatewayToken{} }
+func (m *GatewayToken) String() string            { return proto.CompactTextString(m) }
+func (*GatewayToken) ProtoMessage()               {}
+func (*GatewayToken) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{61} }
+
+func (m *GatewayToken) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GatewayToken) GetKeyID() string {
+	if m != nil {
+		return m.KeyID
+	}
+	return ""
+}
+
+func (m *GatewayToken) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GatewayToken) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *GatewayToken) GetRevokedAt() string {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return ""
+}
+
+type ListGatewayTokensRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+}
+
+func (m *ListGatewayTokensRequest) Reset()                    { *m = ListGatewayTokensRequest{} }
+func (m *ListGatewayTokensRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListGatewayTokensRequest) ProtoMessage()               {}
+func (*ListGatewayTokensRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{62} }
+
+func (m *ListGatewayTokensRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+type ListGatewayTokensResponse struct {
+	// Tokens issued to the gateway (most recent first).
+	Result []*GatewayToken `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *ListGatewayTokensResponse) Reset()                    { *m = ListGatewayTokensResponse{} }
+func (m *ListGatewayTokensResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListGatewayTokensResponse) ProtoMessage()               {}
+func (*ListGatewayTokensResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{63} }
+
+func (m *ListGatewayTokensResponse) GetResult() []*GatewayToken {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type RevokeGatewayTokenRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// ID of the token.
+	Id string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *RevokeGatewayTokenRequest) Reset()                    { *m = RevokeGatewayTokenRequest{} }
+func (m *RevokeGatewayTokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeGatewayTokenRequest) ProtoMessage()               {}
+func (*RevokeGatewayTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{64} }
+
+func (m *RevokeGatewayTokenRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *RevokeGatewayTokenRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type RevokeGatewayTokenResponse struct {
+}
+
+func (m *RevokeGatewayTokenResponse) Reset()                    { *m = RevokeGatewayTokenResponse{} }
+func (m *RevokeGatewayTokenResponse) String() string            { return proto.CompactTextString(m) }
+func (*RevokeGatewayTokenResponse) ProtoMessage()               {}
+func (*RevokeGatewayTokenResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{65} }
+
 type DeleteGatewayResponse struct {
 }
 
 func (m *DeleteGatewayResponse) Reset()                    { *m = DeleteGatewayResponse{} }
 func (m *DeleteGatewayResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteGatewayResponse) ProtoMessage()               {}
-func (*DeleteGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{59} }
+func (*DeleteGatewayResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{66} }
 
 type GatewayStats struct {
 	// Timestamp of the (aggregated) measurement.
@@ -1453,12 +1883,33 @@ type GatewayStats struct {
 	TxPacketsReceived int32 `protobuf:"varint,4,opt,name=txPacketsReceived" json:"txPacketsReceived,omitempty"`
 	// Packets transmitted by the gateway.
 	TxPacketsEmitted int32 `protobuf:"varint,5,opt,name=txPacketsEmitted" json:"txPacketsEmitted,omitempty"`
+	// Frames (with a valid CRC) received by the network-server from the
+	// gateway.
+	RxFrames int32 `protobuf:"varint,6,opt,name=rxFrames" json:"rxFrames,omitempty"`
+	// Frames with an invalid CRC received by the network-server from the
+	// gateway.
+	RxFramesCRCError int32 `protobuf:"varint,7,opt,name=rxFramesCRCError" json:"rxFramesCRCError,omitempty"`
+	// Ratio of the frames with an invalid CRC (0 - 1).
+	RxCRCErrorRatio float64 `protobuf:"fixed64,8,opt,name=rxCRCErrorRatio" json:"rxCRCErrorRatio,omitempty"`
+	// Frames received per frequency and data-rate.
+	RxFramesPerChannel []*GatewayFrameStats `protobuf:"bytes,9,rep,name=rxFramesPerChannel" json:"rxFramesPerChannel,omitempty"`
+	// RSSI (dBm) of the received frames.
+	Rssi *GatewaySignalStats `protobuf:"bytes,10,opt,name=rssi" json:"rssi,omitempty"`
+	// SNR (dB) of the received LoRa frames.
+	Snr *GatewaySignalStats `protobuf:"bytes,11,opt,name=snr" json:"snr,omitempty"`
+	// Unique devices heard (DevAddr for data frames, DevEUI for
+	// join-requests).
+	UniqueDevices int32 `protobuf:"varint,12,opt,name=uniqueDevices" json:"uniqueDevices,omitempty"`
+	// Frames scheduled for transmission by the network-server.
+	TxFramesScheduled int32 `protobuf:"varint,13,opt,name=txFramesScheduled" json:"txFramesScheduled,omitempty"`
+	// Frames acknowledged as transmitted by the gateway.
+	TxFramesAcked int32 `protobuf:"varint,14,opt,name=txFramesAcked" json:"txFramesAcked,omitempty"`
 }
 
 func (m *GatewayStats) Reset()                    { *m = GatewayStats{} }
 func (m *GatewayStats) String() string            { return proto.CompactTextString(m) }
 func (*GatewayStats) ProtoMessage()               {}
-func (*GatewayStats) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{60} }
+func (*GatewayStats) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{67} }
 
 func (m *GatewayStats) GetTimestamp() string {
 	if m != nil {
@@ -1495,48 +1946,190 @@ func (m *GatewayStats) GetTxPacketsEmitted() int32 {
 	return 0
 }
 
-type GetGatewayStatsRequest struct {
-	// MAC address of the gateway.
-	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
-	// Aggregation interval.
-	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,enum=ns.AggregationInterval" json:"interval,omitempty"`
-	// Timestamp to start from.
-	StartTimestamp string `protobuf:"bytes,3,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
-	// Timestamp until to get from.
-	EndTimestamp string `protobuf:"bytes,4,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+func (m *GatewayStats) GetRxFrames() int32 {
+	if m != nil {
+		return m.RxFrames
+	}
+	return 0
 }
 
-func (m *GetGatewayStatsRequest) Reset()                    { *m = GetGatewayStatsRequest{} }
-func (m *GetGatewayStatsRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetGatewayStatsRequest) ProtoMessage()               {}
-func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{61} }
+func (m *GatewayStats) GetRxFramesCRCError() int32 {
+	if m != nil {
+		return m.RxFramesCRCError
+	}
+	return 0
+}
 
-func (m *GetGatewayStatsRequest) GetMac() []byte {
+func (m *GatewayStats) GetRxCRCErrorRatio() float64 {
 	if m != nil {
-		return m.Mac
+		return m.RxCRCErrorRatio
 	}
-	return nil
+	return 0
 }
 
-func (m *GetGatewayStatsRequest) GetInterval() AggregationInterval {
+func (m *GatewayStats) GetRxFramesPerChannel() []*GatewayFrameStats {
 	if m != nil {
-		return m.Interval
+		return m.RxFramesPerChannel
 	}
-	return AggregationInterval_SECOND
+	return nil
 }
 
-func (m *GetGatewayStatsRequest) GetStartTimestamp() string {
+func (m *GatewayStats) GetRssi() *GatewaySignalStats {
 	if m != nil {
-		return m.StartTimestamp
+		return m.Rssi
 	}
-	return ""
+	return nil
 }
 
-func (m *GetGatewayStatsRequest) GetEndTimestamp() string {
+func (m *GatewayStats) GetSnr() *GatewaySignalStats {
 	if m != nil {
-		return m.EndTimestamp
+		return m.Snr
 	}
-	return ""
+	return nil
+}
+
+func (m *GatewayStats) GetUniqueDevices() int32 {
+	if m != nil {
+		return m.UniqueDevices
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxFramesScheduled() int32 {
+	if m != nil {
+		return m.TxFramesScheduled
+	}
+	return 0
+}
+
+func (m *GatewayStats) GetTxFramesAcked() int32 {
+	if m != nil {
+		return m.TxFramesAcked
+	}
+	return 0
+}
+
+type GatewayFrameStats struct {
+	// Frequency (Hz).
+	Frequency uint32 `protobuf:"varint,1,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate.
+	Dr uint32 `protobuf:"varint,2,opt,name=dr" json:"dr,omitempty"`
+	// Frames received.
+	RxFrames int32 `protobuf:"varint,3,opt,name=rxFrames" json:"rxFrames,omitempty"`
+}
+
+func (m *GatewayFrameStats) Reset()                    { *m = GatewayFrameStats{} }
+func (m *GatewayFrameStats) String() string            { return proto.CompactTextString(m) }
+func (*GatewayFrameStats) ProtoMessage()               {}
+func (*GatewayFrameStats) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{68} }
+
+func (m *GatewayFrameStats) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *GatewayFrameStats) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *GatewayFrameStats) GetRxFrames() int32 {
+	if m != nil {
+		return m.RxFrames
+	}
+	return 0
+}
+
+type GatewaySignalStats struct {
+	// Average.
+	Average float64 `protobuf:"fixed64,1,opt,name=average" json:"average,omitempty"`
+	// 10th percentile (1 dB resolution).
+	P10 float64 `protobuf:"fixed64,2,opt,name=p10" json:"p10,omitempty"`
+	// 50th percentile / median (1 dB resolution).
+	P50 float64 `protobuf:"fixed64,3,opt,name=p50" json:"p50,omitempty"`
+	// 90th percentile (1 dB resolution).
+	P90 float64 `protobuf:"fixed64,4,opt,name=p90" json:"p90,omitempty"`
+}
+
+func (m *GatewaySignalStats) Reset()                    { *m = GatewaySignalStats{} }
+func (m *GatewaySignalStats) String() string            { return proto.CompactTextString(m) }
+func (*GatewaySignalStats) ProtoMessage()               {}
+func (*GatewaySignalStats) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{69} }
+
+func (m *GatewaySignalStats) GetAverage() float64 {
+	if m != nil {
+		return m.Average
+	}
+	return 0
+}
+
+func (m *GatewaySignalStats) GetP10() float64 {
+	if m != nil {
+		return m.P10
+	}
+	return 0
+}
+
+func (m *GatewaySignalStats) GetP50() float64 {
+	if m != nil {
+		return m.P50
+	}
+	return 0
+}
+
+func (m *GatewaySignalStats) GetP90() float64 {
+	if m != nil {
+		return m.P90
+	}
+	return 0
+}
+
+type GetGatewayStatsRequest struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Aggregation interval.
+	Interval AggregationInterval `protobuf:"varint,2,opt,name=interval,enum=ns.AggregationInterval" json:"interval,omitempty"`
+	// Timestamp to start from.
+	StartTimestamp string `protobuf:"bytes,3,opt,name=startTimestamp" json:"startTimestamp,omitempty"`
+	// Timestamp until to get from.
+	EndTimestamp string `protobuf:"bytes,4,opt,name=endTimestamp" json:"endTimestamp,omitempty"`
+}
+
+func (m *GetGatewayStatsRequest) Reset()                    { *m = GetGatewayStatsRequest{} }
+func (m *GetGatewayStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayStatsRequest) ProtoMessage()               {}
+func (*GetGatewayStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{70} }
+
+func (m *GetGatewayStatsRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetGatewayStatsRequest) GetInterval() AggregationInterval {
+	if m != nil {
+		return m.Interval
+	}
+	return AggregationInterval_SECOND
+}
+
+func (m *GetGatewayStatsRequest) GetStartTimestamp() string {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return ""
+}
+
+func (m *GetGatewayStatsRequest) GetEndTimestamp() string {
+	if m != nil {
+		return m.EndTimestamp
+	}
+	return ""
 }
 
 type GetGatewayStatsResponse struct {
@@ -1546,7 +2139,7 @@ type GetGatewayStatsResponse struct {
 func (m *GetGatewayStatsResponse) Reset()                    { *m = GetGatewayStatsResponse{} }
 func (m *GetGatewayStatsResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetGatewayStatsResponse) ProtoMessage()               {}
-func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{62} }
+func (*GetGatewayStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{71} }
 
 func (m *GetGatewayStatsResponse) GetResult() []*GatewayStats {
 	if m != nil {
@@ -1567,7 +2160,7 @@ type GetFrameLogsForDevEUIRequest struct {
 func (m *GetFrameLogsForDevEUIRequest) Reset()                    { *m = GetFrameLogsForDevEUIRequest{} }
 func (m *GetFrameLogsForDevEUIRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetFrameLogsForDevEUIRequest) ProtoMessage()               {}
-func (*GetFrameLogsForDevEUIRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{63} }
+func (*GetFrameLogsForDevEUIRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{72} }
 
 func (m *GetFrameLogsForDevEUIRequest) GetDevEUI() []byte {
 	if m != nil {
@@ -1600,7 +2193,7 @@ type GetFrameLogsResponse struct {
 func (m *GetFrameLogsResponse) Reset()                    { *m = GetFrameLogsResponse{} }
 func (m *GetFrameLogsResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetFrameLogsResponse) ProtoMessage()               {}
-func (*GetFrameLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{64} }
+func (*GetFrameLogsResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{73} }
 
 func (m *GetFrameLogsResponse) GetTotalCount() int32 {
 	if m != nil {
@@ -1630,7 +2223,7 @@ type FrameLog struct {
 func (m *FrameLog) Reset()                    { *m = FrameLog{} }
 func (m *FrameLog) String() string            { return proto.CompactTextString(m) }
 func (*FrameLog) ProtoMessage()               {}
-func (*FrameLog) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{65} }
+func (*FrameLog) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{74} }
 
 func (m *FrameLog) GetCreatedAt() string {
 	if m != nil {
@@ -1670,7 +2263,7 @@ type DataRate struct {
 func (m *DataRate) Reset()                    { *m = DataRate{} }
 func (m *DataRate) String() string            { return proto.CompactTextString(m) }
 func (*DataRate) ProtoMessage()               {}
-func (*DataRate) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{66} }
+func (*DataRate) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{75} }
 
 func (m *DataRate) GetModulation() string {
 	if m != nil {
@@ -1719,12 +2312,26 @@ type RXInfo struct {
 	DataRate *DataRate `protobuf:"bytes,8,opt,name=dataRate" json:"dataRate,omitempty"`
 	// Gateway MAC.
 	Mac []byte `protobuf:"bytes,9,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Concentrator board used for RX.
+	Board uint32 `protobuf:"varint,10,opt,name=board" json:"board,omitempty"`
+	// Antenna used for RX.
+	Antenna uint32 `protobuf:"varint,11,opt,name=antenna" json:"antenna,omitempty"`
+	// Type of the fine-timestamp (PLAIN or ENCRYPTED, empty when not available).
+	FineTimestampType string `protobuf:"bytes,12,opt,name=fineTimestampType" json:"fineTimestampType,omitempty"`
+	// Plain fine-timestamp (nanoseconds since the last GPS second).
+	FineTimestamp uint32 `protobuf:"varint,13,opt,name=fineTimestamp" json:"fineTimestamp,omitempty"`
+	// Encrypted fine-timestamp.
+	EncryptedFineTimestamp []byte `protobuf:"bytes,14,opt,name=encryptedFineTimestamp,proto3" json:"encryptedFineTimestamp,omitempty"`
+	// Index of the key used to encrypt the fine-timestamp.
+	FineTimestampKeyID uint32 `protobuf:"varint,15,opt,name=fineTimestampKeyID" json:"fineTimestampKeyID,omitempty"`
+	// Signal RSSI (0 when not reported by the gateway).
+	SignalRSSI int32 `protobuf:"varint,16,opt,name=signalRSSI" json:"signalRSSI,omitempty"`
 }
 
 func (m *RXInfo) Reset()                    { *m = RXInfo{} }
 func (m *RXInfo) String() string            { return proto.CompactTextString(m) }
 func (*RXInfo) ProtoMessage()               {}
-func (*RXInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{67} }
+func (*RXInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{76} }
 
 func (m *RXInfo) GetChannel() int32 {
 	if m != nil {
@@ -1789,6 +2396,55 @@ func (m *RXInfo) GetMac() []byte {
 	return nil
 }
 
+func (m *RXInfo) GetBoard() uint32 {
+	if m != nil {
+		return m.Board
+	}
+	return 0
+}
+
+func (m *RXInfo) GetAntenna() uint32 {
+	if m != nil {
+		return m.Antenna
+	}
+	return 0
+}
+
+func (m *RXInfo) GetFineTimestampType() string {
+	if m != nil {
+		return m.FineTimestampType
+	}
+	return ""
+}
+
+func (m *RXInfo) GetFineTimestamp() uint32 {
+	if m != nil {
+		return m.FineTimestamp
+	}
+	return 0
+}
+
+func (m *RXInfo) GetEncryptedFineTimestamp() []byte {
+	if m != nil {
+		return m.EncryptedFineTimestamp
+	}
+	return nil
+}
+
+func (m *RXInfo) GetFineTimestampKeyID() uint32 {
+	if m != nil {
+		return m.FineTimestampKeyID
+	}
+	return 0
+}
+
+func (m *RXInfo) GetSignalRSSI() int32 {
+	if m != nil {
+		return m.SignalRSSI
+	}
+	return 0
+}
+
 type TXInfo struct {
 	// Code-rate.
 	CodeRate string `protobuf:"bytes,1,opt,name=codeRate" json:"codeRate,omitempty"`
@@ -1809,7 +2465,7 @@ type TXInfo struct {
 func (m *TXInfo) Reset()                    { *m = TXInfo{} }
 func (m *TXInfo) String() string            { return proto.CompactTextString(m) }
 func (*TXInfo) ProtoMessage()               {}
-func (*TXInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{68} }
+func (*TXInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{77} }
 
 func (m *TXInfo) GetCodeRate() string {
 	if m != nil {
@@ -1872,7 +2528,7 @@ func (m *CreateChannelConfigurationRequest) Reset()         { *m = CreateChannel
 func (m *CreateChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateChannelConfigurationRequest) ProtoMessage()    {}
 func (*CreateChannelConfigurationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{69}
+	return fileDescriptor1, []int{78}
 }
 
 func (m *CreateChannelConfigurationRequest) GetName() string {
@@ -1898,7 +2554,7 @@ func (m *CreateChannelConfigurationResponse) Reset()         { *m = CreateChanne
 func (m *CreateChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateChannelConfigurationResponse) ProtoMessage()    {}
 func (*CreateChannelConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{70}
+	return fileDescriptor1, []int{79}
 }
 
 func (m *CreateChannelConfigurationResponse) GetId() int64 {
@@ -1913,10 +2569,12 @@ type GetChannelConfigurationRequest struct {
 	Id int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 }
 
-func (m *GetChannelConfigurationRequest) Reset()                    { *m = GetChannelConfigurationRequest{} }
-func (m *GetChannelConfigurationRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetChannelConfigurationRequest) ProtoMessage()               {}
-func (*GetChannelConfigurationRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{71} }
+func (m *GetChannelConfigurationRequest) Reset()         { *m = GetChannelConfigurationRequest{} }
+func (m *GetChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetChannelConfigurationRequest) ProtoMessage()    {}
+func (*GetChannelConfigurationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{80}
+}
 
 func (m *GetChannelConfigurationRequest) GetId() int64 {
 	if m != nil {
@@ -1943,7 +2601,7 @@ func (m *GetChannelConfigurationResponse) Reset()         { *m = GetChannelConfi
 func (m *GetChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
 func (*GetChannelConfigurationResponse) ProtoMessage()    {}
 func (*GetChannelConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{72}
+	return fileDescriptor1, []int{81}
 }
 
 func (m *GetChannelConfigurationResponse) GetId() int64 {
@@ -1995,7 +2653,7 @@ func (m *UpdateChannelConfigurationRequest) Reset()         { *m = UpdateChannel
 func (m *UpdateChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateChannelConfigurationRequest) ProtoMessage()    {}
 func (*UpdateChannelConfigurationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{73}
+	return fileDescriptor1, []int{82}
 }
 
 func (m *UpdateChannelConfigurationRequest) GetId() int64 {
@@ -2026,7 +2684,7 @@ func (m *UpdateChannelConfigurationResponse) Reset()         { *m = UpdateChanne
 func (m *UpdateChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateChannelConfigurationResponse) ProtoMessage()    {}
 func (*UpdateChannelConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{74}
+	return fileDescriptor1, []int{83}
 }
 
 type DeleteChannelConfigurationRequest struct {
@@ -2038,7 +2696,7 @@ func (m *DeleteChannelConfigurationRequest) Reset()         { *m = DeleteChannel
 func (m *DeleteChannelConfigurationRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteChannelConfigurationRequest) ProtoMessage()    {}
 func (*DeleteChannelConfigurationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{75}
+	return fileDescriptor1, []int{84}
 }
 
 func (m *DeleteChannelConfigurationRequest) GetId() int64 {
@@ -2055,7 +2713,7 @@ func (m *DeleteChannelConfigurationResponse) Reset()         { *m = DeleteChanne
 func (m *DeleteChannelConfigurationResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteChannelConfigurationResponse) ProtoMessage()    {}
 func (*DeleteChannelConfigurationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{76}
+	return fileDescriptor1, []int{85}
 }
 
 type ListChannelConfigurationsRequest struct {
@@ -2065,7 +2723,7 @@ func (m *ListChannelConfigurationsRequest) Reset()         { *m = ListChannelCon
 func (m *ListChannelConfigurationsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListChannelConfigurationsRequest) ProtoMessage()    {}
 func (*ListChannelConfigurationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{77}
+	return fileDescriptor1, []int{86}
 }
 
 type ListChannelConfigurationsResponse struct {
@@ -2077,7 +2735,7 @@ func (m *ListChannelConfigurationsResponse) Reset()         { *m = ListChannelCo
 func (m *ListChannelConfigurationsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListChannelConfigurationsResponse) ProtoMessage()    {}
 func (*ListChannelConfigurationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{78}
+	return fileDescriptor1, []int{87}
 }
 
 func (m *ListChannelConfigurationsResponse) GetResult() []*GetChannelConfigurationResponse {
@@ -2105,7 +2763,7 @@ type CreateExtraChannelRequest struct {
 func (m *CreateExtraChannelRequest) Reset()                    { *m = CreateExtraChannelRequest{} }
 func (m *CreateExtraChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateExtraChannelRequest) ProtoMessage()               {}
-func (*CreateExtraChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{79} }
+func (*CreateExtraChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{88} }
 
 func (m *CreateExtraChannelRequest) GetChannelConfigurationID() int64 {
 	if m != nil {
@@ -2157,7 +2815,7 @@ type CreateExtraChannelResponse struct {
 func (m *CreateExtraChannelResponse) Reset()                    { *m = CreateExtraChannelResponse{} }
 func (m *CreateExtraChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateExtraChannelResponse) ProtoMessage()               {}
-func (*CreateExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{80} }
+func (*CreateExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{89} }
 
 func (m *CreateExtraChannelResponse) GetId() int64 {
 	if m != nil {
@@ -2186,7 +2844,7 @@ type UpdateExtraChannelRequest struct {
 func (m *UpdateExtraChannelRequest) Reset()                    { *m = UpdateExtraChannelRequest{} }
 func (m *UpdateExtraChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateExtraChannelRequest) ProtoMessage()               {}
-func (*UpdateExtraChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{81} }
+func (*UpdateExtraChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{90} }
 
 func (m *UpdateExtraChannelRequest) GetId() int64 {
 	if m != nil {
@@ -2243,7 +2901,7 @@ type UpdateExtraChannelResponse struct {
 func (m *UpdateExtraChannelResponse) Reset()                    { *m = UpdateExtraChannelResponse{} }
 func (m *UpdateExtraChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*UpdateExtraChannelResponse) ProtoMessage()               {}
-func (*UpdateExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{82} }
+func (*UpdateExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{91} }
 
 type DeleteExtraChannelRequest struct {
 	// ID of the extra channel.
@@ -2253,7 +2911,7 @@ type DeleteExtraChannelRequest struct {
 func (m *DeleteExtraChannelRequest) Reset()                    { *m = DeleteExtraChannelRequest{} }
 func (m *DeleteExtraChannelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteExtraChannelRequest) ProtoMessage()               {}
-func (*DeleteExtraChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{83} }
+func (*DeleteExtraChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{92} }
 
 func (m *DeleteExtraChannelRequest) GetId() int64 {
 	if m != nil {
@@ -2268,7 +2926,7 @@ type DeleteExtraChannelResponse struct {
 func (m *DeleteExtraChannelResponse) Reset()                    { *m = DeleteExtraChannelResponse{} }
 func (m *DeleteExtraChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteExtraChannelResponse) ProtoMessage()               {}
-func (*DeleteExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{84} }
+func (*DeleteExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{93} }
 
 type GetExtraChannelResponse struct {
 	// ID of the extra channel.
@@ -2294,7 +2952,7 @@ type GetExtraChannelResponse struct {
 func (m *GetExtraChannelResponse) Reset()                    { *m = GetExtraChannelResponse{} }
 func (m *GetExtraChannelResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetExtraChannelResponse) ProtoMessage()               {}
-func (*GetExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{85} }
+func (*GetExtraChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{94} }
 
 func (m *GetExtraChannelResponse) GetId() int64 {
 	if m != nil {
@@ -2372,7 +3030,7 @@ func (m *GetExtraChannelsForChannelConfigurationIDRequest) String() string {
 }
 func (*GetExtraChannelsForChannelConfigurationIDRequest) ProtoMessage() {}
 func (*GetExtraChannelsForChannelConfigurationIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{86}
+	return fileDescriptor1, []int{95}
 }
 
 func (m *GetExtraChannelsForChannelConfigurationIDRequest) GetId() int64 {
@@ -2395,7 +3053,7 @@ func (m *GetExtraChannelsForChannelConfigurationIDResponse) String() string {
 }
 func (*GetExtraChannelsForChannelConfigurationIDResponse) ProtoMessage() {}
 func (*GetExtraChannelsForChannelConfigurationIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{87}
+	return fileDescriptor1, []int{96}
 }
 
 func (m *GetExtraChannelsForChannelConfigurationIDResponse) GetResult() []*GetExtraChannelResponse {
@@ -2415,7 +3073,7 @@ func (m *MigrateNodeToDeviceSessionRequest) Reset()         { *m = MigrateNodeTo
 func (m *MigrateNodeToDeviceSessionRequest) String() string { return proto.CompactTextString(m) }
 func (*MigrateNodeToDeviceSessionRequest) ProtoMessage()    {}
 func (*MigrateNodeToDeviceSessionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{88}
+	return fileDescriptor1, []int{97}
 }
 
 func (m *MigrateNodeToDeviceSessionRequest) GetDevEUI() []byte {
@@ -2446,1408 +3104,3117 @@ func (m *MigrateNodeToDeviceSessionResponse) Reset()         { *m = MigrateNodeT
 func (m *MigrateNodeToDeviceSessionResponse) String() string { return proto.CompactTextString(m) }
 func (*MigrateNodeToDeviceSessionResponse) ProtoMessage()    {}
 func (*MigrateNodeToDeviceSessionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor1, []int{89}
+	return fileDescriptor1, []int{98}
+}
+
+type MulticastGroup struct {
+	// ID of the multicast-group (UUID).
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Multicast address (4 bytes).
+	McAddr []byte `protobuf:"bytes,2,opt,name=mcAddr,proto3" json:"mcAddr,omitempty"`
+	// Multicast network session key (16 bytes).
+	McNwkSKey []byte `protobuf:"bytes,3,opt,name=mcNwkSKey,proto3" json:"mcNwkSKey,omitempty"`
+	// Downlink frame-counter.
+	FCnt uint32 `protobuf:"varint,4,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Data-rate to use for transmission.
+	Dr uint32 `protobuf:"varint,5,opt,name=dr" json:"dr,omitempty"`
+	// Frequency (Hz) to use for transmission. When set to 0, the
+	// RX2 frequency of the band will be used.
+	Frequency uint32 `protobuf:"varint,6,opt,name=frequency" json:"frequency,omitempty"`
+	// Service-profile ID.
+	ServiceProfileID string `protobuf:"bytes,7,opt,name=serviceProfileID" json:"serviceProfileID,omitempty"`
+}
+
+func (m *MulticastGroup) Reset()                    { *m = MulticastGroup{} }
+func (m *MulticastGroup) String() string            { return proto.CompactTextString(m) }
+func (*MulticastGroup) ProtoMessage()               {}
+func (*MulticastGroup) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{99} }
+
+func (m *MulticastGroup) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func init() {
-	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
-	proto.RegisterType((*CreateServiceProfileResponse)(nil), "ns.CreateServiceProfileResponse")
-	proto.RegisterType((*GetServiceProfileRequest)(nil), "ns.GetServiceProfileRequest")
-	proto.RegisterType((*GetServiceProfileResponse)(nil), "ns.GetServiceProfileResponse")
-	proto.RegisterType((*UpdateServiceProfileRequest)(nil), "ns.UpdateServiceProfileRequest")
-	proto.RegisterType((*UpdateServiceProfileResponse)(nil), "ns.UpdateServiceProfileResponse")
-	proto.RegisterType((*DeleteServiceProfileRequest)(nil), "ns.DeleteServiceProfileRequest")
-	proto.RegisterType((*DeleteServiceProfileResponse)(nil), "ns.DeleteServiceProfileResponse")
-	proto.RegisterType((*RoutingProfile)(nil), "ns.RoutingProfile")
-	proto.RegisterType((*CreateRoutingProfileRequest)(nil), "ns.CreateRoutingProfileRequest")
-	proto.RegisterType((*CreateRoutingProfileResponse)(nil), "ns.CreateRoutingProfileResponse")
-	proto.RegisterType((*GetRoutingProfileRequest)(nil), "ns.GetRoutingProfileRequest")
-	proto.RegisterType((*GetRoutingProfileResponse)(nil), "ns.GetRoutingProfileResponse")
-	proto.RegisterType((*UpdateRoutingProfileRequest)(nil), "ns.UpdateRoutingProfileRequest")
-	proto.RegisterType((*UpdateRoutingProfileResponse)(nil), "ns.UpdateRoutingProfileResponse")
-	proto.RegisterType((*DeleteRoutingProfileRequest)(nil), "ns.DeleteRoutingProfileRequest")
-	proto.RegisterType((*DeleteRoutingProfileResponse)(nil), "ns.DeleteRoutingProfileResponse")
-	proto.RegisterType((*CreateDeviceProfileRequest)(nil), "ns.CreateDeviceProfileRequest")
-	proto.RegisterType((*CreateDeviceProfileResponse)(nil), "ns.CreateDeviceProfileResponse")
-	proto.RegisterType((*GetDeviceProfileRequest)(nil), "ns.GetDeviceProfileRequest")
-	proto.RegisterType((*GetDeviceProfileResponse)(nil), "ns.GetDeviceProfileResponse")
-	proto.RegisterType((*UpdateDeviceProfileRequest)(nil), "ns.UpdateDeviceProfileRequest")
-	proto.RegisterType((*UpdateDeviceProfileResponse)(nil), "ns.UpdateDeviceProfileResponse")
-	proto.RegisterType((*DeleteDeviceProfileRequest)(nil), "ns.DeleteDeviceProfileRequest")
-	proto.RegisterType((*DeleteDeviceProfileResponse)(nil), "ns.DeleteDeviceProfileResponse")
-	proto.RegisterType((*Device)(nil), "ns.Device")
-	proto.RegisterType((*CreateDeviceRequest)(nil), "ns.CreateDeviceRequest")
-	proto.RegisterType((*CreateDeviceResponse)(nil), "ns.CreateDeviceResponse")
-	proto.RegisterType((*GetDeviceRequest)(nil), "ns.GetDeviceRequest")
-	proto.RegisterType((*GetDeviceResponse)(nil), "ns.GetDeviceResponse")
-	proto.RegisterType((*UpdateDeviceRequest)(nil), "ns.UpdateDeviceRequest")
-	proto.RegisterType((*UpdateDeviceResponse)(nil), "ns.UpdateDeviceResponse")
-	proto.RegisterType((*DeleteDeviceRequest)(nil), "ns.DeleteDeviceRequest")
-	proto.RegisterType((*DeleteDeviceResponse)(nil), "ns.DeleteDeviceResponse")
-	proto.RegisterType((*ActivateDeviceRequest)(nil), "ns.ActivateDeviceRequest")
-	proto.RegisterType((*ActivateDeviceResponse)(nil), "ns.ActivateDeviceResponse")
-	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
-	proto.RegisterType((*DeactivateDeviceResponse)(nil), "ns.DeactivateDeviceResponse")
-	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
-	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
-	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "ns.GetRandomDevAddrRequest")
-	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
-	proto.RegisterType((*EnqueueDownlinkMACCommandRequest)(nil), "ns.EnqueueDownlinkMACCommandRequest")
-	proto.RegisterType((*EnqueueDownlinkMACCommandResponse)(nil), "ns.EnqueueDownlinkMACCommandResponse")
-	proto.RegisterType((*SendDownlinkDataRequest)(nil), "ns.SendDownlinkDataRequest")
-	proto.RegisterType((*SendDownlinkDataResponse)(nil), "ns.SendDownlinkDataResponse")
-	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
-	proto.RegisterType((*SendProprietaryPayloadResponse)(nil), "ns.SendProprietaryPayloadResponse")
-	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
-	proto.RegisterType((*CreateGatewayResponse)(nil), "ns.CreateGatewayResponse")
-	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
-	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
-	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
-	proto.RegisterType((*UpdateGatewayResponse)(nil), "ns.UpdateGatewayResponse")
-	proto.RegisterType((*ListGatewayRequest)(nil), "ns.ListGatewayRequest")
-	proto.RegisterType((*ListGatewayResponse)(nil), "ns.ListGatewayResponse")
-	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
-	proto.RegisterType((*GenerateGatewayTokenRequest)(nil), "ns.GenerateGatewayTokenRequest")
-	proto.RegisterType((*GenerateGatewayTokenResponse)(nil), "ns.GenerateGatewayTokenResponse")
-	proto.RegisterType((*DeleteGatewayResponse)(nil), "ns.DeleteGatewayResponse")
-	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
-	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
-	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
-	proto.RegisterType((*GetFrameLogsForDevEUIRequest)(nil), "ns.GetFrameLogsForDevEUIRequest")
-	proto.RegisterType((*GetFrameLogsResponse)(nil), "ns.GetFrameLogsResponse")
-	proto.RegisterType((*FrameLog)(nil), "ns.FrameLog")
-	proto.RegisterType((*DataRate)(nil), "ns.DataRate")
-	proto.RegisterType((*RXInfo)(nil), "ns.RXInfo")
-	proto.RegisterType((*TXInfo)(nil), "ns.TXInfo")
-	proto.RegisterType((*CreateChannelConfigurationRequest)(nil), "ns.CreateChannelConfigurationRequest")
-	proto.RegisterType((*CreateChannelConfigurationResponse)(nil), "ns.CreateChannelConfigurationResponse")
-	proto.RegisterType((*GetChannelConfigurationRequest)(nil), "ns.GetChannelConfigurationRequest")
-	proto.RegisterType((*GetChannelConfigurationResponse)(nil), "ns.GetChannelConfigurationResponse")
-	proto.RegisterType((*UpdateChannelConfigurationRequest)(nil), "ns.UpdateChannelConfigurationRequest")
-	proto.RegisterType((*UpdateChannelConfigurationResponse)(nil), "ns.UpdateChannelConfigurationResponse")
-	proto.RegisterType((*DeleteChannelConfigurationRequest)(nil), "ns.DeleteChannelConfigurationRequest")
-	proto.RegisterType((*DeleteChannelConfigurationResponse)(nil), "ns.DeleteChannelConfigurationResponse")
-	proto.RegisterType((*ListChannelConfigurationsRequest)(nil), "ns.ListChannelConfigurationsRequest")
-	proto.RegisterType((*ListChannelConfigurationsResponse)(nil), "ns.ListChannelConfigurationsResponse")
-	proto.RegisterType((*CreateExtraChannelRequest)(nil), "ns.CreateExtraChannelRequest")
-	proto.RegisterType((*CreateExtraChannelResponse)(nil), "ns.CreateExtraChannelResponse")
-	proto.RegisterType((*UpdateExtraChannelRequest)(nil), "ns.UpdateExtraChannelRequest")
-	proto.RegisterType((*UpdateExtraChannelResponse)(nil), "ns.UpdateExtraChannelResponse")
-	proto.RegisterType((*DeleteExtraChannelRequest)(nil), "ns.DeleteExtraChannelRequest")
-	proto.RegisterType((*DeleteExtraChannelResponse)(nil), "ns.DeleteExtraChannelResponse")
-	proto.RegisterType((*GetExtraChannelResponse)(nil), "ns.GetExtraChannelResponse")
-	proto.RegisterType((*GetExtraChannelsForChannelConfigurationIDRequest)(nil), "ns.GetExtraChannelsForChannelConfigurationIDRequest")
-	proto.RegisterType((*GetExtraChannelsForChannelConfigurationIDResponse)(nil), "ns.GetExtraChannelsForChannelConfigurationIDResponse")
-	proto.RegisterType((*MigrateNodeToDeviceSessionRequest)(nil), "ns.MigrateNodeToDeviceSessionRequest")
-	proto.RegisterType((*MigrateNodeToDeviceSessionResponse)(nil), "ns.MigrateNodeToDeviceSessionResponse")
-	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
-	proto.RegisterEnum("ns.Modulation", Modulation_name, Modulation_value)
-	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
+func (m *MulticastGroup) GetMcAddr() []byte {
+	if m != nil {
+		return m.McAddr
+	}
+	return nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *MulticastGroup) GetMcNwkSKey() []byte {
+	if m != nil {
+		return m.McNwkSKey
+	}
+	return nil
+}
+
+func (m *MulticastGroup) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetDr() uint32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetFrequency() uint32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *MulticastGroup) GetServiceProfileID() string {
+	if m != nil {
+		return m.ServiceProfileID
+	}
+	return ""
+}
+
+type CreateMulticastGroupRequest struct {
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicastGroup" json:"multicastGroup,omitempty"`
+}
+
+func (m *CreateMulticastGroupRequest) Reset()                    { *m = CreateMulticastGroupRequest{} }
+func (m *CreateMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupRequest) ProtoMessage()               {}
+func (*CreateMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{100} }
+
+func (m *CreateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type CreateMulticastGroupResponse struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateMulticastGroupResponse) Reset()                    { *m = CreateMulticastGroupResponse{} }
+func (m *CreateMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateMulticastGroupResponse) ProtoMessage()               {}
+func (*CreateMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{101} }
+
+func (m *CreateMulticastGroupResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetMulticastGroupRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetMulticastGroupRequest) Reset()                    { *m = GetMulticastGroupRequest{} }
+func (m *GetMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetMulticastGroupRequest) ProtoMessage()               {}
+func (*GetMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{102} }
+
+func (m *GetMulticastGroupRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetMulticastGroupResponse struct {
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicastGroup" json:"multicastGroup,omitempty"`
+	CreatedAt      string          `protobuf:"bytes,2,opt,name=createdAt" json:"createdAt,omitempty"`
+	UpdatedAt      string          `protobuf:"bytes,3,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// Devices within the multicast-group.
+	DevEUIs [][]byte `protobuf:"bytes,4,rep,name=devEUIs,proto3" json:"devEUIs,omitempty"`
+}
+
+func (m *GetMulticastGroupResponse) Reset()                    { *m = GetMulticastGroupResponse{} }
+func (m *GetMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetMulticastGroupResponse) ProtoMessage()               {}
+func (*GetMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{103} }
+
+func (m *GetMulticastGroupResponse) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+func (m *GetMulticastGroupResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetMulticastGroupResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+func (m *GetMulticastGroupResponse) GetDevEUIs() [][]byte {
+	if m != nil {
+		return m.DevEUIs
+	}
+	return nil
+}
+
+type UpdateMulticastGroupRequest struct {
+	MulticastGroup *MulticastGroup `protobuf:"bytes,1,opt,name=multicastGroup" json:"multicastGroup,omitempty"`
+}
+
+func (m *UpdateMulticastGroupRequest) Reset()                    { *m = UpdateMulticastGroupRequest{} }
+func (m *UpdateMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateMulticastGroupRequest) ProtoMessage()               {}
+func (*UpdateMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{104} }
+
+func (m *UpdateMulticastGroupRequest) GetMulticastGroup() *MulticastGroup {
+	if m != nil {
+		return m.MulticastGroup
+	}
+	return nil
+}
+
+type UpdateMulticastGroupResponse struct {
+}
+
+func (m *UpdateMulticastGroupResponse) Reset()                    { *m = UpdateMulticastGroupResponse{} }
+func (m *UpdateMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateMulticastGroupResponse) ProtoMessage()               {}
+func (*UpdateMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{105} }
+
+type DeleteMulticastGroupRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteMulticastGroupRequest) Reset()                    { *m = DeleteMulticastGroupRequest{} }
+func (m *DeleteMulticastGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMulticastGroupRequest) ProtoMessage()               {}
+func (*DeleteMulticastGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{106} }
+
+func (m *DeleteMulticastGroupRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteMulticastGroupResponse struct {
+}
+
+func (m *DeleteMulticastGroupResponse) Reset()                    { *m = DeleteMulticastGroupResponse{} }
+func (m *DeleteMulticastGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMulticastGroupResponse) ProtoMessage()               {}
+func (*DeleteMulticastGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{107} }
+
+type AddDeviceToMulticastGroupRequest struct {
+	// DevEUI of the device.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// ID of the multicast-group.
+	MulticastGroupID string `protobuf:"bytes,2,opt,name=multicastGroupID" json:"multicastGroupID,omitempty"`
+}
+
+func (m *AddDeviceToMulticastGroupRequest) Reset()         { *m = AddDeviceToMulticastGroupRequest{} }
+func (m *AddDeviceToMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*AddDeviceToMulticastGroupRequest) ProtoMessage()    {}
+func (*AddDeviceToMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{108}
+}
+
+func (m *AddDeviceToMulticastGroupRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *AddDeviceToMulticastGroupRequest) GetMulticastGroupID() string {
+	if m != nil {
+		return m.MulticastGroupID
+	}
+	return ""
+}
+
+type AddDeviceToMulticastGroupResponse struct {
+}
+
+func (m *AddDeviceToMulticastGroupResponse) Reset()         { *m = AddDeviceToMulticastGroupResponse{} }
+func (m *AddDeviceToMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*AddDeviceToMulticastGroupResponse) ProtoMessage()    {}
+func (*AddDeviceToMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{109}
+}
+
+type RemoveDeviceFromMulticastGroupRequest struct {
+	// DevEUI of the device.
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// ID of the multicast-group.
+	MulticastGroupID string `protobuf:"bytes,2,opt,name=multicastGroupID" json:"multicastGroupID,omitempty"`
+}
+
+func (m *RemoveDeviceFromMulticastGroupRequest) Reset()         { *m = RemoveDeviceFromMulticastGroupRequest{} }
+func (m *RemoveDeviceFromMulticastGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveDeviceFromMulticastGroupRequest) ProtoMessage()    {}
+func (*RemoveDeviceFromMulticastGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{110}
+}
+
+func (m *RemoveDeviceFromMulticastGroupRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *RemoveDeviceFromMulticastGroupRequest) GetMulticastGroupID() string {
+	if m != nil {
+		return m.MulticastGroupID
+	}
+	return ""
+}
+
+type RemoveDeviceFromMulticastGroupResponse struct {
+}
+
+func (m *RemoveDeviceFromMulticastGroupResponse) Reset() {
+	*m = RemoveDeviceFromMulticastGroupResponse{}
+}
+func (m *RemoveDeviceFromMulticastGroupResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveDeviceFromMulticastGroupResponse) ProtoMessage()    {}
+func (*RemoveDeviceFromMulticastGroupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{111}
+}
+
+type EnqueueMulticastQueueItemRequest struct {
+	// ID of the multicast-group.
+	MulticastGroupID string `protobuf:"bytes,1,opt,name=multicastGroupID" json:"multicastGroupID,omitempty"`
+	// Data (encrypted with the McAppSKey) to send to the multicast-group.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// FPort to use for transmitting the payload.
+	FPort uint32 `protobuf:"varint,3,opt,name=fPort" json:"fPort,omitempty"`
+	// FCnt used for encrypting the data. When this does not match the FCnt
+	// of the multicast-group, an error is returned.
+	FCnt uint32 `protobuf:"varint,4,opt,name=fCnt" json:"fCnt,omitempty"`
+}
+
+func (m *EnqueueMulticastQueueItemRequest) Reset()         { *m = EnqueueMulticastQueueItemRequest{} }
+func (m *EnqueueMulticastQueueItemRequest) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastQueueItemRequest) ProtoMessage()    {}
+func (*EnqueueMulticastQueueItemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{112}
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetMulticastGroupID() string {
+	if m != nil {
+		return m.MulticastGroupID
+	}
+	return ""
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetFPort() uint32 {
+	if m != nil {
+		return m.FPort
+	}
+	return 0
+}
+
+func (m *EnqueueMulticastQueueItemRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+type EnqueueMulticastQueueItemResponse struct {
+}
+
+func (m *EnqueueMulticastQueueItemResponse) Reset()         { *m = EnqueueMulticastQueueItemResponse{} }
+func (m *EnqueueMulticastQueueItemResponse) String() string { return proto.CompactTextString(m) }
+func (*EnqueueMulticastQueueItemResponse) ProtoMessage()    {}
+func (*EnqueueMulticastQueueItemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{113}
+}
+
+type DeviceKeys struct {
+	// Device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Network root key (16 bytes).
+	// Note: for LoRaWAN 1.0.x devices, this key is used as AppKey.
+	NwkKey []byte `protobuf:"bytes,2,opt,name=nwkKey,proto3" json:"nwkKey,omitempty"`
+	// Application root key (16 bytes).
+	// Note: this key is not used by LoRaWAN 1.0.x devices.
+	AppKey []byte `protobuf:"bytes,3,opt,name=appKey,proto3" json:"appKey,omitempty"`
+	// Join-nonce (used as AppNonce for LoRaWAN 1.0.x devices).
+	JoinNonce uint32 `protobuf:"varint,4,opt,name=joinNonce" json:"joinNonce,omitempty"`
+}
+
+func (m *DeviceKeys) Reset()                    { *m = DeviceKeys{} }
+func (m *DeviceKeys) String() string            { return proto.CompactTextString(m) }
+func (*DeviceKeys) ProtoMessage()               {}
+func (*DeviceKeys) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{114} }
+
+func (m *DeviceKeys) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *DeviceKeys) GetNwkKey() []byte {
+	if m != nil {
+		return m.NwkKey
+	}
+	return nil
+}
+
+func (m *DeviceKeys) GetAppKey() []byte {
+	if m != nil {
+		return m.AppKey
+	}
+	return nil
+}
+
+func (m *DeviceKeys) GetJoinNonce() uint32 {
+	if m != nil {
+		return m.JoinNonce
+	}
+	return 0
+}
+
+type CreateDeviceKeysRequest struct {
+	DeviceKeys *DeviceKeys `protobuf:"bytes,1,opt,name=deviceKeys" json:"deviceKeys,omitempty"`
+}
+
+func (m *CreateDeviceKeysRequest) Reset()                    { *m = CreateDeviceKeysRequest{} }
+func (m *CreateDeviceKeysRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateDeviceKeysRequest) ProtoMessage()               {}
+func (*CreateDeviceKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{115} }
+
+func (m *CreateDeviceKeysRequest) GetDeviceKeys() *DeviceKeys {
+	if m != nil {
+		return m.DeviceKeys
+	}
+	return nil
+}
+
+type CreateDeviceKeysResponse struct {
+}
+
+func (m *CreateDeviceKeysResponse) Reset()                    { *m = CreateDeviceKeysResponse{} }
+func (m *CreateDeviceKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateDeviceKeysResponse) ProtoMessage()               {}
+func (*CreateDeviceKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{116} }
+
+type GetDeviceKeysRequest struct {
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *GetDeviceKeysRequest) Reset()                    { *m = GetDeviceKeysRequest{} }
+func (m *GetDeviceKeysRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceKeysRequest) ProtoMessage()               {}
+func (*GetDeviceKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{117} }
+
+func (m *GetDeviceKeysRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type GetDeviceKeysResponse struct {
+	DeviceKeys *DeviceKeys `protobuf:"bytes,1,opt,name=deviceKeys" json:"deviceKeys,omitempty"`
+	CreatedAt  string      `protobuf:"bytes,2,opt,name=createdAt" json:"createdAt,omitempty"`
+	UpdatedAt  string      `protobuf:"bytes,3,opt,name=updatedAt" json:"updatedAt,omitempty"`
+}
+
+func (m *GetDeviceKeysResponse) Reset()                    { *m = GetDeviceKeysResponse{} }
+func (m *GetDeviceKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetDeviceKeysResponse) ProtoMessage()               {}
+func (*GetDeviceKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{118} }
+
+func (m *GetDeviceKeysResponse) GetDeviceKeys() *DeviceKeys {
+	if m != nil {
+		return m.DeviceKeys
+	}
+	return nil
+}
+
+func (m *GetDeviceKeysResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetDeviceKeysResponse) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type UpdateDeviceKeysRequest struct {
+	DeviceKeys *DeviceKeys `protobuf:"bytes,1,opt,name=deviceKeys" json:"deviceKeys,omitempty"`
+}
+
+func (m *UpdateDeviceKeysRequest) Reset()                    { *m = UpdateDeviceKeysRequest{} }
+func (m *UpdateDeviceKeysRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateDeviceKeysRequest) ProtoMessage()               {}
+func (*UpdateDeviceKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{119} }
+
+func (m *UpdateDeviceKeysRequest) GetDeviceKeys() *DeviceKeys {
+	if m != nil {
+		return m.DeviceKeys
+	}
+	return nil
+}
+
+type UpdateDeviceKeysResponse struct {
+}
+
+func (m *UpdateDeviceKeysResponse) Reset()                    { *m = UpdateDeviceKeysResponse{} }
+func (m *UpdateDeviceKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateDeviceKeysResponse) ProtoMessage()               {}
+func (*UpdateDeviceKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{120} }
+
+type DeleteDeviceKeysRequest struct {
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+}
+
+func (m *DeleteDeviceKeysRequest) Reset()                    { *m = DeleteDeviceKeysRequest{} }
+func (m *DeleteDeviceKeysRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteDeviceKeysRequest) ProtoMessage()               {}
+func (*DeleteDeviceKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{121} }
+
+func (m *DeleteDeviceKeysRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+type DeleteDeviceKeysResponse struct {
+}
+
+func (m *DeleteDeviceKeysResponse) Reset()                    { *m = DeleteDeviceKeysResponse{} }
+func (m *DeleteDeviceKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteDeviceKeysResponse) ProtoMessage()               {}
+func (*DeleteDeviceKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{122} }
+
+type GetRejectedJoinRequestCountsRequest struct {
+}
+
+func (m *GetRejectedJoinRequestCountsRequest) Reset()         { *m = GetRejectedJoinRequestCountsRequest{} }
+func (m *GetRejectedJoinRequestCountsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRejectedJoinRequestCountsRequest) ProtoMessage()    {}
+func (*GetRejectedJoinRequestCountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{123}
+}
+
+type RejectedJoinRequestCount struct {
+	// Reason of the rejection (UNKNOWN_DEV_EUI, DEV_EUI_RATE_LIMITED,
+	// JOIN_EUI_RATE_LIMITED or DEV_EUI_ATTEMPTS_EXCEEDED).
+	Reason string `protobuf:"bytes,1,opt,name=reason" json:"reason,omitempty"`
+	// Number of rejected join-requests.
+	Count uint64 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *RejectedJoinRequestCount) Reset()                    { *m = RejectedJoinRequestCount{} }
+func (m *RejectedJoinRequestCount) String() string            { return proto.CompactTextString(m) }
+func (*RejectedJoinRequestCount) ProtoMessage()               {}
+func (*RejectedJoinRequestCount) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{124} }
+
+func (m *RejectedJoinRequestCount) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *RejectedJoinRequestCount) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type GetRejectedJoinRequestCountsResponse struct {
+	Counts []*RejectedJoinRequestCount `protobuf:"bytes,1,rep,name=counts" json:"counts,omitempty"`
+}
+
+func (m *GetRejectedJoinRequestCountsResponse) Reset()         { *m = GetRejectedJoinRequestCountsResponse{} }
+func (m *GetRejectedJoinRequestCountsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRejectedJoinRequestCountsResponse) ProtoMessage()    {}
+func (*GetRejectedJoinRequestCountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor1, []int{125}
+}
+
+func (m *GetRejectedJoinRequestCountsResponse) GetCounts() []*RejectedJoinRequestCount {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type ReportDeviceLocationRequest struct {
+	// Device EUI (8 bytes).
+	DevEUI []byte `protobuf:"bytes,1,opt,name=devEUI,proto3" json:"devEUI,omitempty"`
+	// Frame-counter of the uplink containing the location. This must be
+	// the last uplink received from the device.
+	FCnt uint32 `protobuf:"varint,2,opt,name=fCnt" json:"fCnt,omitempty"`
+	// Latitude of the device.
+	Latitude float64 `protobuf:"fixed64,3,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the device.
+	Longitude float64 `protobuf:"fixed64,4,opt,name=longitude" json:"longitude,omitempty"`
+}
+
+func (m *ReportDeviceLocationRequest) Reset()                    { *m = ReportDeviceLocationRequest{} }
+func (m *ReportDeviceLocationRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReportDeviceLocationRequest) ProtoMessage()               {}
+func (*ReportDeviceLocationRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{126} }
+
+func (m *ReportDeviceLocationRequest) GetDevEUI() []byte {
+	if m != nil {
+		return m.DevEUI
+	}
+	return nil
+}
+
+func (m *ReportDeviceLocationRequest) GetFCnt() uint32 {
+	if m != nil {
+		return m.FCnt
+	}
+	return 0
+}
+
+func (m *ReportDeviceLocationRequest) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *ReportDeviceLocationRequest) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+type ReportDeviceLocationResponse struct {
+}
+
+func (m *ReportDeviceLocationResponse) Reset()                    { *m = ReportDeviceLocationResponse{} }
+func (m *ReportDeviceLocationResponse) String() string            { return proto.CompactTextString(m) }
+func (*ReportDeviceLocationResponse) ProtoMessage()               {}
+func (*ReportDeviceLocationResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{127} }
+
+type GetCoverageRequest struct {
+	// Bounding box.
+	BoundingBox *GatewayBoundingBox `protobuf:"bytes,1,opt,name=boundingBox" json:"boundingBox,omitempty"`
+	// Geohash precision of the returned cells (optional, when 0 the
+	// configured precision is used). This can't be greater than the
+	// configured precision.
+	Precision int32 `protobuf:"varint,2,opt,name=precision" json:"precision,omitempty"`
+	// Only return the coverage of the given gateways (optional).
+	GatewayMACs [][]byte `protobuf:"bytes,3,rep,name=gatewayMACs,proto3" json:"gatewayMACs,omitempty"`
+}
+
+func (m *GetCoverageRequest) Reset()                    { *m = GetCoverageRequest{} }
+func (m *GetCoverageRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetCoverageRequest) ProtoMessage()               {}
+func (*GetCoverageRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{128} }
+
+func (m *GetCoverageRequest) GetBoundingBox() *GatewayBoundingBox {
+	if m != nil {
+		return m.BoundingBox
+	}
+	return nil
+}
+
+func (m *GetCoverageRequest) GetPrecision() int32 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func (m *GetCoverageRequest) GetGatewayMACs() [][]byte {
+	if m != nil {
+		return m.GatewayMACs
+	}
+	return nil
+}
+
+type GatewayCoverage struct {
+	// MAC address of the gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Best RSSI.
+	Rssi int32 `protobuf:"varint,2,opt,name=rssi" json:"rssi,omitempty"`
+	// Best SNR.
+	Snr float64 `protobuf:"fixed64,3,opt,name=snr" json:"snr,omitempty"`
+	// Number of uplinks received.
+	RxCount int64 `protobuf:"varint,4,opt,name=rxCount" json:"rxCount,omitempty"`
+	// Last seen at timestamp (RFC3339).
+	LastSeenAt string `protobuf:"bytes,5,opt,name=lastSeenAt" json:"lastSeenAt,omitempty"`
+	// Distance between the gateway and the center of the cell in meters
+	// (0 when the gateway location is unknown).
+	Distance float64 `protobuf:"fixed64,6,opt,name=distance" json:"distance,omitempty"`
+}
+
+func (m *GatewayCoverage) Reset()                    { *m = GatewayCoverage{} }
+func (m *GatewayCoverage) String() string            { return proto.CompactTextString(m) }
+func (*GatewayCoverage) ProtoMessage()               {}
+func (*GatewayCoverage) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{129} }
+
+func (m *GatewayCoverage) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GatewayCoverage) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *GatewayCoverage) GetSnr() float64 {
+	if m != nil {
+		return m.Snr
+	}
+	return 0
+}
+
+func (m *GatewayCoverage) GetRxCount() int64 {
+	if m != nil {
+		return m.RxCount
+	}
+	return 0
+}
+
+func (m *GatewayCoverage) GetLastSeenAt() string {
+	if m != nil {
+		return m.LastSeenAt
+	}
+	return ""
+}
+
+func (m *GatewayCoverage) GetDistance() float64 {
+	if m != nil {
+		return m.Distance
+	}
+	return 0
+}
+
+type CoverageCell struct {
+	// Geohash of the cell.
+	Geohash string `protobuf:"bytes,1,opt,name=geohash" json:"geohash,omitempty"`
+	// Latitude of the center of the cell.
+	Latitude float64 `protobuf:"fixed64,2,opt,name=latitude" json:"latitude,omitempty"`
+	// Longitude of the center of the cell.
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude" json:"longitude,omitempty"`
+	// Coverage per gateway.
+	Gateways []*GatewayCoverage `protobuf:"bytes,4,rep,name=gateways" json:"gateways,omitempty"`
+}
+
+func (m *CoverageCell) Reset()                    { *m = CoverageCell{} }
+func (m *CoverageCell) String() string            { return proto.CompactTextString(m) }
+func (*CoverageCell) ProtoMessage()               {}
+func (*CoverageCell) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{130} }
+
+func (m *CoverageCell) GetGeohash() string {
+	if m != nil {
+		return m.Geohash
+	}
+	return ""
+}
+
+func (m *CoverageCell) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *CoverageCell) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *CoverageCell) GetGateways() []*GatewayCoverage {
+	if m != nil {
+		return m.Gateways
+	}
+	return nil
+}
+
+type GetCoverageResponse struct {
+	// Cells (ordered by geohash).
+	Result []*CoverageCell `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetCoverageResponse) Reset()                    { *m = GetCoverageResponse{} }
+func (m *GetCoverageResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetCoverageResponse) ProtoMessage()               {}
+func (*GetCoverageResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{131} }
+
+func (m *GetCoverageResponse) GetResult() []*CoverageCell {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type StartGatewayLinkTestRequest struct {
+	// MAC address of the transmitting gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Frequency (Hz) to use for the transmission (optional, when 0 the
+	// first uplink channel of the band is used).
+	Frequency int32 `protobuf:"varint,2,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate to use for the transmission.
+	Dr int32 `protobuf:"varint,3,opt,name=dr" json:"dr,omitempty"`
+}
+
+func (m *StartGatewayLinkTestRequest) Reset()                    { *m = StartGatewayLinkTestRequest{} }
+func (m *StartGatewayLinkTestRequest) String() string            { return proto.CompactTextString(m) }
+func (*StartGatewayLinkTestRequest) ProtoMessage()               {}
+func (*StartGatewayLinkTestRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{132} }
+
+func (m *StartGatewayLinkTestRequest) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *StartGatewayLinkTestRequest) GetFrequency() int32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *StartGatewayLinkTestRequest) GetDr() int32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+type StartGatewayLinkTestResponse struct {
+	// ID of the link test.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *StartGatewayLinkTestResponse) Reset()                    { *m = StartGatewayLinkTestResponse{} }
+func (m *StartGatewayLinkTestResponse) String() string            { return proto.CompactTextString(m) }
+func (*StartGatewayLinkTestResponse) ProtoMessage()               {}
+func (*StartGatewayLinkTestResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{133} }
+
+func (m *StartGatewayLinkTestResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetGatewayLinkTestRequest struct {
+	// ID of the link test.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *GetGatewayLinkTestRequest) Reset()                    { *m = GetGatewayLinkTestRequest{} }
+func (m *GetGatewayLinkTestRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayLinkTestRequest) ProtoMessage()               {}
+func (*GetGatewayLinkTestRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{134} }
+
+func (m *GetGatewayLinkTestRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GatewayLinkTestRXInfo struct {
+	// MAC address of the receiving gateway.
+	Mac []byte `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"`
+	// RSSI of the reception.
+	Rssi int32 `protobuf:"varint,2,opt,name=rssi" json:"rssi,omitempty"`
+	// SNR of the reception.
+	Snr float64 `protobuf:"fixed64,3,opt,name=snr" json:"snr,omitempty"`
+	// Received at timestamp (RFC3339).
+	ReceivedAt string `protobuf:"bytes,4,opt,name=receivedAt" json:"receivedAt,omitempty"`
+}
+
+func (m *GatewayLinkTestRXInfo) Reset()                    { *m = GatewayLinkTestRXInfo{} }
+func (m *GatewayLinkTestRXInfo) String() string            { return proto.CompactTextString(m) }
+func (*GatewayLinkTestRXInfo) ProtoMessage()               {}
+func (*GatewayLinkTestRXInfo) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{135} }
+
+func (m *GatewayLinkTestRXInfo) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GatewayLinkTestRXInfo) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *GatewayLinkTestRXInfo) GetSnr() float64 {
+	if m != nil {
+		return m.Snr
+	}
+	return 0
+}
+
+func (m *GatewayLinkTestRXInfo) GetReceivedAt() string {
+	if m != nil {
+		return m.ReceivedAt
+	}
+	return ""
+}
+
+type GetGatewayLinkTestResponse struct {
+	// ID of the link test.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// MAC address of the transmitting gateway.
+	Mac []byte `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	// Created at timestamp (RFC3339).
+	CreatedAt string `protobuf:"bytes,3,opt,name=createdAt" json:"createdAt,omitempty"`
+	// Frequency (Hz) used for the transmission.
+	Frequency int32 `protobuf:"varint,4,opt,name=frequency" json:"frequency,omitempty"`
+	// Data-rate used for the transmission.
+	Dr int32 `protobuf:"varint,5,opt,name=dr" json:"dr,omitempty"`
+	// Gateways which received the test frame (best RSSI first).
+	RxInfo []*GatewayLinkTestRXInfo `protobuf:"bytes,6,rep,name=rxInfo" json:"rxInfo,omitempty"`
+}
+
+func (m *GetGatewayLinkTestResponse) Reset()                    { *m = GetGatewayLinkTestResponse{} }
+func (m *GetGatewayLinkTestResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayLinkTestResponse) ProtoMessage()               {}
+func (*GetGatewayLinkTestResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{136} }
+
+func (m *GetGatewayLinkTestResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GetGatewayLinkTestResponse) GetMac() []byte {
+	if m != nil {
+		return m.Mac
+	}
+	return nil
+}
+
+func (m *GetGatewayLinkTestResponse) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *GetGatewayLinkTestResponse) GetFrequency() int32 {
+	if m != nil {
+		return m.Frequency
+	}
+	return 0
+}
+
+func (m *GetGatewayLinkTestResponse) GetDr() int32 {
+	if m != nil {
+		return m.Dr
+	}
+	return 0
+}
+
+func (m *GetGatewayLinkTestResponse) GetRxInfo() []*GatewayLinkTestRXInfo {
+	if m != nil {
+		return m.RxInfo
+	}
+	return nil
+}
+
+type GetGatewayNeighboursRequest struct {
+	// Only return the links of the given transmitting gateways (optional).
+	GatewayMACs [][]byte `protobuf:"bytes,1,rep,name=gatewayMACs,proto3" json:"gatewayMACs,omitempty"`
+}
+
+func (m *GetGatewayNeighboursRequest) Reset()                    { *m = GetGatewayNeighboursRequest{} }
+func (m *GetGatewayNeighboursRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayNeighboursRequest) ProtoMessage()               {}
+func (*GetGatewayNeighboursRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{137} }
+
+func (m *GetGatewayNeighboursRequest) GetGatewayMACs() [][]byte {
+	if m != nil {
+		return m.GatewayMACs
+	}
+	return nil
+}
+
+type GatewayNeighbour struct {
+	// MAC address of the transmitting gateway.
+	TxMAC []byte `protobuf:"bytes,1,opt,name=txMAC,proto3" json:"txMAC,omitempty"`
+	// MAC address of the receiving gateway.
+	RxMAC []byte `protobuf:"bytes,2,opt,name=rxMAC,proto3" json:"rxMAC,omitempty"`
+	// RSSI of the reception.
+	Rssi int32 `protobuf:"varint,3,opt,name=rssi" json:"rssi,omitempty"`
+	// SNR of the reception.
+	Snr float64 `protobuf:"fixed64,4,opt,name=snr" json:"snr,omitempty"`
+	// ID of the link test.
+	LinkTestID string `protobuf:"bytes,5,opt,name=linkTestID" json:"linkTestID,omitempty"`
+	// Timestamp of the link test (RFC3339).
+	TestedAt string `protobuf:"bytes,6,opt,name=testedAt" json:"testedAt,omitempty"`
+}
+
+func (m *GatewayNeighbour) Reset()                    { *m = GatewayNeighbour{} }
+func (m *GatewayNeighbour) String() string            { return proto.CompactTextString(m) }
+func (*GatewayNeighbour) ProtoMessage()               {}
+func (*GatewayNeighbour) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{138} }
+
+func (m *GatewayNeighbour) GetTxMAC() []byte {
+	if m != nil {
+		return m.TxMAC
+	}
+	return nil
+}
+
+func (m *GatewayNeighbour) GetRxMAC() []byte {
+	if m != nil {
+		return m.RxMAC
+	}
+	return nil
+}
+
+func (m *GatewayNeighbour) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+func (m *GatewayNeighbour) GetSnr() float64 {
+	if m != nil {
+		return m.Snr
+	}
+	return 0
+}
+
+func (m *GatewayNeighbour) GetLinkTestID() string {
+	if m != nil {
+		return m.LinkTestID
+	}
+	return ""
+}
+
+func (m *GatewayNeighbour) GetTestedAt() string {
+	if m != nil {
+		return m.TestedAt
+	}
+	return ""
+}
+
+type GetGatewayNeighboursResponse struct {
+	// Links between the gateways (ordered by transmitting and receiving
+	// gateway MAC).
+	Result []*GatewayNeighbour `protobuf:"bytes,1,rep,name=result" json:"result,omitempty"`
+}
+
+func (m *GetGatewayNeighboursResponse) Reset()                    { *m = GetGatewayNeighboursResponse{} }
+func (m *GetGatewayNeighboursResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetGatewayNeighboursResponse) ProtoMessage()               {}
+func (*GetGatewayNeighboursResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{139} }
+
+func (m *GetGatewayNeighboursResponse) GetResult() []*GatewayNeighbour {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CreateServiceProfileRequest)(nil), "ns.CreateServiceProfileRequest")
+	proto.RegisterType((*CreateServiceProfileResponse)(nil), "ns.CreateServiceProfileResponse")
+	proto.RegisterType((*GetServiceProfileRequest)(nil), "ns.GetServiceProfileRequest")
+	proto.RegisterType((*GetServiceProfileResponse)(nil), "ns.GetServiceProfileResponse")
+	proto.RegisterType((*UpdateServiceProfileRequest)(nil), "ns.UpdateServiceProfileRequest")
+	proto.RegisterType((*UpdateServiceProfileResponse)(nil), "ns.UpdateServiceProfileResponse")
+	proto.RegisterType((*DeleteServiceProfileRequest)(nil), "ns.DeleteServiceProfileRequest")
+	proto.RegisterType((*DeleteServiceProfileResponse)(nil), "ns.DeleteServiceProfileResponse")
+	proto.RegisterType((*RoutingProfile)(nil), "ns.RoutingProfile")
+	proto.RegisterType((*CreateRoutingProfileRequest)(nil), "ns.CreateRoutingProfileRequest")
+	proto.RegisterType((*CreateRoutingProfileResponse)(nil), "ns.CreateRoutingProfileResponse")
+	proto.RegisterType((*GetRoutingProfileRequest)(nil), "ns.GetRoutingProfileRequest")
+	proto.RegisterType((*GetRoutingProfileResponse)(nil), "ns.GetRoutingProfileResponse")
+	proto.RegisterType((*UpdateRoutingProfileRequest)(nil), "ns.UpdateRoutingProfileRequest")
+	proto.RegisterType((*UpdateRoutingProfileResponse)(nil), "ns.UpdateRoutingProfileResponse")
+	proto.RegisterType((*DeleteRoutingProfileRequest)(nil), "ns.DeleteRoutingProfileRequest")
+	proto.RegisterType((*DeleteRoutingProfileResponse)(nil), "ns.DeleteRoutingProfileResponse")
+	proto.RegisterType((*CreateDeviceProfileRequest)(nil), "ns.CreateDeviceProfileRequest")
+	proto.RegisterType((*CreateDeviceProfileResponse)(nil), "ns.CreateDeviceProfileResponse")
+	proto.RegisterType((*GetDeviceProfileRequest)(nil), "ns.GetDeviceProfileRequest")
+	proto.RegisterType((*GetDeviceProfileResponse)(nil), "ns.GetDeviceProfileResponse")
+	proto.RegisterType((*UpdateDeviceProfileRequest)(nil), "ns.UpdateDeviceProfileRequest")
+	proto.RegisterType((*UpdateDeviceProfileResponse)(nil), "ns.UpdateDeviceProfileResponse")
+	proto.RegisterType((*DeleteDeviceProfileRequest)(nil), "ns.DeleteDeviceProfileRequest")
+	proto.RegisterType((*DeleteDeviceProfileResponse)(nil), "ns.DeleteDeviceProfileResponse")
+	proto.RegisterType((*Device)(nil), "ns.Device")
+	proto.RegisterType((*CreateDeviceRequest)(nil), "ns.CreateDeviceRequest")
+	proto.RegisterType((*CreateDeviceResponse)(nil), "ns.CreateDeviceResponse")
+	proto.RegisterType((*GetDeviceRequest)(nil), "ns.GetDeviceRequest")
+	proto.RegisterType((*GetDeviceResponse)(nil), "ns.GetDeviceResponse")
+	proto.RegisterType((*UpdateDeviceRequest)(nil), "ns.UpdateDeviceRequest")
+	proto.RegisterType((*UpdateDeviceResponse)(nil), "ns.UpdateDeviceResponse")
+	proto.RegisterType((*DeleteDeviceRequest)(nil), "ns.DeleteDeviceRequest")
+	proto.RegisterType((*DeleteDeviceResponse)(nil), "ns.DeleteDeviceResponse")
+	proto.RegisterType((*ActivateDeviceRequest)(nil), "ns.ActivateDeviceRequest")
+	proto.RegisterType((*ActivateDeviceResponse)(nil), "ns.ActivateDeviceResponse")
+	proto.RegisterType((*DeactivateDeviceRequest)(nil), "ns.DeactivateDeviceRequest")
+	proto.RegisterType((*DeactivateDeviceResponse)(nil), "ns.DeactivateDeviceResponse")
+	proto.RegisterType((*GetDeviceActivationRequest)(nil), "ns.GetDeviceActivationRequest")
+	proto.RegisterType((*GetDeviceActivationResponse)(nil), "ns.GetDeviceActivationResponse")
+	proto.RegisterType((*GetRandomDevAddrRequest)(nil), "ns.GetRandomDevAddrRequest")
+	proto.RegisterType((*GetRandomDevAddrResponse)(nil), "ns.GetRandomDevAddrResponse")
+	proto.RegisterType((*EnqueueDownlinkMACCommandRequest)(nil), "ns.EnqueueDownlinkMACCommandRequest")
+	proto.RegisterType((*EnqueueDownlinkMACCommandResponse)(nil), "ns.EnqueueDownlinkMACCommandResponse")
+	proto.RegisterType((*SendDownlinkDataRequest)(nil), "ns.SendDownlinkDataRequest")
+	proto.RegisterType((*SendDownlinkDataResponse)(nil), "ns.SendDownlinkDataResponse")
+	proto.RegisterType((*SendProprietaryPayloadRequest)(nil), "ns.SendProprietaryPayloadRequest")
+	proto.RegisterType((*SendProprietaryPayloadResponse)(nil), "ns.SendProprietaryPayloadResponse")
+	proto.RegisterType((*CreateGatewayRequest)(nil), "ns.CreateGatewayRequest")
+	proto.RegisterType((*CreateGatewayResponse)(nil), "ns.CreateGatewayResponse")
+	proto.RegisterType((*GetGatewayRequest)(nil), "ns.GetGatewayRequest")
+	proto.RegisterType((*GetGatewayResponse)(nil), "ns.GetGatewayResponse")
+	proto.RegisterType((*UpdateGatewayRequest)(nil), "ns.UpdateGatewayRequest")
+	proto.RegisterType((*UpdateGatewayResponse)(nil), "ns.UpdateGatewayResponse")
+	proto.RegisterType((*GatewayBoundingBox)(nil), "ns.GatewayBoundingBox")
+	proto.RegisterType((*GatewayRadius)(nil), "ns.GatewayRadius")
+	proto.RegisterType((*ListGatewayRequest)(nil), "ns.ListGatewayRequest")
+	proto.RegisterType((*ListGatewayResponse)(nil), "ns.ListGatewayResponse")
+	proto.RegisterType((*DeleteGatewayRequest)(nil), "ns.DeleteGatewayRequest")
+	proto.RegisterType((*GenerateGatewayTokenRequest)(nil), "ns.GenerateGatewayTokenRequest")
+	proto.RegisterType((*GenerateGatewayTokenResponse)(nil), "ns.GenerateGatewayTokenResponse")
+	proto.RegisterType((*GatewayToken)(nil), "ns.GatewayToken")
+	proto.RegisterType((*ListGatewayTokensRequest)(nil), "ns.ListGatewayTokensRequest")
+	proto.RegisterType((*ListGatewayTokensResponse)(nil), "ns.ListGatewayTokensResponse")
+	proto.RegisterType((*RevokeGatewayTokenRequest)(nil), "ns.RevokeGatewayTokenRequest")
+	proto.RegisterType((*RevokeGatewayTokenResponse)(nil), "ns.RevokeGatewayTokenResponse")
+	proto.RegisterType((*DeleteGatewayResponse)(nil), "ns.DeleteGatewayResponse")
+	proto.RegisterType((*GatewayStats)(nil), "ns.GatewayStats")
+	proto.RegisterType((*GatewayFrameStats)(nil), "ns.GatewayFrameStats")
+	proto.RegisterType((*GatewaySignalStats)(nil), "ns.GatewaySignalStats")
+	proto.RegisterType((*GetGatewayStatsRequest)(nil), "ns.GetGatewayStatsRequest")
+	proto.RegisterType((*GetGatewayStatsResponse)(nil), "ns.GetGatewayStatsResponse")
+	proto.RegisterType((*GetFrameLogsForDevEUIRequest)(nil), "ns.GetFrameLogsForDevEUIRequest")
+	proto.RegisterType((*GetFrameLogsResponse)(nil), "ns.GetFrameLogsResponse")
+	proto.RegisterType((*FrameLog)(nil), "ns.FrameLog")
+	proto.RegisterType((*DataRate)(nil), "ns.DataRate")
+	proto.RegisterType((*RXInfo)(nil), "ns.RXInfo")
+	proto.RegisterType((*TXInfo)(nil), "ns.TXInfo")
+	proto.RegisterType((*CreateChannelConfigurationRequest)(nil), "ns.CreateChannelConfigurationRequest")
+	proto.RegisterType((*CreateChannelConfigurationResponse)(nil), "ns.CreateChannelConfigurationResponse")
+	proto.RegisterType((*GetChannelConfigurationRequest)(nil), "ns.GetChannelConfigurationRequest")
+	proto.RegisterType((*GetChannelConfigurationResponse)(nil), "ns.GetChannelConfigurationResponse")
+	proto.RegisterType((*UpdateChannelConfigurationRequest)(nil), "ns.UpdateChannelConfigurationRequest")
+	proto.RegisterType((*UpdateChannelConfigurationResponse)(nil), "ns.UpdateChannelConfigurationResponse")
+	proto.RegisterType((*DeleteChannelConfigurationRequest)(nil), "ns.DeleteChannelConfigurationRequest")
+	proto.RegisterType((*DeleteChannelConfigurationResponse)(nil), "ns.DeleteChannelConfigurationResponse")
+	proto.RegisterType((*ListChannelConfigurationsRequest)(nil), "ns.ListChannelConfigurationsRequest")
+	proto.RegisterType((*ListChannelConfigurationsResponse)(nil), "ns.ListChannelConfigurationsResponse")
+	proto.RegisterType((*CreateExtraChannelRequest)(nil), "ns.CreateExtraChannelRequest")
+	proto.RegisterType((*CreateExtraChannelResponse)(nil), "ns.CreateExtraChannelResponse")
+	proto.RegisterType((*UpdateExtraChannelRequest)(nil), "ns.UpdateExtraChannelRequest")
+	proto.RegisterType((*UpdateExtraChannelResponse)(nil), "ns.UpdateExtraChannelResponse")
+	proto.RegisterType((*DeleteExtraChannelRequest)(nil), "ns.DeleteExtraChannelRequest")
+	proto.RegisterType((*DeleteExtraChannelResponse)(nil), "ns.DeleteExtraChannelResponse")
+	proto.RegisterType((*GetExtraChannelResponse)(nil), "ns.GetExtraChannelResponse")
+	proto.RegisterType((*GetExtraChannelsForChannelConfigurationIDRequest)(nil), "ns.GetExtraChannelsForChannelConfigurationIDRequest")
+	proto.RegisterType((*GetExtraChannelsForChannelConfigurationIDResponse)(nil), "ns.GetExtraChannelsForChannelConfigurationIDResponse")
+	proto.RegisterType((*MigrateNodeToDeviceSessionRequest)(nil), "ns.MigrateNodeToDeviceSessionRequest")
+	proto.RegisterType((*MigrateNodeToDeviceSessionResponse)(nil), "ns.MigrateNodeToDeviceSessionResponse")
+	proto.RegisterType((*MulticastGroup)(nil), "ns.MulticastGroup")
+	proto.RegisterType((*CreateMulticastGroupRequest)(nil), "ns.CreateMulticastGroupRequest")
+	proto.RegisterType((*CreateMulticastGroupResponse)(nil), "ns.CreateMulticastGroupResponse")
+	proto.RegisterType((*GetMulticastGroupRequest)(nil), "ns.GetMulticastGroupRequest")
+	proto.RegisterType((*GetMulticastGroupResponse)(nil), "ns.GetMulticastGroupResponse")
+	proto.RegisterType((*UpdateMulticastGroupRequest)(nil), "ns.UpdateMulticastGroupRequest")
+	proto.RegisterType((*UpdateMulticastGroupResponse)(nil), "ns.UpdateMulticastGroupResponse")
+	proto.RegisterType((*DeleteMulticastGroupRequest)(nil), "ns.DeleteMulticastGroupRequest")
+	proto.RegisterType((*DeleteMulticastGroupResponse)(nil), "ns.DeleteMulticastGroupResponse")
+	proto.RegisterType((*AddDeviceToMulticastGroupRequest)(nil), "ns.AddDeviceToMulticastGroupRequest")
+	proto.RegisterType((*AddDeviceToMulticastGroupResponse)(nil), "ns.AddDeviceToMulticastGroupResponse")
+	proto.RegisterType((*RemoveDeviceFromMulticastGroupRequest)(nil), "ns.RemoveDeviceFromMulticastGroupRequest")
+	proto.RegisterType((*RemoveDeviceFromMulticastGroupResponse)(nil), "ns.RemoveDeviceFromMulticastGroupResponse")
+	proto.RegisterType((*EnqueueMulticastQueueItemRequest)(nil), "ns.EnqueueMulticastQueueItemRequest")
+	proto.RegisterType((*EnqueueMulticastQueueItemResponse)(nil), "ns.EnqueueMulticastQueueItemResponse")
+	proto.RegisterType((*DeviceKeys)(nil), "ns.DeviceKeys")
+	proto.RegisterType((*CreateDeviceKeysRequest)(nil), "ns.CreateDeviceKeysRequest")
+	proto.RegisterType((*CreateDeviceKeysResponse)(nil), "ns.CreateDeviceKeysResponse")
+	proto.RegisterType((*GetDeviceKeysRequest)(nil), "ns.GetDeviceKeysRequest")
+	proto.RegisterType((*GetDeviceKeysResponse)(nil), "ns.GetDeviceKeysResponse")
+	proto.RegisterType((*UpdateDeviceKeysRequest)(nil), "ns.UpdateDeviceKeysRequest")
+	proto.RegisterType((*UpdateDeviceKeysResponse)(nil), "ns.UpdateDeviceKeysResponse")
+	proto.RegisterType((*DeleteDeviceKeysRequest)(nil), "ns.DeleteDeviceKeysRequest")
+	proto.RegisterType((*DeleteDeviceKeysResponse)(nil), "ns.DeleteDeviceKeysResponse")
+	proto.RegisterType((*GetRejectedJoinRequestCountsRequest)(nil), "ns.GetRejectedJoinRequestCountsRequest")
+	proto.RegisterType((*RejectedJoinRequestCount)(nil), "ns.RejectedJoinRequestCount")
+	proto.RegisterType((*GetRejectedJoinRequestCountsResponse)(nil), "ns.GetRejectedJoinRequestCountsResponse")
+	proto.RegisterType((*ReportDeviceLocationRequest)(nil), "ns.ReportDeviceLocationRequest")
+	proto.RegisterType((*ReportDeviceLocationResponse)(nil), "ns.ReportDeviceLocationResponse")
+	proto.RegisterType((*GetCoverageRequest)(nil), "ns.GetCoverageRequest")
+	proto.RegisterType((*GatewayCoverage)(nil), "ns.GatewayCoverage")
+	proto.RegisterType((*CoverageCell)(nil), "ns.CoverageCell")
+	proto.RegisterType((*GetCoverageResponse)(nil), "ns.GetCoverageResponse")
+	proto.RegisterType((*StartGatewayLinkTestRequest)(nil), "ns.StartGatewayLinkTestRequest")
+	proto.RegisterType((*StartGatewayLinkTestResponse)(nil), "ns.StartGatewayLinkTestResponse")
+	proto.RegisterType((*GetGatewayLinkTestRequest)(nil), "ns.GetGatewayLinkTestRequest")
+	proto.RegisterType((*GatewayLinkTestRXInfo)(nil), "ns.GatewayLinkTestRXInfo")
+	proto.RegisterType((*GetGatewayLinkTestResponse)(nil), "ns.GetGatewayLinkTestResponse")
+	proto.RegisterType((*GetGatewayNeighboursRequest)(nil), "ns.GetGatewayNeighboursRequest")
+	proto.RegisterType((*GatewayNeighbour)(nil), "ns.GatewayNeighbour")
+	proto.RegisterType((*GetGatewayNeighboursResponse)(nil), "ns.GetGatewayNeighboursResponse")
+	proto.RegisterEnum("ns.RXWindow", RXWindow_name, RXWindow_value)
+	proto.RegisterEnum("ns.GatewayState", GatewayState_name, GatewayState_value)
+	proto.RegisterEnum("ns.Modulation", Modulation_name, Modulation_value)
+	proto.RegisterEnum("ns.ListGatewayOrderBy", ListGatewayOrderBy_name, ListGatewayOrderBy_value)
+	proto.RegisterEnum("ns.AggregationInterval", AggregationInterval_name, AggregationInterval_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
 
 // Client API for NetworkServer service
 
-type NetworkServerClient interface {
-	// CreateServiceProfile creates the given service-profile.
-	CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error)
-	// GetServiceProfile returns the service-profile matching the given id.
-	GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error)
-	// UpdateServiceProfile updates the given service-profile.
-	UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*UpdateServiceProfileResponse, error)
-	// DeleteServiceProfile deletes the service-profile matching the given id.
-	DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*DeleteServiceProfileResponse, error)
-	// CreateRoutingProfile creates the given routing-profile.
-	CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error)
-	// GetRoutingProfile returns the routing-profile matching the given id.
-	GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error)
-	// UpdateRoutingProfile updates the given routing-profile.
-	UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*UpdateRoutingProfileResponse, error)
-	// DeleteRoutingProfile deletes the routing-profile matching the given id.
-	DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*DeleteRoutingProfileResponse, error)
-	// CreateDeviceProfile creates the given device-profile.
-	CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error)
-	// GetDeviceProfile returns the device-profile matching the given id.
-	GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error)
-	// UpdateDeviceProfile updates the given device-profile.
-	UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*UpdateDeviceProfileResponse, error)
-	// DeleteDeviceProfile deletes the device-profile matching the given id.
-	DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*DeleteDeviceProfileResponse, error)
-	// CreateDevice creates the given device.
-	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error)
-	// GetDevice returns the device matching the given DevEUI.
-	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
-	// UpdateDevice updates the given device.
-	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
-	// DeleteDevice deletes the device matching the given DevEUI.
-	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
-	// ActivateDevice activates a device (ABP).
-	ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*ActivateDeviceResponse, error)
-	// DeactivateDevice de-activates a device.
-	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*DeactivateDeviceResponse, error)
-	// GetDeviceActivation returns the device activation details.
-	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
-	// EnqueueDownlinkMACCommand adds the downlink mac-command to the queue.
-	EnqueueDownlinkMACCommand(ctx context.Context, in *EnqueueDownlinkMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDownlinkMACCommandResponse, error)
-	// SendDownlinkData pushes the given downlink payload to the node (only works for Class-C nodes).
-	SendDownlinkData(ctx context.Context, in *SendDownlinkDataRequest, opts ...grpc.CallOption) (*SendDownlinkDataResponse, error)
-	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
-	SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*SendProprietaryPayloadResponse, error)
-	// CreateGateway creates the given gateway.
-	CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error)
-	// GetGateway returns data for a particular gateway.
-	GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error)
-	// UpdateGateway updates an existing gateway.
-	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error)
-	// ListGateways returns the existing gateways.
-	ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error)
-	// DeleteGateway deletes a gateway.
-	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error)
-	// GenerateGatewayToken issues a JWT token which can be used by the gateway
-	// for authentication.
-	GenerateGatewayToken(ctx context.Context, in *GenerateGatewayTokenRequest, opts ...grpc.CallOption) (*GenerateGatewayTokenResponse, error)
-	// GetGatewayStats returns stats of an existing gateway.
-	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
-	// GetFrameLogsForDevEUI returns the uplink / downlink frame logs for the given DevEUI.
-	GetFrameLogsForDevEUI(ctx context.Context, in *GetFrameLogsForDevEUIRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error)
-	// CreateChannelConfiguration creates the given channel-configuration.
-	CreateChannelConfiguration(ctx context.Context, in *CreateChannelConfigurationRequest, opts ...grpc.CallOption) (*CreateChannelConfigurationResponse, error)
-	// GetChannelConfiguration returns the channel-configuration for the given ID.
-	GetChannelConfiguration(ctx context.Context, in *GetChannelConfigurationRequest, opts ...grpc.CallOption) (*GetChannelConfigurationResponse, error)
-	// UpdateChannelConfiguration updates the given channel-configuration.
-	UpdateChannelConfiguration(ctx context.Context, in *UpdateChannelConfigurationRequest, opts ...grpc.CallOption) (*UpdateChannelConfigurationResponse, error)
-	// DeleteChannelConfiguration deletes the channel-configuration matching the
-	// given ID.
-	DeleteChannelConfiguration(ctx context.Context, in *DeleteChannelConfigurationRequest, opts ...grpc.CallOption) (*DeleteChannelConfigurationResponse, error)
-	// ListChannelConfigurations returns all channel-configurations.
-	ListChannelConfigurations(ctx context.Context, in *ListChannelConfigurationsRequest, opts ...grpc.CallOption) (*ListChannelConfigurationsResponse, error)
-	// CreateExtraChannel creates the given extra channel.
-	CreateExtraChannel(ctx context.Context, in *CreateExtraChannelRequest, opts ...grpc.CallOption) (*CreateExtraChannelResponse, error)
-	// UpdateExtraChannel updates the given extra channel.
-	UpdateExtraChannel(ctx context.Context, in *UpdateExtraChannelRequest, opts ...grpc.CallOption) (*UpdateExtraChannelResponse, error)
-	// DeleteExtraChannel deletes the extra channel matching the given id.
-	DeleteExtraChannel(ctx context.Context, in *DeleteExtraChannelRequest, opts ...grpc.CallOption) (*DeleteExtraChannelResponse, error)
-	// GetExtraChannelsForChannelConfigurationID returns the extra channels for
-	// the given channel-configuration id.
-	GetExtraChannelsForChannelConfigurationID(ctx context.Context, in *GetExtraChannelsForChannelConfigurationIDRequest, opts ...grpc.CallOption) (*GetExtraChannelsForChannelConfigurationIDResponse, error)
-	// MigrateNodeToDeviceSession. This method is for internal us only.
-	MigrateNodeToDeviceSession(ctx context.Context, in *MigrateNodeToDeviceSessionRequest, opts ...grpc.CallOption) (*MigrateNodeToDeviceSessionResponse, error)
+type NetworkServerClient interface {
+	// CreateServiceProfile creates the given service-profile.
+	CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error)
+	// GetServiceProfile returns the service-profile matching the given id.
+	GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error)
+	// UpdateServiceProfile updates the given service-profile.
+	UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*UpdateServiceProfileResponse, error)
+	// DeleteServiceProfile deletes the service-profile matching the given id.
+	DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*DeleteServiceProfileResponse, error)
+	// CreateRoutingProfile creates the given routing-profile.
+	CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error)
+	// GetRoutingProfile returns the routing-profile matching the given id.
+	GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error)
+	// UpdateRoutingProfile updates the given routing-profile.
+	UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*UpdateRoutingProfileResponse, error)
+	// DeleteRoutingProfile deletes the routing-profile matching the given id.
+	DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*DeleteRoutingProfileResponse, error)
+	// CreateDeviceProfile creates the given device-profile.
+	CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error)
+	// GetDeviceProfile returns the device-profile matching the given id.
+	GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error)
+	// UpdateDeviceProfile updates the given device-profile.
+	UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*UpdateDeviceProfileResponse, error)
+	// DeleteDeviceProfile deletes the device-profile matching the given id.
+	DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*DeleteDeviceProfileResponse, error)
+	// CreateDevice creates the given device.
+	CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error)
+	// GetDevice returns the device matching the given DevEUI.
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error)
+	// UpdateDevice updates the given device.
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
+	// DeleteDevice deletes the device matching the given DevEUI.
+	DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error)
+	// ActivateDevice activates a device (ABP).
+	ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*ActivateDeviceResponse, error)
+	// DeactivateDevice de-activates a device.
+	DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*DeactivateDeviceResponse, error)
+	// GetDeviceActivation returns the device activation details.
+	GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error)
+	// GetRandomDevAddr returns a random free DevAddr taking the NetID type
+	// and the configured DevAddr ranges into account.
+	GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error)
+	// EnqueueDownlinkMACCommand adds the downlink mac-command to the queue.
+	EnqueueDownlinkMACCommand(ctx context.Context, in *EnqueueDownlinkMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDownlinkMACCommandResponse, error)
+	// SendDownlinkData pushes the given downlink payload to the node (only works for Class-C nodes).
+	SendDownlinkData(ctx context.Context, in *SendDownlinkDataRequest, opts ...grpc.CallOption) (*SendDownlinkDataResponse, error)
+	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
+	SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*SendProprietaryPayloadResponse, error)
+	// CreateGateway creates the given gateway.
+	CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error)
+	// GetGateway returns data for a particular gateway.
+	GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error)
+	// UpdateGateway updates an existing gateway.
+	UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error)
+	// ListGateways returns the existing gateways.
+	ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error)
+	// DeleteGateway deletes a gateway.
+	DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error)
+	// GenerateGatewayToken issues a JWT token which can be used by the gateway
+	// for authentication.
+	GenerateGatewayToken(ctx context.Context, in *GenerateGatewayTokenRequest, opts ...grpc.CallOption) (*GenerateGatewayTokenResponse, error)
+	// ListGatewayTokens returns the tokens issued to the given gateway.
+	ListGatewayTokens(ctx context.Context, in *ListGatewayTokensRequest, opts ...grpc.CallOption) (*ListGatewayTokensResponse, error)
+	// RevokeGatewayToken revokes the given gateway token.
+	RevokeGatewayToken(ctx context.Context, in *RevokeGatewayTokenRequest, opts ...grpc.CallOption) (*RevokeGatewayTokenResponse, error)
+	// GetGatewayStats returns stats of an existing gateway.
+	GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error)
+	// ReportDeviceLocation reports the location of the device at the time of
+	// the given uplink, used for the coverage map.
+	ReportDeviceLocation(ctx context.Context, in *ReportDeviceLocationRequest, opts ...grpc.CallOption) (*ReportDeviceLocationResponse, error)
+	// GetCoverage returns the coverage (per gateway and geohash cell) within
+	// the given bounding box.
+	GetCoverage(ctx context.Context, in *GetCoverageRequest, opts ...grpc.CallOption) (*GetCoverageResponse, error)
+	// StartGatewayLinkTest transmits a link test frame from the given
+	// gateway. The gateways receiving it are stored as its neighbours.
+	StartGatewayLinkTest(ctx context.Context, in *StartGatewayLinkTestRequest, opts ...grpc.CallOption) (*StartGatewayLinkTestResponse, error)
+	// GetGatewayLinkTest returns the given link test and its receptions.
+	GetGatewayLinkTest(ctx context.Context, in *GetGatewayLinkTestRequest, opts ...grpc.CallOption) (*GetGatewayLinkTestResponse, error)
+	// GetGatewayNeighbours returns the gateway neighbour matrix, based on the
+	// latest link test of each gateway.
+	GetGatewayNeighbours(ctx context.Context, in *GetGatewayNeighboursRequest, opts ...grpc.CallOption) (*GetGatewayNeighboursResponse, error)
+	// GetFrameLogsForDevEUI returns the uplink / downlink frame logs for the given DevEUI.
+	GetFrameLogsForDevEUI(ctx context.Context, in *GetFrameLogsForDevEUIRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error)
+	// CreateChannelConfiguration creates the given channel-configuration.
+	CreateChannelConfiguration(ctx context.Context, in *CreateChannelConfigurationRequest, opts ...grpc.CallOption) (*CreateChannelConfigurationResponse, error)
+	// GetChannelConfiguration returns the channel-configuration for the given ID.
+	GetChannelConfiguration(ctx context.Context, in *GetChannelConfigurationRequest, opts ...grpc.CallOption) (*GetChannelConfigurationResponse, error)
+	// UpdateChannelConfiguration updates the given channel-configuration.
+	UpdateChannelConfiguration(ctx context.Context, in *UpdateChannelConfigurationRequest, opts ...grpc.CallOption) (*UpdateChannelConfigurationResponse, error)
+	// DeleteChannelConfiguration deletes the channel-configuration matching the
+	// given ID.
+	DeleteChannelConfiguration(ctx context.Context, in *DeleteChannelConfigurationRequest, opts ...grpc.CallOption) (*DeleteChannelConfigurationResponse, error)
+	// ListChannelConfigurations returns all channel-configurations.
+	ListChannelConfigurations(ctx context.Context, in *ListChannelConfigurationsRequest, opts ...grpc.CallOption) (*ListChannelConfigurationsResponse, error)
+	// CreateExtraChannel creates the given extra channel.
+	CreateExtraChannel(ctx context.Context, in *CreateExtraChannelRequest, opts ...grpc.CallOption) (*CreateExtraChannelResponse, error)
+	// UpdateExtraChannel updates the given extra channel.
+	UpdateExtraChannel(ctx context.Context, in *UpdateExtraChannelRequest, opts ...grpc.CallOption) (*UpdateExtraChannelResponse, error)
+	// DeleteExtraChannel deletes the extra channel matching the given id.
+	DeleteExtraChannel(ctx context.Context, in *DeleteExtraChannelRequest, opts ...grpc.CallOption) (*DeleteExtraChannelResponse, error)
+	// GetExtraChannelsForChannelConfigurationID returns the extra channels for
+	// the given channel-configuration id.
+	GetExtraChannelsForChannelConfigurationID(ctx context.Context, in *GetExtraChannelsForChannelConfigurationIDRequest, opts ...grpc.CallOption) (*GetExtraChannelsForChannelConfigurationIDResponse, error)
+	// MigrateNodeToDeviceSession. This method is for internal us only.
+	MigrateNodeToDeviceSession(ctx context.Context, in *MigrateNodeToDeviceSessionRequest, opts ...grpc.CallOption) (*MigrateNodeToDeviceSessionResponse, error)
+	// CreateMulticastGroup creates the given multicast-group.
+	CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast-group matching the given id.
+	GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast-group.
+	UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*UpdateMulticastGroupResponse, error)
+	// DeleteMulticastGroup deletes the multicast-group matching the given id.
+	DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*DeleteMulticastGroupResponse, error)
+	// AddDeviceToMulticastGroup adds the given device to the multicast-group.
+	AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*AddDeviceToMulticastGroupResponse, error)
+	// RemoveDeviceFromMulticastGroup removes the given device from the multicast-group.
+	RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*RemoveDeviceFromMulticastGroupResponse, error)
+	// EnqueueMulticastQueueItem sends the given payload to the multicast-group
+	// (the devices must operate in Class-C mode).
+	EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*EnqueueMulticastQueueItemResponse, error)
+	// CreateDeviceKeys creates the root-keys for the given device (used by
+	// the embedded join-server).
+	CreateDeviceKeys(ctx context.Context, in *CreateDeviceKeysRequest, opts ...grpc.CallOption) (*CreateDeviceKeysResponse, error)
+	// GetDeviceKeys returns the root-keys for the given DevEUI.
+	GetDeviceKeys(ctx context.Context, in *GetDeviceKeysRequest, opts ...grpc.CallOption) (*GetDeviceKeysResponse, error)
+	// UpdateDeviceKeys updates the root-keys for the given device.
+	UpdateDeviceKeys(ctx context.Context, in *UpdateDeviceKeysRequest, opts ...grpc.CallOption) (*UpdateDeviceKeysResponse, error)
+	// DeleteDeviceKeys deletes the root-keys for the given DevEUI.
+	DeleteDeviceKeys(ctx context.Context, in *DeleteDeviceKeysRequest, opts ...grpc.CallOption) (*DeleteDeviceKeysResponse, error)
+	// GetRejectedJoinRequestCounts returns the number of rejected
+	// join-requests (join flood protection) by reason.
+	GetRejectedJoinRequestCounts(ctx context.Context, in *GetRejectedJoinRequestCountsRequest, opts ...grpc.CallOption) (*GetRejectedJoinRequestCountsResponse, error)
+}
+
+type networkServerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNetworkServerClient(cc *grpc.ClientConn) NetworkServerClient {
+	return &networkServerClient{cc}
+}
+
+func (c *networkServerClient) CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error) {
+	out := new(CreateServiceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateServiceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error) {
+	out := new(GetServiceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetServiceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*UpdateServiceProfileResponse, error) {
+	out := new(UpdateServiceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateServiceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*DeleteServiceProfileResponse, error) {
+	out := new(DeleteServiceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteServiceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error) {
+	out := new(CreateRoutingProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateRoutingProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error) {
+	out := new(GetRoutingProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRoutingProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*UpdateRoutingProfileResponse, error) {
+	out := new(UpdateRoutingProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateRoutingProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*DeleteRoutingProfileResponse, error) {
+	out := new(DeleteRoutingProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteRoutingProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error) {
+	out := new(CreateDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error) {
+	out := new(GetDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*UpdateDeviceProfileResponse, error) {
+	out := new(UpdateDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*DeleteDeviceProfileResponse, error) {
+	out := new(DeleteDeviceProfileResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDeviceProfile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error) {
+	out := new(CreateDeviceResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error) {
+	out := new(GetDeviceResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error) {
+	out := new(UpdateDeviceResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error) {
+	out := new(DeleteDeviceResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*ActivateDeviceResponse, error) {
+	out := new(ActivateDeviceResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ActivateDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*DeactivateDeviceResponse, error) {
+	out := new(DeactivateDeviceResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeactivateDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
+	out := new(GetDeviceActivationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceActivation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
+	out := new(GetRandomDevAddrResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRandomDevAddr", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) EnqueueDownlinkMACCommand(ctx context.Context, in *EnqueueDownlinkMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDownlinkMACCommandResponse, error) {
+	out := new(EnqueueDownlinkMACCommandResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueDownlinkMACCommand", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) SendDownlinkData(ctx context.Context, in *SendDownlinkDataRequest, opts ...grpc.CallOption) (*SendDownlinkDataResponse, error) {
+	out := new(SendDownlinkDataResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/SendDownlinkData", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*SendProprietaryPayloadResponse, error) {
+	out := new(SendProprietaryPayloadResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/SendProprietaryPayload", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error) {
+	out := new(CreateGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error) {
+	out := new(GetGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error) {
+	out := new(UpdateGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error) {
+	out := new(ListGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGateways", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error) {
+	out := new(DeleteGatewayResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteGateway", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GenerateGatewayToken(ctx context.Context, in *GenerateGatewayTokenRequest, opts ...grpc.CallOption) (*GenerateGatewayTokenResponse, error) {
+	out := new(GenerateGatewayTokenResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GenerateGatewayToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ListGatewayTokens(ctx context.Context, in *ListGatewayTokensRequest, opts ...grpc.CallOption) (*ListGatewayTokensResponse, error) {
+	out := new(ListGatewayTokensResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGatewayTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) RevokeGatewayToken(ctx context.Context, in *RevokeGatewayTokenRequest, opts ...grpc.CallOption) (*RevokeGatewayTokenResponse, error) {
+	out := new(RevokeGatewayTokenResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/RevokeGatewayToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error) {
+	out := new(GetGatewayStatsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) ReportDeviceLocation(ctx context.Context, in *ReportDeviceLocationRequest, opts ...grpc.CallOption) (*ReportDeviceLocationResponse, error) {
+	out := new(ReportDeviceLocationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ReportDeviceLocation", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetCoverage(ctx context.Context, in *GetCoverageRequest, opts ...grpc.CallOption) (*GetCoverageResponse, error) {
+	out := new(GetCoverageResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetCoverage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) StartGatewayLinkTest(ctx context.Context, in *StartGatewayLinkTestRequest, opts ...grpc.CallOption) (*StartGatewayLinkTestResponse, error) {
+	out := new(StartGatewayLinkTestResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/StartGatewayLinkTest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *networkServerClient) GetGatewayLinkTest(ctx context.Context, in *GetGatewayLinkTestRequest, opts ...grpc.CallOption) (*GetGatewayLinkTestResponse, error) {
+	out := new(GetGatewayLinkTestResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayLinkTest", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type networkServerClient struct {
-	cc *grpc.ClientConn
+func (c *networkServerClient) GetGatewayNeighbours(ctx context.Context, in *GetGatewayNeighboursRequest, opts ...grpc.CallOption) (*GetGatewayNeighboursResponse, error) {
+	out := new(GetGatewayNeighboursResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayNeighbours", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func NewNetworkServerClient(cc *grpc.ClientConn) NetworkServerClient {
-	return &networkServerClient{cc}
+func (c *networkServerClient) GetFrameLogsForDevEUI(ctx context.Context, in *GetFrameLogsForDevEUIRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error) {
+	out := new(GetFrameLogsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetFrameLogsForDevEUI", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *networkServerClient) CreateServiceProfile(ctx context.Context, in *CreateServiceProfileRequest, opts ...grpc.CallOption) (*CreateServiceProfileResponse, error) {
-	out := new(CreateServiceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateServiceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) CreateChannelConfiguration(ctx context.Context, in *CreateChannelConfigurationRequest, opts ...grpc.CallOption) (*CreateChannelConfigurationResponse, error) {
+	out := new(CreateChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateChannelConfiguration", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) GetServiceProfile(ctx context.Context, in *GetServiceProfileRequest, opts ...grpc.CallOption) (*GetServiceProfileResponse, error) {
-	out := new(GetServiceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetServiceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) GetChannelConfiguration(ctx context.Context, in *GetChannelConfigurationRequest, opts ...grpc.CallOption) (*GetChannelConfigurationResponse, error) {
+	out := new(GetChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetChannelConfiguration", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) UpdateServiceProfile(ctx context.Context, in *UpdateServiceProfileRequest, opts ...grpc.CallOption) (*UpdateServiceProfileResponse, error) {
-	out := new(UpdateServiceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateServiceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) UpdateChannelConfiguration(ctx context.Context, in *UpdateChannelConfigurationRequest, opts ...grpc.CallOption) (*UpdateChannelConfigurationResponse, error) {
+	out := new(UpdateChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateChannelConfiguration", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) DeleteServiceProfile(ctx context.Context, in *DeleteServiceProfileRequest, opts ...grpc.CallOption) (*DeleteServiceProfileResponse, error) {
-	out := new(DeleteServiceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteServiceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) DeleteChannelConfiguration(ctx context.Context, in *DeleteChannelConfigurationRequest, opts ...grpc.CallOption) (*DeleteChannelConfigurationResponse, error) {
+	out := new(DeleteChannelConfigurationResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteChannelConfiguration", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) CreateRoutingProfile(ctx context.Context, in *CreateRoutingProfileRequest, opts ...grpc.CallOption) (*CreateRoutingProfileResponse, error) {
-	out := new(CreateRoutingProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateRoutingProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) ListChannelConfigurations(ctx context.Context, in *ListChannelConfigurationsRequest, opts ...grpc.CallOption) (*ListChannelConfigurationsResponse, error) {
+	out := new(ListChannelConfigurationsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListChannelConfigurations", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) GetRoutingProfile(ctx context.Context, in *GetRoutingProfileRequest, opts ...grpc.CallOption) (*GetRoutingProfileResponse, error) {
-	out := new(GetRoutingProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRoutingProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) CreateExtraChannel(ctx context.Context, in *CreateExtraChannelRequest, opts ...grpc.CallOption) (*CreateExtraChannelResponse, error) {
+	out := new(CreateExtraChannelResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateExtraChannel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) UpdateRoutingProfile(ctx context.Context, in *UpdateRoutingProfileRequest, opts ...grpc.CallOption) (*UpdateRoutingProfileResponse, error) {
-	out := new(UpdateRoutingProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateRoutingProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) UpdateExtraChannel(ctx context.Context, in *UpdateExtraChannelRequest, opts ...grpc.CallOption) (*UpdateExtraChannelResponse, error) {
+	out := new(UpdateExtraChannelResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateExtraChannel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) DeleteRoutingProfile(ctx context.Context, in *DeleteRoutingProfileRequest, opts ...grpc.CallOption) (*DeleteRoutingProfileResponse, error) {
-	out := new(DeleteRoutingProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteRoutingProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) DeleteExtraChannel(ctx context.Context, in *DeleteExtraChannelRequest, opts ...grpc.CallOption) (*DeleteExtraChannelResponse, error) {
+	out := new(DeleteExtraChannelResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteExtraChannel", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) CreateDeviceProfile(ctx context.Context, in *CreateDeviceProfileRequest, opts ...grpc.CallOption) (*CreateDeviceProfileResponse, error) {
-	out := new(CreateDeviceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDeviceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) GetExtraChannelsForChannelConfigurationID(ctx context.Context, in *GetExtraChannelsForChannelConfigurationIDRequest, opts ...grpc.CallOption) (*GetExtraChannelsForChannelConfigurationIDResponse, error) {
+	out := new(GetExtraChannelsForChannelConfigurationIDResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetExtraChannelsForChannelConfigurationID", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) GetDeviceProfile(ctx context.Context, in *GetDeviceProfileRequest, opts ...grpc.CallOption) (*GetDeviceProfileResponse, error) {
-	out := new(GetDeviceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) MigrateNodeToDeviceSession(ctx context.Context, in *MigrateNodeToDeviceSessionRequest, opts ...grpc.CallOption) (*MigrateNodeToDeviceSessionResponse, error) {
+	out := new(MigrateNodeToDeviceSessionResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/MigrateNodeToDeviceSession", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) UpdateDeviceProfile(ctx context.Context, in *UpdateDeviceProfileRequest, opts ...grpc.CallOption) (*UpdateDeviceProfileResponse, error) {
-	out := new(UpdateDeviceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDeviceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) CreateMulticastGroup(ctx context.Context, in *CreateMulticastGroupRequest, opts ...grpc.CallOption) (*CreateMulticastGroupResponse, error) {
+	out := new(CreateMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateMulticastGroup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) DeleteDeviceProfile(ctx context.Context, in *DeleteDeviceProfileRequest, opts ...grpc.CallOption) (*DeleteDeviceProfileResponse, error) {
-	out := new(DeleteDeviceProfileResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDeviceProfile", in, out, c.cc, opts...)
+func (c *networkServerClient) GetMulticastGroup(ctx context.Context, in *GetMulticastGroupRequest, opts ...grpc.CallOption) (*GetMulticastGroupResponse, error) {
+	out := new(GetMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetMulticastGroup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) CreateDevice(ctx context.Context, in *CreateDeviceRequest, opts ...grpc.CallOption) (*CreateDeviceResponse, error) {
-	out := new(CreateDeviceResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDevice", in, out, c.cc, opts...)
+func (c *networkServerClient) UpdateMulticastGroup(ctx context.Context, in *UpdateMulticastGroupRequest, opts ...grpc.CallOption) (*UpdateMulticastGroupResponse, error) {
+	out := new(UpdateMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateMulticastGroup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*GetDeviceResponse, error) {
-	out := new(GetDeviceResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDevice", in, out, c.cc, opts...)
+func (c *networkServerClient) DeleteMulticastGroup(ctx context.Context, in *DeleteMulticastGroupRequest, opts ...grpc.CallOption) (*DeleteMulticastGroupResponse, error) {
+	out := new(DeleteMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteMulticastGroup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error) {
-	out := new(UpdateDeviceResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDevice", in, out, c.cc, opts...)
+func (c *networkServerClient) AddDeviceToMulticastGroup(ctx context.Context, in *AddDeviceToMulticastGroupRequest, opts ...grpc.CallOption) (*AddDeviceToMulticastGroupResponse, error) {
+	out := new(AddDeviceToMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/AddDeviceToMulticastGroup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) DeleteDevice(ctx context.Context, in *DeleteDeviceRequest, opts ...grpc.CallOption) (*DeleteDeviceResponse, error) {
-	out := new(DeleteDeviceResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDevice", in, out, c.cc, opts...)
+func (c *networkServerClient) RemoveDeviceFromMulticastGroup(ctx context.Context, in *RemoveDeviceFromMulticastGroupRequest, opts ...grpc.CallOption) (*RemoveDeviceFromMulticastGroupResponse, error) {
+	out := new(RemoveDeviceFromMulticastGroupResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/RemoveDeviceFromMulticastGroup", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) ActivateDevice(ctx context.Context, in *ActivateDeviceRequest, opts ...grpc.CallOption) (*ActivateDeviceResponse, error) {
-	out := new(ActivateDeviceResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/ActivateDevice", in, out, c.cc, opts...)
+func (c *networkServerClient) EnqueueMulticastQueueItem(ctx context.Context, in *EnqueueMulticastQueueItemRequest, opts ...grpc.CallOption) (*EnqueueMulticastQueueItemResponse, error) {
+	out := new(EnqueueMulticastQueueItemResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueMulticastQueueItem", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) DeactivateDevice(ctx context.Context, in *DeactivateDeviceRequest, opts ...grpc.CallOption) (*DeactivateDeviceResponse, error) {
-	out := new(DeactivateDeviceResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeactivateDevice", in, out, c.cc, opts...)
+func (c *networkServerClient) CreateDeviceKeys(ctx context.Context, in *CreateDeviceKeysRequest, opts ...grpc.CallOption) (*CreateDeviceKeysResponse, error) {
+	out := new(CreateDeviceKeysResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateDeviceKeys", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) GetDeviceActivation(ctx context.Context, in *GetDeviceActivationRequest, opts ...grpc.CallOption) (*GetDeviceActivationResponse, error) {
-	out := new(GetDeviceActivationResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceActivation", in, out, c.cc, opts...)
+func (c *networkServerClient) GetDeviceKeys(ctx context.Context, in *GetDeviceKeysRequest, opts ...grpc.CallOption) (*GetDeviceKeysResponse, error) {
+	out := new(GetDeviceKeysResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetDeviceKeys", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) GetRandomDevAddr(ctx context.Context, in *GetRandomDevAddrRequest, opts ...grpc.CallOption) (*GetRandomDevAddrResponse, error) {
-	out := new(GetRandomDevAddrResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRandomDevAddr", in, out, c.cc, opts...)
+func (c *networkServerClient) UpdateDeviceKeys(ctx context.Context, in *UpdateDeviceKeysRequest, opts ...grpc.CallOption) (*UpdateDeviceKeysResponse, error) {
+	out := new(UpdateDeviceKeysResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateDeviceKeys", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) EnqueueDownlinkMACCommand(ctx context.Context, in *EnqueueDownlinkMACCommandRequest, opts ...grpc.CallOption) (*EnqueueDownlinkMACCommandResponse, error) {
-	out := new(EnqueueDownlinkMACCommandResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/EnqueueDownlinkMACCommand", in, out, c.cc, opts...)
+func (c *networkServerClient) DeleteDeviceKeys(ctx context.Context, in *DeleteDeviceKeysRequest, opts ...grpc.CallOption) (*DeleteDeviceKeysResponse, error) {
+	out := new(DeleteDeviceKeysResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteDeviceKeys", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) SendDownlinkData(ctx context.Context, in *SendDownlinkDataRequest, opts ...grpc.CallOption) (*SendDownlinkDataResponse, error) {
-	out := new(SendDownlinkDataResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/SendDownlinkData", in, out, c.cc, opts...)
+func (c *networkServerClient) GetRejectedJoinRequestCounts(ctx context.Context, in *GetRejectedJoinRequestCountsRequest, opts ...grpc.CallOption) (*GetRejectedJoinRequestCountsResponse, error) {
+	out := new(GetRejectedJoinRequestCountsResponse)
+	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetRejectedJoinRequestCounts", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *networkServerClient) SendProprietaryPayload(ctx context.Context, in *SendProprietaryPayloadRequest, opts ...grpc.CallOption) (*SendProprietaryPayloadResponse, error) {
-	out := new(SendProprietaryPayloadResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/SendProprietaryPayload", in, out, c.cc, opts...)
-	if err != nil {
+// Server API for NetworkServer service
+
+type NetworkServerServer interface {
+	// CreateServiceProfile creates the given service-profile.
+	CreateServiceProfile(context.Context, *CreateServiceProfileRequest) (*CreateServiceProfileResponse, error)
+	// GetServiceProfile returns the service-profile matching the given id.
+	GetServiceProfile(context.Context, *GetServiceProfileRequest) (*GetServiceProfileResponse, error)
+	// UpdateServiceProfile updates the given service-profile.
+	UpdateServiceProfile(context.Context, *UpdateServiceProfileRequest) (*UpdateServiceProfileResponse, error)
+	// DeleteServiceProfile deletes the service-profile matching the given id.
+	DeleteServiceProfile(context.Context, *DeleteServiceProfileRequest) (*DeleteServiceProfileResponse, error)
+	// CreateRoutingProfile creates the given routing-profile.
+	CreateRoutingProfile(context.Context, *CreateRoutingProfileRequest) (*CreateRoutingProfileResponse, error)
+	// GetRoutingProfile returns the routing-profile matching the given id.
+	GetRoutingProfile(context.Context, *GetRoutingProfileRequest) (*GetRoutingProfileResponse, error)
+	// UpdateRoutingProfile updates the given routing-profile.
+	UpdateRoutingProfile(context.Context, *UpdateRoutingProfileRequest) (*UpdateRoutingProfileResponse, error)
+	// DeleteRoutingProfile deletes the routing-profile matching the given id.
+	DeleteRoutingProfile(context.Context, *DeleteRoutingProfileRequest) (*DeleteRoutingProfileResponse, error)
+	// CreateDeviceProfile creates the given device-profile.
+	CreateDeviceProfile(context.Context, *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error)
+	// GetDeviceProfile returns the device-profile matching the given id.
+	GetDeviceProfile(context.Context, *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error)
+	// UpdateDeviceProfile updates the given device-profile.
+	UpdateDeviceProfile(context.Context, *UpdateDeviceProfileRequest) (*UpdateDeviceProfileResponse, error)
+	// DeleteDeviceProfile deletes the device-profile matching the given id.
+	DeleteDeviceProfile(context.Context, *DeleteDeviceProfileRequest) (*DeleteDeviceProfileResponse, error)
+	// CreateDevice creates the given device.
+	CreateDevice(context.Context, *CreateDeviceRequest) (*CreateDeviceResponse, error)
+	// GetDevice returns the device matching the given DevEUI.
+	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
+	// UpdateDevice updates the given device.
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error)
+	// DeleteDevice deletes the device matching the given DevEUI.
+	DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeleteDeviceResponse, error)
+	// ActivateDevice activates a device (ABP).
+	ActivateDevice(context.Context, *ActivateDeviceRequest) (*ActivateDeviceResponse, error)
+	// DeactivateDevice de-activates a device.
+	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*DeactivateDeviceResponse, error)
+	// GetDeviceActivation returns the device activation details.
+	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
+	// GetRandomDevAddr returns a random free DevAddr taking the NetID type
+	// and the configured DevAddr ranges into account.
+	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
+	// EnqueueDownlinkMACCommand adds the downlink mac-command to the queue.
+	EnqueueDownlinkMACCommand(context.Context, *EnqueueDownlinkMACCommandRequest) (*EnqueueDownlinkMACCommandResponse, error)
+	// SendDownlinkData pushes the given downlink payload to the node (only works for Class-C nodes).
+	SendDownlinkData(context.Context, *SendDownlinkDataRequest) (*SendDownlinkDataResponse, error)
+	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
+	SendProprietaryPayload(context.Context, *SendProprietaryPayloadRequest) (*SendProprietaryPayloadResponse, error)
+	// CreateGateway creates the given gateway.
+	CreateGateway(context.Context, *CreateGatewayRequest) (*CreateGatewayResponse, error)
+	// GetGateway returns data for a particular gateway.
+	GetGateway(context.Context, *GetGatewayRequest) (*GetGatewayResponse, error)
+	// UpdateGateway updates an existing gateway.
+	UpdateGateway(context.Context, *UpdateGatewayRequest) (*UpdateGatewayResponse, error)
+	// ListGateways returns the existing gateways.
+	ListGateways(context.Context, *ListGatewayRequest) (*ListGatewayResponse, error)
+	// DeleteGateway deletes a gateway.
+	DeleteGateway(context.Context, *DeleteGatewayRequest) (*DeleteGatewayResponse, error)
+	// GenerateGatewayToken issues a JWT token which can be used by the gateway
+	// for authentication.
+	GenerateGatewayToken(context.Context, *GenerateGatewayTokenRequest) (*GenerateGatewayTokenResponse, error)
+	// ListGatewayTokens returns the tokens issued to the given gateway.
+	ListGatewayTokens(context.Context, *ListGatewayTokensRequest) (*ListGatewayTokensResponse, error)
+	// RevokeGatewayToken revokes the given gateway token.
+	RevokeGatewayToken(context.Context, *RevokeGatewayTokenRequest) (*RevokeGatewayTokenResponse, error)
+	// GetGatewayStats returns stats of an existing gateway.
+	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
+	// ReportDeviceLocation reports the location of the device at the time of
+	// the given uplink, used for the coverage map.
+	ReportDeviceLocation(context.Context, *ReportDeviceLocationRequest) (*ReportDeviceLocationResponse, error)
+	// GetCoverage returns the coverage (per gateway and geohash cell) within
+	// the given bounding box.
+	GetCoverage(context.Context, *GetCoverageRequest) (*GetCoverageResponse, error)
+	// StartGatewayLinkTest transmits a link test frame from the given
+	// gateway. The gateways receiving it are stored as its neighbours.
+	StartGatewayLinkTest(context.Context, *StartGatewayLinkTestRequest) (*StartGatewayLinkTestResponse, error)
+	// GetGatewayLinkTest returns the given link test and its receptions.
+	GetGatewayLinkTest(context.Context, *GetGatewayLinkTestRequest) (*GetGatewayLinkTestResponse, error)
+	// GetGatewayNeighbours returns the gateway neighbour matrix, based on the
+	// latest link test of each gateway.
+	GetGatewayNeighbours(context.Context, *GetGatewayNeighboursRequest) (*GetGatewayNeighboursResponse, error)
+	// GetFrameLogsForDevEUI returns the uplink / downlink frame logs for the given DevEUI.
+	GetFrameLogsForDevEUI(context.Context, *GetFrameLogsForDevEUIRequest) (*GetFrameLogsResponse, error)
+	// CreateChannelConfiguration creates the given channel-configuration.
+	CreateChannelConfiguration(context.Context, *CreateChannelConfigurationRequest) (*CreateChannelConfigurationResponse, error)
+	// GetChannelConfiguration returns the channel-configuration for the given ID.
+	GetChannelConfiguration(context.Context, *GetChannelConfigurationRequest) (*GetChannelConfigurationResponse, error)
+	// UpdateChannelConfiguration updates the given channel-configuration.
+	UpdateChannelConfiguration(context.Context, *UpdateChannelConfigurationRequest) (*UpdateChannelConfigurationResponse, error)
+	// DeleteChannelConfiguration deletes the channel-configuration matching the
+	// given ID.
+	DeleteChannelConfiguration(context.Context, *DeleteChannelConfigurationRequest) (*DeleteChannelConfigurationResponse, error)
+	// ListChannelConfigurations returns all channel-configurations.
+	ListChannelConfigurations(context.Context, *ListChannelConfigurationsRequest) (*ListChannelConfigurationsResponse, error)
+	// CreateExtraChannel creates the given extra channel.
+	CreateExtraChannel(context.Context, *CreateExtraChannelRequest) (*CreateExtraChannelResponse, error)
+	// UpdateExtraChannel updates the given extra channel.
+	UpdateExtraChannel(context.Context, *UpdateExtraChannelRequest) (*UpdateExtraChannelResponse, error)
+	// DeleteExtraChannel deletes the extra channel matching the given id.
+	DeleteExtraChannel(context.Context, *DeleteExtraChannelRequest) (*DeleteExtraChannelResponse, error)
+	// GetExtraChannelsForChannelConfigurationID returns the extra channels for
+	// the given channel-configuration id.
+	GetExtraChannelsForChannelConfigurationID(context.Context, *GetExtraChannelsForChannelConfigurationIDRequest) (*GetExtraChannelsForChannelConfigurationIDResponse, error)
+	// MigrateNodeToDeviceSession. This method is for internal us only.
+	MigrateNodeToDeviceSession(context.Context, *MigrateNodeToDeviceSessionRequest) (*MigrateNodeToDeviceSessionResponse, error)
+	// CreateMulticastGroup creates the given multicast-group.
+	CreateMulticastGroup(context.Context, *CreateMulticastGroupRequest) (*CreateMulticastGroupResponse, error)
+	// GetMulticastGroup returns the multicast-group matching the given id.
+	GetMulticastGroup(context.Context, *GetMulticastGroupRequest) (*GetMulticastGroupResponse, error)
+	// UpdateMulticastGroup updates the given multicast-group.
+	UpdateMulticastGroup(context.Context, *UpdateMulticastGroupRequest) (*UpdateMulticastGroupResponse, error)
+	// DeleteMulticastGroup deletes the multicast-group matching the given id.
+	DeleteMulticastGroup(context.Context, *DeleteMulticastGroupRequest) (*DeleteMulticastGroupResponse, error)
+	// AddDeviceToMulticastGroup adds the given device to the multicast-group.
+	AddDeviceToMulticastGroup(context.Context, *AddDeviceToMulticastGroupRequest) (*AddDeviceToMulticastGroupResponse, error)
+	// RemoveDeviceFromMulticastGroup removes the given device from the multicast-group.
+	RemoveDeviceFromMulticastGroup(context.Context, *RemoveDeviceFromMulticastGroupRequest) (*RemoveDeviceFromMulticastGroupResponse, error)
+	// EnqueueMulticastQueueItem sends the given payload to the multicast-group
+	// (the devices must operate in Class-C mode).
+	EnqueueMulticastQueueItem(context.Context, *EnqueueMulticastQueueItemRequest) (*EnqueueMulticastQueueItemResponse, error)
+	// CreateDeviceKeys creates the root-keys for the given device (used by
+	// the embedded join-server).
+	CreateDeviceKeys(context.Context, *CreateDeviceKeysRequest) (*CreateDeviceKeysResponse, error)
+	// GetDeviceKeys returns the root-keys for the given DevEUI.
+	GetDeviceKeys(context.Context, *GetDeviceKeysRequest) (*GetDeviceKeysResponse, error)
+	// UpdateDeviceKeys updates the root-keys for the given device.
+	UpdateDeviceKeys(context.Context, *UpdateDeviceKeysRequest) (*UpdateDeviceKeysResponse, error)
+	// DeleteDeviceKeys deletes the root-keys for the given DevEUI.
+	DeleteDeviceKeys(context.Context, *DeleteDeviceKeysRequest) (*DeleteDeviceKeysResponse, error)
+	// GetRejectedJoinRequestCounts returns the number of rejected
+	// join-requests (join flood protection) by reason.
+	GetRejectedJoinRequestCounts(context.Context, *GetRejectedJoinRequestCountsRequest) (*GetRejectedJoinRequestCountsResponse, error)
+}
+
+func RegisterNetworkServerServer(s *grpc.Server, srv NetworkServerServer) {
+	s.RegisterService(&_NetworkServer_serviceDesc, srv)
+}
+
+func _NetworkServer_CreateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateServiceProfile(ctx, req.(*CreateServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_GetServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetServiceProfile(ctx, req.(*GetServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NetworkServer_UpdateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateServiceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateServiceProfile(ctx, req.(*UpdateServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) CreateGateway(ctx context.Context, in *CreateGatewayRequest, opts ...grpc.CallOption) (*CreateGatewayResponse, error) {
-	out := new(CreateGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeleteServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *networkServerClient) GetGateway(ctx context.Context, in *GetGatewayRequest, opts ...grpc.CallOption) (*GetGatewayResponse, error) {
-	out := new(GetGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGateway", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteServiceProfile(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteServiceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteServiceProfile(ctx, req.(*DeleteServiceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) UpdateGateway(ctx context.Context, in *UpdateGatewayRequest, opts ...grpc.CallOption) (*UpdateGatewayResponse, error) {
-	out := new(UpdateGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_CreateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoutingProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateRoutingProfile(ctx, req.(*CreateRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) ListGateways(ctx context.Context, in *ListGatewayRequest, opts ...grpc.CallOption) (*ListGatewayResponse, error) {
-	out := new(ListGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListGateways", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoutingProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetRoutingProfile(ctx, req.(*GetRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) DeleteGateway(ctx context.Context, in *DeleteGatewayRequest, opts ...grpc.CallOption) (*DeleteGatewayResponse, error) {
-	out := new(DeleteGatewayResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteGateway", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_UpdateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoutingProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateRoutingProfile(ctx, req.(*UpdateRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GenerateGatewayToken(ctx context.Context, in *GenerateGatewayTokenRequest, opts ...grpc.CallOption) (*GenerateGatewayTokenResponse, error) {
-	out := new(GenerateGatewayTokenResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GenerateGatewayToken", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeleteRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoutingProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteRoutingProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteRoutingProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteRoutingProfile(ctx, req.(*DeleteRoutingProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetGatewayStats(ctx context.Context, in *GetGatewayStatsRequest, opts ...grpc.CallOption) (*GetGatewayStatsResponse, error) {
-	out := new(GetGatewayStatsResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetGatewayStats", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_CreateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateDeviceProfile(ctx, req.(*CreateDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetFrameLogsForDevEUI(ctx context.Context, in *GetFrameLogsForDevEUIRequest, opts ...grpc.CallOption) (*GetFrameLogsResponse, error) {
-	out := new(GetFrameLogsResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetFrameLogsForDevEUI", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceProfile(ctx, req.(*GetDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) CreateChannelConfiguration(ctx context.Context, in *CreateChannelConfigurationRequest, opts ...grpc.CallOption) (*CreateChannelConfigurationResponse, error) {
-	out := new(CreateChannelConfigurationResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateChannelConfiguration", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_UpdateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateDeviceProfile(ctx, req.(*UpdateDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetChannelConfiguration(ctx context.Context, in *GetChannelConfigurationRequest, opts ...grpc.CallOption) (*GetChannelConfigurationResponse, error) {
-	out := new(GetChannelConfigurationResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetChannelConfiguration", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeleteDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceProfileRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteDeviceProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteDeviceProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteDeviceProfile(ctx, req.(*DeleteDeviceProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) UpdateChannelConfiguration(ctx context.Context, in *UpdateChannelConfigurationRequest, opts ...grpc.CallOption) (*UpdateChannelConfigurationResponse, error) {
-	out := new(UpdateChannelConfigurationResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateChannelConfiguration", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).CreateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/CreateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) DeleteChannelConfiguration(ctx context.Context, in *DeleteChannelConfigurationRequest, opts ...grpc.CallOption) (*DeleteChannelConfigurationResponse, error) {
-	out := new(DeleteChannelConfigurationResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteChannelConfiguration", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) ListChannelConfigurations(ctx context.Context, in *ListChannelConfigurationsRequest, opts ...grpc.CallOption) (*ListChannelConfigurationsResponse, error) {
-	out := new(ListChannelConfigurationsResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/ListChannelConfigurations", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).UpdateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/UpdateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) CreateExtraChannel(ctx context.Context, in *CreateExtraChannelRequest, opts ...grpc.CallOption) (*CreateExtraChannelResponse, error) {
-	out := new(CreateExtraChannelResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/CreateExtraChannel", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeleteDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeleteDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeleteDevice(ctx, req.(*DeleteDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) UpdateExtraChannel(ctx context.Context, in *UpdateExtraChannelRequest, opts ...grpc.CallOption) (*UpdateExtraChannelResponse, error) {
-	out := new(UpdateExtraChannelResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/UpdateExtraChannel", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_ActivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *networkServerClient) DeleteExtraChannel(ctx context.Context, in *DeleteExtraChannelRequest, opts ...grpc.CallOption) (*DeleteExtraChannelResponse, error) {
-	out := new(DeleteExtraChannelResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/DeleteExtraChannel", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(NetworkServerServer).ActivateDevice(ctx, in)
 	}
-	return out, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/ActivateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).ActivateDevice(ctx, req.(*ActivateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) GetExtraChannelsForChannelConfigurationID(ctx context.Context, in *GetExtraChannelsForChannelConfigurationIDRequest, opts ...grpc.CallOption) (*GetExtraChannelsForChannelConfigurationIDResponse, error) {
-	out := new(GetExtraChannelsForChannelConfigurationIDResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/GetExtraChannelsForChannelConfigurationID", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_DeactivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateDeviceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NetworkServerServer).DeactivateDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/DeactivateDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).DeactivateDevice(ctx, req.(*DeactivateDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *networkServerClient) MigrateNodeToDeviceSession(ctx context.Context, in *MigrateNodeToDeviceSessionRequest, opts ...grpc.CallOption) (*MigrateNodeToDeviceSessionResponse, error) {
-	out := new(MigrateNodeToDeviceSessionResponse)
-	err := grpc.Invoke(ctx, "/ns.NetworkServer/MigrateNodeToDeviceSession", in, out, c.cc, opts...)
-	if err != nil {
+func _NetworkServer_GetDeviceActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceActivationRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// Server API for NetworkServer service
-
-type NetworkServerServer interface {
-	// CreateServiceProfile creates the given service-profile.
-	CreateServiceProfile(context.Context, *CreateServiceProfileRequest) (*CreateServiceProfileResponse, error)
-	// GetServiceProfile returns the service-profile matching the given id.
-	GetServiceProfile(context.Context, *GetServiceProfileRequest) (*GetServiceProfileResponse, error)
-	// UpdateServiceProfile updates the given service-profile.
-	UpdateServiceProfile(context.Context, *UpdateServiceProfileRequest) (*UpdateServiceProfileResponse, error)
-	// DeleteServiceProfile deletes the service-profile matching the given id.
-	DeleteServiceProfile(context.Context, *DeleteServiceProfileRequest) (*DeleteServiceProfileResponse, error)
-	// CreateRoutingProfile creates the given routing-profile.
-	CreateRoutingProfile(context.Context, *CreateRoutingProfileRequest) (*CreateRoutingProfileResponse, error)
-	// GetRoutingProfile returns the routing-profile matching the given id.
-	GetRoutingProfile(context.Context, *GetRoutingProfileRequest) (*GetRoutingProfileResponse, error)
-	// UpdateRoutingProfile updates the given routing-profile.
-	UpdateRoutingProfile(context.Context, *UpdateRoutingProfileRequest) (*UpdateRoutingProfileResponse, error)
-	// DeleteRoutingProfile deletes the routing-profile matching the given id.
-	DeleteRoutingProfile(context.Context, *DeleteRoutingProfileRequest) (*DeleteRoutingProfileResponse, error)
-	// CreateDeviceProfile creates the given device-profile.
-	CreateDeviceProfile(context.Context, *CreateDeviceProfileRequest) (*CreateDeviceProfileResponse, error)
-	// GetDeviceProfile returns the device-profile matching the given id.
-	GetDeviceProfile(context.Context, *GetDeviceProfileRequest) (*GetDeviceProfileResponse, error)
-	// UpdateDeviceProfile updates the given device-profile.
-	UpdateDeviceProfile(context.Context, *UpdateDeviceProfileRequest) (*UpdateDeviceProfileResponse, error)
-	// DeleteDeviceProfile deletes the device-profile matching the given id.
-	DeleteDeviceProfile(context.Context, *DeleteDeviceProfileRequest) (*DeleteDeviceProfileResponse, error)
-	// CreateDevice creates the given device.
-	CreateDevice(context.Context, *CreateDeviceRequest) (*CreateDeviceResponse, error)
-	// GetDevice returns the device matching the given DevEUI.
-	GetDevice(context.Context, *GetDeviceRequest) (*GetDeviceResponse, error)
-	// UpdateDevice updates the given device.
-	UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error)
-	// DeleteDevice deletes the device matching the given DevEUI.
-	DeleteDevice(context.Context, *DeleteDeviceRequest) (*DeleteDeviceResponse, error)
-	// ActivateDevice activates a device (ABP).
-	ActivateDevice(context.Context, *ActivateDeviceRequest) (*ActivateDeviceResponse, error)
-	// DeactivateDevice de-activates a device.
-	DeactivateDevice(context.Context, *DeactivateDeviceRequest) (*DeactivateDeviceResponse, error)
-	// GetDeviceActivation returns the device activation details.
-	GetDeviceActivation(context.Context, *GetDeviceActivationRequest) (*GetDeviceActivationResponse, error)
-	// GetRandomDevAddr returns a random DevAddr taking the NwkID prefix into account.
-	GetRandomDevAddr(context.Context, *GetRandomDevAddrRequest) (*GetRandomDevAddrResponse, error)
-	// EnqueueDownlinkMACCommand adds the downlink mac-command to the queue.
-	EnqueueDownlinkMACCommand(context.Context, *EnqueueDownlinkMACCommandRequest) (*EnqueueDownlinkMACCommandResponse, error)
-	// SendDownlinkData pushes the given downlink payload to the node (only works for Class-C nodes).
-	SendDownlinkData(context.Context, *SendDownlinkDataRequest) (*SendDownlinkDataResponse, error)
-	// SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
-	SendProprietaryPayload(context.Context, *SendProprietaryPayloadRequest) (*SendProprietaryPayloadResponse, error)
-	// CreateGateway creates the given gateway.
-	CreateGateway(context.Context, *CreateGatewayRequest) (*CreateGatewayResponse, error)
-	// GetGateway returns data for a particular gateway.
-	GetGateway(context.Context, *GetGatewayRequest) (*GetGatewayResponse, error)
-	// UpdateGateway updates an existing gateway.
-	UpdateGateway(context.Context, *UpdateGatewayRequest) (*UpdateGatewayResponse, error)
-	// ListGateways returns the existing gateways.
-	ListGateways(context.Context, *ListGatewayRequest) (*ListGatewayResponse, error)
-	// DeleteGateway deletes a gateway.
-	DeleteGateway(context.Context, *DeleteGatewayRequest) (*DeleteGatewayResponse, error)
-	// GenerateGatewayToken issues a JWT token which can be used by the gateway
-	// for authentication.
-	GenerateGatewayToken(context.Context, *GenerateGatewayTokenRequest) (*GenerateGatewayTokenResponse, error)
-	// GetGatewayStats returns stats of an existing gateway.
-	GetGatewayStats(context.Context, *GetGatewayStatsRequest) (*GetGatewayStatsResponse, error)
-	// GetFrameLogsForDevEUI returns the uplink / downlink frame logs for the given DevEUI.
-	GetFrameLogsForDevEUI(context.Context, *GetFrameLogsForDevEUIRequest) (*GetFrameLogsResponse, error)
-	// CreateChannelConfiguration creates the given channel-configuration.
-	CreateChannelConfiguration(context.Context, *CreateChannelConfigurationRequest) (*CreateChannelConfigurationResponse, error)
-	// GetChannelConfiguration returns the channel-configuration for the given ID.
-	GetChannelConfiguration(context.Context, *GetChannelConfigurationRequest) (*GetChannelConfigurationResponse, error)
-	// UpdateChannelConfiguration updates the given channel-configuration.
-	UpdateChannelConfiguration(context.Context, *UpdateChannelConfigurationRequest) (*UpdateChannelConfigurationResponse, error)
-	// DeleteChannelConfiguration deletes the channel-configuration matching the
-	// given ID.
-	DeleteChannelConfiguration(context.Context, *DeleteChannelConfigurationRequest) (*DeleteChannelConfigurationResponse, error)
-	// ListChannelConfigurations returns all channel-configurations.
-	ListChannelConfigurations(context.Context, *ListChannelConfigurationsRequest) (*ListChannelConfigurationsResponse, error)
-	// CreateExtraChannel creates the given extra channel.
-	CreateExtraChannel(context.Context, *CreateExtraChannelRequest) (*CreateExtraChannelResponse, error)
-	// UpdateExtraChannel updates the given extra channel.
-	UpdateExtraChannel(context.Context, *UpdateExtraChannelRequest) (*UpdateExtraChannelResponse, error)
-	// DeleteExtraChannel deletes the extra channel matching the given id.
-	DeleteExtraChannel(context.Context, *DeleteExtraChannelRequest) (*DeleteExtraChannelResponse, error)
-	// GetExtraChannelsForChannelConfigurationID returns the extra channels for
-	// the given channel-configuration id.
-	GetExtraChannelsForChannelConfigurationID(context.Context, *GetExtraChannelsForChannelConfigurationIDRequest) (*GetExtraChannelsForChannelConfigurationIDResponse, error)
-	// MigrateNodeToDeviceSession. This method is for internal us only.
-	MigrateNodeToDeviceSession(context.Context, *MigrateNodeToDeviceSessionRequest) (*MigrateNodeToDeviceSessionResponse, error)
-}
-
-func RegisterNetworkServerServer(s *grpc.Server, srv NetworkServerServer) {
-	s.RegisterService(&_NetworkServer_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(NetworkServerServer).GetDeviceActivation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ns.NetworkServer/GetDeviceActivation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServerServer).GetDeviceActivation(ctx, req.(*GetDeviceActivationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateServiceProfileRequest)
+func _NetworkServer_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRandomDevAddrRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateServiceProfile(ctx, in)
+		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateServiceProfile",
+		FullMethod: "/ns.NetworkServer/GetRandomDevAddr",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateServiceProfile(ctx, req.(*CreateServiceProfileRequest))
+		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, req.(*GetRandomDevAddrRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetServiceProfileRequest)
+func _NetworkServer_EnqueueDownlinkMACCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueDownlinkMACCommandRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetServiceProfile(ctx, in)
+		return srv.(NetworkServerServer).EnqueueDownlinkMACCommand(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetServiceProfile",
+		FullMethod: "/ns.NetworkServer/EnqueueDownlinkMACCommand",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetServiceProfile(ctx, req.(*GetServiceProfileRequest))
+		return srv.(NetworkServerServer).EnqueueDownlinkMACCommand(ctx, req.(*EnqueueDownlinkMACCommandRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateServiceProfileRequest)
+func _NetworkServer_SendDownlinkData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendDownlinkDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateServiceProfile(ctx, in)
+		return srv.(NetworkServerServer).SendDownlinkData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateServiceProfile",
+		FullMethod: "/ns.NetworkServer/SendDownlinkData",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateServiceProfile(ctx, req.(*UpdateServiceProfileRequest))
+		return srv.(NetworkServerServer).SendDownlinkData(ctx, req.(*SendDownlinkDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteServiceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteServiceProfileRequest)
+func _NetworkServer_SendProprietaryPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendProprietaryPayloadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteServiceProfile(ctx, in)
+		return srv.(NetworkServerServer).SendProprietaryPayload(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteServiceProfile",
+		FullMethod: "/ns.NetworkServer/SendProprietaryPayload",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteServiceProfile(ctx, req.(*DeleteServiceProfileRequest))
+		return srv.(NetworkServerServer).SendProprietaryPayload(ctx, req.(*SendProprietaryPayloadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRoutingProfileRequest)
+func _NetworkServer_CreateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGatewayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateRoutingProfile(ctx, in)
+		return srv.(NetworkServerServer).CreateGateway(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateRoutingProfile",
+		FullMethod: "/ns.NetworkServer/CreateGateway",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateRoutingProfile(ctx, req.(*CreateRoutingProfileRequest))
+		return srv.(NetworkServerServer).CreateGateway(ctx, req.(*CreateGatewayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoutingProfileRequest)
+func _NetworkServer_GetGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetRoutingProfile(ctx, in)
+		return srv.(NetworkServerServer).GetGateway(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetRoutingProfile",
+		FullMethod: "/ns.NetworkServer/GetGateway",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetRoutingProfile(ctx, req.(*GetRoutingProfileRequest))
+		return srv.(NetworkServerServer).GetGateway(ctx, req.(*GetGatewayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRoutingProfileRequest)
+func _NetworkServer_UpdateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGatewayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateRoutingProfile(ctx, in)
+		return srv.(NetworkServerServer).UpdateGateway(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateRoutingProfile",
+		FullMethod: "/ns.NetworkServer/UpdateGateway",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateRoutingProfile(ctx, req.(*UpdateRoutingProfileRequest))
+		return srv.(NetworkServerServer).UpdateGateway(ctx, req.(*UpdateGatewayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteRoutingProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRoutingProfileRequest)
+func _NetworkServer_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteRoutingProfile(ctx, in)
+		return srv.(NetworkServerServer).ListGateways(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteRoutingProfile",
+		FullMethod: "/ns.NetworkServer/ListGateways",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteRoutingProfile(ctx, req.(*DeleteRoutingProfileRequest))
+		return srv.(NetworkServerServer).ListGateways(ctx, req.(*ListGatewayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDeviceProfileRequest)
+func _NetworkServer_DeleteGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGatewayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateDeviceProfile(ctx, in)
+		return srv.(NetworkServerServer).DeleteGateway(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateDeviceProfile",
+		FullMethod: "/ns.NetworkServer/DeleteGateway",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateDeviceProfile(ctx, req.(*CreateDeviceProfileRequest))
+		return srv.(NetworkServerServer).DeleteGateway(ctx, req.(*DeleteGatewayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceProfileRequest)
+func _NetworkServer_GenerateGatewayToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateGatewayTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetDeviceProfile(ctx, in)
+		return srv.(NetworkServerServer).GenerateGatewayToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetDeviceProfile",
+		FullMethod: "/ns.NetworkServer/GenerateGatewayToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetDeviceProfile(ctx, req.(*GetDeviceProfileRequest))
+		return srv.(NetworkServerServer).GenerateGatewayToken(ctx, req.(*GenerateGatewayTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDeviceProfileRequest)
+func _NetworkServer_ListGatewayTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGatewayTokensRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateDeviceProfile(ctx, in)
+		return srv.(NetworkServerServer).ListGatewayTokens(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateDeviceProfile",
+		FullMethod: "/ns.NetworkServer/ListGatewayTokens",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateDeviceProfile(ctx, req.(*UpdateDeviceProfileRequest))
+		return srv.(NetworkServerServer).ListGatewayTokens(ctx, req.(*ListGatewayTokensRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteDeviceProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDeviceProfileRequest)
+func _NetworkServer_RevokeGatewayToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeGatewayTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteDeviceProfile(ctx, in)
+		return srv.(NetworkServerServer).RevokeGatewayToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteDeviceProfile",
+		FullMethod: "/ns.NetworkServer/RevokeGatewayToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteDeviceProfile(ctx, req.(*DeleteDeviceProfileRequest))
+		return srv.(NetworkServerServer).RevokeGatewayToken(ctx, req.(*RevokeGatewayTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateDeviceRequest)
+func _NetworkServer_GetGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateDevice(ctx, in)
+		return srv.(NetworkServerServer).GetGatewayStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateDevice",
+		FullMethod: "/ns.NetworkServer/GetGatewayStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateDevice(ctx, req.(*CreateDeviceRequest))
+		return srv.(NetworkServerServer).GetGatewayStats(ctx, req.(*GetGatewayStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceRequest)
+func _NetworkServer_ReportDeviceLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportDeviceLocationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetDevice(ctx, in)
+		return srv.(NetworkServerServer).ReportDeviceLocation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetDevice",
+		FullMethod: "/ns.NetworkServer/ReportDeviceLocation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetDevice(ctx, req.(*GetDeviceRequest))
+		return srv.(NetworkServerServer).ReportDeviceLocation(ctx, req.(*ReportDeviceLocationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDeviceRequest)
+func _NetworkServer_GetCoverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCoverageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateDevice(ctx, in)
+		return srv.(NetworkServerServer).GetCoverage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateDevice",
+		FullMethod: "/ns.NetworkServer/GetCoverage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateDevice(ctx, req.(*UpdateDeviceRequest))
+		return srv.(NetworkServerServer).GetCoverage(ctx, req.(*GetCoverageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteDeviceRequest)
+func _NetworkServer_StartGatewayLinkTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGatewayLinkTestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteDevice(ctx, in)
+		return srv.(NetworkServerServer).StartGatewayLinkTest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteDevice",
+		FullMethod: "/ns.NetworkServer/StartGatewayLinkTest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteDevice(ctx, req.(*DeleteDeviceRequest))
+		return srv.(NetworkServerServer).StartGatewayLinkTest(ctx, req.(*StartGatewayLinkTestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_ActivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ActivateDeviceRequest)
+func _NetworkServer_GetGatewayLinkTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayLinkTestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).ActivateDevice(ctx, in)
+		return srv.(NetworkServerServer).GetGatewayLinkTest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/ActivateDevice",
+		FullMethod: "/ns.NetworkServer/GetGatewayLinkTest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).ActivateDevice(ctx, req.(*ActivateDeviceRequest))
+		return srv.(NetworkServerServer).GetGatewayLinkTest(ctx, req.(*GetGatewayLinkTestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeactivateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeactivateDeviceRequest)
+func _NetworkServer_GetGatewayNeighbours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGatewayNeighboursRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeactivateDevice(ctx, in)
+		return srv.(NetworkServerServer).GetGatewayNeighbours(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeactivateDevice",
+		FullMethod: "/ns.NetworkServer/GetGatewayNeighbours",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeactivateDevice(ctx, req.(*DeactivateDeviceRequest))
+		return srv.(NetworkServerServer).GetGatewayNeighbours(ctx, req.(*GetGatewayNeighboursRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetDeviceActivation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetDeviceActivationRequest)
+func _NetworkServer_GetFrameLogsForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFrameLogsForDevEUIRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetDeviceActivation(ctx, in)
+		return srv.(NetworkServerServer).GetFrameLogsForDevEUI(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetDeviceActivation",
+		FullMethod: "/ns.NetworkServer/GetFrameLogsForDevEUI",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetDeviceActivation(ctx, req.(*GetDeviceActivationRequest))
+		return srv.(NetworkServerServer).GetFrameLogsForDevEUI(ctx, req.(*GetFrameLogsForDevEUIRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetRandomDevAddr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRandomDevAddrRequest)
+func _NetworkServer_CreateChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateChannelConfigurationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, in)
+		return srv.(NetworkServerServer).CreateChannelConfiguration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetRandomDevAddr",
+		FullMethod: "/ns.NetworkServer/CreateChannelConfiguration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetRandomDevAddr(ctx, req.(*GetRandomDevAddrRequest))
+		return srv.(NetworkServerServer).CreateChannelConfiguration(ctx, req.(*CreateChannelConfigurationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_EnqueueDownlinkMACCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EnqueueDownlinkMACCommandRequest)
+func _NetworkServer_GetChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChannelConfigurationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).EnqueueDownlinkMACCommand(ctx, in)
+		return srv.(NetworkServerServer).GetChannelConfiguration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/EnqueueDownlinkMACCommand",
+		FullMethod: "/ns.NetworkServer/GetChannelConfiguration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).EnqueueDownlinkMACCommand(ctx, req.(*EnqueueDownlinkMACCommandRequest))
+		return srv.(NetworkServerServer).GetChannelConfiguration(ctx, req.(*GetChannelConfigurationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_SendDownlinkData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendDownlinkDataRequest)
+func _NetworkServer_UpdateChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateChannelConfigurationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).SendDownlinkData(ctx, in)
+		return srv.(NetworkServerServer).UpdateChannelConfiguration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/SendDownlinkData",
+		FullMethod: "/ns.NetworkServer/UpdateChannelConfiguration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).SendDownlinkData(ctx, req.(*SendDownlinkDataRequest))
+		return srv.(NetworkServerServer).UpdateChannelConfiguration(ctx, req.(*UpdateChannelConfigurationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_SendProprietaryPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendProprietaryPayloadRequest)
+func _NetworkServer_DeleteChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteChannelConfigurationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).SendProprietaryPayload(ctx, in)
+		return srv.(NetworkServerServer).DeleteChannelConfiguration(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/SendProprietaryPayload",
+		FullMethod: "/ns.NetworkServer/DeleteChannelConfiguration",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).SendProprietaryPayload(ctx, req.(*SendProprietaryPayloadRequest))
+		return srv.(NetworkServerServer).DeleteChannelConfiguration(ctx, req.(*DeleteChannelConfigurationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateGatewayRequest)
+func _NetworkServer_ListChannelConfigurations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelConfigurationsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateGateway(ctx, in)
+		return srv.(NetworkServerServer).ListChannelConfigurations(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateGateway",
+		FullMethod: "/ns.NetworkServer/ListChannelConfigurations",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateGateway(ctx, req.(*CreateGatewayRequest))
+		return srv.(NetworkServerServer).ListChannelConfigurations(ctx, req.(*ListChannelConfigurationsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayRequest)
+func _NetworkServer_CreateExtraChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExtraChannelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetGateway(ctx, in)
+		return srv.(NetworkServerServer).CreateExtraChannel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetGateway",
+		FullMethod: "/ns.NetworkServer/CreateExtraChannel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetGateway(ctx, req.(*GetGatewayRequest))
+		return srv.(NetworkServerServer).CreateExtraChannel(ctx, req.(*CreateExtraChannelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateGatewayRequest)
+func _NetworkServer_UpdateExtraChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExtraChannelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateGateway(ctx, in)
+		return srv.(NetworkServerServer).UpdateExtraChannel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateGateway",
+		FullMethod: "/ns.NetworkServer/UpdateExtraChannel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateGateway(ctx, req.(*UpdateGatewayRequest))
+		return srv.(NetworkServerServer).UpdateExtraChannel(ctx, req.(*UpdateExtraChannelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_ListGateways_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListGatewayRequest)
+func _NetworkServer_DeleteExtraChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExtraChannelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).ListGateways(ctx, in)
+		return srv.(NetworkServerServer).DeleteExtraChannel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/ListGateways",
+		FullMethod: "/ns.NetworkServer/DeleteExtraChannel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).ListGateways(ctx, req.(*ListGatewayRequest))
+		return srv.(NetworkServerServer).DeleteExtraChannel(ctx, req.(*DeleteExtraChannelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteGateway_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteGatewayRequest)
+func _NetworkServer_GetExtraChannelsForChannelConfigurationID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExtraChannelsForChannelConfigurationIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteGateway(ctx, in)
+		return srv.(NetworkServerServer).GetExtraChannelsForChannelConfigurationID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteGateway",
+		FullMethod: "/ns.NetworkServer/GetExtraChannelsForChannelConfigurationID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteGateway(ctx, req.(*DeleteGatewayRequest))
+		return srv.(NetworkServerServer).GetExtraChannelsForChannelConfigurationID(ctx, req.(*GetExtraChannelsForChannelConfigurationIDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GenerateGatewayToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GenerateGatewayTokenRequest)
+func _NetworkServer_MigrateNodeToDeviceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateNodeToDeviceSessionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GenerateGatewayToken(ctx, in)
+		return srv.(NetworkServerServer).MigrateNodeToDeviceSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GenerateGatewayToken",
+		FullMethod: "/ns.NetworkServer/MigrateNodeToDeviceSession",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GenerateGatewayToken(ctx, req.(*GenerateGatewayTokenRequest))
+		return srv.(NetworkServerServer).MigrateNodeToDeviceSession(ctx, req.(*MigrateNodeToDeviceSessionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetGatewayStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetGatewayStatsRequest)
+func _NetworkServer_CreateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetGatewayStats(ctx, in)
+		return srv.(NetworkServerServer).CreateMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetGatewayStats",
+		FullMethod: "/ns.NetworkServer/CreateMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetGatewayStats(ctx, req.(*GetGatewayStatsRequest))
+		return srv.(NetworkServerServer).CreateMulticastGroup(ctx, req.(*CreateMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetFrameLogsForDevEUI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetFrameLogsForDevEUIRequest)
+func _NetworkServer_GetMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetFrameLogsForDevEUI(ctx, in)
+		return srv.(NetworkServerServer).GetMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetFrameLogsForDevEUI",
+		FullMethod: "/ns.NetworkServer/GetMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetFrameLogsForDevEUI(ctx, req.(*GetFrameLogsForDevEUIRequest))
+		return srv.(NetworkServerServer).GetMulticastGroup(ctx, req.(*GetMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateChannelConfigurationRequest)
+func _NetworkServer_UpdateMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateChannelConfiguration(ctx, in)
+		return srv.(NetworkServerServer).UpdateMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateChannelConfiguration",
+		FullMethod: "/ns.NetworkServer/UpdateMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateChannelConfiguration(ctx, req.(*CreateChannelConfigurationRequest))
+		return srv.(NetworkServerServer).UpdateMulticastGroup(ctx, req.(*UpdateMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetChannelConfigurationRequest)
+func _NetworkServer_DeleteMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetChannelConfiguration(ctx, in)
+		return srv.(NetworkServerServer).DeleteMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetChannelConfiguration",
+		FullMethod: "/ns.NetworkServer/DeleteMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetChannelConfiguration(ctx, req.(*GetChannelConfigurationRequest))
+		return srv.(NetworkServerServer).DeleteMulticastGroup(ctx, req.(*DeleteMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateChannelConfigurationRequest)
+func _NetworkServer_AddDeviceToMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDeviceToMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateChannelConfiguration(ctx, in)
+		return srv.(NetworkServerServer).AddDeviceToMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateChannelConfiguration",
+		FullMethod: "/ns.NetworkServer/AddDeviceToMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateChannelConfiguration(ctx, req.(*UpdateChannelConfigurationRequest))
+		return srv.(NetworkServerServer).AddDeviceToMulticastGroup(ctx, req.(*AddDeviceToMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteChannelConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteChannelConfigurationRequest)
+func _NetworkServer_RemoveDeviceFromMulticastGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceFromMulticastGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteChannelConfiguration(ctx, in)
+		return srv.(NetworkServerServer).RemoveDeviceFromMulticastGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteChannelConfiguration",
+		FullMethod: "/ns.NetworkServer/RemoveDeviceFromMulticastGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteChannelConfiguration(ctx, req.(*DeleteChannelConfigurationRequest))
+		return srv.(NetworkServerServer).RemoveDeviceFromMulticastGroup(ctx, req.(*RemoveDeviceFromMulticastGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_ListChannelConfigurations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChannelConfigurationsRequest)
+func _NetworkServer_EnqueueMulticastQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnqueueMulticastQueueItemRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).ListChannelConfigurations(ctx, in)
+		return srv.(NetworkServerServer).EnqueueMulticastQueueItem(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/ListChannelConfigurations",
+		FullMethod: "/ns.NetworkServer/EnqueueMulticastQueueItem",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).ListChannelConfigurations(ctx, req.(*ListChannelConfigurationsRequest))
+		return srv.(NetworkServerServer).EnqueueMulticastQueueItem(ctx, req.(*EnqueueMulticastQueueItemRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_CreateExtraChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateExtraChannelRequest)
+func _NetworkServer_CreateDeviceKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDeviceKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).CreateExtraChannel(ctx, in)
+		return srv.(NetworkServerServer).CreateDeviceKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/CreateExtraChannel",
+		FullMethod: "/ns.NetworkServer/CreateDeviceKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).CreateExtraChannel(ctx, req.(*CreateExtraChannelRequest))
+		return srv.(NetworkServerServer).CreateDeviceKeys(ctx, req.(*CreateDeviceKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_UpdateExtraChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateExtraChannelRequest)
+func _NetworkServer_GetDeviceKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).UpdateExtraChannel(ctx, in)
+		return srv.(NetworkServerServer).GetDeviceKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/UpdateExtraChannel",
+		FullMethod: "/ns.NetworkServer/GetDeviceKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).UpdateExtraChannel(ctx, req.(*UpdateExtraChannelRequest))
+		return srv.(NetworkServerServer).GetDeviceKeys(ctx, req.(*GetDeviceKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_DeleteExtraChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteExtraChannelRequest)
+func _NetworkServer_UpdateDeviceKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDeviceKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).DeleteExtraChannel(ctx, in)
+		return srv.(NetworkServerServer).UpdateDeviceKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/DeleteExtraChannel",
+		FullMethod: "/ns.NetworkServer/UpdateDeviceKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).DeleteExtraChannel(ctx, req.(*DeleteExtraChannelRequest))
+		return srv.(NetworkServerServer).UpdateDeviceKeys(ctx, req.(*UpdateDeviceKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_GetExtraChannelsForChannelConfigurationID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetExtraChannelsForChannelConfigurationIDRequest)
+func _NetworkServer_DeleteDeviceKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeviceKeysRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).GetExtraChannelsForChannelConfigurationID(ctx, in)
+		return srv.(NetworkServerServer).DeleteDeviceKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/GetExtraChannelsForChannelConfigurationID",
+		FullMethod: "/ns.NetworkServer/DeleteDeviceKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).GetExtraChannelsForChannelConfigurationID(ctx, req.(*GetExtraChannelsForChannelConfigurationIDRequest))
+		return srv.(NetworkServerServer).DeleteDeviceKeys(ctx, req.(*DeleteDeviceKeysRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NetworkServer_MigrateNodeToDeviceSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MigrateNodeToDeviceSessionRequest)
+func _NetworkServer_GetRejectedJoinRequestCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRejectedJoinRequestCountsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NetworkServerServer).MigrateNodeToDeviceSession(ctx, in)
+		return srv.(NetworkServerServer).GetRejectedJoinRequestCounts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/ns.NetworkServer/MigrateNodeToDeviceSession",
+		FullMethod: "/ns.NetworkServer/GetRejectedJoinRequestCounts",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NetworkServerServer).MigrateNodeToDeviceSession(ctx, req.(*MigrateNodeToDeviceSessionRequest))
+		return srv.(NetworkServerServer).GetRejectedJoinRequestCounts(ctx, req.(*GetRejectedJoinRequestCountsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3972,10 +6339,38 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateGatewayToken",
 			Handler:    _NetworkServer_GenerateGatewayToken_Handler,
 		},
+		{
+			MethodName: "ListGatewayTokens",
+			Handler:    _NetworkServer_ListGatewayTokens_Handler,
+		},
+		{
+			MethodName: "RevokeGatewayToken",
+			Handler:    _NetworkServer_RevokeGatewayToken_Handler,
+		},
 		{
 			MethodName: "GetGatewayStats",
 			Handler:    _NetworkServer_GetGatewayStats_Handler,
 		},
+		{
+			MethodName: "ReportDeviceLocation",
+			Handler:    _NetworkServer_ReportDeviceLocation_Handler,
+		},
+		{
+			MethodName: "GetCoverage",
+			Handler:    _NetworkServer_GetCoverage_Handler,
+		},
+		{
+			MethodName: "StartGatewayLinkTest",
+			Handler:    _NetworkServer_StartGatewayLinkTest_Handler,
+		},
+		{
+			MethodName: "GetGatewayLinkTest",
+			Handler:    _NetworkServer_GetGatewayLinkTest_Handler,
+		},
+		{
+			MethodName: "GetGatewayNeighbours",
+			Handler:    _NetworkServer_GetGatewayNeighbours_Handler,
+		},
 		{
 			MethodName: "GetFrameLogsForDevEUI",
 			Handler:    _NetworkServer_GetFrameLogsForDevEUI_Handler,
@@ -4020,6 +6415,54 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "MigrateNodeToDeviceSession",
 			Handler:    _NetworkServer_MigrateNodeToDeviceSession_Handler,
 		},
+		{
+			MethodName: "CreateMulticastGroup",
+			Handler:    _NetworkServer_CreateMulticastGroup_Handler,
+		},
+		{
+			MethodName: "GetMulticastGroup",
+			Handler:    _NetworkServer_GetMulticastGroup_Handler,
+		},
+		{
+			MethodName: "UpdateMulticastGroup",
+			Handler:    _NetworkServer_UpdateMulticastGroup_Handler,
+		},
+		{
+			MethodName: "DeleteMulticastGroup",
+			Handler:    _NetworkServer_DeleteMulticastGroup_Handler,
+		},
+		{
+			MethodName: "AddDeviceToMulticastGroup",
+			Handler:    _NetworkServer_AddDeviceToMulticastGroup_Handler,
+		},
+		{
+			MethodName: "RemoveDeviceFromMulticastGroup",
+			Handler:    _NetworkServer_RemoveDeviceFromMulticastGroup_Handler,
+		},
+		{
+			MethodName: "EnqueueMulticastQueueItem",
+			Handler:    _NetworkServer_EnqueueMulticastQueueItem_Handler,
+		},
+		{
+			MethodName: "CreateDeviceKeys",
+			Handler:    _NetworkServer_CreateDeviceKeys_Handler,
+		},
+		{
+			MethodName: "GetDeviceKeys",
+			Handler:    _NetworkServer_GetDeviceKeys_Handler,
+		},
+		{
+			MethodName: "UpdateDeviceKeys",
+			Handler:    _NetworkServer_UpdateDeviceKeys_Handler,
+		},
+		{
+			MethodName: "DeleteDeviceKeys",
+			Handler:    _NetworkServer_DeleteDeviceKeys_Handler,
+		},
+		{
+			MethodName: "GetRejectedJoinRequestCounts",
+			Handler:    _NetworkServer_GetRejectedJoinRequestCounts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ns.proto",
@@ -4028,185 +6471,300 @@ var _NetworkServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("ns.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 2872 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x5a, 0x4b, 0x73, 0xe3, 0xc6,
-	0xf1, 0x27, 0x48, 0x91, 0xa2, 0x5a, 0x0f, 0xd3, 0x23, 0xad, 0x44, 0x61, 0xf5, 0x84, 0xd7, 0x2e,
-	0xfd, 0xf5, 0x77, 0x36, 0xf6, 0xee, 0x26, 0x29, 0x3b, 0x95, 0x03, 0x4d, 0x4a, 0x6b, 0x65, 0x57,
-	0x8f, 0x80, 0x52, 0x79, 0x5d, 0xce, 0x21, 0x30, 0x31, 0xd2, 0xc2, 0x22, 0x01, 0x1a, 0x80, 0x24,
-	0xeb, 0x0b, 0xa4, 0x72, 0x49, 0xf9, 0x90, 0xaa, 0x1c, 0x52, 0x95, 0x4b, 0xae, 0x39, 0xe7, 0x98,
-	0x4b, 0x6e, 0xb9, 0xe7, 0x33, 0xa4, 0x72, 0xc8, 0x07, 0xc8, 0x2d, 0xa9, 0x79, 0x01, 0x03, 0x70,
-	0x06, 0x14, 0xad, 0x3d, 0xa4, 0x2a, 0x37, 0x4c, 0xcf, 0x4c, 0x4f, 0xf7, 0x6f, 0xba, 0x7b, 0x66,
-	0xba, 0x01, 0x75, 0x3f, 0x7a, 0x3c, 0x0c, 0x83, 0x38, 0x40, 0x65, 0x3f, 0x32, 0x17, 0x86, 0x61,
-	0x70, 0xee, 0xf5, 0x31, 0xa7, 0x59, 0x9f, 0xc3, 0xc3, 0x76, 0x88, 0x9d, 0x18, 0x77, 0x71, 0x78,
-	0xed, 0xf5, 0xf0, 0x09, 0xeb, 0xb6, 0xf1, 0xd7, 0x57, 0x38, 0x8a, 0xd1, 0xc7, 0xb0, 0x10, 0x65,
-	0x3a, 0x9a, 0xc6, 0x96, 0xb1, 0x33, 0xfb, 0x04, 0x3d, 0xf6, 0xa3, 0xc7, 0xb9, 0x29, 0xb9, 0x91,
-	0xd6, 0x4f, 0x61, 0x4d, 0xcd, 0x3a, 0x1a, 0x06, 0x7e, 0x84, 0xd1, 0x2e, 0x34, 0xb2, 0x33, 0x0e,
-	0x3a, 0x94, 0xfb, 0x8c, 0x3d, 0x42, 0xb7, 0xf6, 0xa1, 0xf9, 0x1c, 0xc7, 0x6a, 0x19, 0x27, 0xe1,
-	0xf3, 0x1b, 0x03, 0x56, 0x15, 0x8c, 0xb8, 0x44, 0xf7, 0xd0, 0x16, 0xad, 0xc1, 0x4c, 0x8f, 0x6a,
-	0xeb, 0xb6, 0xe2, 0x66, 0x99, 0x2e, 0x9f, 0x12, 0x48, 0xef, 0xd5, 0xd0, 0xe5, 0xbd, 0x15, 0xd6,
-	0x9b, 0x10, 0xc8, 0x26, 0x9c, 0xd1, 0xc6, 0x9b, 0xdf, 0x84, 0x0d, 0x58, 0x53, 0xb3, 0x66, 0x2a,
-	0x5b, 0x07, 0xf0, 0xb0, 0x83, 0xfb, 0x58, 0xb7, 0xf4, 0x24, 0xd8, 0x6e, 0xc0, 0x9a, 0x9a, 0x15,
-	0x5f, 0xea, 0x04, 0x16, 0xec, 0xe0, 0x2a, 0xf6, 0xfc, 0x0b, 0x81, 0xd9, 0x2e, 0x34, 0xc2, 0x0c,
-	0x25, 0xe5, 0x9e, 0xa7, 0x23, 0x04, 0x53, 0x4e, 0x74, 0xd0, 0xe1, 0xd0, 0xd2, 0xef, 0xd4, 0x78,
-	0xb3, 0x7c, 0x25, 0xdc, 0xb2, 0x6c, 0x64, 0xdc, 0x72, 0x53, 0x72, 0x23, 0x53, 0xe3, 0xcd, 0xb3,
-	0x4e, 0x8d, 0xf7, 0xae, 0xa2, 0x73, 0xe3, 0x55, 0xcb, 0x38, 0x09, 0x1f, 0x6e, 0xbc, 0x1a, 0x89,
-	0xee, 0xa1, 0xed, 0x9b, 0x31, 0xde, 0x37, 0xbf, 0x09, 0x89, 0xf1, 0xaa, 0x55, 0x4e, 0x8d, 0xf7,
-	0xfe, 0xd8, 0x26, 0xc6, 0xab, 0x59, 0xea, 0x0c, 0x4c, 0x66, 0x0f, 0x1d, 0xac, 0x70, 0x93, 0x1f,
-	0xc1, 0xbc, 0x8b, 0x47, 0x1d, 0xf4, 0x6d, 0xa2, 0x63, 0x76, 0x42, 0x76, 0x9c, 0xf5, 0x5c, 0x58,
-	0x70, 0x8e, 0x2d, 0xdf, 0xd3, 0x1d, 0x78, 0x2b, 0x33, 0x3e, 0x51, 0x20, 0x4f, 0xb6, 0xda, 0xb0,
-	0xf2, 0x1c, 0xc7, 0x4a, 0xe1, 0xee, 0xce, 0xe4, 0x5b, 0x83, 0x5a, 0xaa, 0x5a, 0x96, 0xef, 0xaa,
-	0xe3, 0xbd, 0x8c, 0xeb, 0x0c, 0x4c, 0x66, 0x01, 0x6f, 0x16, 0xf6, 0x75, 0x61, 0xb3, 0x4a, 0x55,
-	0xad, 0x7d, 0x30, 0x99, 0x31, 0xdc, 0x13, 0xcf, 0x75, 0x61, 0x9f, 0xea, 0x65, 0xfe, 0x60, 0x40,
-	0x8d, 0xf5, 0xa0, 0x65, 0xa8, 0xb9, 0xf8, 0x7a, 0xef, 0xec, 0x80, 0xb2, 0x9a, 0xb3, 0x79, 0x4b,
-	0xb5, 0x56, 0x59, 0xb9, 0x96, 0x32, 0x52, 0x57, 0xd4, 0x91, 0x5a, 0xe9, 0x18, 0x53, 0x1a, 0xc7,
-	0xf8, 0x08, 0x16, 0x65, 0x0b, 0x15, 0x20, 0x58, 0x54, 0x60, 0xaf, 0x27, 0x30, 0x87, 0x14, 0x73,
-	0x9b, 0xf7, 0x58, 0xcb, 0xb0, 0x94, 0x9d, 0xca, 0xf5, 0xde, 0x85, 0x46, 0x62, 0x65, 0x82, 0x9f,
-	0x06, 0x00, 0x2b, 0x82, 0xb7, 0xa5, 0xb1, 0xdc, 0x14, 0xef, 0xb0, 0xf8, 0xbd, 0xac, 0xee, 0x23,
-	0x58, 0x94, 0xcd, 0x63, 0x42, 0x9d, 0xb3, 0x53, 0xb9, 0xce, 0xdf, 0x83, 0x45, 0xd9, 0x14, 0xc6,
-	0xa9, 0xbd, 0x0c, 0x4b, 0xd9, 0xe1, 0x9c, 0xcd, 0x9f, 0x0d, 0x78, 0xd0, 0xea, 0xc5, 0xde, 0xb5,
-	0x73, 0x47, 0x4e, 0xa8, 0x09, 0xd3, 0x2e, 0xbe, 0x6e, 0xb9, 0x6e, 0x48, 0x51, 0x98, 0xb3, 0x45,
-	0x93, 0xf4, 0xf8, 0x37, 0x97, 0xdd, 0x17, 0xf8, 0x96, 0x22, 0x30, 0x67, 0x8b, 0x26, 0xe1, 0x75,
-	0xde, 0xf6, 0xe3, 0xb3, 0x21, 0xb5, 0x8a, 0x79, 0x9b, 0xb7, 0x90, 0x09, 0x75, 0xf2, 0xd5, 0x09,
-	0x6e, 0xfc, 0x66, 0x95, 0xf6, 0x24, 0x6d, 0xf4, 0x08, 0xe6, 0xa3, 0x4b, 0x6f, 0xb8, 0xdf, 0xf6,
-	0xe3, 0xf6, 0x6b, 0xdc, 0xbb, 0x6c, 0xd6, 0xb6, 0x8c, 0x9d, 0xba, 0x9d, 0x25, 0x5a, 0x4d, 0x58,
-	0xce, 0x8b, 0xcf, 0x35, 0xfb, 0x10, 0x56, 0x3a, 0xd8, 0x99, 0x44, 0x35, 0xcb, 0x84, 0xe6, 0xe8,
-	0x14, 0xce, 0xee, 0x19, 0x98, 0x89, 0xdd, 0xf0, 0x15, 0xbd, 0xc0, 0x1f, 0xc7, 0xf1, 0x8f, 0x06,
-	0x3c, 0x54, 0x4e, 0xe3, 0x86, 0x27, 0x81, 0x69, 0x68, 0xc1, 0x2c, 0xeb, 0xc0, 0xac, 0x68, 0xc1,
-	0x9c, 0x1a, 0x07, 0x66, 0x55, 0x05, 0xe6, 0x2a, 0x8d, 0xf9, 0xb6, 0xe3, 0xbb, 0xc1, 0xa0, 0xc3,
-	0xe4, 0xe0, 0x0a, 0x5a, 0xcf, 0xd8, 0x95, 0x23, 0xdb, 0x35, 0x4e, 0x09, 0xeb, 0x57, 0x06, 0x6c,
-	0xed, 0xf9, 0x5f, 0x5f, 0xe1, 0x2b, 0x4c, 0xc4, 0xe8, 0x7b, 0xfe, 0xe5, 0x61, 0xab, 0xdd, 0x0e,
-	0x06, 0x03, 0xc7, 0x77, 0xc7, 0x19, 0xda, 0x06, 0xc0, 0x79, 0x38, 0x38, 0x71, 0x6e, 0xfb, 0x81,
-	0xe3, 0x52, 0x10, 0xea, 0xb6, 0x44, 0x41, 0x0d, 0xa8, 0xf4, 0x3c, 0x97, 0xab, 0x4a, 0x3e, 0x09,
-	0x02, 0x3d, 0xc6, 0x3b, 0x6a, 0x56, 0xb7, 0x2a, 0x3b, 0x73, 0x76, 0xd2, 0xb6, 0xde, 0x81, 0xed,
-	0x02, 0x49, 0xf8, 0x26, 0xff, 0xda, 0x80, 0x95, 0x2e, 0xf6, 0x5d, 0x31, 0xa4, 0xe3, 0xc4, 0xce,
-	0x38, 0x31, 0x11, 0x4c, 0xb9, 0x4e, 0xec, 0xf0, 0x5d, 0xa2, 0xdf, 0x34, 0x56, 0x04, 0xfe, 0xb9,
-	0x17, 0x0e, 0xb0, 0x4b, 0x77, 0xa9, 0x6e, 0xa7, 0x04, 0xb4, 0x04, 0xd5, 0xf3, 0x93, 0x20, 0x8c,
-	0xb9, 0xe8, 0xac, 0x41, 0xf8, 0x90, 0xed, 0xe2, 0x7e, 0x40, 0xbf, 0x89, 0x41, 0x8e, 0x8a, 0xc3,
-	0x65, 0xfd, 0x93, 0x01, 0xeb, 0xa4, 0xf3, 0x24, 0x0c, 0x86, 0xa1, 0x87, 0x63, 0x27, 0xbc, 0xe5,
-	0xc8, 0x08, 0x89, 0x37, 0x00, 0x06, 0x4e, 0x4f, 0x00, 0xc8, 0xa4, 0x96, 0x28, 0x04, 0xc0, 0x81,
-	0xd7, 0xe3, 0x82, 0x93, 0x4f, 0xb4, 0x05, 0xb3, 0x17, 0x4e, 0x8c, 0x6f, 0x9c, 0xdb, 0xc3, 0x56,
-	0x3b, 0x6a, 0x56, 0x28, 0x86, 0x32, 0x89, 0x48, 0xe9, 0x9d, 0x04, 0x7d, 0x2a, 0x7a, 0xdd, 0xa6,
-	0xdf, 0x44, 0xdb, 0xf3, 0x90, 0xac, 0xe9, 0xf7, 0x6e, 0xb9, 0xf8, 0x29, 0x01, 0x2d, 0x40, 0xd9,
-	0x0d, 0xa9, 0xf3, 0xce, 0xdb, 0x65, 0x37, 0xb4, 0xb6, 0x60, 0x43, 0x27, 0x36, 0xd7, 0xec, 0x9f,
-	0x86, 0x88, 0xf3, 0xcf, 0xd9, 0xca, 0x42, 0x21, 0x22, 0xb0, 0xd3, 0xe3, 0x9a, 0x90, 0x4f, 0x22,
-	0x8e, 0xef, 0x0c, 0xb0, 0xb8, 0xc4, 0x93, 0x6f, 0xa2, 0x84, 0x8b, 0xa3, 0x5e, 0xe8, 0x0d, 0x89,
-	0xab, 0xf1, 0x60, 0x2c, 0x93, 0x88, 0x9d, 0xf4, 0x9d, 0xd8, 0x8b, 0xaf, 0x5c, 0x4c, 0x15, 0x31,
-	0xec, 0xa4, 0x4d, 0x94, 0xe9, 0x07, 0xfe, 0x05, 0xeb, 0xac, 0xd2, 0xce, 0x94, 0x40, 0x66, 0x3a,
-	0x7d, 0x3e, 0xb3, 0xc6, 0x66, 0x8a, 0x36, 0xfa, 0x21, 0x2c, 0xf7, 0x5e, 0x3b, 0xbe, 0x8f, 0xfb,
-	0x6d, 0xb2, 0xd5, 0x17, 0x57, 0x21, 0xf5, 0xf5, 0x83, 0x4e, 0x73, 0x7a, 0xcb, 0xd8, 0xa9, 0xd8,
-	0x9a, 0x5e, 0x6b, 0x05, 0x1e, 0xe4, 0xb4, 0xe5, 0x38, 0xbc, 0x4b, 0x8f, 0xaa, 0x71, 0x18, 0x58,
-	0xff, 0x28, 0x03, 0x92, 0xc7, 0x71, 0xaf, 0xfc, 0xef, 0x06, 0x2b, 0x73, 0x9a, 0x4e, 0x17, 0x9e,
-	0xa6, 0xf5, 0xdc, 0x69, 0x4a, 0x64, 0x3e, 0xf7, 0xc2, 0x28, 0xee, 0x62, 0xec, 0xb7, 0xe2, 0xe6,
-	0x0c, 0x93, 0x59, 0x22, 0x11, 0xcb, 0xef, 0x3b, 0xc9, 0x00, 0xa0, 0x03, 0x24, 0x4a, 0xc1, 0x56,
-	0xcd, 0x16, 0x6e, 0x15, 0xb1, 0x4c, 0x76, 0x1a, 0xff, 0xaf, 0x58, 0x66, 0x4e, 0x5b, 0x6e, 0x99,
-	0x9f, 0x00, 0x7a, 0xe9, 0x45, 0x79, 0xd3, 0x5c, 0x82, 0x6a, 0xdf, 0x1b, 0x78, 0x31, 0x85, 0xa1,
-	0x6a, 0xb3, 0x06, 0x89, 0x9b, 0xc1, 0xf9, 0x79, 0x84, 0xd9, 0xa5, 0xa9, 0x6a, 0xf3, 0x96, 0x85,
-	0x61, 0x31, 0xc3, 0x83, 0x9b, 0xed, 0x06, 0x40, 0x1c, 0xc4, 0x4e, 0xbf, 0x1d, 0x5c, 0xf9, 0x82,
-	0x93, 0x44, 0x41, 0x8f, 0xa1, 0x16, 0xe2, 0xe8, 0xaa, 0x4f, 0xd8, 0x55, 0x76, 0x66, 0x9f, 0x2c,
-	0x93, 0x3b, 0xd3, 0xa8, 0xf9, 0xdb, 0x7c, 0x94, 0xb5, 0x23, 0x2e, 0x3e, 0x63, 0xfd, 0xe8, 0xfb,
-	0xe4, 0xa8, 0xf6, 0x71, 0x98, 0xea, 0x7b, 0x1a, 0x5c, 0x62, 0x5f, 0x3f, 0xe1, 0x19, 0xac, 0xa9,
-	0x27, 0x70, 0x55, 0x96, 0xa0, 0x1a, 0x13, 0x02, 0xbf, 0xcd, 0xb3, 0x06, 0x01, 0x35, 0x27, 0x10,
-	0x07, 0xf5, 0xef, 0x06, 0xcc, 0x71, 0x5a, 0x37, 0x76, 0xe2, 0x88, 0x6c, 0x78, 0xec, 0x0d, 0x70,
-	0x14, 0x3b, 0x83, 0x21, 0xe7, 0x91, 0x12, 0xd0, 0xfb, 0xf0, 0x76, 0xf8, 0xcd, 0x89, 0xd3, 0xbb,
-	0xc4, 0x71, 0x64, 0xe3, 0x1e, 0xf6, 0xae, 0xb1, 0xcb, 0x21, 0x1e, 0xed, 0x40, 0x1f, 0xc0, 0xe2,
-	0x08, 0xf1, 0xf8, 0x05, 0x35, 0xc1, 0xaa, 0xad, 0xea, 0x22, 0xfc, 0xe3, 0x11, 0xfe, 0x53, 0x8c,
-	0xff, 0x48, 0x07, 0x79, 0x01, 0x24, 0xc4, 0xbd, 0x81, 0x17, 0xc7, 0xd8, 0xa5, 0x36, 0x5a, 0xb5,
-	0x47, 0xe8, 0xe4, 0x52, 0xb4, 0x9c, 0xee, 0x18, 0xd5, 0x55, 0xef, 0x47, 0x4f, 0xa1, 0xee, 0xf9,
-	0x31, 0x0e, 0xaf, 0x9d, 0x3e, 0xd5, 0x6e, 0xe1, 0xc9, 0x0a, 0xd9, 0xf1, 0xd6, 0xc5, 0x45, 0x88,
-	0x2f, 0x98, 0xa1, 0xf2, 0x6e, 0x3b, 0x19, 0x88, 0xde, 0x83, 0x85, 0x28, 0x76, 0xc2, 0xf8, 0x34,
-	0x81, 0x8f, 0xf9, 0x5a, 0x8e, 0x8a, 0x2c, 0x98, 0xc3, 0xbe, 0x9b, 0x8e, 0x62, 0x6f, 0x96, 0x0c,
-	0x8d, 0x3f, 0x84, 0xb3, 0xc2, 0x26, 0xaf, 0x69, 0x61, 0x8b, 0x06, 0xb5, 0xc5, 0x06, 0xb5, 0x45,
-	0x79, 0xa4, 0xb0, 0x42, 0x97, 0x98, 0x4a, 0xbc, 0x1f, 0x3a, 0x03, 0xfc, 0x32, 0xb8, 0x88, 0xf6,
-	0x83, 0xb0, 0x43, 0x6f, 0x0f, 0xe3, 0x2e, 0x17, 0x89, 0x4b, 0x95, 0xd5, 0x2e, 0x55, 0xc9, 0xb8,
-	0xd4, 0xcf, 0x61, 0x49, 0x5e, 0xe5, 0xce, 0x3e, 0xf5, 0x28, 0xe7, 0x53, 0x73, 0x44, 0x0f, 0xc1,
-	0x26, 0xd1, 0xe1, 0xb7, 0x06, 0xd4, 0x05, 0x31, 0x1b, 0xbf, 0x8d, 0x7c, 0xfc, 0xde, 0x81, 0x99,
-	0xf0, 0x9b, 0x03, 0xff, 0x3c, 0xe8, 0x62, 0xc1, 0x93, 0xbe, 0x6d, 0xec, 0x57, 0x84, 0x68, 0xa7,
-	0x9d, 0xe4, 0x09, 0x14, 0xd3, 0x06, 0x55, 0x85, 0x0f, 0x3b, 0x65, 0xc3, 0x78, 0x0f, 0x11, 0x7f,
-	0xf8, 0x5a, 0xdc, 0x12, 0xe8, 0x1e, 0xcd, 0xd9, 0x12, 0xc5, 0xfa, 0xa5, 0x01, 0x75, 0x7a, 0x35,
-	0x72, 0x62, 0xaa, 0xeb, 0x20, 0x70, 0xaf, 0xfa, 0xd4, 0x34, 0xb8, 0x64, 0x12, 0x85, 0x08, 0xfe,
-	0xa5, 0xe3, 0xbb, 0x9f, 0x79, 0x6e, 0xfc, 0x9a, 0xa2, 0x3a, 0x6f, 0xa7, 0x04, 0x62, 0x10, 0xd1,
-	0x30, 0xc4, 0x8e, 0xbb, 0xef, 0xf4, 0xe2, 0x20, 0xe4, 0x37, 0xec, 0x0c, 0x8d, 0x5c, 0x77, 0xbf,
-	0xf4, 0x62, 0xe2, 0xf5, 0xfc, 0x02, 0x27, 0x9a, 0xd6, 0xbf, 0x0c, 0xa8, 0x31, 0x15, 0xc9, 0x20,
-	0x1e, 0x54, 0x39, 0xde, 0xa2, 0xc9, 0x2e, 0xa9, 0x2e, 0x26, 0xc2, 0xf2, 0xc3, 0x21, 0x69, 0x67,
-	0x6f, 0x52, 0x15, 0x1a, 0x9b, 0xa5, 0x9b, 0x54, 0x13, 0xa6, 0xfb, 0x81, 0xed, 0x74, 0x8f, 0x6c,
-	0x7e, 0x36, 0x88, 0x26, 0x39, 0x6c, 0xc2, 0x28, 0xf2, 0xb8, 0xc7, 0xd1, 0x6f, 0x42, 0x23, 0xc1,
-	0x82, 0x1e, 0x06, 0x33, 0x36, 0xfd, 0xce, 0x46, 0x94, 0x69, 0xa6, 0x7c, 0x1a, 0x51, 0x76, 0xa0,
-	0xee, 0x72, 0x18, 0xe9, 0xa1, 0xcb, 0x0d, 0x41, 0x40, 0x6b, 0x27, 0xbd, 0xc2, 0x4d, 0x67, 0xd2,
-	0x58, 0xf8, 0x37, 0x03, 0x6a, 0x6c, 0xdb, 0x32, 0x0a, 0x1a, 0x45, 0x0a, 0x96, 0xf3, 0x0a, 0x6e,
-	0xc1, 0xac, 0x37, 0x18, 0x60, 0xd7, 0x73, 0x62, 0xdc, 0xbf, 0xe5, 0x17, 0x67, 0x99, 0x24, 0x16,
-	0x9e, 0x4a, 0xe3, 0xc3, 0x12, 0x54, 0x87, 0xc1, 0x0d, 0x0e, 0xb9, 0xee, 0xac, 0x91, 0x55, 0xb4,
-	0x56, 0xa4, 0xe8, 0x74, 0x91, 0xa2, 0x56, 0x17, 0xb6, 0xd9, 0xdd, 0xac, 0xad, 0x38, 0x21, 0x85,
-	0xf3, 0x8a, 0xa3, 0xde, 0x90, 0x8e, 0x7a, 0x02, 0x02, 0x9b, 0x12, 0x51, 0x07, 0xa8, 0xda, 0x49,
-	0xdb, 0x7a, 0x06, 0x56, 0x11, 0x53, 0xee, 0xb4, 0x0b, 0x50, 0xf6, 0xd8, 0xad, 0xbd, 0x62, 0x97,
-	0x3d, 0xd7, 0xfa, 0x00, 0x36, 0x9e, 0xe3, 0xb8, 0x48, 0x8e, 0xfc, 0x8c, 0xdf, 0x1b, 0xb0, 0xa9,
-	0x9d, 0xa2, 0x5e, 0x45, 0x79, 0x6d, 0x91, 0x75, 0xa9, 0x64, 0x75, 0xc9, 0xc6, 0x81, 0xa9, 0xc2,
-	0x7b, 0x5c, 0x35, 0x9f, 0x15, 0xe9, 0xc1, 0x36, 0xbb, 0x5e, 0x4c, 0xa0, 0xd4, 0xa4, 0x02, 0x5a,
-	0x8f, 0xc0, 0x2a, 0x5a, 0x84, 0x9f, 0xbd, 0x4f, 0x61, 0x9b, 0x1d, 0xca, 0x93, 0xe0, 0xfb, 0x08,
-	0xac, 0xa2, 0x49, 0x9c, 0xb5, 0x05, 0x5b, 0xe4, 0x9e, 0xa3, 0x1a, 0x23, 0x8e, 0x3d, 0xeb, 0x17,
-	0xb0, 0x5d, 0x30, 0x86, 0x6f, 0xd5, 0x8f, 0x73, 0xa7, 0xcd, 0x3b, 0xfc, 0xe6, 0x53, 0xb4, 0x7a,
-	0x12, 0xbc, 0xff, 0x6d, 0xc0, 0x2a, 0x33, 0xba, 0xbd, 0x6f, 0xe2, 0xd0, 0xe1, 0x73, 0x84, 0x66,
-	0xfa, 0x0b, 0xa2, 0x51, 0x74, 0x41, 0x44, 0x8f, 0x33, 0xc1, 0x96, 0x1d, 0xcf, 0x0b, 0x44, 0xac,
-	0xc3, 0x84, 0x9a, 0x0f, 0xbe, 0xd9, 0xf8, 0x56, 0x95, 0xdd, 0x3f, 0x13, 0x9a, 0xd9, 0x4d, 0x43,
-	0x0a, 0xcd, 0x2c, 0xec, 0x52, 0x9f, 0x65, 0xae, 0x2e, 0x9a, 0x34, 0xb9, 0x21, 0x05, 0xe8, 0xa8,
-	0x59, 0xa3, 0x36, 0x90, 0x25, 0x5a, 0xef, 0x8b, 0x84, 0x7b, 0x16, 0x00, 0x8d, 0xb7, 0x7d, 0x5b,
-	0x86, 0x55, 0x66, 0x37, 0x2a, 0xbc, 0xf2, 0x46, 0xa9, 0xc7, 0xaf, 0x3c, 0x01, 0x7e, 0x95, 0xc9,
-	0xf0, 0x9b, 0x2a, 0xc4, 0xaf, 0x5a, 0x80, 0x5f, 0x6d, 0x0c, 0x7e, 0xd3, 0x2a, 0xfc, 0xd6, 0x44,
-	0xe6, 0x5c, 0x85, 0x9f, 0xf5, 0xff, 0xb0, 0xca, 0x7c, 0xe1, 0x0e, 0x70, 0x11, 0x56, 0xaa, 0xc1,
-	0x9c, 0xd5, 0x5f, 0xcb, 0xf4, 0xc6, 0x75, 0x97, 0x6d, 0xfa, 0xce, 0xc0, 0x67, 0xc2, 0x56, 0xa5,
-	0x30, 0x6c, 0x4d, 0xe5, 0x9f, 0x9f, 0xd9, 0x4d, 0xab, 0x4e, 0xb6, 0x69, 0x35, 0xcd, 0xa6, 0xdd,
-	0xd0, 0x4d, 0x9b, 0x4e, 0x37, 0xed, 0x26, 0xbf, 0x69, 0xf5, 0x31, 0x9b, 0x36, 0xa3, 0xda, 0xb4,
-	0x4f, 0xe0, 0x83, 0x1c, 0x94, 0xe4, 0xee, 0xd9, 0x56, 0x82, 0xa2, 0xdb, 0xad, 0xd7, 0xf0, 0xe1,
-	0x04, 0x3c, 0xf8, 0x46, 0x3d, 0xcd, 0x05, 0xab, 0x87, 0x3c, 0x58, 0xa9, 0x76, 0x35, 0x09, 0x52,
-	0x11, 0x6c, 0x1f, 0x7a, 0x17, 0xe4, 0x2a, 0x75, 0x14, 0xb8, 0xf8, 0x34, 0x60, 0x69, 0xd3, 0x2e,
-	0x8e, 0xa2, 0xf1, 0xa9, 0x56, 0x02, 0xd5, 0x57, 0x81, 0xe7, 0x93, 0x0e, 0x9e, 0x30, 0xe5, 0x4d,
-	0x02, 0xb1, 0x8b, 0xaf, 0x8f, 0x02, 0xbf, 0x87, 0x45, 0x4e, 0x2b, 0x25, 0x90, 0x28, 0x5e, 0xb4,
-	0x28, 0x13, 0x71, 0x77, 0x0d, 0xea, 0xf6, 0xab, 0xcf, 0x3c, 0xdf, 0x0d, 0x6e, 0xd0, 0x34, 0x54,
-	0xec, 0x57, 0x1f, 0x36, 0x4a, 0xec, 0xe3, 0x49, 0xc3, 0xd8, 0xdd, 0x04, 0x48, 0x37, 0x1f, 0xd5,
-	0x61, 0xea, 0xe5, 0xb1, 0xdd, 0x62, 0x03, 0xf6, 0xbb, 0x2f, 0x1a, 0xc6, 0x6e, 0x1f, 0x16, 0x15,
-	0x2f, 0x16, 0x04, 0x50, 0xeb, 0xee, 0xb5, 0x8f, 0x8f, 0x3a, 0x8d, 0x12, 0xf9, 0x3e, 0x3c, 0x38,
-	0x3a, 0x3b, 0xdd, 0x6b, 0x18, 0x84, 0xc3, 0xa7, 0xc7, 0x67, 0x76, 0xa3, 0x4c, 0x38, 0x74, 0x5a,
-	0x9f, 0x37, 0x2a, 0x84, 0xf4, 0xd9, 0xde, 0xde, 0x8b, 0xc6, 0x14, 0x9a, 0x81, 0xea, 0xe1, 0xf1,
-	0xd1, 0xe9, 0xa7, 0x8d, 0x2a, 0x9a, 0x85, 0xe9, 0x9f, 0x9d, 0xb5, 0xec, 0xd3, 0x3d, 0xbb, 0x51,
-	0x23, 0x23, 0x3e, 0xdf, 0x6b, 0xd9, 0x8d, 0xe9, 0x27, 0x7f, 0x59, 0x83, 0xf9, 0x23, 0x1c, 0xdf,
-	0x04, 0xe1, 0x65, 0x17, 0x87, 0xd7, 0x38, 0x44, 0x5f, 0x88, 0x8c, 0x5a, 0xb6, 0x94, 0x8e, 0x36,
-	0xc9, 0xb6, 0x14, 0xfc, 0xaf, 0x61, 0x6e, 0xe9, 0x07, 0x70, 0x77, 0x2d, 0x21, 0x9b, 0xe6, 0xa9,
-	0x72, 0x9c, 0xd7, 0xf8, 0x86, 0xab, 0xd9, 0xae, 0x6b, 0x7a, 0x13, 0x9e, 0x5f, 0x88, 0x44, 0x8b,
-	0x4a, 0xe0, 0x82, 0x7f, 0x1b, 0x98, 0xc0, 0x85, 0x7f, 0x28, 0x50, 0xe6, 0xaa, 0x1f, 0x0b, 0x18,
-	0xf3, 0x82, 0xbf, 0x17, 0x18, 0xf3, 0xc2, 0x7f, 0x12, 0x4a, 0x29, 0xd4, 0xb9, 0x7f, 0x13, 0x24,
-	0xa8, 0x95, 0xd5, 0x65, 0x19, 0x6a, 0x4d, 0xcd, 0x58, 0x40, 0x9d, 0xe3, 0x2c, 0xa0, 0x56, 0xb3,
-	0x5d, 0xd7, 0xf4, 0x8e, 0x42, 0xad, 0x12, 0xb8, 0xa0, 0x12, 0x2f, 0x43, 0x5d, 0xc4, 0x5c, 0x55,
-	0x06, 0x97, 0xa1, 0x2e, 0x60, 0x5e, 0x58, 0x41, 0x2f, 0xa1, 0x57, 0xd9, 0x52, 0xa2, 0xe0, 0xbd,
-	0x91, 0x02, 0xa9, 0xaa, 0xb7, 0x9a, 0x9b, 0xda, 0xfe, 0x84, 0xf3, 0xb1, 0x54, 0x51, 0x14, 0x6c,
-	0x45, 0x08, 0x53, 0xf2, 0x5c, 0x53, 0x77, 0xca, 0xa2, 0x2a, 0x0a, 0xc4, 0x4c, 0x54, 0x7d, 0x41,
-	0xda, 0xdc, 0xd4, 0xf6, 0xcb, 0x9c, 0x15, 0x35, 0x61, 0xc6, 0x59, 0x5f, 0x74, 0x36, 0x37, 0xb5,
-	0xfd, 0x09, 0xe7, 0x36, 0xcc, 0xc9, 0x28, 0xa1, 0x95, 0x3c, 0x6e, 0x82, 0x57, 0x73, 0xb4, 0x23,
-	0x61, 0xf2, 0x31, 0xcc, 0x24, 0xb0, 0xa0, 0xa5, 0x0c, 0x4a, 0x62, 0xfa, 0x83, 0x1c, 0x55, 0x16,
-	0x40, 0xd6, 0x9d, 0x09, 0xa0, 0x28, 0xa4, 0x32, 0x01, 0x94, 0x65, 0x52, 0xca, 0x44, 0x56, 0x93,
-	0x31, 0x51, 0x94, 0x4e, 0x19, 0x13, 0x65, 0x91, 0xb4, 0x84, 0x0e, 0x60, 0x21, 0x5b, 0x66, 0x44,
-	0xab, 0x34, 0x0b, 0xa5, 0x2a, 0x2f, 0x9a, 0xa6, 0xaa, 0x4b, 0x36, 0xad, 0x7c, 0x91, 0x91, 0x99,
-	0x96, 0xa6, 0x5a, 0xc9, 0x4c, 0x4b, 0x5b, 0x97, 0xa4, 0x06, 0xa0, 0x28, 0x31, 0x32, 0x03, 0xd0,
-	0x97, 0x2c, 0x99, 0x01, 0x14, 0xd4, 0x26, 0x13, 0x2f, 0xc8, 0x14, 0xfd, 0x12, 0x2f, 0x50, 0x55,
-	0x09, 0x13, 0x2f, 0x50, 0xd6, 0x09, 0xad, 0x12, 0xfa, 0x0a, 0x56, 0xb5, 0x45, 0x38, 0xf4, 0x88,
-	0x4c, 0x1e, 0x57, 0x2d, 0x34, 0xdf, 0x1d, 0x33, 0x4a, 0x16, 0x3e, 0x5f, 0x3b, 0x63, 0xc2, 0x6b,
-	0x0a, 0x7c, 0x4c, 0x78, 0x6d, 0xb9, 0xad, 0x84, 0x1c, 0x58, 0x56, 0x17, 0xae, 0xd0, 0xb6, 0x98,
-	0xa9, 0xad, 0xc5, 0x99, 0x56, 0xd1, 0x90, 0x64, 0x89, 0x7d, 0x98, 0xcf, 0x94, 0x82, 0x90, 0xe4,
-	0x59, 0xd9, 0xfc, 0xb5, 0xb9, 0xaa, 0xe8, 0x49, 0xf8, 0xfc, 0x04, 0x20, 0xcd, 0x59, 0xa2, 0x07,
-	0xf9, 0x14, 0x39, 0xe3, 0xa0, 0xc9, 0x9c, 0x33, 0x31, 0x32, 0x79, 0x7f, 0x24, 0xf9, 0x97, 0x4a,
-	0x0c, 0x75, 0x91, 0xa0, 0x84, 0x5a, 0x30, 0x27, 0xa5, 0xf8, 0x23, 0x44, 0x57, 0x1c, 0x2d, 0x1c,
-	0x98, 0x2b, 0x23, 0x74, 0x59, 0x94, 0x4c, 0xb6, 0x1c, 0x49, 0x5e, 0xaa, 0x12, 0x45, 0x9d, 0x5a,
-	0xa7, 0xe7, 0x90, 0x2a, 0x57, 0x8f, 0xb8, 0x17, 0x68, 0xd3, 0xfe, 0xec, 0x1c, 0x2a, 0x4a, 0xf3,
-	0x5b, 0x25, 0xf4, 0x12, 0xde, 0xca, 0xa5, 0x88, 0x91, 0x99, 0x05, 0x57, 0x4e, 0x72, 0x9b, 0x0f,
-	0x95, 0x7d, 0x09, 0xb7, 0x33, 0x78, 0xa0, 0xcc, 0x15, 0x23, 0x2e, 0x8a, 0x3e, 0x8d, 0xcc, 0x42,
-	0x98, 0x2a, 0x05, 0x6c, 0x95, 0xd0, 0x40, 0xbc, 0x7f, 0x55, 0x37, 0x77, 0xf4, 0x6e, 0x6a, 0x4e,
-	0x05, 0x29, 0x10, 0xf3, 0xbd, 0x71, 0xc3, 0x92, 0xe5, 0x5c, 0xfa, 0x88, 0x53, 0xae, 0x65, 0x15,
-	0x26, 0x2e, 0xd8, 0x42, 0x77, 0x49, 0x6e, 0x30, 0xa5, 0xf4, 0xd9, 0x1d, 0xa6, 0xd4, 0xd8, 0x14,
-	0x13, 0x53, 0xea, 0x0e, 0x49, 0x22, 0xba, 0x9c, 0x3e, 0xe3, 0xc3, 0x96, 0x1b, 0x9b, 0x46, 0x62,
-	0xcb, 0xdd, 0x21, 0x71, 0x44, 0xc3, 0xa5, 0x36, 0x2d, 0xc4, 0xc2, 0xe5, 0xb8, 0xcc, 0x12, 0x0b,
-	0x97, 0x63, 0x73, 0x4b, 0xd4, 0xea, 0xd0, 0x68, 0x7a, 0x04, 0xad, 0xa7, 0xfb, 0xad, 0x78, 0xd8,
-	0x9b, 0x1b, 0xba, 0x6e, 0x99, 0xed, 0x68, 0xd6, 0x80, 0xb1, 0xd5, 0xa6, 0x57, 0xcc, 0x0d, 0x5d,
-	0xb7, 0xcc, 0x76, 0x34, 0x83, 0xc0, 0xd8, 0x6a, 0xd3, 0x10, 0xe6, 0x86, 0xae, 0x3b, 0x61, 0xfb,
-	0x3b, 0x03, 0xfe, 0xef, 0xce, 0x6f, 0x5d, 0xf4, 0x4c, 0xf1, 0xa6, 0x1d, 0xfb, 0xbc, 0x36, 0x7f,
-	0x30, 0xe1, 0x2c, 0xd9, 0xf8, 0xf4, 0x0f, 0x55, 0x66, 0x7c, 0x63, 0x5f, 0xcf, 0xcc, 0xf8, 0xc6,
-	0xbf, 0x77, 0xad, 0xd2, 0x97, 0x35, 0xfa, 0x3f, 0xff, 0xd3, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff,
-	0xfc, 0x9e, 0x5b, 0x44, 0xef, 0x2f, 0x00, 0x00,
+	// 4713 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x3c, 0x5d, 0x6f, 0xdc, 0x48,
+	0x72, 0xe6, 0x7c, 0xe8, 0xa3, 0xf4, 0xe1, 0x59, 0x5a, 0x96, 0x46, 0xf4, 0x58, 0x1e, 0x73, 0x6d,
+	0x47, 0xab, 0xdb, 0x53, 0x6c, 0xaf, 0x2f, 0x97, 0xbd, 0xe0, 0x70, 0x99, 0x1d, 0x8d, 0xbc, 0x3a,
+	0x4b, 0xb2, 0x8f, 0x23, 0xdd, 0xee, 0xe2, 0xee, 0x70, 0xa1, 0x87, 0x2d, 0x89, 0xab, 0x19, 0x72,
+	0x96, 0xa4, 0x64, 0xe9, 0x39, 0x41, 0x10, 0x20, 0xc9, 0x1d, 0x90, 0x00, 0x79, 0x08, 0x10, 0xe4,
+	0x90, 0xd7, 0x20, 0x8f, 0x79, 0xdc, 0xb7, 0xbc, 0xe4, 0x3d, 0x3f, 0x23, 0x40, 0x7e, 0x42, 0x82,
+	0xfe, 0x22, 0xbb, 0xc9, 0x6e, 0x8e, 0xb4, 0x72, 0x80, 0x00, 0x79, 0x9b, 0xae, 0xaa, 0xae, 0xae,
+	0xae, 0xaa, 0xae, 0xfe, 0xa8, 0xe2, 0xc0, 0x4c, 0x10, 0x6f, 0x8e, 0xa3, 0x30, 0x09, 0xcd, 0x4a,
+	0x10, 0x5b, 0x8b, 0xe3, 0x28, 0x3c, 0xf2, 0x87, 0x88, 0xc1, 0xec, 0xaf, 0xe0, 0x5e, 0x37, 0x42,
+	0x6e, 0x82, 0xfa, 0x28, 0x3a, 0xf7, 0x07, 0xe8, 0x0d, 0x45, 0x3b, 0xe8, 0x9b, 0x33, 0x14, 0x27,
+	0xe6, 0x8f, 0x60, 0x31, 0x96, 0x10, 0x4d, 0xa3, 0x6d, 0xac, 0xcf, 0x3d, 0x37, 0x37, 0x83, 0x78,
+	0x33, 0xd7, 0x25, 0x47, 0x69, 0xff, 0x14, 0x5a, 0x6a, 0xd6, 0xf1, 0x38, 0x0c, 0x62, 0x64, 0x6e,
+	0x40, 0x43, 0xee, 0xb1, 0xb3, 0x45, 0xb8, 0xcf, 0x3a, 0x05, 0xb8, 0xbd, 0x0d, 0xcd, 0x97, 0x28,
+	0x51, 0xcb, 0x78, 0x1d, 0x3e, 0x7f, 0x6b, 0xc0, 0xaa, 0x82, 0x11, 0x93, 0xe8, 0x06, 0xb3, 0x35,
+	0x5b, 0x30, 0x3b, 0x20, 0xb3, 0xf5, 0x3a, 0x49, 0xb3, 0x42, 0x86, 0xcf, 0x00, 0x18, 0x7b, 0x36,
+	0xf6, 0x18, 0xb6, 0x4a, 0xb1, 0x29, 0x00, 0x1b, 0xe1, 0x90, 0x34, 0xde, 0xbf, 0x11, 0xd6, 0xa0,
+	0xa5, 0x66, 0x4d, 0xa7, 0x6c, 0xef, 0xc0, 0xbd, 0x2d, 0x34, 0x44, 0x09, 0xba, 0xb9, 0x6e, 0xd7,
+	0xa0, 0xa5, 0x66, 0xc5, 0x86, 0x7a, 0x03, 0x8b, 0x4e, 0x78, 0x96, 0xf8, 0xc1, 0x31, 0xd7, 0xd9,
+	0x06, 0x34, 0x22, 0x09, 0x92, 0x71, 0xcf, 0xc3, 0x4d, 0x13, 0x6a, 0x6e, 0xbc, 0xb3, 0xc5, 0x54,
+	0x4b, 0x7e, 0x67, 0xce, 0x2b, 0xf3, 0x15, 0xf4, 0x26, 0xb3, 0x11, 0xf5, 0x96, 0xeb, 0x92, 0xa3,
+	0xcc, 0x9c, 0x37, 0xcf, 0x3a, 0x73, 0xde, 0xab, 0x8a, 0xce, 0x9c, 0x57, 0x2d, 0xe3, 0x75, 0xf8,
+	0x30, 0xe7, 0xd5, 0x48, 0x74, 0x83, 0xd9, 0xbe, 0x1f, 0xe7, 0x7d, 0xff, 0x46, 0x48, 0x9d, 0x57,
+	0x3d, 0xe5, 0xcc, 0x79, 0x6f, 0xae, 0xdb, 0xd4, 0x79, 0x35, 0x43, 0x1d, 0x82, 0x45, 0xfd, 0x61,
+	0x0b, 0x29, 0x96, 0xc9, 0x0f, 0x61, 0xc1, 0x43, 0xc5, 0x05, 0xfa, 0x01, 0x9e, 0xa3, 0xdc, 0x41,
+	0xa6, 0xb3, 0x5f, 0x72, 0x0f, 0xce, 0xb1, 0x65, 0x36, 0x5d, 0x87, 0xdb, 0x12, 0x7d, 0x3a, 0x81,
+	0x3c, 0xd8, 0xee, 0xc2, 0xca, 0x4b, 0x94, 0x28, 0x85, 0xbb, 0x3a, 0x93, 0xdf, 0x1a, 0xd0, 0x2c,
+	0x72, 0x61, 0xb2, 0x7c, 0xd7, 0x39, 0xde, 0xc8, 0xb9, 0x0e, 0xc1, 0xa2, 0x1e, 0xf0, 0x7e, 0xd5,
+	0x7e, 0x9f, 0xfb, 0xac, 0x72, 0xaa, 0xf6, 0x36, 0x58, 0xd4, 0x19, 0x6e, 0xa8, 0xcf, 0xfb, 0x70,
+	0x4f, 0xc9, 0x87, 0x0d, 0xf3, 0x4f, 0x06, 0x4c, 0x51, 0x8c, 0xb9, 0x0c, 0x53, 0x1e, 0x3a, 0xef,
+	0x1d, 0xee, 0x10, 0x56, 0xf3, 0x0e, 0x6b, 0xa9, 0xc6, 0xaa, 0x28, 0xc7, 0x52, 0x46, 0xea, 0xaa,
+	0x3a, 0x52, 0x2b, 0x17, 0x46, 0x4d, 0xb3, 0x30, 0x3e, 0x85, 0x3b, 0xa2, 0x87, 0x72, 0x25, 0xd8,
+	0x44, 0x60, 0x7f, 0xc0, 0x75, 0x0e, 0x99, 0xce, 0x1d, 0x86, 0xb1, 0x97, 0x61, 0x49, 0xee, 0xca,
+	0xe6, 0xbd, 0x01, 0x8d, 0xd4, 0xcb, 0x38, 0x3f, 0x8d, 0x02, 0xec, 0x18, 0x3e, 0x10, 0x68, 0x99,
+	0x2b, 0x5e, 0x61, 0xf0, 0x1b, 0x79, 0xdd, 0xa7, 0x70, 0x47, 0x74, 0x8f, 0x6b, 0xce, 0x59, 0xee,
+	0xca, 0xe6, 0xfc, 0x7d, 0xb8, 0x23, 0xba, 0xc2, 0xa4, 0x69, 0x2f, 0xc3, 0x92, 0x4c, 0xce, 0xd8,
+	0x7c, 0x6b, 0xc0, 0xdd, 0xce, 0x20, 0xf1, 0xcf, 0xdd, 0x2b, 0x72, 0x32, 0x9b, 0x30, 0xed, 0xa1,
+	0xf3, 0x8e, 0xe7, 0x45, 0x44, 0x0b, 0xf3, 0x0e, 0x6f, 0x62, 0x4c, 0xf0, 0xee, 0xb4, 0xff, 0x0a,
+	0x5d, 0x12, 0x0d, 0xcc, 0x3b, 0xbc, 0x89, 0x79, 0x1d, 0x75, 0x83, 0xe4, 0x70, 0x4c, 0xbc, 0x62,
+	0xc1, 0x61, 0x2d, 0xd3, 0x82, 0x19, 0xfc, 0x6b, 0x2b, 0x7c, 0x17, 0x34, 0xeb, 0x04, 0x93, 0xb6,
+	0xcd, 0x47, 0xb0, 0x10, 0x9f, 0xfa, 0xe3, 0xed, 0x6e, 0x90, 0x74, 0x4f, 0xd0, 0xe0, 0xb4, 0x39,
+	0xd5, 0x36, 0xd6, 0x67, 0x1c, 0x19, 0x68, 0x37, 0x61, 0x39, 0x2f, 0x3e, 0x9b, 0xd9, 0x33, 0x58,
+	0xd9, 0x42, 0xee, 0x75, 0xa6, 0x66, 0x5b, 0xd0, 0x2c, 0x76, 0x61, 0xec, 0x5e, 0x80, 0x95, 0xfa,
+	0x0d, 0x1b, 0xd1, 0x0f, 0x83, 0x49, 0x1c, 0xff, 0xd9, 0x80, 0x7b, 0xca, 0x6e, 0xcc, 0xf1, 0x04,
+	0x65, 0x1a, 0x5a, 0x65, 0x56, 0x74, 0xca, 0xac, 0x6a, 0x95, 0x59, 0x9b, 0xa4, 0xcc, 0xba, 0x4a,
+	0x99, 0x3d, 0x12, 0xf3, 0x1d, 0x37, 0xf0, 0xc2, 0xd1, 0x16, 0x95, 0xe3, 0xbb, 0x9c, 0xdb, 0x5e,
+	0x40, 0xb3, 0xc8, 0x66, 0xd2, 0x84, 0xed, 0xbf, 0x30, 0xa0, 0xdd, 0x0b, 0xbe, 0x39, 0x43, 0x67,
+	0x08, 0x8b, 0x3c, 0xf4, 0x83, 0xd3, 0xbd, 0x4e, 0xb7, 0x1b, 0x8e, 0x46, 0x6e, 0xe0, 0x4d, 0x72,
+	0xca, 0x35, 0x80, 0xa3, 0x68, 0xf4, 0xc6, 0xbd, 0x1c, 0x86, 0xae, 0x47, 0x14, 0x36, 0xe3, 0x08,
+	0x10, 0xb3, 0x01, 0xd5, 0x81, 0xef, 0x31, 0xb5, 0xe0, 0x9f, 0x58, 0x5b, 0x03, 0xca, 0x3b, 0x6e,
+	0xd6, 0xdb, 0xd5, 0xf5, 0x79, 0x27, 0x6d, 0xdb, 0x1f, 0xc2, 0xc3, 0x12, 0x49, 0x98, 0x43, 0xfc,
+	0xb5, 0x01, 0x2b, 0x7d, 0x14, 0x78, 0x9c, 0x64, 0xcb, 0x4d, 0xdc, 0x49, 0x62, 0x9a, 0x50, 0xf3,
+	0xdc, 0xc4, 0x65, 0x16, 0x25, 0xbf, 0x49, 0x5c, 0x09, 0x83, 0x23, 0x3f, 0x1a, 0x21, 0x8f, 0x58,
+	0x74, 0xc6, 0xc9, 0x00, 0xe6, 0x12, 0xd4, 0x8f, 0xde, 0x84, 0x51, 0xc2, 0x44, 0xa7, 0x0d, 0xcc,
+	0x07, 0x9b, 0x96, 0xad, 0x19, 0xf2, 0x1b, 0x3b, 0x6f, 0x51, 0x1c, 0x26, 0xeb, 0xbf, 0x1a, 0x70,
+	0x1f, 0x23, 0xdf, 0x44, 0xe1, 0x38, 0xf2, 0x51, 0xe2, 0x46, 0x97, 0x4c, 0x33, 0x5c, 0xe2, 0x35,
+	0x80, 0x91, 0x3b, 0xe0, 0x0a, 0xa4, 0x52, 0x0b, 0x10, 0xac, 0xc0, 0x91, 0x3f, 0x60, 0x82, 0xe3,
+	0x9f, 0x66, 0x1b, 0xe6, 0x8e, 0xdd, 0x04, 0xbd, 0x73, 0x2f, 0xf7, 0x3a, 0xdd, 0xb8, 0x59, 0x25,
+	0x3a, 0x14, 0x41, 0x58, 0x4a, 0xff, 0x4d, 0x38, 0x24, 0xa2, 0xcf, 0x38, 0xe4, 0x37, 0x9e, 0xed,
+	0x51, 0x84, 0xc7, 0x0c, 0x06, 0x97, 0x4c, 0xfc, 0x0c, 0x60, 0x2e, 0x42, 0xc5, 0x8b, 0xc8, 0x42,
+	0x5f, 0x70, 0x2a, 0x5e, 0x64, 0xb7, 0x61, 0x4d, 0x27, 0x36, 0x9b, 0xd9, 0x7f, 0x1a, 0x7c, 0x4f,
+	0x78, 0x49, 0x47, 0xe6, 0x13, 0xc2, 0x02, 0xbb, 0x03, 0x36, 0x13, 0xfc, 0x13, 0x8b, 0x13, 0xb8,
+	0x23, 0xc4, 0x0f, 0xfc, 0xf8, 0x37, 0x9e, 0x84, 0x87, 0xe2, 0x41, 0xe4, 0x8f, 0xf1, 0xb2, 0x64,
+	0x81, 0x5b, 0x04, 0x61, 0x3f, 0x19, 0xba, 0x89, 0x9f, 0x9c, 0x79, 0x88, 0x4c, 0xc4, 0x70, 0xd2,
+	0x36, 0x9e, 0xcc, 0x30, 0x0c, 0x8e, 0x29, 0xb2, 0x4e, 0x90, 0x19, 0x00, 0xf7, 0x74, 0x87, 0xac,
+	0xe7, 0x14, 0xed, 0xc9, 0xdb, 0xe6, 0x1f, 0xc0, 0xf2, 0xe0, 0xc4, 0x0d, 0x02, 0x34, 0xec, 0x62,
+	0x53, 0x1f, 0x9f, 0x45, 0x24, 0x2e, 0xec, 0x6c, 0x35, 0xa7, 0xdb, 0xc6, 0x7a, 0xd5, 0xd1, 0x60,
+	0xed, 0x15, 0xb8, 0x9b, 0x9b, 0x2d, 0xd3, 0xc3, 0x63, 0xb2, 0xad, 0x4d, 0xd2, 0x81, 0xfd, 0x37,
+	0x35, 0x30, 0x45, 0x3a, 0xb6, 0x2a, 0xff, 0x6f, 0x2b, 0x4b, 0xda, 0x79, 0xa7, 0x4b, 0x77, 0xde,
+	0x99, 0xdc, 0xce, 0x8b, 0x65, 0x3e, 0xf2, 0xa3, 0x38, 0xe9, 0x23, 0x14, 0x74, 0x92, 0xe6, 0x2c,
+	0x95, 0x59, 0x00, 0x61, 0xcf, 0x1f, 0xba, 0x29, 0x01, 0x10, 0x02, 0x01, 0x52, 0x62, 0xaa, 0xb9,
+	0x32, 0x53, 0xe1, 0x90, 0x4b, 0x96, 0xf1, 0xf1, 0xcf, 0x51, 0x14, 0x63, 0x7d, 0xcd, 0x13, 0xd6,
+	0x32, 0xd0, 0x7c, 0x0e, 0x4b, 0x1e, 0x8a, 0xfd, 0x08, 0x79, 0x5d, 0x89, 0x78, 0x81, 0x10, 0x2b,
+	0x71, 0xe6, 0x13, 0xa8, 0xc7, 0x89, 0x9b, 0xa0, 0xe6, 0x62, 0xdb, 0x58, 0x5f, 0x7c, 0xde, 0xc0,
+	0xa7, 0x06, 0x66, 0xd1, 0x3e, 0x86, 0x3b, 0x14, 0x6d, 0x3e, 0x81, 0x45, 0xf2, 0xa3, 0x7b, 0xe2,
+	0x06, 0xc7, 0x44, 0x3d, 0xb7, 0x09, 0xd7, 0x1c, 0x94, 0xac, 0x21, 0x7a, 0xc6, 0xf8, 0xff, 0xb2,
+	0x86, 0x72, 0xb3, 0x65, 0x6b, 0xe8, 0x77, 0x06, 0x98, 0x0c, 0xf6, 0x59, 0x78, 0x16, 0x78, 0x7e,
+	0x70, 0xfc, 0x59, 0x78, 0x81, 0xe7, 0x37, 0xf2, 0x83, 0x5d, 0x3e, 0x01, 0x83, 0x88, 0x21, 0x82,
+	0x4c, 0x1b, 0xe6, 0x71, 0x33, 0x9d, 0x46, 0x85, 0x90, 0x48, 0x30, 0xc2, 0xc5, 0xbd, 0x48, 0xb9,
+	0x54, 0x19, 0x17, 0xf7, 0x42, 0xe2, 0xe2, 0x5e, 0x64, 0x5c, 0x6a, 0x8c, 0x8b, 0x00, 0xb3, 0x5d,
+	0x58, 0xe0, 0x52, 0xbb, 0x9e, 0x7f, 0x16, 0x4b, 0xaa, 0x35, 0xca, 0x54, 0x5b, 0xc9, 0xab, 0x76,
+	0x19, 0xa6, 0x22, 0xc2, 0x83, 0xc9, 0xc2, 0x5a, 0xf6, 0xb7, 0x55, 0x30, 0x77, 0xfd, 0x38, 0x1f,
+	0x4b, 0x96, 0xa0, 0x3e, 0xf4, 0x47, 0x7e, 0x42, 0x46, 0xa9, 0x3b, 0xb4, 0x81, 0x99, 0x84, 0x47,
+	0x47, 0x31, 0xa2, 0x27, 0xe2, 0xba, 0xc3, 0x5a, 0x18, 0x1e, 0x23, 0x37, 0x1a, 0x9c, 0x30, 0x77,
+	0x60, 0xad, 0x12, 0x9b, 0xd5, 0x4a, 0x17, 0xd3, 0x3a, 0x4c, 0x11, 0xa7, 0xa5, 0x7b, 0xb5, 0xca,
+	0xe7, 0x19, 0x1e, 0x3b, 0x7d, 0xb6, 0x78, 0xb7, 0xa3, 0x70, 0x44, 0xfc, 0x66, 0xd6, 0xc9, 0x41,
+	0xb1, 0xb6, 0x33, 0xc8, 0x41, 0xc8, 0xe2, 0x8a, 0x04, 0x33, 0xff, 0x10, 0xe6, 0xde, 0x66, 0x8e,
+	0x40, 0x82, 0xcb, 0xdc, 0xf3, 0x65, 0x61, 0x68, 0xc1, 0x4d, 0x1c, 0x91, 0xd4, 0xfc, 0x28, 0x55,
+	0xee, 0x6c, 0x76, 0x83, 0x94, 0x2c, 0xc7, 0xf5, 0x6d, 0x3e, 0x85, 0xe9, 0x30, 0xf2, 0x50, 0xf4,
+	0xd9, 0x25, 0x09, 0x3e, 0x8b, 0x74, 0x00, 0xc1, 0x02, 0xaf, 0x29, 0xd6, 0xe1, 0x64, 0xd8, 0xae,
+	0xe4, 0xe7, 0x16, 0x8a, 0x07, 0x24, 0x08, 0xcd, 0x38, 0x19, 0xc0, 0x46, 0x70, 0x47, 0x32, 0x1f,
+	0x0b, 0xf1, 0x6b, 0x00, 0x49, 0x98, 0xb8, 0xc3, 0x6e, 0x78, 0x16, 0x70, 0x23, 0x0a, 0x10, 0x73,
+	0x13, 0xa6, 0x22, 0x14, 0x9f, 0x0d, 0xb1, 0x25, 0xab, 0xe9, 0x34, 0x0b, 0x5b, 0x85, 0xc3, 0xa8,
+	0xec, 0x75, 0x7e, 0xa1, 0x98, 0xb8, 0xe7, 0x74, 0xf0, 0x11, 0x38, 0x40, 0x51, 0xb6, 0xe2, 0x0e,
+	0xc2, 0x53, 0x14, 0x68, 0x3b, 0x60, 0x48, 0x92, 0x0c, 0x89, 0x47, 0x55, 0x1d, 0xfc, 0xd3, 0x7e,
+	0x0b, 0x2d, 0x35, 0x0b, 0x36, 0xb9, 0x25, 0xa8, 0x27, 0x18, 0xc0, 0x8e, 0xa4, 0xb4, 0x81, 0x4f,
+	0x13, 0xbe, 0xc7, 0x42, 0x55, 0xc5, 0xf7, 0xb0, 0xde, 0xd0, 0xc5, 0xd8, 0x8f, 0x50, 0x9c, 0xdd,
+	0xd1, 0x52, 0x80, 0xfd, 0x1b, 0x03, 0xe6, 0x45, 0xe6, 0xac, 0xbb, 0x91, 0x76, 0x5f, 0x82, 0xfa,
+	0x29, 0xba, 0x4c, 0x2f, 0xcc, 0xb4, 0x21, 0x6f, 0x4e, 0x55, 0xc5, 0xe6, 0x94, 0x0d, 0x59, 0xcb,
+	0x0d, 0x89, 0xb1, 0x11, 0x3a, 0x0f, 0x4f, 0x49, 0xdf, 0x3a, 0xc5, 0xa6, 0x00, 0xfb, 0x63, 0x68,
+	0x0a, 0x86, 0x24, 0x32, 0xc5, 0x7a, 0x2d, 0xf7, 0x60, 0x55, 0x41, 0x9d, 0x3e, 0xfb, 0x70, 0xe3,
+	0x1a, 0xc4, 0xb8, 0xe2, 0xf2, 0xa1, 0x9a, 0xe4, 0x66, 0xfd, 0x31, 0xac, 0x3a, 0x44, 0x82, 0xab,
+	0x99, 0x2a, 0xa7, 0x62, 0xbb, 0x05, 0x96, 0xaa, 0x3b, 0x0b, 0xb0, 0x2b, 0x70, 0x37, 0xe7, 0x33,
+	0x0c, 0xf1, 0x5f, 0x35, 0x98, 0x17, 0x56, 0x73, 0x8c, 0x35, 0x93, 0xf8, 0x23, 0x14, 0x27, 0xee,
+	0x68, 0xcc, 0x4c, 0x90, 0x01, 0xcc, 0x8f, 0xe1, 0x83, 0xe8, 0xe2, 0x8d, 0x3b, 0x38, 0x45, 0x49,
+	0xec, 0xa0, 0x01, 0xf2, 0xcf, 0x91, 0xc7, 0x02, 0x50, 0x11, 0x61, 0x3e, 0x85, 0x3b, 0x05, 0xe0,
+	0xeb, 0x57, 0xc4, 0x56, 0x75, 0x47, 0x85, 0xc2, 0xfc, 0x93, 0x02, 0xff, 0x1a, 0xe5, 0x5f, 0x40,
+	0xe0, 0xab, 0x51, 0x0a, 0xec, 0x8d, 0xfc, 0x24, 0x41, 0x1e, 0x31, 0x66, 0xdd, 0x29, 0xc0, 0x71,
+	0xb8, 0x8e, 0x2e, 0xb6, 0x23, 0x77, 0x84, 0x62, 0x12, 0x97, 0xea, 0x4e, 0xda, 0xc6, 0x7c, 0xf8,
+	0xef, 0xae, 0xd3, 0xed, 0x45, 0x51, 0x18, 0x91, 0xa8, 0x54, 0x77, 0x0a, 0x70, 0xfc, 0x8c, 0x13,
+	0x5d, 0xf0, 0x96, 0x83, 0xa3, 0x24, 0x89, 0x4e, 0x86, 0x93, 0x07, 0x9b, 0x3d, 0x30, 0x79, 0xef,
+	0x37, 0x28, 0xea, 0xd2, 0xf0, 0xda, 0x9c, 0x25, 0x6e, 0x70, 0x57, 0x70, 0x03, 0x42, 0x42, 0x94,
+	0xef, 0x28, 0x3a, 0x98, 0x1b, 0x50, 0x8b, 0xe2, 0xd8, 0x27, 0x21, 0x4a, 0x8e, 0x81, 0x7d, 0xff,
+	0x38, 0x70, 0x87, 0xb4, 0x27, 0xa1, 0x31, 0xd7, 0xa1, 0x1a, 0x07, 0x51, 0x73, 0xae, 0x94, 0x14,
+	0x93, 0xe0, 0x33, 0xd2, 0x59, 0xe0, 0x7f, 0x73, 0xc6, 0x2e, 0xdb, 0x31, 0x39, 0x23, 0xd5, 0x1d,
+	0x19, 0x48, 0xcd, 0x41, 0x25, 0xea, 0x0f, 0x4e, 0x90, 0x77, 0x36, 0x44, 0x5e, 0x73, 0x81, 0x9b,
+	0x23, 0x87, 0xc0, 0x3c, 0x39, 0xb0, 0x33, 0x38, 0x45, 0x1e, 0x39, 0x25, 0xd5, 0x1d, 0x19, 0x68,
+	0xff, 0x0a, 0x3e, 0x28, 0x4c, 0x5c, 0xbe, 0x9c, 0x18, 0xea, 0xcb, 0x49, 0x85, 0x5f, 0x4e, 0x24,
+	0x5b, 0x56, 0x65, 0x5b, 0xda, 0x1e, 0x98, 0xc5, 0x39, 0xe3, 0xcb, 0xaf, 0x7b, 0x8e, 0x22, 0xf7,
+	0x98, 0xef, 0xd5, 0xbc, 0x89, 0x57, 0xd6, 0xf8, 0xd9, 0x53, 0xb6, 0x49, 0xe3, 0x9f, 0x04, 0xf2,
+	0x83, 0xa7, 0x6c, 0x6f, 0xc6, 0x3f, 0x09, 0xe4, 0xd3, 0xa7, 0xec, 0x58, 0x80, 0x7f, 0xe2, 0xd7,
+	0x85, 0xe5, 0x2c, 0x44, 0x53, 0xbd, 0x6a, 0x97, 0xea, 0x27, 0x30, 0xe3, 0x07, 0x09, 0x8a, 0xce,
+	0x5d, 0x1a, 0x5a, 0x17, 0x9f, 0xaf, 0x60, 0xd3, 0x74, 0x8e, 0x8f, 0x23, 0x74, 0x4c, 0xf7, 0x59,
+	0x86, 0x76, 0x52, 0x42, 0x76, 0x84, 0x8c, 0x92, 0x83, 0x74, 0x31, 0x56, 0xd3, 0x23, 0xa4, 0x00,
+	0xc5, 0xbb, 0x29, 0x0a, 0xbc, 0x8c, 0x8a, 0x86, 0x3a, 0x09, 0xc6, 0x5e, 0x94, 0x65, 0x61, 0xaf,
+	0x10, 0x9f, 0x28, 0x25, 0x8f, 0x4f, 0x1e, 0xde, 0x09, 0x12, 0xa2, 0xe5, 0xdd, 0xf0, 0x38, 0xde,
+	0x0e, 0xa3, 0x2d, 0x72, 0xb5, 0x9e, 0x74, 0xf3, 0x4e, 0x8f, 0x2f, 0x15, 0xf5, 0xf1, 0xa5, 0x2a,
+	0x1e, 0x5f, 0xec, 0x5f, 0xc2, 0x92, 0x38, 0xca, 0x95, 0x37, 0xd1, 0x47, 0xb9, 0x4d, 0x74, 0x1e,
+	0xcf, 0x83, 0xb3, 0x49, 0xe7, 0xf0, 0x77, 0x06, 0xcc, 0x70, 0xa0, 0xbc, 0x7f, 0x18, 0xf9, 0xfd,
+	0x63, 0x1d, 0x66, 0xa3, 0x8b, 0x9d, 0xe0, 0x28, 0xec, 0x23, 0xce, 0x93, 0x3c, 0x12, 0x3a, 0x5f,
+	0x62, 0xa0, 0x93, 0x21, 0xf1, 0x5b, 0x62, 0x42, 0x1a, 0x64, 0x2a, 0x8c, 0xec, 0x80, 0x92, 0x31,
+	0x0c, 0x16, 0x7f, 0x7c, 0xc2, 0xaf, 0xd0, 0xc4, 0x46, 0xf3, 0x8e, 0x00, 0xb1, 0xff, 0xdc, 0x80,
+	0x19, 0xf2, 0x6e, 0xe0, 0x26, 0x64, 0xae, 0xa3, 0xd0, 0x3b, 0x1b, 0x12, 0xd7, 0x60, 0x92, 0x09,
+	0x10, 0x2c, 0xf8, 0x5b, 0x37, 0xf0, 0xbe, 0xf0, 0xbd, 0xe4, 0x84, 0xad, 0x8a, 0x0c, 0x80, 0x1d,
+	0x22, 0x1e, 0x47, 0xc8, 0xf5, 0xb6, 0xdd, 0x41, 0x12, 0x46, 0xec, 0xa9, 0x4a, 0x82, 0xe1, 0xe5,
+	0xf0, 0xd6, 0x4f, 0xf0, 0xa6, 0xce, 0x5e, 0x37, 0x78, 0xd3, 0xfe, 0xb3, 0x1a, 0x4c, 0xd1, 0x29,
+	0x62, 0x22, 0x76, 0x26, 0x64, 0xfa, 0xe6, 0x4d, 0xfa, 0x82, 0xe3, 0x21, 0x2c, 0x2c, 0xdb, 0x81,
+	0xd2, 0xb6, 0xbc, 0x92, 0xab, 0xe4, 0x20, 0x91, 0x01, 0x30, 0xcf, 0x61, 0xe8, 0xb8, 0xfd, 0x7d,
+	0x87, 0xad, 0x26, 0xde, 0xc4, 0xf7, 0x1b, 0x12, 0xe6, 0x68, 0xfc, 0x26, 0xbf, 0x31, 0x0c, 0x6f,
+	0x3d, 0xec, 0x1c, 0x49, 0x7e, 0xcb, 0xfb, 0xd3, 0x34, 0x9d, 0x7c, 0x0a, 0x30, 0xd7, 0x61, 0xc6,
+	0x63, 0x6a, 0x64, 0x87, 0x46, 0xe2, 0x08, 0x5c, 0xb5, 0x4e, 0x8a, 0xe5, 0xcb, 0x74, 0x36, 0x5b,
+	0xa6, 0x4b, 0x50, 0x7f, 0x1b, 0xba, 0x91, 0x47, 0x22, 0xed, 0x82, 0x43, 0x1b, 0x24, 0x72, 0x04,
+	0x09, 0x0a, 0x02, 0x97, 0x84, 0xd5, 0x05, 0x87, 0x37, 0x71, 0x70, 0x3c, 0xf2, 0x03, 0x94, 0x2e,
+	0xb3, 0x83, 0xcb, 0x31, 0x62, 0x57, 0xcd, 0x22, 0x02, 0x07, 0x47, 0x09, 0x48, 0xc2, 0xe8, 0x82,
+	0x23, 0x03, 0xf1, 0x29, 0x1d, 0x05, 0x83, 0xe8, 0x72, 0x9c, 0x20, 0x6f, 0x5b, 0x22, 0x5f, 0x24,
+	0x82, 0x6a, 0xb0, 0xe6, 0x26, 0x98, 0x12, 0xa3, 0x57, 0xe4, 0xb8, 0x74, 0x9b, 0x0c, 0xa1, 0xc0,
+	0x60, 0x17, 0x8b, 0x49, 0x78, 0x74, 0xfa, 0xfd, 0x9d, 0x66, 0x83, 0x2e, 0xa7, 0x0c, 0x62, 0xff,
+	0x87, 0x01, 0x53, 0xd4, 0x85, 0x25, 0x63, 0x1b, 0x65, 0xc6, 0xae, 0xe4, 0x8d, 0xdd, 0x86, 0x39,
+	0x7f, 0x34, 0x42, 0x9e, 0xef, 0x26, 0x68, 0x78, 0xc9, 0x5e, 0xd8, 0x44, 0x10, 0x37, 0x42, 0x4d,
+	0x32, 0xc2, 0x38, 0x7c, 0x87, 0x22, 0xe6, 0x07, 0xb4, 0x21, 0x1b, 0x7d, 0xaa, 0xcc, 0xe8, 0xd3,
+	0x65, 0x46, 0xb7, 0xfb, 0xf0, 0x90, 0x3e, 0xe2, 0x74, 0x15, 0x97, 0x1d, 0x1e, 0xc8, 0xf8, 0x4d,
+	0xdb, 0x10, 0x6e, 0xda, 0x58, 0x09, 0xb4, 0x4b, 0x4c, 0x82, 0x41, 0xdd, 0x49, 0xdb, 0xf6, 0x0b,
+	0xb0, 0xcb, 0x98, 0xb2, 0x00, 0x96, 0x9d, 0x69, 0xab, 0xe4, 0xbc, 0xf6, 0x14, 0xd6, 0x5e, 0xa2,
+	0xa4, 0x4c, 0x8e, 0x7c, 0x8f, 0x7f, 0x30, 0xe0, 0x81, 0xb6, 0x8b, 0x7a, 0x14, 0xe5, 0xab, 0x81,
+	0x38, 0x97, 0xaa, 0x3c, 0x17, 0x39, 0x26, 0xd6, 0x4a, 0x1f, 0x7c, 0xea, 0xf9, 0x54, 0xcb, 0x00,
+	0x1e, 0xd2, 0xdb, 0xfd, 0x35, 0x26, 0x75, 0x5d, 0x01, 0xed, 0x47, 0x60, 0x97, 0x0d, 0xc2, 0x4e,
+	0xb5, 0x9f, 0xc0, 0x43, 0x7a, 0xdc, 0xbd, 0x8e, 0x7e, 0x1f, 0x81, 0x5d, 0xd6, 0x89, 0xb1, 0xb6,
+	0xa1, 0x8d, 0x4f, 0xfb, 0x2a, 0x1a, 0x7e, 0x04, 0xb0, 0xff, 0x04, 0x1e, 0x96, 0xd0, 0x30, 0x53,
+	0xfd, 0x51, 0x6e, 0xe7, 0xfd, 0x90, 0x5d, 0xfb, 0xca, 0x46, 0x4f, 0x37, 0xb2, 0xff, 0x36, 0x60,
+	0x95, 0x3a, 0x5d, 0xef, 0x22, 0x89, 0x5c, 0xd6, 0x87, 0xcf, 0x4c, 0x7f, 0xd7, 0x37, 0x4a, 0xef,
+	0xfa, 0x9b, 0xd2, 0xc6, 0x43, 0x8f, 0x2a, 0x8b, 0x58, 0xac, 0xbd, 0x14, 0x9a, 0xdf, 0x88, 0xe4,
+	0x58, 0x5f, 0x17, 0x97, 0xbf, 0xb4, 0x4d, 0xd1, 0x33, 0x7c, 0x06, 0x60, 0x5b, 0x10, 0x59, 0xb3,
+	0x74, 0xa9, 0xf3, 0x26, 0xc9, 0x98, 0x08, 0x9b, 0x15, 0x3e, 0xae, 0x63, 0x1f, 0x90, 0x81, 0xf6,
+	0xc7, 0x60, 0xa9, 0x14, 0xa0, 0x59, 0x6d, 0xbf, 0xad, 0xc0, 0x2a, 0xf5, 0x1b, 0x95, 0xbe, 0xf2,
+	0x4e, 0xa9, 0xd7, 0x5f, 0xe5, 0x1a, 0xfa, 0xab, 0x5e, 0x4f, 0x7f, 0xb5, 0x52, 0xfd, 0xd5, 0x4b,
+	0xf4, 0x37, 0x35, 0x41, 0x7f, 0xd3, 0x2a, 0xfd, 0xb5, 0xc0, 0x52, 0x29, 0x84, 0x79, 0xf9, 0xf7,
+	0x60, 0x95, 0xae, 0x85, 0x2b, 0xa8, 0x0b, 0xb3, 0x52, 0x11, 0x33, 0x56, 0xff, 0x5e, 0x21, 0xa7,
+	0xcf, 0xab, 0x98, 0xe9, 0x3b, 0x2b, 0x7e, 0xe2, 0x53, 0x40, 0x16, 0xb6, 0x6a, 0xf9, 0x77, 0x6a,
+	0xd9, 0x68, 0xf5, 0xeb, 0x19, 0x6d, 0x4a, 0x63, 0xb4, 0x77, 0xc4, 0x68, 0xd3, 0x99, 0xd1, 0xde,
+	0xe5, 0x8d, 0x36, 0x33, 0xc1, 0x68, 0xb3, 0x2a, 0xa3, 0x7d, 0x06, 0x4f, 0x73, 0xaa, 0xc4, 0xe7,
+	0xf0, 0xae, 0x52, 0x29, 0x3a, 0x6b, 0x9d, 0xc0, 0xb3, 0x6b, 0xf0, 0x60, 0x86, 0xfa, 0x24, 0x17,
+	0xac, 0xee, 0xb1, 0x60, 0xa5, 0xb2, 0x6a, 0x1a, 0xa4, 0x62, 0x78, 0xb8, 0xe7, 0x1f, 0x47, 0x6e,
+	0x82, 0xf6, 0x43, 0x0f, 0x1d, 0x84, 0xf4, 0x56, 0xd9, 0x47, 0x71, 0x3c, 0x39, 0x7f, 0x8b, 0x55,
+	0xf5, 0x75, 0xe8, 0x07, 0x18, 0xc1, 0xb2, 0xb0, 0xac, 0x89, 0x55, 0xec, 0xa1, 0xf3, 0xfd, 0x30,
+	0x18, 0x20, 0x9e, 0xfc, 0xca, 0x00, 0x38, 0x8a, 0x97, 0x0d, 0xca, 0x9c, 0xf2, 0xdf, 0x0c, 0x58,
+	0xdc, 0x3b, 0x1b, 0x26, 0xfe, 0xc0, 0x8d, 0x93, 0x97, 0x51, 0x78, 0x36, 0x2e, 0x3c, 0x3a, 0x2d,
+	0xc3, 0xd4, 0x68, 0x20, 0x24, 0xdb, 0x59, 0x0b, 0x0f, 0x3f, 0x1a, 0xec, 0x4b, 0xd9, 0xf6, 0x0c,
+	0x90, 0xe6, 0x07, 0x6b, 0x59, 0x7e, 0x90, 0x5d, 0x5f, 0xeb, 0xe9, 0xf5, 0xb5, 0xe0, 0x41, 0xd2,
+	0x65, 0x57, 0x95, 0xef, 0x9d, 0xd6, 0xe4, 0x7b, 0xd3, 0xaa, 0x39, 0x79, 0x2e, 0x42, 0xc1, 0xd6,
+	0x48, 0x42, 0x88, 0x05, 0x5b, 0xb9, 0x2e, 0x39, 0x4a, 0x7b, 0x93, 0x57, 0xcd, 0xe5, 0x59, 0x17,
+	0x96, 0x2e, 0x7d, 0x7f, 0xda, 0x20, 0xa9, 0x67, 0xb5, 0x1c, 0x79, 0xda, 0x7f, 0xa1, 0xd5, 0x6f,
+	0x1a, 0xce, 0x37, 0x90, 0xfa, 0x26, 0xa5, 0x22, 0x2c, 0x41, 0xde, 0x3b, 0xdc, 0x89, 0x9b, 0x35,
+	0xe2, 0x55, 0xbc, 0x99, 0xd5, 0xc5, 0xbd, 0x7f, 0x35, 0xa7, 0x75, 0x71, 0x6a, 0x65, 0xd8, 0xdf,
+	0xe7, 0x75, 0x47, 0x57, 0xd3, 0x6c, 0x5a, 0xfb, 0xa6, 0x61, 0x77, 0x04, 0xed, 0x8e, 0xe7, 0xd1,
+	0x35, 0x71, 0x10, 0xaa, 0x79, 0xea, 0x56, 0xe4, 0x06, 0x34, 0x64, 0xe1, 0xd3, 0x07, 0xd9, 0x02,
+	0x1c, 0xe7, 0xf1, 0x4b, 0xc6, 0x61, 0xc2, 0x9c, 0xc2, 0x63, 0x07, 0x8d, 0xc2, 0x73, 0xf6, 0xdc,
+	0x84, 0x93, 0x03, 0xff, 0x7b, 0x12, 0xad, 0xc3, 0x93, 0x49, 0x83, 0x31, 0xb1, 0xfe, 0x2a, 0x2b,
+	0x87, 0x48, 0x29, 0x7e, 0x86, 0x5b, 0x3b, 0x09, 0x1a, 0x09, 0x55, 0x19, 0x85, 0xa1, 0x0d, 0xf5,
+	0xd0, 0xca, 0xda, 0x83, 0xb4, 0xba, 0xa0, 0xaa, 0xaa, 0x2e, 0x10, 0xa2, 0x87, 0x50, 0x12, 0xa1,
+	0x92, 0x86, 0xc9, 0x1c, 0x01, 0xd0, 0x79, 0xbd, 0x42, 0x97, 0xb1, 0x56, 0x5f, 0xcb, 0x30, 0x15,
+	0xbc, 0x3b, 0xcd, 0x0a, 0x5b, 0x58, 0x0b, 0xc3, 0xdd, 0xf1, 0x38, 0x8b, 0x67, 0xac, 0x85, 0xd7,
+	0x0b, 0x0e, 0xba, 0x24, 0xb2, 0x32, 0x99, 0x32, 0x80, 0xbd, 0x03, 0x2b, 0x62, 0x4d, 0x18, 0x1e,
+	0x99, 0x6b, 0x67, 0x13, 0xc0, 0x4b, 0x81, 0x6c, 0x35, 0x2c, 0x66, 0x25, 0x56, 0x84, 0x54, 0xa0,
+	0xc0, 0x15, 0x14, 0x45, 0x56, 0x6c, 0x6a, 0x9b, 0xe4, 0x45, 0xa8, 0x38, 0x86, 0xae, 0xf0, 0xe7,
+	0x4f, 0x0d, 0xb8, 0x9b, 0xeb, 0xc0, 0x02, 0xcb, 0x35, 0xa5, 0xba, 0x51, 0xdd, 0xd9, 0x0e, 0xac,
+	0x88, 0xc5, 0x63, 0x37, 0x54, 0x4e, 0x91, 0x95, 0x58, 0x6a, 0x35, 0x44, 0x32, 0xee, 0x0a, 0xa5,
+	0x56, 0x43, 0xa4, 0x64, 0xf7, 0x18, 0x3e, 0x7c, 0x89, 0x12, 0x07, 0x7d, 0x8d, 0x06, 0x09, 0xf2,
+	0x7e, 0x1a, 0xfa, 0x7c, 0x9b, 0x26, 0x8f, 0x6c, 0xe9, 0xfd, 0xe6, 0x73, 0x68, 0xea, 0x68, 0xf0,
+	0xb0, 0x11, 0x72, 0xe3, 0xf4, 0xe1, 0x8a, 0xb5, 0xb0, 0xc3, 0x0f, 0x30, 0x01, 0x51, 0x64, 0xcd,
+	0xa1, 0x0d, 0xfb, 0x97, 0xf0, 0xa8, 0x7c, 0x40, 0x66, 0xba, 0x17, 0x30, 0x45, 0x3a, 0xc4, 0xec,
+	0xfc, 0xd1, 0x22, 0x4f, 0x71, 0x9a, 0x6e, 0x0e, 0xa3, 0xc5, 0xae, 0x70, 0xcf, 0x41, 0xe3, 0x30,
+	0x62, 0xde, 0xb0, 0x1b, 0x0e, 0xae, 0x52, 0x3b, 0x96, 0x2e, 0xc3, 0x8a, 0xb0, 0x89, 0x8b, 0xe9,
+	0xde, 0x6a, 0x59, 0xba, 0xb7, 0x96, 0x4b, 0xf7, 0xe2, 0x98, 0xac, 0x16, 0x82, 0x29, 0xfd, 0x37,
+	0x06, 0xa9, 0x0c, 0xe9, 0x86, 0xf4, 0x45, 0x9a, 0x0b, 0x97, 0x4b, 0x81, 0x1a, 0x57, 0x4f, 0x81,
+	0xb6, 0x60, 0x76, 0x1c, 0xa1, 0x81, 0x1f, 0xf3, 0x5b, 0x5c, 0xdd, 0xc9, 0x00, 0x93, 0xab, 0x87,
+	0xec, 0x7f, 0x34, 0xe0, 0x36, 0x1b, 0x83, 0x0b, 0xa5, 0x2e, 0x48, 0x20, 0x0f, 0x76, 0x15, 0xe1,
+	0xc1, 0xae, 0x41, 0xf3, 0x0f, 0xec, 0xe9, 0x3c, 0x0e, 0xc8, 0x4b, 0x63, 0x74, 0x41, 0x1f, 0x6d,
+	0x69, 0x9e, 0x99, 0x37, 0x73, 0xd5, 0x1f, 0xf5, 0x42, 0xf5, 0x87, 0x05, 0x33, 0x9e, 0x1f, 0x27,
+	0x2e, 0x8e, 0x3d, 0xac, 0x00, 0x81, 0xb7, 0x71, 0xf9, 0xfc, 0x3c, 0x17, 0xad, 0x8b, 0x86, 0x43,
+	0x3c, 0xcc, 0x31, 0x0a, 0x4f, 0xdc, 0xf8, 0x84, 0xb9, 0x1d, 0x6f, 0x4a, 0x76, 0xab, 0x94, 0xd9,
+	0xad, 0x9a, 0x4f, 0xd3, 0xff, 0x3e, 0xcc, 0x30, 0xad, 0xd0, 0x03, 0xc1, 0xdc, 0xf3, 0x3b, 0x82,
+	0xf6, 0x53, 0x73, 0xa5, 0x44, 0xf6, 0x4f, 0xe0, 0x8e, 0x64, 0xc7, 0xb2, 0x27, 0x76, 0x51, 0xfa,
+	0xf4, 0xc0, 0xfc, 0x2b, 0xb8, 0xd7, 0x4f, 0xdc, 0x88, 0xbf, 0xd4, 0xef, 0xfa, 0xc1, 0xe9, 0x01,
+	0x8a, 0x13, 0x7d, 0x66, 0xa1, 0xf0, 0xfe, 0x56, 0x2f, 0xa6, 0x4d, 0xe8, 0xbd, 0x1c, 0xd7, 0x74,
+	0x6d, 0x42, 0x4b, 0xcd, 0x5e, 0x73, 0xa4, 0xfb, 0x1e, 0x39, 0xa5, 0x69, 0x84, 0xc9, 0x13, 0x87,
+	0x70, 0x37, 0x4f, 0x49, 0xdf, 0x0f, 0xbf, 0xab, 0xe7, 0xac, 0x01, 0x44, 0x2c, 0xd1, 0x97, 0x5e,
+	0xdb, 0x04, 0x08, 0xae, 0x9f, 0xb5, 0x5e, 0xa2, 0xab, 0x4e, 0x86, 0x8b, 0x51, 0x91, 0x94, 0x57,
+	0x7e, 0x69, 0x2c, 0xb9, 0x9b, 0x67, 0x47, 0x7a, 0xa2, 0x5a, 0xf3, 0x19, 0x4c, 0xd1, 0x84, 0x00,
+	0x79, 0xac, 0x98, 0x7b, 0xbe, 0x2a, 0x78, 0x8a, 0xac, 0x0f, 0x87, 0x11, 0xda, 0x3f, 0x21, 0xf5,
+	0xa9, 0x8c, 0x66, 0x1f, 0xf9, 0xc7, 0x27, 0x6f, 0xc3, 0xb3, 0x28, 0x0d, 0xdf, 0xb9, 0x65, 0x6a,
+	0x14, 0x97, 0xe9, 0xef, 0x0c, 0x68, 0xe4, 0xbb, 0x93, 0x7c, 0xfc, 0xc5, 0x5e, 0xa7, 0xcb, 0xf4,
+	0x4d, 0x1b, 0x18, 0x1a, 0x11, 0x28, 0x9d, 0x3e, 0x6d, 0xa4, 0x76, 0xa8, 0x16, 0xed, 0x50, 0x93,
+	0xec, 0x30, 0x64, 0x33, 0xd8, 0xd9, 0x4a, 0xd7, 0x69, 0x0a, 0xc1, 0x0b, 0x2c, 0x41, 0x31, 0xd5,
+	0x22, 0x7d, 0xa8, 0x4f, 0xdb, 0xf6, 0x2e, 0xc9, 0x19, 0x29, 0xe6, 0xc8, 0x8c, 0xf4, 0x71, 0x6e,
+	0x69, 0x2c, 0x09, 0x6a, 0x4b, 0xc9, 0xf9, 0xf2, 0xd8, 0x68, 0xc1, 0x8c, 0xf3, 0xe5, 0x17, 0x7e,
+	0xe0, 0x85, 0xef, 0xcc, 0x69, 0xa8, 0x3a, 0x5f, 0x3e, 0x6b, 0xdc, 0xa2, 0x3f, 0x9e, 0x37, 0x8c,
+	0x8d, 0x1f, 0x4a, 0x89, 0x6c, 0xec, 0x00, 0xb0, 0xdf, 0xfb, 0x79, 0xcf, 0xf9, 0x75, 0xbf, 0xd7,
+	0xdb, 0x6f, 0xdc, 0x32, 0x01, 0xa6, 0x5e, 0xef, 0xef, 0xee, 0xec, 0xf7, 0x1a, 0x86, 0x39, 0x07,
+	0xd3, 0xaf, 0xb7, 0xb7, 0x49, 0xa3, 0xb2, 0xf1, 0x00, 0x20, 0xbb, 0xea, 0x9b, 0x33, 0x50, 0xdb,
+	0x7d, 0xed, 0x74, 0x28, 0xe7, 0xed, 0xfe, 0xab, 0x86, 0xb1, 0xf1, 0x16, 0xcc, 0x62, 0x51, 0x08,
+	0x46, 0xef, 0x75, 0xba, 0x8d, 0x5b, 0xb8, 0xc7, 0x7e, 0x67, 0x0f, 0xb3, 0x5d, 0x04, 0xe8, 0x3a,
+	0xbd, 0xce, 0x41, 0x6f, 0xeb, 0xd7, 0x9d, 0x83, 0x46, 0xc5, 0x6c, 0xc0, 0xfc, 0x6e, 0xa7, 0x7f,
+	0x40, 0x24, 0xc0, 0x90, 0xaa, 0x39, 0x0b, 0xf5, 0xfe, 0x41, 0xe7, 0xa0, 0xd7, 0xa8, 0x99, 0xf3,
+	0x30, 0xb3, 0xb5, 0xd3, 0x3f, 0xe8, 0xec, 0x77, 0x7b, 0x8d, 0xfa, 0xc6, 0x10, 0xee, 0x28, 0xf2,
+	0x81, 0x58, 0xe8, 0x7e, 0xaf, 0xfb, 0x7a, 0x7f, 0x8b, 0x4e, 0x60, 0x6f, 0x67, 0xff, 0xf0, 0x00,
+	0x8f, 0x34, 0x03, 0xb5, 0xcf, 0x5f, 0x1f, 0x3a, 0x8d, 0x0a, 0x16, 0x63, 0xab, 0xf3, 0x55, 0xa3,
+	0x8a, 0x41, 0x5f, 0xf4, 0x7a, 0xaf, 0x1a, 0x35, 0x3c, 0xc8, 0xde, 0xeb, 0xfd, 0x83, 0xcf, 0x1b,
+	0x75, 0x3c, 0xd1, 0x9f, 0x1d, 0x76, 0x9c, 0x83, 0x9e, 0xd3, 0x98, 0xc2, 0x14, 0x5f, 0xf5, 0x3a,
+	0x4e, 0x63, 0xfa, 0xf9, 0x5f, 0x6e, 0xc0, 0xc2, 0x3e, 0x4a, 0xde, 0x85, 0xd1, 0x29, 0xfe, 0xc2,
+	0x0b, 0x45, 0xe6, 0x2f, 0x78, 0x31, 0xa7, 0xfc, 0xc5, 0x97, 0xf9, 0x80, 0x04, 0x2b, 0xfd, 0x67,
+	0x85, 0x56, 0x5b, 0x4f, 0xc0, 0xf6, 0xb7, 0x5b, 0xa6, 0x43, 0x4a, 0x24, 0x73, 0x9c, 0x5b, 0xec,
+	0x09, 0x41, 0xcd, 0xf6, 0xbe, 0x06, 0x9b, 0xf2, 0xfc, 0x05, 0xaf, 0x9c, 0x53, 0x09, 0x5c, 0xf2,
+	0x09, 0x9e, 0xd5, 0xd6, 0x13, 0x88, 0xcc, 0x55, 0xdf, 0xbf, 0x51, 0xe6, 0x25, 0x1f, 0xd9, 0x59,
+	0x6d, 0x3d, 0x81, 0xc8, 0x5c, 0xf5, 0x3d, 0x9a, 0xa8, 0x6a, 0xe5, 0x47, 0x50, 0x56, 0x5b, 0x4f,
+	0x90, 0x53, 0x75, 0x8e, 0x33, 0x57, 0xb5, 0x9a, 0xed, 0x7d, 0x0d, 0xb6, 0xa8, 0x6a, 0x95, 0xc0,
+	0x25, 0x1f, 0x8c, 0x59, 0x6d, 0x3d, 0x41, 0x51, 0xd5, 0x2a, 0xe6, 0x25, 0x9f, 0x84, 0x59, 0x6d,
+	0x3d, 0x41, 0xca, 0xfc, 0x4b, 0xf9, 0x8b, 0x17, 0xce, 0x7b, 0x2d, 0x53, 0xa4, 0xea, 0xb3, 0x20,
+	0xeb, 0x81, 0x16, 0x9f, 0x72, 0x7e, 0x2d, 0x7c, 0xf8, 0xc2, 0xd9, 0xf2, 0x47, 0x31, 0x25, 0xcf,
+	0x96, 0x1a, 0x29, 0x8a, 0xaa, 0xf8, 0x8e, 0x89, 0x8a, 0xaa, 0xff, 0x6e, 0xca, 0x7a, 0xa0, 0xc5,
+	0x8b, 0x9c, 0x15, 0x9f, 0x2e, 0x51, 0xce, 0xfa, 0x6f, 0xa3, 0xac, 0x07, 0x5a, 0x7c, 0xca, 0xb9,
+	0x0b, 0xf3, 0xa2, 0x96, 0xcc, 0x95, 0xbc, 0xde, 0x38, 0xaf, 0x66, 0x11, 0x91, 0x32, 0xf9, 0x11,
+	0xcc, 0xa6, 0x6a, 0x31, 0x97, 0x24, 0x2d, 0xf1, 0xee, 0x77, 0x73, 0x50, 0x51, 0x00, 0x71, 0xee,
+	0x54, 0x00, 0xc5, 0xf7, 0x3e, 0x56, 0xb3, 0x88, 0x10, 0x99, 0x88, 0xd3, 0xa4, 0x4c, 0x14, 0x5f,
+	0xf8, 0x58, 0xcd, 0x22, 0x22, 0x65, 0xb2, 0x03, 0x8b, 0xf2, 0xd7, 0x30, 0x26, 0x39, 0x02, 0x28,
+	0x3f, 0xf0, 0xb1, 0x2c, 0x15, 0x4a, 0x74, 0xad, 0xfc, 0xb7, 0x30, 0xd4, 0xb5, 0x34, 0x1f, 0xd5,
+	0x58, 0x2d, 0x35, 0x52, 0x74, 0x00, 0xc5, 0x97, 0x30, 0xd4, 0x01, 0xf4, 0x5f, 0xd6, 0x58, 0x0f,
+	0xb4, 0xf8, 0xdc, 0x2a, 0x90, 0xbe, 0x37, 0x49, 0x57, 0x81, 0xea, 0x63, 0x16, 0xab, 0xa5, 0x46,
+	0xa6, 0x0c, 0xbf, 0x86, 0x55, 0xed, 0xf7, 0x1f, 0xe6, 0x23, 0xdc, 0x79, 0xd2, 0x87, 0x2a, 0xd6,
+	0xe3, 0x09, 0x54, 0xa2, 0xf0, 0xf9, 0xcf, 0x36, 0xa8, 0xf0, 0x9a, 0x6f, 0x4b, 0xac, 0x96, 0x1a,
+	0x99, 0x32, 0x74, 0x61, 0x59, 0xfd, 0xcd, 0x84, 0xf9, 0x90, 0xf7, 0xd4, 0x7e, 0x06, 0x62, 0xd9,
+	0x65, 0x24, 0xe9, 0x10, 0xdb, 0xb0, 0x20, 0x7d, 0x85, 0x60, 0x0a, 0x2b, 0x4b, 0x2e, 0x07, 0xb5,
+	0x56, 0x15, 0x98, 0x94, 0xcf, 0x8f, 0x01, 0xb2, 0x83, 0x99, 0x79, 0x37, 0x5f, 0x71, 0x4a, 0x39,
+	0x68, 0x0a, 0x51, 0xa9, 0x18, 0x52, 0x21, 0xb7, 0x29, 0xac, 0x2f, 0x95, 0x18, 0xea, 0xaa, 0xef,
+	0x5b, 0x66, 0x07, 0xe6, 0x85, 0x93, 0x55, 0x6c, 0xe6, 0x0b, 0x70, 0x39, 0x93, 0x95, 0x02, 0x5c,
+	0x14, 0x45, 0xaa, 0x6c, 0x34, 0x85, 0x55, 0xaa, 0x12, 0x45, 0x5d, 0x06, 0x49, 0xf6, 0x21, 0x55,
+	0xa1, 0xab, 0xc9, 0x56, 0x81, 0xb6, 0x8a, 0xd6, 0x6a, 0xeb, 0x09, 0xc4, 0x5d, 0xb9, 0x50, 0x22,
+	0x4a, 0x77, 0x65, 0x5d, 0x9d, 0xa9, 0x75, 0x5f, 0x83, 0x4d, 0x79, 0x1e, 0x82, 0x59, 0x2c, 0xf8,
+	0x34, 0xef, 0xd3, 0x87, 0x11, 0x4d, 0x1d, 0xa9, 0xb5, 0xa6, 0x43, 0xa7, 0x6c, 0x77, 0xe1, 0x76,
+	0xae, 0x56, 0xcc, 0xb4, 0x64, 0x3f, 0x10, 0xab, 0xdd, 0xac, 0x7b, 0x4a, 0x9c, 0xa8, 0x55, 0xd5,
+	0xdb, 0x07, 0xd5, 0x6a, 0xc9, 0xd3, 0x8c, 0xd5, 0xd6, 0x13, 0xa4, 0xcc, 0xff, 0x18, 0xe6, 0x84,
+	0xfb, 0xb6, 0xc9, 0xdd, 0x35, 0xf7, 0x90, 0x62, 0xad, 0x14, 0xe0, 0xa2, 0x78, 0xaa, 0x1b, 0x31,
+	0x15, 0xaf, 0xe4, 0x2a, 0x6e, 0xb5, 0xf5, 0x04, 0xa2, 0x81, 0x8a, 0xf7, 0x53, 0xf3, 0xbe, 0xac,
+	0xb0, 0x3c, 0xe3, 0x35, 0x1d, 0x5a, 0x76, 0xd4, 0xe2, 0x9d, 0x8a, 0x3b, 0xaa, 0xf6, 0x46, 0x69,
+	0xb5, 0xf5, 0x04, 0x82, 0xcc, 0x77, 0x95, 0x45, 0x7e, 0x26, 0xef, 0xac, 0xad, 0xff, 0xb3, 0x9a,
+	0x79, 0x0a, 0x81, 0xed, 0x88, 0x27, 0xeb, 0x55, 0x69, 0x46, 0xf3, 0x71, 0x16, 0xa9, 0x4a, 0xea,
+	0x35, 0xac, 0x27, 0x93, 0xc8, 0xd2, 0xe1, 0x3c, 0x92, 0x71, 0x56, 0x8e, 0x65, 0x97, 0x56, 0x59,
+	0xd0, 0x81, 0xae, 0x52, 0x89, 0x41, 0x27, 0xa5, 0x2f, 0x45, 0xa1, 0x93, 0x9a, 0x58, 0x0f, 0x63,
+	0x3d, 0x99, 0x44, 0x26, 0x0e, 0xa7, 0x2f, 0x4f, 0xa1, 0xc3, 0x4d, 0xac, 0x79, 0xb1, 0x9e, 0x4c,
+	0x22, 0x13, 0x77, 0x62, 0x6d, 0x0d, 0x0b, 0xdd, 0x89, 0x27, 0x95, 0xc1, 0x58, 0x8f, 0x27, 0x50,
+	0x89, 0x2b, 0xa5, 0x58, 0xcb, 0x41, 0x57, 0x8a, 0xb6, 0xc8, 0xc5, 0x5a, 0xd3, 0xa1, 0x45, 0xb6,
+	0xc5, 0x12, 0x07, 0xca, 0x56, 0x5b, 0x0b, 0x62, 0xad, 0xe9, 0xd0, 0x22, 0xdb, 0x62, 0xb9, 0x03,
+	0x65, 0xab, 0xad, 0x99, 0xb0, 0xd6, 0x74, 0xe8, 0x94, 0xed, 0xdf, 0x1b, 0xf0, 0xd1, 0x95, 0x13,
+	0xf3, 0xe6, 0x0b, 0x45, 0x02, 0x7e, 0x62, 0x2d, 0x80, 0xf5, 0x83, 0x6b, 0xf6, 0x12, 0x9d, 0x4f,
+	0x9f, 0x55, 0xa7, 0xce, 0x37, 0x31, 0xd5, 0x6f, 0x3d, 0x99, 0x44, 0x56, 0xbc, 0x22, 0xe7, 0x72,
+	0xf4, 0xc2, 0xc5, 0x4c, 0x99, 0x29, 0xb4, 0xda, 0x7a, 0x82, 0xdc, 0x15, 0x39, 0xc7, 0x99, 0x1f,
+	0x4c, 0xd5, 0x6c, 0xef, 0x6b, 0xb0, 0xc5, 0x2b, 0xb2, 0x4a, 0xe0, 0x92, 0xdc, 0xb1, 0xd5, 0xd6,
+	0x13, 0x14, 0xaf, 0xc8, 0x2a, 0xe6, 0x25, 0xd9, 0x61, 0xab, 0xad, 0x27, 0x10, 0xd7, 0xb9, 0x36,
+	0x53, 0x4b, 0xd7, 0xf9, 0xa4, 0x84, 0xb1, 0xf5, 0x78, 0x02, 0x55, 0x3a, 0xd6, 0x25, 0xac, 0x95,
+	0xe7, 0x60, 0xcd, 0x8f, 0xe8, 0xb6, 0x7f, 0x85, 0xa4, 0xb0, 0xb5, 0x71, 0x15, 0x52, 0xc5, 0xc5,
+	0xa2, 0x98, 0x45, 0x95, 0x2e, 0x16, 0xda, 0x94, 0xaf, 0xf5, 0x78, 0x02, 0x95, 0x78, 0xb1, 0xc8,
+	0x67, 0x33, 0xe9, 0xc5, 0x42, 0x93, 0x2e, 0xb5, 0x5a, 0x6a, 0xa4, 0x78, 0xc6, 0x95, 0x32, 0x9a,
+	0x66, 0x53, 0xba, 0x9a, 0x89, 0xac, 0x56, 0x15, 0x18, 0x51, 0xb0, 0x7c, 0x26, 0x91, 0x0a, 0xa6,
+	0x49, 0x55, 0x5a, 0x2d, 0x35, 0x52, 0xbe, 0xaa, 0x0e, 0x51, 0x91, 0xa1, 0x26, 0x29, 0x69, 0xb5,
+	0xd4, 0xc8, 0x94, 0x61, 0x4c, 0x1e, 0x8c, 0xb5, 0xf9, 0x40, 0xf3, 0xf7, 0xf8, 0xfd, 0x71, 0x42,
+	0x8a, 0xd2, 0x5a, 0x9f, 0x4c, 0xc8, 0x07, 0x7d, 0x3b, 0x45, 0xfe, 0x3f, 0xed, 0x93, 0xff, 0x19,
+	0x00, 0xec, 0x26, 0xe7, 0x5a, 0x5f, 0x4d, 0x00, 0x00,
 }
@@ -10,6 +10,7 @@ It is generated from these files:
 
 It has these top-level messages:
 	ServiceProfile
+	DevAddrRange
 	DeviceProfile
 	CreateServiceProfileRequest
 	CreateServiceProfileResponse
@@ -65,13 +66,22 @@ It has these top-level messages:
 	GetGatewayResponse
 	UpdateGatewayRequest
 	UpdateGatewayResponse
+	GatewayBoundingBox
+	GatewayRadius
 	ListGatewayRequest
 	ListGatewayResponse
 	DeleteGatewayRequest
 	GenerateGatewayTokenRequest
 	GenerateGatewayTokenResponse
+	GatewayToken
+	ListGatewayTokensRequest
+	ListGatewayTokensResponse
+	RevokeGatewayTokenRequest
+	RevokeGatewayTokenResponse
 	DeleteGatewayResponse
 	GatewayStats
+	GatewayFrameStats
+	GatewaySignalStats
 	GetGatewayStatsRequest
 	GetGatewayStatsResponse
 	GetFrameLogsForDevEUIRequest
@@ -101,6 +111,47 @@ It has these top-level messages:
 	GetExtraChannelsForChannelConfigurationIDResponse
 	MigrateNodeToDeviceSessionRequest
 	MigrateNodeToDeviceSessionResponse
+	MulticastGroup
+	CreateMulticastGroupRequest
+	CreateMulticastGroupResponse
+	GetMulticastGroupRequest
+	GetMulticastGroupResponse
+	UpdateMulticastGroupRequest
+	UpdateMulticastGroupResponse
+	DeleteMulticastGroupRequest
+	DeleteMulticastGroupResponse
+	AddDeviceToMulticastGroupRequest
+	AddDeviceToMulticastGroupResponse
+	RemoveDeviceFromMulticastGroupRequest
+	RemoveDeviceFromMulticastGroupResponse
+	EnqueueMulticastQueueItemRequest
+	EnqueueMulticastQueueItemResponse
+	DeviceKeys
+	CreateDeviceKeysRequest
+	CreateDeviceKeysResponse
+	GetDeviceKeysRequest
+	GetDeviceKeysResponse
+	UpdateDeviceKeysRequest
+	UpdateDeviceKeysResponse
+	DeleteDeviceKeysRequest
+	DeleteDeviceKeysResponse
+	GetRejectedJoinRequestCountsRequest
+	RejectedJoinRequestCount
+	GetRejectedJoinRequestCountsResponse
+	ReportDeviceLocationRequest
+	ReportDeviceLocationResponse
+	GetCoverageRequest
+	GatewayCoverage
+	CoverageCell
+	GetCoverageResponse
+	StartGatewayLinkTestRequest
+	StartGatewayLinkTestResponse
+	GetGatewayLinkTestRequest
+	GatewayLinkTestRXInfo
+	GetGatewayLinkTestResponse
+	GetGatewayNeighboursRequest
+	GatewayNeighbour
+	GetGatewayNeighboursResponse
 */
 package ns
 
@@ -163,6 +214,9 @@ type ServiceProfile struct {
 	NwkGeoLoc              bool       `protobuf:"varint,18,opt,name=nwkGeoLoc" json:"nwkGeoLoc,omitempty"`
 	TargetPER              uint32     `protobuf:"varint,19,opt,name=targetPER" json:"targetPER,omitempty"`
 	MinGWDiversity         uint32     `protobuf:"varint,20,opt,name=minGWDiversity" json:"minGWDiversity,omitempty"`
+	// DevAddr ranges used for the activation of devices using this
+	// service-profile (when empty, the network-server ranges are used).
+	DevAddrRanges []*DevAddrRange `protobuf:"bytes,21,rep,name=devAddrRanges" json:"devAddrRanges,omitempty"`
 }
 
 func (m *ServiceProfile) Reset()                    { *m = ServiceProfile{} }
@@ -310,6 +364,39 @@ func (m *ServiceProfile) GetMinGWDiversity() uint32 {
 	return 0
 }
 
+func (m *ServiceProfile) GetDevAddrRanges() []*DevAddrRange {
+	if m != nil {
+		return m.DevAddrRanges
+	}
+	return nil
+}
+
+type DevAddrRange struct {
+	// First DevAddr of the range.
+	Start []byte `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Last DevAddr of the range (inclusive).
+	End []byte `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (m *DevAddrRange) Reset()                    { *m = DevAddrRange{} }
+func (m *DevAddrRange) String() string            { return proto.CompactTextString(m) }
+func (*DevAddrRange) ProtoMessage()               {}
+func (*DevAddrRange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *DevAddrRange) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *DevAddrRange) GetEnd() []byte {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
 type DeviceProfile struct {
 	DeviceProfileID    string   `protobuf:"bytes,1,opt,name=deviceProfileID" json:"deviceProfileID,omitempty"`
 	SupportsClassB     bool     `protobuf:"varint,2,opt,name=supportsClassB" json:"supportsClassB,omitempty"`
@@ -331,12 +418,18 @@ type DeviceProfile struct {
 	SupportsJoin       bool     `protobuf:"varint,18,opt,name=supportsJoin" json:"supportsJoin,omitempty"`
 	RfRegion           string   `protobuf:"bytes,19,opt,name=rfRegion" json:"rfRegion,omitempty"`
 	Supports32BitFCnt  bool     `protobuf:"varint,20,opt,name=supports32bitFCnt" json:"supports32bitFCnt,omitempty"`
+	// Set to true when rxDROffset1 is set (as 0 is a valid value).
+	// For backwards compatibility, a non-zero rxDROffset1 is always set.
+	RxDROffset1Set bool `protobuf:"varint,21,opt,name=rxDROffset1Set" json:"rxDROffset1Set,omitempty"`
+	// Set to true when rxDataRate2 is set (as 0 is a valid value).
+	// For backwards compatibility, a non-zero rxDataRate2 is always set.
+	RxDataRate2Set bool `protobuf:"varint,22,opt,name=rxDataRate2Set" json:"rxDataRate2Set,omitempty"`
 }
 
 func (m *DeviceProfile) Reset()                    { *m = DeviceProfile{} }
 func (m *DeviceProfile) String() string            { return proto.CompactTextString(m) }
 func (*DeviceProfile) ProtoMessage()               {}
-func (*DeviceProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*DeviceProfile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
 func (m *DeviceProfile) GetDeviceProfileID() string {
 	if m != nil {
@@ -478,8 +571,23 @@ func (m *DeviceProfile) GetSupports32BitFCnt() bool {
 	return false
 }
 
+func (m *DeviceProfile) GetRxDROffset1Set() bool {
+	if m != nil {
+		return m.RxDROffset1Set
+	}
+	return false
+}
+
+func (m *DeviceProfile) GetRxDataRate2Set() bool {
+	if m != nil {
+		return m.RxDataRate2Set
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ServiceProfile)(nil), "ns.ServiceProfile")
+	proto.RegisterType((*DevAddrRange)(nil), "ns.DevAddrRange")
 	proto.RegisterType((*DeviceProfile)(nil), "ns.DeviceProfile")
 	proto.RegisterEnum("ns.RatePolicy", RatePolicy_name, RatePolicy_value)
 }
@@ -487,48 +595,53 @@ func init() {
 func init() { proto.RegisterFile("profiles.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 685 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x6f, 0x4f, 0xdb, 0x30,
-	0x10, 0x87, 0x57, 0xa0, 0xd0, 0x1a, 0x52, 0x8a, 0x61, 0xc8, 0x9a, 0xa6, 0x29, 0x42, 0xd3, 0x54,
-	0xa1, 0xa9, 0x12, 0x65, 0xda, 0x7b, 0x68, 0x00, 0xb1, 0xad, 0x22, 0x72, 0xa7, 0xf1, 0xda, 0x24,
-	0xd7, 0x62, 0x91, 0xc6, 0xc5, 0x76, 0x4b, 0xbb, 0xef, 0x3b, 0x69, 0x1f, 0x63, 0xb2, 0xf3, 0x87,
-	0x24, 0xed, 0xde, 0xe5, 0x9e, 0xdf, 0x91, 0x3b, 0x1d, 0x4f, 0x83, 0x5a, 0x53, 0x29, 0x46, 0x3c,
-	0x02, 0xd5, 0x9d, 0x4a, 0xa1, 0x05, 0xde, 0x88, 0xd5, 0xc9, 0xdf, 0x3a, 0x6a, 0x0d, 0x41, 0xce,
-	0x79, 0x00, 0x7e, 0x92, 0xe2, 0x53, 0xd4, 0x56, 0x25, 0x72, 0xeb, 0x91, 0x9a, 0x5b, 0xeb, 0x34,
-	0xe9, 0x0a, 0xc7, 0xc7, 0x68, 0x7b, 0x16, 0x51, 0xa6, 0x81, 0x6c, 0xb8, 0xb5, 0x8e, 0x43, 0xd3,
-	0x0a, 0x9f, 0xa0, 0xbd, 0x59, 0x74, 0x39, 0x0b, 0x9e, 0x40, 0x0f, 0xf9, 0x6f, 0x20, 0x9b, 0x36,
-	0x2d, 0x31, 0xdc, 0x33, 0x3d, 0xa6, 0xdb, 0x17, 0x11, 0x0f, 0x96, 0x64, 0xcb, 0xad, 0x75, 0x5a,
-	0xbd, 0x56, 0x37, 0x56, 0xdd, 0x57, 0x4a, 0x4b, 0x3d, 0x66, 0x5e, 0x98, 0xcc, 0xab, 0x27, 0xf3,
-	0xc2, 0x7c, 0x5e, 0x58, 0x9c, 0xb7, 0x9d, 0xcc, 0x0b, 0x2b, 0xf3, 0xc2, 0xe2, 0xbc, 0x9d, 0xf5,
-	0xf3, 0x8a, 0x3d, 0xf8, 0x23, 0x72, 0x58, 0x18, 0xde, 0xdc, 0x0f, 0x40, 0xb3, 0x90, 0x69, 0x46,
-	0x1a, 0x6e, 0xad, 0xd3, 0xa0, 0x65, 0x68, 0x2e, 0x16, 0xc2, 0x7c, 0xa8, 0x99, 0x9e, 0x29, 0x0a,
-	0xcf, 0xd7, 0x12, 0x9e, 0x49, 0xd3, 0x6e, 0xb0, 0xc2, 0xf1, 0x57, 0x74, 0x2c, 0x61, 0x2a, 0xa4,
-	0xf6, 0xb2, 0xe4, 0x92, 0x69, 0x0d, 0x72, 0x49, 0x90, 0x7d, 0xf5, 0x7f, 0x52, 0xfc, 0x05, 0xbd,
-	0xad, 0x24, 0x03, 0x26, 0xc7, 0x3c, 0x26, 0xbb, 0xf6, 0xcf, 0xd6, 0x87, 0xf8, 0x08, 0xd5, 0x43,
-	0x39, 0xe0, 0x31, 0xd9, 0xb3, 0xeb, 0x24, 0x45, 0x4a, 0xd9, 0x82, 0x38, 0x39, 0x65, 0x0b, 0xec,
-	0xa2, 0xdd, 0xe0, 0x91, 0xc5, 0x31, 0x44, 0x03, 0xa6, 0x9e, 0x48, 0xcb, 0xad, 0x75, 0xf6, 0x68,
-	0x11, 0xe1, 0xf7, 0xa8, 0x39, 0x95, 0x17, 0x51, 0x24, 0x5e, 0x20, 0x24, 0xfb, 0x76, 0xee, 0x2b,
-	0x30, 0xe9, 0x63, 0x9e, 0xb6, 0x93, 0xf4, 0xb1, 0x98, 0x4a, 0x96, 0xa5, 0x07, 0x49, 0x9a, 0x03,
-	0x93, 0xc6, 0x2f, 0x4f, 0x37, 0x20, 0x7e, 0x88, 0x80, 0xe0, 0x24, 0xcd, 0x81, 0x49, 0x35, 0x93,
-	0x63, 0xd0, 0xfe, 0x15, 0x25, 0x87, 0x76, 0xe7, 0x57, 0x80, 0x3f, 0xa1, 0xd6, 0x84, 0xc7, 0x37,
-	0xf7, 0x1e, 0x9f, 0x83, 0x54, 0x5c, 0x2f, 0xc9, 0x91, 0x6d, 0xa9, 0xd0, 0x93, 0x3f, 0x75, 0xe4,
-	0x78, 0x50, 0x34, 0xbd, 0x83, 0xf6, 0x43, 0x58, 0x27, 0x7a, 0x15, 0x9b, 0x19, 0x6a, 0x36, 0x35,
-	0x17, 0x56, 0xfd, 0x88, 0x29, 0x75, 0x69, 0x7d, 0x6f, 0xd0, 0x0a, 0x35, 0xbe, 0x04, 0xf6, 0xe9,
-	0x27, 0x9f, 0x80, 0x98, 0xe9, 0x54, 0xfc, 0x32, 0x34, 0x6f, 0x9b, 0xf2, 0x78, 0x3c, 0x8c, 0x84,
-	0xf6, 0x41, 0x72, 0x11, 0x5a, 0xf7, 0x1d, 0x5a, 0xa1, 0xf8, 0x03, 0x42, 0x19, 0xf1, 0x68, 0x6a,
-	0x7c, 0x81, 0x18, 0xeb, 0xb3, 0xca, 0x3a, 0x97, 0x5a, 0x5f, 0x64, 0x2b, 0x9b, 0xf7, 0xad, 0xf7,
-	0xd5, 0xcd, 0xfb, 0xf9, 0xe6, 0xfd, 0x6c, 0xf3, 0x46, 0x61, 0xf3, 0x0c, 0x9a, 0x8d, 0x26, 0x2c,
-	0xf8, 0x65, 0x2e, 0x2a, 0x62, 0xeb, 0x78, 0x93, 0x16, 0x08, 0xfe, 0x8c, 0x0e, 0x24, 0x8c, 0x7d,
-	0x26, 0xd9, 0x44, 0x51, 0x98, 0x73, 0xdb, 0x86, 0x6c, 0xdb, 0x6a, 0x80, 0xdf, 0xa1, 0x86, 0x5c,
-	0x78, 0x10, 0xb1, 0xe5, 0x99, 0xd5, 0xd8, 0xa1, 0x79, 0x6d, 0x6c, 0x94, 0x0b, 0x8f, 0xde, 0x8d,
-	0x46, 0x0a, 0xf4, 0x59, 0xea, 0x6f, 0x11, 0xa5, 0x1d, 0x4c, 0x33, 0xf3, 0x7b, 0xed, 0xa5, 0x2e,
-	0x17, 0x11, 0x26, 0x68, 0x47, 0x2e, 0xcc, 0x15, 0x7a, 0xd6, 0x66, 0x87, 0x66, 0x25, 0xee, 0x22,
-	0x3c, 0x62, 0x81, 0x16, 0x72, 0xe9, 0x4b, 0x50, 0x60, 0x4f, 0xa5, 0xc8, 0xbe, 0xbb, 0xd9, 0x71,
-	0xe8, 0x9a, 0xc4, 0xbc, 0x69, 0xc2, 0x16, 0x57, 0xb7, 0xd4, 0xb7, 0x66, 0x3b, 0x34, 0x2b, 0xcd,
-	0xff, 0x60, 0xc2, 0x16, 0xde, 0x4c, 0x2f, 0xfb, 0xcb, 0x20, 0x02, 0xab, 0xb6, 0x43, 0x4b, 0xcc,
-	0xf4, 0x64, 0xd7, 0xfe, 0x26, 0x78, 0x9c, 0x0a, 0x5e, 0x62, 0xf6, 0x16, 0x23, 0x0a, 0x63, 0x73,
-	0xb0, 0x43, 0x7b, 0xb0, 0xbc, 0x36, 0x57, 0xcd, 0x7a, 0xcf, 0x7b, 0x0f, 0x5c, 0x5f, 0xf7, 0x63,
-	0x6d, 0x25, 0x6f, 0xd0, 0xd5, 0xe0, 0xd4, 0x45, 0xa8, 0xf0, 0x05, 0x6b, 0xa0, 0x2d, 0x8f, 0xde,
-	0xf9, 0xed, 0x37, 0xe6, 0x69, 0x70, 0x41, 0xbf, 0xb7, 0x6b, 0x0f, 0xdb, 0xf6, 0xfb, 0x7f, 0xfe,
-	0x2f, 0x00, 0x00, 0xff, 0xff, 0x09, 0x9c, 0x54, 0x76, 0x11, 0x06, 0x00, 0x00,
+	// 760 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x95, 0xed, 0x4e, 0xfb, 0x36,
+	0x14, 0xc6, 0x57, 0x0a, 0xa5, 0x35, 0x4d, 0x29, 0xe6, 0x45, 0xd6, 0x34, 0x4d, 0x51, 0x35, 0x4d,
+	0x11, 0x9a, 0x2a, 0x51, 0x26, 0xbe, 0x43, 0x03, 0x88, 0x6d, 0x15, 0x91, 0x3b, 0x8d, 0xcf, 0x26,
+	0x3e, 0x2d, 0x11, 0x69, 0x52, 0x6c, 0xb7, 0xb4, 0xbb, 0x9a, 0xdd, 0xc4, 0xee, 0xef, 0x2f, 0x3b,
+	0x2f, 0x38, 0x6d, 0xff, 0xdf, 0x72, 0x7e, 0xcf, 0x43, 0xce, 0xe1, 0xd8, 0x4f, 0x8a, 0x3a, 0x73,
+	0x91, 0x4e, 0xa2, 0x18, 0x64, 0x7f, 0x2e, 0x52, 0x95, 0xe2, 0xbd, 0x44, 0xf6, 0xfe, 0x6b, 0xa0,
+	0xce, 0x18, 0xc4, 0x32, 0x0a, 0x21, 0xc8, 0x54, 0x7c, 0x89, 0xba, 0xb2, 0x42, 0x9e, 0x7c, 0x52,
+	0x73, 0x6b, 0x5e, 0x8b, 0x6e, 0x71, 0x7c, 0x81, 0x1a, 0x8b, 0x98, 0x32, 0x05, 0x64, 0xcf, 0xad,
+	0x79, 0x0e, 0xcd, 0x2b, 0xdc, 0x43, 0xed, 0x45, 0x7c, 0xb7, 0x08, 0xdf, 0x41, 0x8d, 0xa3, 0x7f,
+	0x81, 0xd4, 0x8d, 0x5a, 0x61, 0x78, 0x80, 0xda, 0x99, 0x3b, 0x48, 0xe3, 0x28, 0x5c, 0x93, 0x7d,
+	0xb7, 0xe6, 0x75, 0x06, 0x9d, 0x7e, 0x22, 0xfb, 0x5f, 0x94, 0x56, 0x3c, 0xba, 0x1f, 0xcf, 0xfa,
+	0x1d, 0x64, 0xfd, 0x78, 0xd9, 0x8f, 0xdb, 0xfd, 0x1a, 0x59, 0x3f, 0xbe, 0xd1, 0x8f, 0xdb, 0xfd,
+	0x0e, 0x77, 0xf7, 0xb3, 0x3d, 0xf8, 0x17, 0xe4, 0x30, 0xce, 0x1f, 0x5f, 0x46, 0xa0, 0x18, 0x67,
+	0x8a, 0x91, 0xa6, 0x5b, 0xf3, 0x9a, 0xb4, 0x0a, 0xf5, 0xc6, 0x38, 0x2c, 0xc7, 0x8a, 0xa9, 0x85,
+	0xa4, 0xf0, 0xf1, 0x20, 0xe0, 0x83, 0xb4, 0xcc, 0x04, 0x5b, 0x1c, 0xdf, 0xa0, 0x0b, 0x01, 0xf3,
+	0x54, 0x28, 0xbf, 0x50, 0xee, 0x98, 0x52, 0x20, 0xd6, 0x04, 0x99, 0x57, 0x7f, 0x47, 0xc5, 0xbf,
+	0xa3, 0xf3, 0x0d, 0x65, 0xc4, 0xc4, 0x34, 0x4a, 0xc8, 0x91, 0xf9, 0xb3, 0xdd, 0x22, 0x3e, 0x43,
+	0x07, 0x5c, 0x8c, 0xa2, 0x84, 0xb4, 0xcd, 0x38, 0x59, 0x91, 0x53, 0xb6, 0x22, 0x4e, 0x49, 0xd9,
+	0x0a, 0xbb, 0xe8, 0x28, 0x7c, 0x63, 0x49, 0x02, 0xf1, 0x88, 0xc9, 0x77, 0xd2, 0x71, 0x6b, 0x5e,
+	0x9b, 0xda, 0x08, 0xff, 0x84, 0x5a, 0x73, 0x71, 0x1b, 0xc7, 0xe9, 0x27, 0x70, 0x72, 0x6c, 0xfa,
+	0x7e, 0x01, 0xad, 0xbe, 0x95, 0x6a, 0x37, 0x53, 0xdf, 0x6c, 0x55, 0xb0, 0x42, 0x3d, 0xc9, 0x54,
+	0xc1, 0x2c, 0x35, 0xf9, 0x7c, 0x7f, 0x84, 0xf4, 0xaf, 0x34, 0x24, 0x38, 0x53, 0x4b, 0xa0, 0x55,
+	0xc5, 0xc4, 0x14, 0x54, 0x70, 0x4f, 0xc9, 0xa9, 0x99, 0xf9, 0x0b, 0xe0, 0x5f, 0x51, 0x67, 0x16,
+	0x25, 0x8f, 0x2f, 0x7e, 0xb4, 0x04, 0x21, 0x23, 0xb5, 0x26, 0x67, 0xc6, 0xb2, 0x41, 0xf1, 0x0d,
+	0x72, 0x38, 0x2c, 0x6f, 0x39, 0x17, 0x94, 0x25, 0x53, 0x90, 0xe4, 0xdc, 0xad, 0x7b, 0x47, 0x83,
+	0xae, 0xbe, 0x00, 0xbe, 0x25, 0xd0, 0xaa, 0xad, 0x77, 0x83, 0xda, 0xb6, 0xac, 0xb7, 0x27, 0x15,
+	0x13, 0xca, 0x84, 0xa2, 0x4d, 0xb3, 0x02, 0x77, 0x51, 0x1d, 0x12, 0x6e, 0x62, 0xd0, 0xa6, 0xfa,
+	0xb1, 0xf7, 0x7f, 0x03, 0x39, 0x3e, 0xd8, 0xc9, 0xf2, 0xd0, 0x31, 0x87, 0x5d, 0xc1, 0xda, 0xc4,
+	0xfa, 0x7f, 0x92, 0x8b, 0xb9, 0x3e, 0x51, 0x39, 0x8c, 0x99, 0x94, 0x77, 0xe6, 0xc5, 0x4d, 0xba,
+	0x41, 0xf5, 0xfd, 0x0c, 0xcd, 0xd3, 0xdf, 0xd1, 0x0c, 0xd2, 0x85, 0xca, 0x83, 0x56, 0x85, 0xfa,
+	0x6d, 0xf3, 0x28, 0x99, 0x8e, 0xe3, 0x54, 0x05, 0x20, 0xa2, 0x94, 0x9b, 0xac, 0x39, 0x74, 0x83,
+	0xe2, 0x9f, 0x11, 0x2a, 0x88, 0x4f, 0xf3, 0x84, 0x59, 0x44, 0xa7, 0xac, 0xa8, 0xcc, 0x1d, 0xcf,
+	0x53, 0x66, 0xb3, 0xad, 0xc9, 0x87, 0xe4, 0x70, 0xc7, 0xe4, 0xc3, 0x72, 0xf2, 0x61, 0x31, 0x79,
+	0xd3, 0x9a, 0xbc, 0x80, 0x7a, 0xa2, 0x19, 0x0b, 0xff, 0xd1, 0x27, 0x98, 0x26, 0x26, 0x53, 0x2d,
+	0x6a, 0x11, 0xfc, 0x1b, 0x3a, 0x11, 0x30, 0x0d, 0x98, 0x60, 0x33, 0x49, 0x61, 0x19, 0x19, 0x1b,
+	0x32, 0xb6, 0x6d, 0x01, 0xff, 0x88, 0x9a, 0x62, 0xe5, 0x43, 0xcc, 0xd6, 0x57, 0x26, 0x36, 0x0e,
+	0x2d, 0x6b, 0x7d, 0xfb, 0xc5, 0xca, 0xa7, 0xcf, 0x93, 0x89, 0x04, 0x75, 0x95, 0xe7, 0xc5, 0x46,
+	0xb9, 0x83, 0x29, 0xa6, 0xbf, 0x0f, 0x83, 0x3c, 0x3b, 0x36, 0xc2, 0x04, 0x1d, 0x8a, 0x95, 0xde,
+	0xc2, 0xc0, 0xa4, 0xc7, 0xa1, 0x45, 0x89, 0xfb, 0x08, 0x4f, 0x58, 0xa8, 0x52, 0xb1, 0x0e, 0x04,
+	0x48, 0x30, 0xab, 0x92, 0xe4, 0xd8, 0xad, 0x7b, 0x0e, 0xdd, 0xa1, 0xe8, 0x37, 0xcd, 0xd8, 0xea,
+	0xfe, 0x89, 0x06, 0x26, 0x49, 0x0e, 0x2d, 0x4a, 0x7d, 0x06, 0x33, 0xb6, 0xf2, 0x17, 0x6a, 0x3d,
+	0x5c, 0x87, 0x31, 0x98, 0x28, 0x39, 0xb4, 0xc2, 0xb4, 0xa7, 0xd8, 0xf6, 0x1f, 0x69, 0x94, 0xe4,
+	0x81, 0xaa, 0x30, 0xb3, 0x8b, 0x09, 0x85, 0xa9, 0x5e, 0xd8, 0xa9, 0x59, 0x58, 0x59, 0xeb, 0xad,
+	0x16, 0xde, 0xeb, 0xc1, 0x6b, 0xa4, 0x1e, 0x86, 0x89, 0x32, 0xa1, 0x6a, 0xd2, 0x6d, 0x41, 0x9f,
+	0xb8, 0xb5, 0xa6, 0x31, 0x28, 0x72, 0x9e, 0x9d, 0x78, 0x95, 0xe6, 0xbe, 0x62, 0x59, 0xda, 0x77,
+	0x51, 0xfa, 0x2c, 0x7a, 0xe9, 0x22, 0x64, 0x7d, 0x81, 0x9b, 0x68, 0xdf, 0xa7, 0xcf, 0x41, 0xf7,
+	0x07, 0xfd, 0x34, 0xba, 0xa5, 0x7f, 0x76, 0x6b, 0xaf, 0x0d, 0xf3, 0xfb, 0x75, 0xfd, 0x6d, 0x00,
+	0x3b, 0xe5, 0xe9, 0x3f, 0xd1, 0x06, 0x00, 0x00,
 }
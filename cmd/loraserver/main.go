@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
@@ -16,7 +17,9 @@ import (
 
 	"github.com/brocaar/loraserver/internal/asclient"
 
+	"github.com/brocaar/loraserver/internal/joinserver"
 	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/kek"
 
 	"github.com/codegangsta/cli"
 	"github.com/pkg/errors"
@@ -31,10 +34,18 @@ import (
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/api"
 	"github.com/brocaar/loraserver/internal/api/auth"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/loraserver/internal/backend/basicstation"
 	"github.com/brocaar/loraserver/internal/backend/controller"
 	gwBackend "github.com/brocaar/loraserver/internal/backend/gateway"
+	"github.com/brocaar/loraserver/internal/backend/semtech"
+	"github.com/brocaar/loraserver/internal/backendapi"
 	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/coverage"
+	"github.com/brocaar/loraserver/internal/devaddr"
+	"github.com/brocaar/loraserver/internal/housekeeping"
 	"github.com/brocaar/loraserver/internal/migrations"
+	"github.com/brocaar/loraserver/internal/roaming"
 	// TODO: merge backend/gateway into internal/gateway?
 	"github.com/brocaar/loraserver/internal/gateway"
 	"github.com/brocaar/loraserver/internal/uplink"
@@ -62,6 +73,8 @@ var bands = []string{
 func run(c *cli.Context) error {
 	var server = new(uplink.Server)
 	var gwStats = new(gateway.StatsHandler)
+	var gwHealth *gateway.HealthMonitor
+	var hk *housekeeping.Housekeeping
 
 	tasks := []func(*cli.Context) error{
 		setLogLevel,
@@ -70,10 +83,12 @@ func run(c *cli.Context) error {
 		setRXParameters,
 		setDeduplicationDelay,
 		setGetDownlinkDataDelay,
+		setJoinRateLimits,
 		setCreateGatewayOnStats,
 		setNodeSessionTTL,
 		setLogNodeFrames,
 		setGatewayServerJWTSecret,
+		setCoverageGeohashPrecision,
 		setStatsAggregationIntervals,
 		setTimezone,
 		printStartMessage,
@@ -84,12 +99,16 @@ func run(c *cli.Context) error {
 		setGatewayBackend,
 		setApplicationServer,
 		setJoinServer,
+		setRoaming,
 		setNetworkController,
 		runDatabaseMigrations,
 		startAPIServer,
 		startGatewayAPIServer,
+		startBackendAPIServer,
 		startLoRaServer(server),
 		startStatsServer(gwStats),
+		startGatewayHealthMonitor(&gwHealth),
+		startHousekeeping(&hk),
 	}
 
 	for _, t := range tasks {
@@ -110,6 +129,14 @@ func run(c *cli.Context) error {
 		if err := gwStats.Stop(); err != nil {
 			log.Fatal(err)
 		}
+		if err := gwHealth.Stop(); err != nil {
+			log.Fatal(err)
+		}
+		if hk != nil {
+			if err := hk.Stop(); err != nil {
+				log.Fatal(err)
+			}
+		}
 		exitChan <- struct{}{}
 	}()
 	select {
@@ -127,6 +154,18 @@ func setNetID(c *cli.Context) error {
 		return errors.Wrap(err, "NetID parse error")
 	}
 	common.NetID = netID
+
+	for _, s := range c.StringSlice("dev-addr-range") {
+		r, err := devaddr.ParseRange(s)
+		if err != nil {
+			return errors.Wrap(err, "parse DevAddr range error")
+		}
+		if err := r.Validate(common.NetID); err != nil {
+			return errors.Wrap(err, "validate DevAddr range error")
+		}
+		common.DevAddrRanges = append(common.DevAddrRanges, r)
+	}
+
 	return nil
 }
 
@@ -175,6 +214,16 @@ func setGetDownlinkDataDelay(c *cli.Context) error {
 	return nil
 }
 
+func setJoinRateLimits(c *cli.Context) error {
+	common.JoinRateLimitDevEUI = c.Int("join-rate-limit-dev-eui")
+	common.JoinRateLimitJoinEUI = c.Int("join-rate-limit-join-eui")
+	common.JoinAttemptLimitDevEUI = c.Int("join-attempt-limit-dev-eui")
+	common.JoinRateLimitInterval = c.Duration("join-rate-limit-interval")
+	common.JoinRateLimitMaxBackoff = c.Duration("join-rate-limit-max-backoff")
+	common.UnknownDevEUICacheTTL = c.Duration("join-unknown-dev-eui-cache-ttl")
+	return nil
+}
+
 func setCreateGatewayOnStats(c *cli.Context) error {
 	common.CreateGatewayOnStats = c.Bool("gw-create-on-stats")
 	return nil
@@ -192,6 +241,31 @@ func setLogNodeFrames(c *cli.Context) error {
 
 func setGatewayServerJWTSecret(c *cli.Context) error {
 	common.GatewayServerJWTSecret = c.String("gw-server-jwt-secret")
+
+	keys, err := auth.ParseKeys(c.String("gw-server-jwt-keys"))
+	if err != nil {
+		return errors.Wrap(err, "parse gw-server-jwt-keys error")
+	}
+	common.GatewayServerJWTKeys = keys
+
+	common.GatewayServerJWTSigningKeyID = c.String("gw-server-jwt-signing-key-id")
+	if _, ok := keys[common.GatewayServerJWTSigningKeyID]; common.GatewayServerJWTSigningKeyID != "" && !ok {
+		return fmt.Errorf("gw-server-jwt-signing-key-id '%s' is not defined in gw-server-jwt-keys", common.GatewayServerJWTSigningKeyID)
+	}
+	if common.GatewayServerJWTSigningKeyID == "" && common.GatewayServerJWTSecret == "" && len(keys) != 0 {
+		return errors.New("gw-server-jwt-signing-key-id must be set when gw-server-jwt-keys is set without gw-server-jwt-secret")
+	}
+
+	common.GatewayTokenTTL = c.Duration("gw-token-ttl")
+	return nil
+}
+
+func setCoverageGeohashPrecision(c *cli.Context) error {
+	precision := c.Int("coverage-geohash-precision")
+	if precision < 1 || precision > coverage.MaxPrecision {
+		return fmt.Errorf("coverage-geohash-precision must be between 1 and %d", coverage.MaxPrecision)
+	}
+	common.CoverageGeohashPrecision = precision
 	return nil
 }
 
@@ -287,11 +361,38 @@ func setPostgreSQLConnection(c *cli.Context) error {
 }
 
 func setGatewayBackend(c *cli.Context) error {
-	gw, err := gwBackend.NewBackend(c.String("gw-mqtt-server"), c.String("gw-mqtt-username"), c.String("gw-mqtt-password"), c.String("gw-mqtt-ca-cert"))
+	var gw backend.Gateway
+	var err error
+
+	switch c.String("gw-backend") {
+	case "mqtt":
+		gw, err = gwBackend.NewBackend(gwBackend.Config{
+			Server:              c.String("gw-mqtt-server"),
+			Username:            c.String("gw-mqtt-username"),
+			Password:            c.String("gw-mqtt-password"),
+			CACert:              c.String("gw-mqtt-ca-cert"),
+			RXTopicTemplate:     c.String("gw-mqtt-rx-topic-template"),
+			StatsTopicTemplate:  c.String("gw-mqtt-stats-topic-template"),
+			TXTopicTemplate:     c.String("gw-mqtt-tx-topic-template"),
+			AckTopicTemplate:    c.String("gw-mqtt-ack-topic-template"),
+			ConfigTopicTemplate: c.String("gw-mqtt-config-topic-template"),
+			QOS:                 uint8(c.Int("gw-mqtt-qos")),
+			SubscribeQOS:        uint8(c.Int("gw-mqtt-subscribe-qos")),
+			SharedSubscription:  c.String("gw-mqtt-shared-subscription-group"),
+			Marshaler:           c.String("gw-mqtt-marshaler"),
+		})
+	case "semtech-udp":
+		gw, err = semtech.NewBackend(c.String("gw-udp-bind"))
+	case "basicstation":
+		gw, err = basicstation.NewBackend(c.String("gw-basicstation-bind"), c.String("gw-basicstation-ca-cert"), c.String("gw-basicstation-tls-cert"), c.String("gw-basicstation-tls-key"))
+	default:
+		return fmt.Errorf("unknown gateway backend: %s", c.String("gw-backend"))
+	}
 	if err != nil {
 		return errors.Wrap(err, "gateway-backend setup failed")
 	}
-	common.Gateway = gw
+	// keep track of the frames scheduled for transmission in the gateway stats
+	common.Gateway = gateway.NewStatsBackend(gw)
 	return nil
 }
 
@@ -304,17 +405,127 @@ func setApplicationServer(c *cli.Context) error {
 	return nil
 }
 
+// answerStore holds the asynchronous join-server answers (when enabled).
+var answerStore *jsclient.AnswerStore
+
 func setJoinServer(c *cli.Context) error {
-	jsClient, err := jsclient.NewClient(
-		c.String("js-server"),
-		c.String("js-ca-cert"),
-		c.String("js-tls-cert"),
-		c.String("js-tls-key"),
-	)
-	if err != nil {
-		return errors.Wrap(err, "create new join-server client error")
+	kekStore := kek.NewStore()
+	for _, k := range c.StringSlice("kek") {
+		label, key, err := kek.ParseKEK(k)
+		if err != nil {
+			return errors.Wrap(err, "parse kek error")
+		}
+
+		if err := kekStore.Add(label, key); err != nil {
+			return errors.Wrapf(err, "add kek '%s' error", label)
+		}
 	}
-	common.JoinServerPool = jsclient.NewPool(jsClient)
+
+	common.KEKStore = kekStore
+	common.JoinServerKEKLabel = c.String("js-embedded-kek-label")
+	common.JoinServerASKEKLabel = c.String("js-embedded-as-kek-label")
+
+	opts := jsclient.ClientOptions{
+		KEKStore: kekStore,
+	}
+
+	// asynchronous answers are received by the backend api
+	if c.String("backend-api-bind") != "" {
+		answerStore = jsclient.NewAnswerStore(common.RedisPool)
+		opts.AnswerStore = answerStore
+	}
+
+	var jsClient jsclient.Client
+	if c.Bool("js-embedded") {
+		// the root-keys must not be stored in plain-text
+		if common.JoinServerKEKLabel == "" {
+			return errors.New("js-embedded-kek-label must be set when the embedded join-server is enabled")
+		}
+		if _, err := kekStore.Get(common.JoinServerKEKLabel); err != nil {
+			return errors.Wrapf(err, "get kek '%s' error", common.JoinServerKEKLabel)
+		}
+
+		log.Info("using the embedded join-server as default join-server")
+		jsClient = joinserver.NewClient()
+	} else {
+		var err error
+		jsClient, err = jsclient.NewClient(
+			c.String("js-server"),
+			c.String("js-ca-cert"),
+			c.String("js-tls-cert"),
+			c.String("js-tls-key"),
+			opts,
+		)
+		if err != nil {
+			return errors.Wrap(err, "create new join-server client error")
+		}
+	}
+
+	var routes []jsclient.Route
+	for _, r := range c.StringSlice("js-server-route") {
+		rc, err := jsclient.ParseRouteConfig(r)
+		if err != nil {
+			return errors.Wrap(err, "parse join-server route error")
+		}
+
+		route, err := jsclient.NewRoute(rc, opts)
+		if err != nil {
+			return errors.Wrap(err, "create join-server route error")
+		}
+		routes = append(routes, route)
+	}
+
+	resolverConfig := jsclient.ResolverConfig{
+		CACert:    c.String("js-resolve-ca-cert"),
+		TLSCert:   c.String("js-resolve-tls-cert"),
+		TLSKey:    c.String("js-resolve-tls-key"),
+		CacheSize: c.Int("js-resolve-cache-size"),
+		CacheTTL:  c.Duration("js-resolve-cache-ttl"),
+	}
+	if c.Bool("js-resolve-join-eui") {
+		resolverConfig.Resolver = jsclient.NewDNSResolver(c.String("js-resolve-domain-suffix"))
+	}
+
+	common.JoinServerPool = jsclient.NewPool(jsClient, routes, resolverConfig, opts)
+
+	return nil
+}
+
+func setRoaming(c *cli.Context) error {
+	if len(c.StringSlice("roaming-agreement")) == 0 {
+		return nil
+	}
+
+	if c.String("backend-api-bind") == "" {
+		return errors.New("backend-api-bind must be set when roaming is enabled")
+	}
+
+	// roaming partners are authenticated using their client certificate
+	if c.String("backend-api-ca-cert") == "" || c.String("backend-api-tls-cert") == "" || c.String("backend-api-tls-key") == "" {
+		return errors.New("backend-api-ca-cert, backend-api-tls-cert and backend-api-tls-key must be set when roaming is enabled")
+	}
+
+	var clients []roaming.Client
+	for _, a := range c.StringSlice("roaming-agreement") {
+		ac, err := roaming.ParseAgreementConfig(a)
+		if err != nil {
+			return errors.Wrap(err, "parse roaming agreement error")
+		}
+
+		client, err := roaming.NewClient(ac, common.NetID, common.KEKStore)
+		if err != nil {
+			return errors.Wrap(err, "create roaming client error")
+		}
+		clients = append(clients, client)
+	}
+
+	common.RoamingPool = roaming.NewPool(clients...)
+	common.RoamingLifetime = c.Duration("roaming-lifetime")
+	common.RoamingHandoverLifetime = c.Duration("roaming-handover-lifetime")
+	common.RoamingKEKLabel = c.String("roaming-kek-label")
+
+	// downlinks for roamed uplinks are sent through the fNS
+	common.Gateway = roaming.NewBackend(common.Gateway, common.RedisPool, common.RoamingPool, common.Band)
 
 	return nil
 }
@@ -400,12 +611,18 @@ func startGatewayAPIServer(c *cli.Context) error {
 		"tls-key":  c.String("gw-server-tls-key"),
 	}).Info("starting gateway api server")
 
-	var validator auth.Validator
-	if c.String("gw-server-jwt-secret") != "" {
-		validator = auth.NewJWTValidator("HS256", c.String("gw-server-jwt-secret"))
-	} else {
-		return errors.New("--gw-server-jwt-secret must be set")
+	keys := make(map[string]string)
+	for kid, secret := range common.GatewayServerJWTKeys {
+		keys[kid] = secret
 	}
+	if common.GatewayServerJWTSecret != "" {
+		keys[""] = common.GatewayServerJWTSecret
+	}
+	if len(keys) == 0 {
+		return errors.New("--gw-server-jwt-secret or --gw-server-jwt-keys must be set")
+	}
+
+	validator := auth.NewJWTValidator(common.DB, "HS256", keys)
 
 	var opts []grpc.ServerOption
 	if c.String("gw-server-tls-cert") != "" && c.String("gw-server-tls-key") != "" {
@@ -424,6 +641,61 @@ func startGatewayAPIServer(c *cli.Context) error {
 	return nil
 }
 
+func startBackendAPIServer(c *cli.Context) error {
+	if c.String("backend-api-bind") == "" {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"bind":     c.String("backend-api-bind"),
+		"ca-cert":  c.String("backend-api-ca-cert"),
+		"tls-cert": c.String("backend-api-tls-cert"),
+		"tls-key":  c.String("backend-api-tls-key"),
+	}).Info("starting backend api server")
+
+	server := &http.Server{
+		Addr: c.String("backend-api-bind"),
+		Handler: backendapi.NewAPI(backendapi.Config{
+			AnswerStore: answerStore,
+			JoinServer:  c.Bool("js-embedded"),
+			Roaming:     common.RoamingPool != nil,
+		}),
+	}
+
+	if c.String("backend-api-ca-cert") == "" {
+		log.Warning("backend-api-ca-cert is not set, asynchronous join-server answers and AppSKey requests will be refused as clients can't be authenticated")
+	}
+
+	if c.String("backend-api-tls-cert") == "" || c.String("backend-api-tls-key") == "" {
+		go func() {
+			log.Fatal(server.ListenAndServe())
+		}()
+		return nil
+	}
+
+	if c.String("backend-api-ca-cert") != "" {
+		rawCACert, err := ioutil.ReadFile(c.String("backend-api-ca-cert"))
+		if err != nil {
+			return errors.Wrap(err, "read ca cert error")
+		}
+
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(rawCACert) {
+			return errors.New("append ca cert to pool error")
+		}
+
+		server.TLSConfig = &tls.Config{
+			ClientCAs:  caCertPool,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		}
+	}
+
+	go func() {
+		log.Fatal(server.ListenAndServeTLS(c.String("backend-api-tls-cert"), c.String("backend-api-tls-key")))
+	}()
+	return nil
+}
+
 func startLoRaServer(server *uplink.Server) func(*cli.Context) error {
 	return func(c *cli.Context) error {
 		*server = *uplink.NewServer()
@@ -441,6 +713,47 @@ func startStatsServer(gwStats *gateway.StatsHandler) func(*cli.Context) error {
 	}
 }
 
+func startGatewayHealthMonitor(gwHealth **gateway.HealthMonitor) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		notifier := gateway.MultiNotifier{gateway.ControllerNotifier{}}
+		if url := c.String("gw-state-webhook-url"); url != "" {
+			notifier = append(notifier, gateway.NewWebhookNotifier(url))
+		}
+
+		*gwHealth = gateway.NewHealthMonitor(notifier, c.Duration("gw-stats-interval"), c.Int("gw-offline-missed-stats"))
+		if err := (*gwHealth).Start(); err != nil {
+			return errors.Wrap(err, "start gateway health monitor error")
+		}
+		return nil
+	}
+}
+
+func startHousekeeping(hk **housekeeping.Housekeeping) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		if c.Duration("housekeeping-interval") == 0 {
+			log.Info("housekeeping is disabled")
+			return nil
+		}
+
+		retention, err := housekeeping.ParseRetention(c.String("gw-stats-retention"))
+		if err != nil {
+			return errors.Wrap(err, "parse gateway stats retention error")
+		}
+
+		*hk = housekeeping.New(common.DB, common.RedisPool, housekeeping.Config{
+			Interval:              c.Duration("housekeeping-interval"),
+			BatchSize:             c.Int("housekeeping-batch-size"),
+			GatewayStatsRetention: retention,
+			FrameLogMaxAge:        c.Duration("frame-log-max-age"),
+			FrameLogMaxCount:      c.Int("frame-log-max-count"),
+		})
+		if err := (*hk).Start(); err != nil {
+			return errors.Wrap(err, "start housekeeping error")
+		}
+		return nil
+	}
+}
+
 func mustGetTransportCredentials(tlsCert, tlsKey, caCert string, verifyClientCert bool) credentials.TransportCredentials {
 	var caCertPool *x509.CertPool
 	cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
@@ -482,12 +795,20 @@ func main() {
 	app.Version = version
 	app.Copyright = "See http://github.com/brocaar/loraserver for copyright information"
 	app.Action = run
+	app.Commands = []cli.Command{
+		simulateCommand,
+	}
 	app.Flags = []cli.Flag{
 		cli.StringFlag{
 			Name:   "net-id",
 			Usage:  "network identifier (NetID, 3 bytes) encoded as HEX (e.g. 010203)",
 			EnvVar: "NET_ID",
 		},
+		cli.StringSliceFlag{
+			Name:   "dev-addr-range",
+			Usage:  "DevAddr range used for the activation of devices in the format START-END encoded as HEX, e.g. 06000000-0601ffff (can be repeated, uses the full NetID range when not set)",
+			EnvVar: "DEV_ADDR_RANGE",
+		},
 		cli.StringFlag{
 			Name:   "band",
 			Usage:  fmt.Sprintf("ism band configuration to use (options: %s)", strings.Join(bands, ", ")),
@@ -548,6 +869,27 @@ func main() {
 			Usage:  "JWT secret used by the gateway api server for gateway authentication / authorization",
 			EnvVar: "GW_SERVER_JWT_SECRET",
 		},
+		cli.StringFlag{
+			Name:   "gw-server-jwt-keys",
+			Usage:  "additional JWT secrets by key id (e.g. kid1=secret1,kid2=secret2), used for key rotation",
+			EnvVar: "GW_SERVER_JWT_KEYS",
+		},
+		cli.StringFlag{
+			Name:   "gw-server-jwt-signing-key-id",
+			Usage:  "id of the gw-server-jwt-keys key used for signing new gateway tokens (when empty, gw-server-jwt-secret is used)",
+			EnvVar: "GW_SERVER_JWT_SIGNING_KEY_ID",
+		},
+		cli.DurationFlag{
+			Name:   "gw-token-ttl",
+			Usage:  "default time-to-live of generated gateway tokens (0 = tokens do not expire)",
+			EnvVar: "GW_TOKEN_TTL",
+		},
+		cli.IntFlag{
+			Name:   "coverage-geohash-precision",
+			Usage:  "geohash precision (number of characters) of the coverage map cells (7 = cells of about 150 x 150 meters)",
+			Value:  7,
+			EnvVar: "COVERAGE_GEOHASH_PRECISION",
+		},
 		cli.StringFlag{
 			Name:   "gw-server-bind",
 			Usage:  "ip:port to bind the gateway api server",
@@ -571,6 +913,12 @@ func main() {
 			Usage:  "automatically apply database migrations",
 			EnvVar: "DB_AUTOMIGRATE",
 		},
+		cli.StringFlag{
+			Name:   "gw-backend",
+			Usage:  "gateway backend to use (options: mqtt, semtech-udp, basicstation)",
+			Value:  "mqtt",
+			EnvVar: "GW_BACKEND",
+		},
 		cli.StringFlag{
 			Name:   "gw-mqtt-server",
 			Usage:  "mqtt broker server used by the gateway backend (e.g. scheme://host:port where scheme is tcp, ssl or ws)",
@@ -592,6 +940,86 @@ func main() {
 			Usage:  "mqtt CA certificate file used by the gateway backend (optional)",
 			EnvVar: "GW_MQTT_CA_CERT",
 		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-rx-topic-template",
+			Usage:  "mqtt topic template for the received packets ({{ .MAC }} is replaced by the gateway mac)",
+			Value:  gwBackend.DefaultRXTopicTemplate,
+			EnvVar: "GW_MQTT_RX_TOPIC_TEMPLATE",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-stats-topic-template",
+			Usage:  "mqtt topic template for the gateway stats ({{ .MAC }} is replaced by the gateway mac)",
+			Value:  gwBackend.DefaultStatsTopicTemplate,
+			EnvVar: "GW_MQTT_STATS_TOPIC_TEMPLATE",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-tx-topic-template",
+			Usage:  "mqtt topic template for the packets to transmit ({{ .MAC }} is replaced by the gateway mac)",
+			Value:  gwBackend.DefaultTXTopicTemplate,
+			EnvVar: "GW_MQTT_TX_TOPIC_TEMPLATE",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-ack-topic-template",
+			Usage:  "mqtt topic template for the transmit acknowledgements ({{ .MAC }} is replaced by the gateway mac)",
+			Value:  gwBackend.DefaultAckTopicTemplate,
+			EnvVar: "GW_MQTT_ACK_TOPIC_TEMPLATE",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-config-topic-template",
+			Usage:  "mqtt topic template for the gateway configuration ({{ .MAC }} is replaced by the gateway mac)",
+			Value:  gwBackend.DefaultConfigTopicTemplate,
+			EnvVar: "GW_MQTT_CONFIG_TOPIC_TEMPLATE",
+		},
+		cli.IntFlag{
+			Name:   "gw-mqtt-qos",
+			Usage:  "mqtt qos used by the gateway backend for publishing (0, 1 or 2)",
+			Value:  0,
+			EnvVar: "GW_MQTT_QOS",
+		},
+		cli.IntFlag{
+			Name:   "gw-mqtt-subscribe-qos",
+			Usage:  "mqtt qos used by the gateway backend for subscribing (0, 1 or 2)",
+			Value:  2,
+			EnvVar: "GW_MQTT_SUBSCRIBE_QOS",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-shared-subscription-group",
+			Usage:  "mqtt shared subscription group, when set each message is delivered to only one loraserver instance of the group (optional)",
+			EnvVar: "GW_MQTT_SHARED_SUBSCRIPTION_GROUP",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-marshaler",
+			Usage:  "payload encoding used by the gateway backend (options: json, protobuf)",
+			Value:  gwBackend.MarshalerJSON,
+			EnvVar: "GW_MQTT_MARSHALER",
+		},
+		cli.StringFlag{
+			Name:   "gw-udp-bind",
+			Usage:  "ip:port to bind the semtech-udp gateway backend (packet-forwarder) listener",
+			Value:  "0.0.0.0:1700",
+			EnvVar: "GW_UDP_BIND",
+		},
+		cli.StringFlag{
+			Name:   "gw-basicstation-bind",
+			Usage:  "ip:port to bind the basicstation gateway backend (websocket) listener",
+			Value:  "0.0.0.0:3001",
+			EnvVar: "GW_BASICSTATION_BIND",
+		},
+		cli.StringFlag{
+			Name:   "gw-basicstation-ca-cert",
+			Usage:  "ca certificate used by the basicstation gateway backend for verifying the gateway client certificates (optional)",
+			EnvVar: "GW_BASICSTATION_CA_CERT",
+		},
+		cli.StringFlag{
+			Name:   "gw-basicstation-tls-cert",
+			Usage:  "tls certificate used by the basicstation gateway backend (optional)",
+			EnvVar: "GW_BASICSTATION_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "gw-basicstation-tls-key",
+			Usage:  "tls key used by the basicstation gateway backend (optional)",
+			EnvVar: "GW_BASICSTATION_TLS_KEY",
+		},
 		cli.StringFlag{
 			Name:   "as-ca-cert",
 			Usage:  "ca certificate used by the application-server client (optional)",
@@ -639,12 +1067,64 @@ func main() {
 			EnvVar: "GET_DOWNLINK_DATA_DELAY",
 			Value:  100 * time.Millisecond,
 		},
+		cli.IntFlag{
+			Name:   "join-rate-limit-dev-eui",
+			Usage:  "max. number of join-requests per DevEUI within the join-rate-limit-interval (0 = disabled)",
+			EnvVar: "JOIN_RATE_LIMIT_DEV_EUI",
+			Value:  10,
+		},
+		cli.IntFlag{
+			Name:   "join-rate-limit-join-eui",
+			Usage:  "max. number of join-requests per JoinEUI within the join-rate-limit-interval (0 = disabled)",
+			EnvVar: "JOIN_RATE_LIMIT_JOIN_EUI",
+		},
+		cli.IntFlag{
+			Name:   "join-attempt-limit-dev-eui",
+			Usage:  "max. number of join-requests per DevEUI within the join-rate-limit-interval, counted before authentication (0 = disabled)",
+			EnvVar: "JOIN_ATTEMPT_LIMIT_DEV_EUI",
+			Value:  30,
+		},
+		cli.DurationFlag{
+			Name:   "join-rate-limit-interval",
+			Usage:  "interval of the join-request rate limits",
+			EnvVar: "JOIN_RATE_LIMIT_INTERVAL",
+			Value:  time.Minute,
+		},
+		cli.DurationFlag{
+			Name:   "join-rate-limit-max-backoff",
+			Usage:  "max. back-off after exceeding a join-request rate limit (the back-off starts at the join-rate-limit-interval and doubles each time the limit is exceeded again)",
+			EnvVar: "JOIN_RATE_LIMIT_MAX_BACKOFF",
+			Value:  time.Hour,
+		},
+		cli.DurationFlag{
+			Name:   "join-unknown-dev-eui-cache-ttl",
+			Usage:  "duration for which join-requests of an unknown DevEUI are rejected without database lookup (0 = disabled)",
+			EnvVar: "JOIN_UNKNOWN_DEV_EUI_CACHE_TTL",
+			Value:  time.Minute,
+		},
 		cli.StringFlag{
 			Name:   "gw-stats-aggregation-intervals",
 			Usage:  "aggregation intervals to use for aggregating the gateway stats (valid options: second, minute, hour, day, week, month, quarter, year)",
 			EnvVar: "GW_STATS_AGGREGATION_INTERVALS",
 			Value:  "minute,hour,day",
 		},
+		cli.DurationFlag{
+			Name:   "gw-stats-interval",
+			Usage:  "interval in which the gateways are expected to send their stats",
+			EnvVar: "GW_STATS_INTERVAL",
+			Value:  30 * time.Second,
+		},
+		cli.IntFlag{
+			Name:   "gw-offline-missed-stats",
+			Usage:  "number of missed stats intervals after which a gateway is marked offline",
+			EnvVar: "GW_OFFLINE_MISSED_STATS",
+			Value:  3,
+		},
+		cli.StringFlag{
+			Name:   "gw-state-webhook-url",
+			Usage:  "url to which gateway online / offline state changes are posted as json (optional)",
+			EnvVar: "GW_STATE_WEBHOOK_URL",
+		},
 		cli.StringFlag{
 			Name:   "timezone",
 			Usage:  "timezone to use when aggregating data (e.g. 'Europe/Amsterdam') (optional, by default the db timezone is used)",
@@ -676,6 +1156,34 @@ func main() {
 			Usage:  "log uplink and downlink frames to the database",
 			EnvVar: "LOG_NODE_FRAMES",
 		},
+		cli.DurationFlag{
+			Name:   "frame-log-max-age",
+			Usage:  "max age of the logged frames, older frames are removed by the housekeeping (0 = no limit)",
+			EnvVar: "FRAME_LOG_MAX_AGE",
+		},
+		cli.IntFlag{
+			Name:   "frame-log-max-count",
+			Usage:  "max number of logged frames, the oldest frames are removed by the housekeeping (0 = no limit)",
+			EnvVar: "FRAME_LOG_MAX_COUNT",
+		},
+		cli.StringFlag{
+			Name:   "gw-stats-retention",
+			Usage:  "retention of the gateway stats per aggregation interval, older stats are removed by the housekeeping (e.g. 'second=1h,minute=48h', intervals which are not set are kept forever)",
+			EnvVar: "GW_STATS_RETENTION",
+			Value:  "second=1h,minute=48h",
+		},
+		cli.DurationFlag{
+			Name:   "housekeeping-interval",
+			Usage:  "interval in which the housekeeping (removal of expired gateway stats and frame logs) runs (0 = disabled)",
+			EnvVar: "HOUSEKEEPING_INTERVAL",
+			Value:  time.Hour,
+		},
+		cli.IntFlag{
+			Name:   "housekeeping-batch-size",
+			Usage:  "max number of rows removed by a single housekeeping query",
+			EnvVar: "HOUSEKEEPING_BATCH_SIZE",
+			Value:  1000,
+		},
 		cli.IntFlag{
 			Name:   "log-level",
 			Value:  4,
@@ -703,6 +1211,109 @@ func main() {
 			Usage:  "tls key used by the default join-server client (optional)",
 			EnvVar: "JS_TLS_KEY",
 		},
+		cli.StringSliceFlag{
+			Name:   "js-server-route",
+			Usage:  "route a JoinEUI prefix or range to a join-server, e.g. 0102030400000000/32=https://js.example.com:8003;ca_cert=ca.pem;tls_cert=cert.pem;tls_key=key.pem (can be repeated)",
+			EnvVar: "JS_SERVER_ROUTE",
+		},
+		cli.BoolFlag{
+			Name:   "js-resolve-join-eui",
+			Usage:  "resolve the join-server using DNS when no route matches the JoinEUI",
+			EnvVar: "JS_RESOLVE_JOIN_EUI",
+		},
+		cli.StringFlag{
+			Name:   "js-resolve-domain-suffix",
+			Usage:  "domain suffix used for resolving the join-server by JoinEUI",
+			EnvVar: "JS_RESOLVE_DOMAIN_SUFFIX",
+			Value:  "joineuis.lora-alliance.org",
+		},
+		cli.StringFlag{
+			Name:   "js-resolve-ca-cert",
+			Usage:  "ca certificate used by the clients of the resolved join-servers (optional)",
+			EnvVar: "JS_RESOLVE_CA_CERT",
+		},
+		cli.StringFlag{
+			Name:   "js-resolve-tls-cert",
+			Usage:  "tls certificate used by the clients of the resolved join-servers (optional)",
+			EnvVar: "JS_RESOLVE_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "js-resolve-tls-key",
+			Usage:  "tls key used by the clients of the resolved join-servers (optional)",
+			EnvVar: "JS_RESOLVE_TLS_KEY",
+		},
+		cli.IntFlag{
+			Name:   "js-resolve-cache-size",
+			Usage:  "max. number of JoinEUIs of which the resolved join-server is cached",
+			EnvVar: "JS_RESOLVE_CACHE_SIZE",
+			Value:  1000,
+		},
+		cli.DurationFlag{
+			Name:   "js-resolve-cache-ttl",
+			Usage:  "duration for which the resolved join-server of a JoinEUI is cached",
+			EnvVar: "JS_RESOLVE_CACHE_TTL",
+			Value:  time.Hour,
+		},
+		cli.BoolFlag{
+			Name:   "js-embedded",
+			Usage:  "use the embedded join-server as default join-server (LoRaWAN 1.0.x only, the root-keys are managed using the api)",
+			EnvVar: "JS_EMBEDDED",
+		},
+		cli.StringFlag{
+			Name:   "js-embedded-kek-label",
+			Usage:  "label of the kek used by the embedded join-server for encrypting the root-keys at rest (must be set when the embedded join-server is enabled)",
+			EnvVar: "JS_EMBEDDED_KEK_LABEL",
+		},
+		cli.StringFlag{
+			Name:   "js-embedded-as-kek-label",
+			Usage:  "label of the kek used by the embedded join-server for wrapping the AppSKey when requested by the application-server (AppSKey requests are refused when empty)",
+			EnvVar: "JS_EMBEDDED_AS_KEK_LABEL",
+		},
+		cli.StringFlag{
+			Name:   "backend-api-bind",
+			Usage:  "ip:port to bind the backend-interfaces api server, used for receiving asynchronous join-server answers and roaming messages (disabled when empty)",
+			EnvVar: "BACKEND_API_BIND",
+		},
+		cli.StringFlag{
+			Name:   "backend-api-ca-cert",
+			Usage:  "ca certificate used by the backend-interfaces api server for verifying client certificates (optional)",
+			EnvVar: "BACKEND_API_CA_CERT",
+		},
+		cli.StringFlag{
+			Name:   "backend-api-tls-cert",
+			Usage:  "tls certificate used by the backend-interfaces api server (optional)",
+			EnvVar: "BACKEND_API_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "backend-api-tls-key",
+			Usage:  "tls key used by the backend-interfaces api server (optional)",
+			EnvVar: "BACKEND_API_TLS_KEY",
+		},
+		cli.StringSliceFlag{
+			Name:   "roaming-agreement",
+			Usage:  "roaming agreement in the format NETID=SERVER[;ca_cert=FILE;tls_cert=FILE;tls_key=FILE;client_cn=NAME;join_eui=START-END] (can be repeated)",
+			EnvVar: "ROAMING_AGREEMENT",
+		},
+		cli.DurationFlag{
+			Name:   "roaming-lifetime",
+			Usage:  "lifetime of the passive-roaming sessions returned to the fNS (0 = stateless passive-roaming)",
+			EnvVar: "ROAMING_LIFETIME",
+		},
+		cli.DurationFlag{
+			Name:   "roaming-handover-lifetime",
+			Usage:  "lifetime of the handover-roaming sessions returned to the sNS (0 = no expiry)",
+			EnvVar: "ROAMING_HANDOVER_LIFETIME",
+		},
+		cli.StringFlag{
+			Name:   "roaming-kek-label",
+			Usage:  "label of the kek used for wrapping the NwkSKey when returned to the fNS or sNS (sent unwrapped when empty)",
+			EnvVar: "ROAMING_KEK_LABEL",
+		},
+		cli.StringSliceFlag{
+			Name:   "kek",
+			Usage:  "key-encryption-key used for (un)wrapping keys, in the format LABEL=HEXKEY (can be repeated)",
+			EnvVar: "KEK",
+		},
 		cli.Float64Flag{
 			Name:   "installation-margin",
 			Usage:  "installation margin (dB) used by the ADR engine",
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/simulator"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// simulateCommand runs virtual gateways and devices against a running
+// LoRa Server instance. The gateways and devices are provisioned through
+// the network-server API, the gateways connect through the MQTT broker.
+var simulateCommand = cli.Command{
+	Name:   "simulate",
+	Usage:  "simulate gateways and devices against a running loraserver instance",
+	Action: runSimulation,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:   "band",
+			Usage:  fmt.Sprintf("ism band configuration to use (options: %s)", strings.Join(bands, ", ")),
+			EnvVar: "BAND",
+		},
+		cli.StringFlag{
+			Name:   "ns-server",
+			Usage:  "hostname:port of the network-server api",
+			Value:  "127.0.0.1:8000",
+			EnvVar: "NS_SERVER",
+		},
+		cli.StringFlag{
+			Name:   "ns-ca-cert",
+			Usage:  "ca certificate used by the network-server api (optional)",
+			EnvVar: "NS_CA_CERT",
+		},
+		cli.StringFlag{
+			Name:   "ns-tls-cert",
+			Usage:  "tls certificate used to connect to the network-server api (optional)",
+			EnvVar: "NS_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   "ns-tls-key",
+			Usage:  "tls key used to connect to the network-server api (optional)",
+			EnvVar: "NS_TLS_KEY",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-server",
+			Usage:  "mqtt broker server used by the gateway backend (e.g. scheme://host:port where scheme is tcp, ssl or ws)",
+			Value:  "tcp://localhost:1883",
+			EnvVar: "GW_MQTT_SERVER",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-username",
+			Usage:  "mqtt username used by the gateway backend (optional)",
+			EnvVar: "GW_MQTT_USERNAME",
+		},
+		cli.StringFlag{
+			Name:   "gw-mqtt-password",
+			Usage:  "mqtt password used by the gateway backend (optional)",
+			EnvVar: "GW_MQTT_PASSWORD",
+		},
+		cli.StringFlag{
+			Name:   "service-profile-id",
+			Usage:  "service-profile id of the simulated devices",
+			EnvVar: "SERVICE_PROFILE_ID",
+		},
+		cli.StringFlag{
+			Name:   "device-profile-id",
+			Usage:  "device-profile id of the simulated devices",
+			EnvVar: "DEVICE_PROFILE_ID",
+		},
+		cli.StringFlag{
+			Name:   "routing-profile-id",
+			Usage:  "routing-profile id of the simulated devices",
+			EnvVar: "ROUTING_PROFILE_ID",
+		},
+		cli.IntFlag{
+			Name:   "gateways",
+			Usage:  "number of simulated gateways",
+			Value:  1,
+			EnvVar: "GATEWAYS",
+		},
+		cli.IntFlag{
+			Name:   "devices",
+			Usage:  "number of simulated devices",
+			Value:  10,
+			EnvVar: "DEVICES",
+		},
+		cli.StringFlag{
+			Name:   "activation",
+			Usage:  "activation method of the simulated devices (otaa or abp, otaa requires the embedded join-server)",
+			Value:  string(simulator.OTAA),
+			EnvVar: "ACTIVATION",
+		},
+		cli.DurationFlag{
+			Name:   "uplink-interval",
+			Usage:  "interval between the uplinks of each device",
+			Value:  time.Minute,
+			EnvVar: "UPLINK_INTERVAL",
+		},
+		cli.DurationFlag{
+			Name:   "ack-timeout",
+			Usage:  "time to wait for a join-accept or ack before retransmitting",
+			Value:  10 * time.Second,
+			EnvVar: "ACK_TIMEOUT",
+		},
+		cli.DurationFlag{
+			Name:   "stats-interval",
+			Usage:  "interval of the gateway stats (0 = disabled)",
+			Value:  30 * time.Second,
+			EnvVar: "STATS_INTERVAL",
+		},
+		cli.DurationFlag{
+			Name:   "duration",
+			Usage:  "duration of the simulation (0 = until interrupted)",
+			EnvVar: "DURATION",
+		},
+		cli.IntFlag{
+			Name:   "fport",
+			Usage:  "fport of the uplink payloads",
+			Value:  10,
+			EnvVar: "FPORT",
+		},
+		cli.StringFlag{
+			Name:   "payload",
+			Usage:  "uplink payload encoded as HEX",
+			Value:  "010203",
+			EnvVar: "PAYLOAD",
+		},
+		cli.BoolFlag{
+			Name:   "confirmed",
+			Usage:  "send confirmed uplinks",
+			EnvVar: "CONFIRMED",
+		},
+		cli.IntFlag{
+			Name:   "nb-trans",
+			Usage:  "max. number of transmissions of an unacknowledged confirmed uplink",
+			Value:  3,
+			EnvVar: "NB_TRANS",
+		},
+		cli.IntFlag{
+			Name:   "dr",
+			Usage:  "initial uplink data-rate of the devices",
+			EnvVar: "DR",
+		},
+		cli.BoolFlag{
+			Name:   "adr",
+			Usage:  "enable adr for the simulated devices",
+			EnvVar: "ADR",
+		},
+		cli.IntFlag{
+			Name:   "rssi",
+			Usage:  "rssi (dBm) of the uplinks received by the gateways",
+			Value:  -80,
+			EnvVar: "RSSI",
+		},
+		cli.Float64Flag{
+			Name:   "snr",
+			Usage:  "snr (dB) of the uplinks received by the gateways",
+			Value:  5,
+			EnvVar: "SNR",
+		},
+		cli.BoolFlag{
+			Name:   "keep",
+			Usage:  "do not delete the provisioned gateways and devices when the simulation ends",
+			EnvVar: "KEEP",
+		},
+		cli.StringFlag{
+			Name:   "log-level",
+			Value:  "info",
+			Usage:  "debug=5, info=4, warning=3, error=2, fatal=1, panic=0",
+			EnvVar: "LOG_LEVEL",
+		},
+	},
+}
+
+func runSimulation(c *cli.Context) error {
+	level, err := log.ParseLevel(c.String("log-level"))
+	if err != nil {
+		return errors.Wrap(err, "parse log-level error")
+	}
+	log.SetLevel(level)
+
+	if c.String("band") == "" {
+		return fmt.Errorf("--band is undefined, valid options are: %s", strings.Join(bands, ", "))
+	}
+	b, err := band.GetConfig(band.Name(c.String("band")), false, lorawan.DwellTimeNoLimit)
+	if err != nil {
+		return errors.Wrap(err, "get band config error")
+	}
+
+	activation := simulator.Activation(c.String("activation"))
+	if activation != simulator.OTAA && activation != simulator.ABP {
+		return fmt.Errorf("invalid activation: %s", activation)
+	}
+
+	payload, err := hex.DecodeString(c.String("payload"))
+	if err != nil {
+		return errors.Wrap(err, "decode payload error")
+	}
+
+	nsClient, err := newNetworkServerClient(c)
+	if err != nil {
+		return err
+	}
+
+	backend, err := simulator.NewMQTTBackend(c.String("gw-mqtt-server"), c.String("gw-mqtt-username"), c.String("gw-mqtt-password"))
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	sim := simulator.NewSimulator(backend, simulator.Config{
+		Band:           b,
+		UplinkInterval: c.Duration("uplink-interval"),
+		AckTimeout:     c.Duration("ack-timeout"),
+		StatsInterval:  c.Duration("stats-interval"),
+	})
+
+	var gateways []lorawan.EUI64
+	var devices []lorawan.EUI64
+	defer func() {
+		if !c.Bool("keep") {
+			cleanupSimulation(nsClient, gateways, devices)
+		}
+	}()
+
+	for i := 0; i < c.Int("gateways"); i++ {
+		g := simulator.Gateway{
+			RSSI: c.Int("rssi"),
+			SNR:  c.Float64("snr"),
+		}
+		if _, err := rand.Read(g.MAC[:]); err != nil {
+			return errors.Wrap(err, "read random bytes error")
+		}
+
+		_, err := nsClient.CreateGateway(context.Background(), &ns.CreateGatewayRequest{
+			Mac:  g.MAC[:],
+			Name: fmt.Sprintf("simulator-%s", g.MAC),
+		})
+		if err != nil {
+			return errors.Wrap(err, "create gateway error")
+		}
+		gateways = append(gateways, g.MAC)
+		sim.AddGateway(&g)
+	}
+
+	for i := 0; i < c.Int("devices"); i++ {
+		d := simulator.Device{
+			Activation: activation,
+			DR:         c.Int("dr"),
+			ADR:        c.Bool("adr"),
+			NbTrans:    c.Int("nb-trans"),
+			FPort:      uint8(c.Int("fport")),
+			Payload:    payload,
+			Confirmed:  c.Bool("confirmed"),
+		}
+		if err := provisionDevice(c, nsClient, &d); err != nil {
+			return err
+		}
+		devices = append(devices, d.DevEUI)
+		sim.AddDevice(&d)
+	}
+
+	log.WithFields(log.Fields{
+		"gateways":   len(gateways),
+		"devices":    len(devices),
+		"activation": activation,
+	}).Info("simulator: starting simulation")
+	sim.Start()
+
+	var timeout <-chan time.Time
+	if c.Duration("duration") > 0 {
+		timeout = time.After(c.Duration("duration"))
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	select {
+	case s := <-sigChan:
+		log.WithField("signal", s).Info("signal received")
+	case <-timeout:
+	}
+
+	log.Warning("stopping simulation")
+	sim.Stop()
+
+	stats := sim.Stats()
+	log.WithFields(log.Fields{
+		"join_requests":   stats.JoinRequests,
+		"join_accepts":    stats.JoinAccepts,
+		"uplinks":         stats.Uplinks,
+		"retransmissions": stats.Retransmissions,
+		"acks":            stats.Acks,
+		"ack_timeouts":    stats.AckTimeouts,
+		"downlinks":       stats.Downlinks,
+		"mac_commands":    stats.MACCommands,
+		"errors":          stats.Errors,
+	}).Info("simulator: simulation finished")
+
+	return nil
+}
+
+// newNetworkServerClient returns a client for the network-server api.
+func newNetworkServerClient(c *cli.Context) (ns.NetworkServerClient, error) {
+	opts := []grpc.DialOption{
+		grpc.WithBlock(),
+	}
+	if c.String("ns-tls-cert") == "" && c.String("ns-tls-key") == "" && c.String("ns-ca-cert") == "" {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(
+			mustGetTransportCredentials(c.String("ns-tls-cert"), c.String("ns-tls-key"), c.String("ns-ca-cert"), false),
+		))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, c.String("ns-server"), opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial network-server api error")
+	}
+
+	return ns.NewNetworkServerClient(conn), nil
+}
+
+// provisionDevice creates the given device with random keys. OTAA devices
+// get a random AppKey, ABP devices are activated using a random DevAddr
+// and session-keys.
+func provisionDevice(c *cli.Context, nsClient ns.NetworkServerClient, d *simulator.Device) error {
+	for _, b := range [][]byte{d.DevEUI[:], d.AppEUI[:], d.AppKey[:], d.NwkSKey[:], d.AppSKey[:]} {
+		if _, err := rand.Read(b); err != nil {
+			return errors.Wrap(err, "read random bytes error")
+		}
+	}
+
+	_, err := nsClient.CreateDevice(context.Background(), &ns.CreateDeviceRequest{
+		Device: &ns.Device{
+			DevEUI:           d.DevEUI[:],
+			DeviceProfileID:  c.String("device-profile-id"),
+			ServiceProfileID: c.String("service-profile-id"),
+			RoutingProfileID: c.String("routing-profile-id"),
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "create device error")
+	}
+
+	if d.Activation == simulator.OTAA {
+		_, err := nsClient.CreateDeviceKeys(context.Background(), &ns.CreateDeviceKeysRequest{
+			DeviceKeys: &ns.DeviceKeys{
+				DevEUI: d.DevEUI[:],
+				NwkKey: d.AppKey[:],
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "create device-keys error")
+		}
+		return nil
+	}
+
+	resp, err := nsClient.GetRandomDevAddr(context.Background(), &ns.GetRandomDevAddrRequest{
+		ServiceProfileID: c.String("service-profile-id"),
+	})
+	if err != nil {
+		return errors.Wrap(err, "get random devaddr error")
+	}
+	copy(d.DevAddr[:], resp.DevAddr)
+
+	_, err = nsClient.ActivateDevice(context.Background(), &ns.ActivateDeviceRequest{
+		DevEUI:  d.DevEUI[:],
+		DevAddr: d.DevAddr[:],
+		NwkSKey: d.NwkSKey[:],
+	})
+	if err != nil {
+		return errors.Wrap(err, "activate device error")
+	}
+
+	return nil
+}
+
+// cleanupSimulation deletes the provisioned gateways and devices.
+func cleanupSimulation(nsClient ns.NetworkServerClient, gateways, devices []lorawan.EUI64) {
+	for _, devEUI := range devices {
+		if _, err := nsClient.DeleteDevice(context.Background(), &ns.DeleteDeviceRequest{DevEUI: devEUI[:]}); err != nil {
+			log.WithField("dev_eui", devEUI).Errorf("simulator: delete device error: %s", err)
+		}
+	}
+
+	for _, mac := range gateways {
+		if _, err := nsClient.DeleteGateway(context.Background(), &ns.DeleteGatewayRequest{Mac: mac[:]}); err != nil {
+			log.WithField("mac", mac).Errorf("simulator: delete gateway error: %s", err)
+		}
+	}
+}
@@ -3,11 +3,15 @@ package auth
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/metadata"
 
+	"github.com/brocaar/loraserver/internal/gateway"
 	"github.com/brocaar/lorawan"
 	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 )
 
@@ -25,16 +29,46 @@ type Validator interface {
 	GetMAC(context.Context) (lorawan.EUI64, error)
 }
 
+// ParseKeys parses the given signing keys string
+// (e.g. "kid1=secret1,kid2=secret2") into a map of key ID to secret.
+func ParseKeys(s string) (map[string]string, error) {
+	out := make(map[string]string)
+	if s == "" {
+		return out, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid key '%s', expected kid=secret", item)
+		}
+		if _, ok := out[kv[0]]; ok {
+			return nil, fmt.Errorf("duplicate key id '%s'", kv[0])
+		}
+		out[kv[0]] = kv[1]
+	}
+
+	return out, nil
+}
+
 // JWTValidator validates JWT tokens.
 type JWTValidator struct {
-	secret    string
+	db        *sqlx.DB
+	keys      map[string]string
 	algorithm string
 }
 
-// NewJWTValidator creates a new JWTValidator.
-func NewJWTValidator(algorithm, secret string) *JWTValidator {
+// NewJWTValidator creates a new JWTValidator. The keys map contains the
+// secret per key ID (kid). The secret of tokens without kid header must be
+// stored under the empty key ID. Tokens containing a token ID (jti) are
+// validated against the gateway tokens stored in the database, so that
+// they can be revoked. Tokens without token ID can't be revoked and are
+// therefore only accepted when they don't have a kid header (legacy tokens
+// signed with the default secret).
+func NewJWTValidator(db *sqlx.DB, algorithm string, keys map[string]string) *JWTValidator {
 	return &JWTValidator{
-		secret:    secret,
+		db:        db,
+		keys:      keys,
 		algorithm: algorithm,
 	}
 }
@@ -55,11 +89,23 @@ func (v JWTValidator) getClaims(ctx context.Context) (*Claims, error) {
 		return nil, errors.Wrap(err, "get token from context error")
 	}
 
+	var kid string
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if token.Header["alg"] != v.algorithm {
 			return nil, ErrInvalidAlgorithm
 		}
-		return []byte(v.secret), nil
+
+		if k, ok := token.Header["kid"]; ok {
+			if kid, ok = k.(string); !ok {
+				return nil, ErrUnknownKeyID
+			}
+		}
+
+		secret, ok := v.keys[kid]
+		if !ok {
+			return nil, ErrUnknownKeyID
+		}
+		return []byte(secret), nil
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "jwt parse error")
@@ -75,9 +121,47 @@ func (v JWTValidator) getClaims(ctx context.Context) (*Claims, error) {
 		return nil, fmt.Errorf("api/auth: expected *Claims, got %T", token.Claims)
 	}
 
+	if claims.Id == "" {
+		// tokens signed with a key id are always issued with a token id,
+		// accepting them without would bypass the revocation check
+		if kid != "" {
+			return nil, ErrNoTokenID
+		}
+	} else {
+		if err := v.validateTokenID(claims); err != nil {
+			return nil, err
+		}
+	}
+
 	return claims, nil
 }
 
+// validateTokenID validates that the token ID has been issued to the
+// gateway of the claims and that it has not been revoked or expired.
+func (v JWTValidator) validateTokenID(claims *Claims) error {
+	t, err := gateway.GetToken(v.db, claims.Id)
+	if err != nil {
+		if errors.Cause(err) == gateway.ErrTokenDoesNotExist {
+			return ErrInvalidToken
+		}
+		return errors.Wrap(err, "get gateway token error")
+	}
+
+	if t.MAC != claims.MAC {
+		return ErrInvalidToken
+	}
+
+	if t.IsRevoked() {
+		return ErrRevokedToken
+	}
+
+	if t.ExpiresAt != nil && !t.ExpiresAt.After(time.Now()) {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
 func getTokenFromContext(ctx context.Context) (string, error) {
 	md, ok := metadata.FromContext(ctx)
 	if !ok {
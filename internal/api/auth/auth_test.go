@@ -8,6 +8,9 @@ import (
 
 	"google.golang.org/grpc/metadata"
 
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/pkg/errors"
@@ -17,12 +20,16 @@ import (
 func TestJWTValidator(t *testing.T) {
 	Convey("Given a JWT validator", t, func() {
 		v := JWTValidator{
-			secret:    "verysecret",
+			keys: map[string]string{
+				"":     "verysecret",
+				"key2": "othersecret",
+			},
 			algorithm: "HS256",
 		}
 
 		testTable := []struct {
 			Description   string
+			KeyID         string
 			Key           string
 			Claims        Claims
 			ExpectedMAC   lorawan.EUI64
@@ -30,13 +37,13 @@ func TestJWTValidator(t *testing.T) {
 		}{
 			{
 				Description: "valid key and passing validation",
-				Key:         v.secret,
+				Key:         v.keys[""],
 				Claims:      Claims{MAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
 				ExpectedMAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
 			},
 			{
 				Description:   "valid key and expired token",
-				Key:           v.secret,
+				Key:           v.keys[""],
 				Claims:        Claims{MAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Unix() - 1}},
 				ExpectedError: "token is expired by 1s",
 			},
@@ -46,11 +53,28 @@ func TestJWTValidator(t *testing.T) {
 				Claims:        Claims{},
 				ExpectedError: "signature is invalid",
 			},
+			{
+				Description:   "valid key id without token id",
+				KeyID:         "key2",
+				Key:           v.keys["key2"],
+				Claims:        Claims{MAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
+				ExpectedError: "token id is required for tokens with key id",
+			},
+			{
+				Description:   "unknown key id",
+				KeyID:         "key3",
+				Key:           v.keys["key2"],
+				Claims:        Claims{},
+				ExpectedError: "unknown key id",
+			},
 		}
 
 		for i, test := range testTable {
 			Convey(fmt.Sprintf("Test %s [%d]", test.Description, i), func() {
 				token := jwt.NewWithClaims(jwt.SigningMethodHS256, test.Claims)
+				if test.KeyID != "" {
+					token.Header["kid"] = test.KeyID
+				}
 				ss, err := token.SignedString([]byte(test.Key))
 				So(err, ShouldBeNil)
 
@@ -69,3 +93,120 @@ func TestJWTValidator(t *testing.T) {
 		}
 	})
 }
+
+func TestParseKeys(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Keys          string
+			Expected      map[string]string
+			ExpectedError bool
+		}{
+			{
+				Name:     "empty",
+				Keys:     "",
+				Expected: map[string]string{},
+			},
+			{
+				Name: "valid",
+				Keys: "key1=secret1, key2=secret=2",
+				Expected: map[string]string{
+					"key1": "secret1",
+					"key2": "secret=2",
+				},
+			},
+			{
+				Name:          "missing secret",
+				Keys:          "key1",
+				ExpectedError: true,
+			},
+			{
+				Name:          "duplicate key id",
+				Keys:          "key1=secret1,key1=secret2",
+				ExpectedError: true,
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				keys, err := ParseKeys(tst.Keys)
+				if tst.ExpectedError {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(keys, ShouldResemble, tst.Expected)
+			})
+		}
+	})
+}
+
+func TestJWTValidatorTokenID(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with a gateway and token", t, func() {
+		test.MustResetDB(db)
+
+		v := NewJWTValidator(db, "HS256", map[string]string{"": "verysecret", "key2": "othersecret"})
+
+		g := gateway.Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(gateway.CreateGateway(db, &g), ShouldBeNil)
+
+		gt := gateway.Token{MAC: g.MAC}
+		So(gateway.CreateToken(db, &gt), ShouldBeNil)
+
+		getMAC := func(claims Claims) (lorawan.EUI64, error) {
+			ss, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("verysecret"))
+			So(err, ShouldBeNil)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"authorization": []string{ss},
+			})
+			return v.GetMAC(ctx)
+		}
+
+		Convey("Then a token with a valid token ID is accepted", func() {
+			mac, err := getMAC(Claims{MAC: g.MAC, StandardClaims: jwt.StandardClaims{Id: gt.ID}})
+			So(err, ShouldBeNil)
+			So(mac, ShouldEqual, g.MAC)
+		})
+
+		Convey("Then a token with a key ID and a valid token ID is accepted", func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{MAC: g.MAC, StandardClaims: jwt.StandardClaims{Id: gt.ID}})
+			token.Header["kid"] = "key2"
+			ss, err := token.SignedString([]byte("othersecret"))
+			So(err, ShouldBeNil)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{
+				"authorization": []string{ss},
+			})
+			mac, err := v.GetMAC(ctx)
+			So(err, ShouldBeNil)
+			So(mac, ShouldEqual, g.MAC)
+		})
+
+		Convey("Then a token with an unknown token ID is rejected", func() {
+			_, err := getMAC(Claims{MAC: g.MAC, StandardClaims: jwt.StandardClaims{Id: "e8a0bb8a-7d2e-4b3f-a2b1-6f1ed5ee7f2c"}})
+			So(errors.Cause(err), ShouldEqual, ErrInvalidToken)
+		})
+
+		Convey("Then a token issued to an other gateway is rejected", func() {
+			_, err := getMAC(Claims{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, StandardClaims: jwt.StandardClaims{Id: gt.ID}})
+			So(errors.Cause(err), ShouldEqual, ErrInvalidToken)
+		})
+
+		Convey("When the token is revoked", func() {
+			So(gateway.RevokeToken(db, g.MAC, gt.ID), ShouldBeNil)
+
+			Convey("Then the token is rejected", func() {
+				_, err := getMAC(Claims{MAC: g.MAC, StandardClaims: jwt.StandardClaims{Id: gt.ID}})
+				So(errors.Cause(err), ShouldEqual, ErrRevokedToken)
+			})
+		})
+	})
+}
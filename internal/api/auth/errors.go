@@ -8,4 +8,7 @@ var (
 	ErrNoAuthorizationInMetadata = errors.New("no authorization-data in metadata")
 	ErrInvalidAlgorithm          = errors.New("invalid algorithm")
 	ErrInvalidToken              = errors.New("invalid token")
+	ErrRevokedToken              = errors.New("token has been revoked")
+	ErrUnknownKeyID              = errors.New("unknown key id")
+	ErrNoTokenID                 = errors.New("token id is required for tokens with key id")
 )
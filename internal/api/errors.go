@@ -5,12 +5,19 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/brocaar/loraserver/internal/coverage"
 	"github.com/brocaar/loraserver/internal/downlink"
 	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/linktest"
+	"github.com/brocaar/loraserver/internal/roaming"
 	"github.com/brocaar/loraserver/internal/storage"
 )
 
 var errToCode = map[error]codes.Code{
+	coverage.ErrInvalidLocation:  codes.InvalidArgument,
+	coverage.ErrInvalidPrecision: codes.InvalidArgument,
+
 	downlink.ErrFPortMustNotBeZero:     codes.InvalidArgument,
 	downlink.ErrFPortMustBeZero:        codes.InvalidArgument,
 	downlink.ErrNoLastRXInfoSet:        codes.FailedPrecondition,
@@ -25,9 +32,24 @@ var errToCode = map[error]codes.Code{
 	gateway.ErrInvalidChannel:             codes.InvalidArgument,
 	gateway.ErrInvalidChannelConfig:       codes.InvalidArgument,
 	gateway.ErrInvalidChannelModulation:   codes.InvalidArgument,
+	gateway.ErrInvalidGatewayFilters:      codes.InvalidArgument,
+	gateway.ErrTokenDoesNotExist:          codes.NotFound,
+
+	kek.ErrUnknownLabel: codes.FailedPrecondition,
+
+	linktest.ErrDoesNotExist:    codes.NotFound,
+	linktest.ErrInvalidDataRate: codes.InvalidArgument,
+
+	roaming.ErrDoesNotExist: codes.NotFound,
+	roaming.ErrNoAgreement:  codes.FailedPrecondition,
 
 	storage.ErrDoesNotExistOrFCntOrMICInvalid: codes.NotFound,
 	storage.ErrDoesNotExist:                   codes.NotFound,
+	storage.ErrAlreadyExists:                  codes.AlreadyExists,
+	storage.ErrNoFreeDevAddr:                  codes.ResourceExhausted,
+	storage.ErrInvalidFCnt:                    codes.InvalidArgument,
+	storage.ErrNoKEKLabel:                     codes.FailedPrecondition,
+	storage.ErrJoinNonceDecreased:             codes.InvalidArgument,
 }
 
 func errToRPCError(err error) error {
@@ -6,6 +6,7 @@ import (
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -14,10 +15,15 @@ import (
 	"github.com/brocaar/loraserver/api/ns"
 	"github.com/brocaar/loraserver/internal/api/auth"
 	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/coverage"
+	"github.com/brocaar/loraserver/internal/devaddr"
 	"github.com/brocaar/loraserver/internal/downlink"
 	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/joinlimit"
+	"github.com/brocaar/loraserver/internal/linktest"
 	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/node"
+	"github.com/brocaar/loraserver/internal/roaming"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
@@ -72,6 +78,12 @@ func (n *NetworkServerAPI) CreateServiceProfile(ctx context.Context, req *ns.Cre
 		},
 	}
 
+	var err error
+	sp.DevAddrRanges, err = devAddrRangesFromReq(req.ServiceProfile.DevAddrRanges)
+	if err != nil {
+		return nil, err
+	}
+
 	switch req.ServiceProfile.UlRatePolicy {
 	case ns.RatePolicy_MARK:
 		sp.ServiceProfile.ULRatePolicy = backend.Mark
@@ -124,6 +136,7 @@ func (n *NetworkServerAPI) GetServiceProfile(ctx context.Context, req *ns.GetSer
 			NwkGeoLoc:      sp.ServiceProfile.NwkGeoLoc,
 			TargetPER:      uint32(sp.ServiceProfile.TargetPER),
 			MinGWDiversity: uint32(sp.ServiceProfile.MinGWDiversity),
+			DevAddrRanges:  devAddrRangesToResp(sp.DevAddrRanges),
 		},
 	}
 
@@ -172,6 +185,11 @@ func (n *NetworkServerAPI) UpdateServiceProfile(ctx context.Context, req *ns.Upd
 		MinGWDiversity: int(req.ServiceProfile.MinGWDiversity),
 	}
 
+	sp.DevAddrRanges, err = devAddrRangesFromReq(req.ServiceProfile.DevAddrRanges)
+	if err != nil {
+		return nil, err
+	}
+
 	switch req.ServiceProfile.UlRatePolicy {
 	case ns.RatePolicy_MARK:
 		sp.ServiceProfile.ULRatePolicy = backend.Mark
@@ -292,6 +310,8 @@ func (n *NetworkServerAPI) CreateDeviceProfile(ctx context.Context, req *ns.Crea
 			SupportsJoin:       req.DeviceProfile.SupportsJoin,
 			Supports32bitFCnt:  req.DeviceProfile.Supports32BitFCnt,
 		},
+		RXDROffset1Set: req.DeviceProfile.RxDROffset1Set || req.DeviceProfile.RxDROffset1 != 0,
+		RXDataRate2Set: req.DeviceProfile.RxDataRate2Set || req.DeviceProfile.RxDataRate2 != 0,
 	}
 
 	var ok bool
@@ -348,6 +368,8 @@ func (n *NetworkServerAPI) GetDeviceProfile(ctx context.Context, req *ns.GetDevi
 			SupportsJoin:       dp.DeviceProfile.SupportsJoin,
 			RfRegion:           string(dp.DeviceProfile.RFRegion),
 			Supports32BitFCnt:  dp.DeviceProfile.Supports32bitFCnt,
+			RxDROffset1Set:     dp.RXDROffset1Set,
+			RxDataRate2Set:     dp.RXDataRate2Set,
 		},
 	}
 
@@ -387,6 +409,8 @@ func (n *NetworkServerAPI) UpdateDeviceProfile(ctx context.Context, req *ns.Upda
 		SupportsJoin:       req.DeviceProfile.SupportsJoin,
 		Supports32bitFCnt:  req.DeviceProfile.Supports32BitFCnt,
 	}
+	dp.RXDROffset1Set = req.DeviceProfile.RxDROffset1Set || req.DeviceProfile.RxDROffset1 != 0
+	dp.RXDataRate2Set = req.DeviceProfile.RxDataRate2Set || req.DeviceProfile.RxDataRate2 != 0
 
 	var ok bool
 	dp.DeviceProfile.RFRegion, ok = rfRegionMapping[common.BandName]
@@ -429,6 +453,12 @@ func (n *NetworkServerAPI) CreateDevice(ctx context.Context, req *ns.CreateDevic
 		return nil, errToRPCError(err)
 	}
 
+	// the device might have been cached as unknown by the join flood
+	// protection
+	if err := joinlimit.ClearUnknownDevEUI(common.RedisPool, devEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
 	return &ns.CreateDeviceResponse{}, nil
 }
 
@@ -530,10 +560,10 @@ func (n *NetworkServerAPI) ActivateDevice(ctx context.Context, req *ns.ActivateD
 		SkipFCntValidation: req.SkipFCntCheck,
 
 		RXWindow:       storage.RX1,
-		RXDelay:        uint8(dp.RXDelay1),
-		RX1DROffset:    uint8(dp.RXDROffset1),
-		RX2DR:          uint8(dp.RXDataRate2),
-		RX2Frequency:   int(dp.RXFreq2),
+		RXDelay:        uint8(dp.GetRXDelay1()),
+		RX1DROffset:    uint8(dp.GetRXDROffset1()),
+		RX2DR:          uint8(dp.GetRXDataRate2()),
+		RX2Frequency:   dp.GetRXFreq2(),
 		MaxSupportedDR: sp.ServiceProfile.DRMax,
 
 		EnabledChannels:    common.Band.GetUplinkChannels(), // TODO: replace by ServiceProfile.ChannelMask?
@@ -581,9 +611,21 @@ func (n *NetworkServerAPI) GetDeviceActivation(ctx context.Context, req *ns.GetD
 	}, nil
 }
 
-// GetRandomDevAddr returns a random DevAddr.
+// GetRandomDevAddr returns a random free DevAddr. When a service-profile ID
+// is given, its DevAddr ranges are used (when set).
 func (n *NetworkServerAPI) GetRandomDevAddr(ctx context.Context, req *ns.GetRandomDevAddrRequest) (*ns.GetRandomDevAddrResponse, error) {
-	devAddr, err := storage.GetRandomDevAddr(common.RedisPool, common.NetID)
+	ranges := common.DevAddrRanges
+	if req.ServiceProfileID != "" {
+		sp, err := storage.GetServiceProfile(common.DB, req.ServiceProfileID)
+		if err != nil {
+			return nil, errToRPCError(err)
+		}
+		if len(sp.DevAddrRanges) != 0 {
+			ranges = sp.DevAddrRanges
+		}
+	}
+
+	devAddr, err := storage.GetRandomDevAddr(common.RedisPool, common.NetID, ranges)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -629,6 +671,10 @@ func (n *NetworkServerAPI) SendDownlinkData(ctx context.Context, req *ns.SendDow
 	copy(devEUI[:], req.DevEUI)
 
 	ds, err := storage.GetDeviceSession(common.RedisPool, devEUI)
+	if err == storage.ErrDoesNotExist && common.RoamingPool != nil {
+		// the device might be served by a roaming partner (handover roaming)
+		return sendHandoverDownlinkData(devEUI, req)
+	}
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -650,6 +696,26 @@ func (n *NetworkServerAPI) SendDownlinkData(ctx context.Context, req *ns.SendDow
 	return &ns.SendDownlinkDataResponse{}, nil
 }
 
+// sendHandoverDownlinkData sends the given downlink payload to the sNS
+// serving the device through handover roaming.
+func sendHandoverDownlinkData(devEUI lorawan.EUI64, req *ns.SendDownlinkDataRequest) (*ns.SendDownlinkDataResponse, error) {
+	hs, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if req.FCnt != hs.FCntDown {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid FCnt (expected: %d)", hs.FCntDown)
+	}
+
+	err = downlink.PushHandoverDataDown(hs, req.Confirmed, uint8(req.FPort), req.Data)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.SendDownlinkDataResponse{}, nil
+}
+
 // SendProprietaryPayload send a payload using the 'Proprietary' LoRaWAN message-type.
 func (n *NetworkServerAPI) SendProprietaryPayload(ctx context.Context, req *ns.SendProprietaryPayloadRequest) (*ns.SendProprietaryPayloadResponse, error) {
 	var mic lorawan.MIC
@@ -694,6 +760,12 @@ func (n *NetworkServerAPI) CreateGateway(ctx context.Context, req *ns.CreateGate
 		return nil, errToRPCError(err)
 	}
 
+	if gw.ChannelConfigurationID != nil {
+		if err := gateway.SendConfigPacket(common.DB, gw.MAC); err != nil {
+			log.WithField("mac", gw.MAC).Errorf("send gateway configuration error: %s", err)
+		}
+	}
+
 	return &ns.CreateGatewayResponse{}, nil
 }
 
@@ -707,7 +779,13 @@ func (n *NetworkServerAPI) GetGateway(ctx context.Context, req *ns.GetGatewayReq
 		return nil, errToRPCError(err)
 	}
 
-	return gwToResp(gw), nil
+	resp := gwToResp(gw)
+	resp.DesiredConfigVersion, err = gateway.GetDesiredConfigVersion(common.DB, gw)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return resp, nil
 }
 
 // UpdateGateway updates an existing gateway.
@@ -720,6 +798,11 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 		return nil, errToRPCError(err)
 	}
 
+	var oldCFID int64
+	if gw.ChannelConfigurationID != nil {
+		oldCFID = *gw.ChannelConfigurationID
+	}
+
 	if req.ChannelConfigurationID != 0 {
 		gw.ChannelConfigurationID = &req.ChannelConfigurationID
 	} else {
@@ -739,17 +822,70 @@ func (n *NetworkServerAPI) UpdateGateway(ctx context.Context, req *ns.UpdateGate
 		return nil, errToRPCError(err)
 	}
 
+	if req.ChannelConfigurationID != oldCFID {
+		if err := gateway.SendConfigPacket(common.DB, gw.MAC); err != nil {
+			log.WithField("mac", gw.MAC).Errorf("send gateway configuration error: %s", err)
+		}
+	}
+
 	return &ns.UpdateGatewayResponse{}, nil
 }
 
 // ListGateways returns the existing gateways.
 func (n *NetworkServerAPI) ListGateways(ctx context.Context, req *ns.ListGatewayRequest) (*ns.ListGatewayResponse, error) {
-	count, err := gateway.GetGatewayCount(common.DB)
+	filters := gateway.GatewayFilters{
+		Search:    req.Search,
+		OrderBy:   req.OrderBy.String(),
+		OrderDesc: req.OrderDesc,
+	}
+
+	if req.ChannelConfigurationID != 0 {
+		filters.ChannelConfigurationID = &req.ChannelConfigurationID
+	}
+
+	for _, s := range req.States {
+		filters.States = append(filters.States, s.String())
+	}
+
+	if req.LastSeenAtFrom != "" {
+		from, err := time.Parse(time.RFC3339Nano, req.LastSeenAtFrom)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parse last seen at from timestamp: %s", err)
+		}
+		filters.LastSeenAtFrom = &from
+	}
+
+	if req.LastSeenAtTo != "" {
+		to, err := time.Parse(time.RFC3339Nano, req.LastSeenAtTo)
+		if err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "parse last seen at to timestamp: %s", err)
+		}
+		filters.LastSeenAtTo = &to
+	}
+
+	if req.BoundingBox != nil {
+		filters.BoundingBox = &gateway.BoundingBox{
+			MinLatitude:  req.BoundingBox.MinLatitude,
+			MinLongitude: req.BoundingBox.MinLongitude,
+			MaxLatitude:  req.BoundingBox.MaxLatitude,
+			MaxLongitude: req.BoundingBox.MaxLongitude,
+		}
+	}
+
+	if req.Radius != nil {
+		filters.Radius = &gateway.Radius{
+			Latitude:  req.Radius.Latitude,
+			Longitude: req.Radius.Longitude,
+			Radius:    req.Radius.Radius,
+		}
+	}
+
+	count, err := gateway.GetGatewayCountForFilters(common.DB, filters)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	gws, err := gateway.GetGateways(common.DB, int(req.Limit), int(req.Offset))
+	gws, err := gateway.GetGatewaysForFilters(common.DB, filters, int(req.Limit), int(req.Offset))
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
@@ -790,25 +926,294 @@ func (n *NetworkServerAPI) GenerateGatewayToken(ctx context.Context, req *ns.Gen
 		return nil, errToRPCError(err)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+	if req.Ttl < 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "ttl must not be negative")
+	}
+
+	secret := common.GatewayServerJWTSecret
+	if common.GatewayServerJWTSigningKeyID != "" {
+		secret = common.GatewayServerJWTKeys[common.GatewayServerJWTSigningKeyID]
+	}
+	if secret == "" {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "no gateway token signing secret configured")
+	}
+
+	gt := gateway.Token{
+		MAC:   mac,
+		KeyID: common.GatewayServerJWTSigningKeyID,
+	}
+
+	ttl := common.GatewayTokenTTL
+	if req.Ttl > 0 {
+		ttl = time.Duration(req.Ttl) * time.Second
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		gt.ExpiresAt = &expiresAt
+	}
+
+	if err := gateway.CreateToken(common.DB, &gt); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	claims := auth.Claims{
 		StandardClaims: jwt.StandardClaims{
 			Audience:  "ns",
+			Id:        gt.ID,
 			Issuer:    "ns",
 			NotBefore: time.Now().Unix(),
 			Subject:   "gateway",
 		},
 		MAC: mac,
-	})
-	signedToken, err := token.SignedString([]byte(common.GatewayServerJWTSecret))
+	}
+	if gt.ExpiresAt != nil {
+		claims.ExpiresAt = gt.ExpiresAt.Unix()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if gt.KeyID != "" {
+		token.Header["kid"] = gt.KeyID
+	}
+	signedToken, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
-	return &ns.GenerateGatewayTokenResponse{
+	resp := ns.GenerateGatewayTokenResponse{
 		Token: signedToken,
+		Id:    gt.ID,
+	}
+	if gt.ExpiresAt != nil {
+		resp.ExpiresAt = gt.ExpiresAt.Format(time.RFC3339Nano)
+	}
+
+	return &resp, nil
+}
+
+// ListGatewayTokens returns the tokens issued to the given gateway.
+func (n *NetworkServerAPI) ListGatewayTokens(ctx context.Context, req *ns.ListGatewayTokensRequest) (*ns.ListGatewayTokensResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	tokens, err := gateway.GetTokensForMAC(common.DB, mac)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.ListGatewayTokensResponse
+	for _, t := range tokens {
+		gt := ns.GatewayToken{
+			Id:        t.ID,
+			KeyID:     t.KeyID,
+			CreatedAt: t.CreatedAt.Format(time.RFC3339Nano),
+		}
+		if t.ExpiresAt != nil {
+			gt.ExpiresAt = t.ExpiresAt.Format(time.RFC3339Nano)
+		}
+		if t.RevokedAt != nil {
+			gt.RevokedAt = t.RevokedAt.Format(time.RFC3339Nano)
+		}
+		resp.Result = append(resp.Result, &gt)
+	}
+
+	return &resp, nil
+}
+
+// RevokeGatewayToken revokes the given gateway token.
+func (n *NetworkServerAPI) RevokeGatewayToken(ctx context.Context, req *ns.RevokeGatewayTokenRequest) (*ns.RevokeGatewayTokenResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	if err := gateway.RevokeToken(common.DB, mac, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.RevokeGatewayTokenResponse{}, nil
+}
+
+// ReportDeviceLocation reports the location of the device at the time of
+// the given uplink, used for the coverage map. Repeated reports of the same
+// uplink are ignored.
+func (n *NetworkServerAPI) ReportDeviceLocation(ctx context.Context, req *ns.ReportDeviceLocationRequest) (*ns.ReportDeviceLocationResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	ds, err := storage.GetDeviceSession(common.RedisPool, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// the device-session contains the (synchronized) next expected
+	// frame-counter
+	if len(ds.LastRXInfoSet) == 0 || ds.FCntUp != req.FCnt+1 {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "rx-info of uplink with fCnt %d is not available", req.FCnt)
+	}
+
+	err = coverage.HandleDeviceLocation(common.DB, coverage.DeviceLocation{
+		DevEUI:    ds.DevEUI,
+		DevAddr:   ds.DevAddr,
+		FCnt:      req.FCnt,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		RXInfoSet: ds.LastRXInfoSet,
+	}, common.CoverageGeohashPrecision)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.ReportDeviceLocationResponse{}, nil
+}
+
+// GetCoverage returns the coverage (per gateway and geohash cell) within
+// the given bounding box.
+func (n *NetworkServerAPI) GetCoverage(ctx context.Context, req *ns.GetCoverageRequest) (*ns.GetCoverageResponse, error) {
+	if req.BoundingBox == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "bounding box must be set")
+	}
+
+	precision := common.CoverageGeohashPrecision
+	if req.Precision != 0 {
+		if int(req.Precision) > precision {
+			return nil, grpc.Errorf(codes.InvalidArgument, "precision must not be greater than %d", precision)
+		}
+		precision = int(req.Precision)
+	}
+
+	var macs []lorawan.EUI64
+	for i := range req.GatewayMACs {
+		var mac lorawan.EUI64
+		copy(mac[:], req.GatewayMACs[i])
+		macs = append(macs, mac)
+	}
+
+	bb := coverage.Cell{
+		MinLatitude:  req.BoundingBox.MinLatitude,
+		MinLongitude: req.BoundingBox.MinLongitude,
+		MaxLatitude:  req.BoundingBox.MaxLatitude,
+		MaxLongitude: req.BoundingBox.MaxLongitude,
+	}
+
+	gwCoverage, err := coverage.GetCoverage(common.DB, bb, precision, macs)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetCoverageResponse
+	for _, c := range gwCoverage {
+		// the coverage is ordered by geohash
+		if len(resp.Result) == 0 || resp.Result[len(resp.Result)-1].Geohash != c.Geohash {
+			cell, err := c.Cell()
+			if err != nil {
+				return nil, errToRPCError(err)
+			}
+			lat, long := cell.Center()
+			resp.Result = append(resp.Result, &ns.CoverageCell{
+				Geohash:   c.Geohash,
+				Latitude:  lat,
+				Longitude: long,
+			})
+		}
+
+		cell := resp.Result[len(resp.Result)-1]
+		cell.Gateways = append(cell.Gateways, &ns.GatewayCoverage{
+			Mac:        c.MAC[:],
+			Rssi:       int32(c.RSSI),
+			Snr:        c.SNR,
+			RxCount:    c.RXCount,
+			LastSeenAt: c.LastSeenAt.Format(time.RFC3339Nano),
+			Distance:   c.Distance,
+		})
+	}
+
+	return &resp, nil
+}
+
+// StartGatewayLinkTest transmits a link test frame from the given gateway.
+func (n *NetworkServerAPI) StartGatewayLinkTest(ctx context.Context, req *ns.StartGatewayLinkTestRequest) (*ns.StartGatewayLinkTestResponse, error) {
+	var mac lorawan.EUI64
+	copy(mac[:], req.Mac)
+
+	lt, err := linktest.StartLinkTest(common.DB, mac, int(req.Frequency), int(req.Dr))
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.StartGatewayLinkTestResponse{
+		Id: lt.ID,
 	}, nil
 }
 
+// GetGatewayLinkTest returns the given link test and its receptions.
+func (n *NetworkServerAPI) GetGatewayLinkTest(ctx context.Context, req *ns.GetGatewayLinkTestRequest) (*ns.GetGatewayLinkTestResponse, error) {
+	lt, err := linktest.GetLinkTest(common.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	rxInfo, err := linktest.GetRXInfoForLinkTest(common.DB, lt.ID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetGatewayLinkTestResponse{
+		Id:        lt.ID,
+		Mac:       lt.MAC[:],
+		CreatedAt: lt.CreatedAt.Format(time.RFC3339Nano),
+		Frequency: int32(lt.Frequency),
+		Dr:        int32(lt.DR),
+	}
+
+	for _, rx := range rxInfo {
+		// make sure we have a copy of the MAC byte slice
+		mac := make([]byte, 8)
+		copy(mac, rx.MAC[:])
+
+		resp.RxInfo = append(resp.RxInfo, &ns.GatewayLinkTestRXInfo{
+			Mac:        mac,
+			Rssi:       int32(rx.RSSI),
+			Snr:        rx.SNR,
+			ReceivedAt: rx.ReceivedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &resp, nil
+}
+
+// GetGatewayNeighbours returns the gateway neighbour matrix, based on the
+// latest link test of each gateway.
+func (n *NetworkServerAPI) GetGatewayNeighbours(ctx context.Context, req *ns.GetGatewayNeighboursRequest) (*ns.GetGatewayNeighboursResponse, error) {
+	var macs []lorawan.EUI64
+	for i := range req.GatewayMACs {
+		var mac lorawan.EUI64
+		copy(mac[:], req.GatewayMACs[i])
+		macs = append(macs, mac)
+	}
+
+	neighbours, err := linktest.GetNeighbours(common.DB, macs)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetGatewayNeighboursResponse
+	for _, nb := range neighbours {
+		txMAC := make([]byte, 8)
+		rxMAC := make([]byte, 8)
+		copy(txMAC, nb.TXMAC[:])
+		copy(rxMAC, nb.RXMAC[:])
+
+		resp.Result = append(resp.Result, &ns.GatewayNeighbour{
+			TxMAC:      txMAC,
+			RxMAC:      rxMAC,
+			Rssi:       int32(nb.RSSI),
+			Snr:        nb.SNR,
+			LinkTestID: nb.LinkTestID,
+			TestedAt:   nb.TestedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	return &resp, nil
+}
+
 // GetGatewayStats returns stats of an existing gateway.
 func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatewayStatsRequest) (*ns.GetGatewayStatsResponse, error) {
 	var mac lorawan.EUI64
@@ -832,18 +1237,48 @@ func (n *NetworkServerAPI) GetGatewayStats(ctx context.Context, req *ns.GetGatew
 	var resp ns.GetGatewayStatsResponse
 
 	for _, stat := range stats {
-		resp.Result = append(resp.Result, &ns.GatewayStats{
+		s := ns.GatewayStats{
 			Timestamp:           stat.Timestamp.Format(time.RFC3339Nano),
 			RxPacketsReceived:   int32(stat.RXPacketsReceived),
 			RxPacketsReceivedOK: int32(stat.RXPacketsReceivedOK),
 			TxPacketsReceived:   int32(stat.TXPacketsReceived),
 			TxPacketsEmitted:    int32(stat.TXPacketsEmitted),
-		})
+			RxFrames:            int32(stat.RXFrames),
+			RxFramesCRCError:    int32(stat.RXFramesCRCError),
+			Rssi:                signalStatsToResp(stat.RSSI),
+			Snr:                 signalStatsToResp(stat.SNR),
+			UniqueDevices:       int32(stat.UniqueDevices),
+			TxFramesScheduled:   int32(stat.TXFramesScheduled),
+			TxFramesAcked:       int32(stat.TXFramesAcked),
+		}
+
+		if total := stat.RXFrames + stat.RXFramesCRCError; total != 0 {
+			s.RxCRCErrorRatio = float64(stat.RXFramesCRCError) / float64(total)
+		}
+
+		for _, f := range stat.Frames {
+			s.RxFramesPerChannel = append(s.RxFramesPerChannel, &ns.GatewayFrameStats{
+				Frequency: uint32(f.Frequency),
+				Dr:        uint32(f.DR),
+				RxFrames:  int32(f.RXFrames),
+			})
+		}
+
+		resp.Result = append(resp.Result, &s)
 	}
 
 	return &resp, nil
 }
 
+func signalStatsToResp(s gateway.SignalStats) *ns.GatewaySignalStats {
+	return &ns.GatewaySignalStats{
+		Average: s.Average,
+		P10:     s.P10,
+		P50:     s.P50,
+		P90:     s.P90,
+	}
+}
+
 // GetFrameLogsForDevEUI returns the uplink / downlink frame logs for the given DevEUI.
 func (n *NetworkServerAPI) GetFrameLogsForDevEUI(ctx context.Context, req *ns.GetFrameLogsForDevEUIRequest) (*ns.GetFrameLogsResponse, error) {
 	var devEUI lorawan.EUI64
@@ -912,7 +1347,18 @@ func (n *NetworkServerAPI) GetFrameLogsForDevEUI(ctx context.Context, req *ns.Ge
 						SpreadFactor: uint32(rxInfoSet[i].DataRate.SpreadFactor),
 						Bitrate:      uint32(rxInfoSet[i].DataRate.BitRate),
 					},
-					Mac: rxInfoSet[i].MAC[:],
+					Mac:                    rxInfoSet[i].MAC[:],
+					Board:                  uint32(rxInfoSet[i].Board),
+					Antenna:                uint32(rxInfoSet[i].Antenna),
+					FineTimestampType:      rxInfoSet[i].FineTimestampType,
+					EncryptedFineTimestamp: rxInfoSet[i].EncryptedFineTimestamp,
+					FineTimestampKeyID:     uint32(rxInfoSet[i].FineTimestampKeyID),
+				}
+				if rxInfoSet[i].FineTimestamp != nil {
+					rxInfo.FineTimestamp = uint32(*rxInfoSet[i].FineTimestamp)
+				}
+				if rxInfoSet[i].SignalRSSI != nil {
+					rxInfo.SignalRSSI = int32(*rxInfoSet[i].SignalRSSI)
 				}
 				fl.RxInfoSet = append(fl.RxInfoSet, &rxInfo)
 			}
@@ -968,6 +1414,8 @@ func (n *NetworkServerAPI) UpdateChannelConfiguration(ctx context.Context, req *
 		return nil, errToRPCError(err)
 	}
 
+	sendGatewayConfigPackets(cf.ID)
+
 	return &ns.UpdateChannelConfigurationResponse{}, nil
 }
 
@@ -1023,6 +1471,8 @@ func (n *NetworkServerAPI) CreateExtraChannel(ctx context.Context, req *ns.Creat
 		return nil, errToRPCError(err)
 	}
 
+	sendGatewayConfigPackets(ec.ChannelConfigurationID)
+
 	return &ns.CreateExtraChannelResponse{Id: ec.ID}, nil
 }
 
@@ -1033,6 +1483,7 @@ func (n *NetworkServerAPI) UpdateExtraChannel(ctx context.Context, req *ns.Updat
 		return nil, errToRPCError(err)
 	}
 
+	oldCFID := ec.ChannelConfigurationID
 	ec.ChannelConfigurationID = req.ChannelConfigurationID
 	ec.Frequency = int(req.Frequency)
 	ec.BandWidth = int(req.BandWidth)
@@ -1056,16 +1507,28 @@ func (n *NetworkServerAPI) UpdateExtraChannel(ctx context.Context, req *ns.Updat
 		return nil, errToRPCError(err)
 	}
 
+	sendGatewayConfigPackets(ec.ChannelConfigurationID)
+	if oldCFID != ec.ChannelConfigurationID {
+		sendGatewayConfigPackets(oldCFID)
+	}
+
 	return &ns.UpdateExtraChannelResponse{}, nil
 }
 
 // DeleteExtraChannel deletes the extra channel matching the given id.
 func (n *NetworkServerAPI) DeleteExtraChannel(ctx context.Context, req *ns.DeleteExtraChannelRequest) (*ns.DeleteExtraChannelResponse, error) {
-	err := gateway.DeleteExtraChannel(common.DB, req.Id)
+	ec, err := gateway.GetExtraChannel(common.DB, req.Id)
 	if err != nil {
 		return nil, errToRPCError(err)
 	}
 
+	err = gateway.DeleteExtraChannel(common.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	sendGatewayConfigPackets(ec.ChannelConfigurationID)
+
 	return &ns.DeleteExtraChannelResponse{}, nil
 }
 
@@ -1136,6 +1599,308 @@ func (n *NetworkServerAPI) MigrateNodeToDeviceSession(ctx context.Context, req *
 	return &ns.MigrateNodeToDeviceSessionResponse{}, nil
 }
 
+// CreateMulticastGroup creates the given multicast-group.
+func (n *NetworkServerAPI) CreateMulticastGroup(ctx context.Context, req *ns.CreateMulticastGroupRequest) (*ns.CreateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicastGroup must not be nil")
+	}
+
+	mg := multicastGroupFromReq(req.MulticastGroup)
+	if err := storage.CreateMulticastGroup(common.DB, &mg); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.CreateMulticastGroupResponse{
+		Id: mg.ID,
+	}, nil
+}
+
+// GetMulticastGroup returns the multicast-group matching the given id.
+func (n *NetworkServerAPI) GetMulticastGroup(ctx context.Context, req *ns.GetMulticastGroupRequest) (*ns.GetMulticastGroupResponse, error) {
+	mg, err := storage.GetMulticastGroup(common.DB, req.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	devEUIs, err := storage.GetDevEUIsForMulticastGroup(common.DB, mg.ID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	resp := ns.GetMulticastGroupResponse{
+		CreatedAt: mg.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: mg.UpdatedAt.Format(time.RFC3339Nano),
+		MulticastGroup: &ns.MulticastGroup{
+			Id:               mg.ID,
+			McAddr:           mg.MCAddr[:],
+			McNwkSKey:        mg.MCNwkSKey[:],
+			FCnt:             mg.FCnt,
+			Dr:               uint32(mg.DR),
+			Frequency:        uint32(mg.Frequency),
+			ServiceProfileID: mg.ServiceProfileID,
+		},
+	}
+
+	for i := range devEUIs {
+		resp.DevEUIs = append(resp.DevEUIs, devEUIs[i][:])
+	}
+
+	return &resp, nil
+}
+
+// UpdateMulticastGroup updates the given multicast-group.
+func (n *NetworkServerAPI) UpdateMulticastGroup(ctx context.Context, req *ns.UpdateMulticastGroupRequest) (*ns.UpdateMulticastGroupResponse, error) {
+	if req.MulticastGroup == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "multicastGroup must not be nil")
+	}
+
+	mg, err := storage.GetMulticastGroup(common.DB, req.MulticastGroup.Id)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// decreasing the frame-counter would re-use frame-counter values for
+	// the same McNwkSKey
+	if req.MulticastGroup.FCnt < mg.FCnt {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fCnt must not be lower than the current fCnt (%d)", mg.FCnt)
+	}
+
+	mgUpdated := multicastGroupFromReq(req.MulticastGroup)
+	mgUpdated.CreatedAt = mg.CreatedAt
+
+	if err := storage.UpdateMulticastGroup(common.DB, &mgUpdated); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.UpdateMulticastGroupResponse{}, nil
+}
+
+// DeleteMulticastGroup deletes the multicast-group matching the given id.
+func (n *NetworkServerAPI) DeleteMulticastGroup(ctx context.Context, req *ns.DeleteMulticastGroupRequest) (*ns.DeleteMulticastGroupResponse, error) {
+	if err := storage.DeleteMulticastGroup(common.DB, req.Id); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.DeleteMulticastGroupResponse{}, nil
+}
+
+// AddDeviceToMulticastGroup adds the given device to the multicast-group.
+func (n *NetworkServerAPI) AddDeviceToMulticastGroup(ctx context.Context, req *ns.AddDeviceToMulticastGroupRequest) (*ns.AddDeviceToMulticastGroupResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	d, err := storage.GetDevice(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	dp, err := storage.GetDeviceProfile(common.DB, d.DeviceProfileID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	// multicast downlinks are sent as Class-C downlinks
+	if !dp.DeviceProfile.SupportsClassC {
+		return nil, grpc.Errorf(codes.InvalidArgument, "device-profile of device does not support Class-C")
+	}
+
+	if err := storage.AddDeviceToMulticastGroup(common.DB, devEUI, req.MulticastGroupID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.AddDeviceToMulticastGroupResponse{}, nil
+}
+
+// RemoveDeviceFromMulticastGroup removes the given device from the multicast-group.
+func (n *NetworkServerAPI) RemoveDeviceFromMulticastGroup(ctx context.Context, req *ns.RemoveDeviceFromMulticastGroupRequest) (*ns.RemoveDeviceFromMulticastGroupResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	if err := storage.RemoveDeviceFromMulticastGroup(common.DB, devEUI, req.MulticastGroupID); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.RemoveDeviceFromMulticastGroupResponse{}, nil
+}
+
+// EnqueueMulticastQueueItem sends the given payload to the multicast-group
+// (the devices must operate in Class-C mode).
+func (n *NetworkServerAPI) EnqueueMulticastQueueItem(ctx context.Context, req *ns.EnqueueMulticastQueueItemRequest) (*ns.EnqueueMulticastQueueItemResponse, error) {
+	// FPort 0 is reserved for mac-commands
+	if req.FPort == 0 || req.FPort > 224 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "fPort must be between 1 and 224")
+	}
+
+	mg, err := storage.GetMulticastGroup(common.DB, req.MulticastGroupID)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	if req.FCnt != mg.FCnt {
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid FCnt (expected: %d)", mg.FCnt)
+	}
+
+	err = downlink.Flow.RunMulticastDown(mg, uint8(req.FPort), req.Data)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.EnqueueMulticastQueueItemResponse{}, nil
+}
+
+// CreateDeviceKeys creates the root-keys for the given device (used by
+// the embedded join-server).
+func (n *NetworkServerAPI) CreateDeviceKeys(ctx context.Context, req *ns.CreateDeviceKeysRequest) (*ns.CreateDeviceKeysResponse, error) {
+	if req.DeviceKeys == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "deviceKeys must not be nil")
+	}
+
+	dk, err := deviceKeysFromReq(req.DeviceKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.CreateDeviceKeys(common.DB, &dk); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.CreateDeviceKeysResponse{}, nil
+}
+
+// GetDeviceKeys returns the root-keys for the given DevEUI.
+func (n *NetworkServerAPI) GetDeviceKeys(ctx context.Context, req *ns.GetDeviceKeysRequest) (*ns.GetDeviceKeysResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	dk, err := storage.GetDeviceKeys(common.DB, devEUI)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.GetDeviceKeysResponse{
+		CreatedAt: dk.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: dk.UpdatedAt.Format(time.RFC3339Nano),
+		DeviceKeys: &ns.DeviceKeys{
+			DevEUI:    dk.DevEUI[:],
+			NwkKey:    dk.NwkKey[:],
+			AppKey:    dk.AppKey[:],
+			JoinNonce: uint32(dk.JoinNonce),
+		},
+	}, nil
+}
+
+// UpdateDeviceKeys updates the root-keys for the given device.
+func (n *NetworkServerAPI) UpdateDeviceKeys(ctx context.Context, req *ns.UpdateDeviceKeysRequest) (*ns.UpdateDeviceKeysResponse, error) {
+	if req.DeviceKeys == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "deviceKeys must not be nil")
+	}
+
+	dk, err := deviceKeysFromReq(req.DeviceKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.UpdateDeviceKeys(common.DB, &dk); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.UpdateDeviceKeysResponse{}, nil
+}
+
+// DeleteDeviceKeys deletes the root-keys for the given DevEUI.
+func (n *NetworkServerAPI) DeleteDeviceKeys(ctx context.Context, req *ns.DeleteDeviceKeysRequest) (*ns.DeleteDeviceKeysResponse, error) {
+	var devEUI lorawan.EUI64
+	copy(devEUI[:], req.DevEUI)
+
+	if err := storage.DeleteDeviceKeys(common.DB, devEUI); err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	return &ns.DeleteDeviceKeysResponse{}, nil
+}
+
+// GetRejectedJoinRequestCounts returns the number of rejected join-requests
+// (join flood protection) by reason.
+func (n *NetworkServerAPI) GetRejectedJoinRequestCounts(ctx context.Context, req *ns.GetRejectedJoinRequestCountsRequest) (*ns.GetRejectedJoinRequestCountsResponse, error) {
+	counts, err := joinlimit.GetRejectedCounts(common.RedisPool)
+	if err != nil {
+		return nil, errToRPCError(err)
+	}
+
+	var resp ns.GetRejectedJoinRequestCountsResponse
+	for _, reason := range []joinlimit.Reason{joinlimit.UnknownDevEUI, joinlimit.DevEUIRateLimited, joinlimit.JoinEUIRateLimited, joinlimit.DevEUIAttemptsExceeded} {
+		resp.Counts = append(resp.Counts, &ns.RejectedJoinRequestCount{
+			Reason: string(reason),
+			Count:  uint64(counts[reason]),
+		})
+	}
+
+	return &resp, nil
+}
+
+func multicastGroupFromReq(req *ns.MulticastGroup) storage.MulticastGroup {
+	mg := storage.MulticastGroup{
+		ID:               req.Id,
+		FCnt:             req.FCnt,
+		DR:               int(req.Dr),
+		Frequency:        int(req.Frequency),
+		ServiceProfileID: req.ServiceProfileID,
+	}
+	copy(mg.MCAddr[:], req.McAddr)
+	copy(mg.MCNwkSKey[:], req.McNwkSKey)
+
+	return mg
+}
+
+func deviceKeysFromReq(req *ns.DeviceKeys) (storage.DeviceKeys, error) {
+	var dk storage.DeviceKeys
+
+	if len(req.NwkKey) != len(dk.NwkKey) || len(req.AppKey) != len(dk.AppKey) {
+		return dk, grpc.Errorf(codes.InvalidArgument, "nwkKey and appKey must be 16 bytes")
+	}
+	if req.JoinNonce > 16777215 {
+		return dk, grpc.Errorf(codes.InvalidArgument, "max value of joinNonce is 2^24-1")
+	}
+
+	copy(dk.DevEUI[:], req.DevEUI)
+	copy(dk.NwkKey[:], req.NwkKey)
+	copy(dk.AppKey[:], req.AppKey)
+	dk.JoinNonce = int(req.JoinNonce)
+
+	return dk, nil
+}
+
+func devAddrRangesFromReq(req []*ns.DevAddrRange) (storage.DevAddrRanges, error) {
+	var out storage.DevAddrRanges
+
+	for _, r := range req {
+		var dr devaddr.Range
+		if len(r.Start) != len(dr.Start) || len(r.End) != len(dr.End) {
+			return nil, grpc.Errorf(codes.InvalidArgument, "devAddrRange start and end must be 4 bytes")
+		}
+		copy(dr.Start[:], r.Start)
+		copy(dr.End[:], r.End)
+
+		if err := dr.Validate(common.NetID); err != nil {
+			return nil, grpc.Errorf(codes.InvalidArgument, "%s", err)
+		}
+		out = append(out, dr)
+	}
+
+	return out, nil
+}
+
+func devAddrRangesToResp(ranges storage.DevAddrRanges) []*ns.DevAddrRange {
+	var out []*ns.DevAddrRange
+	for _, r := range ranges {
+		out = append(out, &ns.DevAddrRange{
+			Start: r.Start[:],
+			End:   r.End[:],
+		})
+	}
+	return out
+}
+
 func channelConfigurationToResp(cf gateway.ChannelConfiguration) *ns.GetChannelConfigurationResponse {
 	out := ns.GetChannelConfigurationResponse{
 		Id:        cf.ID,
@@ -1173,5 +1938,27 @@ func gwToResp(gw gateway.Gateway) *ns.GetGatewayResponse {
 		resp.ChannelConfigurationID = *gw.ChannelConfigurationID
 	}
 
+	resp.ConfigVersion = gw.ConfigVersion
+
+	switch gw.State {
+	case gateway.StateOnline:
+		resp.State = ns.GatewayState_ONLINE
+	case gateway.StateOffline:
+		resp.State = ns.GatewayState_OFFLINE
+	}
+
+	if gw.StateChangedAt != nil {
+		resp.StateChangedAt = gw.StateChangedAt.Format(time.RFC3339Nano)
+	}
+
 	return &resp
 }
+
+// sendGatewayConfigPackets sends the updated configuration to the gateways
+// using the given channel-configuration. Errors are logged, as the
+// configuration change itself has already been stored.
+func sendGatewayConfigPackets(channelConfigurationID int64) {
+	if err := gateway.SendConfigPacketsForChannelConfigurationID(common.DB, channelConfigurationID); err != nil {
+		log.WithField("channel_configuration_id", channelConfigurationID).Errorf("send gateway configurations error: %s", err)
+	}
+}
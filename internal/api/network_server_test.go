@@ -74,6 +74,9 @@ func TestNetworkServerAPI(t *testing.T) {
 					NwkGeoLoc:      true,
 					TargetPER:      1,
 					MinGWDiversity: 7,
+					DevAddrRanges: []*ns.DevAddrRange{
+						{Start: []byte{6, 0, 0, 0}, End: []byte{6, 0, 0, 255}},
+					},
 				},
 			})
 			So(err, ShouldBeNil)
@@ -105,7 +108,33 @@ func TestNetworkServerAPI(t *testing.T) {
 					NwkGeoLoc:      true,
 					TargetPER:      1,
 					MinGWDiversity: 7,
+					DevAddrRanges: []*ns.DevAddrRange{
+						{Start: []byte{6, 0, 0, 0}, End: []byte{6, 0, 0, 255}},
+					},
+				})
+			})
+
+			Convey("Then GetRandomDevAddr returns a DevAddr within the service-profile DevAddr range", func() {
+				for i := 0; i < 10; i++ {
+					devAddrResp, err := api.GetRandomDevAddr(ctx, &ns.GetRandomDevAddrRequest{
+						ServiceProfileID: resp.ServiceProfileID,
+					})
+					So(err, ShouldBeNil)
+					So(devAddrResp.DevAddr[:3], ShouldResemble, []byte{6, 0, 0})
+				}
+			})
+
+			Convey("Then UpdateServiceProfile with a DevAddr range outside the NetID range returns an error", func() {
+				_, err := api.UpdateServiceProfile(ctx, &ns.UpdateServiceProfileRequest{
+					ServiceProfile: &ns.ServiceProfile{
+						ServiceProfileID: resp.ServiceProfileID,
+						DevAddrRanges: []*ns.DevAddrRange{
+							{Start: []byte{2, 0, 0, 0}, End: []byte{2, 0, 0, 255}},
+						},
+					},
 				})
+				So(err, ShouldNotBeNil)
+				So(grpc.Code(err), ShouldEqual, codes.InvalidArgument)
 			})
 
 			Convey("Then UpdateServiceProfile updates the service-profile", func() {
@@ -279,6 +308,8 @@ func TestNetworkServerAPI(t *testing.T) {
 					SupportsJoin:       true,
 					RfRegion:           "EU868", // set by the api
 					Supports32BitFCnt:  true,
+					RxDROffset1Set:     true, // non-zero, set by the api
+					RxDataRate2Set:     true, // non-zero, set by the api
 				})
 			})
 		})
@@ -593,6 +624,18 @@ func TestNetworkServerAPI(t *testing.T) {
 				So(err, ShouldResemble, grpc.Errorf(codes.NotFound, "gateway does not exist"))
 			})
 
+			Convey("When calling GenerateGatewayToken without signing secret", func() {
+				common.GatewayServerJWTSecret = ""
+				common.GatewayServerJWTSigningKeyID = ""
+
+				_, err := api.GenerateGatewayToken(ctx, &ns.GenerateGatewayTokenRequest{
+					Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+				})
+				Convey("Then an error is returned", func() {
+					So(err, ShouldResemble, grpc.Errorf(codes.FailedPrecondition, "no gateway token signing secret configured"))
+				})
+			})
+
 			Convey("When calling GenerateGatewayToken", func() {
 				common.GatewayServerJWTSecret = "verysecret"
 
@@ -613,6 +656,34 @@ func TestNetworkServerAPI(t *testing.T) {
 					claims, ok := token.Claims.(*auth.Claims)
 					So(ok, ShouldBeTrue)
 					So(claims.MAC, ShouldEqual, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+					So(claims.Id, ShouldEqual, tokenResp.Id)
+				})
+
+				Convey("Then ListGatewayTokens returns the token", func() {
+					resp, err := api.ListGatewayTokens(ctx, &ns.ListGatewayTokensRequest{
+						Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+					})
+					So(err, ShouldBeNil)
+					So(resp.Result, ShouldHaveLength, 1)
+					So(resp.Result[0].Id, ShouldEqual, tokenResp.Id)
+					So(resp.Result[0].RevokedAt, ShouldEqual, "")
+				})
+
+				Convey("When calling RevokeGatewayToken", func() {
+					_, err := api.RevokeGatewayToken(ctx, &ns.RevokeGatewayTokenRequest{
+						Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						Id:  tokenResp.Id,
+					})
+					So(err, ShouldBeNil)
+
+					Convey("Then the token has been revoked", func() {
+						resp, err := api.ListGatewayTokens(ctx, &ns.ListGatewayTokensRequest{
+							Mac: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+						})
+						So(err, ShouldBeNil)
+						So(resp.Result, ShouldHaveLength, 1)
+						So(resp.Result[0].RevokedAt, ShouldNotEqual, "")
+					})
 				})
 			})
 
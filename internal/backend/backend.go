@@ -1,12 +1,22 @@
 package backend
 
-import "github.com/brocaar/loraserver/api/gw"
+import (
+	"errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+)
+
+// ErrNotSupported is returned by the gateway backends when the requested
+// feature is not supported by the protocol used by the gateways.
+var ErrNotSupported = errors.New("not supported by the gateway backend")
 
 // Gateway is the interface of a gateway backend.
 // A gateway backend is responsible for the communication with the gateway.
 type Gateway interface {
-	SendTXPacket(gw.TXPacket) error              // send the given packet to the gateway
-	RXPacketChan() chan gw.RXPacket              // channel containing the received packets
-	StatsPacketChan() chan gw.GatewayStatsPacket // channel containing the received gateway stats
-	Close() error                                // close the gateway backend.
+	SendTXPacket(gw.TXPacket) error                       // send the given packet to the gateway
+	SendGatewayConfigPacket(gw.GatewayConfigPacket) error // send the given configuration to the gateway
+	RXPacketChan() chan gw.RXPacket                       // channel containing the received packets
+	StatsPacketChan() chan gw.GatewayStatsPacket          // channel containing the received gateway stats
+	TXAckChan() chan gw.TXAck                             // channel containing the received tx acknowledgements
+	Close() error                                         // close the gateway backend.
 }
@@ -0,0 +1,616 @@
+// Package basicstation implements a gateway backend for the LoRa Basics
+// Station. It hosts the router-info (discovery) and the websocket endpoint
+// to which the Stations connect, and translates the Station uplink and
+// downlink messages to gw.RXPacket and from gw.TXPacket.
+package basicstation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Endpoints to which the Station connects.
+const (
+	routerInfoPath = "/router-info"
+	gatewayPath    = "/gateway/"
+)
+
+// uplinkContextTTL defines the duration for which the uplink context
+// (needed to schedule class-a downlinks) is kept.
+const uplinkContextTTL = time.Minute
+
+// maxRXDelay defines the max. RX delay (in seconds) of a class-a downlink.
+const maxRXDelay = 15
+
+// errors
+var (
+	ErrGatewayDoesNotExist   = errors.New("gateway does not exist or is not connected")
+	ErrUplinkContextNotFound = errors.New("uplink context for downlink timestamp not found")
+)
+
+// uplinkContext contains the Station context of an uplink frame, needed
+// to schedule the class-a downlink.
+type uplinkContext struct {
+	xtime      int64
+	rctx       int64
+	receivedAt time.Time
+}
+
+// connection contains the websocket connection of a gateway.
+type connection struct {
+	sync.Mutex
+
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+	uplinks map[uint32]uplinkContext // by (32 bit) concentrator timestamp
+}
+
+// send sends the given message as JSON.
+func (c *connection) send(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return websocket.JSON.Send(c.ws, v)
+}
+
+// storeUplinkContext stores the context of the given uplink and removes
+// the expired uplink contexts.
+func (c *connection) storeUplinkContext(info upInfo) {
+	c.Lock()
+	defer c.Unlock()
+
+	for ts, ctx := range c.uplinks {
+		if time.Since(ctx.receivedAt) > uplinkContextTTL {
+			delete(c.uplinks, ts)
+		}
+	}
+
+	c.uplinks[uint32(info.XTime)] = uplinkContext{
+		xtime:      info.XTime,
+		rctx:       info.RCtx,
+		receivedAt: time.Now(),
+	}
+}
+
+// getUplinkContext returns the uplink context and the delay (in seconds)
+// between the uplink and the given downlink timestamp. The delay of a RX2
+// downlink is one second more than the RX delay.
+func (c *connection) getUplinkContext(timestamp uint32) (uplinkContext, int, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	for ts, ctx := range c.uplinks {
+		// uint32 arithmetic takes care of the timestamp rollover
+		delay := timestamp - ts
+		if delay%1000000 == 0 && delay >= 1000000 && delay <= (maxRXDelay+1)*1000000 {
+			return ctx, int(delay / 1000000), nil
+		}
+	}
+
+	return uplinkContext{}, 0, ErrUplinkContextNotFound
+}
+
+// Backend implements a LoRa Basics Station backend.
+type Backend struct {
+	sync.RWMutex
+
+	ln              net.Listener
+	server          *http.Server
+	scheme          string
+	rxPacketChan    chan gw.RXPacket
+	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+	connections     map[lorawan.EUI64]*connection
+	clientAuth      bool
+	closed          bool
+	wg              sync.WaitGroup
+	diid            int64
+
+	band        band.Band
+	bandName    band.Name
+	getChannels func(lorawan.EUI64) ([]channel, error)
+}
+
+// NewBackend creates a new Backend listening on the given bind address
+// (ip:port). When a TLS certificate and key are given, the Stations must
+// connect using TLS (wss://). When a CA certificate is given as well, the
+// Stations must present a client certificate signed by this CA, of which
+// the common-name is the gateway MAC (EUI64).
+func NewBackend(bind, caCert, tlsCert, tlsKey string) (backend.Gateway, error) {
+	b := Backend{
+		scheme:          "ws",
+		rxPacketChan:    make(chan gw.RXPacket),
+		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
+		connections:     make(map[lorawan.EUI64]*connection),
+		band:            common.Band,
+		bandName:        common.BandName,
+		getChannels:     getGatewayChannels,
+	}
+
+	log.WithField("bind", bind).Info("backend/basicstation: starting websocket listener")
+	ln, err := net.Listen("tcp", bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen tcp error")
+	}
+
+	if tlsCert != "" || tlsKey != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
+		if err != nil {
+			ln.Close()
+			return nil, errors.Wrap(err, "load tls key-pair error")
+		}
+		tlsConfig := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+
+		if caCert != "" {
+			rawCACert, err := ioutil.ReadFile(caCert)
+			if err != nil {
+				ln.Close()
+				return nil, errors.Wrap(err, "read ca cert error")
+			}
+
+			caCertPool := x509.NewCertPool()
+			if !caCertPool.AppendCertsFromPEM(rawCACert) {
+				ln.Close()
+				return nil, errors.New("append ca cert to pool error")
+			}
+
+			tlsConfig.ClientCAs = caCertPool
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			b.clientAuth = true
+		}
+
+		ln = tls.NewListener(ln, tlsConfig)
+		b.scheme = "wss"
+	}
+
+	if !b.clientAuth {
+		log.Warning("backend/basicstation: client certificates are not configured, gateways are not authenticated")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(routerInfoPath, websocket.Server{Handler: b.handleRouterInfo})
+	mux.Handle(gatewayPath, websocket.Server{Handler: b.handleGateway})
+	b.ln = ln
+	b.server = &http.Server{Handler: mux}
+
+	go func() {
+		if err := b.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			log.Errorf("backend/basicstation: serve http error: %s", err)
+		}
+	}()
+
+	return &b, nil
+}
+
+// Close closes the backend.
+// Note that this closes the websocket connections of all gateways, after
+// closing no downlink packets can be sent.
+func (b *Backend) Close() error {
+	log.Info("backend/basicstation: closing backend")
+
+	b.Lock()
+	b.closed = true
+	for _, conn := range b.connections {
+		conn.ws.Close()
+	}
+	b.Unlock()
+
+	if err := b.server.Close(); err != nil {
+		return errors.Wrap(err, "close http server error")
+	}
+
+	log.Info("backend/basicstation: handling last messages")
+	b.wg.Wait()
+	close(b.rxPacketChan)
+	close(b.statsPacketChan)
+	close(b.txAckChan)
+	return nil
+}
+
+// RXPacketChan returns the RXPacket channel.
+func (b *Backend) RXPacketChan() chan gw.RXPacket {
+	return b.rxPacketChan
+}
+
+// StatsPacketChan returns the gateway stats channel.
+// Note that the Basics Station does not report gateway statistics.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
+	return b.statsPacketChan
+}
+
+// TXAckChan returns the tx acknowledgement channel (dntxed).
+// Note that the Station only reports successfully transmitted downlinks.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// SendTXPacket sends the given TXPacket to the gateway (dnmsg).
+func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
+	b.RLock()
+	conn, ok := b.connections[txPacket.TXInfo.MAC]
+	b.RUnlock()
+	if !ok {
+		return ErrGatewayDoesNotExist
+	}
+
+	var ctx *uplinkContext
+	var delay int
+	if !txPacket.TXInfo.Immediately {
+		uc, d, err := conn.getUplinkContext(txPacket.TXInfo.Timestamp)
+		if err != nil {
+			return err
+		}
+		ctx = &uc
+		delay = d
+	}
+
+	dm, err := newDownlinkMessage(b.band, txPacket, atomic.AddInt64(&b.diid, 1), ctx, delay)
+	if err != nil {
+		return errors.Wrap(err, "get downlink message error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":  txPacket.TXInfo.MAC,
+		"diid": dm.DIID,
+	}).Info("backend/basicstation: sending downlink message")
+
+	if err := conn.send(dm); err != nil {
+		return errors.Wrap(err, "send websocket message error")
+	}
+	return nil
+}
+
+// SendGatewayConfigPacket sends the given configuration to the gateway
+// as router_config message. Note that the Station does not acknowledge
+// the configuration version, as it does not report gateway statistics.
+func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
+	b.RLock()
+	conn, ok := b.connections[configPacket.MAC]
+	b.RUnlock()
+	if !ok {
+		return ErrGatewayDoesNotExist
+	}
+
+	var channels []channel
+	for _, c := range configPacket.Channels {
+		channels = append(channels, channel{
+			Frequency:     c.Frequency,
+			Bandwidth:     c.Bandwidth,
+			Modulation:    c.Modulation,
+			SpreadFactors: c.SpreadFactors,
+			BitRate:       c.BitRate,
+		})
+	}
+
+	rc, err := newRouterConfig(b.bandName, b.band, channels)
+	if err != nil {
+		return errors.Wrap(err, "get router_config error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":     configPacket.MAC,
+		"version": configPacket.Version,
+	}).Info("backend/basicstation: sending router_config")
+
+	if err := conn.send(rc); err != nil {
+		return errors.Wrap(err, "send websocket message error")
+	}
+	return nil
+}
+
+// handleRouterInfo handles the router-info (discovery) request and returns
+// the websocket URI to which the Station must connect.
+func (b *Backend) handleRouterInfo(ws *websocket.Conn) {
+	var data []byte
+	if err := websocket.Message.Receive(ws, &data); err != nil {
+		log.Errorf("backend/basicstation: receive router-info request error: %s", err)
+		return
+	}
+
+	var req struct {
+		Router interface{} `json:"router"`
+	}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	if err := dec.Decode(&req); err != nil {
+		log.Errorf("backend/basicstation: decode router-info request error: %s", err)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"router": req.Router,
+	}
+
+	mac, err := parseRouterID(req.Router)
+	if err != nil {
+		resp["error"] = err.Error()
+	} else {
+		resp["muxs"] = "::0"
+		resp["uri"] = fmt.Sprintf("%s://%s%s%s", b.scheme, ws.Request().Host, gatewayPath, mac)
+	}
+
+	log.WithFields(log.Fields{
+		"router": req.Router,
+		"mac":    mac,
+	}).Info("backend/basicstation: router-info requested")
+
+	if err := websocket.JSON.Send(ws, resp); err != nil {
+		log.Errorf("backend/basicstation: send router-info response error: %s", err)
+	}
+}
+
+// handleGateway handles the websocket connection of a gateway.
+func (b *Backend) handleGateway(ws *websocket.Conn) {
+	mac, err := parseEUI(strings.TrimPrefix(ws.Request().URL.Path, gatewayPath))
+	if err != nil {
+		log.WithField("path", ws.Request().URL.Path).Errorf("backend/basicstation: parse gateway mac error: %s", err)
+		return
+	}
+
+	// the connection of a gateway must not be taken over by an other client
+	if b.clientAuth && !hasClientCertificateForMAC(ws.Request(), mac) {
+		log.WithFields(log.Fields{
+			"mac":  mac,
+			"addr": ws.Request().RemoteAddr,
+		}).Warning("backend/basicstation: client certificate does not match gateway mac")
+		return
+	}
+
+	conn := &connection{
+		ws:      ws,
+		uplinks: make(map[uint32]uplinkContext),
+	}
+
+	b.Lock()
+	if b.closed {
+		b.Unlock()
+		return
+	}
+	if old, ok := b.connections[mac]; ok {
+		log.WithField("mac", mac).Warning("backend/basicstation: closing previous gateway connection")
+		old.ws.Close()
+	}
+	b.connections[mac] = conn
+	b.wg.Add(1)
+	b.Unlock()
+
+	log.WithFields(log.Fields{
+		"mac":  mac,
+		"addr": ws.Request().RemoteAddr,
+	}).Info("backend/basicstation: gateway connected")
+
+	defer func() {
+		b.Lock()
+		if b.connections[mac] == conn {
+			delete(b.connections, mac)
+		}
+		b.Unlock()
+		b.wg.Done()
+	}()
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			b.RLock()
+			closed := b.closed
+			b.RUnlock()
+			if closed {
+				return
+			}
+
+			if err == io.EOF {
+				log.WithField("mac", mac).Info("backend/basicstation: gateway disconnected")
+			} else {
+				log.WithField("mac", mac).Errorf("backend/basicstation: receive websocket message error: %s", err)
+			}
+			return
+		}
+
+		if err := b.handleMessage(mac, conn, data); err != nil {
+			log.WithField("mac", mac).Errorf("backend/basicstation: handle message error: %s", err)
+		}
+	}
+}
+
+// hasClientCertificateForMAC returns true when the given request has a
+// verified client certificate of which the common-name matches the given
+// gateway MAC.
+func hasClientCertificateForMAC(r *http.Request, mac lorawan.EUI64) bool {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return false
+	}
+
+	cn, err := parseEUI(r.TLS.VerifiedChains[0][0].Subject.CommonName)
+	if err != nil {
+		return false
+	}
+	return cn == mac
+}
+
+func (b *Backend) handleMessage(mac lorawan.EUI64, conn *connection, data []byte) error {
+	var msg message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":     mac,
+		"msgtype": msg.MsgType,
+	}).Debug("backend/basicstation: message received")
+
+	switch msg.MsgType {
+	case versionMessage:
+		return b.handleVersion(mac, conn, data)
+	case uplinkDataFrameMessage, joinRequestMessage, proprietaryDataMessage:
+		return b.handleUplink(mac, conn, msg.MsgType, data)
+	case downlinkTXedMessage:
+		return b.handleDownlinkTXed(mac, data)
+	case timeSyncMessage:
+		return b.handleTimeSync(conn, data)
+	default:
+		log.WithFields(log.Fields{
+			"mac":     mac,
+			"msgtype": msg.MsgType,
+		}).Warning("backend/basicstation: unexpected message type")
+		return nil
+	}
+}
+
+// handleVersion handles the version message, which is sent by the Station
+// after connecting and must be answered with the router_config.
+func (b *Backend) handleVersion(mac lorawan.EUI64, conn *connection, data []byte) error {
+	var v version
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":      mac,
+		"station":  v.Station,
+		"firmware": v.Firmware,
+		"model":    v.Model,
+		"protocol": v.Protocol,
+		"features": v.Features,
+	}).Info("backend/basicstation: version received")
+
+	channels, err := b.getChannels(mac)
+	if err != nil {
+		return errors.Wrap(err, "get gateway channels error")
+	}
+
+	rc, err := newRouterConfig(b.bandName, b.band, channels)
+	if err != nil {
+		return errors.Wrap(err, "get router_config error")
+	}
+
+	if err := conn.send(rc); err != nil {
+		return errors.Wrap(err, "send websocket message error")
+	}
+	return nil
+}
+
+func (b *Backend) handleUplink(mac lorawan.EUI64, conn *connection, mt messageType, data []byte) error {
+	var rxPacket gw.RXPacket
+	var rmd radioMetaData
+	var err error
+
+	if err := json.Unmarshal(data, &rmd); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	switch mt {
+	case uplinkDataFrameMessage:
+		var f uplinkDataFrame
+		if err := json.Unmarshal(data, &f); err != nil {
+			return errors.Wrap(err, "unmarshal json error")
+		}
+		rxPacket, err = newRXPacketFromUplinkDataFrame(b.band, mac, f)
+	case joinRequestMessage:
+		var jr joinRequest
+		if err := json.Unmarshal(data, &jr); err != nil {
+			return errors.Wrap(err, "unmarshal json error")
+		}
+		rxPacket, err = newRXPacketFromJoinRequest(b.band, mac, jr)
+	case proprietaryDataMessage:
+		var f proprietaryDataFrame
+		if err := json.Unmarshal(data, &f); err != nil {
+			return errors.Wrap(err, "unmarshal json error")
+		}
+		rxPacket, err = newRXPacketFromProprietaryDataFrame(b.band, mac, f)
+	}
+	if err != nil {
+		return errors.Wrap(err, "get rx packet error")
+	}
+
+	conn.storeUplinkContext(rmd.UpInfo)
+
+	log.WithFields(log.Fields{
+		"mac":     mac,
+		"msgtype": mt,
+	}).Info("backend/basicstation: rx packet received")
+	b.rxPacketChan <- rxPacket
+
+	return nil
+}
+
+func (b *Backend) handleDownlinkTXed(mac lorawan.EUI64, data []byte) error {
+	var txed downlinkTXed
+	if err := json.Unmarshal(data, &txed); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":  mac,
+		"diid": txed.DIID,
+	}).Debug("backend/basicstation: downlink transmitted")
+
+	b.txAckChan <- gw.TXAck{
+		MAC: mac,
+	}
+	return nil
+}
+
+// handleTimeSync answers the time-synchronization request with the
+// current GPS time.
+func (b *Backend) handleTimeSync(conn *connection, data []byte) error {
+	var req timeSync
+	if err := json.Unmarshal(data, &req); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	resp := timeSync{
+		MsgType: timeSyncMessage,
+		TXTime:  req.TXTime,
+		GPSTime: getGPSTime(time.Now()),
+	}
+
+	if err := conn.send(resp); err != nil {
+		return errors.Wrap(err, "send websocket message error")
+	}
+	return nil
+}
+
+// parseRouterID parses the router ID of the router-info request, which is
+// either an integer or a string representation of the gateway EUI.
+func parseRouterID(v interface{}) (lorawan.EUI64, error) {
+	var mac lorawan.EUI64
+
+	switch id := v.(type) {
+	case json.Number:
+		i, err := strconv.ParseUint(id.String(), 10, 64)
+		if err != nil {
+			return mac, errors.Wrapf(ErrInvalidEUI, "%s", id)
+		}
+		for j := range mac {
+			mac[j] = byte(i >> uint(56-8*j))
+		}
+		return mac, nil
+	case string:
+		return parseEUI(id)
+	default:
+		return mac, errors.Wrapf(ErrInvalidEUI, "%v", v)
+	}
+}
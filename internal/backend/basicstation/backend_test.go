@@ -0,0 +1,153 @@
+package basicstation
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/websocket"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestBackend(t *testing.T) {
+	Convey("Given a new Backend for the EU band", t, func() {
+		b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+
+		gwBackend, err := NewBackend("127.0.0.1:0", "", "", "")
+		So(err, ShouldBeNil)
+		defer gwBackend.Close()
+
+		backend := gwBackend.(*Backend)
+		backend.band = b
+		backend.bandName = band.EU_863_870
+		backend.getChannels = func(lorawan.EUI64) ([]channel, error) {
+			return getBandChannels(b, []int{0, 1, 2})
+		}
+
+		server := backend.ln.Addr().String()
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("When requesting the router-info", func() {
+			ws, err := websocket.Dial("ws://"+server+routerInfoPath, "", "http://localhost/")
+			So(err, ShouldBeNil)
+			defer ws.Close()
+
+			So(websocket.Message.Send(ws, `{"router": "102:304:506:708"}`), ShouldBeNil)
+
+			Convey("Then the websocket uri of the gateway is returned", func() {
+				var resp map[string]interface{}
+				So(websocket.JSON.Receive(ws, &resp), ShouldBeNil)
+				So(resp["uri"], ShouldEqual, "ws://"+server+"/gateway/0102030405060708")
+			})
+		})
+
+		Convey("When sending a TXPacket to a gateway which is not connected", func() {
+			err := backend.SendTXPacket(gw.TXPacket{TXInfo: gw.TXInfo{MAC: mac}})
+
+			Convey("Then ErrGatewayDoesNotExist is returned", func() {
+				So(err, ShouldEqual, ErrGatewayDoesNotExist)
+			})
+		})
+
+		Convey("When the gateway connects and sends its version", func() {
+			ws, err := websocket.Dial("ws://"+server+gatewayPath+mac.String(), "", "http://localhost/")
+			So(err, ShouldBeNil)
+			defer ws.Close()
+
+			So(websocket.Message.Send(ws, `{"msgtype": "version", "station": "2.0.0", "protocol": 2}`), ShouldBeNil)
+
+			Convey("Then the router_config is returned", func() {
+				var rc routerConfig
+				So(websocket.JSON.Receive(ws, &rc), ShouldBeNil)
+				So(rc.Region, ShouldEqual, "EU863")
+
+				Convey("When the gateway sends an uplink and a downlink is scheduled", func() {
+					So(websocket.Message.Send(ws, `{"msgtype": "jreq", "MHdr": 0, "JoinEui": "01-02-03-04-05-06-07-08", "DevEui": "11-12-13-14-15-16-17-18", "DevNonce": 513, "MIC": 67305985, "DR": 0, "Freq": 868100000, "upinfo": {"rctx": 1, "xtime": 36028797019426480}}`), ShouldBeNil)
+
+					rxPacket := <-backend.RXPacketChan()
+					So(rxPacket.RXInfo.MAC, ShouldEqual, mac)
+					So(rxPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.JoinRequest)
+
+					txPacket := gw.TXPacket{
+						TXInfo: gw.TXInfo{
+							MAC:       mac,
+							Timestamp: rxPacket.RXInfo.Timestamp + 5000000,
+							Frequency: 868100000,
+							DataRate:  b.DataRates[0],
+						},
+						PHYPayload: lorawan.PHYPayload{
+							MHDR: lorawan.MHDR{
+								MType: lorawan.UnconfirmedDataDown,
+								Major: lorawan.LoRaWANR1,
+							},
+							MACPayload: &lorawan.MACPayload{},
+						},
+					}
+					So(backend.SendTXPacket(txPacket), ShouldBeNil)
+
+					Convey("Then the gateway receives the dnmsg within the uplink context", func() {
+						var dm downlinkMessage
+						So(websocket.JSON.Receive(ws, &dm), ShouldBeNil)
+						So(dm.MsgType, ShouldEqual, downlinkMessageMessage)
+						So(dm.DeviceClass, ShouldEqual, deviceClassA)
+						So(*dm.RxDelay, ShouldEqual, 5)
+						So(dm.XTime, ShouldEqual, 36028797019426480)
+						So(dm.RCtx, ShouldEqual, 1)
+					})
+
+					Convey("Then a RX2 downlink with a RX delay of 15 seconds is sent as RX2", func() {
+						txPacket.TXInfo.Timestamp = rxPacket.RXInfo.Timestamp + 16000000
+						txPacket.TXInfo.RXWindow = gw.RXWindow2
+						So(backend.SendTXPacket(txPacket), ShouldBeNil)
+
+						var dm downlinkMessage
+						So(websocket.JSON.Receive(ws, &dm), ShouldBeNil)
+						So(*dm.RxDelay, ShouldEqual, 15)
+						So(dm.RX1DR, ShouldBeNil)
+						So(*dm.RX2Freq, ShouldEqual, 868100000)
+					})
+
+					Convey("Then a downlink with an unknown timestamp returns an error", func() {
+						txPacket.TXInfo.Timestamp++
+						So(backend.SendTXPacket(txPacket), ShouldEqual, ErrUplinkContextNotFound)
+					})
+				})
+			})
+		})
+	})
+}
+
+func TestHasClientCertificateForMAC(t *testing.T) {
+	Convey("Given a gateway MAC", t, func() {
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		newRequest := func(cn string) *http.Request {
+			return &http.Request{
+				TLS: &tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{
+						{{Subject: pkix.Name{CommonName: cn}}},
+					},
+				},
+			}
+		}
+
+		Convey("Then a request without client certificate is rejected", func() {
+			So(hasClientCertificateForMAC(&http.Request{}, mac), ShouldBeFalse)
+		})
+
+		Convey("Then a client certificate of an other gateway is rejected", func() {
+			So(hasClientCertificateForMAC(newRequest("0807060504030201"), mac), ShouldBeFalse)
+		})
+
+		Convey("Then the client certificate of the gateway is accepted", func() {
+			So(hasClientCertificateForMAC(newRequest("0102030405060708"), mac), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,425 @@
+package basicstation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// messageType defines the Basics Station message type (msgtype field).
+type messageType string
+
+// Supported message types.
+const (
+	versionMessage         messageType = "version"
+	routerConfigMessage    messageType = "router_config"
+	uplinkDataFrameMessage messageType = "updf"
+	joinRequestMessage     messageType = "jreq"
+	proprietaryDataMessage messageType = "propdf"
+	downlinkMessageMessage messageType = "dnmsg"
+	downlinkTXedMessage    messageType = "dntxed"
+	timeSyncMessage        messageType = "timesync"
+)
+
+// Device classes (dC) of the downlink message.
+const (
+	deviceClassA = 0
+	deviceClassC = 2
+)
+
+// gpsEpoch defines the GPS epoch and gpsLeapSeconds the number of leap
+// seconds between GPS time and UTC.
+var (
+	gpsEpoch       = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	gpsLeapSeconds = 18 * time.Second
+)
+
+// errors
+var (
+	ErrInvalidEUI = errors.New("invalid eui")
+)
+
+// eui implements the EUI64 representation used by the Basics Station
+// (e.g. 01-02-03-04-05-06-07-08).
+type eui lorawan.EUI64
+
+// MarshalJSON implements json.Marshaler.
+func (e eui) MarshalJSON() ([]byte, error) {
+	parts := make([]string, len(e))
+	for i := range e {
+		parts[i] = fmt.Sprintf("%02x", e[i])
+	}
+	return json.Marshal(strings.Join(parts, "-"))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *eui) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	id, err := parseEUI(s)
+	if err != nil {
+		return err
+	}
+	*e = eui(id)
+	return nil
+}
+
+// hexBytes implements a hex encoded byte slice.
+type hexBytes []byte
+
+// MarshalJSON implements json.Marshaler.
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(h))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *hexBytes) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*h = data
+	return nil
+}
+
+// parseEUI parses an EUI in one of the formats used by the Basics Station:
+// plain hex (0102030405060708), dash or colon separated bytes
+// (01-02-03-04-05-06-07-08) or the IPv6 like ID6 format (102:304:506:708,
+// with :: for a sequence of zero groups).
+func parseEUI(s string) (lorawan.EUI64, error) {
+	var out lorawan.EUI64
+
+	if c := strings.Count(s, ":"); c == 0 || c == 7 {
+		plain := strings.NewReplacer("-", "", ":", "").Replace(s)
+		if err := out.UnmarshalText([]byte(plain)); err != nil {
+			return out, errors.Wrapf(ErrInvalidEUI, "%s", s)
+		}
+		return out, nil
+	}
+
+	// ID6 format
+	var groups []string
+	if parts := strings.Split(s, "::"); len(parts) == 2 {
+		head := splitGroups(parts[0])
+		tail := splitGroups(parts[1])
+		if len(head)+len(tail) > 3 {
+			return out, errors.Wrapf(ErrInvalidEUI, "%s", s)
+		}
+		groups = append(head, make([]string, 4-len(head)-len(tail))...)
+		groups = append(groups, tail...)
+	} else if len(parts) == 1 {
+		groups = splitGroups(s)
+	}
+
+	if len(groups) != 4 {
+		return out, errors.Wrapf(ErrInvalidEUI, "%s", s)
+	}
+
+	for i, g := range groups {
+		if g == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(g, 16, 16)
+		if err != nil {
+			return out, errors.Wrapf(ErrInvalidEUI, "%s", s)
+		}
+		binary.BigEndian.PutUint16(out[i*2:], uint16(v))
+	}
+
+	return out, nil
+}
+
+func splitGroups(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ":")
+}
+
+// message is used to decode the message type before decoding the
+// message itself.
+type message struct {
+	MsgType messageType `json:"msgtype"`
+}
+
+// version contains the version message sent by the Station after
+// connecting.
+type version struct {
+	Station  string `json:"station"`
+	Firmware string `json:"firmware"`
+	Package  string `json:"package"`
+	Model    string `json:"model"`
+	Protocol int    `json:"protocol"`
+	Features string `json:"features"`
+}
+
+// upInfo contains the radio meta-data of an uplink frame.
+type upInfo struct {
+	RCtx    int64   `json:"rctx"`
+	XTime   int64   `json:"xtime"`
+	GPSTime int64   `json:"gpstime"`
+	RSSI    float64 `json:"rssi"`
+	SNR     float64 `json:"snr"`
+	RXTime  float64 `json:"rxtime"`
+	FTS     *int64  `json:"fts,omitempty"` // fine-timestamp in ns (-1 when not available)
+}
+
+// radioMetaData contains the data-rate, frequency and upInfo shared by
+// all uplink messages.
+type radioMetaData struct {
+	DR     int    `json:"DR"`
+	Freq   int    `json:"Freq"`
+	UpInfo upInfo `json:"upinfo"`
+}
+
+// uplinkDataFrame contains a (parsed) uplink data frame.
+type uplinkDataFrame struct {
+	MHdr       uint8    `json:"MHdr"`
+	DevAddr    int32    `json:"DevAddr"`
+	FCtrl      uint8    `json:"FCtrl"`
+	FCnt       uint16   `json:"FCnt"`
+	FOpts      hexBytes `json:"FOpts"`
+	FPort      int      `json:"FPort"`
+	FRMPayload hexBytes `json:"FRMPayload"`
+	MIC        int32    `json:"MIC"`
+	radioMetaData
+}
+
+// joinRequest contains a (parsed) join-request.
+type joinRequest struct {
+	MHdr     uint8  `json:"MHdr"`
+	JoinEUI  eui    `json:"JoinEui"`
+	DevEUI   eui    `json:"DevEui"`
+	DevNonce uint16 `json:"DevNonce"`
+	MIC      int32  `json:"MIC"`
+	radioMetaData
+}
+
+// proprietaryDataFrame contains a proprietary frame, FRMPayload holds
+// the complete frame.
+type proprietaryDataFrame struct {
+	FRMPayload hexBytes `json:"FRMPayload"`
+	radioMetaData
+}
+
+// downlinkMessage contains a frame to be transmitted by the Station.
+type downlinkMessage struct {
+	MsgType     messageType `json:"msgtype"`
+	DevEUI      eui         `json:"DevEui"`
+	DeviceClass int         `json:"dC"`
+	DIID        int64       `json:"diid"`
+	PDU         hexBytes    `json:"pdu"`
+	RxDelay     *int        `json:"RxDelay,omitempty"`
+	RX1DR       *int        `json:"RX1DR,omitempty"`
+	RX1Freq     *int        `json:"RX1Freq,omitempty"`
+	RX2DR       *int        `json:"RX2DR,omitempty"`
+	RX2Freq     *int        `json:"RX2Freq,omitempty"`
+	Priority    int         `json:"priority"`
+	XTime       int64       `json:"xtime,omitempty"`
+	RCtx        int64       `json:"rctx,omitempty"`
+}
+
+// downlinkTXed is sent by the Station when a downlink has been
+// transmitted.
+type downlinkTXed struct {
+	DevEUI  eui     `json:"DevEui"`
+	DIID    int64   `json:"diid"`
+	XTime   int64   `json:"xtime"`
+	TXTime  float64 `json:"txtime"`
+	GPSTime int64   `json:"gpstime"`
+}
+
+// timeSync contains a time-synchronization request (txtime) or response
+// (txtime and gpstime).
+type timeSync struct {
+	MsgType messageType `json:"msgtype"`
+	TXTime  float64     `json:"txtime"`
+	GPSTime int64       `json:"gpstime,omitempty"`
+}
+
+// getGPSTime returns the GPS time (in microseconds) for the given time.
+func getGPSTime(t time.Time) int64 {
+	return int64((t.Sub(gpsEpoch) + gpsLeapSeconds) / time.Microsecond)
+}
+
+// newRXInfo returns the gw.RXInfo for the given radio meta-data.
+func newRXInfo(b band.Band, mac lorawan.EUI64, rmd radioMetaData, size int) (gw.RXInfo, error) {
+	if rmd.DR < 0 || rmd.DR >= len(b.DataRates) {
+		return gw.RXInfo{}, fmt.Errorf("invalid data-rate: %d", rmd.DR)
+	}
+
+	rxInfo := gw.RXInfo{
+		MAC:       mac,
+		Timestamp: uint32(rmd.UpInfo.XTime),
+		Frequency: rmd.Freq,
+		CRCStatus: 1,
+		RSSI:      int(rmd.UpInfo.RSSI),
+		LoRaSNR:   rmd.UpInfo.SNR,
+		Size:      size,
+		DataRate:  b.DataRates[rmd.DR],
+	}
+
+	if rxInfo.DataRate.Modulation == band.LoRaModulation {
+		rxInfo.CodeRate = "4/5"
+	}
+
+	if rmd.UpInfo.RXTime != 0 {
+		sec, frac := math.Modf(rmd.UpInfo.RXTime)
+		rxInfo.Time = time.Unix(int64(sec), int64(frac*1e9)).Round(time.Microsecond).UTC()
+	}
+
+	if rmd.UpInfo.FTS != nil && *rmd.UpInfo.FTS >= 0 {
+		ft := time.Duration(*rmd.UpInfo.FTS)
+		rxInfo.FineTimestampType = gw.FineTimestampPlain
+		rxInfo.FineTimestamp = &ft
+	}
+
+	return rxInfo, nil
+}
+
+// newRXPacketFromUplinkDataFrame returns the gw.RXPacket for the given
+// uplink data frame. As the Station sends the frame in parsed form, the
+// PHYPayload is re-assembled from its fields.
+func newRXPacketFromUplinkDataFrame(b band.Band, mac lorawan.EUI64, f uplinkDataFrame) (gw.RXPacket, error) {
+	phy := []byte{f.MHdr}
+	phy = appendUint32(phy, uint32(f.DevAddr))
+	phy = append(phy, f.FCtrl)
+	phy = appendUint16(phy, f.FCnt)
+	phy = append(phy, f.FOpts...)
+	if f.FPort >= 0 {
+		phy = append(phy, uint8(f.FPort))
+		phy = append(phy, f.FRMPayload...)
+	}
+	phy = appendUint32(phy, uint32(f.MIC))
+
+	return newRXPacket(b, mac, f.radioMetaData, phy)
+}
+
+// newRXPacketFromJoinRequest returns the gw.RXPacket for the given
+// join-request.
+func newRXPacketFromJoinRequest(b band.Band, mac lorawan.EUI64, jr joinRequest) (gw.RXPacket, error) {
+	phy := []byte{jr.MHdr}
+	phy = appendReversed(phy, jr.JoinEUI[:])
+	phy = appendReversed(phy, jr.DevEUI[:])
+	phy = appendUint16(phy, jr.DevNonce)
+	phy = appendUint32(phy, uint32(jr.MIC))
+
+	return newRXPacket(b, mac, jr.radioMetaData, phy)
+}
+
+// newRXPacketFromProprietaryDataFrame returns the gw.RXPacket for the given
+// proprietary frame.
+func newRXPacketFromProprietaryDataFrame(b band.Band, mac lorawan.EUI64, f proprietaryDataFrame) (gw.RXPacket, error) {
+	return newRXPacket(b, mac, f.radioMetaData, f.FRMPayload)
+}
+
+func newRXPacket(b band.Band, mac lorawan.EUI64, rmd radioMetaData, phy []byte) (gw.RXPacket, error) {
+	rxInfo, err := newRXInfo(b, mac, rmd, len(phy))
+	if err != nil {
+		return gw.RXPacket{}, err
+	}
+
+	rxPacket := gw.RXPacket{
+		RXInfo: rxInfo,
+	}
+
+	if err := rxPacket.PHYPayload.UnmarshalBinary(phy); err != nil {
+		return rxPacket, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	return rxPacket, nil
+}
+
+// newDownlinkMessage returns the downlink message for the given TXPacket.
+// For class-A downlinks, the uplink context (xtime and rctx) must be given
+// together with the delay (in seconds) between the uplink and the downlink.
+// As the RX2 window opens one second after the RX1 window, the RX delay of
+// a RX2 downlink is one second less than this delay.
+func newDownlinkMessage(b band.Band, txPacket gw.TXPacket, diid int64, ctx *uplinkContext, delay int) (downlinkMessage, error) {
+	pdu, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return downlinkMessage{}, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	dr, err := b.GetDataRate(txPacket.TXInfo.DataRate)
+	if err != nil {
+		return downlinkMessage{}, errors.Wrap(err, "get data-rate error")
+	}
+	freq := txPacket.TXInfo.Frequency
+
+	dm := downlinkMessage{
+		MsgType: downlinkMessageMessage,
+		DIID:    diid,
+		PDU:     pdu,
+	}
+
+	if txPacket.TXInfo.Immediately {
+		dm.DeviceClass = deviceClassC
+		dm.RX2DR = &dr
+		dm.RX2Freq = &freq
+		if ctx != nil {
+			dm.RCtx = ctx.rctx
+		}
+		return dm, nil
+	}
+
+	if ctx == nil {
+		return dm, errors.New("uplink context is required for class-a downlink")
+	}
+
+	rxDelay := delay
+	if txPacket.TXInfo.RXWindow == gw.RXWindow2 {
+		rxDelay--
+	}
+	if rxDelay < 1 || rxDelay > maxRXDelay {
+		return dm, fmt.Errorf("invalid rx delay: %d (max rx delay: %d)", rxDelay, maxRXDelay)
+	}
+
+	dm.DeviceClass = deviceClassA
+	dm.RxDelay = &rxDelay
+	if txPacket.TXInfo.RXWindow == gw.RXWindow2 {
+		dm.RX2DR = &dr
+		dm.RX2Freq = &freq
+	} else {
+		dm.RX1DR = &dr
+		dm.RX1Freq = &freq
+	}
+	dm.XTime = ctx.xtime
+	dm.RCtx = ctx.rctx
+
+	return dm, nil
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// appendReversed appends the given EUI in little-endian byte order (as
+// used in the PHYPayload).
+func appendReversed(b []byte, e []byte) []byte {
+	for i := len(e) - 1; i >= 0; i-- {
+		b = append(b, e[i])
+	}
+	return b
+}
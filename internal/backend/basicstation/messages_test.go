@@ -0,0 +1,190 @@
+package basicstation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestParseEUI(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			EUI      string
+			Expected lorawan.EUI64
+			Error    error
+		}{
+			{"0102030405060708", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, nil},
+			{"01-02-03-04-05-06-07-08", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, nil},
+			{"01:02:03:04:05:06:07:08", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, nil},
+			{"102:304:506:708", lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}, nil},
+			{"::1", lorawan.EUI64{0, 0, 0, 0, 0, 0, 0, 1}, nil},
+			{"102::708", lorawan.EUI64{1, 2, 0, 0, 0, 0, 7, 8}, nil},
+			{"0102", lorawan.EUI64{}, ErrInvalidEUI},
+			{"1:2:3:4:5", lorawan.EUI64{}, ErrInvalidEUI},
+		}
+
+		for _, test := range tests {
+			eui, err := parseEUI(test.EUI)
+			So(errors.Cause(err), ShouldEqual, test.Error)
+			So(eui, ShouldEqual, test.Expected)
+		}
+	})
+}
+
+func TestNewRXPacket(t *testing.T) {
+	Convey("Given the EU band", t, func() {
+		b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then an uplink data frame is converted to a RXPacket", func() {
+			var f uplinkDataFrame
+			So(json.Unmarshal([]byte(`{"msgtype": "updf", "MHdr": 64, "DevAddr": 16909060, "FCtrl": 128, "FCnt": 258, "FOpts": "", "FPort": 10, "FRMPayload": "0102", "MIC": 67305985, "DR": 5, "Freq": 868100000, "upinfo": {"rctx": 0, "xtime": 36028797019426480, "rssi": -50, "snr": 9.5, "rxtime": 1539688312.25}}`), &f), ShouldBeNil)
+
+			rxPacket, err := newRXPacketFromUplinkDataFrame(b, mac, f)
+			So(err, ShouldBeNil)
+			So(rxPacket.RXInfo, ShouldResemble, gw.RXInfo{
+				MAC:       mac,
+				Time:      time.Date(2018, 10, 16, 11, 11, 52, 250000000, time.UTC),
+				Timestamp: 462512,
+				Frequency: 868100000,
+				CRCStatus: 1,
+				CodeRate:  "4/5",
+				RSSI:      -50,
+				LoRaSNR:   9.5,
+				Size:      15,
+				DataRate: band.DataRate{
+					Modulation:   band.LoRaModulation,
+					SpreadFactor: 7,
+					Bandwidth:    125,
+				},
+			})
+
+			phyB, err := rxPacket.PHYPayload.MarshalBinary()
+			So(err, ShouldBeNil)
+			So(phyB, ShouldResemble, []byte{0x40, 0x04, 0x03, 0x02, 0x01, 0x80, 0x02, 0x01, 0x0a, 0x01, 0x02, 0x01, 0x02, 0x03, 0x04})
+		})
+
+		Convey("Then a join-request is converted to a RXPacket", func() {
+			var jr joinRequest
+			So(json.Unmarshal([]byte(`{"msgtype": "jreq", "MHdr": 0, "JoinEui": "01-02-03-04-05-06-07-08", "DevEui": "11-12-13-14-15-16-17-18", "DevNonce": 513, "MIC": 67305985, "DR": 0, "Freq": 868300000, "upinfo": {"xtime": 12345}}`), &jr), ShouldBeNil)
+
+			rxPacket, err := newRXPacketFromJoinRequest(b, mac, jr)
+			So(err, ShouldBeNil)
+			So(rxPacket.RXInfo.Timestamp, ShouldEqual, 12345)
+			So(rxPacket.RXInfo.DataRate.SpreadFactor, ShouldEqual, 12)
+
+			jrPL, ok := rxPacket.PHYPayload.MACPayload.(*lorawan.JoinRequestPayload)
+			So(ok, ShouldBeTrue)
+			So(jrPL.AppEUI, ShouldEqual, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			So(jrPL.DevEUI, ShouldEqual, lorawan.EUI64{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18})
+			So(rxPacket.PHYPayload.MIC, ShouldEqual, lorawan.MIC{1, 2, 3, 4})
+		})
+
+		Convey("Then an invalid data-rate returns an error", func() {
+			_, err := newRXPacketFromProprietaryDataFrame(b, mac, proprietaryDataFrame{
+				FRMPayload:    []byte{0xe0, 1, 2, 3, 4},
+				radioMetaData: radioMetaData{DR: 16},
+			})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestNewDownlinkMessage(t *testing.T) {
+	Convey("Given the EU band and a TXPacket", t, func() {
+		b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+
+		txPacket := gw.TXPacket{
+			TXInfo: gw.TXInfo{
+				Frequency: 869525000,
+				DataRate:  b.DataRates[0],
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataDown,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{},
+			},
+		}
+		pdu, err := txPacket.PHYPayload.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		Convey("Then a class-a downlink without uplink context returns an error", func() {
+			_, err := newDownlinkMessage(b, txPacket, 1, nil, 2)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then a class-a downlink message is returned for the uplink context", func() {
+			dm, err := newDownlinkMessage(b, txPacket, 1, &uplinkContext{xtime: 123, rctx: 2}, 2)
+			So(err, ShouldBeNil)
+
+			rxDelay := 2
+			dr := 0
+			freq := 869525000
+			So(dm, ShouldResemble, downlinkMessage{
+				MsgType:     downlinkMessageMessage,
+				DeviceClass: deviceClassA,
+				DIID:        1,
+				PDU:         pdu,
+				RxDelay:     &rxDelay,
+				RX1DR:       &dr,
+				RX1Freq:     &freq,
+				XTime:       123,
+				RCtx:        2,
+			})
+		})
+
+		Convey("Then a RX2 downlink message is returned for the RX2 window", func() {
+			txPacket.TXInfo.RXWindow = gw.RXWindow2
+			dm, err := newDownlinkMessage(b, txPacket, 1, &uplinkContext{xtime: 123, rctx: 2}, 16)
+			So(err, ShouldBeNil)
+
+			rxDelay := 15
+			dr := 0
+			freq := 869525000
+			So(dm, ShouldResemble, downlinkMessage{
+				MsgType:     downlinkMessageMessage,
+				DeviceClass: deviceClassA,
+				DIID:        1,
+				PDU:         pdu,
+				RxDelay:     &rxDelay,
+				RX2DR:       &dr,
+				RX2Freq:     &freq,
+				XTime:       123,
+				RCtx:        2,
+			})
+		})
+
+		Convey("Then a RX1 downlink exceeding the max. RX delay returns an error", func() {
+			_, err := newDownlinkMessage(b, txPacket, 1, &uplinkContext{xtime: 123, rctx: 2}, 16)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then a class-c downlink message is returned when sending immediately", func() {
+			txPacket.TXInfo.Immediately = true
+			dm, err := newDownlinkMessage(b, txPacket, 2, nil, 0)
+			So(err, ShouldBeNil)
+
+			dr := 0
+			freq := 869525000
+			So(dm, ShouldResemble, downlinkMessage{
+				MsgType:     downlinkMessageMessage,
+				DeviceClass: deviceClassC,
+				DIID:        2,
+				PDU:         pdu,
+				RX2DR:       &dr,
+				RX2Freq:     &freq,
+			})
+		})
+	})
+}
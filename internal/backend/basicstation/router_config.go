@@ -0,0 +1,330 @@
+package basicstation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// maxRadioSpan defines the max. distance (in Hz) between the frequencies of
+// the channels assigned to a single SX1301 radio (the IF frequencies range
+// from -400 kHz to 400 kHz).
+const maxRadioSpan = 800000
+
+// fskBandwidth defines the default FSK channel bandwidth (in kHz) in case
+// the data-rate does not define the bandwidth.
+const fskBandwidth = 125
+
+// Number of IF channels available per type on the SX1301.
+const (
+	maxMultiSFChannels = 8
+	maxRadios          = 2
+)
+
+// maxDataRates defines the number of data-rates of the DRs table.
+const maxDataRates = 16
+
+// regions maps the band names to the Basics Station region, frequency
+// range and the first downlink-only data-rate (0 when the band does not
+// define downlink-only data-rates).
+var regions = map[band.Name]struct {
+	region     string
+	freqRange  [2]int
+	dnOnlyFrom int
+}{
+	band.AS_923:     {"AS923", [2]int{915000000, 928000000}, 0},
+	band.AU_915_928: {"AU915", [2]int{915000000, 928000000}, 8},
+	band.CN_470_510: {"CN470", [2]int{470000000, 510000000}, 0},
+	band.CN_779_787: {"CN779", [2]int{779000000, 787000000}, 0},
+	band.EU_433:     {"EU433", [2]int{433175000, 434665000}, 0},
+	band.EU_863_870: {"EU863", [2]int{863000000, 870000000}, 0},
+	band.IN_865_867: {"IN865", [2]int{865000000, 867000000}, 0},
+	band.KR_920_923: {"KR920", [2]int{920900000, 923300000}, 0},
+	band.US_902_928: {"US902", [2]int{902000000, 928000000}, 8},
+}
+
+// channel contains a single channel the gateway must listen on.
+type channel struct {
+	Frequency     int // in Hz
+	Bandwidth     int // in kHz
+	Modulation    band.Modulation
+	SpreadFactors []int
+	BitRate       int
+}
+
+// routerConfig contains the router_config message.
+type routerConfig struct {
+	MsgType    messageType  `json:"msgtype"`
+	NetID      []uint32     `json:"NetID"`
+	JoinEUI    [][2]uint64  `json:"JoinEui"`
+	Region     string       `json:"region"`
+	HWSpec     string       `json:"hwspec"`
+	FreqRange  [2]int       `json:"freq_range"`
+	DRs        [][3]int     `json:"DRs"`
+	SX1301Conf []sx1301Conf `json:"sx1301_conf"`
+}
+
+// sx1301Conf contains the concentrator configuration.
+type sx1301Conf struct {
+	Radios  [maxRadios]radioConf
+	MultiSF [maxMultiSFChannels]chanConf
+	LoRaStd chanConf
+	FSK     chanConf
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c sx1301Conf) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{
+		"chan_Lora_std": c.LoRaStd,
+		"chan_FSK":      c.FSK,
+	}
+	for i := range c.Radios {
+		out[fmt.Sprintf("radio_%d", i)] = c.Radios[i]
+	}
+	for i := range c.MultiSF {
+		out[fmt.Sprintf("chan_multiSF_%d", i)] = c.MultiSF[i]
+	}
+	return json.Marshal(out)
+}
+
+// radioConf contains the configuration of a radio.
+type radioConf struct {
+	Enable bool `json:"enable"`
+	Freq   int  `json:"freq,omitempty"`
+}
+
+// chanConf contains the configuration of an IF channel.
+type chanConf struct {
+	Enable       bool `json:"enable"`
+	Radio        int  `json:"radio"`
+	IF           int  `json:"if"`
+	Bandwidth    int  `json:"bandwidth,omitempty"`
+	SpreadFactor int  `json:"spread_factor,omitempty"`
+	DataRate     int  `json:"datarate,omitempty"`
+}
+
+// getGatewayChannels returns the channels of the channel-configuration
+// assigned to the given gateway. When the gateway does not exist or does
+// not have a channel-configuration, the enabled uplink channels of the
+// band are returned.
+func getGatewayChannels(mac lorawan.EUI64) ([]channel, error) {
+	g, err := gateway.GetGateway(common.DB, mac)
+	if err != nil && errors.Cause(err) != gateway.ErrDoesNotExist {
+		return nil, errors.Wrap(err, "get gateway error")
+	}
+
+	if err != nil || g.ChannelConfigurationID == nil {
+		return getBandChannels(common.Band, common.Band.GetEnabledUplinkChannels())
+	}
+
+	cf, err := gateway.GetChannelConfiguration(common.DB, *g.ChannelConfigurationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get channel-configuration error")
+	}
+
+	extraChannels, err := gateway.GetExtraChannelsForChannelConfigurationID(common.DB, cf.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get extra channels error")
+	}
+
+	var channels []int
+	for _, c := range cf.Channels {
+		channels = append(channels, int(c))
+	}
+
+	out, err := getBandChannels(common.Band, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, getExtraChannels(extraChannels)...), nil
+}
+
+// getBandChannels returns the channels for the given band uplink channel
+// indices.
+func getBandChannels(b band.Band, indices []int) ([]channel, error) {
+	var out []channel
+
+	for _, i := range indices {
+		if i < 0 || i >= len(b.UplinkChannels) {
+			return nil, fmt.Errorf("channel %d does not exist in band", i)
+		}
+
+		c := channel{
+			Frequency: b.UplinkChannels[i].Frequency,
+		}
+
+		for _, drIndex := range b.UplinkChannels[i].DataRates {
+			dr := b.DataRates[drIndex]
+			c.Modulation = dr.Modulation
+			c.Bandwidth = dr.Bandwidth
+
+			switch dr.Modulation {
+			case band.LoRaModulation:
+				c.SpreadFactors = append(c.SpreadFactors, dr.SpreadFactor)
+			case band.FSKModulation:
+				c.BitRate = dr.BitRate
+				if c.Bandwidth == 0 {
+					c.Bandwidth = fskBandwidth
+				}
+			}
+		}
+
+		out = append(out, c)
+	}
+
+	return out, nil
+}
+
+// getExtraChannels returns the channels for the given extra channels.
+func getExtraChannels(extraChannels []gateway.ExtraChannel) []channel {
+	var out []channel
+
+	for _, ec := range extraChannels {
+		c := channel{
+			Frequency:  ec.Frequency,
+			Bandwidth:  ec.BandWidth,
+			Modulation: band.Modulation(ec.Modulation),
+			BitRate:    ec.BitRate,
+		}
+
+		for _, sf := range ec.SpreadFactors {
+			c.SpreadFactors = append(c.SpreadFactors, int(sf))
+		}
+
+		out = append(out, c)
+	}
+
+	return out
+}
+
+// newRouterConfig returns the router_config message for the given band
+// and channels.
+func newRouterConfig(bandName band.Name, b band.Band, channels []channel) (routerConfig, error) {
+	region, ok := regions[bandName]
+	if !ok {
+		return routerConfig{}, fmt.Errorf("band %s is not supported by the basics station", bandName)
+	}
+
+	conf, err := newSX1301Conf(channels)
+	if err != nil {
+		return routerConfig{}, errors.Wrap(err, "get sx1301 configuration error")
+	}
+
+	return routerConfig{
+		MsgType:    routerConfigMessage,
+		Region:     region.region,
+		HWSpec:     "sx1301/1",
+		FreqRange:  region.freqRange,
+		DRs:        getDataRates(b, region.dnOnlyFrom),
+		SX1301Conf: []sx1301Conf{conf},
+	}, nil
+}
+
+// getDataRates returns the DRs table (spreading-factor, bandwidth and
+// downlink-only flag for each data-rate) for the given band. FSK data-rates
+// have a spreading-factor of 0, undefined data-rates a spreading-factor
+// of -1.
+func getDataRates(b band.Band, dnOnlyFrom int) [][3]int {
+	out := make([][3]int, maxDataRates)
+	for i := range out {
+		out[i] = [3]int{-1, 0, 0}
+		if i >= len(b.DataRates) {
+			continue
+		}
+
+		var dnOnly int
+		if dnOnlyFrom != 0 && i >= dnOnlyFrom {
+			dnOnly = 1
+		}
+
+		switch b.DataRates[i].Modulation {
+		case band.LoRaModulation:
+			out[i] = [3]int{b.DataRates[i].SpreadFactor, b.DataRates[i].Bandwidth, dnOnly}
+		case band.FSKModulation:
+			out[i] = [3]int{0, 0, dnOnly}
+		}
+	}
+
+	return out
+}
+
+// newSX1301Conf returns the concentrator configuration for the given
+// channels. The channels are assigned to the radios in order of frequency,
+// each radio covering at most maxRadioSpan Hz.
+func newSX1301Conf(channels []channel) (sx1301Conf, error) {
+	var conf sx1301Conf
+
+	channels = append([]channel(nil), channels...)
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Frequency < channels[j].Frequency
+	})
+
+	// group the channels per radio
+	var groups [][]channel
+	for _, c := range channels {
+		if len(groups) == 0 || c.Frequency-groups[len(groups)-1][0].Frequency > maxRadioSpan {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], c)
+	}
+
+	if len(groups) > maxRadios {
+		return conf, fmt.Errorf("channels require %d radios, the concentrator has %d", len(groups), maxRadios)
+	}
+
+	var multiSF int
+	for radio, group := range groups {
+		center := (group[0].Frequency + group[len(group)-1].Frequency) / 2
+
+		conf.Radios[radio] = radioConf{
+			Enable: true,
+			Freq:   center,
+		}
+
+		for _, c := range group {
+			cc := chanConf{
+				Enable: true,
+				Radio:  radio,
+				IF:     c.Frequency - center,
+			}
+
+			switch {
+			case c.Modulation == band.FSKModulation:
+				if conf.FSK.Enable {
+					return conf, errors.New("only one fsk channel is supported")
+				}
+				cc.Bandwidth = c.Bandwidth * 1000
+				cc.DataRate = c.BitRate
+				conf.FSK = cc
+			case c.Modulation == band.LoRaModulation && c.Bandwidth == 125:
+				if multiSF == maxMultiSFChannels {
+					return conf, fmt.Errorf("only %d multi-sf channels are supported", maxMultiSFChannels)
+				}
+				conf.MultiSF[multiSF] = cc
+				multiSF++
+			case c.Modulation == band.LoRaModulation:
+				if conf.LoRaStd.Enable {
+					return conf, errors.New("only one lora standard channel is supported")
+				}
+				if len(c.SpreadFactors) != 1 {
+					return conf, errors.New("the lora standard channel must have exactly one spreading-factor")
+				}
+				cc.Bandwidth = c.Bandwidth * 1000
+				cc.SpreadFactor = c.SpreadFactors[0]
+				conf.LoRaStd = cc
+			default:
+				return conf, fmt.Errorf("unknown modulation: %s", c.Modulation)
+			}
+		}
+	}
+
+	return conf, nil
+}
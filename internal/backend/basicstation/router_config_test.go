@@ -0,0 +1,83 @@
+package basicstation
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestNewRouterConfig(t *testing.T) {
+	Convey("Given the EU band with the default channels and two extra channels", t, func() {
+		b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+
+		channels, err := getBandChannels(b, []int{0, 1, 2})
+		So(err, ShouldBeNil)
+		channels = append(channels, getExtraChannels([]gateway.ExtraChannel{
+			{Modulation: gateway.ChannelModulationLoRa, Frequency: 868300000, BandWidth: 250, SpreadFactors: []int64{7}},
+			{Modulation: gateway.ChannelModulationFSK, Frequency: 868800000, BandWidth: 125, BitRate: 50000},
+		})...)
+
+		Convey("Then the expected router_config is returned", func() {
+			rc, err := newRouterConfig(band.EU_863_870, b, channels)
+			So(err, ShouldBeNil)
+			So(rc.MsgType, ShouldEqual, routerConfigMessage)
+			So(rc.Region, ShouldEqual, "EU863")
+			So(rc.FreqRange, ShouldEqual, [2]int{863000000, 870000000})
+			So(rc.DRs[0], ShouldEqual, [3]int{12, 125, 0})
+			So(rc.DRs[6], ShouldEqual, [3]int{7, 250, 0})
+			So(rc.DRs[7], ShouldEqual, [3]int{0, 0, 0})
+			So(rc.DRs[8], ShouldEqual, [3]int{-1, 0, 0})
+
+			So(rc.SX1301Conf, ShouldHaveLength, 1)
+			conf := rc.SX1301Conf[0]
+			So(conf.Radios[0], ShouldResemble, radioConf{Enable: true, Freq: 868450000})
+			So(conf.Radios[1], ShouldResemble, radioConf{})
+			So(conf.MultiSF[0], ShouldResemble, chanConf{Enable: true, IF: -350000})
+			So(conf.MultiSF[1], ShouldResemble, chanConf{Enable: true, IF: -150000})
+			So(conf.MultiSF[2], ShouldResemble, chanConf{Enable: true, IF: 50000})
+			So(conf.MultiSF[3], ShouldResemble, chanConf{})
+			So(conf.LoRaStd, ShouldResemble, chanConf{Enable: true, IF: -150000, Bandwidth: 250000, SpreadFactor: 7})
+			So(conf.FSK, ShouldResemble, chanConf{Enable: true, IF: 350000, Bandwidth: 125000, DataRate: 50000})
+		})
+	})
+
+	Convey("Given the US band with the first sub-band", t, func() {
+		b, err := band.GetConfig(band.US_902_928, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+
+		channels, err := getBandChannels(b, []int{0, 1, 2, 3, 4, 5, 6, 7, 64})
+		So(err, ShouldBeNil)
+
+		Convey("Then the channels are divided over both radios", func() {
+			rc, err := newRouterConfig(band.US_902_928, b, channels)
+			So(err, ShouldBeNil)
+			So(rc.Region, ShouldEqual, "US902")
+			So(rc.DRs[4], ShouldEqual, [3]int{8, 500, 0})
+			So(rc.DRs[8], ShouldEqual, [3]int{12, 500, 1})
+
+			conf := rc.SX1301Conf[0]
+			So(conf.Radios[0], ShouldResemble, radioConf{Enable: true, Freq: 902700000})
+			So(conf.Radios[1], ShouldResemble, radioConf{Enable: true, Freq: 903500000})
+			So(conf.LoRaStd, ShouldResemble, chanConf{Enable: true, IF: 300000, Bandwidth: 500000, SpreadFactor: 8})
+			So(conf.MultiSF[7], ShouldResemble, chanConf{Enable: true, Radio: 1, IF: 200000})
+		})
+	})
+
+	Convey("Given the US band with all channels enabled", t, func() {
+		b, err := band.GetConfig(band.US_902_928, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+
+		channels, err := getBandChannels(b, b.GetEnabledUplinkChannels())
+		So(err, ShouldBeNil)
+
+		Convey("Then newRouterConfig returns an error", func() {
+			_, err := newRouterConfig(band.US_902_928, b, channels)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -23,3 +23,8 @@ func (n *NopNetworkControllerClient) HandleRXInfo(ctx context.Context, in *nc.Ha
 func (n *NopNetworkControllerClient) HandleDataUpMACCommand(ctx context.Context, in *nc.HandleDataUpMACCommandRequest, opts ...grpc.CallOption) (*nc.HandleDataUpMACCommandResponse, error) {
 	return &nc.HandleDataUpMACCommandResponse{}, nil
 }
+
+// HandleGatewayStateChange publishes a gateway going online or offline.
+func (n *NopNetworkControllerClient) HandleGatewayStateChange(ctx context.Context, in *nc.HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*nc.HandleGatewayStateChangeResponse, error) {
+	return &nc.HandleGatewayStateChangeResponse{}, nil
+}
@@ -1,13 +1,14 @@
 package gateway
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"sync"
+	"text/template"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -20,35 +21,109 @@ import (
 	"github.com/pkg/errors"
 )
 
-const rxTopic = "gateway/+/rx"
-const statsTopic = "gateway/+/stats"
+// Default topic templates.
+const (
+	DefaultRXTopicTemplate     = "gateway/{{ .MAC }}/rx"
+	DefaultStatsTopicTemplate  = "gateway/{{ .MAC }}/stats"
+	DefaultTXTopicTemplate     = "gateway/{{ .MAC }}/tx"
+	DefaultAckTopicTemplate    = "gateway/{{ .MAC }}/ack"
+	DefaultConfigTopicTemplate = "gateway/{{ .MAC }}/config"
+)
+
 const uplinkLockTTL = time.Millisecond * 500
 const statsLockTTL = time.Millisecond * 500
 
+// Config contains the configuration of the MQTT backend.
+// The topic templates are Go templates in which {{ .MAC }} is replaced by
+// the gateway MAC (or by the + wildcard when subscribing). Empty templates
+// fall back to the default templates.
+type Config struct {
+	Server              string
+	Username            string
+	Password            string
+	CACert              string
+	RXTopicTemplate     string
+	StatsTopicTemplate  string
+	TXTopicTemplate     string
+	AckTopicTemplate    string
+	ConfigTopicTemplate string
+	QOS                 uint8  // QoS used for publishing
+	SubscribeQOS        uint8  // QoS used for subscribing
+	SharedSubscription  string // shared subscription group (optional)
+	Marshaler           string // json (default) or protobuf
+}
+
+// topicTemplateData contains the data used to execute the topic templates.
+type topicTemplateData struct {
+	MAC string
+}
+
 // Backend implements a MQTT pub-sub backend.
 type Backend struct {
 	conn            mqtt.Client
 	rxPacketChan    chan gw.RXPacket
 	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
 	wg              sync.WaitGroup
+
+	qos                 uint8
+	subscribeQOS        uint8
+	marshaler           marshaler
+	rxTopic             string
+	statsTopic          string
+	ackTopic            string
+	txTopicTemplate     *template.Template
+	configTopicTemplate *template.Template
 }
 
 // NewBackend creates a new Backend.
-func NewBackend(server, username, password, cafile string) (backend.Gateway, error) {
+func NewBackend(c Config) (backend.Gateway, error) {
 	b := Backend{
 		rxPacketChan:    make(chan gw.RXPacket),
 		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
+		qos:             c.QOS,
+		subscribeQOS:    c.SubscribeQOS,
+	}
+
+	if c.QOS > 2 {
+		return nil, fmt.Errorf("invalid mqtt qos: %d", c.QOS)
+	}
+	if c.SubscribeQOS > 2 {
+		return nil, fmt.Errorf("invalid mqtt subscribe qos: %d", c.SubscribeQOS)
+	}
+
+	var err error
+	b.marshaler, err = getMarshaler(c.Marshaler)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.txTopicTemplate, err = parseTopicTemplate("tx", c.TXTopicTemplate, DefaultTXTopicTemplate); err != nil {
+		return nil, err
+	}
+	if b.configTopicTemplate, err = parseTopicTemplate("config", c.ConfigTopicTemplate, DefaultConfigTopicTemplate); err != nil {
+		return nil, err
+	}
+	if b.rxTopic, err = getSubscribeTopic("rx", c.RXTopicTemplate, DefaultRXTopicTemplate, c.SharedSubscription); err != nil {
+		return nil, err
+	}
+	if b.statsTopic, err = getSubscribeTopic("stats", c.StatsTopicTemplate, DefaultStatsTopicTemplate, c.SharedSubscription); err != nil {
+		return nil, err
+	}
+	if b.ackTopic, err = getSubscribeTopic("ack", c.AckTopicTemplate, DefaultAckTopicTemplate, c.SharedSubscription); err != nil {
+		return nil, err
 	}
 
 	opts := mqtt.NewClientOptions()
-	opts.AddBroker(server)
-	opts.SetUsername(username)
-	opts.SetPassword(password)
+	opts.AddBroker(c.Server)
+	opts.SetUsername(c.Username)
+	opts.SetPassword(c.Password)
 	opts.SetOnConnectHandler(b.onConnected)
 	opts.SetConnectionLostHandler(b.onConnectionLost)
 
-	if cafile != "" {
-		tlsconfig, err := newTLSConfig(cafile)
+	if c.CACert != "" {
+		tlsconfig, err := newTLSConfig(c.CACert)
 		if err != nil {
 			log.Fatalf("Error with the mqtt CA certificate: %s", err)
 		} else {
@@ -56,7 +131,7 @@ func NewBackend(server, username, password, cafile string) (backend.Gateway, err
 		}
 	}
 
-	log.WithField("server", server).Info("backend/gateway: connecting to mqtt broker")
+	log.WithField("server", c.Server).Info("backend/gateway: connecting to mqtt broker")
 	b.conn = mqtt.NewClient(opts)
 	for {
 		if token := b.conn.Connect(); token.Wait() && token.Error() != nil {
@@ -70,6 +145,50 @@ func NewBackend(server, username, password, cafile string) (backend.Gateway, err
 	return &b, nil
 }
 
+// parseTopicTemplate parses the given topic template, or the default
+// template when empty.
+func parseTopicTemplate(name, tmpl, defaultTmpl string) (*template.Template, error) {
+	if tmpl == "" {
+		tmpl = defaultTmpl
+	}
+
+	t, err := template.New(name).Parse(tmpl)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s topic template error", name)
+	}
+	return t, nil
+}
+
+// getSubscribeTopic returns the topic to subscribe to for the given topic
+// template, using the + wildcard for the gateway MAC. When a shared
+// subscription group is given, the topic is prefixed with
+// $share/<group>/ so that each message is delivered to only one of the
+// subscribed LoRa Server instances.
+func getSubscribeTopic(name, tmpl, defaultTmpl, sharedSubscription string) (string, error) {
+	t, err := parseTopicTemplate(name, tmpl, defaultTmpl)
+	if err != nil {
+		return "", err
+	}
+
+	topic, err := executeTopicTemplate(t, "+")
+	if err != nil {
+		return "", err
+	}
+
+	if sharedSubscription != "" {
+		topic = fmt.Sprintf("$share/%s/%s", sharedSubscription, topic)
+	}
+	return topic, nil
+}
+
+func executeTopicTemplate(t *template.Template, mac string) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, topicTemplateData{MAC: mac}); err != nil {
+		return "", errors.Wrapf(err, "execute %s topic template error", t.Name())
+	}
+	return buf.String(), nil
+}
+
 func newTLSConfig(cafile string) (*tls.Config, error) {
 	// Import trusted certificates from CAfile.pem.
 
@@ -95,18 +214,23 @@ func newTLSConfig(cafile string) (*tls.Config, error) {
 // still packets to send back to the gateway).
 func (b *Backend) Close() error {
 	log.Info("backend/gateway: closing backend")
-	log.WithField("topic", rxTopic).Info("backend/gateway: unsubscribing from rx topic")
-	if token := b.conn.Unsubscribe(rxTopic); token.Wait() && token.Error() != nil {
-		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", rxTopic, token.Error())
+	log.WithField("topic", b.rxTopic).Info("backend/gateway: unsubscribing from rx topic")
+	if token := b.conn.Unsubscribe(b.rxTopic); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", b.rxTopic, token.Error())
+	}
+	log.WithField("topic", b.statsTopic).Info("backend/gateway: unsubscribing from stats topic")
+	if token := b.conn.Unsubscribe(b.statsTopic); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", b.statsTopic, token.Error())
 	}
-	log.WithField("topic", statsTopic).Info("backend/gateway: unsubscribing from stats topic")
-	if token := b.conn.Unsubscribe(statsTopic); token.Wait() && token.Error() != nil {
-		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", statsTopic, token.Error())
+	log.WithField("topic", b.ackTopic).Info("backend/gateway: unsubscribing from ack topic")
+	if token := b.conn.Unsubscribe(b.ackTopic); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("backend/gateway: unsubscribe from %s error: %s", b.ackTopic, token.Error())
 	}
 	log.Info("backend/gateway: handling last messages")
 	b.wg.Wait()
 	close(b.rxPacketChan)
 	close(b.statsPacketChan)
+	close(b.txAckChan)
 	return nil
 }
 
@@ -120,13 +244,18 @@ func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
 	return b.statsPacketChan
 }
 
+// TXAckChan returns the tx acknowledgement channel.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
 // SendTXPacket sends the given TXPacket to the gateway.
 func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
 	phyB, err := txPacket.PHYPayload.MarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "marshal binary error")
 	}
-	bytes, err := json.Marshal(gw.TXPacketBytes{
+	payload, err := b.marshaler.marshalTXPacket(gw.TXPacketBytes{
 		TXInfo:     txPacket.TXInfo,
 		PHYPayload: phyB,
 	})
@@ -134,15 +263,40 @@ func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
 		return fmt.Errorf("backend/gateway: tx packet marshal error: %s", err)
 	}
 
-	topic := fmt.Sprintf("gateway/%s/tx", txPacket.TXInfo.MAC)
+	topic, err := executeTopicTemplate(b.txTopicTemplate, txPacket.TXInfo.MAC.String())
+	if err != nil {
+		return err
+	}
 	log.WithField("topic", topic).Info("backend/gateway: publishing tx packet")
 
-	if token := b.conn.Publish(topic, 0, false, bytes); token.Wait() && token.Error() != nil {
+	if token := b.conn.Publish(topic, b.qos, false, payload); token.Wait() && token.Error() != nil {
 		return fmt.Errorf("backend/gateway: publish tx packet failed: %s", token.Error())
 	}
 	return nil
 }
 
+// SendGatewayConfigPacket sends the given GatewayConfigPacket to the gateway.
+func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
+	payload, err := b.marshaler.marshalConfigPacket(configPacket)
+	if err != nil {
+		return fmt.Errorf("backend/gateway: config packet marshal error: %s", err)
+	}
+
+	topic, err := executeTopicTemplate(b.configTopicTemplate, configPacket.MAC.String())
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{
+		"topic":   topic,
+		"version": configPacket.Version,
+	}).Info("backend/gateway: publishing config packet")
+
+	if token := b.conn.Publish(topic, b.qos, false, payload); token.Wait() && token.Error() != nil {
+		return fmt.Errorf("backend/gateway: publish config packet failed: %s", token.Error())
+	}
+	return nil
+}
+
 func (b *Backend) rxPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.wg.Add(1)
 	defer b.wg.Done()
@@ -150,8 +304,8 @@ func (b *Backend) rxPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	log.Info("backend/gateway: rx packet received")
 
 	var phy lorawan.PHYPayload
-	var rxPacketBytes gw.RXPacketBytes
-	if err := json.Unmarshal(msg.Payload(), &rxPacketBytes); err != nil {
+	rxPacketBytes, err := b.marshaler.unmarshalRXPacket(msg.Payload())
+	if err != nil {
 		log.WithFields(log.Fields{
 			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
 		}).Errorf("backend/gateway: unmarshal rx packet error: %s", err)
@@ -197,8 +351,8 @@ func (b *Backend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.wg.Add(1)
 	defer b.wg.Done()
 
-	var statsPacket gw.GatewayStatsPacket
-	if err := json.Unmarshal(msg.Payload(), &statsPacket); err != nil {
+	statsPacket, err := b.marshaler.unmarshalStatsPacket(msg.Payload())
+	if err != nil {
 		log.WithFields(log.Fields{
 			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
 		}).Errorf("backend/gateway: unmarshal stats packet error: %s", err)
@@ -214,7 +368,7 @@ func (b *Backend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	redisConn := common.RedisPool.Get()
 	defer redisConn.Close()
 
-	_, err := redis.String(redisConn.Do("SET", key, "lock", "PX", int64(statsLockTTL/time.Millisecond), "NX"))
+	_, err = redis.String(redisConn.Do("SET", key, "lock", "PX", int64(statsLockTTL/time.Millisecond), "NX"))
 	if err != nil {
 		if err == redis.ErrNil {
 			// the payload is already being processed by an other instance
@@ -228,22 +382,43 @@ func (b *Backend) statsPacketHandler(c mqtt.Client, msg mqtt.Message) {
 	b.statsPacketChan <- statsPacket
 }
 
+func (b *Backend) txAckHandler(c mqtt.Client, msg mqtt.Message) {
+	b.wg.Add(1)
+	defer b.wg.Done()
+
+	ack, err := b.marshaler.unmarshalTXAck(msg.Payload())
+	if err != nil {
+		log.WithFields(log.Fields{
+			"data_base64": base64.StdEncoding.EncodeToString(msg.Payload()),
+		}).Errorf("backend/gateway: unmarshal tx ack error: %s", err)
+		return
+	}
+
+	if ack.Error != "" {
+		log.WithFields(log.Fields{
+			"mac":   ack.MAC,
+			"error": ack.Error,
+		}).Error("backend/gateway: gateway rejected tx packet")
+	} else {
+		log.WithField("mac", ack.MAC).Debug("backend/gateway: tx packet acknowledged")
+	}
+
+	b.txAckChan <- ack
+}
+
 func (b *Backend) onConnected(c mqtt.Client) {
 	log.Info("backend/gateway: connected to mqtt server")
-	for {
-		log.WithField("topic", rxTopic).Info("backend/gateway: subscribing to rx topic")
-		if token := b.conn.Subscribe(rxTopic, 2, b.rxPacketHandler); token.Wait() && token.Error() != nil {
-			log.WithField("topic", rxTopic).Errorf("backend/gateway: subscribe error: %s", token.Error())
-			time.Sleep(time.Second)
-			continue
-		}
-		break
-	}
+	b.subscribe("rx", b.rxTopic, b.rxPacketHandler)
+	b.subscribe("stats", b.statsTopic, b.statsPacketHandler)
+	b.subscribe("ack", b.ackTopic, b.txAckHandler)
+}
 
+// subscribe subscribes to the given topic, retrying until it succeeds.
+func (b *Backend) subscribe(name, topic string, handler mqtt.MessageHandler) {
 	for {
-		log.WithField("topic", statsTopic).Info("backend/gateway: subscribing to stats topic")
-		if token := b.conn.Subscribe(statsTopic, 2, b.statsPacketHandler); token.Wait() && token.Error() != nil {
-			log.WithField("topic", statsTopic).Errorf("backend/gateway: subscribe error: %s", token.Error())
+		log.WithField("topic", topic).Infof("backend/gateway: subscribing to %s topic", name)
+		if token := b.conn.Subscribe(topic, b.subscribeQOS, handler); token.Wait() && token.Error() != nil {
+			log.WithField("topic", topic).Errorf("backend/gateway: subscribe error: %s", token.Error())
 			time.Sleep(time.Second)
 			continue
 		}
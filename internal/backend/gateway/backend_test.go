@@ -26,7 +26,11 @@ func TestBackend(t *testing.T) {
 
 		Convey("Given a new Backend", func() {
 			test.MustFlushRedis(common.RedisPool)
-			backend, err := NewBackend(conf.Server, conf.Username, conf.Password, "")
+			backend, err := NewBackend(Config{
+				Server:   conf.Server,
+				Username: conf.Username,
+				Password: conf.Password,
+			})
 			So(err, ShouldBeNil)
 			defer backend.Close()
 			time.Sleep(time.Millisecond * 100) // give the backend some time to subscribe to the topic
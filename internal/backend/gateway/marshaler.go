@@ -0,0 +1,278 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Supported payload encodings.
+const (
+	MarshalerJSON     = "json"
+	MarshalerProtobuf = "protobuf"
+)
+
+// marshaler implements the encoding and decoding of the gateway payloads.
+type marshaler interface {
+	marshalTXPacket(gw.TXPacketBytes) ([]byte, error)
+	marshalConfigPacket(gw.GatewayConfigPacket) ([]byte, error)
+	unmarshalRXPacket([]byte) (gw.RXPacketBytes, error)
+	unmarshalStatsPacket([]byte) (gw.GatewayStatsPacket, error)
+	unmarshalTXAck([]byte) (gw.TXAck, error)
+}
+
+// getMarshaler returns the marshaler for the given encoding.
+func getMarshaler(encoding string) (marshaler, error) {
+	switch encoding {
+	case MarshalerJSON, "":
+		return jsonMarshaler{}, nil
+	case MarshalerProtobuf:
+		return protobufMarshaler{}, nil
+	default:
+		return nil, fmt.Errorf("unknown marshaler: %s", encoding)
+	}
+}
+
+// jsonMarshaler implements the JSON encoding.
+type jsonMarshaler struct{}
+
+func (jsonMarshaler) marshalTXPacket(txPacket gw.TXPacketBytes) ([]byte, error) {
+	return json.Marshal(txPacket)
+}
+
+func (jsonMarshaler) marshalConfigPacket(configPacket gw.GatewayConfigPacket) ([]byte, error) {
+	return json.Marshal(configPacket)
+}
+
+func (jsonMarshaler) unmarshalRXPacket(b []byte) (gw.RXPacketBytes, error) {
+	var rxPacket gw.RXPacketBytes
+	err := json.Unmarshal(b, &rxPacket)
+	return rxPacket, err
+}
+
+func (jsonMarshaler) unmarshalStatsPacket(b []byte) (gw.GatewayStatsPacket, error) {
+	var statsPacket gw.GatewayStatsPacket
+	err := json.Unmarshal(b, &statsPacket)
+	return statsPacket, err
+}
+
+func (jsonMarshaler) unmarshalTXAck(b []byte) (gw.TXAck, error) {
+	var ack gw.TXAck
+	err := json.Unmarshal(b, &ack)
+	return ack, err
+}
+
+// protobufMarshaler implements the (compact) protobuf encoding.
+// Note that the custom data of the gateway stats is not supported.
+type protobufMarshaler struct{}
+
+func (protobufMarshaler) marshalTXPacket(txPacket gw.TXPacketBytes) ([]byte, error) {
+	txInfo := txPacket.TXInfo
+	frame := gw.DownlinkFrame{
+		TxInfo: &gw.DownlinkTXInfo{
+			Mac:         txInfo.MAC[:],
+			Immediately: txInfo.Immediately,
+			Timestamp:   txInfo.Timestamp,
+			Frequency:   uint32(txInfo.Frequency),
+			Power:       int32(txInfo.Power),
+			CodeRate:    txInfo.CodeRate,
+		},
+		PhyPayload: txPacket.PHYPayload,
+	}
+
+	dr, err := dataRateToProto(txInfo.DataRate)
+	if err != nil {
+		return nil, err
+	}
+	frame.TxInfo.DataRate = dr
+
+	if txInfo.IPol != nil {
+		if *txInfo.IPol {
+			frame.TxInfo.Polarization = gw.Polarization_INVERTED
+		} else {
+			frame.TxInfo.Polarization = gw.Polarization_NON_INVERTED
+		}
+	}
+
+	return proto.Marshal(&frame)
+}
+
+func (protobufMarshaler) marshalConfigPacket(configPacket gw.GatewayConfigPacket) ([]byte, error) {
+	conf := gw.GatewayConfiguration{
+		Mac:     configPacket.MAC[:],
+		Version: configPacket.Version,
+	}
+
+	for _, c := range configPacket.Channels {
+		channel := gw.Channel{
+			Frequency: int32(c.Frequency),
+			Bandwidth: int32(c.Bandwidth),
+			BitRate:   int32(c.BitRate),
+		}
+
+		switch c.Modulation {
+		case band.LoRaModulation:
+			channel.Modulation = gw.Modulation_LORA
+		case band.FSKModulation:
+			channel.Modulation = gw.Modulation_FSK
+		default:
+			return nil, fmt.Errorf("unknown modulation: %s", c.Modulation)
+		}
+
+		for _, sf := range c.SpreadFactors {
+			channel.SpreadFactors = append(channel.SpreadFactors, int32(sf))
+		}
+
+		conf.Channels = append(conf.Channels, &channel)
+	}
+
+	return proto.Marshal(&conf)
+}
+
+func (protobufMarshaler) unmarshalRXPacket(b []byte) (gw.RXPacketBytes, error) {
+	var frame gw.UplinkFrame
+	if err := proto.Unmarshal(b, &frame); err != nil {
+		return gw.RXPacketBytes{}, err
+	}
+
+	rxInfo := frame.GetRxInfo()
+	if rxInfo == nil {
+		return gw.RXPacketBytes{}, errors.New("rxInfo must not be nil")
+	}
+
+	rxPacket := gw.RXPacketBytes{
+		RXInfo: gw.RXInfo{
+			Timestamp: rxInfo.Timestamp,
+			Frequency: int(rxInfo.Frequency),
+			Channel:   int(rxInfo.Channel),
+			RFChain:   int(rxInfo.RfChain),
+			CRCStatus: int(rxInfo.CrcStatus),
+			CodeRate:  rxInfo.CodeRate,
+			RSSI:      int(rxInfo.Rssi),
+			LoRaSNR:   rxInfo.LoRaSNR,
+			Size:      int(rxInfo.Size),
+			DataRate:  dataRateFromProto(rxInfo.DataRate),
+			Board:     int(rxInfo.Board),
+			Antenna:   int(rxInfo.Antenna),
+		},
+		PHYPayload: frame.PhyPayload,
+	}
+	copy(rxPacket.RXInfo.MAC[:], rxInfo.Mac)
+
+	if rxInfo.SignalRSSI != 0 {
+		signalRSSI := int(rxInfo.SignalRSSI)
+		rxPacket.RXInfo.SignalRSSI = &signalRSSI
+	}
+
+	switch rxInfo.FineTimestampType {
+	case gw.FineTimestampType_PLAIN:
+		ft := time.Duration(rxInfo.FineTimestamp)
+		rxPacket.RXInfo.FineTimestampType = gw.FineTimestampPlain
+		rxPacket.RXInfo.FineTimestamp = &ft
+	case gw.FineTimestampType_ENCRYPTED:
+		rxPacket.RXInfo.FineTimestampType = gw.FineTimestampEncrypted
+		rxPacket.RXInfo.EncryptedFineTimestamp = rxInfo.EncryptedFineTimestamp
+		rxPacket.RXInfo.FineTimestampKeyID = int(rxInfo.FineTimestampKeyID)
+	}
+
+	if rxInfo.Time != "" {
+		t, err := time.Parse(time.RFC3339Nano, rxInfo.Time)
+		if err != nil {
+			return rxPacket, errors.Wrap(err, "parse time error")
+		}
+		rxPacket.RXInfo.Time = t
+	}
+
+	return rxPacket, nil
+}
+
+func (protobufMarshaler) unmarshalStatsPacket(b []byte) (gw.GatewayStatsPacket, error) {
+	var stats gw.GatewayStats
+	if err := proto.Unmarshal(b, &stats); err != nil {
+		return gw.GatewayStatsPacket{}, err
+	}
+
+	statsPacket := gw.GatewayStatsPacket{
+		RXPacketsReceived:   int(stats.RxPacketsReceived),
+		RXPacketsReceivedOK: int(stats.RxPacketsReceivedOK),
+		TXPacketsReceived:   int(stats.TxPacketsReceived),
+		TXPacketsEmitted:    int(stats.TxPacketsEmitted),
+		ConfigVersion:       stats.ConfigVersion,
+	}
+	copy(statsPacket.MAC[:], stats.Mac)
+
+	if loc := stats.GetLocation(); loc != nil {
+		statsPacket.Latitude = &loc.Latitude
+		statsPacket.Longitude = &loc.Longitude
+		statsPacket.Altitude = &loc.Altitude
+	}
+
+	if stats.Time != "" {
+		t, err := time.Parse(time.RFC3339Nano, stats.Time)
+		if err != nil {
+			return statsPacket, errors.Wrap(err, "parse time error")
+		}
+		statsPacket.Time = t
+	}
+
+	return statsPacket, nil
+}
+
+func (protobufMarshaler) unmarshalTXAck(b []byte) (gw.TXAck, error) {
+	var pbAck gw.DownlinkTXAck
+	if err := proto.Unmarshal(b, &pbAck); err != nil {
+		return gw.TXAck{}, err
+	}
+
+	ack := gw.TXAck{
+		Error: pbAck.Error,
+	}
+	copy(ack.MAC[:], pbAck.Mac)
+	return ack, nil
+}
+
+func dataRateToProto(dr band.DataRate) (*gw.DataRate, error) {
+	out := gw.DataRate{
+		SpreadFactor: uint32(dr.SpreadFactor),
+		Bandwidth:    uint32(dr.Bandwidth),
+		BitRate:      uint32(dr.BitRate),
+	}
+
+	switch dr.Modulation {
+	case band.LoRaModulation:
+		out.Modulation = gw.Modulation_LORA
+	case band.FSKModulation:
+		out.Modulation = gw.Modulation_FSK
+	default:
+		return nil, fmt.Errorf("unknown modulation: %s", dr.Modulation)
+	}
+
+	return &out, nil
+}
+
+func dataRateFromProto(dr *gw.DataRate) band.DataRate {
+	if dr == nil {
+		return band.DataRate{}
+	}
+
+	out := band.DataRate{
+		SpreadFactor: int(dr.SpreadFactor),
+		Bandwidth:    int(dr.Bandwidth),
+		BitRate:      int(dr.BitRate),
+	}
+
+	switch dr.Modulation {
+	case gw.Modulation_LORA:
+		out.Modulation = band.LoRaModulation
+	case gw.Modulation_FSK:
+		out.Modulation = band.FSKModulation
+	}
+
+	return out
+}
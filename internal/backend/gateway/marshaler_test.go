@@ -0,0 +1,233 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestProtobufMarshaler(t *testing.T) {
+	Convey("Given the protobuf marshaler", t, func() {
+		m, err := getMarshaler(MarshalerProtobuf)
+		So(err, ShouldBeNil)
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then a TXPacket is marshaled as DownlinkFrame", func() {
+			iPol := false
+			b, err := m.marshalTXPacket(gw.TXPacketBytes{
+				TXInfo: gw.TXInfo{
+					MAC:       mac,
+					Timestamp: 12345,
+					Frequency: 868100000,
+					Power:     14,
+					DataRate: band.DataRate{
+						Modulation:   band.LoRaModulation,
+						SpreadFactor: 12,
+						Bandwidth:    125,
+					},
+					CodeRate: "4/5",
+					IPol:     &iPol,
+				},
+				PHYPayload: []byte{1, 2, 3},
+			})
+			So(err, ShouldBeNil)
+
+			var frame gw.DownlinkFrame
+			So(proto.Unmarshal(b, &frame), ShouldBeNil)
+			So(frame, ShouldResemble, gw.DownlinkFrame{
+				TxInfo: &gw.DownlinkTXInfo{
+					Mac:       mac[:],
+					Timestamp: 12345,
+					Frequency: 868100000,
+					Power:     14,
+					DataRate: &gw.DataRate{
+						Modulation:   gw.Modulation_LORA,
+						SpreadFactor: 12,
+						Bandwidth:    125,
+					},
+					CodeRate:     "4/5",
+					Polarization: gw.Polarization_NON_INVERTED,
+				},
+				PhyPayload: []byte{1, 2, 3},
+			})
+		})
+
+		Convey("Then an UplinkFrame is unmarshaled as RXPacketBytes", func() {
+			b, err := proto.Marshal(&gw.UplinkFrame{
+				RxInfo: &gw.UplinkRXInfo{
+					Mac:       mac[:],
+					Time:      "2018-01-02T03:04:05.123456Z",
+					Timestamp: 12345,
+					Frequency: 868100000,
+					Channel:   2,
+					CrcStatus: 1,
+					CodeRate:  "4/5",
+					Rssi:      -60,
+					LoRaSNR:   5.5,
+					Size:      3,
+					DataRate: &gw.DataRate{
+						Modulation: gw.Modulation_FSK,
+						BitRate:    50000,
+					},
+					Board:             1,
+					Antenna:           2,
+					FineTimestampType: gw.FineTimestampType_PLAIN,
+					FineTimestamp:     123456789,
+					SignalRSSI:        -65,
+				},
+				PhyPayload: []byte{1, 2, 3},
+			})
+			So(err, ShouldBeNil)
+
+			rxPacket, err := m.unmarshalRXPacket(b)
+			So(err, ShouldBeNil)
+			signalRSSI := -65
+			fineTimestamp := 123456789 * time.Nanosecond
+			So(rxPacket, ShouldResemble, gw.RXPacketBytes{
+				RXInfo: gw.RXInfo{
+					MAC:       mac,
+					Time:      time.Date(2018, 1, 2, 3, 4, 5, 123456000, time.UTC),
+					Timestamp: 12345,
+					Frequency: 868100000,
+					Channel:   2,
+					CRCStatus: 1,
+					CodeRate:  "4/5",
+					RSSI:      -60,
+					LoRaSNR:   5.5,
+					Size:      3,
+					DataRate: band.DataRate{
+						Modulation: band.FSKModulation,
+						BitRate:    50000,
+					},
+					SignalRSSI:        &signalRSSI,
+					Board:             1,
+					Antenna:           2,
+					FineTimestampType: gw.FineTimestampPlain,
+					FineTimestamp:     &fineTimestamp,
+				},
+				PHYPayload: []byte{1, 2, 3},
+			})
+		})
+
+		Convey("Then GatewayStats are unmarshaled as GatewayStatsPacket", func() {
+			b, err := proto.Marshal(&gw.GatewayStats{
+				Mac: mac[:],
+				Location: &gw.Location{
+					Latitude:  1.123,
+					Longitude: 2.123,
+					Altitude:  3.123,
+				},
+				RxPacketsReceived:   10,
+				RxPacketsReceivedOK: 9,
+				TxPacketsReceived:   8,
+				TxPacketsEmitted:    7,
+				ConfigVersion:       "1-2",
+			})
+			So(err, ShouldBeNil)
+
+			statsPacket, err := m.unmarshalStatsPacket(b)
+			So(err, ShouldBeNil)
+
+			lat := 1.123
+			long := 2.123
+			alt := 3.123
+			So(statsPacket, ShouldResemble, gw.GatewayStatsPacket{
+				MAC:                 mac,
+				Latitude:            &lat,
+				Longitude:           &long,
+				Altitude:            &alt,
+				RXPacketsReceived:   10,
+				RXPacketsReceivedOK: 9,
+				TXPacketsReceived:   8,
+				TXPacketsEmitted:    7,
+				ConfigVersion:       "1-2",
+			})
+		})
+
+		Convey("Then a GatewayConfigPacket is marshaled as GatewayConfiguration", func() {
+			b, err := m.marshalConfigPacket(gw.GatewayConfigPacket{
+				MAC:     mac,
+				Version: "1-2",
+				Channels: []gw.ConfigChannel{
+					{
+						Modulation:    band.LoRaModulation,
+						Frequency:     868100000,
+						Bandwidth:     125,
+						SpreadFactors: []int{7, 8, 9, 10, 11, 12},
+					},
+					{
+						Modulation: band.FSKModulation,
+						Frequency:  868800000,
+						Bandwidth:  125,
+						BitRate:    50000,
+					},
+				},
+			})
+			So(err, ShouldBeNil)
+
+			var conf gw.GatewayConfiguration
+			So(proto.Unmarshal(b, &conf), ShouldBeNil)
+			So(conf, ShouldResemble, gw.GatewayConfiguration{
+				Mac:     mac[:],
+				Version: "1-2",
+				Channels: []*gw.Channel{
+					{
+						Modulation:    gw.Modulation_LORA,
+						Frequency:     868100000,
+						Bandwidth:     125,
+						SpreadFactors: []int32{7, 8, 9, 10, 11, 12},
+					},
+					{
+						Modulation: gw.Modulation_FSK,
+						Frequency:  868800000,
+						Bandwidth:  125,
+						BitRate:    50000,
+					},
+				},
+			})
+		})
+
+		Convey("Then a DownlinkTXAck is unmarshaled as TXAck", func() {
+			b, err := proto.Marshal(&gw.DownlinkTXAck{
+				Mac:   mac[:],
+				Error: "TOO_LATE",
+			})
+			So(err, ShouldBeNil)
+
+			ack, err := m.unmarshalTXAck(b)
+			So(err, ShouldBeNil)
+			So(ack, ShouldResemble, gw.TXAck{MAC: mac, Error: "TOO_LATE"})
+		})
+	})
+}
+
+func TestGetSubscribeTopic(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Template           string
+			SharedSubscription string
+			Expected           string
+		}{
+			{"", "", "gateway/+/rx"},
+			{"", "loraserver", "$share/loraserver/gateway/+/rx"},
+			{"eu868/gateway/{{ .MAC }}/up", "", "eu868/gateway/+/up"},
+		}
+
+		for _, test := range tests {
+			topic, err := getSubscribeTopic("rx", test.Template, DefaultRXTopicTemplate, test.SharedSubscription)
+			So(err, ShouldBeNil)
+			So(topic, ShouldEqual, test.Expected)
+		}
+
+		Convey("Then an invalid template returns an error", func() {
+			_, err := getSubscribeTopic("rx", "gateway/{{ .MAC }", DefaultRXTopicTemplate, "")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
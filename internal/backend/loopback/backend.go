@@ -0,0 +1,157 @@
+// Package loopback implements an in-process gateway backend. Instead of
+// communicating with real gateways, the gateway side of the backend is
+// exposed through SendRXPacket, SendStatsPacket, TXPacketChan and
+// GatewayConfigPacketChan, so that virtual gateways (e.g. the simulator) can
+// be connected to LoRa Server without a MQTT broker or radio hardware.
+package loopback
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+)
+
+// txPacketBufferSize defines the number of downlink packets that are
+// buffered for the gateway side.
+const txPacketBufferSize = 100
+
+// errors
+var (
+	ErrBackendClosed    = errors.New("backend is closed")
+	ErrTXBufferFull     = errors.New("tx packet buffer is full")
+	ErrConfigBufferFull = errors.New("config packet buffer is full")
+)
+
+// Backend implements an in-process loopback backend.
+type Backend struct {
+	sync.RWMutex
+
+	rxPacketChan     chan gw.RXPacket
+	statsPacketChan  chan gw.GatewayStatsPacket
+	txAckChan        chan gw.TXAck
+	txPacketChan     chan gw.TXPacket
+	configPacketChan chan gw.GatewayConfigPacket
+	closed           bool
+}
+
+// NewBackend creates a new Backend.
+func NewBackend() *Backend {
+	return &Backend{
+		rxPacketChan:     make(chan gw.RXPacket),
+		statsPacketChan:  make(chan gw.GatewayStatsPacket),
+		txAckChan:        make(chan gw.TXAck),
+		txPacketChan:     make(chan gw.TXPacket, txPacketBufferSize),
+		configPacketChan: make(chan gw.GatewayConfigPacket, txPacketBufferSize),
+	}
+}
+
+// SendTXPacket sends the given packet to the gateway side of the backend.
+// An error is returned when the downlink buffer is full, as the network
+// server must never be blocked by a slow (or absent) consumer.
+func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
+	select {
+	case b.txPacketChan <- txPacket:
+		return nil
+	default:
+		return ErrTXBufferFull
+	}
+}
+
+// SendGatewayConfigPacket sends the given configuration to the gateway
+// side of the backend. Like SendTXPacket, it never blocks.
+func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
+	select {
+	case b.configPacketChan <- configPacket:
+		return nil
+	default:
+		return ErrConfigBufferFull
+	}
+}
+
+// RXPacketChan returns the channel containing the received packets.
+func (b *Backend) RXPacketChan() chan gw.RXPacket {
+	return b.rxPacketChan
+}
+
+// StatsPacketChan returns the channel containing the received gateway stats.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
+	return b.statsPacketChan
+}
+
+// TXAckChan returns the channel containing the received tx
+// acknowledgements.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// Close closes the backend.
+func (b *Backend) Close() error {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.closed {
+		return nil
+	}
+
+	b.closed = true
+	close(b.rxPacketChan)
+	close(b.statsPacketChan)
+	close(b.txAckChan)
+	return nil
+}
+
+// SendRXPacket sends the given packet to LoRa Server, as if it was received
+// by a gateway. This blocks until the packet has been consumed.
+func (b *Backend) SendRXPacket(rxPacket gw.RXPacket) error {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.closed {
+		return ErrBackendClosed
+	}
+
+	b.rxPacketChan <- rxPacket
+	return nil
+}
+
+// SendStatsPacket sends the given gateway stats to LoRa Server, as if they
+// were sent by a gateway. This blocks until the stats have been consumed.
+func (b *Backend) SendStatsPacket(statsPacket gw.GatewayStatsPacket) error {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.closed {
+		return ErrBackendClosed
+	}
+
+	b.statsPacketChan <- statsPacket
+	return nil
+}
+
+// SendTXAck sends the given tx acknowledgement to LoRa Server, as if it was
+// sent by a gateway. This blocks until the acknowledgement has been consumed.
+func (b *Backend) SendTXAck(ack gw.TXAck) error {
+	b.RLock()
+	defer b.RUnlock()
+
+	if b.closed {
+		return ErrBackendClosed
+	}
+
+	b.txAckChan <- ack
+	return nil
+}
+
+// TXPacketChan returns the channel containing the packets sent by
+// LoRa Server to the gateways.
+func (b *Backend) TXPacketChan() chan gw.TXPacket {
+	return b.txPacketChan
+}
+
+// GatewayConfigPacketChan returns the channel containing the configurations
+// sent by LoRa Server to the gateways.
+func (b *Backend) GatewayConfigPacketChan() chan gw.GatewayConfigPacket {
+	return b.configPacketChan
+}
@@ -0,0 +1,55 @@
+package loopback
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+func TestBackend(t *testing.T) {
+	Convey("Given a new Backend", t, func() {
+		backend := NewBackend()
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then a packet sent by the gateway side is received by LoRa Server", func() {
+			rxPacket := gw.RXPacket{RXInfo: gw.RXInfo{MAC: mac, Frequency: 868100000}}
+			go backend.SendRXPacket(rxPacket)
+			So(<-backend.RXPacketChan(), ShouldResemble, rxPacket)
+		})
+
+		Convey("Then gateway stats sent by the gateway side are received by LoRa Server", func() {
+			statsPacket := gw.GatewayStatsPacket{MAC: mac, RXPacketsReceived: 3}
+			go backend.SendStatsPacket(statsPacket)
+			So(<-backend.StatsPacketChan(), ShouldResemble, statsPacket)
+		})
+
+		Convey("Then a packet sent by LoRa Server is received by the gateway side", func() {
+			txPacket := gw.TXPacket{TXInfo: gw.TXInfo{MAC: mac, Immediately: true}}
+			So(backend.SendTXPacket(txPacket), ShouldBeNil)
+			So(<-backend.TXPacketChan(), ShouldResemble, txPacket)
+		})
+
+		Convey("Then SendTXPacket returns an error when the buffer is full", func() {
+			for i := 0; i < txPacketBufferSize; i++ {
+				So(backend.SendTXPacket(gw.TXPacket{}), ShouldBeNil)
+			}
+			So(backend.SendTXPacket(gw.TXPacket{}), ShouldEqual, ErrTXBufferFull)
+		})
+
+		Convey("When closing the backend", func() {
+			So(backend.Close(), ShouldBeNil)
+
+			Convey("Then the channels are closed and sending returns an error", func() {
+				_, ok := <-backend.RXPacketChan()
+				So(ok, ShouldBeFalse)
+				_, ok = <-backend.StatsPacketChan()
+				So(ok, ShouldBeFalse)
+				So(backend.SendRXPacket(gw.RXPacket{}), ShouldEqual, ErrBackendClosed)
+				So(backend.SendStatsPacket(gw.GatewayStatsPacket{}), ShouldEqual, ErrBackendClosed)
+			})
+		})
+	})
+}
@@ -0,0 +1,338 @@
+// Package semtech implements a gateway backend using the Semtech UDP
+// packet-forwarder protocol (PUSH_DATA, PULL_DATA, PULL_RESP and TX_ACK).
+// This makes it possible to connect the gateways directly to LoRa Server,
+// without running a separate gateway bridge.
+package semtech
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/lorawan"
+)
+
+// gatewayTimeout defines the duration after which a gateway is considered
+// disconnected when no PULL_DATA has been received (the packet-forwarder
+// sends a PULL_DATA every keepalive interval, by default 10 seconds).
+const gatewayTimeout = time.Minute
+
+// maxPacketSize defines the max size of an UDP packet.
+const maxPacketSize = 65507
+
+// errors
+var (
+	ErrGatewayDoesNotExist = errors.New("gateway does not exist or is not connected")
+)
+
+// gateway contains the address of the gateway to which the downlink
+// packets must be sent.
+type gateway struct {
+	addr            *net.UDPAddr
+	protocolVersion uint8
+	lastSeen        time.Time
+}
+
+// Backend implements a Semtech UDP packet-forwarder backend.
+type Backend struct {
+	sync.RWMutex
+
+	conn            *net.UDPConn
+	rxPacketChan    chan gw.RXPacket
+	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+	gateways        map[lorawan.EUI64]gateway
+	closed          bool
+	wg              sync.WaitGroup
+}
+
+// NewBackend creates a new Backend listening on the given bind address
+// (ip:port).
+func NewBackend(bind string) (backend.Gateway, error) {
+	addr, err := net.ResolveUDPAddr("udp", bind)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve udp addr error")
+	}
+
+	log.WithField("bind", bind).Info("backend/semtech: starting udp listener")
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "listen udp error")
+	}
+
+	b := Backend{
+		conn:            conn,
+		rxPacketChan:    make(chan gw.RXPacket),
+		statsPacketChan: make(chan gw.GatewayStatsPacket),
+		txAckChan:       make(chan gw.TXAck),
+		gateways:        make(map[lorawan.EUI64]gateway),
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.readPackets()
+	}()
+
+	return &b, nil
+}
+
+// Close closes the backend.
+// Note that this closes the backend one-way (gateway to backend), the
+// UDP socket can still be used for sending the remaining downlink packets.
+func (b *Backend) Close() error {
+	log.Info("backend/semtech: closing backend")
+
+	b.Lock()
+	b.closed = true
+	b.Unlock()
+
+	// unblock the reader
+	if err := b.conn.SetReadDeadline(time.Now()); err != nil {
+		return errors.Wrap(err, "set read deadline error")
+	}
+
+	log.Info("backend/semtech: handling last packets")
+	b.wg.Wait()
+	close(b.rxPacketChan)
+	close(b.statsPacketChan)
+	close(b.txAckChan)
+	return nil
+}
+
+// RXPacketChan returns the RXPacket channel.
+func (b *Backend) RXPacketChan() chan gw.RXPacket {
+	return b.rxPacketChan
+}
+
+// StatsPacketChan returns the gateway stats channel.
+func (b *Backend) StatsPacketChan() chan gw.GatewayStatsPacket {
+	return b.statsPacketChan
+}
+
+// TXAckChan returns the tx acknowledgement channel (TX_ACK).
+// Note that only packet-forwarders implementing protocol v2 send a TX_ACK.
+func (b *Backend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
+// SendTXPacket sends the given TXPacket to the gateway (PULL_RESP).
+func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
+	b.RLock()
+	gwConn, ok := b.gateways[txPacket.TXInfo.MAC]
+	b.RUnlock()
+	if !ok || time.Since(gwConn.lastSeen) > gatewayTimeout {
+		return ErrGatewayDoesNotExist
+	}
+
+	tx, err := newTXPKFromTXPacket(txPacket)
+	if err != nil {
+		return errors.Wrap(err, "get txpk error")
+	}
+
+	pl, err := json.Marshal(pullRespPayload{TXPK: tx})
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	// protocol version 1 uses an unused (zero) token
+	header := []byte{gwConn.protocolVersion, 0x00, 0x00, byte(pullResp)}
+	if gwConn.protocolVersion == protocolVersion2 {
+		if _, err := rand.Read(header[1:3]); err != nil {
+			return errors.Wrap(err, "read random bytes error")
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"mac":  txPacket.TXInfo.MAC,
+		"addr": gwConn.addr,
+	}).Info("backend/semtech: sending PULL_RESP packet")
+
+	if _, err := b.conn.WriteToUDP(append(header, pl...), gwConn.addr); err != nil {
+		return errors.Wrap(err, "write udp error")
+	}
+	return nil
+}
+
+// SendGatewayConfigPacket is not supported by the packet-forwarder, as its
+// channel configuration can only be changed locally.
+func (b *Backend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
+	return backend.ErrNotSupported
+}
+
+func (b *Backend) readPackets() {
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			b.RLock()
+			closed := b.closed
+			b.RUnlock()
+			if closed {
+				return
+			}
+
+			log.Errorf("backend/semtech: read udp error: %s", err)
+			continue
+		}
+
+		data := make([]byte, n)
+		copy(data, buf[:n])
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			if err := b.handlePacket(addr, data); err != nil {
+				log.WithField("addr", addr).Errorf("backend/semtech: handle packet error: %s", err)
+			}
+		}()
+	}
+}
+
+func (b *Backend) handlePacket(addr *net.UDPAddr, data []byte) error {
+	pt, err := getPacketType(data)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"addr": addr,
+		"type": pt,
+	}).Debug("backend/semtech: packet received")
+
+	switch pt {
+	case pushData:
+		return b.handlePushData(addr, data)
+	case pullData:
+		return b.handlePullData(addr, data)
+	case txACK:
+		return b.handleTXACK(addr, data)
+	default:
+		return errors.Wrapf(ErrInvalidPacket, "unexpected packet type %s", pt)
+	}
+}
+
+func (b *Backend) handlePushData(addr *net.UDPAddr, data []byte) error {
+	mac, err := getGatewayMAC(data)
+	if err != nil {
+		return err
+	}
+
+	// acknowledge the packet
+	if _, err := b.conn.WriteToUDP([]byte{data[0], data[1], data[2], byte(pushACK)}, addr); err != nil {
+		return errors.Wrap(err, "write udp error")
+	}
+
+	var pl pushDataPayload
+	if err := json.Unmarshal(data[headerSize:], &pl); err != nil {
+		return errors.Wrap(err, "unmarshal json error")
+	}
+
+	if pl.Stat != nil {
+		statsPacket, err := newGatewayStatsPacketFromStat(mac, *pl.Stat)
+		if err != nil {
+			log.WithField("mac", mac).Errorf("backend/semtech: get stats packet error: %s", err)
+		} else {
+			log.WithField("mac", mac).Info("backend/semtech: gateway stats packet received")
+			b.statsPacketChan <- statsPacket
+		}
+	}
+
+	for _, rx := range pl.RXPK {
+		// packets without CRC can't be handled, packets with an invalid CRC
+		// are only used for the gateway statistics
+		if rx.Stat == gw.CRCStatusNoCRC {
+			log.WithFields(log.Fields{
+				"mac":  mac,
+				"stat": rx.Stat,
+			}).Debug("backend/semtech: ignoring packet without crc")
+			continue
+		}
+
+		rxPackets, err := newRXPacketsFromRXPK(mac, rx)
+		if err != nil {
+			log.WithField("mac", mac).Errorf("backend/semtech: get rx packets error: %s", err)
+			continue
+		}
+
+		for _, rxPacket := range rxPackets {
+			log.WithFields(log.Fields{
+				"mac":     mac,
+				"antenna": rxPacket.RXInfo.Antenna,
+			}).Info("backend/semtech: rx packet received")
+			b.rxPacketChan <- rxPacket
+		}
+	}
+
+	return nil
+}
+
+func (b *Backend) handlePullData(addr *net.UDPAddr, data []byte) error {
+	mac, err := getGatewayMAC(data)
+	if err != nil {
+		return err
+	}
+
+	b.Lock()
+	if gwConn, ok := b.gateways[mac]; !ok || gwConn.addr.String() != addr.String() {
+		log.WithFields(log.Fields{
+			"mac":  mac,
+			"addr": addr,
+		}).Info("backend/semtech: gateway connected")
+	}
+	b.gateways[mac] = gateway{
+		addr:            addr,
+		protocolVersion: data[0],
+		lastSeen:        time.Now(),
+	}
+	b.Unlock()
+
+	// acknowledge the packet
+	if _, err := b.conn.WriteToUDP([]byte{data[0], data[1], data[2], byte(pullACK)}, addr); err != nil {
+		return errors.Wrap(err, "write udp error")
+	}
+
+	return nil
+}
+
+func (b *Backend) handleTXACK(addr *net.UDPAddr, data []byte) error {
+	mac, err := getGatewayMAC(data)
+	if err != nil {
+		return err
+	}
+
+	ack := gw.TXAck{
+		MAC: mac,
+	}
+
+	// the payload is optional and only sent in case of an error
+	if len(data) > headerSize {
+		var pl txACKPayload
+		if err := json.Unmarshal(data[headerSize:], &pl); err != nil {
+			return errors.Wrap(err, "unmarshal json error")
+		}
+
+		if pl.TXPKACK.Error != "" && pl.TXPKACK.Error != "NONE" {
+			ack.Error = pl.TXPKACK.Error
+		}
+	}
+
+	if ack.Error != "" {
+		log.WithFields(log.Fields{
+			"mac":   mac,
+			"error": ack.Error,
+		}).Error("backend/semtech: gateway rejected tx packet")
+	} else {
+		log.WithField("mac", mac).Debug("backend/semtech: tx packet acknowledged")
+	}
+
+	b.txAckChan <- ack
+	return nil
+}
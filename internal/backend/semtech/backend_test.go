@@ -0,0 +1,151 @@
+package semtech
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestBackend(t *testing.T) {
+	Convey("Given a new Backend and a packet-forwarder UDP connection", t, func() {
+		backend, err := NewBackend("127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer backend.Close()
+
+		serverAddr := backend.(*Backend).conn.LocalAddr().(*net.UDPAddr)
+		gwConn, err := net.DialUDP("udp", nil, serverAddr)
+		So(err, ShouldBeNil)
+		defer gwConn.Close()
+		So(gwConn.SetDeadline(time.Now().Add(time.Second)), ShouldBeNil)
+
+		mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		buf := make([]byte, maxPacketSize)
+
+		Convey("When sending a PUSH_DATA packet containing a rxpk and stat", func() {
+			pl := []byte(`{
+				"rxpk": [{"time": "2013-03-31T16:21:17.528002Z", "tmst": 3512348611, "chan": 2, "rfch": 0, "freq": 868.3, "stat": 1, "modu": "LORA", "datr": "SF7BW125", "codr": "4/6", "rssi": -35, "lsnr": 5.1, "size": 14, "data": "QAQDAgGAAQABqgECAwQ"}],
+				"stat": {"time": "2014-01-12 08:59:28 GMT", "lati": 46.24000, "long": 3.25230, "alti": 145, "rxnb": 2, "rxok": 2, "rxfw": 2, "ackr": 100.0, "dwnb": 2, "txnb": 1}
+			}`)
+			_, err := gwConn.Write(append([]byte{protocolVersion2, 0x12, 0x34, byte(pushData), 1, 2, 3, 4, 5, 6, 7, 8}, pl...))
+			So(err, ShouldBeNil)
+
+			Convey("Then a PUSH_ACK is returned and the stats and rx packets are received", func() {
+				n, err := gwConn.Read(buf)
+				So(err, ShouldBeNil)
+				So(buf[:n], ShouldResemble, []byte{protocolVersion2, 0x12, 0x34, byte(pushACK)})
+
+				statsPacket := <-backend.StatsPacketChan()
+				lat := 46.24
+				long := 3.2523
+				alt := 145.0
+				So(statsPacket, ShouldResemble, gw.GatewayStatsPacket{
+					MAC:                 mac,
+					Time:                time.Date(2014, 1, 12, 8, 59, 28, 0, time.UTC),
+					Latitude:            &lat,
+					Longitude:           &long,
+					Altitude:            &alt,
+					RXPacketsReceived:   2,
+					RXPacketsReceivedOK: 2,
+					TXPacketsReceived:   2,
+					TXPacketsEmitted:    1,
+				})
+
+				rxPacket := <-backend.RXPacketChan()
+				So(rxPacket.RXInfo, ShouldResemble, gw.RXInfo{
+					MAC:       mac,
+					Time:      time.Date(2013, 3, 31, 16, 21, 17, 528002000, time.UTC),
+					Timestamp: 3512348611,
+					Frequency: 868300000,
+					Channel:   2,
+					CRCStatus: 1,
+					CodeRate:  "4/6",
+					RSSI:      -35,
+					LoRaSNR:   5.1,
+					Size:      14,
+					DataRate: band.DataRate{
+						Modulation:   band.LoRaModulation,
+						SpreadFactor: 7,
+						Bandwidth:    125,
+					},
+				})
+				So(rxPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.UnconfirmedDataUp)
+			})
+		})
+
+		Convey("When sending a TXPacket before the gateway sent a PULL_DATA", func() {
+			err := backend.SendTXPacket(gw.TXPacket{TXInfo: gw.TXInfo{MAC: mac}})
+
+			Convey("Then ErrGatewayDoesNotExist is returned", func() {
+				So(err, ShouldEqual, ErrGatewayDoesNotExist)
+			})
+		})
+
+		Convey("When sending a PULL_DATA packet", func() {
+			_, err := gwConn.Write([]byte{protocolVersion2, 0x56, 0x78, byte(pullData), 1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+
+			Convey("Then a PULL_ACK is returned", func() {
+				n, err := gwConn.Read(buf)
+				So(err, ShouldBeNil)
+				So(buf[:n], ShouldResemble, []byte{protocolVersion2, 0x56, 0x78, byte(pullACK)})
+
+				Convey("When sending a TXPacket", func() {
+					txPacket := gw.TXPacket{
+						TXInfo: gw.TXInfo{
+							MAC:       mac,
+							Timestamp: 12345,
+							Frequency: 868100000,
+							Power:     14,
+							CodeRate:  "4/5",
+							DataRate: band.DataRate{
+								Modulation:   band.LoRaModulation,
+								SpreadFactor: 12,
+								Bandwidth:    125,
+							},
+						},
+						PHYPayload: lorawan.PHYPayload{
+							MHDR: lorawan.MHDR{
+								MType: lorawan.UnconfirmedDataDown,
+								Major: lorawan.LoRaWANR1,
+							},
+							MACPayload: &lorawan.MACPayload{},
+						},
+					}
+					So(backend.SendTXPacket(txPacket), ShouldBeNil)
+
+					Convey("Then the gateway receives a PULL_RESP with the txpk", func() {
+						n, err := gwConn.Read(buf)
+						So(err, ShouldBeNil)
+						So(buf[0], ShouldEqual, protocolVersion2)
+						So(buf[3], ShouldEqual, byte(pullResp))
+
+						var pl pullRespPayload
+						So(json.Unmarshal(buf[4:n], &pl), ShouldBeNil)
+
+						phyB, err := txPacket.PHYPayload.MarshalBinary()
+						So(err, ShouldBeNil)
+						tmst := uint32(12345)
+						So(pl.TXPK, ShouldResemble, txpk{
+							Tmst: &tmst,
+							Freq: 868.1,
+							Powe: 14,
+							Modu: "LORA",
+							DatR: datR{LoRa: "SF12BW125"},
+							CodR: "4/5",
+							IPol: true,
+							Size: len(phyB),
+							Data: phyB,
+						})
+					})
+				})
+			})
+		})
+	})
+}
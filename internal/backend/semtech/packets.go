@@ -0,0 +1,395 @@
+package semtech
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// packetType defines the packet type (identifier).
+type packetType byte
+
+// Available packet types.
+const (
+	pushData packetType = 0x00
+	pushACK  packetType = 0x01
+	pullData packetType = 0x02
+	pullResp packetType = 0x03
+	pullACK  packetType = 0x04
+	txACK    packetType = 0x05
+)
+
+// Supported protocol versions.
+const (
+	protocolVersion1 uint8 = 0x01
+	protocolVersion2 uint8 = 0x02
+)
+
+// headerSize defines the size of the header of the packets sent by the
+// gateway (version, token, identifier and gateway MAC).
+const headerSize = 12
+
+// statTimeLayout defines the time layout of the stat object.
+const statTimeLayout = "2006-01-02 15:04:05 MST"
+
+// errors
+var (
+	ErrInvalidPacket = errors.New("invalid packet")
+)
+
+func (t packetType) String() string {
+	switch t {
+	case pushData:
+		return "PUSH_DATA"
+	case pushACK:
+		return "PUSH_ACK"
+	case pullData:
+		return "PULL_DATA"
+	case pullResp:
+		return "PULL_RESP"
+	case pullACK:
+		return "PULL_ACK"
+	case txACK:
+		return "TX_ACK"
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", byte(t))
+}
+
+// getPacketType returns the packet type of the given packet after
+// validating the protocol version.
+func getPacketType(b []byte) (packetType, error) {
+	if len(b) < 4 {
+		return 0, errors.Wrap(ErrInvalidPacket, "at least 4 bytes of data are expected")
+	}
+	if b[0] != protocolVersion1 && b[0] != protocolVersion2 {
+		return 0, errors.Wrapf(ErrInvalidPacket, "unsupported protocol version %d", b[0])
+	}
+	return packetType(b[3]), nil
+}
+
+// getGatewayMAC returns the MAC of the gateway which sent the given packet.
+func getGatewayMAC(b []byte) (lorawan.EUI64, error) {
+	var mac lorawan.EUI64
+	if len(b) < headerSize {
+		return mac, errors.Wrapf(ErrInvalidPacket, "at least %d bytes of data are expected", headerSize)
+	}
+	copy(mac[:], b[4:headerSize])
+	return mac, nil
+}
+
+// datR contains the data-rate, which is either a string (LoRa, e.g.
+// SF12BW125) or an integer (FSK bit rate).
+type datR struct {
+	LoRa string
+	FSK  int
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d datR) MarshalJSON() ([]byte, error) {
+	if d.LoRa != "" {
+		return json.Marshal(d.LoRa)
+	}
+	return json.Marshal(d.FSK)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *datR) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &d.LoRa)
+	}
+	return json.Unmarshal(b, &d.FSK)
+}
+
+// payload contains a base64 encoded RF packet payload. Some packet-forwarder
+// implementations omit the base64 padding, therefore both are accepted.
+type payload []byte
+
+// MarshalJSON implements json.Marshaler.
+func (p payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.StdEncoding.EncodeToString(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *payload) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*p = data
+	return nil
+}
+
+// rxpk contains a received RF packet.
+type rxpk struct {
+	Time *string `json:"time,omitempty"` // UTC time of pkt RX, us precision, ISO 8601 'compact' format
+	Tmst uint32  `json:"tmst"`           // Internal timestamp of "RX finished" event (32b unsigned)
+	Freq float64 `json:"freq"`           // RX central frequency in MHz
+	Chan int     `json:"chan"`           // Concentrator "IF" channel used for RX
+	RFCh int     `json:"rfch"`           // Concentrator "RF chain" used for RX
+	Stat int     `json:"stat"`           // CRC status: 1 = OK, -1 = fail, 0 = no CRC
+	Modu string  `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR datR    `json:"datr"`           // LoRa datarate identifier or FSK datarate (bits per second)
+	CodR string  `json:"codr"`           // LoRa ECC coding rate identifier
+	RSSI int     `json:"rssi"`           // RSSI in dBm (signed integer, 1 dB precision)
+	LSNR float64 `json:"lsnr"`           // LoRa SNR ratio in dB (signed float, 0.1 dB precision)
+	Size int     `json:"size"`           // RF packet payload size in bytes
+	Data payload `json:"data"`           // Base64 encoded RF packet payload
+	Brd  int     `json:"brd"`            // Concentrator board used for RX (protocol v2)
+	Aesk int     `json:"aesk"`           // AES key index used for encrypting the fine timestamps (protocol v2)
+	RSig []rsig  `json:"rsig,omitempty"` // Received signal information, per antenna (protocol v2)
+}
+
+// rsig contains the signal information of a single antenna.
+type rsig struct {
+	Ant   int     `json:"ant"`             // Antenna number on which the signal has been received
+	Chan  int     `json:"chan"`            // Concentrator "IF" channel used for RX
+	RSSIC int     `json:"rssic"`           // RSSI in dBm of the channel
+	RSSIS *int    `json:"rssis,omitempty"` // RSSI in dBm of the signal
+	LSNR  float64 `json:"lsnr"`            // LoRa SNR ratio in dB
+	ETime []byte  `json:"etime,omitempty"` // Base64 encoded encrypted fine timestamp
+	FTime *int64  `json:"ftime,omitempty"` // Not-encrypted fine timestamp, ns precision
+}
+
+// stat contains the gateway status.
+type stat struct {
+	Time string   `json:"time"`           // UTC 'system' time of the gateway
+	Lati *float64 `json:"lati,omitempty"` // GPS latitude of the gateway in degree
+	Long *float64 `json:"long,omitempty"` // GPS longitude of the gateway in degree
+	Alti *float64 `json:"alti,omitempty"` // GPS altitude of the gateway in meter RX
+	RXNb int      `json:"rxnb"`           // Number of radio packets received
+	RXOK int      `json:"rxok"`           // Number of radio packets received with a valid PHY CRC
+	RXFW int      `json:"rxfw"`           // Number of radio packets forwarded
+	ACKR float64  `json:"ackr"`           // Percentage of upstream datagrams that were acknowledged
+	DWNb int      `json:"dwnb"`           // Number of downlink datagrams received
+	TXNb int      `json:"txnb"`           // Number of packets emitted
+}
+
+// pushDataPayload contains the payload of a PUSH_DATA packet.
+type pushDataPayload struct {
+	RXPK []rxpk `json:"rxpk,omitempty"`
+	Stat *stat  `json:"stat,omitempty"`
+}
+
+// txpk contains a RF packet to be emitted.
+type txpk struct {
+	Imme bool    `json:"imme"`           // Send packet immediately (will ignore tmst & time)
+	Tmst *uint32 `json:"tmst,omitempty"` // Send packet on a certain timestamp value (will ignore time)
+	Freq float64 `json:"freq"`           // TX central frequency in MHz
+	RFCh int     `json:"rfch"`           // Concentrator "RF chain" used for TX
+	Powe int     `json:"powe"`           // TX output power in dBm
+	Modu string  `json:"modu"`           // Modulation identifier "LORA" or "FSK"
+	DatR datR    `json:"datr"`           // LoRa datarate identifier or FSK datarate (bits per second)
+	CodR string  `json:"codr,omitempty"` // LoRa ECC coding rate identifier
+	FDev uint16  `json:"fdev,omitempty"` // FSK frequency deviation (in Hz)
+	IPol bool    `json:"ipol"`           // Lora modulation polarization inversion
+	Size int     `json:"size"`           // RF packet payload size in bytes
+	Data payload `json:"data"`           // Base64 encoded RF packet payload
+}
+
+// pullRespPayload contains the payload of a PULL_RESP packet.
+type pullRespPayload struct {
+	TXPK txpk `json:"txpk"`
+}
+
+// txACKPayload contains the (optional) payload of a TX_ACK packet.
+type txACKPayload struct {
+	TXPKACK struct {
+		Error string `json:"error"`
+	} `json:"txpk_ack"`
+}
+
+// newRXPacketsFromRXPK returns the gw.RXPacket slice for the given rxpk.
+// When the rxpk contains signal information per antenna (protocol v2), a
+// gw.RXPacket is returned for each antenna.
+func newRXPacketsFromRXPK(mac lorawan.EUI64, rx rxpk) ([]gw.RXPacket, error) {
+	dr, err := newDataRateFromDatR(rx.Modu, rx.DatR)
+	if err != nil {
+		return nil, errors.Wrap(err, "get data-rate error")
+	}
+
+	rxInfo := gw.RXInfo{
+		MAC:       mac,
+		Timestamp: rx.Tmst,
+		Frequency: int(math.Round(rx.Freq * 1000000)),
+		Channel:   rx.Chan,
+		RFChain:   rx.RFCh,
+		CRCStatus: rx.Stat,
+		CodeRate:  rx.CodR,
+		RSSI:      rx.RSSI,
+		LoRaSNR:   rx.LSNR,
+		Size:      rx.Size,
+		DataRate:  dr,
+		Board:     rx.Brd,
+	}
+
+	if rx.Time != nil && *rx.Time != "" {
+		ts, err := time.Parse(time.RFC3339Nano, *rx.Time)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse time error")
+		}
+		rxInfo.Time = ts
+	}
+
+	rxInfos := []gw.RXInfo{rxInfo}
+	if len(rx.RSig) != 0 {
+		rxInfos = nil
+		for _, sig := range rx.RSig {
+			antRXInfo := rxInfo
+			antRXInfo.Antenna = sig.Ant
+			antRXInfo.Channel = sig.Chan
+			antRXInfo.RSSI = sig.RSSIC
+			antRXInfo.SignalRSSI = sig.RSSIS
+			antRXInfo.LoRaSNR = sig.LSNR
+
+			switch {
+			case len(sig.ETime) != 0:
+				antRXInfo.FineTimestampType = gw.FineTimestampEncrypted
+				antRXInfo.EncryptedFineTimestamp = sig.ETime
+				antRXInfo.FineTimestampKeyID = rx.Aesk
+			case sig.FTime != nil:
+				ft := time.Duration(*sig.FTime)
+				antRXInfo.FineTimestampType = gw.FineTimestampPlain
+				antRXInfo.FineTimestamp = &ft
+			}
+
+			rxInfos = append(rxInfos, antRXInfo)
+		}
+	}
+
+	var out []gw.RXPacket
+	for _, rxInfo := range rxInfos {
+		// each rx packet gets its own PHYPayload, as the receiver might
+		// modify it (e.g. on decryption)
+		rxPacket := gw.RXPacket{
+			RXInfo: rxInfo,
+		}
+
+		// the payload of a packet with an invalid CRC can't be trusted
+		// (and often can't be decoded), it is left empty
+		if rx.Stat == gw.CRCStatusError {
+			out = append(out, rxPacket)
+			continue
+		}
+
+		if err := rxPacket.PHYPayload.UnmarshalBinary(rx.Data); err != nil {
+			return nil, errors.Wrap(err, "unmarshal phypayload error")
+		}
+		out = append(out, rxPacket)
+	}
+
+	return out, nil
+}
+
+// newGatewayStatsPacketFromStat returns a gw.GatewayStatsPacket for the given
+// stat.
+func newGatewayStatsPacketFromStat(mac lorawan.EUI64, s stat) (gw.GatewayStatsPacket, error) {
+	statsPacket := gw.GatewayStatsPacket{
+		MAC:                 mac,
+		Latitude:            s.Lati,
+		Longitude:           s.Long,
+		Altitude:            s.Alti,
+		RXPacketsReceived:   s.RXNb,
+		RXPacketsReceivedOK: s.RXOK,
+		TXPacketsReceived:   s.DWNb,
+		TXPacketsEmitted:    s.TXNb,
+	}
+
+	if s.Time != "" {
+		ts, err := time.Parse(statTimeLayout, s.Time)
+		if err != nil {
+			return statsPacket, errors.Wrap(err, "parse time error")
+		}
+		statsPacket.Time = ts.UTC()
+	}
+
+	return statsPacket, nil
+}
+
+// newTXPKFromTXPacket returns a txpk for the given gw.TXPacket.
+func newTXPKFromTXPacket(txPacket gw.TXPacket) (txpk, error) {
+	b, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return txpk{}, errors.Wrap(err, "marshal phypayload error")
+	}
+
+	tx := txpk{
+		Imme: txPacket.TXInfo.Immediately,
+		Freq: float64(txPacket.TXInfo.Frequency) / 1000000,
+		Powe: txPacket.TXInfo.Power,
+		Modu: string(txPacket.TXInfo.DataRate.Modulation),
+		CodR: txPacket.TXInfo.CodeRate,
+		Size: len(b),
+		Data: b,
+	}
+
+	if !txPacket.TXInfo.Immediately {
+		tmst := txPacket.TXInfo.Timestamp
+		tx.Tmst = &tmst
+	}
+
+	switch txPacket.TXInfo.DataRate.Modulation {
+	case band.LoRaModulation:
+		tx.DatR.LoRa = fmt.Sprintf("SF%dBW%d", txPacket.TXInfo.DataRate.SpreadFactor, txPacket.TXInfo.DataRate.Bandwidth)
+		// the polarization is inverted for LoRa downlinks by default
+		tx.IPol = true
+	case band.FSKModulation:
+		tx.DatR.FSK = txPacket.TXInfo.DataRate.BitRate
+		tx.FDev = uint16(txPacket.TXInfo.DataRate.BitRate / 2)
+		tx.CodR = ""
+	default:
+		return tx, fmt.Errorf("unknown modulation: %s", txPacket.TXInfo.DataRate.Modulation)
+	}
+
+	if txPacket.TXInfo.IPol != nil {
+		tx.IPol = *txPacket.TXInfo.IPol
+	}
+
+	return tx, nil
+}
+
+// newDataRateFromDatR returns the band.DataRate for the given modulation
+// and datr.
+func newDataRateFromDatR(modu string, d datR) (band.DataRate, error) {
+	var dr band.DataRate
+
+	switch band.Modulation(modu) {
+	case band.LoRaModulation:
+		// e.g. SF12BW125
+		parts := strings.SplitN(strings.TrimPrefix(d.LoRa, "SF"), "BW", 2)
+		if len(parts) != 2 {
+			return dr, fmt.Errorf("invalid LoRa datr: %s", d.LoRa)
+		}
+		sf, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return dr, errors.Wrap(err, "parse spreading-factor error")
+		}
+		bw, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return dr, errors.Wrap(err, "parse bandwidth error")
+		}
+		dr.Modulation = band.LoRaModulation
+		dr.SpreadFactor = sf
+		dr.Bandwidth = bw
+	case band.FSKModulation:
+		dr.Modulation = band.FSKModulation
+		dr.BitRate = d.FSK
+	default:
+		return dr, fmt.Errorf("unknown modulation: %s", modu)
+	}
+
+	return dr, nil
+}
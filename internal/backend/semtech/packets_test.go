@@ -0,0 +1,183 @@
+package semtech
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestGetPacketType(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Data       []byte
+			PacketType packetType
+			Error      error
+		}{
+			{[]byte{protocolVersion1, 0, 0, byte(pullData)}, pullData, nil},
+			{[]byte{protocolVersion2, 0, 0, byte(txACK)}, txACK, nil},
+			{[]byte{protocolVersion2, 0, 0}, 0, ErrInvalidPacket},
+			{[]byte{0x03, 0, 0, byte(pushData)}, 0, ErrInvalidPacket},
+		}
+
+		for _, test := range tests {
+			pt, err := getPacketType(test.Data)
+			So(errors.Cause(err), ShouldEqual, test.Error)
+			So(pt, ShouldEqual, test.PacketType)
+		}
+	})
+}
+
+func TestDatR(t *testing.T) {
+	Convey("Given a LoRa and FSK datr", t, func() {
+		lora := datR{LoRa: "SF7BW125"}
+		fsk := datR{FSK: 50000}
+
+		Convey("Then they are marshaled as string and integer", func() {
+			b, err := json.Marshal(lora)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `"SF7BW125"`)
+
+			b, err = json.Marshal(fsk)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `50000`)
+		})
+
+		Convey("Then they are unmarshaled from string and integer", func() {
+			var d datR
+			So(json.Unmarshal([]byte(`"SF7BW125"`), &d), ShouldBeNil)
+			So(d, ShouldResemble, lora)
+
+			d = datR{}
+			So(json.Unmarshal([]byte(`50000`), &d), ShouldBeNil)
+			So(d, ShouldResemble, fsk)
+		})
+	})
+}
+
+func TestPayload(t *testing.T) {
+	Convey("Then a payload is unmarshaled with and without base64 padding", t, func() {
+		var p payload
+		So(json.Unmarshal([]byte(`"AQID"`), &p), ShouldBeNil)
+		So(p, ShouldResemble, payload{1, 2, 3})
+
+		So(json.Unmarshal([]byte(`"AQIDBA=="`), &p), ShouldBeNil)
+		So(p, ShouldResemble, payload{1, 2, 3, 4})
+
+		So(json.Unmarshal([]byte(`"AQIDBA"`), &p), ShouldBeNil)
+		So(p, ShouldResemble, payload{1, 2, 3, 4})
+	})
+}
+
+func TestNewRXPacketsFromRXPK(t *testing.T) {
+	Convey("Given a rxpk with signal information for two antennas", t, func() {
+		var rx rxpk
+		So(json.Unmarshal([]byte(`{
+			"tmst": 3512348611, "chan": 2, "rfch": 0, "freq": 868.3, "stat": 1, "modu": "LORA", "datr": "SF7BW125", "codr": "4/6", "size": 14, "data": "QAQDAgGAAQABqgECAwQ",
+			"brd": 1, "aesk": 3,
+			"rsig": [
+				{"ant": 0, "chan": 2, "rssic": -35, "rssis": -36, "lsnr": 5.1, "ftime": 123456789},
+				{"ant": 1, "chan": 2, "rssic": -40, "lsnr": 3.5, "etime": "AQIDBAUGBwgJCgsMDQ4PEA=="}
+			]
+		}`), &rx), ShouldBeNil)
+
+		Convey("Then newRXPacketsFromRXPK returns a RXPacket for each antenna", func() {
+			mac := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+			rxPackets, err := newRXPacketsFromRXPK(mac, rx)
+			So(err, ShouldBeNil)
+			So(rxPackets, ShouldHaveLength, 2)
+
+			dr := band.DataRate{
+				Modulation:   band.LoRaModulation,
+				SpreadFactor: 7,
+				Bandwidth:    125,
+			}
+			signalRSSI := -36
+			fineTimestamp := 123456789 * time.Nanosecond
+
+			So(rxPackets[0].RXInfo, ShouldResemble, gw.RXInfo{
+				MAC:               mac,
+				Timestamp:         3512348611,
+				Frequency:         868300000,
+				Channel:           2,
+				CRCStatus:         1,
+				CodeRate:          "4/6",
+				RSSI:              -35,
+				SignalRSSI:        &signalRSSI,
+				LoRaSNR:           5.1,
+				Size:              14,
+				DataRate:          dr,
+				Board:             1,
+				FineTimestampType: gw.FineTimestampPlain,
+				FineTimestamp:     &fineTimestamp,
+			})
+			So(rxPackets[1].RXInfo, ShouldResemble, gw.RXInfo{
+				MAC:                    mac,
+				Timestamp:              3512348611,
+				Frequency:              868300000,
+				Channel:                2,
+				CRCStatus:              1,
+				CodeRate:               "4/6",
+				RSSI:                   -40,
+				LoRaSNR:                3.5,
+				Size:                   14,
+				DataRate:               dr,
+				Board:                  1,
+				Antenna:                1,
+				FineTimestampType:      gw.FineTimestampEncrypted,
+				EncryptedFineTimestamp: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				FineTimestampKeyID:     3,
+			})
+			So(rxPackets[1].PHYPayload.MHDR.MType, ShouldEqual, lorawan.UnconfirmedDataUp)
+		})
+	})
+}
+
+func TestNewTXPKFromTXPacket(t *testing.T) {
+	Convey("Given a FSK TXPacket to be sent immediately", t, func() {
+		iPol := false
+		txPacket := gw.TXPacket{
+			TXInfo: gw.TXInfo{
+				Immediately: true,
+				Frequency:   868800000,
+				Power:       27,
+				DataRate: band.DataRate{
+					Modulation: band.FSKModulation,
+					BitRate:    50000,
+				},
+				IPol: &iPol,
+			},
+			PHYPayload: lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataDown,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{},
+			},
+		}
+
+		Convey("Then newTXPKFromTXPacket returns the expected txpk", func() {
+			phyB, err := txPacket.PHYPayload.MarshalBinary()
+			So(err, ShouldBeNil)
+
+			tx, err := newTXPKFromTXPacket(txPacket)
+			So(err, ShouldBeNil)
+			So(tx, ShouldResemble, txpk{
+				Imme: true,
+				Freq: 868.8,
+				Powe: 27,
+				Modu: "FSK",
+				DatR: datR{FSK: 50000},
+				FDev: 25000,
+				Size: len(phyB),
+				Data: phyB,
+			})
+		})
+	})
+}
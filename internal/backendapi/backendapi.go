@@ -0,0 +1,455 @@
+// Package backendapi implements the LoRaWAN backend-interfaces HTTP API.
+// It is used by the other backend components for sending asynchronous
+// answers to the network-server (e.g. by the join-server) and, when the
+// embedded join-server is enabled, for requesting the AppSKey (by the
+// application-server). When roaming is enabled, it handles the
+// passive and handover-roaming messages sent by the roaming partners.
+package backendapi
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/downlink"
+	"github.com/brocaar/loraserver/internal/joinserver"
+	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/roaming"
+	"github.com/brocaar/loraserver/internal/uplink"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// maxBodySize defines the max. size of the request body.
+const maxBodySize = 1 << 20
+
+// unknownSenderResult is returned to roaming partners using a SenderID
+// which is not a NetID.
+var unknownSenderResult = backend.Result{
+	ResultCode:  backend.UnknownSender,
+	Description: "SenderID must be a NetID",
+}
+
+// Config holds the backend-interfaces API configuration.
+type Config struct {
+	// AnswerStore is used for publishing asynchronous join-server answers
+	// (optional).
+	AnswerStore *jsclient.AnswerStore
+
+	// JoinServer enables the handling of the AppSKeyReq messages by the
+	// embedded join-server.
+	JoinServer bool
+
+	// Roaming enables the handling of the roaming messages (PRStartReq,
+	// XmitDataReq, ProfileReq, HRStartReq and HRStopReq).
+	Roaming bool
+}
+
+// API implements the backend-interfaces API.
+type API struct {
+	answerStore *jsclient.AnswerStore
+	joinServer  bool
+	roaming     bool
+}
+
+// NewAPI creates a new API.
+func NewAPI(conf Config) *API {
+	return &API{
+		answerStore: conf.AnswerStore,
+		joinServer:  conf.JoinServer,
+		roaming:     conf.Roaming,
+	}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "read body error", http.StatusBadRequest)
+		return
+	}
+
+	var basePL backend.BasePayload
+	if err := json.Unmarshal(b, &basePL); err != nil {
+		http.Error(w, "unmarshal json error", http.StatusBadRequest)
+		return
+	}
+
+	logFields := log.Fields{
+		"sender_id":      basePL.SenderID,
+		"receiver_id":    basePL.ReceiverID,
+		"message_type":   basePL.MessageType,
+		"transaction_id": basePL.TransactionID,
+	}
+
+	var ans interface{}
+
+	switch basePL.MessageType {
+	case backend.JoinAns:
+		// the answer is matched against the join-server to which the
+		// request was sent (see handleAnswer)
+		if !hasVerifiedClientCertificate(r) {
+			log.WithFields(logFields).Warning("backend api: JoinAns without verified client certificate")
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		err = a.handleAnswer(basePL, b)
+	case backend.AppSKeyReq:
+		if !a.joinServer {
+			log.WithFields(logFields).Warning("backend api: embedded join-server is not enabled")
+			http.Error(w, "unsupported message-type", http.StatusBadRequest)
+			return
+		}
+		// the AppSKey must only be handed to authenticated clients
+		if !hasVerifiedClientCertificate(r) {
+			log.WithFields(logFields).Warning("backend api: AppSKeyReq without verified client certificate")
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		ans, err = a.handleAppSKeyReq(b)
+	case backend.PRStartReq, backend.XmitDataReq, backend.ProfileReq, backend.HRStartReq, backend.HRStopReq:
+		if !a.roaming {
+			log.WithFields(logFields).Warning("backend api: roaming is not enabled")
+			http.Error(w, "unsupported message-type", http.StatusBadRequest)
+			return
+		}
+		// the SenderID must match the client certificate of the partner
+		if !hasVerifiedClientCertificate(r) {
+			log.WithFields(logFields).Warning("backend api: roaming message without verified client certificate")
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		if !isRoamingPartner(r, basePL.SenderID) {
+			log.WithFields(logFields).Warning("backend api: client certificate does not match the roaming partner")
+			http.Error(w, "client certificate does not match SenderID", http.StatusForbidden)
+			return
+		}
+		switch basePL.MessageType {
+		case backend.PRStartReq:
+			ans, err = a.handlePRStartReq(b)
+		case backend.XmitDataReq:
+			ans, err = a.handleXmitDataReq(b)
+		case backend.ProfileReq:
+			ans, err = a.handleProfileReq(b)
+		case backend.HRStartReq:
+			ans, err = a.handleHRStartReq(b)
+		case backend.HRStopReq:
+			ans, err = a.handleHRStopReq(b)
+		}
+	default:
+		log.WithFields(logFields).Warning("backend api: unsupported message-type")
+		http.Error(w, "unsupported message-type", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		log.WithFields(logFields).Errorf("backend api: handle message error: %s", err)
+
+		switch errors.Cause(err) {
+		case jsclient.ErrUnknownTransaction:
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		case jsclient.ErrInvalidSender:
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	log.WithFields(logFields).Info("backend api: message received")
+
+	if ans == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(ans); err != nil {
+		log.WithFields(logFields).Errorf("backend api: encode answer error: %s", err)
+	}
+}
+
+// hasVerifiedClientCertificate returns true when the client of the given
+// request presented a client certificate which has been verified using the
+// configured CA certificate (see --backend-api-ca-cert).
+func hasVerifiedClientCertificate(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) != 0
+}
+
+// isRoamingPartner returns true when the verified client certificate of the
+// given request belongs to the roaming partner matching the given SenderID
+// (see roaming.AgreementConfig.ClientCN).
+func isRoamingPartner(r *http.Request, senderID string) bool {
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(senderID)); err != nil {
+		return false
+	}
+
+	if common.RoamingPool == nil {
+		return false
+	}
+
+	client, err := common.RoamingPool.Get(netID)
+	if err != nil {
+		return false
+	}
+
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName == client.ClientCN()
+}
+
+func (a *API) handleAnswer(basePL backend.BasePayload, b []byte) error {
+	if a.answerStore == nil {
+		return errors.New("asynchronous answers are not enabled")
+	}
+
+	if err := a.answerStore.Publish(basePL.TransactionID, basePL.SenderID, b); err != nil {
+		return errors.Wrap(err, "publish answer error")
+	}
+
+	return nil
+}
+
+func (a *API) handleAppSKeyReq(b []byte) (interface{}, error) {
+	var pl backend.AppSKeyReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		return nil, errors.Wrap(err, "unmarshal AppSKeyReq error")
+	}
+
+	ans, err := joinserver.HandleAppSKeyReq(pl)
+	if err != nil {
+		return nil, errors.Wrap(err, "handle AppSKeyReq error")
+	}
+
+	return ans, nil
+}
+
+func (a *API) handlePRStartReq(b []byte) (interface{}, error) {
+	var pl backend.PRStartReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		return nil, errors.Wrap(err, "unmarshal PRStartReq error")
+	}
+
+	ans := roaming.PRStartAnsPayload{
+		PRStartAnsPayload: backend.PRStartAnsPayload{
+			BasePayload: getAnswerBasePayload(pl.BasePayload, backend.PRStartAns),
+		},
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		ans.Result = unknownSenderResult
+		return ans, nil
+	}
+
+	ds, err := uplink.HandleRoamingDataUp(netID, pl.PHYPayload, pl.ULMetaData)
+	if err != nil {
+		if res, ok := roaming.GetResult(err); ok {
+			ans.Result = res
+			return ans, nil
+		}
+		return nil, errors.Wrap(err, "handle roaming uplink error")
+	}
+
+	lifetime := int(common.RoamingLifetime / time.Second)
+	fCntUp := int(ds.FCntUp)
+
+	ans.Result = backend.Result{ResultCode: backend.Success}
+	ans.DevEUI = &ds.DevEUI
+	ans.Lifetime = &lifetime
+	ans.FCntUp = &fCntUp
+
+	// the NwkSKey is only needed by the fNS for stateful passive-roaming
+	if lifetime > 0 {
+		ans.NwkSKey, err = common.KEKStore.WrapAES128Key(common.RoamingKEKLabel, ds.NwkSKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "wrap nwk_s_key error")
+		}
+	}
+
+	return ans, nil
+}
+
+func (a *API) handleXmitDataReq(b []byte) (interface{}, error) {
+	var pl backend.XmitDataReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		return nil, errors.Wrap(err, "unmarshal XmitDataReq error")
+	}
+
+	ans := backend.XmitDataAnsPayload{
+		BasePayload: getAnswerBasePayload(pl.BasePayload, backend.XmitDataAns),
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		ans.Result = unknownSenderResult
+		return ans, nil
+	}
+
+	var err error
+	switch {
+	case len(pl.PHYPayload) == 0 && pl.ULMetaData != nil:
+		// application payload forwarded by the sNS (handover roaming)
+		err = uplink.HandleHandoverDataUp(netID, pl.FRMPayload, *pl.ULMetaData)
+	case len(pl.PHYPayload) == 0 && pl.DLMetaData != nil:
+		// application payload sent by the hNS (handover roaming)
+		err = downlink.HandleHandoverDataDown(netID, pl)
+	case pl.ULMetaData != nil:
+		// uplink forwarded by the fNS (stateful passive-roaming)
+		_, err = uplink.HandleRoamingDataUp(netID, pl.PHYPayload, *pl.ULMetaData)
+	case pl.DLMetaData != nil:
+		// downlink sent by the sNS
+		if common.RoamingPool == nil {
+			err = roaming.ErrNoAgreement
+		} else if _, err = common.RoamingPool.Get(netID); err == nil {
+			err = downlink.HandleRoamingDataDown(netID, pl)
+		}
+	default:
+		err = errors.Wrap(roaming.ErrMalformedRequest, "ULMetaData or DLMetaData expected")
+	}
+
+	if err != nil {
+		if res, ok := roaming.GetResult(err); ok {
+			ans.Result = res
+			return ans, nil
+		}
+		return nil, errors.Wrap(err, "handle XmitDataReq error")
+	}
+
+	ans.Result = backend.Result{ResultCode: backend.Success}
+	if pl.DLMetaData != nil && pl.DLMetaData.DLFreq1 != 0 {
+		ans.DLFreq1 = &pl.DLMetaData.DLFreq1
+	}
+	if pl.DLMetaData != nil && pl.DLMetaData.DLFreq2 != 0 {
+		ans.DLFreq2 = &pl.DLMetaData.DLFreq2
+	}
+
+	return ans, nil
+}
+
+func (a *API) handleProfileReq(b []byte) (interface{}, error) {
+	var pl backend.ProfileReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		return nil, errors.Wrap(err, "unmarshal ProfileReq error")
+	}
+
+	ans := backend.ProfileAnsPayload{
+		BasePayload: getAnswerBasePayload(pl.BasePayload, backend.ProfileAns),
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		ans.Result = unknownSenderResult
+		return ans, nil
+	}
+
+	dp, rt, err := uplink.HandleProfileReq(netID, pl.DevEUI)
+	if err != nil {
+		if res, ok := roaming.GetResult(err); ok {
+			ans.Result = res
+			return ans, nil
+		}
+		return nil, errors.Wrap(err, "handle ProfileReq error")
+	}
+
+	timestamp := dp.UpdatedAt.Format(time.RFC3339Nano)
+
+	ans.Result = backend.Result{ResultCode: backend.Success}
+	ans.DeviceProfile = &dp.DeviceProfile
+	ans.DeviceProfileTimestamp = &timestamp
+	ans.RoamingActivationType = &rt
+
+	return ans, nil
+}
+
+func (a *API) handleHRStartReq(b []byte) (interface{}, error) {
+	var pl backend.HRStartReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		return nil, errors.Wrap(err, "unmarshal HRStartReq error")
+	}
+
+	ans := roaming.HRStartAnsPayload{
+		HRStartAnsPayload: backend.HRStartAnsPayload{
+			BasePayload: getAnswerBasePayload(pl.BasePayload, backend.HRStartAns),
+		},
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		ans.Result = unknownSenderResult
+		return ans, nil
+	}
+
+	joinAns, sp, err := uplink.HandleHRStartReq(netID, pl)
+	if err != nil {
+		if res, ok := roaming.GetResult(err); ok {
+			ans.Result = res
+			return ans, nil
+		}
+		return nil, errors.Wrap(err, "handle HRStartReq error")
+	}
+
+	lifetime := int(common.RoamingHandoverLifetime / time.Second)
+
+	ans.Result = backend.Result{ResultCode: backend.Success}
+	ans.PHYPayload = joinAns.PHYPayload
+	ans.Lifetime = &lifetime
+	ans.ServiceProfile = &sp.ServiceProfile
+	ans.NwkSKey, err = common.KEKStore.WrapAES128Key(common.RoamingKEKLabel, joinAns.NwkSKey.AESKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "wrap nwk_s_key error")
+	}
+
+	return ans, nil
+}
+
+func (a *API) handleHRStopReq(b []byte) (interface{}, error) {
+	var pl backend.HRStopReqPayload
+	if err := json.Unmarshal(b, &pl); err != nil {
+		return nil, errors.Wrap(err, "unmarshal HRStopReq error")
+	}
+
+	ans := backend.HRStopAnsPayload{
+		BasePayload: getAnswerBasePayload(pl.BasePayload, backend.HRStopAns),
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		ans.Result = unknownSenderResult
+		return ans, nil
+	}
+
+	if err := uplink.HandleHRStopReq(netID, pl.DevEUI); err != nil {
+		if res, ok := roaming.GetResult(err); ok {
+			ans.Result = res
+			return ans, nil
+		}
+		return nil, errors.Wrap(err, "handle HRStopReq error")
+	}
+
+	ans.Result = backend.Result{ResultCode: backend.Success}
+	return ans, nil
+}
+
+// getAnswerBasePayload returns the base payload for answering the given
+// request.
+func getAnswerBasePayload(req backend.BasePayload, mt backend.MessageType) backend.BasePayload {
+	return backend.BasePayload{
+		ProtocolVersion: backend.ProtocolVersion1_0,
+		SenderID:        req.ReceiverID,
+		ReceiverID:      req.SenderID,
+		TransactionID:   req.TransactionID,
+		MessageType:     mt,
+	}
+}
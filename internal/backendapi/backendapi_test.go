@@ -0,0 +1,234 @@
+package backendapi
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestAnswerStore(t *testing.T) {
+	conf := test.GetConfig()
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean Redis database and an AnswerStore", t, func() {
+		test.MustFlushRedis(common.RedisPool)
+		s := jsclient.NewAnswerStore(common.RedisPool)
+
+		Convey("Then publishing an answer without pending request returns an error", func() {
+			So(s.Publish(123, "0102030405060708", []byte("answer")), ShouldEqual, jsclient.ErrUnknownTransaction)
+		})
+
+		Convey("Given a pending request", func() {
+			So(s.SavePending(123, "0102030405060708", time.Second), ShouldBeNil)
+
+			Convey("Then an answer of an other join-server is rejected", func() {
+				So(s.Publish(123, "0807060504030201", []byte("answer")), ShouldEqual, jsclient.ErrInvalidSender)
+			})
+
+			Convey("Then the published answer is returned by Get", func() {
+				So(s.Publish(123, "0102030405060708", []byte("answer")), ShouldBeNil)
+
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				b, err := s.Get(ctx, 123)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "answer")
+
+				Convey("Then a second answer is rejected", func() {
+					So(s.Publish(123, "0102030405060708", []byte("answer")), ShouldEqual, jsclient.ErrUnknownTransaction)
+				})
+			})
+
+			Convey("Then an answer published within a sub-second deadline is returned", func() {
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					s.Publish(123, "0102030405060708", []byte("answer"))
+				}()
+
+				ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+				defer cancel()
+				b, err := s.Get(ctx, 123)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, "answer")
+			})
+
+			Convey("Then Get returns ErrTimeout at the (sub-second) deadline", func() {
+				start := time.Now()
+				ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+				defer cancel()
+				_, err := s.Get(ctx, 123)
+				So(err, ShouldEqual, jsclient.ErrTimeout)
+				So(time.Since(start), ShouldBeLessThan, 300*time.Millisecond)
+			})
+		})
+	})
+}
+
+func TestAsyncJoinAns(t *testing.T) {
+	conf := test.GetConfig()
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean Redis database, the backend api and an asynchronous join-server", t, func() {
+		test.MustFlushRedis(common.RedisPool)
+
+		answerStore := jsclient.NewAnswerStore(common.RedisPool)
+		api := NewAPI(Config{AnswerStore: answerStore})
+		apiServer := httptest.NewServer(withClientCertificate("js", api))
+		defer apiServer.Close()
+
+		answer := backend.JoinAnsPayload{
+			BasePayload: backend.BasePayload{
+				SenderID:    "0102030405060708",
+				MessageType: backend.JoinAns,
+			},
+			PHYPayload: backend.HEXBytes{1, 2, 3},
+			Result:     backend.Result{ResultCode: backend.Success},
+			NwkSKey: &backend.KeyEnvelope{
+				AESKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		}
+
+		jsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req backend.JoinReqPayload
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			// acknowledge the request and send the answer asynchronously
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				answer.TransactionID = req.TransactionID
+				b, _ := json.Marshal(answer)
+				resp, err := http.Post(apiServer.URL, "application/json", bytes.NewReader(b))
+				if err == nil {
+					resp.Body.Close()
+				}
+			}()
+		}))
+		defer jsServer.Close()
+
+		Convey("Then JoinReq returns the asynchronous answer", func() {
+			c, err := jsclient.NewClient(jsServer.URL, "", "", "", jsclient.ClientOptions{AnswerStore: answerStore})
+			So(err, ShouldBeNil)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			ans, err := c.JoinReq(ctx, backend.JoinReqPayload{
+				BasePayload: backend.BasePayload{
+					ReceiverID:    "0102030405060708",
+					TransactionID: 1234,
+					MessageType:   backend.JoinReq,
+				},
+			})
+			So(err, ShouldBeNil)
+			So(ans.TransactionID, ShouldEqual, 1234)
+			So(ans.PHYPayload, ShouldResemble, answer.PHYPayload)
+			So(ans.NwkSKey, ShouldResemble, answer.NwkSKey)
+		})
+
+		Convey("Then an answer for an unknown TransactionID is rejected", func() {
+			answer.TransactionID = 4321
+			b, err := json.Marshal(answer)
+			So(err, ShouldBeNil)
+
+			resp, err := http.Post(apiServer.URL, "application/json", bytes.NewReader(b))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+		})
+
+		Convey("Given a pending request", func() {
+			So(answerStore.SavePending(4321, "0102030405060708", time.Second), ShouldBeNil)
+			answer.TransactionID = 4321
+
+			Convey("Then an answer without client certificate is rejected", func() {
+				noCertServer := httptest.NewServer(api)
+				defer noCertServer.Close()
+
+				b, err := json.Marshal(answer)
+				So(err, ShouldBeNil)
+				resp, err := http.Post(noCertServer.URL, "application/json", bytes.NewReader(b))
+				So(err, ShouldBeNil)
+				resp.Body.Close()
+				So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+			})
+
+			Convey("Then an answer of an other join-server is rejected", func() {
+				answer.SenderID = "0807060504030201"
+				b, err := json.Marshal(answer)
+				So(err, ShouldBeNil)
+				resp, err := http.Post(apiServer.URL, "application/json", bytes.NewReader(b))
+				So(err, ShouldBeNil)
+				resp.Body.Close()
+				So(resp.StatusCode, ShouldEqual, http.StatusForbidden)
+			})
+		})
+
+		Convey("Then an unsupported message-type is rejected", func() {
+			b, err := json.Marshal(backend.BasePayload{MessageType: backend.PRStartReq})
+			So(err, ShouldBeNil)
+
+			resp, err := http.Post(apiServer.URL, "application/json", bytes.NewReader(b))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Then an AppSKeyReq is rejected when the embedded join-server is disabled", func() {
+			b, err := json.Marshal(backend.AppSKeyReqPayload{
+				BasePayload: backend.BasePayload{MessageType: backend.AppSKeyReq},
+			})
+			So(err, ShouldBeNil)
+
+			resp, err := http.Post(apiServer.URL, "application/json", bytes.NewReader(b))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("Then an AppSKeyReq without client certificate is rejected", func() {
+			jsAPIServer := httptest.NewServer(NewAPI(Config{JoinServer: true}))
+			defer jsAPIServer.Close()
+
+			b, err := json.Marshal(backend.AppSKeyReqPayload{
+				BasePayload: backend.BasePayload{MessageType: backend.AppSKeyReq},
+			})
+			So(err, ShouldBeNil)
+
+			resp, err := http.Post(jsAPIServer.URL, "application/json", bytes.NewReader(b))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+		})
+	})
+}
+
+// withClientCertificate sets a verified client certificate with the given
+// common name on the requests.
+func withClientCertificate(cn string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{
+				{{Subject: pkix.Name{CommonName: cn}}},
+			},
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,382 @@
+package channels
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// CFListTypeChannelMask defines the CFListType for a CFList containing a
+// channel-mask instead of a list of frequencies (LoRaWAN 1.0.3+, for bands
+// with a fixed channel plan).
+const CFListTypeChannelMask = 1
+
+// gatewayChannelConfigurationKeyTempl defines the Redis key template for
+// caching the channel-configuration of a gateway.
+const gatewayChannelConfigurationKeyTempl = "lora:ns:gw:%s:channel_conf"
+
+// ChannelConfigurationCacheTTL defines the duration for which the
+// channel-configuration of a gateway is cached for handling the channel
+// reconfiguration on uplink. Changes to the channel-configuration of a
+// gateway are therefore applied within this duration.
+var ChannelConfigurationCacheTTL = time.Minute
+
+// ActivationChannels contains the channel settings to use for an OTAA
+// activation.
+type ActivationChannels struct {
+	// CFList to include in the join-accept (or nil).
+	CFList *lorawan.CFList
+
+	// CFListType is set when the CFList does not contain a list of
+	// frequencies.
+	CFListType *int
+
+	// EnabledChannels contains the channels that are enabled on the device
+	// after the activation.
+	EnabledChannels []int
+}
+
+// GetChannelConfigurationForRXInfoSet returns the channel-configuration of
+// the first gateway (the set is sorted, best gateway first) within the given
+// rx-info set having a channel-configuration for the active band. When none
+// of the gateways has one, nil is returned.
+func GetChannelConfigurationForRXInfoSet(db *sqlx.DB, rxInfoSet models.RXInfoSet) (*gateway.ChannelConfiguration, error) {
+	for _, rxInfo := range rxInfoSet {
+		cf, err := getChannelConfigurationForGateway(db, rxInfo.MAC)
+		if err != nil {
+			return nil, err
+		}
+
+		if cf == nil || cf.Band != string(common.BandName) {
+			continue
+		}
+
+		return cf, nil
+	}
+
+	return nil, nil
+}
+
+// getCachedChannelConfigurationForRXInfoSet implements
+// GetChannelConfigurationForRXInfoSet, using the cached channel-configuration
+// of each gateway (see ChannelConfigurationCacheTTL). This avoids querying
+// the database for every uplink.
+func getCachedChannelConfigurationForRXInfoSet(db *sqlx.DB, p *redis.Pool, rxInfoSet models.RXInfoSet) (cachedChannelConfiguration, error) {
+	for _, rxInfo := range rxInfoSet {
+		cached, err := getCachedChannelConfigurationForGateway(db, p, rxInfo.MAC)
+		if err != nil {
+			return cachedChannelConfiguration{}, err
+		}
+
+		if cached.ChannelConfiguration == nil || cached.ChannelConfiguration.Band != string(common.BandName) {
+			continue
+		}
+
+		return cached, nil
+	}
+
+	return cachedChannelConfiguration{}, nil
+}
+
+// getChannelConfigurationForGateway returns the channel-configuration of the
+// given gateway, or nil when the gateway does not exist or does not have a
+// channel-configuration.
+func getChannelConfigurationForGateway(db *sqlx.DB, mac lorawan.EUI64) (*gateway.ChannelConfiguration, error) {
+	gw, err := gateway.GetGateway(db, mac)
+	if err != nil {
+		if errors.Cause(err) == gateway.ErrDoesNotExist {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get gateway error")
+	}
+
+	if gw.ChannelConfigurationID == nil {
+		return nil, nil
+	}
+
+	cf, err := gateway.GetChannelConfiguration(db, *gw.ChannelConfigurationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get channel-configuration error")
+	}
+
+	return &cf, nil
+}
+
+// cachedChannelConfiguration holds the cached channel-configuration of a
+// gateway (nil when the gateway does not have a channel-configuration) and
+// the CFList of its extra channels (nil when there are none or when the
+// band does not implement the CFList).
+type cachedChannelConfiguration struct {
+	ChannelConfiguration *gateway.ChannelConfiguration
+	CFList               *lorawan.CFList
+}
+
+// getCachedChannelConfigurationForGateway returns the channel-configuration
+// of the given gateway from the cache, or from the database (after which it
+// is cached) on a cache miss.
+func getCachedChannelConfigurationForGateway(db *sqlx.DB, p *redis.Pool, mac lorawan.EUI64) (cachedChannelConfiguration, error) {
+	var cached cachedChannelConfiguration
+	key := fmt.Sprintf(gatewayChannelConfigurationKeyTempl, mac)
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", key))
+	if err != nil && err != redis.ErrNil {
+		return cached, errors.Wrap(err, "get cached channel-configuration error")
+	}
+	if err == nil {
+		if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&cached); err != nil {
+			return cached, errors.Wrap(err, "gob decode error")
+		}
+		return cached, nil
+	}
+
+	cached.ChannelConfiguration, err = getChannelConfigurationForGateway(db, mac)
+	if err != nil {
+		return cached, err
+	}
+
+	if cached.ChannelConfiguration != nil && common.Band.ImplementsCFlist {
+		extraChannels, err := gateway.GetExtraChannelsForChannelConfigurationID(db, cached.ChannelConfiguration.ID)
+		if err != nil {
+			return cached, errors.Wrap(err, "get extra channels error")
+		}
+		cached.CFList = getCFListForExtraChannels(extraChannels)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(cached); err != nil {
+		return cached, errors.Wrap(err, "gob encode error")
+	}
+
+	_, err = c.Do("PSETEX", key, int64(ChannelConfigurationCacheTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return cached, errors.Wrap(err, "cache channel-configuration error")
+	}
+
+	return cached, nil
+}
+
+// GetActivationChannels returns the CFList and enabled channels for an
+// OTAA activation, based on the channel-configuration of the gateway(s)
+// that received the join-request. When none of these gateways has a
+// channel-configuration, the band configuration is used.
+func GetActivationChannels(db *sqlx.DB, macVersion string, rxInfoSet models.RXInfoSet) (ActivationChannels, error) {
+	out := ActivationChannels{
+		CFList:          common.Band.GetCFList(),
+		EnabledChannels: getDefaultActivationChannels(common.Band, common.Band.GetCFList()),
+	}
+
+	cf, err := GetChannelConfigurationForRXInfoSet(db, rxInfoSet)
+	if err != nil {
+		return out, errors.Wrap(err, "get channel-configuration error")
+	}
+	if cf == nil {
+		return out, nil
+	}
+
+	if common.Band.ImplementsCFlist {
+		extraChannels, err := gateway.GetExtraChannelsForChannelConfigurationID(db, cf.ID)
+		if err != nil {
+			return out, errors.Wrap(err, "get extra channels error")
+		}
+
+		out.CFList = getCFListForExtraChannels(extraChannels)
+		out.EnabledChannels = getDefaultActivationChannels(common.Band, out.CFList)
+		return out, nil
+	}
+
+	// the channel-mask CFList is only supported by LoRaWAN 1.0.3+ devices,
+	// older devices will enable all channels and will be reconfigured on the
+	// first downlink (see HandleChannelReconfigure).
+	if !supportsCFListChannelMask(macVersion) {
+		return out, nil
+	}
+
+	var channels []int
+	for _, c := range cf.Channels {
+		channels = append(channels, int(c))
+	}
+	sort.Ints(channels)
+
+	cfListType := CFListTypeChannelMask
+	out.CFList = getCFListForChannelMask(channels)
+	out.CFListType = &cfListType
+	out.EnabledChannels = channels
+
+	return out, nil
+}
+
+// WithoutChannelMask returns the activation channels to use when a
+// channel-mask CFList can't be sent to the device (e.g. because the
+// join-server does not handle the CFListType). Without CFList, the device
+// enables the channels defined by the band, these are then reconfigured on
+// the first downlink (see HandleChannelReconfigure).
+func (a ActivationChannels) WithoutChannelMask() ActivationChannels {
+	if a.CFListType == nil {
+		return a
+	}
+
+	return ActivationChannels{
+		CFList:          common.Band.GetCFList(),
+		EnabledChannels: getDefaultActivationChannels(common.Band, common.Band.GetCFList()),
+	}
+}
+
+// GetBandForChannelConfiguration returns a copy of the active band with only
+// the channels of the given channel-configuration enabled. The
+// user-configured channels of the active band are replaced by the channels
+// of the given CFList (the extra channels of the channel-configuration, see
+// GetActivationChannels), matching the channels of the device after the
+// activation. In case cf is nil, the active band is returned.
+func GetBandForChannelConfiguration(cf *gateway.ChannelConfiguration, cFList *lorawan.CFList) (band.Band, error) {
+	b := common.Band
+	if cf == nil {
+		return b, nil
+	}
+
+	// make sure we don't modify the channels of the active band
+	n := getDefaultChannelCount(common.Band)
+	b.UplinkChannels = make([]band.Channel, n)
+	copy(b.UplinkChannels, common.Band.UplinkChannels)
+	b.DownlinkChannels = make([]band.Channel, len(common.Band.DownlinkChannels))
+	copy(b.DownlinkChannels, common.Band.DownlinkChannels)
+
+	enabled := make(map[int]struct{})
+	for _, c := range cf.Channels {
+		enabled[int(c)] = struct{}{}
+	}
+
+	for _, c := range b.GetUplinkChannels() {
+		if _, ok := enabled[c]; ok {
+			if err := b.EnableUplinkChannel(c); err != nil {
+				return b, errors.Wrap(err, "enable uplink channel error")
+			}
+		} else {
+			if err := b.DisableUplinkChannel(c); err != nil {
+				return b, errors.Wrap(err, "disable uplink channel error")
+			}
+		}
+	}
+
+	if cFList != nil {
+		for _, f := range cFList {
+			if f == 0 {
+				break
+			}
+			if err := b.AddChannel(int(f)); err != nil {
+				return b, errors.Wrap(err, "add channel error")
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// getDefaultActivationChannels returns the channels that are enabled on the
+// device after an activation with the given CFList (frequency list). These
+// are the channels defined by the band, followed by a channel for each
+// frequency of the given CFList.
+func getDefaultActivationChannels(b band.Band, cFList *lorawan.CFList) []int {
+	n := getDefaultChannelCount(b)
+
+	var out []int
+	for i := 0; i < n; i++ {
+		out = append(out, i)
+	}
+
+	if cFList != nil {
+		for i, f := range cFList {
+			if f != 0 {
+				out = append(out, n+i)
+			}
+		}
+	}
+
+	return out
+}
+
+// getDefaultChannelCount returns the number of channels defined by the band,
+// excluding the user-configured channels (the channels returned by the
+// CFList of the band), which are always added after these.
+func getDefaultChannelCount(b band.Band) int {
+	userConfigured := make(map[int]struct{})
+	if bandCFList := b.GetCFList(); bandCFList != nil {
+		for _, f := range bandCFList {
+			userConfigured[int(f)] = struct{}{}
+		}
+	}
+
+	for i, c := range b.UplinkChannels {
+		if _, ok := userConfigured[c.Frequency]; ok {
+			return i
+		}
+	}
+	return len(b.UplinkChannels)
+}
+
+// getCFListForExtraChannels returns the CFList containing the frequencies
+// of the first five LoRa extra channels, or nil when there are none.
+func getCFListForExtraChannels(channels []gateway.ExtraChannel) *lorawan.CFList {
+	var cFList lorawan.CFList
+	var i int
+	for _, c := range channels {
+		if c.Modulation != gateway.ChannelModulationLoRa || i >= len(cFList) {
+			continue
+		}
+		cFList[i] = uint32(c.Frequency)
+		i++
+	}
+
+	if i == 0 {
+		return nil
+	}
+	return &cFList
+}
+
+// getCFListForChannelMask returns the CFList containing the given channels
+// as ChMask. As lorawan.CFList encodes each item as a 3 byte little-endian
+// value (divided by 100), the ChMask bytes are packed so that the binary
+// encoding of the CFList results in the ChMask0 - ChMask4 fields, followed
+// by the RFU bytes. The CFListType must be set by the join-server.
+func getCFListForChannelMask(channels []int) *lorawan.CFList {
+	// ChMask0 - ChMask4 (2 bytes each) + RFU (5 bytes)
+	var b [15]byte
+	for _, c := range channels {
+		if c/8 >= 10 {
+			continue
+		}
+		b[c/8] |= 1 << uint(c%8)
+	}
+
+	var cFList lorawan.CFList
+	for i := range cFList {
+		cFList[i] = (uint32(b[i*3]) | uint32(b[i*3+1])<<8 | uint32(b[i*3+2])<<16) * 100
+	}
+
+	return &cFList
+}
+
+// supportsCFListChannelMask returns true when the given LoRaWAN MAC version
+// supports a CFList containing a channel-mask.
+func supportsCFListChannelMask(macVersion string) bool {
+	switch macVersion {
+	case "", "1.0", "1.0.0", "1.0.1", "1.0.2":
+		return false
+	default:
+		return true
+	}
+}
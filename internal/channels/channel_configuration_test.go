@@ -0,0 +1,196 @@
+package channels
+
+import (
+	"testing"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetCFListForChannelMask(t *testing.T) {
+	Convey("Given channels 8 - 15 and 65", t, func() {
+		channels := []int{8, 9, 10, 11, 12, 13, 14, 15, 65}
+
+		Convey("Then the binary encoding of the CFList contains the expected ChMask", func() {
+			cFList := getCFListForChannelMask(channels)
+			b, err := cFList.MarshalBinary()
+			So(err, ShouldBeNil)
+			So(b, ShouldResemble, []byte{
+				0x00, 0xff, // ChMask0
+				0x00, 0x00, // ChMask1
+				0x00, 0x00, // ChMask2
+				0x00, 0x00, // ChMask3
+				0x02, 0x00, // ChMask4
+				0x00, 0x00, 0x00, 0x00, 0x00, // RFU
+				0x00, // CFListType (set by the join-server)
+			})
+		})
+	})
+}
+
+func TestGetActivationChannels(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	Convey("Given a clean database", t, func() {
+		test.MustResetDB(common.DB)
+
+		rxInfoSet := models.RXInfoSet{
+			{MAC: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}},
+		}
+
+		Convey("Given the EU band and a gateway without channel-configuration", func() {
+			common.BandName = band.EU_863_870
+			common.Band, err = band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+			So(err, ShouldBeNil)
+
+			g := gateway.Gateway{
+				MAC:  rxInfoSet[0].MAC,
+				Name: "test-gw",
+			}
+			So(gateway.CreateGateway(common.DB, &g), ShouldBeNil)
+
+			Convey("Then the band channels are returned", func() {
+				ac, err := GetActivationChannels(common.DB, "1.0.2", rxInfoSet)
+				So(err, ShouldBeNil)
+				So(ac, ShouldResemble, ActivationChannels{
+					EnabledChannels: []int{0, 1, 2},
+				})
+			})
+
+			Convey("Given the gateway has a channel-configuration with extra channels", func() {
+				cf := gateway.ChannelConfiguration{
+					Name:     "test-conf",
+					Band:     string(common.BandName),
+					Channels: []int64{0, 1, 2},
+				}
+				So(gateway.CreateChannelConfiguration(common.DB, &cf), ShouldBeNil)
+
+				for _, f := range []int{867100000, 867300000} {
+					ec := gateway.ExtraChannel{
+						ChannelConfigurationID: cf.ID,
+						Modulation:             gateway.ChannelModulationLoRa,
+						Frequency:              f,
+						BandWidth:              125,
+						SpreadFactors:          []int64{12, 11, 10, 9, 8, 7},
+					}
+					So(gateway.CreateExtraChannel(common.DB, &ec), ShouldBeNil)
+				}
+
+				g.ChannelConfigurationID = &cf.ID
+				So(gateway.UpdateGateway(common.DB, &g), ShouldBeNil)
+
+				Convey("Then the CFList and the enabled channels contain the extra channels", func() {
+					ac, err := GetActivationChannels(common.DB, "1.0.2", rxInfoSet)
+					So(err, ShouldBeNil)
+					So(ac, ShouldResemble, ActivationChannels{
+						CFList:          &lorawan.CFList{867100000, 867300000},
+						EnabledChannels: []int{0, 1, 2, 3, 4},
+					})
+
+					Convey("Then GetBandForChannelConfiguration returns a band matching the channels of the device", func() {
+						b, err := GetBandForChannelConfiguration(&cf, ac.CFList)
+						So(err, ShouldBeNil)
+						So(b.GetEnabledUplinkChannels(), ShouldResemble, ac.EnabledChannels)
+						So(b.UplinkChannels[3].Frequency, ShouldEqual, 867100000)
+						So(b.UplinkChannels[4].Frequency, ShouldEqual, 867300000)
+						So(b.GetLinkADRReqPayloadsForEnabledChannels(ac.EnabledChannels), ShouldHaveLength, 0)
+						So(common.Band.UplinkChannels, ShouldHaveLength, 3)
+					})
+				})
+			})
+		})
+
+		Convey("Given the US band and a gateway with an 8 channel channel-configuration", func() {
+			common.BandName = band.US_902_928
+			common.Band, err = band.GetConfig(band.US_902_928, false, lorawan.DwellTimeNoLimit)
+			So(err, ShouldBeNil)
+
+			cf := gateway.ChannelConfiguration{
+				Name:     "sub-band-2",
+				Band:     string(common.BandName),
+				Channels: []int64{8, 9, 10, 11, 12, 13, 14, 15},
+			}
+			So(gateway.CreateChannelConfiguration(common.DB, &cf), ShouldBeNil)
+
+			g := gateway.Gateway{
+				MAC:                    rxInfoSet[0].MAC,
+				Name:                   "test-gw",
+				ChannelConfigurationID: &cf.ID,
+			}
+			So(gateway.CreateGateway(common.DB, &g), ShouldBeNil)
+
+			Reset(func() {
+				common.BandName = band.EU_863_870
+				common.Band, err = band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("Then a LoRaWAN 1.0.2 device gets all channels and no CFList", func() {
+				ac, err := GetActivationChannels(common.DB, "1.0.2", rxInfoSet)
+				So(err, ShouldBeNil)
+				So(ac.CFList, ShouldBeNil)
+				So(ac.CFListType, ShouldBeNil)
+				So(ac.EnabledChannels, ShouldResemble, common.Band.GetUplinkChannels())
+
+				Convey("Then GetBandForChannelConfiguration only enables the channels of the gateway", func() {
+					b, err := GetBandForChannelConfiguration(&cf, nil)
+					So(err, ShouldBeNil)
+					So(b.GetEnabledUplinkChannels(), ShouldResemble, []int{8, 9, 10, 11, 12, 13, 14, 15})
+					So(common.Band.GetEnabledUplinkChannels(), ShouldResemble, common.Band.GetUplinkChannels())
+				})
+			})
+
+			Convey("Then a LoRaWAN 1.0.3 device gets a channel-mask CFList", func() {
+				ac, err := GetActivationChannels(common.DB, "1.0.3", models.RXInfoSet{
+					{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}}, // unknown gateway
+					rxInfoSet[0],
+				})
+				So(err, ShouldBeNil)
+				So(*ac.CFListType, ShouldEqual, CFListTypeChannelMask)
+				So(ac.CFList, ShouldResemble, getCFListForChannelMask([]int{8, 9, 10, 11, 12, 13, 14, 15}))
+				So(ac.EnabledChannels, ShouldResemble, []int{8, 9, 10, 11, 12, 13, 14, 15})
+
+				Convey("Then WithoutChannelMask returns all channels and no CFList", func() {
+					ac = ac.WithoutChannelMask()
+					So(ac.CFList, ShouldBeNil)
+					So(ac.CFListType, ShouldBeNil)
+					So(ac.EnabledChannels, ShouldResemble, common.Band.GetUplinkChannels())
+				})
+			})
+
+			Convey("Given a clean Redis database", func() {
+				common.RedisPool = common.NewRedisPool(conf.RedisURL)
+				test.MustFlushRedis(common.RedisPool)
+
+				Convey("Then the cached channel-configuration is returned until it expires", func() {
+					cached, err := getCachedChannelConfigurationForRXInfoSet(common.DB, common.RedisPool, rxInfoSet)
+					So(err, ShouldBeNil)
+					So(cached.ChannelConfiguration, ShouldNotBeNil)
+					So(cached.ChannelConfiguration.Channels, ShouldResemble, cf.Channels)
+
+					g.ChannelConfigurationID = nil
+					So(gateway.UpdateGateway(common.DB, &g), ShouldBeNil)
+
+					cached, err = getCachedChannelConfigurationForRXInfoSet(common.DB, common.RedisPool, rxInfoSet)
+					So(err, ShouldBeNil)
+					So(cached.ChannelConfiguration, ShouldNotBeNil)
+
+					test.MustFlushRedis(common.RedisPool)
+					cached, err = getCachedChannelConfigurationForRXInfoSet(common.DB, common.RedisPool, rxInfoSet)
+					So(err, ShouldBeNil)
+					So(cached.ChannelConfiguration, ShouldBeNil)
+				})
+			})
+		})
+	})
+}
@@ -15,8 +15,23 @@ import (
 // on the node. This is needed in case only a sub-set of channels is used
 // (e.g. for the US band) or when a reconfiguration of active channels
 // happens.
+//
+// When the receiving gateway has a channel-configuration, the channels
+// of this channel-configuration will be used, else the enabled channels of
+// the band. The channel-configuration of the gateway is cached for
+// ChannelConfigurationCacheTTL.
 func HandleChannelReconfigure(ds storage.DeviceSession, rxPacket models.RXPacket) error {
-	payloads := common.Band.GetLinkADRReqPayloadsForEnabledChannels(ds.EnabledChannels)
+	cached, err := getCachedChannelConfigurationForRXInfoSet(common.DB, common.RedisPool, rxPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "get channel-configuration error")
+	}
+
+	b, err := GetBandForChannelConfiguration(cached.ChannelConfiguration, cached.CFList)
+	if err != nil {
+		return errors.Wrap(err, "get band for channel-configuration error")
+	}
+
+	payloads := b.GetLinkADRReqPayloadsForEnabledChannels(ds.EnabledChannels)
 	if len(payloads) == 0 {
 		return nil
 	}
@@ -15,10 +15,16 @@ import (
 
 func TestHandleChannelReconfigure(t *testing.T) {
 	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
 
 	Convey("Given a clean Redis database and a set of tests", t, func() {
 		common.RedisPool = common.NewRedisPool(conf.RedisURL)
 		test.MustFlushRedis(common.RedisPool)
+		test.MustResetDB(common.DB)
 
 		rxPacket := models.RXPacket{
 			RXInfoSet: models.RXInfoSet{
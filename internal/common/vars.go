@@ -6,7 +6,10 @@ import (
 	"github.com/brocaar/loraserver/api/nc"
 	"github.com/brocaar/loraserver/internal/asclient"
 	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/loraserver/internal/devaddr"
 	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/roaming"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/band"
 	"github.com/garyburd/redigo/redis"
@@ -57,6 +60,22 @@ var LogNodeFrames bool
 // server for gateway authentication.
 var GatewayServerJWTSecret string
 
+// GatewayServerJWTKeys contains the additional JWT secrets (by key ID) used
+// by the gateway API server for gateway authentication.
+var GatewayServerJWTKeys map[string]string
+
+// GatewayServerJWTSigningKeyID contains the ID of the key used for signing
+// new gateway tokens. When empty, GatewayServerJWTSecret is used.
+var GatewayServerJWTSigningKeyID string
+
+// CoverageGeohashPrecision defines the geohash precision (number of
+// characters) of the coverage cells.
+var CoverageGeohashPrecision = 7
+
+// GatewayTokenTTL defines the default time-to-live of a gateway token
+// (0 = tokens do not expire).
+var GatewayTokenTTL time.Duration
+
 // RedisPool holds the Redis connection pool.
 var RedisPool *redis.Pool
 
@@ -66,6 +85,11 @@ var DB *sqlx.DB
 // NetID contains the LoRaWAN NetID.
 var NetID lorawan.NetID
 
+// DevAddrRanges contains the DevAddr ranges used for the activation of
+// devices (the full DevAddr range of the NetID is used when empty). These
+// can be overridden per service-profile.
+var DevAddrRanges []devaddr.Range
+
 // ApplicationServerPool holds the connection(s) to the application-server(s).
 var ApplicationServerPool asclient.Pool
 
@@ -78,6 +102,34 @@ var Gateway backend.Gateway
 // JoinServerPool holds the join-server client pool.
 var JoinServerPool jsclient.Pool
 
+// KEKStore holds the key-encryption-keys.
+var KEKStore *kek.Store
+
+// JoinServerKEKLabel holds the label of the KEK used by the embedded
+// join-server for encrypting the root-keys at rest.
+var JoinServerKEKLabel string
+
+// JoinServerASKEKLabel holds the label of the KEK used by the embedded
+// join-server for wrapping the AppSKey when handing it to the
+// application-server (AppSKey requests are refused when empty).
+var JoinServerASKEKLabel string
+
+// RoamingPool holds the roaming client pool (nil = roaming is disabled).
+var RoamingPool roaming.Pool
+
+// RoamingLifetime holds the lifetime of the passive-roaming sessions
+// returned to the fNS (0 = stateless passive-roaming).
+var RoamingLifetime time.Duration
+
+// RoamingHandoverLifetime holds the lifetime of the handover-roaming
+// sessions returned to the sNS (0 = no expiry).
+var RoamingHandoverLifetime time.Duration
+
+// RoamingKEKLabel holds the label of the KEK used for wrapping the NwkSKey
+// when returning it to the fNS or sNS (the NwkSKey is sent unwrapped when
+// empty).
+var RoamingKEKLabel string
+
 // InstallationMargin (dB), used by the ADR engine
 var InstallationMargin float64
 
@@ -89,3 +141,26 @@ var RX1DROffset int
 
 // RX2DR hodsl the RX2 data-rate
 var RX2DR int
+
+// JoinRateLimitDevEUI holds the max. number of join-requests per DevEUI
+// within the JoinRateLimitInterval (0 = disabled).
+var JoinRateLimitDevEUI int
+
+// JoinRateLimitJoinEUI holds the max. number of join-requests per JoinEUI
+// within the JoinRateLimitInterval (0 = disabled).
+var JoinRateLimitJoinEUI int
+
+// JoinAttemptLimitDevEUI holds the max. number of (not yet authenticated)
+// join-requests per DevEUI within the JoinRateLimitInterval (0 = disabled).
+var JoinAttemptLimitDevEUI int
+
+// JoinRateLimitInterval holds the interval of the join-request rate limits.
+var JoinRateLimitInterval = time.Minute
+
+// JoinRateLimitMaxBackoff holds the max. back-off duration after exceeding
+// a join-request rate limit.
+var JoinRateLimitMaxBackoff = time.Hour
+
+// UnknownDevEUICacheTTL holds the duration for which unknown DevEUIs are
+// cached (0 = disabled).
+var UnknownDevEUICacheTTL = time.Minute
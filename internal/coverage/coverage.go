@@ -0,0 +1,256 @@
+// Package coverage implements the aggregation of the network coverage. For
+// uplinks of which the device location is known, the best RSSI and SNR per
+// gateway are stored per geohash cell.
+package coverage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+// GatewayCoverage contains the coverage of a gateway within a geohash cell.
+type GatewayCoverage struct {
+	MAC        lorawan.EUI64 `db:"mac"`
+	Geohash    string        `db:"geohash"`
+	RSSI       int           `db:"rssi"`
+	SNR        float64       `db:"snr"`
+	RXCount    int64         `db:"rx_count"`
+	LastSeenAt time.Time     `db:"last_seen_at"`
+
+	// Distance contains the distance in meters between the gateway and the
+	// center of the cell (0 when the gateway location is unknown).
+	Distance float64 `db:"-"`
+}
+
+// Cell returns the geohash cell.
+func (c GatewayCoverage) Cell() (Cell, error) {
+	return DecodeGeohash(c.Geohash)
+}
+
+// DeviceLocation contains the location of a device at the time of an
+// uplink.
+type DeviceLocation struct {
+	DevEUI    lorawan.EUI64
+	DevAddr   lorawan.DevAddr
+	FCnt      uint32
+	Latitude  float64
+	Longitude float64
+
+	// RXInfoSet contains the gateways which received the uplink.
+	RXInfoSet []gw.RXInfo
+}
+
+// HandleDeviceLocation aggregates the best RSSI and SNR of each gateway in
+// the RXInfoSet into the geohash cell (of the given precision) containing
+// the given device location. Gateways unknown to LoRa Server (e.g. of a
+// roaming partner) are ignored.
+// The last reported uplink (DevAddr and FCnt) is stored per device, a
+// repeated report of the same uplink is ignored.
+func HandleDeviceLocation(db *sqlx.DB, loc DeviceLocation, precision int) error {
+	if loc.Latitude < -90 || loc.Latitude > 90 || loc.Longitude < -180 || loc.Longitude > 180 {
+		return ErrInvalidLocation
+	}
+	if precision < 1 || precision > MaxPrecision {
+		return ErrInvalidPrecision
+	}
+
+	geohash := EncodeGeohash(loc.Latitude, loc.Longitude, precision)
+	cell, _ := DecodeGeohash(geohash)
+	cellLat, cellLong := cell.Center()
+	now := time.Now()
+
+	var duplicate bool
+	err := storage.Transaction(db, func(tx *sqlx.Tx) error {
+		// in case of concurrent reports of the same uplink, the second
+		// report waits for the first transaction and then affects no rows
+		res, err := tx.Exec(`
+			insert into device_coverage_report (
+				dev_eui,
+				dev_addr,
+				f_cnt,
+				reported_at
+			) values ($1, $2, $3, $4)
+			on conflict (dev_eui) do update
+			set
+				dev_addr = excluded.dev_addr,
+				f_cnt = excluded.f_cnt,
+				reported_at = excluded.reported_at
+			where
+				device_coverage_report.dev_addr != excluded.dev_addr
+				or device_coverage_report.f_cnt != excluded.f_cnt`,
+			loc.DevEUI[:],
+			loc.DevAddr[:],
+			loc.FCnt,
+			now,
+		)
+		if err != nil {
+			return errors.Wrap(err, "insert device coverage report error")
+		}
+		ra, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "get rows affected error")
+		}
+		if ra == 0 {
+			duplicate = true
+			return nil
+		}
+
+		for _, rxInfo := range loc.RXInfoSet {
+			// a foreign key violation would abort the transaction, therefore
+			// unknown gateways are filtered out by the where clause
+			_, err := tx.Exec(`
+				insert into gateway_coverage (
+					mac,
+					geohash,
+					location,
+					rssi,
+					snr,
+					rx_count,
+					first_seen_at,
+					last_seen_at
+				)
+				select $1, $2, point($3, $4), $5, $6, 1, $7, $7
+				where exists (select 1 from gateway where mac = $1)
+				on conflict (mac, geohash) do update
+				set
+					rssi = greatest(gateway_coverage.rssi, excluded.rssi),
+					snr = greatest(gateway_coverage.snr, excluded.snr),
+					rx_count = gateway_coverage.rx_count + 1,
+					last_seen_at = excluded.last_seen_at`,
+				rxInfo.MAC[:],
+				geohash,
+				cellLat,
+				cellLong,
+				rxInfo.RSSI,
+				rxInfo.LoRaSNR,
+				now,
+			)
+			if err != nil {
+				return errors.Wrap(err, "insert error")
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if duplicate {
+		log.WithFields(log.Fields{
+			"dev_eui": loc.DevEUI,
+			"f_cnt":   loc.FCnt,
+		}).Info("location of uplink has already been reported, ignoring")
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":  loc.DevEUI,
+		"geohash":  geohash,
+		"gw_count": len(loc.RXInfoSet),
+	}).Info("coverage updated")
+
+	return nil
+}
+
+// GetCoverage returns the coverage of the cells of which the center is
+// within the given bounding box, ordered by geohash. The cells are
+// aggregated to the given precision, which must not be greater than the
+// precision used for storing the coverage. When macs is not empty, only
+// the coverage of the given gateways is returned.
+func GetCoverage(db *sqlx.DB, bb Cell, precision int, macs []lorawan.EUI64) ([]GatewayCoverage, error) {
+	if bb.MinLatitude > bb.MaxLatitude || bb.MinLongitude > bb.MaxLongitude {
+		return nil, ErrInvalidLocation
+	}
+	if precision < 1 || precision > MaxPrecision {
+		return nil, ErrInvalidPrecision
+	}
+
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var out []GatewayCoverage
+	err := db.Select(&out, `
+		select
+			mac,
+			left(geohash, $5) as geohash,
+			max(rssi) as rssi,
+			max(snr) as snr,
+			sum(rx_count)::bigint as rx_count,
+			max(last_seen_at) as last_seen_at
+		from gateway_coverage
+		where
+			location <@ box(point($1, $2), point($3, $4))
+			and ($6::bytea[] is null or mac = any($6))
+		group by
+			mac,
+			left(geohash, $5)
+		order by
+			left(geohash, $5),
+			mac`,
+		bb.MinLatitude,
+		bb.MinLongitude,
+		bb.MaxLatitude,
+		bb.MaxLongitude,
+		precision,
+		pq.ByteaArray(macsB),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+
+	if err := setDistance(db, out); err != nil {
+		return nil, errors.Wrap(err, "set distance error")
+	}
+
+	return out, nil
+}
+
+// setDistance sets the distance between the gateway and the center of the
+// cell.
+func setDistance(db *sqlx.DB, coverage []GatewayCoverage) error {
+	macSet := make(map[lorawan.EUI64]struct{})
+	var macs []lorawan.EUI64
+	for _, c := range coverage {
+		if _, ok := macSet[c.MAC]; !ok {
+			macSet[c.MAC] = struct{}{}
+			macs = append(macs, c.MAC)
+		}
+	}
+	if len(macs) == 0 {
+		return nil
+	}
+
+	gws, err := gateway.GetGatewaysForMACs(db, macs)
+	if err != nil {
+		return errors.Wrap(err, "get gateways error")
+	}
+
+	for i := range coverage {
+		g := gws[coverage[i].MAC]
+		// a gateway without location is stored at (0, 0)
+		if g.Location.Latitude == 0 && g.Location.Longitude == 0 {
+			continue
+		}
+
+		cell, err := coverage[i].Cell()
+		if err != nil {
+			return errors.Wrap(err, "decode geohash error")
+		}
+		lat, long := cell.Center()
+		coverage[i].Distance = gateway.Distance(g.Location.Latitude, g.Location.Longitude, lat, long)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package coverage
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGeohash(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Latitude  float64
+			Longitude float64
+			Precision int
+			Expected  string
+		}{
+			{57.64911, 10.40744, 11, "u4pruydqqvj"},
+			{-25.382708, -49.265506, 9, "6gkzwgjzn"},
+			{52.0907, 5.1214, 7, "u178kdc"},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Expected, func() {
+				So(EncodeGeohash(tst.Latitude, tst.Longitude, tst.Precision), ShouldEqual, tst.Expected)
+
+				cell, err := DecodeGeohash(tst.Expected)
+				So(err, ShouldBeNil)
+				So(tst.Latitude, ShouldBeBetweenOrEqual, cell.MinLatitude, cell.MaxLatitude)
+				So(tst.Longitude, ShouldBeBetweenOrEqual, cell.MinLongitude, cell.MaxLongitude)
+			})
+		}
+
+		Convey("Then decoding an invalid geohash returns an error", func() {
+			_, err := DecodeGeohash("u4a")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCoverage(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with two gateways and a device", t, func() {
+		test.MustResetDB(db)
+
+		gws := []gateway.Gateway{
+			{
+				MAC:      lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+				Name:     "gw-1",
+				Location: gateway.GPSPoint{Latitude: 52.3702, Longitude: 4.8952},
+			},
+			{
+				MAC:  lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+				Name: "gw-2",
+			},
+		}
+		for i := range gws {
+			So(gateway.CreateGateway(db, &gws[i]), ShouldBeNil)
+		}
+
+		sp := storage.ServiceProfile{}
+		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
+		dp := storage.DeviceProfile{}
+		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
+		rp := storage.RoutingProfile{}
+		So(storage.CreateRoutingProfile(db, &rp), ShouldBeNil)
+		d := storage.Device{
+			DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+			RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+		}
+		So(storage.CreateDevice(db, &d), ShouldBeNil)
+
+		Convey("When handling device locations", func() {
+			So(HandleDeviceLocation(db, DeviceLocation{
+				DevEUI:    d.DevEUI,
+				DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:      10,
+				Latitude:  52.0907,
+				Longitude: 5.1214,
+				RXInfoSet: []gw.RXInfo{
+					{MAC: gws[0].MAC, RSSI: -110, LoRaSNR: -5},
+					{MAC: gws[1].MAC, RSSI: -90, LoRaSNR: 2},
+					{MAC: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, RSSI: -80, LoRaSNR: 5},
+				},
+			}, 7), ShouldBeNil)
+
+			So(HandleDeviceLocation(db, DeviceLocation{
+				DevEUI:    d.DevEUI,
+				DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+				FCnt:      11,
+				Latitude:  52.0907,
+				Longitude: 5.1214,
+				RXInfoSet: []gw.RXInfo{
+					{MAC: gws[0].MAC, RSSI: -100, LoRaSNR: -7},
+				},
+			}, 7), ShouldBeNil)
+
+			bb := Cell{MinLatitude: 52, MinLongitude: 5, MaxLatitude: 53, MaxLongitude: 6}
+
+			Convey("Then GetCoverage returns the best RSSI and SNR per gateway", func() {
+				cov, err := GetCoverage(db, bb, 7, nil)
+				So(err, ShouldBeNil)
+				So(cov, ShouldHaveLength, 2)
+
+				So(cov[0].MAC, ShouldEqual, gws[0].MAC)
+				So(cov[0].Geohash, ShouldEqual, "u178kdc")
+				So(cov[0].RSSI, ShouldEqual, -100)
+				So(cov[0].SNR, ShouldEqual, -5)
+				So(cov[0].RXCount, ShouldEqual, 2)
+				So(cov[0].Distance, ShouldBeBetween, 34000, 35000)
+
+				So(cov[1].MAC, ShouldEqual, gws[1].MAC)
+				So(cov[1].RSSI, ShouldEqual, -90)
+				So(cov[1].Distance, ShouldEqual, 0)
+			})
+
+			Convey("When the location of the last uplink is reported again", func() {
+				So(HandleDeviceLocation(db, DeviceLocation{
+					DevEUI:    d.DevEUI,
+					DevAddr:   lorawan.DevAddr{1, 2, 3, 4},
+					FCnt:      11,
+					Latitude:  52.0907,
+					Longitude: 5.1214,
+					RXInfoSet: []gw.RXInfo{
+						{MAC: gws[0].MAC, RSSI: -50, LoRaSNR: 10},
+					},
+				}, 7), ShouldBeNil)
+
+				Convey("Then the report is ignored", func() {
+					cov, err := GetCoverage(db, bb, 7, []lorawan.EUI64{gws[0].MAC})
+					So(err, ShouldBeNil)
+					So(cov, ShouldHaveLength, 1)
+					So(cov[0].RSSI, ShouldEqual, -100)
+					So(cov[0].RXCount, ShouldEqual, 2)
+				})
+			})
+
+			Convey("Then GetCoverage aggregates to the given precision", func() {
+				cov, err := GetCoverage(db, bb, 5, []lorawan.EUI64{gws[0].MAC})
+				So(err, ShouldBeNil)
+				So(cov, ShouldHaveLength, 1)
+				So(cov[0].Geohash, ShouldEqual, "u178k")
+			})
+
+			Convey("Then GetCoverage does not return cells outside the bounding box", func() {
+				cov, err := GetCoverage(db, Cell{MinLatitude: 40, MinLongitude: 0, MaxLatitude: 50, MaxLongitude: 1}, 7, nil)
+				So(err, ShouldBeNil)
+				So(cov, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Then an invalid location returns an error", func() {
+			So(HandleDeviceLocation(db, DeviceLocation{Latitude: 91}, 7), ShouldEqual, ErrInvalidLocation)
+		})
+	})
+}
@@ -0,0 +1,9 @@
+package coverage
+
+import "errors"
+
+// coverage errors
+var (
+	ErrInvalidLocation  = errors.New("invalid location")
+	ErrInvalidPrecision = errors.New("invalid geohash precision")
+)
@@ -0,0 +1,95 @@
+package coverage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// geohashAlphabet contains the geohash base32 alphabet.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxPrecision defines the max supported geohash precision.
+const MaxPrecision = 12
+
+// Cell defines the bounds of a geohash cell.
+type Cell struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Center returns the center of the cell.
+func (c Cell) Center() (float64, float64) {
+	return (c.MinLatitude + c.MaxLatitude) / 2, (c.MinLongitude + c.MaxLongitude) / 2
+}
+
+// EncodeGeohash returns the geohash of the given precision (number of
+// characters) containing the given location.
+func EncodeGeohash(lat, long float64, precision int) string {
+	c := Cell{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180}
+	out := make([]byte, 0, precision)
+
+	var even = true // the bits alternate between longitude (even) and latitude
+	for len(out) < precision {
+		var idx int
+		for bit := 4; bit >= 0; bit-- {
+			if even {
+				mid := (c.MinLongitude + c.MaxLongitude) / 2
+				if long >= mid {
+					idx |= 1 << uint(bit)
+					c.MinLongitude = mid
+				} else {
+					c.MaxLongitude = mid
+				}
+			} else {
+				mid := (c.MinLatitude + c.MaxLatitude) / 2
+				if lat >= mid {
+					idx |= 1 << uint(bit)
+					c.MinLatitude = mid
+				} else {
+					c.MaxLatitude = mid
+				}
+			}
+			even = !even
+		}
+		out = append(out, geohashAlphabet[idx])
+	}
+
+	return string(out)
+}
+
+// DecodeGeohash returns the cell of the given geohash.
+func DecodeGeohash(geohash string) (Cell, error) {
+	c := Cell{MinLatitude: -90, MaxLatitude: 90, MinLongitude: -180, MaxLongitude: 180}
+
+	var even = true
+	for _, r := range geohash {
+		idx := strings.IndexRune(geohashAlphabet, r)
+		if idx == -1 {
+			return c, fmt.Errorf("invalid geohash character '%c'", r)
+		}
+
+		for bit := 4; bit >= 0; bit-- {
+			set := idx&(1<<uint(bit)) != 0
+			if even {
+				mid := (c.MinLongitude + c.MaxLongitude) / 2
+				if set {
+					c.MinLongitude = mid
+				} else {
+					c.MaxLongitude = mid
+				}
+			} else {
+				mid := (c.MinLatitude + c.MaxLatitude) / 2
+				if set {
+					c.MinLatitude = mid
+				} else {
+					c.MaxLatitude = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,156 @@
+// Package devaddr implements the DevAddr allocation. It takes the NetID type
+// into account (the length of the DevAddr prefix depends on the NetID type,
+// see the LoRaWAN Backend Interfaces specification) and supports allocating
+// from configured DevAddr (sub-)ranges.
+package devaddr
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Errors returned by this package.
+var (
+	ErrInvalidRange = errors.New("invalid DevAddr range")
+	ErrNoRanges     = errors.New("no DevAddr ranges given")
+)
+
+// nwkIDBits contains the number of NwkID bits by NetID type.
+var nwkIDBits = [8]uint{6, 6, 9, 11, 12, 13, 15, 17}
+
+// NetIDType returns the type of the given NetID (the 3 MSB).
+func NetIDType(netID lorawan.NetID) int {
+	return int(netID[0] >> 5)
+}
+
+// PrefixLength returns the number of DevAddr MSB which are set by the given
+// NetID (the type prefix followed by the NwkID).
+func PrefixLength(netID lorawan.NetID) int {
+	t := NetIDType(netID)
+	return t + 1 + int(nwkIDBits[t])
+}
+
+// NetIDRange returns the DevAddr range of the given NetID.
+func NetIDRange(netID lorawan.NetID) Range {
+	t := uint(NetIDType(netID))
+	id := uint32(netID[0])<<16 | uint32(netID[1])<<8 | uint32(netID[2])
+
+	// the type prefix consists of t ones followed by a zero
+	typePrefix := uint32(1<<t-1) << 1
+	nwkID := id & (1<<nwkIDBits[t] - 1)
+	addrBits := uint(32 - PrefixLength(netID))
+
+	start := (typePrefix<<nwkIDBits[t] | nwkID) << addrBits
+	return Range{
+		Start: toDevAddr(start),
+		End:   toDevAddr(start | (1<<addrBits - 1)),
+	}
+}
+
+// IsNetIDMember returns true when the given DevAddr belongs to the given
+// NetID.
+func IsNetIDMember(netID lorawan.NetID, devAddr lorawan.DevAddr) bool {
+	return NetIDRange(netID).Contains(devAddr)
+}
+
+// Range defines a DevAddr range (both start and end are inclusive).
+type Range struct {
+	Start lorawan.DevAddr
+	End   lorawan.DevAddr
+}
+
+// ParseRange parses a range in the format START-END (hex encoded).
+func ParseRange(s string) (Range, error) {
+	var r Range
+
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return r, errors.Wrap(ErrInvalidRange, "expected START-END")
+	}
+	if err := r.Start.UnmarshalText([]byte(strings.TrimSpace(parts[0]))); err != nil {
+		return r, errors.Wrap(err, "parse start error")
+	}
+	if err := r.End.UnmarshalText([]byte(strings.TrimSpace(parts[1]))); err != nil {
+		return r, errors.Wrap(err, "parse end error")
+	}
+	if fromDevAddr(r.Start) > fromDevAddr(r.End) {
+		return r, errors.Wrap(ErrInvalidRange, "start must be before end")
+	}
+
+	return r, nil
+}
+
+// String implements fmt.Stringer.
+func (r Range) String() string {
+	return fmt.Sprintf("%s-%s", r.Start, r.End)
+}
+
+// Contains returns true when the given DevAddr is within the range.
+func (r Range) Contains(devAddr lorawan.DevAddr) bool {
+	a := fromDevAddr(devAddr)
+	return a >= fromDevAddr(r.Start) && a <= fromDevAddr(r.End)
+}
+
+// Validate validates that the range is within the DevAddr range of the
+// given NetID.
+func (r Range) Validate(netID lorawan.NetID) error {
+	if fromDevAddr(r.Start) > fromDevAddr(r.End) {
+		return errors.Wrap(ErrInvalidRange, "start must be before end")
+	}
+	nr := NetIDRange(netID)
+	if !nr.Contains(r.Start) || !nr.Contains(r.End) {
+		return errors.Wrapf(ErrInvalidRange, "%s is not within the NetID range %s", r, nr)
+	}
+	return nil
+}
+
+// size returns the number of addresses within the range.
+func (r Range) size() uint64 {
+	return uint64(fromDevAddr(r.End)) - uint64(fromDevAddr(r.Start)) + 1
+}
+
+// Random returns a random DevAddr within the given ranges. Each address has
+// the same probability of being returned, independent of the size of the
+// range it belongs to.
+func Random(ranges []Range) (lorawan.DevAddr, error) {
+	var total uint64
+	for _, r := range ranges {
+		total += r.size()
+	}
+	if total == 0 {
+		return lorawan.DevAddr{}, ErrNoRanges
+	}
+
+	n, err := rand.Int(rand.Reader, new(big.Int).SetUint64(total))
+	if err != nil {
+		return lorawan.DevAddr{}, errors.Wrap(err, "read random number error")
+	}
+	i := n.Uint64()
+
+	for _, r := range ranges {
+		if i < r.size() {
+			return toDevAddr(fromDevAddr(r.Start) + uint32(i)), nil
+		}
+		i -= r.size()
+	}
+
+	// this should never happen
+	return lorawan.DevAddr{}, ErrNoRanges
+}
+
+func toDevAddr(a uint32) lorawan.DevAddr {
+	var d lorawan.DevAddr
+	binary.BigEndian.PutUint32(d[:], a)
+	return d
+}
+
+func fromDevAddr(d lorawan.DevAddr) uint32 {
+	return binary.BigEndian.Uint32(d[:])
+}
@@ -0,0 +1,98 @@
+package devaddr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+func TestNetIDRange(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			NetID        lorawan.NetID
+			Type         int
+			PrefixLength int
+			Range        Range
+		}{
+			{lorawan.NetID{0x01, 0x02, 0x03}, 0, 7, Range{lorawan.DevAddr{0x06, 0x00, 0x00, 0x00}, lorawan.DevAddr{0x07, 0xff, 0xff, 0xff}}},
+			{lorawan.NetID{0x00, 0x00, 0x3f}, 0, 7, Range{lorawan.DevAddr{0x7e, 0x00, 0x00, 0x00}, lorawan.DevAddr{0x7f, 0xff, 0xff, 0xff}}},
+			{lorawan.NetID{0x20, 0x00, 0x01}, 1, 8, Range{lorawan.DevAddr{0x81, 0x00, 0x00, 0x00}, lorawan.DevAddr{0x81, 0xff, 0xff, 0xff}}},
+			{lorawan.NetID{0x40, 0x00, 0x01}, 2, 12, Range{lorawan.DevAddr{0xc0, 0x10, 0x00, 0x00}, lorawan.DevAddr{0xc0, 0x1f, 0xff, 0xff}}},
+			{lorawan.NetID{0x60, 0x00, 0x01}, 3, 15, Range{lorawan.DevAddr{0xe0, 0x02, 0x00, 0x00}, lorawan.DevAddr{0xe0, 0x03, 0xff, 0xff}}},
+			{lorawan.NetID{0xc0, 0x00, 0x05}, 6, 22, Range{lorawan.DevAddr{0xfc, 0x00, 0x14, 0x00}, lorawan.DevAddr{0xfc, 0x00, 0x17, 0xff}}},
+			{lorawan.NetID{0xe0, 0x00, 0x01}, 7, 25, Range{lorawan.DevAddr{0xfe, 0x00, 0x00, 0x80}, lorawan.DevAddr{0xfe, 0x00, 0x00, 0xff}}},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing NetID %s [%d]", test.NetID, i), func() {
+				So(NetIDType(test.NetID), ShouldEqual, test.Type)
+				So(PrefixLength(test.NetID), ShouldEqual, test.PrefixLength)
+				So(NetIDRange(test.NetID), ShouldResemble, test.Range)
+				So(IsNetIDMember(test.NetID, test.Range.Start), ShouldBeTrue)
+				So(IsNetIDMember(test.NetID, test.Range.End), ShouldBeTrue)
+			})
+		}
+	})
+}
+
+func TestRange(t *testing.T) {
+	Convey("Given NetID 010203", t, func() {
+		netID := lorawan.NetID{1, 2, 3}
+
+		Convey("When parsing a valid range", func() {
+			r, err := ParseRange("06000000-0600ffff")
+			So(err, ShouldBeNil)
+			So(r, ShouldResemble, Range{lorawan.DevAddr{0x06, 0x00, 0x00, 0x00}, lorawan.DevAddr{0x06, 0x00, 0xff, 0xff}})
+			So(r.String(), ShouldEqual, "06000000-0600ffff")
+
+			Convey("Then it is valid for the NetID", func() {
+				So(r.Validate(netID), ShouldBeNil)
+			})
+
+			Convey("Then it is not valid for an other NetID", func() {
+				So(errors.Cause(r.Validate(lorawan.NetID{3, 2, 1})), ShouldEqual, ErrInvalidRange)
+			})
+
+			Convey("Then Contains returns the expected values", func() {
+				So(r.Contains(lorawan.DevAddr{0x06, 0x00, 0x12, 0x34}), ShouldBeTrue)
+				So(r.Contains(lorawan.DevAddr{0x06, 0x01, 0x00, 0x00}), ShouldBeFalse)
+			})
+		})
+
+		Convey("Then parsing invalid ranges returns an error", func() {
+			for _, s := range []string{"06000000", "0600ffff-06000000", "060000-0600ffff"} {
+				_, err := ParseRange(s)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestRandom(t *testing.T) {
+	Convey("Given two small DevAddr ranges", t, func() {
+		ranges := []Range{
+			{lorawan.DevAddr{0x06, 0x00, 0x00, 0x00}, lorawan.DevAddr{0x06, 0x00, 0x00, 0x01}},
+			{lorawan.DevAddr{0x06, 0x00, 0x01, 0x00}, lorawan.DevAddr{0x06, 0x00, 0x01, 0x01}},
+		}
+
+		Convey("Then Random returns all addresses within these ranges", func() {
+			seen := make(map[lorawan.DevAddr]struct{})
+			for i := 0; i < 1000; i++ {
+				devAddr, err := Random(ranges)
+				So(err, ShouldBeNil)
+				So(ranges[0].Contains(devAddr) || ranges[1].Contains(devAddr), ShouldBeTrue)
+				seen[devAddr] = struct{}{}
+			}
+			So(seen, ShouldHaveLength, 4)
+		})
+	})
+
+	Convey("Then Random without ranges returns an error", t, func() {
+		_, err := Random(nil)
+		So(err, ShouldEqual, ErrNoRanges)
+	})
+}
@@ -65,7 +65,7 @@ func getDataTXInfoForRX2(ctx *DataContext) error {
 	ctx.TXInfo = gw.TXInfo{
 		MAC:         rxInfo.MAC,
 		Immediately: true,
-		Frequency:   int(common.Band.RX2Frequency),
+		Frequency:   getRX2Frequency(ctx.DeviceSession),
 		Power:       common.Band.DefaultTXPower,
 		DataRate:    common.Band.DataRates[int(ctx.DeviceSession.RX2DR)],
 		CodeRate:    "4/5",
@@ -264,7 +264,7 @@ func getDataDownTXInfoAndDR(ds storage.DeviceSession, rxInfo gw.RXInfo) (gw.TXIn
 		txInfo.DataRate = common.Band.DataRates[dr]
 
 		// rx2 frequency
-		txInfo.Frequency = common.Band.RX2Frequency
+		txInfo.Frequency = getRX2Frequency(ds)
 
 		// rx2 timestamp (rx1 + 1 sec)
 		txInfo.Timestamp = rxInfo.Timestamp + uint32(common.Band.ReceiveDelay1/time.Microsecond)
@@ -272,6 +272,7 @@ func getDataDownTXInfoAndDR(ds storage.DeviceSession, rxInfo gw.RXInfo) (gw.TXIn
 			txInfo.Timestamp = rxInfo.Timestamp + uint32(time.Duration(ds.RXDelay)*time.Second/time.Microsecond)
 		}
 		txInfo.Timestamp = txInfo.Timestamp + uint32(time.Second/time.Microsecond)
+		txInfo.RXWindow = gw.RXWindow2
 	} else {
 		return txInfo, dr, fmt.Errorf("unknown RXWindow option %d", ds.RXWindow)
 	}
@@ -279,9 +280,23 @@ func getDataDownTXInfoAndDR(ds storage.DeviceSession, rxInfo gw.RXInfo) (gw.TXIn
 	return txInfo, dr, nil
 }
 
+// getRX2Frequency returns the RX2 frequency of the device-session or the
+// default RX2 frequency of the band when not set.
+func getRX2Frequency(ds storage.DeviceSession) int {
+	if ds.RX2Frequency != 0 {
+		return ds.RX2Frequency
+	}
+	return common.Band.RX2Frequency
+}
+
 // getDataDownFromApplication gets the downlink data from the application
 // (if any). On error the error is logged.
 func getDataDownFromApplication(ds storage.DeviceSession, dr int) *as.GetDataDownResponse {
+	// the application-server is reached through the hNS (handover roaming)
+	if ds.HomeNetID != nil {
+		return getDataDownFromHomeNS(ds, dr)
+	}
+
 	rp, err := storage.GetRoutingProfile(common.DB, ds.RoutingProfileID)
 	if err != nil {
 		log.WithError(err).Error("get routing-profile error")
@@ -29,6 +29,11 @@ var Flow = newFlow().JoinResponse(
 	saveDeviceSession,
 ).ProprietaryDown(
 	sendProprietaryDown,
+).MulticastDown(
+	validateMulticastDown,
+	getMulticastGatewayMACs,
+	incrementMulticastFCnt,
+	sendMulticastDown,
 )
 
 // DataContext holds the context of a downlink transmission.
@@ -103,6 +108,7 @@ func (ctx DataContext) Validate() error {
 
 // JoinContext holds the context of a join response.
 type JoinContext struct {
+	DeviceProfile storage.DeviceProfile
 	DeviceSession storage.DeviceSession
 	TXInfo        gw.TXInfo
 	PHYPayload    lorawan.PHYPayload
@@ -118,6 +124,22 @@ type ProprietaryDownContext struct {
 	DR          int
 }
 
+// MulticastContext holds the context of a multicast downlink transmission.
+type MulticastContext struct {
+	// MulticastGroup holds the multicast-group to which to send the data.
+	MulticastGroup storage.MulticastGroup
+
+	// GatewayMACs contains the MACs of the gateways to use for transmission.
+	GatewayMACs []lorawan.EUI64
+
+	// FPort to use for transmission.
+	FPort uint8
+
+	// Data contains the bytes to send (encrypted by the application-server
+	// using the McAppSKey).
+	Data []byte
+}
+
 // UplinkResponseTask is the signature of an uplink response task.
 type UplinkResponseTask func(*DataContext) error
 
@@ -130,12 +152,16 @@ type JoinResponseTask func(*JoinContext) error
 // ProprietaryDownTask is the signature of a proprietary down task.
 type ProprietaryDownTask func(*ProprietaryDownContext) error
 
+// MulticastDownTask is the signature of a multicast down task.
+type MulticastDownTask func(*MulticastContext) error
+
 // Flow contains one or multiple tasks to execute.
 type flow struct {
 	uplinkResponseTasks  []UplinkResponseTask
 	pushDataDownTasks    []PushDataDownTask
 	joinResponseTasks    []JoinResponseTask
 	proprietaryDownTasks []ProprietaryDownTask
+	multicastDownTasks   []MulticastDownTask
 }
 
 func newFlow() *flow {
@@ -166,6 +192,12 @@ func (f *flow) ProprietaryDown(tasks ...ProprietaryDownTask) *flow {
 	return f
 }
 
+// MulticastDown adds multicast down tasks to the flow.
+func (f *flow) MulticastDown(tasks ...MulticastDownTask) *flow {
+	f.multicastDownTasks = tasks
+	return f
+}
+
 // RunUplinkResponse runs the uplink response flow.
 func (f *flow) RunUplinkResponse(sp storage.ServiceProfile, ds storage.DeviceSession, adr, mustSend, ack bool) error {
 	ctx := DataContext{
@@ -212,8 +244,9 @@ func (f *flow) RunPushDataDown(sp storage.ServiceProfile, ds storage.DeviceSessi
 }
 
 // RunJoinResponse runs the join response flow.
-func (f *flow) RunJoinResponse(ds storage.DeviceSession, phy lorawan.PHYPayload) error {
+func (f *flow) RunJoinResponse(dp storage.DeviceProfile, ds storage.DeviceSession, phy lorawan.PHYPayload) error {
 	ctx := JoinContext{
+		DeviceProfile: dp,
 		DeviceSession: ds,
 		PHYPayload:    phy,
 	}
@@ -254,3 +287,24 @@ func (f *flow) RunProprietaryDown(macPayload []byte, mic lorawan.MIC, gwMACs []l
 
 	return nil
 }
+
+// RunMulticastDown runs the multicast down flow.
+func (f *flow) RunMulticastDown(mg storage.MulticastGroup, fPort uint8, data []byte) error {
+	ctx := MulticastContext{
+		MulticastGroup: mg,
+		FPort:          fPort,
+		Data:           data,
+	}
+
+	for _, t := range f.multicastDownTasks {
+		if err := t(&ctx); err != nil {
+			if err == ErrAbort {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
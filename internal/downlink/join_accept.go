@@ -47,8 +47,19 @@ func getJoinAcceptTXInfo(ctx *JoinContext) error {
 		}
 	} else if ctx.DeviceSession.RXWindow == storage.RX2 {
 		ctx.TXInfo.Timestamp = rxInfo.Timestamp + uint32(common.Band.JoinAcceptDelay2/time.Microsecond)
-		ctx.TXInfo.DataRate = common.Band.DataRates[common.Band.RX2DataRate]
-		ctx.TXInfo.Frequency = common.Band.RX2Frequency
+
+		// the device-profile rx2 settings reflect the (factory) settings of
+		// the device before activation, else the band defaults apply
+		rx2DR := common.Band.RX2DataRate
+		if ctx.DeviceProfile.RXDataRate2Set {
+			rx2DR = ctx.DeviceProfile.DeviceProfile.RXDataRate2
+		}
+		if rx2DR > len(common.Band.DataRates)-1 {
+			return errors.Wrapf(ErrInvalidDataRate, "dr: %d (max dr: %d)", rx2DR, len(common.Band.DataRates)-1)
+		}
+		ctx.TXInfo.DataRate = common.Band.DataRates[rx2DR]
+		ctx.TXInfo.Frequency = ctx.DeviceProfile.GetRXFreq2()
+		ctx.TXInfo.RXWindow = gw.RXWindow2
 	} else {
 		return fmt.Errorf("unknown RXWindow defined %d", ctx.DeviceSession.RXWindow)
 	}
@@ -0,0 +1,102 @@
+package downlink
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+)
+
+func validateMulticastDown(ctx *MulticastContext) error {
+	if ctx.FPort == 0 {
+		return ErrFPortMustNotBeZero
+	}
+
+	if ctx.FPort > 224 {
+		return ErrInvalidAppFPort
+	}
+
+	if ctx.MulticastGroup.DR > len(common.Band.DataRates)-1 {
+		return errors.Wrapf(ErrInvalidDataRate, "dr: %d (max dr: %d)", ctx.MulticastGroup.DR, len(common.Band.DataRates)-1)
+	}
+
+	plSize := common.Band.MaxPayloadSize[ctx.MulticastGroup.DR].N
+	if len(ctx.Data) > plSize {
+		return errors.Wrapf(ErrMaxPayloadSizeExceeded, "(max: %d, got: %d)", plSize, len(ctx.Data))
+	}
+
+	return nil
+}
+
+func getMulticastGatewayMACs(ctx *MulticastContext) error {
+	macs, err := storage.GetGatewaysForMulticastGroup(common.DB, common.RedisPool, ctx.MulticastGroup.ID)
+	if err != nil {
+		return errors.Wrap(err, "get gateways for multicast-group error")
+	}
+
+	if len(macs) == 0 {
+		return ErrNoLastRXInfoSet
+	}
+
+	ctx.GatewayMACs = macs
+	return nil
+}
+
+func incrementMulticastFCnt(ctx *MulticastContext) error {
+	// the frame-counter is incremented before sending, so that a concurrent
+	// downlink can't use the same frame-counter
+	if err := storage.IncrementMulticastGroupFCnt(common.DB, ctx.MulticastGroup.ID, ctx.MulticastGroup.FCnt); err != nil {
+		return errors.Wrap(err, "increment multicast-group frame-counter error")
+	}
+	return nil
+}
+
+func sendMulticastDown(ctx *MulticastContext) error {
+	frequency := ctx.MulticastGroup.Frequency
+	if frequency == 0 {
+		frequency = common.Band.RX2Frequency
+	}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.UnconfirmedDataDown,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: ctx.MulticastGroup.MCAddr,
+				FCnt:    ctx.MulticastGroup.FCnt,
+			},
+			FPort: &ctx.FPort,
+			FRMPayload: []lorawan.Payload{
+				&lorawan.DataPayload{Bytes: ctx.Data},
+			},
+		},
+	}
+
+	if err := phy.SetMIC(ctx.MulticastGroup.MCNwkSKey); err != nil {
+		return errors.Wrap(err, "set MIC error")
+	}
+
+	for _, mac := range ctx.GatewayMACs {
+		txInfo := gw.TXInfo{
+			MAC:         mac,
+			Immediately: true,
+			Frequency:   frequency,
+			Power:       common.Band.DefaultTXPower,
+			DataRate:    common.Band.DataRates[ctx.MulticastGroup.DR],
+			CodeRate:    "4/5",
+		}
+
+		if err := common.Gateway.SendTXPacket(gw.TXPacket{
+			TXInfo:     txInfo,
+			PHYPayload: phy,
+		}); err != nil {
+			return errors.Wrap(err, "send tx packet to gateway error")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package downlink
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/roaming"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// HandleRoamingDataDown sends the given downlink, received from the sNS of
+// the roaming partner with the given NetID (passive-roaming fNS), through
+// the gateway of the uplink. The rx-info of the uplink is obtained using
+// the ULToken (see uplink.getULMetaData), which must have been sent to this
+// roaming partner.
+func HandleRoamingDataDown(netID lorawan.NetID, pl backend.XmitDataReqPayload) error {
+	if pl.DLMetaData == nil {
+		return errors.Wrap(roaming.ErrMalformedRequest, "downlink meta-data expected")
+	}
+	dlMetaData := *pl.DLMetaData
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(pl.PHYPayload); err != nil {
+		return errors.Wrap(roaming.ErrMalformedRequest, err.Error())
+	}
+
+	var rxInfo *gw.RXInfo
+	for _, gwInfo := range dlMetaData.GWInfo {
+		t, err := roaming.GetULToken(common.RedisPool, netID, gwInfo.ULToken)
+		if err != nil {
+			if errors.Cause(err) == roaming.ErrDoesNotExist {
+				continue
+			}
+			return errors.Wrap(err, "get ul token error")
+		}
+		rxInfo = &t.RXInfo
+		break
+	}
+	if rxInfo == nil {
+		return errors.Wrap(roaming.ErrMalformedRequest, "gateway info with valid ULToken expected")
+	}
+
+	txInfo := gw.TXInfo{
+		MAC:         rxInfo.MAC,
+		CodeRate:    rxInfo.CodeRate,
+		Power:       common.Band.DefaultTXPower,
+		Immediately: dlMetaData.ClassMode == "C",
+	}
+
+	rxDelay := common.Band.ReceiveDelay1
+	if dlMetaData.RXDelay1 > 0 {
+		rxDelay = time.Duration(dlMetaData.RXDelay1) * time.Second
+	}
+
+	freq, dr := dlMetaData.DLFreq1, dlMetaData.DataRate1
+	if freq == 0 {
+		// use the second receive window
+		freq, dr = dlMetaData.DLFreq2, dlMetaData.DataRate2
+		rxDelay += time.Second
+		txInfo.RXWindow = gw.RXWindow2
+	}
+	if freq == 0 {
+		return errors.Wrap(roaming.ErrMalformedRequest, "DLFreq1 or DLFreq2 expected")
+	}
+	if dr < 0 || dr > len(common.Band.DataRates)-1 {
+		return errors.Wrap(ErrInvalidDataRate, "get data-rate error")
+	}
+
+	txInfo.Frequency = int(freq*1000000 + 0.5)
+	txInfo.DataRate = common.Band.DataRates[dr]
+	if !txInfo.Immediately {
+		txInfo.Timestamp = rxInfo.Timestamp + uint32(rxDelay/time.Microsecond)
+	}
+
+	if err := common.Gateway.SendTXPacket(gw.TXPacket{
+		TXInfo:     txInfo,
+		PHYPayload: phy,
+	}); err != nil {
+		return errors.Wrap(err, "send tx packet to gateway error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":        txInfo.MAC,
+		"class_mode": dlMetaData.ClassMode,
+	}).Info("roaming: downlink from sNS sent to gateway")
+
+	return nil
+}
+
+// HandleHandoverDataDown handles the given downlink payload, sent by the
+// hNS of the roaming partner with the given NetID (handover-roaming sNS).
+// Class-C downlinks are sent immediately, Class-A downlinks are sent on
+// the next uplink of the device.
+func HandleHandoverDataDown(netID lorawan.NetID, pl backend.XmitDataReqPayload) error {
+	if pl.DLMetaData == nil {
+		return errors.Wrap(roaming.ErrMalformedRequest, "downlink meta-data expected")
+	}
+	dlMetaData := *pl.DLMetaData
+
+	ds, err := storage.GetDeviceSession(common.RedisPool, dlMetaData.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return roaming.ErrUnknownDevEUI
+		}
+		return errors.Wrap(err, "get device-session error")
+	}
+	if ds.HomeNetID == nil || *ds.HomeNetID != netID {
+		return roaming.ErrUnknownDevEUI
+	}
+
+	if dlMetaData.FPort < 1 || dlMetaData.FPort > 255 {
+		return errors.Wrapf(roaming.ErrMalformedRequest, "invalid FPort: %d", dlMetaData.FPort)
+	}
+	if uint32(dlMetaData.FCntDown) != ds.FCntDown {
+		return errors.Wrapf(roaming.ErrMalformedRequest, "invalid FCntDown (expected: %d)", ds.FCntDown)
+	}
+
+	logFields := log.Fields{
+		"net_id":     netID,
+		"dev_eui":    ds.DevEUI,
+		"fcnt":       ds.FCntDown,
+		"class_mode": dlMetaData.ClassMode,
+	}
+
+	if dlMetaData.ClassMode == "C" {
+		sp, err := storage.GetServiceProfile(common.DB, ds.ServiceProfileID)
+		if err != nil {
+			return errors.Wrap(err, "get service-profile error")
+		}
+
+		if err := Flow.RunPushDataDown(sp, ds, dlMetaData.Confirmed, uint8(dlMetaData.FPort), pl.FRMPayload); err != nil {
+			return errors.Wrap(err, "run push data down flow error")
+		}
+
+		log.WithFields(logFields).Info("roaming: downlink payload from hNS sent")
+		return nil
+	}
+
+	err = roaming.SaveHandoverDownlink(common.RedisPool, ds.DevEUI, roaming.HandoverDownlink{
+		FPort:      uint8(dlMetaData.FPort),
+		FCntDown:   ds.FCntDown,
+		Confirmed:  dlMetaData.Confirmed,
+		FRMPayload: pl.FRMPayload,
+	})
+	if err != nil {
+		return errors.Wrap(err, "save handover downlink error")
+	}
+
+	log.WithFields(logFields).Info("roaming: downlink payload from hNS queued")
+	return nil
+}
+
+// PushHandoverDataDown sends the given (Class-C) downlink payload to the
+// sNS serving the device of the given handover-roaming session (hNS).
+func PushHandoverDataDown(hs roaming.HandoverSession, confirmed bool, fPort uint8, data []byte) error {
+	if fPort == 0 {
+		return ErrFPortMustNotBeZero
+	}
+
+	if common.RoamingPool == nil {
+		return roaming.ErrNoAgreement
+	}
+	client, err := common.RoamingPool.Get(hs.NetID)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.XmitDataReq(context.Background(), backend.XmitDataReqPayload{
+		FRMPayload: backend.HEXBytes(data),
+		DLMetaData: &backend.DLMetaData{
+			DevEUI:    hs.DevEUI,
+			FPort:     int(fPort),
+			FCntDown:  int(hs.FCntDown),
+			Confirmed: confirmed,
+			ClassMode: "C",
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "xmit data request error")
+	}
+
+	// the sNS increments the FCntDown, it is synchronized on the next uplink
+	hs.FCntDown++
+	if err := roaming.SaveHandoverSession(common.RedisPool, hs, common.NodeSessionTTL); err != nil {
+		return errors.Wrap(err, "save handover session error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":  hs.NetID,
+		"dev_eui": hs.DevEUI,
+	}).Info("roaming: downlink payload sent to sNS")
+
+	return nil
+}
+
+// getDataDownFromHomeNS returns the downlink payload received from the hNS
+// (handover roaming), or nil when there is nothing to send.
+func getDataDownFromHomeNS(ds storage.DeviceSession, dr int) *as.GetDataDownResponse {
+	dl, err := roaming.GetAndDeleteHandoverDownlink(common.RedisPool, ds.DevEUI)
+	if err != nil {
+		if errors.Cause(err) != roaming.ErrDoesNotExist {
+			log.WithError(err).Error("get handover downlink error")
+		}
+		return nil
+	}
+
+	logFields := log.Fields{
+		"dev_eui": ds.DevEUI,
+		"fcnt":    ds.FCntDown,
+	}
+
+	// the payload is encrypted using the FCntDown known by the hNS
+	if dl.FCntDown != ds.FCntDown {
+		log.WithFields(logFields).Warning("roaming: discarding downlink payload from hNS with outdated FCntDown")
+		return nil
+	}
+
+	if len(dl.FRMPayload) > common.Band.MaxPayloadSize[dr].N {
+		log.WithFields(logFields).Warning("roaming: downlink payload from hNS exceeds max payload size")
+		return nil
+	}
+
+	return &as.GetDataDownResponse{
+		Data:      dl.FRMPayload,
+		Confirmed: dl.Confirmed,
+		FPort:     uint32(dl.FPort),
+	}
+}
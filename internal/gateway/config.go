@@ -0,0 +1,177 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// GetConfigVersion returns the configuration version for the given
+// channel-configuration. As creating, updating or deleting an extra channel
+// also updates the UpdatedAt timestamp of the channel-configuration, the
+// version changes on every change of the gateway configuration.
+func GetConfigVersion(cf ChannelConfiguration) string {
+	return fmt.Sprintf("%d-%d", cf.ID, cf.UpdatedAt.UnixNano()/1000)
+}
+
+// GetDesiredConfigVersion returns the configuration version the given
+// gateway should have. An empty string is returned when the gateway does
+// not have a channel-configuration.
+func GetDesiredConfigVersion(db *sqlx.DB, g Gateway) (string, error) {
+	if g.ChannelConfigurationID == nil {
+		return "", nil
+	}
+
+	cf, err := GetChannelConfiguration(db, *g.ChannelConfigurationID)
+	if err != nil {
+		return "", errors.Wrap(err, "get channel-configuration error")
+	}
+
+	return GetConfigVersion(cf), nil
+}
+
+// GetConfigPacket returns the configuration for the given gateway, based
+// on its channel-configuration and extra channels. ErrDoesNotExist is
+// returned when the gateway does not have a channel-configuration.
+func GetConfigPacket(db *sqlx.DB, mac lorawan.EUI64) (gw.GatewayConfigPacket, error) {
+	out := gw.GatewayConfigPacket{
+		MAC: mac,
+	}
+
+	g, err := GetGateway(db, mac)
+	if err != nil {
+		return out, errors.Wrap(err, "get gateway error")
+	}
+	if g.ChannelConfigurationID == nil {
+		return out, ErrDoesNotExist
+	}
+
+	cf, err := GetChannelConfiguration(db, *g.ChannelConfigurationID)
+	if err != nil {
+		return out, errors.Wrap(err, "get channel-configuration error")
+	}
+	out.Version = GetConfigVersion(cf)
+
+	for _, i := range cf.Channels {
+		if int(i) < 0 || int(i) >= len(common.Band.UplinkChannels) {
+			return out, ErrInvalidChannel
+		}
+
+		c := gw.ConfigChannel{
+			Frequency: common.Band.UplinkChannels[i].Frequency,
+		}
+
+		for _, drIndex := range common.Band.UplinkChannels[i].DataRates {
+			dr := common.Band.DataRates[drIndex]
+			c.Modulation = dr.Modulation
+			c.Bandwidth = dr.Bandwidth
+
+			switch dr.Modulation {
+			case band.LoRaModulation:
+				c.SpreadFactors = append(c.SpreadFactors, dr.SpreadFactor)
+			case band.FSKModulation:
+				c.BitRate = dr.BitRate
+			}
+		}
+
+		out.Channels = append(out.Channels, c)
+	}
+
+	extraChannels, err := GetExtraChannelsForChannelConfigurationID(db, cf.ID)
+	if err != nil {
+		return out, errors.Wrap(err, "get extra channels error")
+	}
+
+	for _, ec := range extraChannels {
+		c := gw.ConfigChannel{
+			Modulation: band.Modulation(ec.Modulation),
+			Frequency:  ec.Frequency,
+			Bandwidth:  ec.BandWidth,
+			BitRate:    ec.BitRate,
+		}
+
+		for _, sf := range ec.SpreadFactors {
+			c.SpreadFactors = append(c.SpreadFactors, int(sf))
+		}
+
+		out.Channels = append(out.Channels, c)
+	}
+
+	return out, nil
+}
+
+// SendConfigPacket sends the configuration to the given gateway. Gateways
+// without channel-configuration and gateway backends not supporting
+// configuration updates are ignored.
+func SendConfigPacket(db *sqlx.DB, mac lorawan.EUI64) error {
+	configPacket, err := GetConfigPacket(db, mac)
+	if err != nil {
+		if errors.Cause(err) == ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get config packet error")
+	}
+
+	if err := common.Gateway.SendGatewayConfigPacket(configPacket); err != nil {
+		if err == backend.ErrNotSupported {
+			return nil
+		}
+		return errors.Wrap(err, "send config packet error")
+	}
+
+	log.WithFields(log.Fields{
+		"mac":     mac,
+		"version": configPacket.Version,
+	}).Info("gateway configuration sent")
+
+	return nil
+}
+
+// SendConfigPacketsForChannelConfigurationID sends the configuration to all
+// gateways using the given channel-configuration. Errors for individual
+// gateways are logged, so that one failing gateway does not prevent the
+// other gateways from being updated.
+func SendConfigPacketsForChannelConfigurationID(db *sqlx.DB, id int64) error {
+	var macs []lorawan.EUI64
+	err := db.Select(&macs, "select mac from gateway where channel_configuration_id = $1", id)
+	if err != nil {
+		return errors.Wrap(err, "select error")
+	}
+
+	for _, mac := range macs {
+		if err := SendConfigPacket(db, mac); err != nil {
+			log.WithField("mac", mac).Errorf("send gateway configuration error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// SetConfigVersion stores the configuration version acknowledged by the
+// given gateway.
+func SetConfigVersion(db *sqlx.DB, mac lorawan.EUI64, version string) error {
+	res, err := db.Exec("update gateway set config_version = $2 where mac = $1", mac[:], version)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+	log.WithFields(log.Fields{
+		"mac":     mac,
+		"version": version,
+	}).Info("gateway configuration version updated")
+	return nil
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestGatewayConfiguration(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	Convey("Given a clean database with a channel-configuration, extra channel and gateway", t, func() {
+		test.MustResetDB(common.DB)
+		backend := test.NewGatewayBackend()
+		common.Gateway = backend
+
+		cf := ChannelConfiguration{
+			Name:     "test-conf",
+			Band:     string(common.BandName),
+			Channels: []int64{0, 1},
+		}
+		So(CreateChannelConfiguration(common.DB, &cf), ShouldBeNil)
+
+		ec := ExtraChannel{
+			ChannelConfigurationID: cf.ID,
+			Modulation:             ChannelModulationFSK,
+			Frequency:              868800000,
+			BandWidth:              125,
+			BitRate:                50000,
+		}
+		So(CreateExtraChannel(common.DB, &ec), ShouldBeNil)
+
+		g := Gateway{
+			MAC:                    lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name:                   "test-gw",
+			ChannelConfigurationID: &cf.ID,
+		}
+		So(CreateGateway(common.DB, &g), ShouldBeNil)
+
+		cf, err := GetChannelConfiguration(common.DB, cf.ID)
+		So(err, ShouldBeNil)
+
+		Convey("Then GetConfigPacket returns the configuration", func() {
+			configPacket, err := GetConfigPacket(common.DB, g.MAC)
+			So(err, ShouldBeNil)
+			So(configPacket, ShouldResemble, gw.GatewayConfigPacket{
+				MAC:     g.MAC,
+				Version: GetConfigVersion(cf),
+				Channels: []gw.ConfigChannel{
+					{
+						Modulation:    band.LoRaModulation,
+						Frequency:     868100000,
+						Bandwidth:     125,
+						SpreadFactors: []int{12, 11, 10, 9, 8, 7},
+					},
+					{
+						Modulation:    band.LoRaModulation,
+						Frequency:     868300000,
+						Bandwidth:     125,
+						SpreadFactors: []int{12, 11, 10, 9, 8, 7},
+					},
+					{
+						Modulation: band.FSKModulation,
+						Frequency:  868800000,
+						Bandwidth:  125,
+						BitRate:    50000,
+					},
+				},
+			})
+		})
+
+		Convey("Then deleting the extra channel changes the config version", func() {
+			So(DeleteExtraChannel(common.DB, ec.ID), ShouldBeNil)
+			version, err := GetDesiredConfigVersion(common.DB, g)
+			So(err, ShouldBeNil)
+			So(version, ShouldNotEqual, GetConfigVersion(cf))
+		})
+
+		Convey("Then SendConfigPacketsForChannelConfigurationID sends the configuration to the gateway", func() {
+			So(SendConfigPacketsForChannelConfigurationID(common.DB, cf.ID), ShouldBeNil)
+			configPacket := <-backend.GatewayConfigPacketChan
+			So(configPacket.MAC, ShouldEqual, g.MAC)
+			So(configPacket.Version, ShouldEqual, GetConfigVersion(cf))
+		})
+
+		Convey("When handling stats reporting an outdated config version", func() {
+			So(handleStatsPacket(common.DB, gw.GatewayStatsPacket{
+				MAC:           g.MAC,
+				Time:          time.Now(),
+				ConfigVersion: "outdated",
+			}), ShouldBeNil)
+
+			Convey("Then the reported version is stored", func() {
+				g, err := GetGateway(common.DB, g.MAC)
+				So(err, ShouldBeNil)
+				So(g.ConfigVersion, ShouldEqual, "outdated")
+			})
+
+			Convey("Then the configuration is sent again", func() {
+				configPacket := <-backend.GatewayConfigPacketChan
+				So(configPacket.Version, ShouldEqual, GetConfigVersion(cf))
+			})
+		})
+
+		Convey("When handling stats reporting the current config version", func() {
+			So(handleStatsPacket(common.DB, gw.GatewayStatsPacket{
+				MAC:           g.MAC,
+				Time:          time.Now(),
+				ConfigVersion: GetConfigVersion(cf),
+			}), ShouldBeNil)
+
+			Convey("Then the configuration is not sent", func() {
+				So(backend.GatewayConfigPacketChan, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
@@ -0,0 +1,16 @@
+package gateway
+
+import "math"
+
+// EarthRadius defines the (mean) radius of the earth in meters.
+const EarthRadius = 6371000
+
+// Distance returns the (haversine) distance in meters between the given
+// locations.
+func Distance(lat1, long1, lat2, long2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLong := (long2 - long1) * math.Pi / 180
+
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Pow(math.Sin(dLong/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,24 @@
+package gateway
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDistance(t *testing.T) {
+	Convey("Given two locations", t, func() {
+		lat1, long1 := 52.3676, 4.9041 // Amsterdam
+		lat2, long2 := 51.9244, 4.4777 // Rotterdam
+
+		Convey("Then Distance returns the distance in meters", func() {
+			So(Distance(lat1, long1, lat2, long2), ShouldBeBetween, 57000, 58000)
+			So(Distance(lat2, long2, lat1, long1), ShouldAlmostEqual, Distance(lat1, long1, lat2, long2))
+			So(Distance(lat1, long1, lat1, long1), ShouldEqual, 0)
+		})
+
+		Convey("Then Distance handles the antimeridian", func() {
+			So(Distance(0, 179.5, 0, -179.5), ShouldBeBetween, 111000, 111400)
+		})
+	})
+}
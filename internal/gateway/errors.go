@@ -12,4 +12,6 @@ var (
 	ErrInvalidChannel             = errors.New("invalid channel")
 	ErrInvalidChannelConfig       = errors.New("invalid channel configuration")
 	ErrInvalidChannelModulation   = errors.New("invalid channel modulation")
+	ErrInvalidGatewayFilters      = errors.New("invalid gateway filters")
+	ErrTokenDoesNotExist          = errors.New("gateway token does not exist")
 )
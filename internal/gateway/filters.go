@@ -0,0 +1,267 @@
+package gateway
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Gateway list order options.
+const (
+	OrderByMAC        = "MAC"
+	OrderByName       = "NAME"
+	OrderByCreatedAt  = "CREATED_AT"
+	OrderByLastSeenAt = "LAST_SEEN_AT"
+	OrderByState      = "STATE"
+	OrderByDistance   = "DISTANCE"
+)
+
+// orderByColumns maps the order options to the sql order expression.
+var orderByColumns = map[string]string{
+	OrderByMAC:        "mac",
+	OrderByName:       "name",
+	OrderByCreatedAt:  "created_at",
+	OrderByLastSeenAt: "last_seen_at",
+	OrderByState:      "state",
+}
+
+// BoundingBox defines a geographic bounding box.
+type BoundingBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// Radius defines a geographic circle, the radius is in meters.
+type Radius struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+}
+
+// boundingBoxes returns the bounding box(es) of the circle. As the bounding
+// boxes can make use of the location index, they are used to limit the
+// number of gateways for which the distance must be calculated. When the
+// circle crosses the antimeridian, the box is split in two boxes (one on
+// each side). When the circle contains a pole, all longitudes are covered.
+func (r Radius) boundingBoxes() []BoundingBox {
+	latDelta := r.Radius / EarthRadius * 180 / math.Pi
+	bb := BoundingBox{
+		MinLatitude:  math.Max(r.Latitude-latDelta, -90),
+		MinLongitude: -180,
+		MaxLatitude:  math.Min(r.Latitude+latDelta, 90),
+		MaxLongitude: 180,
+	}
+
+	if bb.MinLatitude == -90 || bb.MaxLatitude == 90 {
+		return []BoundingBox{bb}
+	}
+
+	cos := math.Cos(r.Latitude * math.Pi / 180)
+	if cos <= 0.0001 || latDelta/cos >= 180 {
+		return []BoundingBox{bb}
+	}
+	lonDelta := latDelta / cos
+
+	bb.MinLongitude = r.Longitude - lonDelta
+	bb.MaxLongitude = r.Longitude + lonDelta
+
+	switch {
+	case bb.MinLongitude < -180:
+		east := bb
+		east.MinLongitude += 360
+		east.MaxLongitude = 180
+		bb.MinLongitude = -180
+		return []BoundingBox{bb, east}
+	case bb.MaxLongitude > 180:
+		west := bb
+		west.MinLongitude = -180
+		west.MaxLongitude -= 360
+		bb.MaxLongitude = 180
+		return []BoundingBox{bb, west}
+	}
+
+	return []BoundingBox{bb}
+}
+
+// GatewayFilters contains the filters and the order used for listing the
+// gateways. Filters which are not set are ignored.
+type GatewayFilters struct {
+	Search                 string // name or description contains (case-insensitive)
+	ChannelConfigurationID *int64
+	States                 []string
+	LastSeenAtFrom         *time.Time
+	LastSeenAtTo           *time.Time
+	BoundingBox            *BoundingBox
+	Radius                 *Radius
+
+	OrderBy   string // defaults to OrderByMAC
+	OrderDesc bool
+}
+
+// Validate validates the filters.
+func (f GatewayFilters) Validate() error {
+	for _, s := range f.States {
+		if s != StateNeverSeen && s != StateOnline && s != StateOffline {
+			return ErrInvalidGatewayFilters
+		}
+	}
+
+	if f.Radius != nil && f.Radius.Radius <= 0 {
+		return ErrInvalidGatewayFilters
+	}
+
+	if f.BoundingBox != nil && (f.BoundingBox.MinLatitude > f.BoundingBox.MaxLatitude || f.BoundingBox.MinLongitude > f.BoundingBox.MaxLongitude) {
+		return ErrInvalidGatewayFilters
+	}
+
+	if f.OrderBy != "" {
+		if _, ok := orderByColumns[f.OrderBy]; !ok && f.OrderBy != OrderByDistance {
+			return ErrInvalidGatewayFilters
+		}
+	}
+
+	if f.OrderBy == OrderByDistance && f.Radius == nil {
+		return ErrInvalidGatewayFilters
+	}
+
+	return nil
+}
+
+// distanceSQL returns the (haversine) distance in meters between the
+// gateway location and the given point (see Distance). Note that the location point is
+// stored as (latitude, longitude).
+func distanceSQL(latArg, lonArg string) string {
+	return fmt.Sprintf(`(2 * %d * asin(sqrt(
+		power(sin(radians(location[0] - %s) / 2), 2)
+		+ cos(radians(%s)) * cos(radians(location[0])) * power(sin(radians(location[1] - %s) / 2), 2))))`,
+		EarthRadius, latArg, latArg, lonArg,
+	)
+}
+
+// whereSQL returns the sql where clause and its arguments.
+func (f GatewayFilters) whereSQL() (string, []interface{}) {
+	var where []string
+	var args []interface{}
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.Search != "" {
+		replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+		search := arg("%" + replacer.Replace(f.Search) + "%")
+		where = append(where, fmt.Sprintf("(name ilike %s or description ilike %s)", search, search))
+	}
+
+	if f.ChannelConfigurationID != nil {
+		where = append(where, fmt.Sprintf("channel_configuration_id = %s", arg(*f.ChannelConfigurationID)))
+	}
+
+	if len(f.States) != 0 {
+		where = append(where, fmt.Sprintf("state = any(%s)", arg(pq.Array(f.States))))
+	}
+
+	if f.LastSeenAtFrom != nil {
+		where = append(where, fmt.Sprintf("last_seen_at >= %s", arg(*f.LastSeenAtFrom)))
+	}
+
+	if f.LastSeenAtTo != nil {
+		where = append(where, fmt.Sprintf("last_seen_at < %s", arg(*f.LastSeenAtTo)))
+	}
+
+	boxSQL := func(bb BoundingBox) string {
+		return fmt.Sprintf("location <@ box(point(%s, %s), point(%s, %s))",
+			arg(bb.MinLatitude), arg(bb.MinLongitude), arg(bb.MaxLatitude), arg(bb.MaxLongitude),
+		)
+	}
+
+	if f.BoundingBox != nil {
+		where = append(where, boxSQL(*f.BoundingBox))
+	}
+
+	if f.Radius != nil {
+		var boxes []string
+		for _, bb := range f.Radius.boundingBoxes() {
+			boxes = append(boxes, boxSQL(bb))
+		}
+		where = append(where, "("+strings.Join(boxes, " or ")+")")
+
+		where = append(where, fmt.Sprintf("%s <= %s",
+			distanceSQL(arg(f.Radius.Latitude), arg(f.Radius.Longitude)),
+			arg(f.Radius.Radius),
+		))
+	}
+
+	if len(where) == 0 {
+		return "", args
+	}
+	return "where " + strings.Join(where, " and "), args
+}
+
+// orderSQL returns the sql order by clause. The given args are extended
+// with the arguments used by the order by clause.
+func (f GatewayFilters) orderSQL(args []interface{}) (string, []interface{}) {
+	direction := "asc"
+	if f.OrderDesc {
+		direction = "desc"
+	}
+
+	var column string
+	switch f.OrderBy {
+	case "":
+		column = orderByColumns[OrderByMAC]
+	case OrderByDistance:
+		args = append(args, f.Radius.Latitude, f.Radius.Longitude)
+		column = distanceSQL(fmt.Sprintf("$%d", len(args)-1), fmt.Sprintf("$%d", len(args)))
+	default:
+		column = orderByColumns[f.OrderBy]
+	}
+
+	// the mac makes the order (and thus pagination) stable
+	return fmt.Sprintf("order by %s %s nulls last, mac", column, direction), args
+}
+
+// GetGatewayCountForFilters returns the number of gateways matching the
+// given filters.
+func GetGatewayCountForFilters(db *sqlx.DB, filters GatewayFilters) (int, error) {
+	if err := filters.Validate(); err != nil {
+		return 0, errors.Wrap(err, "validate error")
+	}
+
+	where, args := filters.whereSQL()
+
+	var count int
+	err := db.Get(&count, "select count(*) from gateway "+where, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "select error")
+	}
+	return count, nil
+}
+
+// GetGatewaysForFilters returns a slice of gateways matching the given
+// filters, ordered by the given order and respecting the given limit and
+// offset.
+func GetGatewaysForFilters(db *sqlx.DB, filters GatewayFilters, limit, offset int) ([]Gateway, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, errors.Wrap(err, "validate error")
+	}
+
+	where, args := filters.whereSQL()
+	order, args := filters.orderSQL(args)
+	args = append(args, limit, offset)
+
+	var gws []Gateway
+	err := db.Select(&gws, fmt.Sprintf("select * from gateway %s %s limit $%d offset $%d", where, order, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return gws, nil
+}
@@ -0,0 +1,246 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGatewayFiltersValidate(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Filters       GatewayFilters
+			ExpectedError error
+		}{
+			{
+				Name:    "no filters",
+				Filters: GatewayFilters{},
+			},
+			{
+				Name:    "valid states",
+				Filters: GatewayFilters{States: []string{StateOnline, StateOffline}},
+			},
+			{
+				Name:          "invalid state",
+				Filters:       GatewayFilters{States: []string{"BROKEN"}},
+				ExpectedError: ErrInvalidGatewayFilters,
+			},
+			{
+				Name:          "invalid order by",
+				Filters:       GatewayFilters{OrderBy: "mac; drop table gateway"},
+				ExpectedError: ErrInvalidGatewayFilters,
+			},
+			{
+				Name:          "order by distance without radius",
+				Filters:       GatewayFilters{OrderBy: OrderByDistance},
+				ExpectedError: ErrInvalidGatewayFilters,
+			},
+			{
+				Name:          "zero radius",
+				Filters:       GatewayFilters{Radius: &Radius{}},
+				ExpectedError: ErrInvalidGatewayFilters,
+			},
+			{
+				Name: "inverted bounding box",
+				Filters: GatewayFilters{BoundingBox: &BoundingBox{
+					MinLatitude: 2,
+					MaxLatitude: 1,
+				}},
+				ExpectedError: ErrInvalidGatewayFilters,
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				So(tst.Filters.Validate(), ShouldEqual, tst.ExpectedError)
+			})
+		}
+	})
+}
+
+func TestRadiusBoundingBoxes(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name     string
+			Radius   Radius
+			Expected []BoundingBox
+		}{
+			{
+				Name:   "at the equator",
+				Radius: Radius{Latitude: 0, Longitude: 10, Radius: 111195},
+				Expected: []BoundingBox{
+					{MinLatitude: -1, MinLongitude: 9, MaxLatitude: 1, MaxLongitude: 11},
+				},
+			},
+			{
+				Name:   "crossing the antimeridian (east)",
+				Radius: Radius{Latitude: 0, Longitude: 179.5, Radius: 111195},
+				Expected: []BoundingBox{
+					{MinLatitude: -1, MinLongitude: 178.5, MaxLatitude: 1, MaxLongitude: 180},
+					{MinLatitude: -1, MinLongitude: -180, MaxLatitude: 1, MaxLongitude: -179.5},
+				},
+			},
+			{
+				Name:   "crossing the antimeridian (west)",
+				Radius: Radius{Latitude: 0, Longitude: -179.5, Radius: 111195},
+				Expected: []BoundingBox{
+					{MinLatitude: -1, MinLongitude: -180, MaxLatitude: 1, MaxLongitude: -178.5},
+					{MinLatitude: -1, MinLongitude: 179.5, MaxLatitude: 1, MaxLongitude: 180},
+				},
+			},
+			{
+				Name:   "containing the north pole",
+				Radius: Radius{Latitude: 89.5, Longitude: 10, Radius: 111195},
+				Expected: []BoundingBox{
+					{MinLatitude: 88.5, MinLongitude: -180, MaxLatitude: 90, MaxLongitude: 180},
+				},
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				boxes := tst.Radius.boundingBoxes()
+				So(boxes, ShouldHaveLength, len(tst.Expected))
+				for i := range boxes {
+					So(boxes[i].MinLatitude, ShouldAlmostEqual, tst.Expected[i].MinLatitude, 0.001)
+					So(boxes[i].MinLongitude, ShouldAlmostEqual, tst.Expected[i].MinLongitude, 0.001)
+					So(boxes[i].MaxLatitude, ShouldAlmostEqual, tst.Expected[i].MaxLatitude, 0.001)
+					So(boxes[i].MaxLongitude, ShouldAlmostEqual, tst.Expected[i].MaxLongitude, 0.001)
+				}
+			})
+		}
+	})
+}
+
+func TestGetGatewaysForFilters(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with three gateways", t, func() {
+		test.MustResetDB(db)
+
+		now := time.Now()
+		hourAgo := now.Add(-time.Hour)
+
+		gws := []Gateway{
+			{
+				MAC:         lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+				Name:        "amsterdam-1",
+				Description: "Amsterdam 100%",
+				Location:    GPSPoint{Latitude: 52.3702, Longitude: 4.8952},
+				LastSeenAt:  &now,
+				State:       StateOnline,
+			},
+			{
+				MAC:         lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2},
+				Name:        "utrecht-1",
+				Description: "rooftop",
+				Location:    GPSPoint{Latitude: 52.0907, Longitude: 5.1214},
+				LastSeenAt:  &hourAgo,
+				State:       StateOffline,
+			},
+			{
+				MAC:      lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3},
+				Name:     "paris-1",
+				Location: GPSPoint{Latitude: 48.8566, Longitude: 2.3522},
+			},
+		}
+		for i := range gws {
+			So(CreateGateway(db, &gws[i]), ShouldBeNil)
+		}
+
+		tests := []struct {
+			Name         string
+			Filters      GatewayFilters
+			ExpectedMACs []lorawan.EUI64
+		}{
+			{
+				Name:         "no filters",
+				ExpectedMACs: []lorawan.EUI64{gws[0].MAC, gws[1].MAC, gws[2].MAC},
+			},
+			{
+				Name:         "search name",
+				Filters:      GatewayFilters{Search: "UTRECHT"},
+				ExpectedMACs: []lorawan.EUI64{gws[1].MAC},
+			},
+			{
+				Name:         "search description with wildcard character",
+				Filters:      GatewayFilters{Search: "100%"},
+				ExpectedMACs: []lorawan.EUI64{gws[0].MAC},
+			},
+			{
+				Name:         "states",
+				Filters:      GatewayFilters{States: []string{StateOffline, StateNeverSeen}},
+				ExpectedMACs: []lorawan.EUI64{gws[1].MAC, gws[2].MAC},
+			},
+			{
+				Name: "last seen at range",
+				Filters: GatewayFilters{
+					LastSeenAtFrom: func() *time.Time { t := now.Add(-time.Minute); return &t }(),
+				},
+				ExpectedMACs: []lorawan.EUI64{gws[0].MAC},
+			},
+			{
+				Name: "bounding box",
+				Filters: GatewayFilters{BoundingBox: &BoundingBox{
+					MinLatitude:  50,
+					MinLongitude: 3,
+					MaxLatitude:  54,
+					MaxLongitude: 7,
+				}},
+				ExpectedMACs: []lorawan.EUI64{gws[0].MAC, gws[1].MAC},
+			},
+			{
+				Name: "radius ordered by distance",
+				Filters: GatewayFilters{
+					Radius:  &Radius{Latitude: 52.0907, Longitude: 5.1214, Radius: 50000},
+					OrderBy: OrderByDistance,
+				},
+				ExpectedMACs: []lorawan.EUI64{gws[1].MAC, gws[0].MAC},
+			},
+			{
+				Name: "small radius",
+				Filters: GatewayFilters{
+					Radius: &Radius{Latitude: 52.0907, Longitude: 5.1214, Radius: 10000},
+				},
+				ExpectedMACs: []lorawan.EUI64{gws[1].MAC},
+			},
+			{
+				Name:         "order by last seen at descending",
+				Filters:      GatewayFilters{OrderBy: OrderByLastSeenAt, OrderDesc: true},
+				ExpectedMACs: []lorawan.EUI64{gws[0].MAC, gws[1].MAC, gws[2].MAC},
+			},
+			{
+				Name:         "order by name",
+				Filters:      GatewayFilters{OrderBy: OrderByName},
+				ExpectedMACs: []lorawan.EUI64{gws[0].MAC, gws[2].MAC, gws[1].MAC},
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				count, err := GetGatewayCountForFilters(db, tst.Filters)
+				So(err, ShouldBeNil)
+				So(count, ShouldEqual, len(tst.ExpectedMACs))
+
+				result, err := GetGatewaysForFilters(db, tst.Filters, 10, 0)
+				So(err, ShouldBeNil)
+
+				var macs []lorawan.EUI64
+				for _, gw := range result {
+					macs = append(macs, gw.MAC)
+				}
+				So(macs, ShouldResemble, tst.ExpectedMACs)
+			})
+		}
+	})
+}
@@ -0,0 +1,569 @@
+package gateway
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Signal metrics of which a histogram (with a resolution of 1 dB) is
+// stored in the gateway_stats_signal table.
+const (
+	signalMetricRSSI = "RSSI"
+	signalMetricSNR  = "SNR"
+)
+
+// FrameStats contains the number of frames received by the gateway on the
+// given frequency and data-rate.
+type FrameStats struct {
+	Frequency int `db:"frequency"`
+	DR        int `db:"dr"`
+	RXFrames  int `db:"rx_frames"`
+}
+
+// SignalStats contains the average and the 10th, 50th (median) and 90th
+// percentile of a signal metric (RSSI or SNR).
+type SignalStats struct {
+	Average float64
+	P10     float64
+	P50     float64
+	P90     float64
+}
+
+// frameStatsQueueSize defines the max. number of frames (and tx
+// acknowledgements) waiting for the aggregation of their stats. When the
+// queue is full, the stats are dropped.
+const frameStatsQueueSize = 1000
+
+// frameStatsWorkers defines the number of workers aggregating the stats
+// of the queued frames.
+const frameStatsWorkers = 4
+
+// frameStatsQueueItem holds a received or transmitted frame (or a tx
+// acknowledgement) waiting for the aggregation of its stats.
+type frameStatsQueueItem struct {
+	mac    lorawan.EUI64
+	name   string
+	handle func(db *sqlx.DB) error
+}
+
+// frameStatsQueue holds the frames waiting for the aggregation of their
+// stats (see QueueRXPacketStats).
+var frameStatsQueue = make(chan frameStatsQueueItem, frameStatsQueueSize)
+
+// StatsBackend wraps a gateway backend and keeps track of the frames
+// scheduled for transmission in the gateway stats.
+type StatsBackend struct {
+	backend.Gateway
+}
+
+// NewStatsBackend creates a new StatsBackend.
+func NewStatsBackend(b backend.Gateway) *StatsBackend {
+	return &StatsBackend{
+		Gateway: b,
+	}
+}
+
+// SendTXPacket sends the given packet to the gateway.
+func (b *StatsBackend) SendTXPacket(txPacket gw.TXPacket) error {
+	if err := b.Gateway.SendTXPacket(txPacket); err != nil {
+		return err
+	}
+
+	queueFrameStats(frameStatsQueueItem{
+		mac:  txPacket.TXInfo.MAC,
+		name: "tx packet",
+		handle: func(db *sqlx.DB) error {
+			return HandleTXPacketStats(db, txPacket)
+		},
+	})
+
+	return nil
+}
+
+// QueueRXPacketStats queues the given frame for the aggregation of its
+// stats (see HandleRXPacketStats), so that the handling of the frame does
+// not wait for the database transaction. The queue is consumed by the
+// StatsHandler. When the queue is full, the stats of the frame are dropped.
+func QueueRXPacketStats(rxPacket gw.RXPacket) {
+	queueFrameStats(frameStatsQueueItem{
+		mac:  rxPacket.RXInfo.MAC,
+		name: "rx packet",
+		handle: func(db *sqlx.DB) error {
+			return HandleRXPacketStats(db, rxPacket)
+		},
+	})
+}
+
+// queueFrameStats adds the given item to the frame stats queue, or drops it
+// when the queue is full.
+func queueFrameStats(item frameStatsQueueItem) {
+	select {
+	case frameStatsQueue <- item:
+	default:
+		log.WithField("mac", item.mac).Warningf("frame stats queue is full, dropping %s stats", item.name)
+	}
+}
+
+// handleFrameStatsQueue aggregates the stats of the queued frames until
+// done is closed, after which the remaining frames are aggregated.
+func handleFrameStatsQueue(done chan struct{}) {
+	for {
+		select {
+		case item := <-frameStatsQueue:
+			handleQueuedFrameStats(item)
+		case <-done:
+			for {
+				select {
+				case item := <-frameStatsQueue:
+					handleQueuedFrameStats(item)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func handleQueuedFrameStats(item frameStatsQueueItem) {
+	if err := item.handle(common.DB); err != nil {
+		log.WithField("mac", item.mac).Errorf("handle %s stats error: %s", item.name, err)
+	}
+}
+
+// HandleRXPacketStats aggregates the radio-level stats of the given frame,
+// received by the gateway. Of frames with an invalid CRC only the number
+// of frames is aggregated. Frames received by unknown gateways are ignored.
+func HandleRXPacketStats(db *sqlx.DB, rxPacket gw.RXPacket) error {
+	stats := Stats{
+		MAC:       rxPacket.RXInfo.MAC,
+		Timestamp: time.Now(),
+	}
+
+	if rxPacket.RXInfo.CRCStatus == gw.CRCStatusError {
+		stats.RXFramesCRCError = 1
+		return aggregateFrameStats(db, stats, nil)
+	}
+
+	dr, err := common.Band.GetDataRate(rxPacket.RXInfo.DataRate)
+	if err != nil {
+		return errors.Wrap(err, "get data-rate error")
+	}
+
+	stats.RXFrames = 1
+	stats.RXRSSISum = int64(rxPacket.RXInfo.RSSI)
+
+	// FSK frames don't have a SNR
+	isLoRa := rxPacket.RXInfo.DataRate.Modulation == band.LoRaModulation
+	if isLoRa {
+		stats.RXSNRSum = rxPacket.RXInfo.LoRaSNR
+	}
+
+	device := getDeviceID(rxPacket.PHYPayload)
+
+	return aggregateFrameStats(db, stats, func(tx *sqlx.Tx, stats Stats) error {
+		if err := aggregateGatewayFrameStats(tx, stats, rxPacket.RXInfo.Frequency, dr); err != nil {
+			return err
+		}
+
+		if err := aggregateGatewaySignalStats(tx, stats, signalMetricRSSI, rxPacket.RXInfo.RSSI); err != nil {
+			return err
+		}
+
+		if isLoRa {
+			if err := aggregateGatewaySignalStats(tx, stats, signalMetricSNR, int(math.Round(rxPacket.RXInfo.LoRaSNR))); err != nil {
+				return err
+			}
+		}
+
+		if device != nil {
+			if err := aggregateGatewayDeviceStats(tx, stats, device); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// HandleTXPacketStats aggregates the given frame as scheduled for
+// transmission by the gateway.
+func HandleTXPacketStats(db *sqlx.DB, txPacket gw.TXPacket) error {
+	return aggregateFrameStats(db, Stats{
+		MAC:               txPacket.TXInfo.MAC,
+		Timestamp:         time.Now(),
+		TXFramesScheduled: 1,
+	}, nil)
+}
+
+// handleTXAcks queues the received tx acknowledgements for the aggregation
+// of their stats (see handleTXAck), until the gateway backend is closed.
+func handleTXAcks() {
+	for ack := range common.Gateway.TXAckChan() {
+		ack := ack
+		queueFrameStats(frameStatsQueueItem{
+			mac:  ack.MAC,
+			name: "tx ack",
+			handle: func(db *sqlx.DB) error {
+				return handleTXAck(db, ack)
+			},
+		})
+	}
+}
+
+// handleTXAck aggregates the given tx acknowledgement. Acknowledgements
+// containing an error are not counted.
+func handleTXAck(db *sqlx.DB, ack gw.TXAck) error {
+	if ack.Error != "" {
+		return nil
+	}
+
+	return aggregateFrameStats(db, Stats{
+		MAC:           ack.MAC,
+		Timestamp:     time.Now(),
+		TXFramesAcked: 1,
+	}, nil)
+}
+
+// aggregateFrameStats aggregates the given stats for each aggregation
+// interval, within a single transaction. For each interval, f (when not
+// nil) is called for storing the additional (per frequency, data-rate,
+// signal and device) stats. Stats of unknown gateways are ignored.
+func aggregateFrameStats(db *sqlx.DB, stats Stats, f func(tx *sqlx.Tx, stats Stats) error) error {
+	comitted := false
+	tx, err := db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin transaction error")
+	}
+	defer func() {
+		if !comitted {
+			tx.Rollback()
+		}
+	}()
+
+	// set the database timezone for this transaction
+	if common.TimeLocation != time.Local {
+		// when TimeLocation == time.Local, it would have 'Local' as name
+		_, err = tx.Exec(fmt.Sprintf("set local time zone '%s'", common.TimeLocation.String()))
+		if err != nil {
+			return errors.Wrap(err, "set timezone error")
+		}
+	}
+
+	for _, aggr := range statsAggregationIntervals {
+		stats.Interval = aggr
+
+		if err := aggregateGatewayStats(tx, stats); err != nil {
+			if pqErr, ok := errors.Cause(err).(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+				return nil
+			}
+			return errors.Wrap(err, "aggregate gateway stats error")
+		}
+
+		if f == nil {
+			continue
+		}
+
+		if err := f(tx, stats); err != nil {
+			return errors.Wrap(err, "aggregate gateway frame stats error")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "commit error")
+	}
+	comitted = true
+	return nil
+}
+
+func aggregateGatewayFrameStats(db sqlx.Execer, stats Stats, frequency, dr int) error {
+	_, err := db.Exec(`
+		insert into gateway_stats_frame (
+			mac,
+			"timestamp",
+			"interval",
+			frequency,
+			dr,
+			rx_frames
+		) values (
+			$1,
+			cast(date_trunc($2, $3::timestamptz) as timestamp with time zone),
+			$2,
+			$4,
+			$5,
+			1
+		)
+		on conflict (mac, "timestamp", "interval", frequency, dr)
+			do update set
+				rx_frames = gateway_stats_frame.rx_frames + 1`,
+		stats.MAC[:],
+		stats.Interval,
+		stats.Timestamp,
+		frequency,
+		dr,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert or update frame aggregate error")
+	}
+	return nil
+}
+
+func aggregateGatewaySignalStats(db sqlx.Execer, stats Stats, metric string, value int) error {
+	_, err := db.Exec(`
+		insert into gateway_stats_signal (
+			mac,
+			"timestamp",
+			"interval",
+			metric,
+			value,
+			count
+		) values (
+			$1,
+			cast(date_trunc($2, $3::timestamptz) as timestamp with time zone),
+			$2,
+			$4,
+			$5,
+			1
+		)
+		on conflict (mac, "timestamp", "interval", metric, value)
+			do update set
+				count = gateway_stats_signal.count + 1`,
+		stats.MAC[:],
+		stats.Interval,
+		stats.Timestamp,
+		metric,
+		value,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert or update signal aggregate error")
+	}
+	return nil
+}
+
+func aggregateGatewayDeviceStats(db sqlx.Execer, stats Stats, device []byte) error {
+	_, err := db.Exec(`
+		insert into gateway_stats_device (
+			mac,
+			"timestamp",
+			"interval",
+			device
+		) values (
+			$1,
+			cast(date_trunc($2, $3::timestamptz) as timestamp with time zone),
+			$2,
+			$4
+		)
+		on conflict (mac, "timestamp", "interval", device)
+			do nothing`,
+		stats.MAC[:],
+		stats.Interval,
+		stats.Timestamp,
+		device,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert device aggregate error")
+	}
+	return nil
+}
+
+// getDeviceID returns the identifier used for counting the unique devices.
+// This is the DevEUI for join-requests and the DevAddr for data frames.
+// For other frames, nil is returned.
+func getDeviceID(phy lorawan.PHYPayload) []byte {
+	switch phy.MHDR.MType {
+	case lorawan.JoinRequest:
+		if pl, ok := phy.MACPayload.(*lorawan.JoinRequestPayload); ok {
+			return pl.DevEUI[:]
+		}
+	case lorawan.UnconfirmedDataUp, lorawan.ConfirmedDataUp:
+		if pl, ok := phy.MACPayload.(*lorawan.MACPayload); ok {
+			return pl.FHDR.DevAddr[:]
+		}
+	}
+	return nil
+}
+
+// setFrameStats sets the per frequency and data-rate frames, the signal
+// stats and the number of unique devices for the given stats records.
+func setFrameStats(tx *sqlx.Tx, mac lorawan.EUI64, interval string, start, end time.Time, stats []Stats) error {
+	index := make(map[int64]int)
+	for i := range stats {
+		index[stats[i].Timestamp.UnixNano()] = i
+	}
+
+	var frames []struct {
+		Timestamp time.Time `db:"timestamp"`
+		FrameStats
+	}
+	err := tx.Select(&frames, `
+		select
+			"timestamp",
+			frequency,
+			dr,
+			rx_frames
+		from gateway_stats_frame
+		where
+			mac = $1
+			and interval = $2
+			and "timestamp" >= cast(date_trunc($2, $3::timestamptz) as timestamp with time zone)
+			and "timestamp" < $4
+		order by "timestamp", frequency, dr`,
+		mac[:],
+		interval,
+		start,
+		end,
+	)
+	if err != nil {
+		return errors.Wrap(err, "select frame stats error")
+	}
+	for _, f := range frames {
+		if i, ok := index[f.Timestamp.UnixNano()]; ok {
+			stats[i].Frames = append(stats[i].Frames, f.FrameStats)
+		}
+	}
+
+	var signals []struct {
+		Timestamp time.Time `db:"timestamp"`
+		Metric    string    `db:"metric"`
+		Value     int       `db:"value"`
+		Count     int       `db:"count"`
+	}
+	err = tx.Select(&signals, `
+		select
+			"timestamp",
+			metric,
+			value,
+			count
+		from gateway_stats_signal
+		where
+			mac = $1
+			and interval = $2
+			and "timestamp" >= cast(date_trunc($2, $3::timestamptz) as timestamp with time zone)
+			and "timestamp" < $4
+		order by "timestamp", metric, value`,
+		mac[:],
+		interval,
+		start,
+		end,
+	)
+	if err != nil {
+		return errors.Wrap(err, "select signal stats error")
+	}
+
+	histograms := make(map[int]map[string][]histogramBucket)
+	for _, s := range signals {
+		i, ok := index[s.Timestamp.UnixNano()]
+		if !ok {
+			continue
+		}
+		if histograms[i] == nil {
+			histograms[i] = make(map[string][]histogramBucket)
+		}
+		histograms[i][s.Metric] = append(histograms[i][s.Metric], histogramBucket{Value: s.Value, Count: s.Count})
+	}
+	for i, h := range histograms {
+		if stats[i].RXFrames != 0 {
+			stats[i].RSSI = getSignalStats(h[signalMetricRSSI])
+			stats[i].RSSI.Average = float64(stats[i].RXRSSISum) / float64(stats[i].RXFrames)
+		}
+
+		stats[i].SNR = getSignalStats(h[signalMetricSNR])
+		if count := getHistogramCount(h[signalMetricSNR]); count != 0 {
+			stats[i].SNR.Average = stats[i].RXSNRSum / float64(count)
+		}
+	}
+
+	var devices []struct {
+		Timestamp time.Time `db:"timestamp"`
+		Count     int       `db:"count"`
+	}
+	err = tx.Select(&devices, `
+		select
+			"timestamp",
+			count(*) as count
+		from gateway_stats_device
+		where
+			mac = $1
+			and interval = $2
+			and "timestamp" >= cast(date_trunc($2, $3::timestamptz) as timestamp with time zone)
+			and "timestamp" < $4
+		group by "timestamp"`,
+		mac[:],
+		interval,
+		start,
+		end,
+	)
+	if err != nil {
+		return errors.Wrap(err, "select device stats error")
+	}
+	for _, d := range devices {
+		if i, ok := index[d.Timestamp.UnixNano()]; ok {
+			stats[i].UniqueDevices = d.Count
+		}
+	}
+
+	return nil
+}
+
+// histogramBucket contains the number of measurements of a single value.
+type histogramBucket struct {
+	Value int
+	Count int
+}
+
+func getHistogramCount(buckets []histogramBucket) int {
+	var count int
+	for _, b := range buckets {
+		count += b.Count
+	}
+	return count
+}
+
+// getSignalStats returns the percentiles of the given histogram. Note that
+// the average is not set, as it is calculated using the exact values.
+func getSignalStats(buckets []histogramBucket) SignalStats {
+	return SignalStats{
+		P10: getPercentile(buckets, 10),
+		P50: getPercentile(buckets, 50),
+		P90: getPercentile(buckets, 90),
+	}
+}
+
+// getPercentile returns the value of the given percentile (nearest-rank
+// method) of the given histogram.
+func getPercentile(buckets []histogramBucket, percentile int) float64 {
+	count := getHistogramCount(buckets)
+	if count == 0 {
+		return 0
+	}
+
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Value < buckets[j].Value
+	})
+
+	rank := int(math.Ceil(float64(percentile) / 100 * float64(count)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var cumulative int
+	for _, b := range buckets {
+		cumulative += b.Count
+		if cumulative >= rank {
+			return float64(b.Value)
+		}
+	}
+	return float64(buckets[len(buckets)-1].Value)
+}
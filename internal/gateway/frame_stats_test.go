@@ -0,0 +1,208 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGetPercentile(t *testing.T) {
+	Convey("Given a histogram", t, func() {
+		buckets := []histogramBucket{
+			{Value: -100, Count: 1},
+			{Value: -80, Count: 8},
+			{Value: -120, Count: 1},
+		}
+
+		Convey("Then getPercentile returns the expected values", func() {
+			So(getPercentile(buckets, 10), ShouldEqual, -120)
+			So(getPercentile(buckets, 20), ShouldEqual, -100)
+			So(getPercentile(buckets, 50), ShouldEqual, -80)
+			So(getPercentile(buckets, 90), ShouldEqual, -80)
+		})
+
+		Convey("Then an empty histogram returns 0", func() {
+			So(getPercentile(nil, 50), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestGetDeviceID(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name       string
+			PHYPayload lorawan.PHYPayload
+			Expected   []byte
+		}{
+			{
+				Name: "join-request",
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{MType: lorawan.JoinRequest, Major: lorawan.LoRaWANR1},
+					MACPayload: &lorawan.JoinRequestPayload{
+						DevEUI: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					},
+				},
+				Expected: []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			{
+				Name: "unconfirmed data-up",
+				PHYPayload: lorawan.PHYPayload{
+					MHDR: lorawan.MHDR{MType: lorawan.UnconfirmedDataUp, Major: lorawan.LoRaWANR1},
+					MACPayload: &lorawan.MACPayload{
+						FHDR: lorawan.FHDR{
+							DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+						},
+					},
+				},
+				Expected: []byte{1, 2, 3, 4},
+			},
+			{
+				Name: "proprietary",
+				PHYPayload: lorawan.PHYPayload{
+					MHDR:       lorawan.MHDR{MType: lorawan.Proprietary, Major: lorawan.LoRaWANR1},
+					MACPayload: &lorawan.DataPayload{},
+				},
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				So(getDeviceID(tst.PHYPayload), ShouldResemble, tst.Expected)
+			})
+		}
+	})
+}
+
+func TestFrameStatsQueue(t *testing.T) {
+	Convey("Given a full frame stats queue", t, func() {
+		var handled int
+		for i := 0; i < frameStatsQueueSize; i++ {
+			queueFrameStats(frameStatsQueueItem{
+				name: "test",
+				handle: func(db *sqlx.DB) error {
+					handled++
+					return nil
+				},
+			})
+		}
+
+		Convey("Then queueing more stats does not block", func() {
+			queueFrameStats(frameStatsQueueItem{name: "test"})
+			So(frameStatsQueue, ShouldHaveLength, frameStatsQueueSize)
+		})
+
+		Convey("Then the queued stats are handled after done is closed", func() {
+			done := make(chan struct{})
+			close(done)
+			handleFrameStatsQueue(done)
+
+			So(frameStatsQueue, ShouldHaveLength, 0)
+			So(handled, ShouldEqual, frameStatsQueueSize)
+		})
+	})
+}
+
+func TestFrameStats(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(common.DB)
+		MustSetStatsAggregationIntervals([]string{"MINUTE"})
+
+		g := Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(CreateGateway(common.DB, &g), ShouldBeNil)
+
+		start := time.Now().Truncate(time.Minute)
+
+		Convey("When handling received and transmitted frames", func() {
+			for _, rssi := range []int{-120, -100, -80, -80} {
+				So(HandleRXPacketStats(common.DB, gw.RXPacket{
+					RXInfo: gw.RXInfo{
+						MAC:       g.MAC,
+						Frequency: 868100000,
+						DataRate:  common.Band.DataRates[5],
+						CRCStatus: gw.CRCStatusOK,
+						RSSI:      rssi,
+						LoRaSNR:   5,
+					},
+					PHYPayload: lorawan.PHYPayload{
+						MHDR: lorawan.MHDR{MType: lorawan.UnconfirmedDataUp, Major: lorawan.LoRaWANR1},
+						MACPayload: &lorawan.MACPayload{
+							FHDR: lorawan.FHDR{
+								DevAddr: lorawan.DevAddr{1, 2, 3, 4},
+							},
+						},
+					},
+				}), ShouldBeNil)
+			}
+
+			So(HandleRXPacketStats(common.DB, gw.RXPacket{
+				RXInfo: gw.RXInfo{
+					MAC:       g.MAC,
+					CRCStatus: gw.CRCStatusError,
+				},
+			}), ShouldBeNil)
+
+			txPacket := gw.TXPacket{
+				TXInfo: gw.TXInfo{
+					MAC: g.MAC,
+				},
+			}
+			So(HandleTXPacketStats(common.DB, txPacket), ShouldBeNil)
+			So(HandleTXPacketStats(common.DB, txPacket), ShouldBeNil)
+			So(handleTXAck(common.DB, gw.TXAck{MAC: g.MAC}), ShouldBeNil)
+			So(handleTXAck(common.DB, gw.TXAck{MAC: g.MAC, Error: "TOO_LATE"}), ShouldBeNil)
+
+			Convey("Then GetGatewayStats returns the frame stats", func() {
+				stats, err := GetGatewayStats(common.DB, g.MAC, "MINUTE", start, start)
+				So(err, ShouldBeNil)
+				So(stats, ShouldHaveLength, 1)
+
+				So(stats[0].RXFrames, ShouldEqual, 4)
+				So(stats[0].RXFramesCRCError, ShouldEqual, 1)
+				So(stats[0].TXFramesScheduled, ShouldEqual, 2)
+				So(stats[0].TXFramesAcked, ShouldEqual, 1)
+				So(stats[0].UniqueDevices, ShouldEqual, 1)
+				So(stats[0].Frames, ShouldResemble, []FrameStats{
+					{Frequency: 868100000, DR: 5, RXFrames: 4},
+				})
+				So(stats[0].RSSI, ShouldResemble, SignalStats{
+					Average: -95,
+					P10:     -120,
+					P50:     -100,
+					P90:     -80,
+				})
+				So(stats[0].SNR, ShouldResemble, SignalStats{
+					Average: 5,
+					P10:     5,
+					P50:     5,
+					P90:     5,
+				})
+			})
+		})
+
+		Convey("Then frames received by an unknown gateway are ignored", func() {
+			So(HandleRXPacketStats(common.DB, gw.RXPacket{
+				RXInfo: gw.RXInfo{
+					MAC:       lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+					CRCStatus: gw.CRCStatusError,
+				},
+			}), ShouldBeNil)
+		})
+	})
+}
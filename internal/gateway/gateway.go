@@ -84,13 +84,19 @@ func (l *GPSPoint) Scan(src interface{}) error {
 }
 
 // StatsHandler represents a stat handler for incoming gateway stats.
+// It also aggregates the stats of the received and transmitted frames (see
+// QueueRXPacketStats and StatsBackend).
 type StatsHandler struct {
-	wg sync.WaitGroup
+	wg     sync.WaitGroup
+	txAcks sync.WaitGroup
+	done   chan struct{}
 }
 
 // NewStatsHandler creates a new StatsHandler.
 func NewStatsHandler() *StatsHandler {
-	return &StatsHandler{}
+	return &StatsHandler{
+		done: make(chan struct{}),
+	}
 }
 
 // Start starts the stats handler.
@@ -100,12 +106,27 @@ func (s *StatsHandler) Start() error {
 		defer s.wg.Done()
 		handleStatsPackets(&s.wg)
 	}()
+	s.txAcks.Add(1)
+	go func() {
+		defer s.txAcks.Done()
+		handleTXAcks()
+	}()
+	for i := 0; i < frameStatsWorkers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			handleFrameStatsQueue(s.done)
+		}()
+	}
 	return nil
 }
 
 // Stop waits for the stats handler to complete the pending packets.
 // At this stage the gateway backend must already been closed.
 func (s *StatsHandler) Stop() error {
+	// the tx acknowledgements must be queued before the queue is drained
+	s.txAcks.Wait()
+	close(s.done)
 	s.wg.Wait()
 	return nil
 }
@@ -122,6 +143,9 @@ type Gateway struct {
 	Location               GPSPoint      `db:"location"`
 	Altitude               float64       `db:"altitude"`
 	ChannelConfigurationID *int64        `db:"channel_configuration_id"`
+	ConfigVersion          string        `db:"config_version"`
+	State                  string        `db:"state"`
+	StateChangedAt         *time.Time    `db:"state_changed_at"`
 }
 
 // Validate validates the data of the gateway.
@@ -133,6 +157,9 @@ func (g Gateway) Validate() error {
 }
 
 // Stats represents a single gateway stats record.
+// The RX* and TX*Packets* counters are reported by the gateway, the
+// RXFrames* and TXFrames* counters are computed by LoRa Server from the
+// frames it received from and sent to the gateway.
 type Stats struct {
 	MAC                 lorawan.EUI64 `db:"mac"`
 	Timestamp           time.Time     `db:"timestamp"`
@@ -141,6 +168,19 @@ type Stats struct {
 	RXPacketsReceivedOK int           `db:"rx_packets_received_ok"`
 	TXPacketsReceived   int           `db:"tx_packets_received"`
 	TXPacketsEmitted    int           `db:"tx_packets_emitted"`
+	RXFrames            int           `db:"rx_frames"`
+	RXFramesCRCError    int           `db:"rx_frames_crc_error"`
+	RXRSSISum           int64         `db:"rx_rssi_sum"`
+	RXSNRSum            float64       `db:"rx_snr_sum"`
+	TXFramesScheduled   int           `db:"tx_frames_scheduled"`
+	TXFramesAcked       int           `db:"tx_frames_acked"`
+
+	// The fields below are not stored in the gateway_stats table and are
+	// only set by GetGatewayStats.
+	UniqueDevices int          `db:"-"`
+	Frames        []FrameStats `db:"-"`
+	RSSI          SignalStats  `db:"-"`
+	SNR           SignalStats  `db:"-"`
 }
 
 // ChannelConfiguration contains the channel-configuration for a gateway.
@@ -222,6 +262,10 @@ func CreateGateway(db *sqlx.DB, gw *Gateway) error {
 		return errors.Wrap(err, "validate error")
 	}
 
+	if gw.State == "" {
+		gw.State = StateNeverSeen
+	}
+
 	now := time.Now()
 	_, err := db.Exec(`
 		insert into gateway (
@@ -234,8 +278,9 @@ func CreateGateway(db *sqlx.DB, gw *Gateway) error {
 			last_seen_at,
 			location,
 			altitude,
-			channel_configuration_id
-		) values ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9)`,
+			channel_configuration_id,
+			state
+		) values ($1, $2, $3, $4, $4, $5, $6, $7, $8, $9, $10)`,
 		gw.MAC[:],
 		gw.Name,
 		gw.Description,
@@ -245,6 +290,7 @@ func CreateGateway(db *sqlx.DB, gw *Gateway) error {
 		gw.Location,
 		gw.Altitude,
 		gw.ChannelConfigurationID,
+		gw.State,
 	)
 	if err != nil {
 		switch err := err.(type) {
@@ -425,7 +471,13 @@ func GetGatewayStats(db *sqlx.DB, mac lorawan.EUI64, interval string, start, end
 			coalesce(gs.rx_packets_received, 0) as rx_packets_received,
 			coalesce(gs.rx_packets_received_ok, 0) as rx_packets_received_ok,
 			coalesce(gs.tx_packets_received, 0) as tx_packets_received,
-			coalesce(gs.tx_packets_emitted, 0) as tx_packets_emitted
+			coalesce(gs.tx_packets_emitted, 0) as tx_packets_emitted,
+			coalesce(gs.rx_frames, 0) as rx_frames,
+			coalesce(gs.rx_frames_crc_error, 0) as rx_frames_crc_error,
+			coalesce(gs.rx_rssi_sum, 0) as rx_rssi_sum,
+			coalesce(gs.rx_snr_sum, 0) as rx_snr_sum,
+			coalesce(gs.tx_frames_scheduled, 0) as tx_frames_scheduled,
+			coalesce(gs.tx_frames_acked, 0) as tx_frames_acked
 		from (
 			select
 				*
@@ -452,6 +504,11 @@ func GetGatewayStats(db *sqlx.DB, mac lorawan.EUI64, interval string, start, end
 	if err != nil {
 		return nil, errors.Wrap(err, "select error")
 	}
+
+	if err := setFrameStats(tx, mac, interval, start, end, stats); err != nil {
+		return nil, errors.Wrap(err, "set frame stats error")
+	}
+
 	return stats, nil
 }
 
@@ -732,6 +789,21 @@ func UpdateExtraChannel(db *sqlx.DB, c *ExtraChannel) error {
 	}
 
 	now := time.Now()
+
+	// when the extra channel is moved to an other channel-configuration,
+	// the configuration it is removed from changes as well
+	_, err := db.Exec(`
+		update channel_configuration
+		set
+			updated_at = $2
+		where
+			id = (select channel_configuration_id from extra_channel where id = $1)
+			and id != $3
+	`, c.ID, now, c.ChannelConfigurationID)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+
 	res, err := db.Exec(`
 		update extra_channel set
 			channel_configuration_id = $2,
@@ -783,19 +855,28 @@ func UpdateExtraChannel(db *sqlx.DB, c *ExtraChannel) error {
 }
 
 // DeleteExtraChannel deletes the extra channel matching the given id.
+// This will also update the UpdatedAt timestamp of the ChannelConfiguration.
 func DeleteExtraChannel(db *sqlx.DB, id int64) error {
-	res, err := db.Exec("delete from extra_channel where id = $1", id)
+	var cfID int64
+	err := db.Get(&cfID, "delete from extra_channel where id = $1 returning channel_configuration_id", id)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDoesNotExist
+		}
 		return errors.Wrap(err, "delete error")
 	}
-	ra, err := res.RowsAffected()
+	log.WithField("id", id).Info("extra channel deleted")
+
+	_, err = db.Exec(`
+		update channel_configuration
+		set
+			updated_at = $2
+		where
+			id = $1
+	`, cfID, time.Now())
 	if err != nil {
-		return errors.Wrap(err, "get rows affected error")
-	}
-	if ra == 0 {
-		return ErrDoesNotExist
+		return errors.Wrap(err, "update error")
 	}
-	log.WithField("id", id).Info("extra channel deleted")
 
 	return nil
 }
@@ -916,6 +997,10 @@ func handleStatsPacket(db *sqlx.DB, stats gw.GatewayStatsPacket) error {
 		}
 	}
 
+	if err := handleConfigVersion(db, gw, stats.ConfigVersion); err != nil {
+		log.WithField("mac", stats.MAC).Errorf("handle gateway configuration version error: %s", err)
+	}
+
 	comitted := false
 	tx, err := db.Beginx()
 	if err != nil {
@@ -958,6 +1043,35 @@ func handleStatsPacket(db *sqlx.DB, stats gw.GatewayStatsPacket) error {
 	return nil
 }
 
+// handleConfigVersion stores the configuration version reported by the
+// gateway. When the gateway reports a version (thus supports configuration
+// updates) which is not equal to the desired version, the configuration
+// is sent again.
+func handleConfigVersion(db *sqlx.DB, gw Gateway, version string) error {
+	if version == "" {
+		return nil
+	}
+
+	if version != gw.ConfigVersion {
+		if err := SetConfigVersion(db, gw.MAC, version); err != nil {
+			return errors.Wrap(err, "set config version error")
+		}
+	}
+
+	desired, err := GetDesiredConfigVersion(db, gw)
+	if err != nil {
+		return errors.Wrap(err, "get desired config version error")
+	}
+
+	if desired != "" && desired != version {
+		if err := SendConfigPacket(db, gw.MAC); err != nil {
+			return errors.Wrap(err, "send config packet error")
+		}
+	}
+
+	return nil
+}
+
 func aggregateGatewayStats(db sqlx.Execer, stats Stats) error {
 	_, err := db.Exec(`
 		insert into gateway_stats (
@@ -967,7 +1081,13 @@ func aggregateGatewayStats(db sqlx.Execer, stats Stats) error {
 			rx_packets_received,
 			rx_packets_received_ok,
 			tx_packets_received,
-			tx_packets_emitted
+			tx_packets_emitted,
+			rx_frames,
+			rx_frames_crc_error,
+			rx_rssi_sum,
+			rx_snr_sum,
+			tx_frames_scheduled,
+			tx_frames_acked
 		) values (
 			$1,
 			cast(date_trunc($2, $3::timestamptz) as timestamp with time zone),
@@ -975,14 +1095,26 @@ func aggregateGatewayStats(db sqlx.Execer, stats Stats) error {
 			$4,
 			$5,
 			$6,
-			$7
+			$7,
+			$8,
+			$9,
+			$10,
+			$11,
+			$12,
+			$13
 		)
 		on conflict (mac, "timestamp", "interval")
 			do update set
 				rx_packets_received = gateway_stats.rx_packets_received + $4,
 				rx_packets_received_ok = gateway_stats.rx_packets_received_ok + $5,
 				tx_packets_received = gateway_stats.tx_packets_received + $6,
-				tx_packets_emitted = gateway_stats.tx_packets_emitted + $7`,
+				tx_packets_emitted = gateway_stats.tx_packets_emitted + $7,
+				rx_frames = gateway_stats.rx_frames + $8,
+				rx_frames_crc_error = gateway_stats.rx_frames_crc_error + $9,
+				rx_rssi_sum = gateway_stats.rx_rssi_sum + $10,
+				rx_snr_sum = gateway_stats.rx_snr_sum + $11,
+				tx_frames_scheduled = gateway_stats.tx_frames_scheduled + $12,
+				tx_frames_acked = gateway_stats.tx_frames_acked + $13`,
 		stats.MAC[:],
 		stats.Interval,
 		stats.Timestamp,
@@ -990,6 +1122,12 @@ func aggregateGatewayStats(db sqlx.Execer, stats Stats) error {
 		stats.RXPacketsReceivedOK,
 		stats.TXPacketsReceived,
 		stats.TXPacketsEmitted,
+		stats.RXFrames,
+		stats.RXFramesCRCError,
+		stats.RXRSSISum,
+		stats.RXSNRSum,
+		stats.TXFramesScheduled,
+		stats.TXFramesAcked,
 	)
 	if err != nil {
 		return errors.Wrap(err, "insert or update aggregate error")
@@ -0,0 +1,247 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/api/nc"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/lorawan"
+)
+
+// Gateway states.
+const (
+	StateNeverSeen = "NEVER_SEEN"
+	StateOnline    = "ONLINE"
+	StateOffline   = "OFFLINE"
+)
+
+// webhookTimeout defines the timeout of a webhook request.
+const webhookTimeout = 10 * time.Second
+
+// StateChange contains the state change of a gateway.
+type StateChange struct {
+	MAC            lorawan.EUI64 `json:"mac"`
+	State          string        `json:"state"`
+	StateChangedAt time.Time     `json:"stateChangedAt"`
+	LastSeenAt     *time.Time    `json:"lastSeenAt"`
+}
+
+// Notifier defines the interface for publishing gateway state changes.
+type Notifier interface {
+	NotifyStateChange(StateChange) error
+}
+
+// ControllerNotifier publishes the state changes to the network-controller.
+type ControllerNotifier struct{}
+
+// NotifyStateChange publishes the given state change.
+func (ControllerNotifier) NotifyStateChange(sc StateChange) error {
+	req := nc.HandleGatewayStateChangeRequest{
+		Mac:            sc.MAC[:],
+		StateChangedAt: sc.StateChangedAt.Format(time.RFC3339Nano),
+	}
+
+	switch sc.State {
+	case StateOnline:
+		req.State = nc.GatewayState_ONLINE
+	case StateOffline:
+		req.State = nc.GatewayState_OFFLINE
+	}
+
+	if sc.LastSeenAt != nil {
+		req.LastSeenAt = sc.LastSeenAt.Format(time.RFC3339Nano)
+	}
+
+	_, err := common.Controller.HandleGatewayStateChange(context.Background(), &req)
+	if err != nil {
+		return errors.Wrap(err, "handle gateway state change error")
+	}
+	return nil
+}
+
+// WebhookNotifier publishes the state changes as JSON to the configured URL
+// (using POST).
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url: url,
+		httpClient: &http.Client{
+			Timeout: webhookTimeout,
+		},
+	}
+}
+
+// NotifyStateChange publishes the given state change.
+func (n *WebhookNotifier) NotifyStateChange(sc StateChange) error {
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	resp, err := n.httpClient.Post(n.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "http post error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("expected 2XX response, got: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MultiNotifier publishes the state changes to all of its notifiers.
+type MultiNotifier []Notifier
+
+// NotifyStateChange publishes the given state change. Notifiers are not
+// skipped when a previous notifier returns an error, the last error is
+// returned.
+func (m MultiNotifier) NotifyStateChange(sc StateChange) error {
+	var lastErr error
+	for _, n := range m {
+		if err := n.NotifyStateChange(sc); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// HealthMonitor marks the gateways offline after a given number of missed
+// stats intervals and back online when they are seen again.
+type HealthMonitor struct {
+	notifier      Notifier
+	statsInterval time.Duration
+	missedStats   int
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewHealthMonitor creates a new HealthMonitor.
+func NewHealthMonitor(notifier Notifier, statsInterval time.Duration, missedStats int) *HealthMonitor {
+	return &HealthMonitor{
+		notifier:      notifier,
+		statsInterval: statsInterval,
+		missedStats:   missedStats,
+		done:          make(chan struct{}),
+	}
+}
+
+// Start starts the health monitor. The gateway states are checked every
+// stats interval.
+func (m *HealthMonitor) Start() error {
+	if m.statsInterval <= 0 || m.missedStats <= 0 {
+		return errors.New("stats interval and missed stats must be greater than 0")
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.statsInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+				if err := m.check(common.DB); err != nil {
+					log.Errorf("check gateway states error: %s", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the health monitor.
+func (m *HealthMonitor) Stop() error {
+	close(m.done)
+	m.wg.Wait()
+	return nil
+}
+
+// check updates the gateway states and publishes the changes.
+func (m *HealthMonitor) check(db *sqlx.DB) error {
+	changes, err := UpdateGatewayStates(db, time.Now().Add(-m.statsInterval*time.Duration(m.missedStats)))
+	if err != nil {
+		return errors.Wrap(err, "update gateway states error")
+	}
+
+	for _, sc := range changes {
+		log.WithFields(log.Fields{
+			"mac":   sc.MAC,
+			"state": sc.State,
+		}).Info("gateway state changed")
+
+		if err := m.notifier.NotifyStateChange(sc); err != nil {
+			log.WithField("mac", sc.MAC).Errorf("notify gateway state change error: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateGatewayStates marks the gateways which were not seen since the given
+// time as offline and the gateways which were seen since the given time as
+// online. It returns the state changes. As the state is updated atomically,
+// each state change is returned only once, also when called concurrently
+// by multiple instances.
+func UpdateGatewayStates(db *sqlx.DB, seenSince time.Time) ([]StateChange, error) {
+	var out []StateChange
+	now := time.Now()
+
+	for _, q := range []string{
+		`update gateway set
+			state = 'OFFLINE',
+			state_changed_at = $2
+		where
+			state = 'ONLINE'
+			and (last_seen_at is null or last_seen_at < $1)
+		returning mac, state, state_changed_at, last_seen_at`,
+		`update gateway set
+			state = 'ONLINE',
+			state_changed_at = $2
+		where
+			state != 'ONLINE'
+			and last_seen_at >= $1
+		returning mac, state, state_changed_at, last_seen_at`,
+	} {
+		rows, err := db.Queryx(q, seenSince, now)
+		if err != nil {
+			return nil, errors.Wrap(err, "update error")
+		}
+
+		for rows.Next() {
+			var sc StateChange
+			if err := rows.Scan(&sc.MAC, &sc.State, &sc.StateChangedAt, &sc.LastSeenAt); err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, "scan row error")
+			}
+			out = append(out, sc)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "rows error")
+		}
+		rows.Close()
+	}
+
+	return out, nil
+}
@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+type testNotifier struct {
+	changes []StateChange
+}
+
+func (n *testNotifier) NotifyStateChange(sc StateChange) error {
+	n.changes = append(n.changes, sc)
+	return nil
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	Convey("Given a test webhook server", t, func() {
+		scChan := make(chan StateChange, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var sc StateChange
+			if err := json.NewDecoder(r.Body).Decode(&sc); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			scChan <- sc
+		}))
+		defer server.Close()
+
+		Convey("Then NotifyStateChange posts the state change", func() {
+			sc := StateChange{
+				MAC:            lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				State:          StateOffline,
+				StateChangedAt: time.Now().UTC().Truncate(time.Millisecond),
+			}
+			So(NewWebhookNotifier(server.URL).NotifyStateChange(sc), ShouldBeNil)
+			So(<-scChan, ShouldResemble, sc)
+		})
+	})
+}
+
+func TestUpdateGatewayStates(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(common.DB)
+
+		g := Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(CreateGateway(common.DB, &g), ShouldBeNil)
+
+		Convey("Then the gateway state is NEVER_SEEN", func() {
+			g, err := GetGateway(common.DB, g.MAC)
+			So(err, ShouldBeNil)
+			So(g.State, ShouldEqual, StateNeverSeen)
+			So(g.StateChangedAt, ShouldBeNil)
+		})
+
+		Convey("Then UpdateGatewayStates does not return any changes", func() {
+			changes, err := UpdateGatewayStates(common.DB, time.Now().Add(-time.Minute))
+			So(err, ShouldBeNil)
+			So(changes, ShouldHaveLength, 0)
+		})
+
+		Convey("When the gateway has been seen", func() {
+			now := time.Now()
+			g.LastSeenAt = &now
+			So(UpdateGateway(common.DB, &g), ShouldBeNil)
+
+			notifier := testNotifier{}
+			hm := NewHealthMonitor(&notifier, 30*time.Second, 3)
+			So(hm.check(common.DB), ShouldBeNil)
+
+			Convey("Then the gateway is marked online", func() {
+				So(notifier.changes, ShouldHaveLength, 1)
+				So(notifier.changes[0].MAC, ShouldEqual, g.MAC)
+				So(notifier.changes[0].State, ShouldEqual, StateOnline)
+
+				g, err := GetGateway(common.DB, g.MAC)
+				So(err, ShouldBeNil)
+				So(g.State, ShouldEqual, StateOnline)
+				So(g.StateChangedAt, ShouldNotBeNil)
+			})
+
+			Convey("Then checking again does not return any changes", func() {
+				So(hm.check(common.DB), ShouldBeNil)
+				So(notifier.changes, ShouldHaveLength, 1)
+			})
+
+			Convey("When the gateway missed its stats intervals", func() {
+				lastSeen := now.Add(-2 * time.Minute)
+				g.LastSeenAt = &lastSeen
+				So(UpdateGateway(common.DB, &g), ShouldBeNil)
+				So(hm.check(common.DB), ShouldBeNil)
+
+				Convey("Then the gateway is marked offline", func() {
+					So(notifier.changes, ShouldHaveLength, 2)
+					So(notifier.changes[1].State, ShouldEqual, StateOffline)
+
+					g, err := GetGateway(common.DB, g.MAC)
+					So(err, ShouldBeNil)
+					So(g.State, ShouldEqual, StateOffline)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,134 @@
+package gateway
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Token contains the meta-data of a JWT token issued to a gateway. The
+// token itself is not stored.
+type Token struct {
+	ID        string        `db:"id"`
+	MAC       lorawan.EUI64 `db:"mac"`
+	KeyID     string        `db:"key_id"`
+	CreatedAt time.Time     `db:"created_at"`
+	ExpiresAt *time.Time    `db:"expires_at"`
+	RevokedAt *time.Time    `db:"revoked_at"`
+}
+
+// IsRevoked returns true when the token has been revoked.
+func (t Token) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// CreateToken creates the given gateway token. When the ID is not set, a
+// random ID will be generated.
+func CreateToken(db sqlx.Execer, t *Token) error {
+	if t.ID == "" {
+		t.ID = uuid.NewV4().String()
+	}
+	t.CreatedAt = time.Now()
+
+	_, err := db.Exec(`
+		insert into gateway_token (
+			id,
+			mac,
+			key_id,
+			created_at,
+			expires_at,
+			revoked_at
+		) values ($1, $2, $3, $4, $5, $6)`,
+		t.ID,
+		t.MAC[:],
+		t.KeyID,
+		t.CreatedAt,
+		t.ExpiresAt,
+		t.RevokedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":     t.ID,
+		"mac":    t.MAC,
+		"key_id": t.KeyID,
+	}).Info("gateway token created")
+	return nil
+}
+
+// GetToken returns the gateway token for the given ID.
+func GetToken(db sqlx.Queryer, id string) (Token, error) {
+	var t Token
+	if _, err := uuid.FromString(id); err != nil {
+		return t, ErrTokenDoesNotExist
+	}
+
+	err := sqlx.Get(db, &t, "select * from gateway_token where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return t, ErrTokenDoesNotExist
+		}
+		return t, errors.Wrap(err, "select error")
+	}
+	return t, nil
+}
+
+// GetTokensForMAC returns the tokens issued to the given gateway, most
+// recent first.
+func GetTokensForMAC(db sqlx.Queryer, mac lorawan.EUI64) ([]Token, error) {
+	var tokens []Token
+	err := sqlx.Select(db, &tokens, `
+		select *
+		from gateway_token
+		where mac = $1
+		order by created_at desc`,
+		mac[:],
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes the given token of the given gateway. Revoking an
+// already revoked token does not change its revocation timestamp.
+func RevokeToken(db sqlx.Execer, mac lorawan.EUI64, id string) error {
+	if _, err := uuid.FromString(id); err != nil {
+		return ErrTokenDoesNotExist
+	}
+
+	res, err := db.Exec(`
+		update gateway_token
+		set revoked_at = coalesce(revoked_at, $3)
+		where
+			id = $1
+			and mac = $2`,
+		id,
+		mac[:],
+		time.Now(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrTokenDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"id":  id,
+		"mac": mac,
+	}).Info("gateway token revoked")
+	return nil
+}
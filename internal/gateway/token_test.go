@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestToken(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Convey("Given a clean database with a gateway", t, func() {
+		test.MustResetDB(db)
+
+		g := Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(CreateGateway(db, &g), ShouldBeNil)
+
+		Convey("When creating a token", func() {
+			expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+			gt := Token{
+				MAC:       g.MAC,
+				KeyID:     "key1",
+				ExpiresAt: &expiresAt,
+			}
+			So(CreateToken(db, &gt), ShouldBeNil)
+			So(gt.ID, ShouldNotEqual, "")
+
+			Convey("Then GetToken returns the token", func() {
+				gt2, err := GetToken(db, gt.ID)
+				So(err, ShouldBeNil)
+				So(gt2.MAC, ShouldEqual, g.MAC)
+				So(gt2.KeyID, ShouldEqual, "key1")
+				So(gt2.ExpiresAt.Equal(expiresAt), ShouldBeTrue)
+				So(gt2.IsRevoked(), ShouldBeFalse)
+			})
+
+			Convey("Then GetTokensForMAC returns the token", func() {
+				tokens, err := GetTokensForMAC(db, g.MAC)
+				So(err, ShouldBeNil)
+				So(tokens, ShouldHaveLength, 1)
+				So(tokens[0].ID, ShouldEqual, gt.ID)
+			})
+
+			Convey("Then the token can not be revoked for an other gateway", func() {
+				So(RevokeToken(db, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}, gt.ID), ShouldEqual, ErrTokenDoesNotExist)
+			})
+
+			Convey("When revoking the token", func() {
+				So(RevokeToken(db, g.MAC, gt.ID), ShouldBeNil)
+
+				Convey("Then the token is revoked", func() {
+					gt2, err := GetToken(db, gt.ID)
+					So(err, ShouldBeNil)
+					So(gt2.IsRevoked(), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("Then GetToken returns ErrTokenDoesNotExist for an invalid ID", func() {
+			_, err := GetToken(db, "invalid")
+			So(err, ShouldEqual, ErrTokenDoesNotExist)
+		})
+	})
+}
@@ -0,0 +1,309 @@
+// Package housekeeping implements the periodic removal of expired gateway
+// stats and frame logs. When running multiple LoRa Server instances, a
+// Redis lock makes sure only one instance runs the housekeeping at a time.
+package housekeeping
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// lockKey defines the Redis key used for the housekeeping lock.
+const lockKey = "lora:ns:housekeeping:lock"
+
+// gatewayStatsTables contains the tables containing the (aggregated)
+// gateway stats.
+var gatewayStatsTables = []string{
+	"gateway_stats",
+	"gateway_stats_frame",
+	"gateway_stats_signal",
+	"gateway_stats_device",
+}
+
+// aggregationIntervals contains the valid gateway stats aggregation
+// intervals.
+var aggregationIntervals = []string{
+	"SECOND",
+	"MINUTE",
+	"HOUR",
+	"DAY",
+	"WEEK",
+	"MONTH",
+	"QUARTER",
+	"YEAR",
+}
+
+// Config contains the housekeeping configuration.
+type Config struct {
+	// Interval defines the interval in which the housekeeping runs.
+	Interval time.Duration
+
+	// BatchSize defines the max number of rows deleted by a single query,
+	// so that the tables are not locked for long.
+	BatchSize int
+
+	// GatewayStatsRetention contains the retention per aggregation interval.
+	// The stats of intervals without retention are kept forever.
+	GatewayStatsRetention map[string]time.Duration
+
+	// FrameLogMaxAge defines the max age of a frame log (0 = no limit).
+	FrameLogMaxAge time.Duration
+
+	// FrameLogMaxCount defines the max number of frame logs (0 = no limit).
+	// The oldest logs are removed first.
+	FrameLogMaxCount int
+}
+
+// ParseRetention parses the given retention policy string
+// (e.g. "second=1h,minute=48h") into a map of aggregation interval to
+// retention.
+func ParseRetention(s string) (map[string]time.Duration, error) {
+	out := make(map[string]time.Duration)
+	if s == "" {
+		return out, nil
+	}
+
+	for _, item := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid retention '%s', expected interval=duration", item)
+		}
+
+		interval := strings.ToUpper(kv[0])
+		var valid bool
+		for _, i := range aggregationIntervals {
+			if i == interval {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("'%s' is not a valid aggregation interval", kv[0])
+		}
+
+		d, err := time.ParseDuration(kv[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "parse duration error")
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("retention of interval '%s' must be greater than 0", kv[0])
+		}
+
+		out[interval] = d
+	}
+
+	return out, nil
+}
+
+// Housekeeping periodically removes the expired gateway stats and frame
+// logs.
+type Housekeeping struct {
+	config    Config
+	db        *sqlx.DB
+	redisPool *redis.Pool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new Housekeeping.
+func New(db *sqlx.DB, p *redis.Pool, c Config) *Housekeeping {
+	return &Housekeeping{
+		config:    c,
+		db:        db,
+		redisPool: p,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start starts the housekeeping. It runs every configured interval.
+func (h *Housekeeping) Start() error {
+	if h.config.Interval <= 0 {
+		return errors.New("housekeeping interval must be greater than 0")
+	}
+	if h.config.BatchSize <= 0 {
+		return errors.New("housekeeping batch-size must be greater than 0")
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(h.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-h.done:
+				return
+			case <-ticker.C:
+				if err := h.run(); err != nil {
+					log.Errorf("housekeeping: run error: %s", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the housekeeping. It waits for a running housekeeping job to
+// complete.
+func (h *Housekeeping) Stop() error {
+	close(h.done)
+	h.wg.Wait()
+	return nil
+}
+
+// run runs the housekeeping, when the lock could be acquired.
+func (h *Housekeeping) run() error {
+	ok, err := acquireLock(h.redisPool, h.config.Interval)
+	if err != nil {
+		return errors.Wrap(err, "acquire lock error")
+	}
+	if !ok {
+		log.Debug("housekeeping: lock is held by an other instance")
+		return nil
+	}
+
+	now := time.Now()
+	for interval, retention := range h.config.GatewayStatsRetention {
+		count, err := DeleteGatewayStats(h.db, interval, now.Add(-retention), h.config.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, "delete gateway stats error")
+		}
+		log.WithFields(log.Fields{
+			"interval": interval,
+			"count":    count,
+		}).Info("housekeeping: expired gateway stats deleted")
+	}
+
+	if h.config.FrameLogMaxAge > 0 {
+		count, err := DeleteFrameLogsBefore(h.db, now.Add(-h.config.FrameLogMaxAge), h.config.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, "delete expired frame logs error")
+		}
+		log.WithField("count", count).Info("housekeeping: expired frame logs deleted")
+	}
+
+	if h.config.FrameLogMaxCount > 0 {
+		count, err := DeleteFrameLogsExceeding(h.db, h.config.FrameLogMaxCount, h.config.BatchSize)
+		if err != nil {
+			return errors.Wrap(err, "delete exceeding frame logs error")
+		}
+		log.WithField("count", count).Info("housekeeping: exceeding frame logs deleted")
+	}
+
+	return nil
+}
+
+// acquireLock tries to acquire the housekeeping lock. The lock is not
+// released, but expires after the given TTL (the housekeeping interval),
+// so that the housekeeping runs only once per interval.
+func acquireLock(p *redis.Pool, ttl time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := redis.String(c.Do("SET", lockKey, "lock", "PX", int64(ttl/time.Millisecond), "NX"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteGatewayStats deletes the gateway stats of the given aggregation
+// interval before the given time, in batches of the given size. It returns
+// the number of deleted rows.
+func DeleteGatewayStats(db *sqlx.DB, interval string, before time.Time, batchSize int) (int64, error) {
+	var total int64
+	for _, table := range gatewayStatsTables {
+		count, err := deleteInBatches(db, batchSize, fmt.Sprintf(`
+			delete from %s
+			where id in (
+				select id
+				from %s
+				where
+					"interval" = $1
+					and "timestamp" < $2
+				limit $3
+			)`, table, table),
+			strings.ToUpper(interval),
+			before,
+		)
+		if err != nil {
+			return total, errors.Wrapf(err, "delete from %s error", table)
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// DeleteFrameLogsBefore deletes the frame logs created before the given
+// time, in batches of the given size. It returns the number of deleted rows.
+func DeleteFrameLogsBefore(db *sqlx.DB, before time.Time, batchSize int) (int64, error) {
+	return deleteInBatches(db, batchSize, `
+		delete from frame_log
+		where id in (
+			select id
+			from frame_log
+			where created_at < $1
+			limit $2
+		)`,
+		before,
+	)
+}
+
+// DeleteFrameLogsExceeding deletes the oldest frame logs so that at most
+// maxCount frame logs are left, in batches of the given size. It returns
+// the number of deleted rows.
+func DeleteFrameLogsExceeding(db *sqlx.DB, maxCount int, batchSize int) (int64, error) {
+	return deleteInBatches(db, batchSize, `
+		delete from frame_log
+		where id in (
+			select id
+			from frame_log
+			where id <= (
+				select id
+				from frame_log
+				order by id desc
+				offset $1
+				limit 1
+			)
+			limit $2
+		)`,
+		maxCount,
+	)
+}
+
+// deleteInBatches executes the given delete query until less than batchSize
+// rows are affected. The batch-size is appended to the given query
+// arguments. As each batch is executed in its own transaction, the table
+// is only locked for a short time.
+func deleteInBatches(db *sqlx.DB, batchSize int, query string, args ...interface{}) (int64, error) {
+	var total int64
+	args = append(args, batchSize)
+
+	for {
+		res, err := db.Exec(query, args...)
+		if err != nil {
+			return total, errors.Wrap(err, "delete error")
+		}
+		ra, err := res.RowsAffected()
+		if err != nil {
+			return total, errors.Wrap(err, "get rows affected error")
+		}
+		total += ra
+
+		if ra < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
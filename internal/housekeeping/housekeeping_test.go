@@ -0,0 +1,133 @@
+package housekeeping
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/node"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestParseRetention(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name          string
+			Retention     string
+			Expected      map[string]time.Duration
+			ExpectedError bool
+		}{
+			{
+				Name:      "empty",
+				Retention: "",
+				Expected:  map[string]time.Duration{},
+			},
+			{
+				Name:      "valid",
+				Retention: "second=1h, MINUTE=48h",
+				Expected: map[string]time.Duration{
+					"SECOND": time.Hour,
+					"MINUTE": 48 * time.Hour,
+				},
+			},
+			{
+				Name:          "invalid interval",
+				Retention:     "fortnight=1h",
+				ExpectedError: true,
+			},
+			{
+				Name:          "invalid duration",
+				Retention:     "second=1d",
+				ExpectedError: true,
+			},
+			{
+				Name:          "missing duration",
+				Retention:     "second",
+				ExpectedError: true,
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				retention, err := ParseRetention(tst.Retention)
+				if tst.ExpectedError {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(retention, ShouldResemble, tst.Expected)
+			})
+		}
+	})
+}
+
+func TestHousekeeping(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+	p := common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database and Redis", t, func() {
+		test.MustResetDB(common.DB)
+		test.MustFlushRedis(p)
+		gateway.MustSetStatsAggregationIntervals([]string{"SECOND", "MINUTE"})
+
+		g := gateway.Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gw",
+		}
+		So(gateway.CreateGateway(common.DB, &g), ShouldBeNil)
+		So(gateway.HandleTXPacketStats(common.DB, gw.TXPacket{TXInfo: gw.TXInfo{MAC: g.MAC}}), ShouldBeNil)
+
+		for i := 0; i < 5; i++ {
+			So(node.CreateFrameLog(common.DB, &node.FrameLog{
+				DevEUI:     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				PHYPayload: []byte{1, 2, 3, 4},
+			}), ShouldBeNil)
+		}
+
+		Convey("Then DeleteGatewayStats only deletes the stats of the given interval", func() {
+			count, err := DeleteGatewayStats(common.DB, "second", time.Now().Add(time.Minute), 1)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+
+			var intervals []string
+			So(common.DB.Select(&intervals, `select "interval" from gateway_stats`), ShouldBeNil)
+			So(intervals, ShouldResemble, []string{"MINUTE"})
+		})
+
+		Convey("Then DeleteFrameLogsExceeding keeps the most recent frame logs", func() {
+			count, err := DeleteFrameLogsExceeding(common.DB, 2, 2)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 3)
+
+			n, err := node.GetFrameLogCountForDevEUI(common.DB, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+			So(n, ShouldEqual, 2)
+		})
+
+		Convey("Then DeleteFrameLogsBefore deletes the expired frame logs", func() {
+			count, err := DeleteFrameLogsBefore(common.DB, time.Now().Add(time.Minute), 2)
+			So(err, ShouldBeNil)
+			So(count, ShouldEqual, 5)
+		})
+
+		Convey("Then the lock can only be acquired once per interval", func() {
+			ok, err := acquireLock(p, time.Minute)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+
+			ok, err = acquireLock(p, time.Minute)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
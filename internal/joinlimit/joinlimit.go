@@ -0,0 +1,211 @@
+// Package joinlimit implements the join-request flood protection: rate
+// limiting (with back-off) by DevEUI and JoinEUI, caching of unknown
+// DevEUIs, limiting of the (not yet authenticated) join-request attempts and
+// counting of the rejected join-requests.
+package joinlimit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// Templates used for generating Redis keys
+const (
+	counterKeyTempl       = "lora:ns:join:limit:%s:%s"
+	levelKeyTempl         = "lora:ns:join:limit:%s:%s:level"
+	backoffKeyTempl       = "lora:ns:join:limit:%s:%s:backoff"
+	unknownDevEUIKeyTempl = "lora:ns:join:unknown:%s"
+	rejectedKey           = "lora:ns:join:rejected"
+)
+
+// Scope defines the scope of a rate limit.
+type Scope string
+
+// Available rate limit scopes.
+const (
+	DevEUI        Scope = "dev_eui"
+	JoinEUI       Scope = "join_eui"
+	DevEUIAttempt Scope = "dev_eui_attempt"
+)
+
+// Reason defines the reason for rejecting a join-request.
+type Reason string
+
+// Available reasons for rejecting a join-request.
+const (
+	UnknownDevEUI          Reason = "UNKNOWN_DEV_EUI"
+	DevEUIRateLimited      Reason = "DEV_EUI_RATE_LIMITED"
+	JoinEUIRateLimited     Reason = "JOIN_EUI_RATE_LIMITED"
+	DevEUIAttemptsExceeded Reason = "DEV_EUI_ATTEMPTS_EXCEEDED"
+)
+
+// incrementScript atomically increments the counter (KEYS[1]) and sets its
+// expiration (ARGV[1], in milliseconds) when the counter has been created.
+var incrementScript = redis.NewScript(1, `
+	local count = redis.call("INCR", KEYS[1])
+	if count == 1 then
+		redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	end
+	return count
+`)
+
+// InBackoff returns true when the given scope and EUI are within a back-off
+// period (see Allow). As it does not count the join-request, it can be used
+// before the join-request has been authenticated.
+func InBackoff(p *redis.Pool, scope Scope, eui lorawan.EUI64) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	backoff, err := redis.Bool(c.Do("EXISTS", fmt.Sprintf(backoffKeyTempl, scope, eui)))
+	if err != nil {
+		return false, errors.Wrap(err, "get back-off error")
+	}
+	return backoff, nil
+}
+
+// Allow counts the join-request and returns false when more than limit
+// join-requests have been received within the given interval for the given
+// scope and EUI. When the limit is exceeded, join-requests are rejected
+// during a back-off period, starting at the interval and doubling each time
+// the limit is exceeded again (until maxBackoff). The back-off is reset
+// after a quiet period of twice the maxBackoff.
+//
+// Allow must only be called for authenticated join-requests, else forged
+// join-requests would consume the limit of a device.
+func Allow(p *redis.Pool, scope Scope, eui lorawan.EUI64, limit int, interval, maxBackoff time.Duration) (bool, error) {
+	backoff, err := InBackoff(p, scope, eui)
+	if err != nil {
+		return false, err
+	}
+	if backoff {
+		return false, nil
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	counterKey := fmt.Sprintf(counterKeyTempl, scope, eui)
+	count, err := redis.Int(incrementScript.Do(c, counterKey, int64(interval/time.Millisecond)))
+	if err != nil {
+		return false, errors.Wrap(err, "increment counter error")
+	}
+	if count <= limit {
+		return true, nil
+	}
+
+	levelKey := fmt.Sprintf(levelKeyTempl, scope, eui)
+	level, err := redis.Int(c.Do("INCR", levelKey))
+	if err != nil {
+		return false, errors.Wrap(err, "increment back-off level error")
+	}
+
+	d := getBackoff(level, interval, maxBackoff)
+
+	c.Send("MULTI")
+	c.Send("PEXPIRE", levelKey, int64(2*maxBackoff/time.Millisecond))
+	c.Send("PSETEX", fmt.Sprintf(backoffKeyTempl, scope, eui), int64(d/time.Millisecond), level)
+	c.Send("DEL", counterKey)
+	if _, err := c.Do("EXEC"); err != nil {
+		return false, errors.Wrap(err, "set back-off error")
+	}
+
+	return false, nil
+}
+
+// AllowAttempt counts the join-request and returns false when more than
+// limit join-requests have been received within the given interval for the
+// given scope and EUI. Unlike Allow, there is no back-off as AllowAttempt
+// is called before the join-request has been authenticated: forged
+// join-requests can block a device at most until the end of the interval.
+func AllowAttempt(p *redis.Pool, scope Scope, eui lorawan.EUI64, limit int, interval time.Duration) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	count, err := redis.Int(incrementScript.Do(c, fmt.Sprintf(counterKeyTempl, scope, eui), int64(interval/time.Millisecond)))
+	if err != nil {
+		return false, errors.Wrap(err, "increment counter error")
+	}
+	return count <= limit, nil
+}
+
+// SetUnknownDevEUI caches the given DevEUI as unknown for the given ttl.
+func SetUnknownDevEUI(p *redis.Pool, devEUI lorawan.EUI64, ttl time.Duration) error {
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(unknownDevEUIKeyTempl, devEUI), int64(ttl/time.Millisecond), 1)
+	if err != nil {
+		return errors.Wrap(err, "set unknown DevEUI error")
+	}
+	return nil
+}
+
+// IsUnknownDevEUI returns true when the given DevEUI is cached as unknown.
+func IsUnknownDevEUI(p *redis.Pool, devEUI lorawan.EUI64) (bool, error) {
+	c := p.Get()
+	defer c.Close()
+
+	unknown, err := redis.Bool(c.Do("EXISTS", fmt.Sprintf(unknownDevEUIKeyTempl, devEUI)))
+	if err != nil {
+		return false, errors.Wrap(err, "get unknown DevEUI error")
+	}
+	return unknown, nil
+}
+
+// ClearUnknownDevEUI removes the given DevEUI from the unknown DevEUI cache.
+// This must be called when a device is created.
+func ClearUnknownDevEUI(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(unknownDevEUIKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "delete unknown DevEUI error")
+	}
+	return nil
+}
+
+// IncrementRejected increments the rejected join-requests counter for the
+// given reason.
+func IncrementRejected(p *redis.Pool, reason Reason) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("HINCRBY", rejectedKey, string(reason), 1); err != nil {
+		return errors.Wrap(err, "increment rejected counter error")
+	}
+	return nil
+}
+
+// GetRejectedCounts returns the number of rejected join-requests by reason.
+func GetRejectedCounts(p *redis.Pool) (map[Reason]int64, error) {
+	c := p.Get()
+	defer c.Close()
+
+	values, err := redis.Int64Map(c.Do("HGETALL", rejectedKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "get rejected counters error")
+	}
+
+	out := make(map[Reason]int64)
+	for k, v := range values {
+		out[Reason(k)] = v
+	}
+	return out, nil
+}
+
+// getBackoff returns the back-off duration for the given level.
+func getBackoff(level int, interval, maxBackoff time.Duration) time.Duration {
+	d := interval
+	for i := 1; i < level && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
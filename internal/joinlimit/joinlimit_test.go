@@ -0,0 +1,168 @@
+package joinlimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGetBackoff(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Level    int
+			Expected time.Duration
+		}{
+			{1, time.Minute},
+			{2, 2 * time.Minute},
+			{3, 4 * time.Minute},
+			{6, 32 * time.Minute},
+			{7, time.Hour},
+			{100, time.Hour},
+		}
+
+		for i, t := range tests {
+			Convey(fmt.Sprintf("Testing level: %d [%d]", t.Level, i), func() {
+				So(getBackoff(t.Level, time.Minute, time.Hour), ShouldEqual, t.Expected)
+			})
+		}
+	})
+}
+
+func TestJoinLimit(t *testing.T) {
+	conf := test.GetConfig()
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean Redis database", t, func() {
+		test.MustFlushRedis(common.RedisPool)
+
+		eui := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+
+		Convey("Then Allow returns true until the limit is exceeded", func() {
+			for i := 0; i < 3; i++ {
+				allowed, err := Allow(common.RedisPool, DevEUI, eui, 3, time.Second, time.Minute)
+				So(err, ShouldBeNil)
+				So(allowed, ShouldBeTrue)
+			}
+
+			allowed, err := Allow(common.RedisPool, DevEUI, eui, 3, time.Second, time.Minute)
+			So(err, ShouldBeNil)
+			So(allowed, ShouldBeFalse)
+
+			Convey("Then the limit is scoped", func() {
+				allowed, err := Allow(common.RedisPool, JoinEUI, eui, 3, time.Second, time.Minute)
+				So(err, ShouldBeNil)
+				So(allowed, ShouldBeTrue)
+			})
+
+			Convey("Then InBackoff returns true", func() {
+				backoff, err := InBackoff(common.RedisPool, DevEUI, eui)
+				So(err, ShouldBeNil)
+				So(backoff, ShouldBeTrue)
+
+				backoff, err = InBackoff(common.RedisPool, JoinEUI, eui)
+				So(err, ShouldBeNil)
+				So(backoff, ShouldBeFalse)
+			})
+
+			Convey("Then the counter expires after the interval", func() {
+				c := common.RedisPool.Get()
+				defer c.Close()
+
+				ttl, err := redis.Int64(c.Do("PTTL", fmt.Sprintf(counterKeyTempl, JoinEUI, eui)))
+				So(err, ShouldBeNil)
+				So(ttl, ShouldEqual, -2)
+
+				_, err = Allow(common.RedisPool, JoinEUI, eui, 3, time.Second, time.Minute)
+				So(err, ShouldBeNil)
+				ttl, err = redis.Int64(c.Do("PTTL", fmt.Sprintf(counterKeyTempl, JoinEUI, eui)))
+				So(err, ShouldBeNil)
+				So(ttl, ShouldBeBetweenOrEqual, 1, 1000)
+			})
+
+			Convey("Then Allow returns false during the back-off", func() {
+				time.Sleep(500 * time.Millisecond)
+				allowed, err := Allow(common.RedisPool, DevEUI, eui, 3, time.Second, time.Minute)
+				So(err, ShouldBeNil)
+				So(allowed, ShouldBeFalse)
+
+				Convey("Then Allow returns true after the back-off", func() {
+					time.Sleep(600 * time.Millisecond)
+					allowed, err := Allow(common.RedisPool, DevEUI, eui, 3, time.Second, time.Minute)
+					So(err, ShouldBeNil)
+					So(allowed, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("Then AllowAttempt returns true until the limit is exceeded", func() {
+			for i := 0; i < 3; i++ {
+				allowed, err := AllowAttempt(common.RedisPool, DevEUIAttempt, eui, 3, time.Second)
+				So(err, ShouldBeNil)
+				So(allowed, ShouldBeTrue)
+			}
+
+			allowed, err := AllowAttempt(common.RedisPool, DevEUIAttempt, eui, 3, time.Second)
+			So(err, ShouldBeNil)
+			So(allowed, ShouldBeFalse)
+
+			Convey("Then no back-off is set", func() {
+				backoff, err := InBackoff(common.RedisPool, DevEUIAttempt, eui)
+				So(err, ShouldBeNil)
+				So(backoff, ShouldBeFalse)
+			})
+
+			Convey("Then AllowAttempt returns true after the interval", func() {
+				time.Sleep(1100 * time.Millisecond)
+				allowed, err := AllowAttempt(common.RedisPool, DevEUIAttempt, eui, 3, time.Second)
+				So(err, ShouldBeNil)
+				So(allowed, ShouldBeTrue)
+			})
+		})
+
+		Convey("Then IsUnknownDevEUI returns false", func() {
+			unknown, err := IsUnknownDevEUI(common.RedisPool, eui)
+			So(err, ShouldBeNil)
+			So(unknown, ShouldBeFalse)
+		})
+
+		Convey("When caching the DevEUI as unknown", func() {
+			So(SetUnknownDevEUI(common.RedisPool, eui, time.Minute), ShouldBeNil)
+
+			Convey("Then IsUnknownDevEUI returns true", func() {
+				unknown, err := IsUnknownDevEUI(common.RedisPool, eui)
+				So(err, ShouldBeNil)
+				So(unknown, ShouldBeTrue)
+			})
+
+			Convey("Then ClearUnknownDevEUI removes it from the cache", func() {
+				So(ClearUnknownDevEUI(common.RedisPool, eui), ShouldBeNil)
+
+				unknown, err := IsUnknownDevEUI(common.RedisPool, eui)
+				So(err, ShouldBeNil)
+				So(unknown, ShouldBeFalse)
+			})
+		})
+
+		Convey("When incrementing the rejected counters", func() {
+			So(IncrementRejected(common.RedisPool, UnknownDevEUI), ShouldBeNil)
+			So(IncrementRejected(common.RedisPool, UnknownDevEUI), ShouldBeNil)
+			So(IncrementRejected(common.RedisPool, DevEUIRateLimited), ShouldBeNil)
+
+			Convey("Then GetRejectedCounts returns the expected counts", func() {
+				counts, err := GetRejectedCounts(common.RedisPool)
+				So(err, ShouldBeNil)
+				So(counts, ShouldResemble, map[Reason]int64{
+					UnknownDevEUI:     2,
+					DevEUIRateLimited: 1,
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,320 @@
+// Package joinserver implements an embedded (LoRaWAN 1.0.x) join-server.
+// It handles the join-requests in-process using the root-keys stored in
+// the database and hands the AppSKey to the application-server on its
+// request (AppSKeyReq).
+package joinserver
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/jacobsa/crypto/cmac"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// session-key derivation types
+const (
+	nwkSKeyType byte = 0x01
+	appSKeyType byte = 0x02
+)
+
+// AppSKeyAnsPayload overrides the AppSKey field of the
+// backend.AppSKeyAnsPayload, so that it is able to hold the wrapped AppSKey.
+type AppSKeyAnsPayload struct {
+	backend.AppSKeyAnsPayload
+	AppSKey *kek.KeyEnvelope `json:"AppSKey,omitempty"`
+}
+
+type client struct{}
+
+// NewClient returns a join-server client handling the join-requests by the
+// embedded join-server.
+func NewClient() jsclient.Client {
+	return &client{}
+}
+
+// SupportsCFListType implements jsclient.CFListTypeSupporter.
+func (c *client) SupportsCFListType() bool {
+	return true
+}
+
+// JoinReq handles the given join-request.
+func (c *client) JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+	ans := backend.JoinAnsPayload{
+		BasePayload: backend.BasePayload{
+			ProtocolVersion: backend.ProtocolVersion1_0,
+			SenderID:        pl.ReceiverID,
+			ReceiverID:      pl.SenderID,
+			TransactionID:   pl.TransactionID,
+			MessageType:     backend.JoinAns,
+		},
+	}
+
+	if err := handleJoinReq(pl, &ans); err != nil {
+		if resErr, ok := errors.Cause(err).(*jsclient.ResultError); ok {
+			ans.Result = backend.Result{
+				ResultCode:  resErr.ResultCode,
+				Description: resErr.Description,
+			}
+		}
+		return ans, err
+	}
+	ans.Result = backend.Result{ResultCode: backend.Success}
+
+	log.WithFields(log.Fields{
+		"dev_eui":  pl.DevEUI,
+		"dev_addr": pl.DevAddr,
+	}).Info("join-server: join-request accepted")
+
+	return ans, nil
+}
+
+func handleJoinReq(pl backend.JoinReqPayload, ans *backend.JoinAnsPayload) error {
+	if !isLoRaWAN10(pl.MACVersion) {
+		return &jsclient.ResultError{
+			ResultCode:  backend.JoinReqFailed,
+			Description: fmt.Sprintf("unsupported mac version: %s", pl.MACVersion),
+		}
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(pl.PHYPayload); err != nil {
+		return &jsclient.ResultError{
+			ResultCode:  backend.MalformedRequest,
+			Description: err.Error(),
+		}
+	}
+	jrPL, ok := phy.MACPayload.(*lorawan.JoinRequestPayload)
+	if !ok {
+		return &jsclient.ResultError{
+			ResultCode:  backend.MalformedRequest,
+			Description: "join-request payload expected",
+		}
+	}
+
+	var netID lorawan.NetID
+	if err := netID.UnmarshalText([]byte(pl.SenderID)); err != nil {
+		return &jsclient.ResultError{
+			ResultCode:  backend.UnknownSender,
+			Description: "SenderID must be a NetID",
+		}
+	}
+
+	dk, err := storage.GetDeviceKeys(common.DB, pl.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return &jsclient.ResultError{
+				ResultCode:  backend.UnknownDevEUI,
+				Description: "device-keys do not exist",
+			}
+		}
+		return errors.Wrap(err, "get device-keys error")
+	}
+
+	// with LoRaWAN 1.0.x, the NwkKey is used as AppKey
+	ok, err = phy.ValidateMIC(dk.NwkKey)
+	if err != nil {
+		return errors.Wrap(err, "validate mic error")
+	}
+	if !ok {
+		return &jsclient.ResultError{
+			ResultCode:  backend.MICFailed,
+			Description: "invalid mic",
+		}
+	}
+
+	joinNonce, err := storage.IncrementJoinNonce(common.DB, pl.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "increment join-nonce error")
+	}
+
+	jaPL := lorawan.JoinAcceptPayload{
+		AppNonce:   lorawan.AppNonce{byte(joinNonce >> 16), byte(joinNonce >> 8), byte(joinNonce)},
+		NetID:      netID,
+		DevAddr:    pl.DevAddr,
+		DLSettings: pl.DLSettings,
+		RXDelay:    uint8(pl.RxDelay),
+		CFList:     pl.CFList,
+	}
+
+	jaBytes, err := jaPL.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal join-accept payload error")
+	}
+	// the last byte of the CFList holds the CFListType
+	if pl.CFList != nil && pl.CFListType != nil {
+		jaBytes[len(jaBytes)-1] = byte(*pl.CFListType)
+	}
+
+	jrBytes, err := jrPL.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal join-request payload error")
+	}
+
+	ans.PHYPayload, err = encryptJoinAccept(dk.NwkKey, jaBytes)
+	if err != nil {
+		return errors.Wrap(err, "encrypt join-accept error")
+	}
+
+	nwkSKey, err := getSessionKey(dk.NwkKey, nwkSKeyType, jaBytes, jrBytes)
+	if err != nil {
+		return errors.Wrap(err, "get nwk_s_key error")
+	}
+	appSKey, err := getSessionKey(dk.NwkKey, appSKeyType, jaBytes, jrBytes)
+	if err != nil {
+		return errors.Wrap(err, "get app_s_key error")
+	}
+
+	sessionKeyID := make([]byte, 16)
+	if _, err := rand.Read(sessionKeyID); err != nil {
+		return errors.Wrap(err, "read random bytes error")
+	}
+
+	// the AppSKey is stored so that it can be requested by the
+	// application-server
+	if err := storage.SetDeviceKeysAppSKey(common.DB, pl.DevEUI, sessionKeyID, appSKey); err != nil {
+		return errors.Wrap(err, "set app_s_key error")
+	}
+
+	ans.NwkSKey = &backend.KeyEnvelope{AESKey: nwkSKey}
+	ans.SessionKeyID = backend.HEXBytes(sessionKeyID)
+
+	return nil
+}
+
+// HandleAppSKeyReq handles the given AppSKeyReq, sent by the
+// application-server. The AppSKey is wrapped using the KEK matching
+// common.JoinServerASKEKLabel, the request is refused when this label is
+// not configured or when the SessionKeyID does not match the latest
+// activation of the device.
+func HandleAppSKeyReq(pl backend.AppSKeyReqPayload) (AppSKeyAnsPayload, error) {
+	ans := AppSKeyAnsPayload{
+		AppSKeyAnsPayload: backend.AppSKeyAnsPayload{
+			BasePayload: backend.BasePayload{
+				ProtocolVersion: backend.ProtocolVersion1_0,
+				SenderID:        pl.ReceiverID,
+				ReceiverID:      pl.SenderID,
+				TransactionID:   pl.TransactionID,
+				MessageType:     backend.AppSKeyAns,
+			},
+			DevEUI:       pl.DevEUI,
+			SessionKeyID: pl.SessionKeyID,
+		},
+	}
+
+	// the AppSKey must never be sent in plain-text
+	if common.JoinServerASKEKLabel == "" {
+		ans.Result = backend.Result{
+			ResultCode:  backend.Other,
+			Description: "AppSKey wrapping is not configured",
+		}
+		return ans, nil
+	}
+
+	sessionKeyID, appSKey, err := storage.GetDeviceKeysAppSKey(common.DB, pl.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			ans.Result = backend.Result{
+				ResultCode:  backend.UnknownDevEUI,
+				Description: "device-keys or session-key do not exist",
+			}
+			return ans, nil
+		}
+		return ans, errors.Wrap(err, "get app_s_key error")
+	}
+
+	if len(pl.SessionKeyID) == 0 || string(pl.SessionKeyID) != string(sessionKeyID) {
+		ans.Result = backend.Result{
+			ResultCode:  backend.Other,
+			Description: "unknown SessionKeyID",
+		}
+		return ans, nil
+	}
+
+	ans.AppSKey, err = common.KEKStore.WrapAES128Key(common.JoinServerASKEKLabel, appSKey)
+	if err != nil {
+		return ans, errors.Wrap(err, "wrap app_s_key error")
+	}
+	ans.SessionKeyID = backend.HEXBytes(sessionKeyID)
+	ans.Result = backend.Result{ResultCode: backend.Success}
+
+	return ans, nil
+}
+
+// encryptJoinAccept returns the MHDR, encrypted join-accept payload and MIC
+// (the PHYPayload) for the given join-accept payload bytes.
+func encryptJoinAccept(key lorawan.AES128Key, jaBytes []byte) ([]byte, error) {
+	mhdr := lorawan.MHDR{
+		MType: lorawan.JoinAccept,
+		Major: lorawan.LoRaWANR1,
+	}
+	mhdrBytes, err := mhdr.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal mhdr error")
+	}
+
+	hash, err := cmac.New(key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new cmac error")
+	}
+	if _, err = hash.Write(append(mhdrBytes, jaBytes...)); err != nil {
+		return nil, errors.Wrap(err, "write cmac error")
+	}
+	mic := hash.Sum(nil)[0:4]
+
+	pt := append(append([]byte{}, jaBytes...), mic...)
+	if len(pt)%16 != 0 {
+		return nil, errors.New("plaintext must be a multiple of 16 bytes")
+	}
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "new cipher error")
+	}
+
+	// the join-accept is encrypted using the aes decrypt operation, so that
+	// the device only needs to implement the aes encrypt operation
+	ct := make([]byte, len(pt))
+	for i := 0; i < len(ct)/16; i++ {
+		offset := i * 16
+		block.Decrypt(ct[offset:offset+16], pt[offset:offset+16])
+	}
+
+	return append(mhdrBytes, ct...), nil
+}
+
+// getSessionKey derives the LoRaWAN 1.0.x session-key of the given type:
+// aes128_encrypt(key, type | AppNonce | NetID | DevNonce | pad16).
+func getSessionKey(key lorawan.AES128Key, typ byte, jaBytes, jrBytes []byte) (lorawan.AES128Key, error) {
+	var sKey lorawan.AES128Key
+
+	b := make([]byte, 16)
+	b[0] = typ
+	copy(b[1:7], jaBytes[0:6])   // AppNonce + NetID
+	copy(b[7:9], jrBytes[16:18]) // DevNonce
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return sKey, errors.Wrap(err, "new cipher error")
+	}
+	block.Encrypt(sKey[:], b)
+
+	return sKey, nil
+}
+
+// isLoRaWAN10 returns true when the given MAC version is a LoRaWAN 1.0.x
+// version (an empty version is considered LoRaWAN 1.0).
+func isLoRaWAN10(macVersion string) bool {
+	return macVersion == "" || macVersion == "1.0" || strings.HasPrefix(macVersion, "1.0.")
+}
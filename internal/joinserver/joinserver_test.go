@@ -0,0 +1,249 @@
+package joinserver
+
+import (
+	"crypto/aes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestJoinServer(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	common.KEKStore = kek.NewStore()
+	if err := common.KEKStore.Add("js", []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}); err != nil {
+		t.Fatal(err)
+	}
+	if err := common.KEKStore.Add("as", []byte{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}); err != nil {
+		t.Fatal(err)
+	}
+	common.JoinServerKEKLabel = "js"
+	common.JoinServerASKEKLabel = "as"
+	defer func() {
+		common.KEKStore = nil
+		common.JoinServerKEKLabel = ""
+		common.JoinServerASKEKLabel = ""
+	}()
+
+	Convey("Given a clean database with a device and its root-keys", t, func() {
+		test.MustResetDB(common.DB)
+
+		sp := storage.ServiceProfile{}
+		So(storage.CreateServiceProfile(db, &sp), ShouldBeNil)
+		dp := storage.DeviceProfile{}
+		So(storage.CreateDeviceProfile(db, &dp), ShouldBeNil)
+		rp := storage.RoutingProfile{}
+		So(storage.CreateRoutingProfile(db, &rp), ShouldBeNil)
+
+		d := storage.Device{
+			DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+			RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+		}
+		So(storage.CreateDevice(db, &d), ShouldBeNil)
+
+		dk := storage.DeviceKeys{
+			DevEUI: d.DevEUI,
+			NwkKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			AppKey: lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+		}
+		So(storage.CreateDeviceKeys(db, &dk), ShouldBeNil)
+
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinRequest,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinRequestPayload{
+				AppEUI:   lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+				DevEUI:   d.DevEUI,
+				DevNonce: lorawan.DevNonce{1, 2},
+			},
+		}
+		So(phy.SetMIC(dk.NwkKey), ShouldBeNil)
+		phyB, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		pl := backend.JoinReqPayload{
+			BasePayload: backend.BasePayload{
+				SenderID:      "010203",
+				ReceiverID:    "0807060504030201",
+				TransactionID: 1234,
+				MessageType:   backend.JoinReq,
+			},
+			MACVersion: "1.0.2",
+			PHYPayload: backend.HEXBytes(phyB),
+			DevEUI:     d.DevEUI,
+			DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+			DLSettings: lorawan.DLSettings{RX2DataRate: 3, RX1DROffset: 1},
+			RxDelay:    1,
+			CFList:     &lorawan.CFList{100, 200, 300},
+		}
+
+		Convey("When calling JoinReq", func() {
+			ans, err := NewClient().JoinReq(context.Background(), pl)
+			So(err, ShouldBeNil)
+
+			Convey("Then the answer is as expected", func() {
+				So(ans.MessageType, ShouldEqual, backend.JoinAns)
+				So(ans.TransactionID, ShouldEqual, 1234)
+				So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				So(ans.SessionKeyID, ShouldHaveLength, 16)
+				So(ans.AppSKey, ShouldBeNil)
+			})
+
+			Convey("Then the join-accept can be decrypted and validated using the NwkKey", func() {
+				var jaPHY lorawan.PHYPayload
+				So(jaPHY.UnmarshalBinary(ans.PHYPayload), ShouldBeNil)
+				So(jaPHY.DecryptJoinAcceptPayload(dk.NwkKey), ShouldBeNil)
+
+				ok, err := jaPHY.ValidateMIC(dk.NwkKey)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				jaPL, ok := jaPHY.MACPayload.(*lorawan.JoinAcceptPayload)
+				So(ok, ShouldBeTrue)
+				So(jaPL.AppNonce, ShouldEqual, lorawan.AppNonce{0, 0, 1})
+				So(jaPL.NetID, ShouldEqual, lorawan.NetID{1, 2, 3})
+				So(jaPL.DevAddr, ShouldEqual, pl.DevAddr)
+				So(jaPL.DLSettings, ShouldResemble, pl.DLSettings)
+				So(jaPL.RXDelay, ShouldEqual, 1)
+				So(jaPL.CFList, ShouldResemble, pl.CFList)
+			})
+
+			Convey("Then the NwkSKey has been derived from the NwkKey", func() {
+				block, err := aes.NewCipher(dk.NwkKey[:])
+				So(err, ShouldBeNil)
+
+				var nwkSKey lorawan.AES128Key
+				block.Encrypt(nwkSKey[:], []byte{0x01, 0x01, 0x00, 0x00, 0x03, 0x02, 0x01, 0x02, 0x01, 0, 0, 0, 0, 0, 0, 0})
+				So(ans.NwkSKey, ShouldResemble, &backend.KeyEnvelope{AESKey: nwkSKey})
+			})
+
+			Convey("Then the AppSKey can be requested using the SessionKeyID", func() {
+				skAns, err := HandleAppSKeyReq(backend.AppSKeyReqPayload{
+					BasePayload: backend.BasePayload{
+						TransactionID: 4321,
+						MessageType:   backend.AppSKeyReq,
+					},
+					DevEUI:       d.DevEUI,
+					SessionKeyID: ans.SessionKeyID,
+				})
+				So(err, ShouldBeNil)
+				So(skAns.Result.ResultCode, ShouldEqual, backend.Success)
+				So(skAns.MessageType, ShouldEqual, backend.AppSKeyAns)
+				So(skAns.TransactionID, ShouldEqual, 4321)
+				So(skAns.AppSKey.KEKLabel, ShouldEqual, "as")
+
+				block, err := aes.NewCipher(dk.NwkKey[:])
+				So(err, ShouldBeNil)
+
+				var appSKey lorawan.AES128Key
+				block.Encrypt(appSKey[:], []byte{0x02, 0x01, 0x00, 0x00, 0x03, 0x02, 0x01, 0x02, 0x01, 0, 0, 0, 0, 0, 0, 0})
+
+				key, err := common.KEKStore.UnwrapKeyEnvelope(*skAns.AppSKey)
+				So(err, ShouldBeNil)
+				So(key, ShouldEqual, appSKey)
+			})
+
+			Convey("Then an AppSKeyReq with an unknown SessionKeyID is rejected", func() {
+				skAns, err := HandleAppSKeyReq(backend.AppSKeyReqPayload{
+					DevEUI:       d.DevEUI,
+					SessionKeyID: backend.HEXBytes{1, 2, 3},
+				})
+				So(err, ShouldBeNil)
+				So(skAns.Result.ResultCode, ShouldEqual, backend.Other)
+				So(skAns.AppSKey, ShouldBeNil)
+			})
+
+			Convey("Then an AppSKeyReq without SessionKeyID is rejected", func() {
+				skAns, err := HandleAppSKeyReq(backend.AppSKeyReqPayload{
+					DevEUI: d.DevEUI,
+				})
+				So(err, ShouldBeNil)
+				So(skAns.Result.ResultCode, ShouldEqual, backend.Other)
+				So(skAns.AppSKey, ShouldBeNil)
+			})
+
+			Convey("Given no AS KEK label is configured", func() {
+				common.JoinServerASKEKLabel = ""
+				Reset(func() {
+					common.JoinServerASKEKLabel = "as"
+				})
+
+				Convey("Then the AppSKeyReq is rejected", func() {
+					skAns, err := HandleAppSKeyReq(backend.AppSKeyReqPayload{
+						DevEUI:       d.DevEUI,
+						SessionKeyID: ans.SessionKeyID,
+					})
+					So(err, ShouldBeNil)
+					So(skAns.Result.ResultCode, ShouldEqual, backend.Other)
+					So(skAns.AppSKey, ShouldBeNil)
+				})
+			})
+
+			Convey("Then a second JoinReq increments the AppNonce", func() {
+				ans, err := NewClient().JoinReq(context.Background(), pl)
+				So(err, ShouldBeNil)
+
+				var jaPHY lorawan.PHYPayload
+				So(jaPHY.UnmarshalBinary(ans.PHYPayload), ShouldBeNil)
+				So(jaPHY.DecryptJoinAcceptPayload(dk.NwkKey), ShouldBeNil)
+				jaPL, ok := jaPHY.MACPayload.(*lorawan.JoinAcceptPayload)
+				So(ok, ShouldBeTrue)
+				So(jaPL.AppNonce, ShouldEqual, lorawan.AppNonce{0, 0, 2})
+			})
+		})
+
+		Convey("When calling JoinReq with an invalid MIC", func() {
+			phy.MIC = lorawan.MIC{1, 2, 3, 4}
+			phyB, err := phy.MarshalBinary()
+			So(err, ShouldBeNil)
+			pl.PHYPayload = backend.HEXBytes(phyB)
+
+			ans, err := NewClient().JoinReq(context.Background(), pl)
+
+			Convey("Then a MICFailed result is returned", func() {
+				So(err, ShouldResemble, &jsclient.ResultError{ResultCode: backend.MICFailed, Description: "invalid mic"})
+				So(ans.Result.ResultCode, ShouldEqual, backend.MICFailed)
+			})
+		})
+
+		Convey("When calling JoinReq for an unknown DevEUI", func() {
+			pl.DevEUI = lorawan.EUI64{8, 8, 8, 8, 8, 8, 8, 8}
+			_, err := NewClient().JoinReq(context.Background(), pl)
+
+			Convey("Then an UnknownDevEUI result is returned", func() {
+				resErr, ok := err.(*jsclient.ResultError)
+				So(ok, ShouldBeTrue)
+				So(resErr.ResultCode, ShouldEqual, backend.UnknownDevEUI)
+			})
+		})
+
+		Convey("When calling JoinReq for a LoRaWAN 1.1 device", func() {
+			pl.MACVersion = "1.1.0"
+			_, err := NewClient().JoinReq(context.Background(), pl)
+
+			Convey("Then a JoinReqFailed result is returned", func() {
+				resErr, ok := err.(*jsclient.ResultError)
+				So(ok, ShouldBeTrue)
+				So(resErr.ResultCode, ShouldEqual, backend.JoinReqFailed)
+			})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package jsclient
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// Templates used for generating Redis keys
+const (
+	pendingAnswerKeyTempl = "lora:ns:js:answer:%d:pending"
+	answerKeyTempl        = "lora:ns:js:answer:%d"
+)
+
+// ErrUnknownTransaction is returned when an answer is published for a
+// TransactionID for which there is no pending request (or it expired).
+var ErrUnknownTransaction = errors.New("unknown or expired transaction")
+
+// ErrInvalidSender is returned when an answer is published by an other
+// join-server than the one to which the request was sent.
+var ErrInvalidSender = errors.New("answer sender does not match the request receiver")
+
+// AnswerStore correlates the asynchronous answers of the join-server with
+// the pending requests by their TransactionID. As the answers are stored in
+// Redis, the answer might be received by a different network-server
+// instance than the one waiting for it.
+type AnswerStore struct {
+	pool *redis.Pool
+}
+
+// NewAnswerStore creates a new AnswerStore.
+func NewAnswerStore(p *redis.Pool) *AnswerStore {
+	return &AnswerStore{
+		pool: p,
+	}
+}
+
+// SavePending registers a pending request for the given TransactionID,
+// sent to the join-server with the given ID (the ReceiverID of the
+// request). Answers for this TransactionID are accepted from this
+// join-server until the ttl expires.
+func (s *AnswerStore) SavePending(transactionID uint32, receiverID string, ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return ErrTimeout
+	}
+
+	c := s.pool.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(pendingAnswerKeyTempl, transactionID), int64(ttl/time.Millisecond), strings.ToLower(receiverID))
+	if err != nil {
+		return errors.Wrap(err, "set pending answer key error")
+	}
+
+	return nil
+}
+
+// publishScript atomically checks that the request is pending and publishes
+// the answer (KEYS[1] = pending key, KEYS[2] = answer key, ARGV[1] = answer,
+// ARGV[2] = sender id). The answer expires together with the pending
+// request. It returns 0 when there is no pending request and -1 when the
+// sender does not match the receiver of the request.
+var publishScript = redis.NewScript(2, `
+	local receiver = redis.call("GET", KEYS[1])
+	if not receiver then
+		return 0
+	end
+	if receiver ~= ARGV[2] then
+		return -1
+	end
+
+	local ttl = redis.call("PTTL", KEYS[1])
+	if ttl <= 0 then
+		ttl = 1000
+	end
+
+	redis.call("DEL", KEYS[1])
+	redis.call("RPUSH", KEYS[2], ARGV[1])
+	redis.call("PEXPIRE", KEYS[2], ttl)
+	return 1
+`)
+
+// pollInterval defines the interval for polling the answer when the time
+// left until the deadline is less than the (one second) BLPOP resolution.
+const pollInterval = 10 * time.Millisecond
+
+// Publish publishes the given (JSON encoded) answer for the given
+// TransactionID, sent by the join-server with the given ID (the SenderID of
+// the answer). ErrUnknownTransaction is returned when there is no pending
+// request for this TransactionID, ErrInvalidSender when the request was
+// sent to an other join-server.
+func (s *AnswerStore) Publish(transactionID uint32, senderID string, b []byte) error {
+	c := s.pool.Get()
+	defer c.Close()
+
+	res, err := redis.Int(publishScript.Do(c, fmt.Sprintf(pendingAnswerKeyTempl, transactionID), fmt.Sprintf(answerKeyTempl, transactionID), b, strings.ToLower(senderID)))
+	if err != nil {
+		return errors.Wrap(err, "publish answer error")
+	}
+
+	switch res {
+	case 0:
+		return ErrUnknownTransaction
+	case -1:
+		return ErrInvalidSender
+	}
+
+	return nil
+}
+
+// Get blocks until the answer for the given TransactionID has been
+// published or until the deadline of the given context has passed, in which
+// case ErrTimeout is returned.
+func (s *AnswerStore) Get(ctx context.Context, transactionID uint32) ([]byte, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultRequestTimeout)
+	}
+
+	c := s.pool.Get()
+	defer c.Close()
+
+	key := fmt.Sprintf(answerKeyTempl, transactionID)
+
+	for {
+		timeLeft := deadline.Sub(time.Now())
+		if timeLeft <= 0 {
+			return nil, ErrTimeout
+		}
+
+		// BLPOP only supports a timeout in (whole) seconds, therefore the
+		// last (sub-second) part is polled so that the deadline is never
+		// exceeded
+		if timeLeft >= time.Second {
+			values, err := redis.ByteSlices(c.Do("BLPOP", key, int64(timeLeft/time.Second)))
+			if err != nil {
+				if err == redis.ErrNil {
+					continue
+				}
+				return nil, errors.Wrap(err, "get answer error")
+			}
+
+			// BLPOP returns the key and the value
+			if len(values) != 2 {
+				return nil, fmt.Errorf("expected 2 values, got: %d", len(values))
+			}
+			return values[1], nil
+		}
+
+		b, err := redis.Bytes(c.Do("LPOP", key))
+		if err == nil {
+			return b, nil
+		}
+		if err != redis.ErrNil {
+			return nil, errors.Wrap(err, "get answer error")
+		}
+
+		wait := pollInterval
+		if timeLeft < wait {
+			wait = timeLeft
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ErrTimeout
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package jsclient
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker implements a consecutive-failures circuit-breaker. After
+// threshold consecutive failures, the breaker opens and rejects all requests
+// until the cooldown has passed. Then a single request is let through
+// (half-open), its outcome either closes or re-opens the breaker.
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow returns true when a request is allowed.
+func (b *circuitBreaker) allow() bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+
+	now := time.Now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+
+	// half-open: let this request through and reject the others until we
+	// know its outcome
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+// record records the outcome of a request.
+func (b *circuitBreaker) record(failed bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,72 @@
+package jsclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan/backend"
+)
+
+// join-server client errors
+var (
+	ErrTimeout     = errors.New("join-server request timeout")
+	ErrCircuitOpen = errors.New("join-server circuit-breaker is open")
+)
+
+// StatusError is returned when the join-server responds with an unexpected
+// HTTP status code.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected http status: %d (%s), body: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// ResultError is returned when the join-server responds with a ResultCode
+// other than Success.
+type ResultError struct {
+	ResultCode  backend.ResultCode
+	Description string
+}
+
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("response error, code: %s, description: %s", e.ResultCode, e.Description)
+}
+
+// IsRetryable returns true when the given error is a connection failure
+// which occurred before the request was sent to the join-server, in which
+// case the request might succeed when it is retried. As the join-server
+// might already have used the DevNonce of the join-request, a request is
+// never retried once it has been sent (e.g. on a timeout or HTTP 5xx).
+func IsRetryable(err error) bool {
+	e := errors.Cause(err)
+	if urlErr, ok := e.(*url.Error); ok {
+		e = urlErr.Err
+	}
+
+	opErr, ok := e.(*net.OpError)
+	return ok && opErr.Op == "dial"
+}
+
+// isFailure returns true when the given error is a (temporary) failure of
+// the join-server or the connection to it (see circuitBreaker).
+func isFailure(err error) bool {
+	if errors.Cause(err) == ErrTimeout {
+		return true
+	}
+
+	switch e := errors.Cause(err).(type) {
+	case *StatusError:
+		return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+	case net.Error:
+		return true
+	default:
+		return false
+	}
+}
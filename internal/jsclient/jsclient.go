@@ -5,28 +5,95 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
-	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
 )
 
+const (
+	// defaultRequestTimeout is used when the context passed to the client
+	// does not have a deadline.
+	defaultRequestTimeout = 5 * time.Second
+
+	// maxRetries defines the max. number of retries for requests that could
+	// not be sent (see IsRetryable).
+	maxRetries = 2
+
+	// retryInterval defines the time to wait before retrying a request.
+	retryInterval = 100 * time.Millisecond
+
+	// breakerThreshold defines the number of consecutive failures after which
+	// the circuit-breaker of a join-server opens.
+	breakerThreshold = 5
+
+	// breakerCooldown defines the time the circuit-breaker stays open.
+	breakerCooldown = 30 * time.Second
+)
+
 // Client defines the join-server client interface.
 type Client interface {
-	JoinReq(pl backend.JoinReqPayload) (backend.JoinAnsPayload, error)
+	// JoinReq issues a join-request. The request (including retries) must
+	// complete before the deadline of the given context. Network session-keys
+	// wrapped by the join-server are returned unwrapped (with an empty
+	// KEKLabel). The AppSKey is never unwrapped (see decodeJoinAns).
+	JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error)
+}
+
+// CFListTypeSupporter is implemented by the clients of join-servers that
+// handle the CFListType field of the join-request.
+type CFListTypeSupporter interface {
+	SupportsCFListType() bool
+}
+
+// SupportsCFListType returns true when the join-server of the given client
+// handles the CFListType field of the join-request. An external join-server
+// is expected to encode the CFList as a list of frequencies (CFListType 0).
+func SupportsCFListType(c Client) bool {
+	s, ok := c.(CFListTypeSupporter)
+	return ok && s.SupportsCFListType()
+}
+
+// joinAnsPayload overrides the key envelope fields of the
+// backend.JoinAnsPayload, so that it is able to hold wrapped keys.
+type joinAnsPayload struct {
+	backend.JoinAnsPayload
+	SNwkSIntKey *kek.KeyEnvelope `json:"SNwkSIntKey,omitempty"`
+	FNwkSIntKey *kek.KeyEnvelope `json:"FNwkSIntKey,omitempty"`
+	NwkSEncKey  *kek.KeyEnvelope `json:"NwkSEncKey,omitempty"`
+	NwkSKey     *kek.KeyEnvelope `json:"NwkSKey,omitempty"`
+	AppSKey     *kek.KeyEnvelope `json:"AppSKey,omitempty"`
+}
+
+// ClientOptions holds the options shared by the join-server clients.
+type ClientOptions struct {
+	// KEKStore is used for unwrapping the session-keys (optional, only
+	// needed when the join-server wraps the session-keys).
+	KEKStore *kek.Store
+
+	// AnswerStore is used for receiving asynchronous answers (optional,
+	// only needed when the join-server answers asynchronously).
+	AnswerStore *AnswerStore
 }
 
 type client struct {
-	server     string
-	httpClient *http.Client
+	server      string
+	httpClient  *http.Client
+	kekStore    *kek.Store
+	answerStore *AnswerStore
+	breaker     *circuitBreaker
 }
 
 // JoinReq issues a join-request.
-func (c *client) JoinReq(pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+func (c *client) JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
 	var ans backend.JoinAnsPayload
 
 	b, err := json.Marshal(pl)
@@ -34,25 +101,159 @@ func (c *client) JoinReq(pl backend.JoinReqPayload) (backend.JoinAnsPayload, err
 		return ans, errors.Wrap(err, "marshal request error")
 	}
 
-	resp, err := c.httpClient.Post(c.server, "application/json", bytes.NewReader(b))
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	for retry := 0; ; retry++ {
+		if !c.breaker.allow() {
+			return ans, ErrCircuitOpen
+		}
+
+		ans, err = c.joinReq(ctx, pl, b)
+		c.breaker.record(isFailure(err))
+
+		if err == nil || retry >= maxRetries || !IsRetryable(err) {
+			return ans, err
+		}
+
+		log.WithFields(log.Fields{
+			"server":         c.server,
+			"dev_eui":        pl.DevEUI,
+			"transaction_id": pl.TransactionID,
+			"retry":          retry + 1,
+		}).Warningf("join-request to join-server failed, retrying: %s", err)
+
+		select {
+		case <-ctx.Done():
+			return ans, ErrTimeout
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func (c *client) joinReq(ctx context.Context, pl backend.JoinReqPayload, b []byte) (backend.JoinAnsPayload, error) {
+	var ans backend.JoinAnsPayload
+
+	// the pending request must be registered before sending the request, as
+	// the answer might be received before the request has completed
+	if c.answerStore != nil {
+		deadline, _ := ctx.Deadline()
+		if err := c.answerStore.SavePending(pl.TransactionID, pl.ReceiverID, deadline.Sub(time.Now())); err != nil {
+			return ans, errors.Wrap(err, "save pending answer error")
+		}
+	}
+
+	req, err := http.NewRequest("POST", c.server, bytes.NewReader(b))
+	if err != nil {
+		return ans, errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ans, ErrTimeout
+		}
 		return ans, errors.Wrap(err, "http post error")
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
+		return ans, &StatusError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+		}
+	}
 
-	err = json.NewDecoder(resp.Body).Decode(&ans)
+	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return ans, ErrTimeout
+		}
+		return ans, errors.Wrap(err, "read response error")
+	}
+
+	// an empty response means that the join-server will send the answer
+	// asynchronously
+	if len(bytes.TrimSpace(body)) == 0 {
+		if c.answerStore == nil {
+			return ans, errors.New("empty response and asynchronous answers are not enabled")
+		}
+
+		body, err = c.answerStore.Get(ctx, pl.TransactionID)
+		if err != nil {
+			return ans, errors.Wrap(err, "get asynchronous answer error")
+		}
+	}
+
+	return c.decodeJoinAns(body)
+}
+
+// decodeJoinAns decodes the given JoinAns and unwraps the network
+// session-keys.
+func (c *client) decodeJoinAns(b []byte) (backend.JoinAnsPayload, error) {
+	var ans backend.JoinAnsPayload
+	var jaPL joinAnsPayload
+	if err := json.Unmarshal(b, &jaPL); err != nil {
 		return ans, errors.Wrap(err, "unmarshal response error")
 	}
+	ans = jaPL.JoinAnsPayload
 
 	if ans.Result.ResultCode != backend.Success {
-		return ans, fmt.Errorf("response error, code: %s, description: %s", ans.Result.ResultCode, ans.Result.Description)
+		return ans, &ResultError{
+			ResultCode:  ans.Result.ResultCode,
+			Description: ans.Result.Description,
+		}
+	}
+
+	for _, k := range []struct {
+		name string
+		in   *kek.KeyEnvelope
+		out  **backend.KeyEnvelope
+	}{
+		{"SNwkSIntKey", jaPL.SNwkSIntKey, &ans.SNwkSIntKey},
+		{"FNwkSIntKey", jaPL.FNwkSIntKey, &ans.FNwkSIntKey},
+		{"NwkSEncKey", jaPL.NwkSEncKey, &ans.NwkSEncKey},
+		{"NwkSKey", jaPL.NwkSKey, &ans.NwkSKey},
+	} {
+		if k.in == nil {
+			continue
+		}
+
+		key, err := c.kekStore.UnwrapKeyEnvelope(*k.in)
+		if err != nil {
+			return ans, errors.Wrapf(err, "unwrap %s error", k.name)
+		}
+		*k.out = &backend.KeyEnvelope{AESKey: key}
+	}
+
+	// the AppSKey is intended for the application-server and is wrapped
+	// using a KEK unknown to the network-server. As backend.KeyEnvelope
+	// can't hold a wrapped key, only a plain AppSKey is returned. A wrapped
+	// AppSKey is discarded, the application-server must then request it
+	// from the join-server using the SessionKeyID (AppSKeyReq).
+	if jaPL.AppSKey != nil {
+		if jaPL.AppSKey.KEKLabel == "" && len(jaPL.AppSKey.AESKey) == len(lorawan.AES128Key{}) {
+			ans.AppSKey = &backend.KeyEnvelope{}
+			copy(ans.AppSKey.AESKey[:], jaPL.AppSKey.AESKey)
+		} else {
+			log.WithFields(log.Fields{
+				"server":         c.server,
+				"kek_label":      jaPL.AppSKey.KEKLabel,
+				"session_key_id": ans.SessionKeyID,
+			}).Warning("wrapped AppSKey discarded, the application-server must request it using the SessionKeyID")
+		}
 	}
 
 	return ans, nil
 }
 
 // NewClient creates a new join-server client.
-func NewClient(server, caCert, tlsCert, tlsKey string) (Client, error) {
+func NewClient(server, caCert, tlsCert, tlsKey string, opts ClientOptions) (Client, error) {
 	log.WithFields(log.Fields{
 		"server":   server,
 		"ca_cert":  caCert,
@@ -62,8 +263,13 @@ func NewClient(server, caCert, tlsCert, tlsKey string) (Client, error) {
 
 	if caCert == "" && tlsCert == "" && tlsKey == "" {
 		return &client{
-			server:     server,
-			httpClient: http.DefaultClient,
+			server: server,
+			httpClient: &http.Client{
+				Timeout: defaultRequestTimeout,
+			},
+			kekStore:    opts.KEKStore,
+			answerStore: opts.AnswerStore,
+			breaker:     newCircuitBreaker(breakerThreshold, breakerCooldown),
 		}, nil
 	}
 
@@ -93,7 +299,11 @@ func NewClient(server, caCert, tlsCert, tlsKey string) (Client, error) {
 			Transport: &http.Transport{
 				TLSClientConfig: tlsConfig,
 			},
+			Timeout: defaultRequestTimeout,
 		},
-		server: server,
+		server:      server,
+		kekStore:    opts.KEKStore,
+		answerStore: opts.AnswerStore,
+		breaker:     newCircuitBreaker(breakerThreshold, breakerCooldown),
 	}, nil
 }
@@ -0,0 +1,210 @@
+package jsclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+)
+
+func TestClient(t *testing.T) {
+	Convey("Given a KEK store and a test join-server", t, func() {
+		kekStore := kek.NewStore()
+		So(kekStore.Add("lora-ns", []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}), ShouldBeNil)
+
+		var response string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(response))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, "", "", "", ClientOptions{KEKStore: kekStore})
+		So(err, ShouldBeNil)
+
+		Convey("When the join-server returns a wrapped NwkSKey", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "PHYPayload": "0102", "NwkSKey": {"KEKLabel": "lora-ns", "AESKey": "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"}}`
+
+			Convey("Then JoinReq returns the unwrapped NwkSKey", func() {
+				ans, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldBeNil)
+				So(ans.PHYPayload, ShouldResemble, backend.HEXBytes{1, 2})
+				So(ans.NwkSKey, ShouldResemble, &backend.KeyEnvelope{
+					AESKey: lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+				})
+			})
+		})
+
+		Convey("When the join-server returns a plain NwkSKey", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "NwkSKey": {"AESKey": "00112233445566778899aabbccddeeff"}}`
+
+			Convey("Then JoinReq returns the NwkSKey", func() {
+				ans, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldBeNil)
+				So(ans.NwkSKey, ShouldResemble, &backend.KeyEnvelope{
+					AESKey: lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+				})
+			})
+		})
+
+		Convey("When the join-server returns an AppSKey wrapped with an unknown KEK", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "NwkSKey": {"KEKLabel": "lora-ns", "AESKey": "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"}, "AppSKey": {"KEKLabel": "lora-app-server", "AESKey": "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"}}`
+
+			Convey("Then JoinReq returns the unwrapped NwkSKey and does not unwrap the AppSKey", func() {
+				ans, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldBeNil)
+				So(ans.NwkSKey, ShouldResemble, &backend.KeyEnvelope{
+					AESKey: lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+				})
+				So(ans.AppSKey, ShouldBeNil)
+			})
+		})
+
+		Convey("When the join-server returns a plain AppSKey", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "AppSKey": {"AESKey": "00112233445566778899aabbccddeeff"}}`
+
+			Convey("Then JoinReq returns the AppSKey unchanged", func() {
+				ans, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldBeNil)
+				So(ans.AppSKey, ShouldResemble, &backend.KeyEnvelope{
+					AESKey: lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+				})
+			})
+		})
+
+		Convey("When the join-server returns a NwkSKey wrapped with an unknown KEK", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "NwkSKey": {"KEKLabel": "foo", "AESKey": "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"}}`
+
+			Convey("Then JoinReq returns an error", func() {
+				_, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When the join-server returns a ResultCode other than Success", func() {
+			response = `{"Result": {"ResultCode": "UnknownDevEUI", "Description": "unknown device"}}`
+
+			Convey("Then JoinReq returns a ResultError", func() {
+				_, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldResemble, &ResultError{ResultCode: backend.UnknownDevEUI, Description: "unknown device"})
+			})
+		})
+	})
+}
+
+func TestClientErrorHandling(t *testing.T) {
+	Convey("Given a test join-server returning the configured status codes", t, func() {
+		var statusCodes []int
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if len(statusCodes) != 0 {
+				code := statusCodes[0]
+				statusCodes = statusCodes[1:]
+				if code == -1 {
+					time.Sleep(200 * time.Millisecond)
+				} else if code != http.StatusOK {
+					w.WriteHeader(code)
+					return
+				}
+			}
+			w.Write([]byte(`{"Result": {"ResultCode": "Success"}}`))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(server.URL, "", "", "", ClientOptions{})
+		So(err, ShouldBeNil)
+
+		Convey("When the join-server fails once with a 503", func() {
+			statusCodes = []int{http.StatusServiceUnavailable}
+
+			Convey("Then a StatusError is returned without retrying", func() {
+				_, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldHaveSameTypeAs, &StatusError{})
+				So(err.(*StatusError).StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(IsRetryable(err), ShouldBeFalse)
+				So(requests, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the join-server returns a 400", func() {
+			statusCodes = []int{http.StatusBadRequest}
+
+			Convey("Then a StatusError is returned without retrying", func() {
+				_, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldHaveSameTypeAs, &StatusError{})
+				So(err.(*StatusError).StatusCode, ShouldEqual, http.StatusBadRequest)
+				So(IsRetryable(err), ShouldBeFalse)
+				So(requests, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the join-server responds after the deadline", func() {
+			statusCodes = []int{-1}
+
+			Convey("Then ErrTimeout is returned without retrying", func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+
+				_, err := c.JoinReq(ctx, backend.JoinReqPayload{})
+				So(err, ShouldEqual, ErrTimeout)
+				So(requests, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the join-server keeps failing", func() {
+			for i := 0; i < breakerThreshold; i++ {
+				statusCodes = append(statusCodes, http.StatusInternalServerError)
+			}
+
+			Convey("Then the circuit-breaker opens", func() {
+				for i := 0; i < breakerThreshold; i++ {
+					_, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+					So(err, ShouldHaveSameTypeAs, &StatusError{})
+				}
+				So(requests, ShouldEqual, breakerThreshold)
+
+				_, err = c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(err, ShouldEqual, ErrCircuitOpen)
+				So(requests, ShouldEqual, breakerThreshold)
+			})
+		})
+
+		Convey("When the join-server is not reachable", func() {
+			closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			closed.Close()
+
+			c, err := NewClient(closed.URL, "", "", "", ClientOptions{})
+			So(err, ShouldBeNil)
+
+			Convey("Then the request is retried and a retryable error is returned", func() {
+				start := time.Now()
+				_, err := c.JoinReq(context.Background(), backend.JoinReqPayload{})
+				So(IsRetryable(err), ShouldBeTrue)
+				So(time.Since(start), ShouldBeGreaterThanOrEqualTo, maxRetries*retryInterval)
+			})
+		})
+	})
+}
+
+type cfListTypeClient struct {
+	Client
+}
+
+func (c cfListTypeClient) SupportsCFListType() bool {
+	return true
+}
+
+func TestSupportsCFListType(t *testing.T) {
+	Convey("Given a client supporting the CFListType and one that does not", t, func() {
+		Convey("Then SupportsCFListType returns the expected value", func() {
+			So(SupportsCFListType(cfListTypeClient{}), ShouldBeTrue)
+			So(SupportsCFListType(&client{}), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,337 @@
+package jsclient
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DNS constants used for the NAPTR lookup.
+const (
+	dnsTypeNAPTR  = 35
+	dnsClassIN    = 1
+	dnsRcodeNX    = 3
+	dnsTimeout    = 2 * time.Second
+	dnsResolvConf = "/etc/resolv.conf"
+)
+
+// naptrBackrefRegexp matches the back-references (\1 - \9) of a NAPTR
+// regexp replacement.
+var naptrBackrefRegexp = regexp.MustCompile(`\\([0-9])`)
+
+// NAPTR holds a NAPTR record (RFC 3403).
+type NAPTR struct {
+	Order       uint16
+	Preference  uint16
+	Flags       string
+	Service     string
+	Regexp      string
+	Replacement string
+}
+
+// ApplyRegexp applies the regexp of the NAPTR record (e.g.
+// !^.*$!https://js.example.com!) to the given string and returns the
+// resulting http(s) URL.
+func (n NAPTR) ApplyRegexp(s string) (string, error) {
+	if len(n.Regexp) < 3 {
+		return "", errors.New("regexp expected")
+	}
+
+	parts := strings.Split(n.Regexp[1:], n.Regexp[:1])
+	if len(parts) != 3 || (parts[2] != "" && parts[2] != "i") {
+		return "", fmt.Errorf("invalid regexp: %s", n.Regexp)
+	}
+
+	pattern := parts[0]
+	if parts[2] == "i" {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", errors.Wrap(err, "compile regexp error")
+	}
+
+	match := re.FindStringSubmatchIndex(s)
+	if match == nil {
+		return "", errors.New("regexp does not match")
+	}
+	template := naptrBackrefRegexp.ReplaceAllString(parts[1], "$${$1}")
+	out := string(re.ExpandString(nil, template, s, match))
+
+	u, err := url.Parse(out)
+	if err != nil {
+		return "", errors.Wrap(err, "parse url error")
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return "", fmt.Errorf("http(s) url expected, got: %s", out)
+	}
+
+	return out, nil
+}
+
+// LookupNAPTR returns the NAPTR records of the given hostname, using the
+// nameservers configured in /etc/resolv.conf. A (not temporary)
+// *net.DNSError is returned when the hostname does not exist.
+func LookupNAPTR(host string) ([]NAPTR, error) {
+	servers := getNameservers()
+
+	var err error
+	for _, server := range servers {
+		var records []NAPTR
+		records, err = queryNAPTR(server, host)
+		if err == nil {
+			return records, nil
+		}
+		if dnsErr, ok := err.(*net.DNSError); ok && !dnsErr.Temporary() {
+			return nil, err
+		}
+	}
+
+	return nil, err
+}
+
+// getNameservers returns the nameservers (ip:port) configured in
+// /etc/resolv.conf, or the local nameserver when none are configured.
+func getNameservers() []string {
+	var servers []string
+
+	f, err := os.Open(dnsResolvConf)
+	if err == nil {
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) >= 2 && fields[0] == "nameserver" {
+				servers = append(servers, net.JoinHostPort(fields[1], "53"))
+			}
+		}
+	}
+
+	if len(servers) == 0 {
+		servers = []string{"127.0.0.1:53"}
+	}
+
+	return servers
+}
+
+// queryNAPTR queries the given nameserver for the NAPTR records of the given
+// hostname. The query is retried over TCP when the UDP response is
+// truncated.
+func queryNAPTR(server, host string) ([]NAPTR, error) {
+	query, id, err := newNAPTRQuery(host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := exchangeDNS("udp", server, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// truncated
+	if len(resp) > 2 && resp[2]&0x02 != 0 {
+		resp, err = exchangeDNS("tcp", server, query)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parseNAPTRResponse(host, id, resp)
+}
+
+// exchangeDNS sends the given query to the given server and returns the
+// response.
+func exchangeDNS(network, server string, query []byte) ([]byte, error) {
+	conn, err := net.DialTimeout(network, server, dnsTimeout)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Server: server, IsTemporary: true}
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dnsTimeout))
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, &net.DNSError{Err: err.Error(), Server: server, IsTemporary: true}
+		}
+
+		b := make([]byte, 65535)
+		n, err := conn.Read(b)
+		if err != nil {
+			return nil, &net.DNSError{Err: err.Error(), Server: server, IsTimeout: true, IsTemporary: true}
+		}
+		return b[:n], nil
+	}
+
+	// tcp messages are prefixed by their length
+	b := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(b, uint16(len(query)))
+	copy(b[2:], query)
+	if _, err := conn.Write(b); err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Server: server, IsTemporary: true}
+	}
+
+	if _, err := io.ReadFull(conn, b[:2]); err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Server: server, IsTemporary: true}
+	}
+	resp := make([]byte, binary.BigEndian.Uint16(b[:2]))
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Server: server, IsTemporary: true}
+	}
+	return resp, nil
+}
+
+// newNAPTRQuery returns the (recursive) NAPTR query for the given hostname
+// and its message ID.
+func newNAPTRQuery(host string) ([]byte, uint16, error) {
+	var idB [2]byte
+	if _, err := rand.Read(idB[:]); err != nil {
+		return nil, 0, errors.Wrap(err, "read random bytes error")
+	}
+	id := binary.BigEndian.Uint16(idB[:])
+
+	// header: id, flags (recursion desired) and a single question
+	b := []byte{idB[0], idB[1], 0x01, 0x00, 0, 1, 0, 0, 0, 0, 0, 0}
+
+	for _, label := range strings.Split(strings.Trim(host, "."), ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return nil, 0, fmt.Errorf("invalid hostname: %s", host)
+		}
+		b = append(b, byte(len(label)))
+		b = append(b, label...)
+	}
+	b = append(b, 0, 0, dnsTypeNAPTR, 0, dnsClassIN)
+
+	return b, id, nil
+}
+
+// parseNAPTRResponse returns the NAPTR records of the given DNS response.
+func parseNAPTRResponse(host string, id uint16, b []byte) ([]NAPTR, error) {
+	if len(b) < 12 {
+		return nil, &net.DNSError{Err: "response too short", Name: host, IsTemporary: true}
+	}
+	if binary.BigEndian.Uint16(b[0:2]) != id || b[2]&0x80 == 0 {
+		return nil, &net.DNSError{Err: "invalid response", Name: host, IsTemporary: true}
+	}
+
+	switch rcode := b[3] & 0x0f; rcode {
+	case 0:
+	case dnsRcodeNX:
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	default:
+		return nil, &net.DNSError{Err: fmt.Sprintf("server failure (rcode: %d)", rcode), Name: host, IsTemporary: true}
+	}
+
+	qdCount := int(binary.BigEndian.Uint16(b[4:6]))
+	anCount := int(binary.BigEndian.Uint16(b[6:8]))
+	off := 12
+
+	var err error
+	for i := 0; i < qdCount; i++ {
+		if _, off, err = readDNSName(b, off); err != nil {
+			return nil, err
+		}
+		off += 4
+	}
+
+	var records []NAPTR
+	for i := 0; i < anCount; i++ {
+		if _, off, err = readDNSName(b, off); err != nil {
+			return nil, err
+		}
+		if off+10 > len(b) {
+			return nil, errors.New("invalid resource record")
+		}
+		rrType := binary.BigEndian.Uint16(b[off : off+2])
+		rdLength := int(binary.BigEndian.Uint16(b[off+8 : off+10]))
+		off += 10
+		if off+rdLength > len(b) {
+			return nil, errors.New("invalid resource record data")
+		}
+
+		if rrType == dnsTypeNAPTR {
+			rec, err := parseNAPTR(b, off, off+rdLength)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, rec)
+		}
+		off += rdLength
+	}
+
+	return records, nil
+}
+
+// parseNAPTR parses the NAPTR record data between off and end.
+func parseNAPTR(b []byte, off, end int) (NAPTR, error) {
+	var rec NAPTR
+	if off+4 > end {
+		return rec, errors.New("invalid naptr record")
+	}
+	rec.Order = binary.BigEndian.Uint16(b[off : off+2])
+	rec.Preference = binary.BigEndian.Uint16(b[off+2 : off+4])
+	off += 4
+
+	for _, s := range []*string{&rec.Flags, &rec.Service, &rec.Regexp} {
+		if off >= end || off+1+int(b[off]) > end {
+			return rec, errors.New("invalid naptr record")
+		}
+		*s = string(b[off+1 : off+1+int(b[off])])
+		off += 1 + int(b[off])
+	}
+
+	var err error
+	rec.Replacement, _, err = readDNSName(b, off)
+	if err != nil {
+		return rec, err
+	}
+
+	return rec, nil
+}
+
+// readDNSName reads the (possibly compressed) domain name at the given
+// offset and returns it together with the offset following the name.
+func readDNSName(b []byte, off int) (string, int, error) {
+	var labels []string
+	next := -1
+
+	for hops := 0; ; {
+		if off >= len(b) {
+			return "", 0, errors.New("invalid domain name")
+		}
+		l := int(b[off])
+
+		switch {
+		case l == 0:
+			if next == -1 {
+				next = off + 1
+			}
+			return strings.Join(labels, "."), next, nil
+		case l&0xc0 == 0xc0:
+			if off+1 >= len(b) || hops > 10 {
+				return "", 0, errors.New("invalid domain name pointer")
+			}
+			if next == -1 {
+				next = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(b[off:off+2]) & 0x3fff)
+			hops++
+		default:
+			if off+1+l > len(b) {
+				return "", 0, errors.New("invalid domain name")
+			}
+			labels = append(labels, string(b[off+1:off+1+l]))
+			off += 1 + l
+		}
+	}
+}
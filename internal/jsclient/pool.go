@@ -1,6 +1,13 @@
 package jsclient
 
 import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
 	"github.com/brocaar/lorawan"
 )
 
@@ -9,18 +16,172 @@ type Pool interface {
 	Get(joinEUI lorawan.EUI64) (Client, error)
 }
 
+// Route defines a JoinEUI range (inclusive) for which the join-requests
+// must be sent to the given client.
+type Route struct {
+	JoinEUIFrom lorawan.EUI64
+	JoinEUITo   lorawan.EUI64
+	Client      Client
+}
+
+// Matches returns true when the given JoinEUI is within the route range.
+func (r Route) Matches(joinEUI lorawan.EUI64) bool {
+	eui := binary.BigEndian.Uint64(joinEUI[:])
+	return eui >= binary.BigEndian.Uint64(r.JoinEUIFrom[:]) && eui <= binary.BigEndian.Uint64(r.JoinEUITo[:])
+}
+
+// size returns the number of JoinEUIs covered by the route (minus one).
+func (r Route) size() uint64 {
+	return binary.BigEndian.Uint64(r.JoinEUITo[:]) - binary.BigEndian.Uint64(r.JoinEUIFrom[:])
+}
+
+// ResolverConfig holds the configuration for resolving the join-server of
+// JoinEUIs which do not match any of the routes.
+type ResolverConfig struct {
+	// Resolver resolves the join-server (resolving is disabled when nil).
+	Resolver Resolver
+
+	// CACert, TLSCert and TLSKey are used by the clients created for the
+	// resolved join-servers (optional).
+	CACert  string
+	TLSCert string
+	TLSKey  string
+
+	// CacheSize holds the max. number of JoinEUIs of which the resolved
+	// join-server is cached.
+	CacheSize int
+
+	// CacheTTL holds the duration for which the resolved join-server of a
+	// JoinEUI is cached.
+	CacheTTL time.Duration
+}
+
+// resolvedClient holds the (cached) client for a resolved JoinEUI. The
+// server is empty when the JoinEUI could not be resolved.
+type resolvedClient struct {
+	server  string
+	client  Client
+	expires time.Time
+}
+
 type pool struct {
-	defaultClient Client
+	sync.Mutex
+	defaultClient  Client
+	routes         []Route
+	resolverConfig ResolverConfig
+	resolved       map[lorawan.EUI64]resolvedClient
+	clientOptions  ClientOptions
 }
 
-// NewPool creates a new Pool.
-func NewPool(defaultClient Client) Pool {
+// NewPool creates a new Pool. For a given JoinEUI, the client of the most
+// specific matching route is returned. When none of the routes matches and
+// a resolver is configured, the join-server will be resolved using this
+// resolver. In all other cases the default client is returned. The client
+// options are used for the clients created for resolved join-servers.
+func NewPool(defaultClient Client, routes []Route, rc ResolverConfig, opts ClientOptions) Pool {
 	return &pool{
-		defaultClient: defaultClient,
+		defaultClient:  defaultClient,
+		routes:         routes,
+		resolverConfig: rc,
+		resolved:       make(map[lorawan.EUI64]resolvedClient),
+		clientOptions:  opts,
 	}
 }
 
 // Get returns the join-server client for the given joinEUI.
 func (p *pool) Get(joinEUI lorawan.EUI64) (Client, error) {
-	return p.defaultClient, nil
+	var route *Route
+	for i := range p.routes {
+		if !p.routes[i].Matches(joinEUI) {
+			continue
+		}
+		if route == nil || p.routes[i].size() < route.size() {
+			route = &p.routes[i]
+		}
+	}
+	if route != nil {
+		return route.Client, nil
+	}
+
+	if p.resolverConfig.Resolver == nil {
+		return p.defaultClient, nil
+	}
+
+	return p.getResolvedClient(joinEUI)
+}
+
+// getResolvedClient returns the client for the resolved join-server of the
+// given JoinEUI, or the default client when it could not be resolved. The
+// result is cached for the configured TTL.
+func (p *pool) getResolvedClient(joinEUI lorawan.EUI64) (Client, error) {
+	p.Lock()
+	rc, ok := p.resolved[joinEUI]
+	p.Unlock()
+	if ok && time.Now().Before(rc.expires) {
+		return rc.client, nil
+	}
+
+	server, err := p.resolverConfig.Resolver.ResolveJoinEUI(joinEUI)
+	if err != nil && errors.Cause(err) != ErrJoinEUINotFound {
+		return nil, errors.Wrap(err, "resolve joineui error")
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	rc = resolvedClient{
+		server:  server,
+		client:  p.defaultClient,
+		expires: time.Now().Add(p.resolverConfig.CacheTTL),
+	}
+
+	if server != "" {
+		// re-use the client of an other JoinEUI resolving to the same server
+		rc.client = nil
+		for _, c := range p.resolved {
+			if c.server == server {
+				rc.client = c.client
+				break
+			}
+		}
+
+		if rc.client == nil {
+			log.WithFields(log.Fields{
+				"join_eui": joinEUI,
+				"server":   server,
+			}).Info("creating client for resolved join-server")
+			rc.client, err = NewClient(server, p.resolverConfig.CACert, p.resolverConfig.TLSCert, p.resolverConfig.TLSKey, p.clientOptions)
+			if err != nil {
+				return nil, errors.Wrap(err, "create join-server client error")
+			}
+		}
+	}
+
+	p.evict()
+	p.resolved[joinEUI] = rc
+
+	return rc.client, nil
+}
+
+// evict removes the expired entries from the cache. When the cache is still
+// full, the entry expiring first is removed.
+func (p *pool) evict() {
+	now := time.Now()
+	for eui, rc := range p.resolved {
+		if !now.Before(rc.expires) {
+			delete(p.resolved, eui)
+		}
+	}
+
+	for len(p.resolved) > 0 && len(p.resolved) >= p.resolverConfig.CacheSize {
+		var first lorawan.EUI64
+		var firstExpires time.Time
+		for eui, rc := range p.resolved {
+			if firstExpires.IsZero() || rc.expires.Before(firstExpires) {
+				first = eui
+				firstExpires = rc.expires
+			}
+		}
+		delete(p.resolved, first)
+	}
 }
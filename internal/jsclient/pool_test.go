@@ -0,0 +1,287 @@
+package jsclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+)
+
+type testClient struct {
+	name string
+}
+
+func (c *testClient) JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+	return backend.JoinAnsPayload{}, nil
+}
+
+type testResolver struct {
+	servers map[lorawan.EUI64]string
+	err     error
+	lookups int
+}
+
+func (r *testResolver) ResolveJoinEUI(joinEUI lorawan.EUI64) (string, error) {
+	r.lookups++
+	if r.err != nil {
+		return "", r.err
+	}
+	s, ok := r.servers[joinEUI]
+	if !ok {
+		return "", ErrJoinEUINotFound
+	}
+	return s, nil
+}
+
+func TestParseRouteConfig(t *testing.T) {
+	Convey("Given a set of test routes", t, func() {
+		tests := []struct {
+			Route    string
+			Expected RouteConfig
+			Error    bool
+		}{
+			{
+				Route: "0102030400000000/32=https://js.example.com:8003",
+				Expected: RouteConfig{
+					JoinEUIFrom: lorawan.EUI64{1, 2, 3, 4, 0, 0, 0, 0},
+					JoinEUITo:   lorawan.EUI64{1, 2, 3, 4, 255, 255, 255, 255},
+					Server:      "https://js.example.com:8003",
+				},
+			},
+			{
+				Route: "0102030405060708-0102030405060710=https://js.example.com;ca_cert=ca.pem;tls_cert=cert.pem;tls_key=key.pem",
+				Expected: RouteConfig{
+					JoinEUIFrom: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					JoinEUITo:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 16},
+					Server:      "https://js.example.com",
+					CACert:      "ca.pem",
+					TLSCert:     "cert.pem",
+					TLSKey:      "key.pem",
+				},
+			},
+			{
+				Route: "0102030405060708=http://localhost:8003",
+				Expected: RouteConfig{
+					JoinEUIFrom: lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					JoinEUITo:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+					Server:      "http://localhost:8003",
+				},
+			},
+			{Route: "0102030400000000/32", Error: true},
+			{Route: "0102030400000000/65=http://localhost:8003", Error: true},
+			{Route: "0102030405060710-0102030405060708=http://localhost:8003", Error: true},
+			{Route: "0102030400000000/32=http://localhost:8003;foo=bar", Error: true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Route, i), func() {
+				rc, err := ParseRouteConfig(test.Route)
+				if test.Error {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(rc, ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
+
+func TestPool(t *testing.T) {
+	Convey("Given a pool with a default client, two routes and a resolver", t, func() {
+		defaultClient := &testClient{name: "default"}
+		wideClient := &testClient{name: "wide"}
+		narrowClient := &testClient{name: "narrow"}
+		resolver := &testResolver{
+			servers: map[lorawan.EUI64]string{
+				{8, 7, 6, 5, 4, 3, 2, 1}: "https://js.example.com",
+			},
+		}
+
+		p := NewPool(defaultClient, []Route{
+			{JoinEUIFrom: lorawan.EUI64{1, 0, 0, 0, 0, 0, 0, 0}, JoinEUITo: lorawan.EUI64{1, 255, 255, 255, 255, 255, 255, 255}, Client: wideClient},
+			{JoinEUIFrom: lorawan.EUI64{1, 2, 3, 4, 0, 0, 0, 0}, JoinEUITo: lorawan.EUI64{1, 2, 3, 4, 255, 255, 255, 255}, Client: narrowClient},
+		}, ResolverConfig{
+			Resolver:  resolver,
+			CacheSize: 2,
+			CacheTTL:  time.Minute,
+		}, ClientOptions{})
+
+		Convey("Then the most specific route is used", func() {
+			c, err := p.Get(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+			So(c, ShouldEqual, narrowClient)
+
+			c, err = p.Get(lorawan.EUI64{1, 2, 3, 5, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+			So(c, ShouldEqual, wideClient)
+		})
+
+		Convey("Then a resolved JoinEUI returns a (cached) client for the resolved server", func() {
+			c1, err := p.Get(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+			So(err, ShouldBeNil)
+			So(c1.(*client).server, ShouldEqual, "https://js.example.com")
+
+			c2, err := p.Get(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+			So(err, ShouldBeNil)
+			So(c2, ShouldEqual, c1)
+			So(resolver.lookups, ShouldEqual, 1)
+
+			Convey("Then the cache is bounded by the cache size", func() {
+				for _, eui := range []lorawan.EUI64{{2, 2, 2, 2, 2, 2, 2, 2}, {3, 3, 3, 3, 3, 3, 3, 3}} {
+					_, err := p.Get(eui)
+					So(err, ShouldBeNil)
+				}
+				So(p.(*pool).resolved, ShouldHaveLength, 2)
+				So(p.(*pool).resolved, ShouldNotContainKey, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+			})
+
+			Convey("Then the JoinEUI is resolved again after the cache TTL", func() {
+				p.(*pool).resolverConfig.CacheTTL = 0
+				p.(*pool).resolved = make(map[lorawan.EUI64]resolvedClient)
+
+				for i := 0; i < 2; i++ {
+					_, err := p.Get(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+					So(err, ShouldBeNil)
+				}
+				So(resolver.lookups, ShouldEqual, 3)
+			})
+		})
+
+		Convey("Then an unknown JoinEUI returns the default client", func() {
+			c, err := p.Get(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2})
+			So(err, ShouldBeNil)
+			So(c, ShouldEqual, defaultClient)
+		})
+
+		Convey("Then a resolver error is returned", func() {
+			resolver.err = errors.New("dns is down")
+			_, err := p.Get(lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestDNSResolver(t *testing.T) {
+	Convey("Given a DNSResolver with a stubbed LookupNAPTR", t, func() {
+		var lookups []string
+		r := NewDNSResolver("joineuis.lora-alliance.org")
+		r.LookupNAPTR = func(host string) ([]NAPTR, error) {
+			lookups = append(lookups, host)
+			switch host {
+			case "8.0.7.0.6.0.5.0.4.0.3.0.2.0.1.0.joineuis.lora-alliance.org":
+				return []NAPTR{
+					{Order: 20, Flags: "U", Regexp: "!^.*$!https://js2.example.com!"},
+					{Order: 10, Preference: 20, Flags: "U", Regexp: "!^.*$!https://js1.example.com!"},
+					{Order: 10, Preference: 10, Flags: "S", Replacement: "_js._tcp.example.com"},
+				}, nil
+			case "1.0.1.0.1.0.1.0.1.0.1.0.1.0.1.0.joineuis.lora-alliance.org":
+				return []NAPTR{
+					{Order: 10, Flags: "U", Regexp: "!^.*$!not a url!"},
+				}, nil
+			}
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+
+		Convey("Then a known JoinEUI resolves to the server of the first terminal NAPTR record", func() {
+			server, err := r.ResolveJoinEUI(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+			So(err, ShouldBeNil)
+			So(server, ShouldEqual, "https://js1.example.com")
+		})
+
+		Convey("Then a JoinEUI without valid NAPTR record returns ErrJoinEUINotFound", func() {
+			_, err := r.ResolveJoinEUI(lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1})
+			So(err, ShouldEqual, ErrJoinEUINotFound)
+		})
+
+		Convey("Then an unknown JoinEUI returns ErrJoinEUINotFound", func() {
+			_, err := r.ResolveJoinEUI(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+			So(err, ShouldEqual, ErrJoinEUINotFound)
+			So(lookups, ShouldResemble, []string{"1.0.2.0.3.0.4.0.5.0.6.0.7.0.8.0.joineuis.lora-alliance.org"})
+		})
+
+		Convey("Then a temporary DNS error is returned", func() {
+			r.LookupNAPTR = func(host string) ([]NAPTR, error) {
+				return nil, &net.DNSError{Err: "timeout", Name: host, IsTimeout: true}
+			}
+			_, err := r.ResolveJoinEUI(lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+			So(err, ShouldNotBeNil)
+			So(err, ShouldNotEqual, ErrJoinEUINotFound)
+		})
+	})
+}
+
+func TestNAPTR(t *testing.T) {
+	Convey("Given a set of NAPTR regexp tests", t, func() {
+		tests := []struct {
+			Regexp   string
+			Input    string
+			Expected string
+			Error    bool
+		}{
+			{Regexp: "!^.*$!https://js.example.com!", Input: "1.0.example.com", Expected: "https://js.example.com"},
+			{Regexp: "!^([0-9.]+)\\.EXAMPLE\\.com$!https://\\1.js.example.com:8003!i", Input: "1.0.example.com", Expected: "https://1.0.js.example.com:8003"},
+			{Regexp: "#^.*$#http://js.example.com/path#", Input: "1.0.example.com", Expected: "http://js.example.com/path"},
+			{Regexp: "!^foo$!https://js.example.com!", Input: "1.0.example.com", Error: true},
+			{Regexp: "!^.*$!ftp://js.example.com!", Input: "1.0.example.com", Error: true},
+			{Regexp: "!^.*$!https://js.example.com", Input: "1.0.example.com", Error: true},
+			{Regexp: "", Input: "1.0.example.com", Error: true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Regexp, i), func() {
+				out, err := NAPTR{Regexp: test.Regexp}.ApplyRegexp(test.Input)
+				if test.Error {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(out, ShouldEqual, test.Expected)
+			})
+		}
+	})
+
+	Convey("Given a NAPTR query and response", t, func() {
+		query, id, err := newNAPTRQuery("1.0.example.com")
+		So(err, ShouldBeNil)
+		So(query[12:], ShouldResemble, []byte{1, '1', 1, '0', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0, 0, 35, 0, 1})
+
+		// response header (answer, recursion available), the question
+		// and a NAPTR answer with a compressed name (pointer to the
+		// question)
+		resp := append([]byte{query[0], query[1], 0x81, 0x80, 0, 1, 0, 1, 0, 0, 0, 0}, query[12:]...)
+		rdata := []byte{0, 10, 0, 20, 1, 'U', 0, 29}
+		rdata = append(rdata, "!^.*$!https://js.example.com!"...)
+		rdata = append(rdata, 0)
+		resp = append(resp, 0xc0, 12, 0, 35, 0, 1, 0, 0, 0, 60, 0, byte(len(rdata)))
+		resp = append(resp, rdata...)
+
+		Convey("Then the NAPTR records are returned", func() {
+			records, err := parseNAPTRResponse("1.0.example.com", id, resp)
+			So(err, ShouldBeNil)
+			So(records, ShouldResemble, []NAPTR{
+				{Order: 10, Preference: 20, Flags: "U", Regexp: "!^.*$!https://js.example.com!"},
+			})
+		})
+
+		Convey("Then a NXDOMAIN response returns a not found error", func() {
+			resp[3] = 0x83
+			_, err := parseNAPTRResponse("1.0.example.com", id, resp)
+			dnsErr, ok := err.(*net.DNSError)
+			So(ok, ShouldBeTrue)
+			So(dnsErr.IsNotFound, ShouldBeTrue)
+			So(dnsErr.Temporary(), ShouldBeFalse)
+		})
+
+		Convey("Then a response with an other ID is rejected", func() {
+			_, err := parseNAPTRResponse("1.0.example.com", id+1, resp)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package jsclient
+
+import (
+	"encoding/hex"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// ErrJoinEUINotFound is returned by a Resolver when no join-server could
+// be found for the given JoinEUI.
+var ErrJoinEUINotFound = errors.New("join-server for joineui not found")
+
+// Resolver defines the interface for resolving the join-server of a JoinEUI.
+type Resolver interface {
+	// ResolveJoinEUI returns the join-server URL for the given JoinEUI.
+	// ErrJoinEUINotFound is returned when the JoinEUI could not be resolved.
+	ResolveJoinEUI(joinEUI lorawan.EUI64) (string, error)
+}
+
+// DNSResolver resolves the join-server by looking up the NAPTR records of
+// the JoinEUI, following the LoRaWAN backend-interfaces convention. The
+// JoinEUI is converted to a hostname by reversing its nibbles, separating
+// them by dots and appending the domain suffix, e.g. for JoinEUI
+// 0102030405060708 and suffix joineuis.lora-alliance.org:
+// 8.0.7.0.6.0.5.0.4.0.3.0.2.0.1.0.joineuis.lora-alliance.org.
+// The join-server URL is the result of the regexp of the first (by order
+// and preference) terminal ("U" flag) NAPTR record.
+type DNSResolver struct {
+	// DomainSuffix is appended to the JoinEUI nibbles.
+	DomainSuffix string
+
+	// LookupNAPTR returns the NAPTR records of the given hostname. It
+	// defaults to LookupNAPTR and can be overridden for testing.
+	LookupNAPTR func(host string) ([]NAPTR, error)
+}
+
+// NewDNSResolver creates a new DNSResolver using the given domain suffix.
+func NewDNSResolver(domainSuffix string) *DNSResolver {
+	return &DNSResolver{
+		DomainSuffix: domainSuffix,
+		LookupNAPTR:  LookupNAPTR,
+	}
+}
+
+// ResolveJoinEUI returns the join-server URL for the given JoinEUI.
+func (r *DNSResolver) ResolveJoinEUI(joinEUI lorawan.EUI64) (string, error) {
+	host := r.JoinEUIToHostname(joinEUI)
+
+	records, err := r.LookupNAPTR(host)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && !dnsErr.Temporary() {
+			return "", ErrJoinEUINotFound
+		}
+		return "", errors.Wrap(err, "lookup naptr error")
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Order != records[j].Order {
+			return records[i].Order < records[j].Order
+		}
+		return records[i].Preference < records[j].Preference
+	})
+
+	for _, rec := range records {
+		if !strings.EqualFold(rec.Flags, "u") {
+			continue
+		}
+
+		server, err := rec.ApplyRegexp(host)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"host":   host,
+				"regexp": rec.Regexp,
+			}).Warningf("invalid naptr record: %s", err)
+			continue
+		}
+		return server, nil
+	}
+
+	return "", ErrJoinEUINotFound
+}
+
+// JoinEUIToHostname returns the hostname for the given JoinEUI.
+func (r *DNSResolver) JoinEUIToHostname(joinEUI lorawan.EUI64) string {
+	s := hex.EncodeToString(joinEUI[:])
+	nibbles := make([]string, 0, len(s)+1)
+	for i := len(s) - 1; i >= 0; i-- {
+		nibbles = append(nibbles, string(s[i]))
+	}
+	nibbles = append(nibbles, strings.Trim(r.DomainSuffix, "."))
+
+	return strings.Join(nibbles, ".")
+}
@@ -0,0 +1,125 @@
+package jsclient
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// RouteConfig defines the configuration of a join-server route.
+type RouteConfig struct {
+	JoinEUIFrom lorawan.EUI64
+	JoinEUITo   lorawan.EUI64
+	Server      string
+	CACert      string
+	TLSCert     string
+	TLSKey      string
+}
+
+// ParseRouteConfig parses a route in one of the formats below. The TLS
+// options are optional.
+//
+//   JOINEUI/PREFIX_BITS=SERVER[;ca_cert=FILE;tls_cert=FILE;tls_key=FILE]
+//   JOINEUI_FROM-JOINEUI_TO=SERVER[;ca_cert=FILE;tls_cert=FILE;tls_key=FILE]
+//
+// Example: 0102030400000000/32=https://js.example.com:8003
+func ParseRouteConfig(s string) (RouteConfig, error) {
+	var rc RouteConfig
+
+	parts := strings.Split(s, ";")
+	kv := strings.SplitN(parts[0], "=", 2)
+	if len(kv) != 2 || kv[1] == "" {
+		return rc, fmt.Errorf("route '%s' must be in the format JOINEUI_RANGE=SERVER", s)
+	}
+	rc.Server = kv[1]
+
+	var err error
+	rc.JoinEUIFrom, rc.JoinEUITo, err = parseJoinEUIRange(kv[0])
+	if err != nil {
+		return rc, errors.Wrapf(err, "parse joineui range of route '%s' error", s)
+	}
+
+	for _, opt := range parts[1:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			return rc, fmt.Errorf("invalid option '%s' in route '%s'", opt, s)
+		}
+
+		switch kv[0] {
+		case "ca_cert":
+			rc.CACert = kv[1]
+		case "tls_cert":
+			rc.TLSCert = kv[1]
+		case "tls_key":
+			rc.TLSKey = kv[1]
+		default:
+			return rc, fmt.Errorf("unknown option '%s' in route '%s'", kv[0], s)
+		}
+	}
+
+	return rc, nil
+}
+
+// NewRoute creates a new Route (and join-server client) for the given
+// route configuration.
+func NewRoute(rc RouteConfig, opts ClientOptions) (Route, error) {
+	c, err := NewClient(rc.Server, rc.CACert, rc.TLSCert, rc.TLSKey, opts)
+	if err != nil {
+		return Route{}, errors.Wrap(err, "create join-server client error")
+	}
+
+	return Route{
+		JoinEUIFrom: rc.JoinEUIFrom,
+		JoinEUITo:   rc.JoinEUITo,
+		Client:      c,
+	}, nil
+}
+
+func parseJoinEUIRange(s string) (lorawan.EUI64, lorawan.EUI64, error) {
+	var from, to lorawan.EUI64
+
+	if parts := strings.SplitN(s, "/", 2); len(parts) == 2 {
+		if err := from.UnmarshalText([]byte(parts[0])); err != nil {
+			return from, to, errors.Wrap(err, "parse joineui error")
+		}
+
+		bits, err := strconv.Atoi(parts[1])
+		if err != nil || bits < 0 || bits > 64 {
+			return from, to, fmt.Errorf("prefix length must be between 0 and 64")
+		}
+
+		var mask uint64
+		if bits < 64 {
+			mask = ^uint64(0) >> uint(bits)
+		}
+		eui := binary.BigEndian.Uint64(from[:])
+		binary.BigEndian.PutUint64(from[:], eui&^mask)
+		binary.BigEndian.PutUint64(to[:], eui|mask)
+
+		return from, to, nil
+	}
+
+	if parts := strings.SplitN(s, "-", 2); len(parts) == 2 {
+		if err := from.UnmarshalText([]byte(parts[0])); err != nil {
+			return from, to, errors.Wrap(err, "parse joineui error")
+		}
+		if err := to.UnmarshalText([]byte(parts[1])); err != nil {
+			return from, to, errors.Wrap(err, "parse joineui error")
+		}
+		if binary.BigEndian.Uint64(from[:]) > binary.BigEndian.Uint64(to[:]) {
+			return from, to, fmt.Errorf("range start must not be greater than range end")
+		}
+
+		return from, to, nil
+	}
+
+	if err := from.UnmarshalText([]byte(s)); err != nil {
+		return from, to, errors.Wrap(err, "parse joineui error")
+	}
+	return from, from, nil
+}
@@ -0,0 +1,226 @@
+// Package kek implements the key-encryption-key (KEK) store and the
+// RFC 3394 AES key wrap algorithm used for exchanging (wrapped) session-keys
+// with the other backend components.
+package kek
+
+import (
+	"crypto/aes"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// defaultIV is the default initial value as defined by RFC 3394.
+var defaultIV = []byte{0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6, 0xa6}
+
+// Errors returned by this package.
+var (
+	ErrUnknownLabel     = errors.New("unknown kek label")
+	ErrIntegrityCheck   = errors.New("key unwrap integrity check failed")
+	ErrInvalidKeyLength = errors.New("invalid key length")
+)
+
+// Store holds the KEKs by label.
+type Store struct {
+	sync.RWMutex
+	keys map[string][]byte
+}
+
+// NewStore creates a new (empty) KEK store.
+func NewStore() *Store {
+	return &Store{
+		keys: make(map[string][]byte),
+	}
+}
+
+// Add adds the given KEK to the store. The KEK must be a valid AES key
+// (16, 24 or 32 bytes).
+func (s *Store) Add(label string, kek []byte) error {
+	if _, err := aes.NewCipher(kek); err != nil {
+		return errors.Wrap(err, "invalid kek")
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.keys[label] = kek
+
+	return nil
+}
+
+// Get returns the KEK for the given label.
+func (s *Store) Get(label string) ([]byte, error) {
+	if s == nil {
+		return nil, ErrUnknownLabel
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	kek, ok := s.keys[label]
+	if !ok {
+		return nil, ErrUnknownLabel
+	}
+	return kek, nil
+}
+
+// UnwrapAES128Key unwraps the given wrapped AES128 key using the KEK
+// matching the given label.
+func (s *Store) UnwrapAES128Key(label string, wrapped []byte) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+
+	kek, err := s.Get(label)
+	if err != nil {
+		return key, errors.Wrapf(err, "get kek '%s' error", label)
+	}
+
+	b, err := Unwrap(kek, wrapped)
+	if err != nil {
+		return key, errors.Wrap(err, "unwrap key error")
+	}
+	if len(b) != len(key) {
+		return key, ErrInvalidKeyLength
+	}
+	copy(key[:], b)
+
+	return key, nil
+}
+
+// KeyEnvelope defines the key envelope used by the backend-interfaces.
+// Unlike backend.KeyEnvelope, it is able to hold wrapped keys (which are
+// longer than the key itself).
+type KeyEnvelope struct {
+	KEKLabel string           `json:"KEKLabel"`
+	AESKey   backend.HEXBytes `json:"AESKey"`
+}
+
+// WrapAES128Key returns the key envelope for the given key, wrapped using
+// the KEK matching the given label. When the label is empty, the key is
+// not wrapped.
+func (s *Store) WrapAES128Key(label string, key lorawan.AES128Key) (*KeyEnvelope, error) {
+	if label == "" {
+		return &KeyEnvelope{AESKey: backend.HEXBytes(key[:])}, nil
+	}
+
+	kek, err := s.Get(label)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get kek '%s' error", label)
+	}
+
+	b, err := Wrap(kek, key[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "wrap key error")
+	}
+
+	return &KeyEnvelope{
+		KEKLabel: label,
+		AESKey:   backend.HEXBytes(b),
+	}, nil
+}
+
+// UnwrapKeyEnvelope returns the plain key of the given key envelope. When the
+// KEKLabel of the envelope is empty, the key is returned as-is.
+func (s *Store) UnwrapKeyEnvelope(ke KeyEnvelope) (lorawan.AES128Key, error) {
+	var key lorawan.AES128Key
+
+	if ke.KEKLabel == "" {
+		if len(ke.AESKey) != len(key) {
+			return key, ErrInvalidKeyLength
+		}
+		copy(key[:], ke.AESKey)
+		return key, nil
+	}
+
+	return s.UnwrapAES128Key(ke.KEKLabel, ke.AESKey)
+}
+
+// ParseKEK parses a KEK in the LABEL=HEXKEY format.
+func ParseKEK(s string) (string, []byte, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", nil, fmt.Errorf("kek must be in the format LABEL=HEXKEY")
+	}
+
+	kek, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, errors.Wrap(err, "decode hex error")
+	}
+
+	return parts[0], kek, nil
+}
+
+// Wrap wraps the given key using the given KEK (RFC 3394).
+func Wrap(kek, key []byte) ([]byte, error) {
+	if len(key) < 16 || len(key)%8 != 0 {
+		return nil, ErrInvalidKeyLength
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errors.Wrap(err, "new cipher error")
+	}
+
+	n := len(key) / 8
+	out := make([]byte, (n+1)*8)
+	copy(out[:8], defaultIV)
+	copy(out[8:], key)
+
+	b := make([]byte, 16)
+	for j := 0; j < 6; j++ {
+		for i := 1; i <= n; i++ {
+			copy(b[:8], out[:8])
+			copy(b[8:], out[i*8:(i+1)*8])
+			block.Encrypt(b, b)
+
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(out[:8], binary.BigEndian.Uint64(b[:8])^t)
+			copy(out[i*8:(i+1)*8], b[8:])
+		}
+	}
+
+	return out, nil
+}
+
+// Unwrap unwraps the given wrapped key using the given KEK (RFC 3394).
+func Unwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 24 || len(wrapped)%8 != 0 {
+		return nil, ErrInvalidKeyLength
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, errors.Wrap(err, "new cipher error")
+	}
+
+	n := len(wrapped)/8 - 1
+	a := make([]byte, 8)
+	copy(a, wrapped[:8])
+	r := make([]byte, n*8)
+	copy(r, wrapped[8:])
+
+	b := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n; i >= 1; i-- {
+			t := uint64(n*j + i)
+			binary.BigEndian.PutUint64(b[:8], binary.BigEndian.Uint64(a)^t)
+			copy(b[8:], r[(i-1)*8:i*8])
+			block.Decrypt(b, b)
+
+			copy(a, b[:8])
+			copy(r[(i-1)*8:i*8], b[8:])
+		}
+	}
+
+	if subtle.ConstantTimeCompare(a, defaultIV) != 1 {
+		return nil, ErrIntegrityCheck
+	}
+
+	return r, nil
+}
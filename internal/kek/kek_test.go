@@ -0,0 +1,90 @@
+package kek
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/brocaar/lorawan"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestWrapUnwrap(t *testing.T) {
+	Convey("Given the RFC 3394 test vectors", t, func() {
+		tests := []struct {
+			Name    string
+			KEK     string
+			Key     string
+			Wrapped string
+		}{
+			{
+				Name:    "128 bit key with 128 bit kek",
+				KEK:     "000102030405060708090a0b0c0d0e0f",
+				Key:     "00112233445566778899aabbccddeeff",
+				Wrapped: "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5",
+			},
+			{
+				Name:    "128 bit key with 256 bit kek",
+				KEK:     "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				Key:     "00112233445566778899aabbccddeeff",
+				Wrapped: "64e8c3f9ce0f5ba263e9777905818a2a93c8191e7d6e8ae7",
+			},
+			{
+				Name:    "256 bit key with 256 bit kek",
+				KEK:     "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+				Key:     "00112233445566778899aabbccddeeff000102030405060708090a0b0c0d0e0f",
+				Wrapped: "28c9f404c4b810f4cbccb35cfb87f8263f5786e2d80ed326cbc7f0e71a99f43bfb988b9b7a02dd21",
+			},
+		}
+
+		for _, test := range tests {
+			Convey("Testing: "+test.Name, func() {
+				wrapped, err := Wrap(mustDecodeHex(test.KEK), mustDecodeHex(test.Key))
+				So(err, ShouldBeNil)
+				So(hex.EncodeToString(wrapped), ShouldEqual, test.Wrapped)
+
+				key, err := Unwrap(mustDecodeHex(test.KEK), wrapped)
+				So(err, ShouldBeNil)
+				So(hex.EncodeToString(key), ShouldEqual, test.Key)
+			})
+		}
+
+		Convey("Then unwrapping with the wrong kek fails the integrity check", func() {
+			_, err := Unwrap(mustDecodeHex("0f0e0d0c0b0a09080706050403020100"), mustDecodeHex(tests[0].Wrapped))
+			So(err, ShouldEqual, ErrIntegrityCheck)
+		})
+	})
+}
+
+func TestStore(t *testing.T) {
+	Convey("Given a store with a KEK", t, func() {
+		label, kek, err := ParseKEK("lora-ns=000102030405060708090a0b0c0d0e0f")
+		So(err, ShouldBeNil)
+		So(label, ShouldEqual, "lora-ns")
+
+		s := NewStore()
+		So(s.Add(label, kek), ShouldBeNil)
+
+		Convey("Then UnwrapAES128Key returns the unwrapped key", func() {
+			key, err := s.UnwrapAES128Key("lora-ns", mustDecodeHex("1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"))
+			So(err, ShouldBeNil)
+			So(key, ShouldEqual, lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff})
+		})
+
+		Convey("Then an unknown label returns an error", func() {
+			_, err := s.UnwrapAES128Key("foo", mustDecodeHex("1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Then adding an invalid KEK returns an error", func() {
+			So(s.Add("invalid", []byte{1, 2, 3}), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,9 @@
+package linktest
+
+import "errors"
+
+// link test errors
+var (
+	ErrDoesNotExist    = errors.New("link test does not exist")
+	ErrInvalidDataRate = errors.New("invalid data-rate")
+)
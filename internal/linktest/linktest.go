@@ -0,0 +1,284 @@
+// Package linktest implements the gateway-to-gateway link test. A gateway
+// transmits a proprietary test frame (using the uplink polarization) and the
+// gateways receiving it report the RSSI and SNR. The results of the latest
+// test of each gateway form the gateway neighbour matrix.
+package linktest
+
+import (
+	"bytes"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/lorawan"
+)
+
+// magic defines the prefix of the MACPayload of a link test frame. It is
+// followed by the (16 byte) link test ID.
+var magic = []byte{'L', 'T'}
+
+// ReceiveTimeout defines the max duration between starting the link test
+// and receiving the test frame. As the frame is transmitted immediately,
+// frames received after this duration are ignored.
+var ReceiveTimeout = time.Minute
+
+// LinkTest defines a gateway link test.
+type LinkTest struct {
+	ID        string        `db:"id"`
+	MAC       lorawan.EUI64 `db:"mac"`
+	CreatedAt time.Time     `db:"created_at"`
+	Frequency int           `db:"frequency"`
+	DR        int           `db:"dr"`
+}
+
+// RXInfo contains the reception of a link test frame by a gateway.
+type RXInfo struct {
+	LinkTestID string        `db:"link_test_id"`
+	MAC        lorawan.EUI64 `db:"mac"`
+	RSSI       int           `db:"rssi"`
+	SNR        float64       `db:"snr"`
+	ReceivedAt time.Time     `db:"received_at"`
+}
+
+// Neighbour contains the link between two gateways, as measured by the
+// latest link test of the transmitting gateway.
+type Neighbour struct {
+	TXMAC      lorawan.EUI64 `db:"tx_mac"`
+	RXMAC      lorawan.EUI64 `db:"rx_mac"`
+	RSSI       int           `db:"rssi"`
+	SNR        float64       `db:"snr"`
+	LinkTestID string        `db:"link_test_id"`
+	TestedAt   time.Time     `db:"tested_at"`
+}
+
+// StartLinkTest creates a link test and schedules the transmission of the
+// test frame by the given gateway. When frequency is 0, the first uplink
+// channel of the band is used.
+func StartLinkTest(db *sqlx.DB, mac lorawan.EUI64, frequency, dr int) (LinkTest, error) {
+	lt := LinkTest{
+		ID:        uuid.NewV4().String(),
+		MAC:       mac,
+		CreatedAt: time.Now(),
+		Frequency: frequency,
+		DR:        dr,
+	}
+
+	if lt.DR < 0 || lt.DR > len(common.Band.DataRates)-1 {
+		return lt, errors.Wrapf(ErrInvalidDataRate, "dr: %d (max dr: %d)", lt.DR, len(common.Band.DataRates)-1)
+	}
+	if lt.Frequency == 0 {
+		lt.Frequency = common.Band.UplinkChannels[0].Frequency
+	}
+
+	// check that the gateway exists
+	if _, err := gateway.GetGateway(db, mac); err != nil {
+		return lt, errors.Wrap(err, "get gateway error")
+	}
+
+	_, err := db.Exec(`
+		insert into gateway_link_test (
+			id,
+			mac,
+			created_at,
+			frequency,
+			dr
+		) values ($1, $2, $3, $4, $5)`,
+		lt.ID,
+		lt.MAC[:],
+		lt.CreatedAt,
+		lt.Frequency,
+		lt.DR,
+	)
+	if err != nil {
+		return lt, errors.Wrap(err, "insert error")
+	}
+
+	id := uuid.FromStringOrNil(lt.ID)
+	iPol := false
+
+	err = common.Gateway.SendTXPacket(gw.TXPacket{
+		TXInfo: gw.TXInfo{
+			MAC:         mac,
+			Immediately: true,
+			Frequency:   lt.Frequency,
+			Power:       common.Band.DefaultTXPower,
+			DataRate:    common.Band.DataRates[lt.DR],
+			CodeRate:    "4/5",
+			IPol:        &iPol,
+		},
+		PHYPayload: lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				Major: lorawan.LoRaWANR1,
+				MType: lorawan.Proprietary,
+			},
+			MACPayload: &lorawan.DataPayload{Bytes: append(append([]byte{}, magic...), id[:]...)},
+		},
+	})
+	if err != nil {
+		return lt, errors.Wrap(err, "send tx packet to gateway error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":        lt.ID,
+		"mac":       lt.MAC,
+		"frequency": lt.Frequency,
+		"dr":        lt.DR,
+	}).Info("gateway link test started")
+
+	return lt, nil
+}
+
+// GetLinkTestID returns the link test ID contained by the given proprietary
+// MACPayload. It returns false when the payload is not a link test frame.
+func GetLinkTestID(macPayload []byte) (string, bool) {
+	if len(macPayload) != len(magic)+16 || !bytes.HasPrefix(macPayload, magic) {
+		return "", false
+	}
+
+	id, err := uuid.FromBytes(macPayload[len(magic):])
+	if err != nil {
+		return "", false
+	}
+	return id.String(), true
+}
+
+// HandleRXInfoSet stores the receptions of the test frame of the given link
+// test. Frames of unknown or timed out link tests and receptions by the
+// transmitting or unknown gateways are ignored.
+func HandleRXInfoSet(db *sqlx.DB, linkTestID string, rxInfoSet []gw.RXInfo) error {
+	lt, err := GetLinkTest(db, linkTestID)
+	if err != nil {
+		if errors.Cause(err) == ErrDoesNotExist {
+			log.WithField("id", linkTestID).Warning("unknown gateway link test frame received")
+			return nil
+		}
+		return errors.Wrap(err, "get link test error")
+	}
+
+	if time.Since(lt.CreatedAt) > ReceiveTimeout {
+		log.WithField("id", linkTestID).Warning("timed out gateway link test frame received")
+		return nil
+	}
+
+	now := time.Now()
+	for _, rxInfo := range rxInfoSet {
+		if rxInfo.MAC == lt.MAC {
+			continue
+		}
+
+		// in case of multiple antennas, the best reception is stored
+		_, err := db.Exec(`
+			insert into gateway_link_test_rx (
+				link_test_id,
+				mac,
+				rssi,
+				snr,
+				received_at
+			) values ($1, $2, $3, $4, $5)
+			on conflict (link_test_id, mac) do update
+			set
+				rssi = greatest(gateway_link_test_rx.rssi, excluded.rssi),
+				snr = greatest(gateway_link_test_rx.snr, excluded.snr)`,
+			lt.ID,
+			rxInfo.MAC[:],
+			rxInfo.RSSI,
+			rxInfo.LoRaSNR,
+			now,
+		)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+				continue
+			}
+			return errors.Wrap(err, "insert error")
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"id":       lt.ID,
+		"mac":      lt.MAC,
+		"gw_count": len(rxInfoSet),
+	}).Info("gateway link test frame received")
+
+	return nil
+}
+
+// GetLinkTest returns the link test for the given ID.
+func GetLinkTest(db sqlx.Queryer, id string) (LinkTest, error) {
+	var lt LinkTest
+	if _, err := uuid.FromString(id); err != nil {
+		return lt, ErrDoesNotExist
+	}
+
+	err := sqlx.Get(db, &lt, "select * from gateway_link_test where id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return lt, ErrDoesNotExist
+		}
+		return lt, errors.Wrap(err, "select error")
+	}
+	return lt, nil
+}
+
+// GetRXInfoForLinkTest returns the receptions of the given link test,
+// ordered by RSSI (best first).
+func GetRXInfoForLinkTest(db sqlx.Queryer, id string) ([]RXInfo, error) {
+	var out []RXInfo
+	err := sqlx.Select(db, &out, `
+		select *
+		from gateway_link_test_rx
+		where link_test_id = $1
+		order by rssi desc, mac`,
+		id,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return out, nil
+}
+
+// GetNeighbours returns the gateway neighbour matrix (as a list of links),
+// based on the latest link test of each transmitting gateway. When macs
+// is not empty, only the links transmitted by the given gateways are
+// returned.
+func GetNeighbours(db sqlx.Queryer, macs []lorawan.EUI64) ([]Neighbour, error) {
+	var macsB [][]byte
+	for i := range macs {
+		macsB = append(macsB, macs[i][:])
+	}
+
+	var out []Neighbour
+	err := sqlx.Select(db, &out, `
+		select
+			lt.mac as tx_mac,
+			rx.mac as rx_mac,
+			rx.rssi,
+			rx.snr,
+			lt.id as link_test_id,
+			lt.created_at as tested_at
+		from (
+			select distinct on (mac) *
+			from gateway_link_test
+			where
+				$1::bytea[] is null or mac = any($1)
+			order by mac, created_at desc
+		) lt
+		inner join gateway_link_test_rx rx
+			on rx.link_test_id = lt.id
+		order by
+			lt.mac,
+			rx.mac`,
+		pq.ByteaArray(macsB),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "select error")
+	}
+	return out, nil
+}
@@ -0,0 +1,141 @@
+package linktest
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+)
+
+func TestGetLinkTestID(t *testing.T) {
+	Convey("Given a set of tests", t, func() {
+		tests := []struct {
+			Name       string
+			MACPayload []byte
+			ExpectedID string
+			ExpectedOK bool
+		}{
+			{
+				Name:       "link test frame",
+				MACPayload: []byte{'L', 'T', 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+				ExpectedID: "01020304-0506-0708-090a-0b0c0d0e0f10",
+				ExpectedOK: true,
+			},
+			{
+				Name:       "invalid prefix",
+				MACPayload: []byte{'X', 'T', 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			},
+			{
+				Name:       "invalid length",
+				MACPayload: []byte{'L', 'T', 1, 2, 3, 4},
+			},
+		}
+
+		for _, tst := range tests {
+			Convey("Testing: "+tst.Name, func() {
+				id, ok := GetLinkTestID(tst.MACPayload)
+				So(ok, ShouldEqual, tst.ExpectedOK)
+				So(id, ShouldEqual, tst.ExpectedID)
+			})
+		}
+	})
+}
+
+func TestLinkTest(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	Convey("Given a clean database with three gateways", t, func() {
+		test.MustResetDB(db)
+		gwBackend := test.NewGatewayBackend()
+		common.Gateway = gwBackend
+
+		gws := []gateway.Gateway{
+			{MAC: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, Name: "gw-1"},
+			{MAC: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, Name: "gw-2"},
+			{MAC: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}, Name: "gw-3"},
+		}
+		for i := range gws {
+			So(gateway.CreateGateway(db, &gws[i]), ShouldBeNil)
+		}
+
+		Convey("When starting a link test", func() {
+			lt, err := StartLinkTest(db, gws[0].MAC, 0, 5)
+			So(err, ShouldBeNil)
+
+			Convey("Then the test frame has been sent to the gateway", func() {
+				txPacket := <-gwBackend.TXPacketChan
+				So(txPacket.TXInfo.MAC, ShouldEqual, gws[0].MAC)
+				So(txPacket.TXInfo.Frequency, ShouldEqual, common.Band.UplinkChannels[0].Frequency)
+				So(*txPacket.TXInfo.IPol, ShouldBeFalse)
+				So(txPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.Proprietary)
+
+				pl, ok := txPacket.PHYPayload.MACPayload.(*lorawan.DataPayload)
+				So(ok, ShouldBeTrue)
+				id, ok := GetLinkTestID(pl.Bytes)
+				So(ok, ShouldBeTrue)
+				So(id, ShouldEqual, lt.ID)
+			})
+
+			Convey("When the test frame is received by the other gateways", func() {
+				So(HandleRXInfoSet(db, lt.ID, []gw.RXInfo{
+					{MAC: gws[1].MAC, RSSI: -100, LoRaSNR: 3},
+					{MAC: gws[1].MAC, RSSI: -90, LoRaSNR: 1},
+					{MAC: gws[2].MAC, RSSI: -120, LoRaSNR: -10},
+					{MAC: lorawan.EUI64{4, 4, 4, 4, 4, 4, 4, 4}, RSSI: -80, LoRaSNR: 5},
+				}), ShouldBeNil)
+
+				Convey("Then the receptions have been stored", func() {
+					rxInfo, err := GetRXInfoForLinkTest(db, lt.ID)
+					So(err, ShouldBeNil)
+					So(rxInfo, ShouldHaveLength, 2)
+					So(rxInfo[0].MAC, ShouldEqual, gws[1].MAC)
+					So(rxInfo[0].RSSI, ShouldEqual, -90)
+					So(rxInfo[0].SNR, ShouldEqual, 3)
+					So(rxInfo[1].MAC, ShouldEqual, gws[2].MAC)
+				})
+
+				Convey("Then GetNeighbours returns the links of the gateway", func() {
+					neighbours, err := GetNeighbours(db, nil)
+					So(err, ShouldBeNil)
+					So(neighbours, ShouldHaveLength, 2)
+					So(neighbours[0].TXMAC, ShouldEqual, gws[0].MAC)
+					So(neighbours[0].RXMAC, ShouldEqual, gws[1].MAC)
+					So(neighbours[0].LinkTestID, ShouldEqual, lt.ID)
+
+					neighbours, err = GetNeighbours(db, []lorawan.EUI64{gws[1].MAC})
+					So(err, ShouldBeNil)
+					So(neighbours, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("Then a timed out test frame is ignored", func() {
+				_, err := db.Exec("update gateway_link_test set created_at = $1", time.Now().Add(-2*ReceiveTimeout))
+				So(err, ShouldBeNil)
+
+				So(HandleRXInfoSet(db, lt.ID, []gw.RXInfo{
+					{MAC: gws[1].MAC, RSSI: -100, LoRaSNR: 3},
+				}), ShouldBeNil)
+
+				rxInfo, err := GetRXInfoForLinkTest(db, lt.ID)
+				So(err, ShouldBeNil)
+				So(rxInfo, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Then starting a link test with an invalid data-rate returns an error", func() {
+			_, err := StartLinkTest(db, gws[0].MAC, 0, 100)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package roaming
+
+import (
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend"
+	"github.com/brocaar/lorawan"
+	lwbackend "github.com/brocaar/lorawan/backend"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Backend wraps a gateway backend. Downlinks for gateways of a roaming
+// partner (see SaveGatewayRoute) are sent to the fNS using a XmitDataReq,
+// all other downlinks are sent using the wrapped gateway backend.
+type Backend struct {
+	backend.Gateway
+
+	redisPool *redis.Pool
+	pool      Pool
+	band      band.Band
+}
+
+// NewBackend creates a new Backend.
+func NewBackend(gateway backend.Gateway, redisPool *redis.Pool, pool Pool, b band.Band) *Backend {
+	return &Backend{
+		Gateway:   gateway,
+		redisPool: redisPool,
+		pool:      pool,
+		band:      b,
+	}
+}
+
+// SendTXPacket sends the given packet to the gateway.
+func (b *Backend) SendTXPacket(txPacket gw.TXPacket) error {
+	macPL, ok := txPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return b.Gateway.SendTXPacket(txPacket)
+	}
+
+	route, err := GetGatewayRoute(b.redisPool, txPacket.TXInfo.MAC, macPL.FHDR.DevAddr)
+	if err != nil {
+		if errors.Cause(err) == ErrDoesNotExist {
+			return b.Gateway.SendTXPacket(txPacket)
+		}
+		return errors.Wrap(err, "get gateway route error")
+	}
+
+	client, err := b.pool.Get(route.NetID)
+	if err != nil {
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	dr, err := b.band.GetDataRate(txPacket.TXInfo.DataRate)
+	if err != nil {
+		return errors.Wrap(err, "get data-rate error")
+	}
+
+	phyB, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	dlMetaData := lwbackend.DLMetaData{
+		FCntDown:   int(macPL.FHDR.FCnt),
+		Confirmed:  txPacket.PHYPayload.MHDR.MType == lorawan.ConfirmedDataDown,
+		DLFreq1:    float64(txPacket.TXInfo.Frequency) / 1000000,
+		DataRate1:  dr,
+		ClassMode:  "A",
+		FNSULToken: route.FNSULToken,
+		GWInfo: []lwbackend.GWInfoElement{
+			{
+				ID:        lwbackend.HEXBytes(txPacket.TXInfo.MAC[:]),
+				ULToken:   route.ULToken,
+				DLAllowed: true,
+			},
+		},
+	}
+	if macPL.FPort != nil {
+		dlMetaData.FPort = int(*macPL.FPort)
+	}
+
+	if txPacket.TXInfo.Immediately {
+		dlMetaData.ClassMode = "C"
+	} else {
+		// as the gateway timestamp of a roamed uplink is unknown (it is
+		// set to 0), the timestamp holds the delay after the uplink
+		dlMetaData.RXDelay1 = int(time.Duration(txPacket.TXInfo.Timestamp) * time.Microsecond / time.Second)
+	}
+
+	_, err = client.XmitDataReq(context.Background(), lwbackend.XmitDataReqPayload{
+		PHYPayload: lwbackend.HEXBytes(phyB),
+		DLMetaData: &dlMetaData,
+	})
+	if err != nil {
+		return errors.Wrap(err, "xmit data request error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":   route.NetID,
+		"mac":      txPacket.TXInfo.MAC,
+		"dev_addr": macPL.FHDR.DevAddr,
+	}).Info("roaming: downlink sent to fNS")
+
+	return nil
+}
@@ -0,0 +1,364 @@
+package roaming
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// defaultRequestTimeout is used when the context passed to the client does
+// not have a deadline.
+const defaultRequestTimeout = 5 * time.Second
+
+// Client defines the roaming client interface.
+type Client interface {
+	// NetID returns the NetID of the roaming partner.
+	NetID() lorawan.NetID
+
+	// ClientCN returns the common-name of the client certificate used by
+	// the roaming partner for connecting to the backend-interfaces api.
+	ClientCN() string
+
+	// HandlesJoinEUI returns true when the given JoinEUI is within one of
+	// the JoinEUI ranges of the roaming partner.
+	HandlesJoinEUI(joinEUI lorawan.EUI64) bool
+
+	// PRStartReq issues a passive-roaming start request. A wrapped NwkSKey
+	// is returned unwrapped (with an empty KEKLabel).
+	PRStartReq(ctx context.Context, pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error)
+
+	// XmitDataReq issues a transmit data request (uplink or downlink).
+	XmitDataReq(ctx context.Context, pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error)
+
+	// ProfileReq issues a profile request.
+	ProfileReq(ctx context.Context, pl backend.ProfileReqPayload) (backend.ProfileAnsPayload, error)
+
+	// HRStartReq issues a handover-roaming start request. Wrapped keys are
+	// returned unwrapped (with an empty KEKLabel).
+	HRStartReq(ctx context.Context, pl backend.HRStartReqPayload) (backend.HRStartAnsPayload, error)
+
+	// HRStopReq issues a handover-roaming stop request.
+	HRStopReq(ctx context.Context, pl backend.HRStopReqPayload) (backend.HRStopAnsPayload, error)
+}
+
+// PRStartAnsPayload overrides the key envelope fields of the
+// backend.PRStartAnsPayload, so that it is able to hold wrapped keys.
+type PRStartAnsPayload struct {
+	backend.PRStartAnsPayload
+	FNwkSIntKey *kek.KeyEnvelope `json:"FNwkSIntKey,omitempty"`
+	NwkSKey     *kek.KeyEnvelope `json:"NwkSKey,omitempty"`
+}
+
+// HRStartAnsPayload overrides the key envelope fields of the
+// backend.HRStartAnsPayload, so that it is able to hold wrapped keys.
+type HRStartAnsPayload struct {
+	backend.HRStartAnsPayload
+	SNwkSIntKey *kek.KeyEnvelope `json:"SNwkSIntKey,omitempty"`
+	FNwkSIntKey *kek.KeyEnvelope `json:"FNwkSIntKey,omitempty"`
+	NwkSEncKey  *kek.KeyEnvelope `json:"NwkSEncKey,omitempty"`
+	NwkSKey     *kek.KeyEnvelope `json:"NwkSKey,omitempty"`
+}
+
+// keyEnvelope maps a (wrapped) key of an answer to its unwrapped
+// counterpart.
+type keyEnvelope struct {
+	name string
+	in   *kek.KeyEnvelope
+	out  **backend.KeyEnvelope
+}
+
+type client struct {
+	netID      lorawan.NetID
+	clientCN   string
+	joinEUIs   []JoinEUIRange
+	senderID   lorawan.NetID
+	server     string
+	httpClient *http.Client
+	kekStore   *kek.Store
+}
+
+// NewClient creates a new roaming client for the given agreement. The
+// senderID must be set to the NetID of this network-server. The kekStore
+// is used for unwrapping the keys (optional, only needed when the roaming
+// partner wraps the keys).
+func NewClient(ac AgreementConfig, senderID lorawan.NetID, kekStore *kek.Store) (Client, error) {
+	log.WithFields(log.Fields{
+		"net_id":    ac.NetID,
+		"server":    ac.Server,
+		"ca_cert":   ac.CACert,
+		"tls_cert":  ac.TLSCert,
+		"tls_key":   ac.TLSKey,
+		"client_cn": ac.ClientCN,
+	}).Info("configuring roaming agreement")
+
+	c := client{
+		netID:    ac.NetID,
+		clientCN: ac.ClientCN,
+		joinEUIs: ac.JoinEUIRanges,
+		senderID: senderID,
+		server:   ac.Server,
+		httpClient: &http.Client{
+			Timeout: defaultRequestTimeout,
+		},
+		kekStore: kekStore,
+	}
+
+	if c.clientCN == "" {
+		c.clientCN = ac.NetID.String()
+	}
+
+	if ac.CACert == "" && ac.TLSCert == "" && ac.TLSKey == "" {
+		return &c, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(ac.TLSCert, ac.TLSKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "load x509 keypair error")
+	}
+
+	rawCACert, err := ioutil.ReadFile(ac.CACert)
+	if err != nil {
+		return nil, errors.Wrap(err, "load ca cert error")
+	}
+
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(rawCACert) {
+		return nil, errors.New("append ca cert to pool error")
+	}
+
+	c.httpClient.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      caCertPool,
+		},
+	}
+
+	return &c, nil
+}
+
+// NetID returns the NetID of the roaming partner.
+func (c *client) NetID() lorawan.NetID {
+	return c.netID
+}
+
+// ClientCN returns the common-name of the client certificate used by the
+// roaming partner.
+func (c *client) ClientCN() string {
+	return c.clientCN
+}
+
+// HandlesJoinEUI returns true when the JoinEUI is within one of the
+// JoinEUI ranges of the roaming partner.
+func (c *client) HandlesJoinEUI(joinEUI lorawan.EUI64) bool {
+	for _, r := range c.joinEUIs {
+		if r.Contains(joinEUI) {
+			return true
+		}
+	}
+	return false
+}
+
+// PRStartReq issues a passive-roaming start request.
+func (c *client) PRStartReq(ctx context.Context, pl backend.PRStartReqPayload) (backend.PRStartAnsPayload, error) {
+	var ansPL PRStartAnsPayload
+
+	var err error
+	pl.BasePayload, err = c.getBasePayload(backend.PRStartReq)
+	if err != nil {
+		return ansPL.PRStartAnsPayload, err
+	}
+
+	if err := c.request(ctx, pl, &ansPL); err != nil {
+		return ansPL.PRStartAnsPayload, err
+	}
+
+	ans := ansPL.PRStartAnsPayload
+	if err := checkResult(ans.Result); err != nil {
+		return ans, err
+	}
+
+	err = c.unwrapKeys([]keyEnvelope{
+		{"FNwkSIntKey", ansPL.FNwkSIntKey, &ans.FNwkSIntKey},
+		{"NwkSKey", ansPL.NwkSKey, &ans.NwkSKey},
+	})
+	if err != nil {
+		return ans, err
+	}
+
+	return ans, nil
+}
+
+// XmitDataReq issues a transmit data request.
+func (c *client) XmitDataReq(ctx context.Context, pl backend.XmitDataReqPayload) (backend.XmitDataAnsPayload, error) {
+	var ans backend.XmitDataAnsPayload
+
+	var err error
+	pl.BasePayload, err = c.getBasePayload(backend.XmitDataReq)
+	if err != nil {
+		return ans, err
+	}
+
+	if err := c.request(ctx, pl, &ans); err != nil {
+		return ans, err
+	}
+
+	return ans, checkResult(ans.Result)
+}
+
+// ProfileReq issues a profile request.
+func (c *client) ProfileReq(ctx context.Context, pl backend.ProfileReqPayload) (backend.ProfileAnsPayload, error) {
+	var ans backend.ProfileAnsPayload
+
+	var err error
+	pl.BasePayload, err = c.getBasePayload(backend.ProfileReq)
+	if err != nil {
+		return ans, err
+	}
+
+	if err := c.request(ctx, pl, &ans); err != nil {
+		return ans, err
+	}
+
+	return ans, checkResult(ans.Result)
+}
+
+// HRStartReq issues a handover-roaming start request.
+func (c *client) HRStartReq(ctx context.Context, pl backend.HRStartReqPayload) (backend.HRStartAnsPayload, error) {
+	var ansPL HRStartAnsPayload
+
+	var err error
+	pl.BasePayload, err = c.getBasePayload(backend.HRStartReq)
+	if err != nil {
+		return ansPL.HRStartAnsPayload, err
+	}
+
+	if err := c.request(ctx, pl, &ansPL); err != nil {
+		return ansPL.HRStartAnsPayload, err
+	}
+
+	ans := ansPL.HRStartAnsPayload
+	if err := checkResult(ans.Result); err != nil {
+		return ans, err
+	}
+
+	err = c.unwrapKeys([]keyEnvelope{
+		{"SNwkSIntKey", ansPL.SNwkSIntKey, &ans.SNwkSIntKey},
+		{"FNwkSIntKey", ansPL.FNwkSIntKey, &ans.FNwkSIntKey},
+		{"NwkSEncKey", ansPL.NwkSEncKey, &ans.NwkSEncKey},
+		{"NwkSKey", ansPL.NwkSKey, &ans.NwkSKey},
+	})
+	if err != nil {
+		return ans, err
+	}
+
+	return ans, nil
+}
+
+// HRStopReq issues a handover-roaming stop request.
+func (c *client) HRStopReq(ctx context.Context, pl backend.HRStopReqPayload) (backend.HRStopAnsPayload, error) {
+	var ans backend.HRStopAnsPayload
+
+	var err error
+	pl.BasePayload, err = c.getBasePayload(backend.HRStopReq)
+	if err != nil {
+		return ans, err
+	}
+
+	if err := c.request(ctx, pl, &ans); err != nil {
+		return ans, err
+	}
+
+	return ans, checkResult(ans.Result)
+}
+
+// unwrapKeys unwraps the given (wrapped) keys.
+func (c *client) unwrapKeys(keys []keyEnvelope) error {
+	for _, k := range keys {
+		if k.in == nil {
+			continue
+		}
+
+		key, err := c.kekStore.UnwrapKeyEnvelope(*k.in)
+		if err != nil {
+			return errors.Wrapf(err, "unwrap %s error", k.name)
+		}
+		*k.out = &backend.KeyEnvelope{AESKey: key}
+	}
+	return nil
+}
+
+func (c *client) getBasePayload(mt backend.MessageType) (backend.BasePayload, error) {
+	randomBytes := make([]byte, 4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return backend.BasePayload{}, errors.Wrap(err, "read random bytes error")
+	}
+
+	return backend.BasePayload{
+		ProtocolVersion: backend.ProtocolVersion1_0,
+		SenderID:        c.senderID.String(),
+		ReceiverID:      c.netID.String(),
+		TransactionID:   binary.LittleEndian.Uint32(randomBytes),
+		MessageType:     mt,
+	}, nil
+}
+
+func (c *client) request(ctx context.Context, pl, ans interface{}) error {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return errors.Wrap(err, "marshal request error")
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequest("POST", c.server, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "new request error")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "http post error")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
+		return errors.Errorf("expected 2xx response, got: %d (%s)", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(ans); err != nil {
+		return errors.Wrap(err, "unmarshal response error")
+	}
+
+	return nil
+}
+
+func checkResult(res backend.Result) error {
+	if res.ResultCode != backend.Success {
+		return &ResultError{
+			ResultCode:  res.ResultCode,
+			Description: res.Description,
+		}
+	}
+	return nil
+}
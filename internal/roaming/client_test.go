@@ -0,0 +1,104 @@
+package roaming
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestClient(t *testing.T) {
+	Convey("Given a KEK store and a test roaming partner", t, func() {
+		kekStore := kek.NewStore()
+		So(kekStore.Add("lora-ns", []byte{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}), ShouldBeNil)
+
+		var request backend.BasePayload
+		var response string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewDecoder(r.Body).Decode(&request)
+			w.Write([]byte(response))
+		}))
+		defer server.Close()
+
+		c, err := NewClient(AgreementConfig{NetID: lorawan.NetID{1, 2, 3}, Server: server.URL}, lorawan.NetID{3, 2, 1}, kekStore)
+		So(err, ShouldBeNil)
+
+		Convey("When the roaming partner returns a wrapped NwkSKey", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "DevEUI": "0102030405060708", "Lifetime": 60, "FCntUp": 10, "NwkSKey": {"KEKLabel": "lora-ns", "AESKey": "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"}}`
+
+			Convey("Then PRStartReq returns the unwrapped NwkSKey", func() {
+				ans, err := c.PRStartReq(context.Background(), backend.PRStartReqPayload{})
+				So(err, ShouldBeNil)
+				So(*ans.DevEUI, ShouldEqual, lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8})
+				So(*ans.Lifetime, ShouldEqual, 60)
+				So(*ans.FCntUp, ShouldEqual, 10)
+				So(ans.NwkSKey, ShouldResemble, &backend.KeyEnvelope{
+					AESKey: lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+				})
+			})
+
+			Convey("Then the request has the expected base payload", func() {
+				_, err := c.PRStartReq(context.Background(), backend.PRStartReqPayload{})
+				So(err, ShouldBeNil)
+				So(request.MessageType, ShouldEqual, backend.PRStartReq)
+				So(request.SenderID, ShouldEqual, "030201")
+				So(request.ReceiverID, ShouldEqual, "010203")
+			})
+		})
+
+		Convey("When the roaming partner returns a HRStartAns with a wrapped NwkSKey", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "PHYPayload": "01020304", "Lifetime": 0, "ServiceProfile": {"HRAllowed": true}, "NwkSKey": {"KEKLabel": "lora-ns", "AESKey": "1fa68b0a8112b447aef34bd8fb5a7b829d3e862371d2cfe5"}}`
+
+			Convey("Then HRStartReq returns the unwrapped NwkSKey", func() {
+				ans, err := c.HRStartReq(context.Background(), backend.HRStartReqPayload{})
+				So(err, ShouldBeNil)
+				So(request.MessageType, ShouldEqual, backend.HRStartReq)
+				So(ans.PHYPayload, ShouldResemble, backend.HEXBytes{1, 2, 3, 4})
+				So(ans.ServiceProfile.HRAllowed, ShouldBeTrue)
+				So(ans.NwkSKey, ShouldResemble, &backend.KeyEnvelope{
+					AESKey: lorawan.AES128Key{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff},
+				})
+			})
+		})
+
+		Convey("When the roaming partner returns a ProfileAns", func() {
+			response = `{"Result": {"ResultCode": "Success"}, "DeviceProfile": {"DeviceProfileID": "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d"}, "RoamingActivationType": "Handover"}`
+
+			Convey("Then ProfileReq returns the device-profile and roaming activation type", func() {
+				ans, err := c.ProfileReq(context.Background(), backend.ProfileReqPayload{})
+				So(err, ShouldBeNil)
+				So(request.MessageType, ShouldEqual, backend.ProfileReq)
+				So(ans.DeviceProfile.DeviceProfileID, ShouldEqual, "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d")
+				So(*ans.RoamingActivationType, ShouldEqual, backend.Handover)
+			})
+		})
+
+		Convey("When the roaming partner returns a ResultCode other than Success", func() {
+			response = `{"Result": {"ResultCode": "DevRoamingDisallowed", "Description": "not allowed"}}`
+
+			Convey("Then PRStartReq returns a ResultError", func() {
+				_, err := c.PRStartReq(context.Background(), backend.PRStartReqPayload{})
+				So(err, ShouldResemble, &ResultError{ResultCode: backend.DevRoamingDisallowed, Description: "not allowed"})
+			})
+
+			Convey("Then XmitDataReq returns a ResultError", func() {
+				_, err := c.XmitDataReq(context.Background(), backend.XmitDataReqPayload{})
+				So(err, ShouldResemble, &ResultError{ResultCode: backend.DevRoamingDisallowed, Description: "not allowed"})
+				So(request.MessageType, ShouldEqual, backend.XmitDataReq)
+			})
+
+			Convey("Then HRStopReq returns a ResultError", func() {
+				_, err := c.HRStopReq(context.Background(), backend.HRStopReqPayload{})
+				So(err, ShouldResemble, &ResultError{ResultCode: backend.DevRoamingDisallowed, Description: "not allowed"})
+				So(request.MessageType, ShouldEqual, backend.HRStopReq)
+			})
+		})
+	})
+}
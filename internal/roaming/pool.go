@@ -0,0 +1,72 @@
+package roaming
+
+import (
+	"github.com/brocaar/loraserver/internal/devaddr"
+	"github.com/brocaar/lorawan"
+)
+
+// Pool defines the roaming client pool interface.
+type Pool interface {
+	// Get returns the client for the roaming partner with the given NetID.
+	// ErrNoAgreement is returned when no agreement exists.
+	Get(netID lorawan.NetID) (Client, error)
+
+	// GetForDevAddr returns the client for the roaming partner to which
+	// the given DevAddr belongs (matched by the DevAddr prefix of the
+	// NetID). ErrNoAgreement is returned when no agreement exists.
+	GetForDevAddr(devAddr lorawan.DevAddr) (Client, error)
+
+	// GetForJoinEUI returns the clients of the roaming partners handling
+	// the given JoinEUI (see Client.HandlesJoinEUI).
+	GetForJoinEUI(joinEUI lorawan.EUI64) []Client
+
+	// Clients returns all the clients of the pool.
+	Clients() []Client
+}
+
+type pool struct {
+	clients []Client
+}
+
+// NewPool creates a new roaming client pool for the given clients.
+func NewPool(clients ...Client) Pool {
+	return &pool{
+		clients: clients,
+	}
+}
+
+// Get returns the client for the given NetID.
+func (p *pool) Get(netID lorawan.NetID) (Client, error) {
+	for _, c := range p.clients {
+		if c.NetID() == netID {
+			return c, nil
+		}
+	}
+	return nil, ErrNoAgreement
+}
+
+// GetForDevAddr returns the client for the given DevAddr.
+func (p *pool) GetForDevAddr(devAddr lorawan.DevAddr) (Client, error) {
+	for _, c := range p.clients {
+		if devaddr.IsNetIDMember(c.NetID(), devAddr) {
+			return c, nil
+		}
+	}
+	return nil, ErrNoAgreement
+}
+
+// GetForJoinEUI returns the clients handling the given JoinEUI.
+func (p *pool) GetForJoinEUI(joinEUI lorawan.EUI64) []Client {
+	var out []Client
+	for _, c := range p.clients {
+		if c.HandlesJoinEUI(joinEUI) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Clients returns all the clients.
+func (p *pool) Clients() []Client {
+	return p.clients
+}
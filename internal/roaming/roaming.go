@@ -0,0 +1,172 @@
+// Package roaming implements the (LoRaWAN backend-interfaces) passive and
+// handover roaming. As forwarding network-server (fNS), uplinks for
+// DevAddrs of a roaming partner are forwarded to the serving network-server
+// (sNS) and downlinks received from the sNS are sent through the local
+// gateways. As sNS, downlinks for roamed uplinks are sent back through the
+// fNS. With handover roaming, the device-session is transferred to the sNS
+// of the visited network, the home network-server (hNS) remains the anchor
+// towards the application-server and join-server.
+package roaming
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// Roaming errors.
+var (
+	ErrNoAgreement       = errors.New("no roaming agreement")
+	ErrUnknownDevAddr    = errors.New("unknown DevAddr")
+	ErrUnknownDevEUI     = errors.New("unknown DevEUI")
+	ErrRoamingDisallowed = errors.New("roaming is not allowed for the device")
+	ErrDoesNotExist      = errors.New("object does not exist")
+	ErrMalformedRequest  = errors.New("malformed request")
+	ErrJoinReqFailed     = errors.New("join-request failed")
+)
+
+// ResultError is returned when the roaming partner returns a non-Success
+// result.
+type ResultError struct {
+	ResultCode  backend.ResultCode
+	Description string
+}
+
+// Error implements the error interface.
+func (e *ResultError) Error() string {
+	return fmt.Sprintf("roaming partner returned result %s: %s", e.ResultCode, e.Description)
+}
+
+// GetResult returns the backend.Result for the given error. It returns
+// false when the error does not map to a result (e.g. storage errors).
+func GetResult(err error) (backend.Result, bool) {
+	var code backend.ResultCode
+
+	switch errors.Cause(err) {
+	case ErrNoAgreement:
+		code = backend.NoRoamingAgreement
+	case ErrUnknownDevAddr:
+		code = backend.UnknownDevAddr
+	case ErrUnknownDevEUI:
+		code = backend.UnknownDevEUI
+	case ErrRoamingDisallowed:
+		code = backend.DevRoamingDisallowed
+	case ErrMalformedRequest:
+		code = backend.MalformedRequest
+	case ErrJoinReqFailed:
+		code = backend.JoinReqFailed
+	default:
+		return backend.Result{}, false
+	}
+
+	return backend.Result{
+		ResultCode:  code,
+		Description: err.Error(),
+	}, true
+}
+
+// AgreementConfig defines the configuration of a roaming agreement.
+type AgreementConfig struct {
+	NetID   lorawan.NetID
+	Server  string
+	CACert  string
+	TLSCert string
+	TLSKey  string
+
+	// ClientCN holds the common-name of the client certificate used by the
+	// roaming partner for connecting to the backend-interfaces api. When
+	// empty, the NetID (HEX encoded) is expected as common-name.
+	ClientCN string
+
+	// JoinEUIRanges holds the JoinEUIs of the devices of the roaming
+	// partner. The partner is only asked to activate unknown devices
+	// (handover roaming) of which the JoinEUI is within one of the ranges.
+	JoinEUIRanges []JoinEUIRange
+}
+
+// JoinEUIRange defines a JoinEUI range (both start and end are inclusive).
+type JoinEUIRange struct {
+	Start lorawan.EUI64
+	End   lorawan.EUI64
+}
+
+// ParseJoinEUIRange parses a range in the format START-END (hex encoded).
+func ParseJoinEUIRange(s string) (JoinEUIRange, error) {
+	var r JoinEUIRange
+
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return r, fmt.Errorf("JoinEUI range '%s' must be in the format START-END", s)
+	}
+	if err := r.Start.UnmarshalText([]byte(parts[0])); err != nil {
+		return r, errors.Wrap(err, "parse start error")
+	}
+	if err := r.End.UnmarshalText([]byte(parts[1])); err != nil {
+		return r, errors.Wrap(err, "parse end error")
+	}
+	if bytes.Compare(r.Start[:], r.End[:]) > 0 {
+		return r, fmt.Errorf("start of JoinEUI range '%s' must be before end", s)
+	}
+
+	return r, nil
+}
+
+// Contains returns true when the given JoinEUI is within the range.
+func (r JoinEUIRange) Contains(joinEUI lorawan.EUI64) bool {
+	return bytes.Compare(joinEUI[:], r.Start[:]) >= 0 && bytes.Compare(joinEUI[:], r.End[:]) <= 0
+}
+
+// ParseAgreementConfig parses a roaming agreement in the format below. The
+// TLS, client_cn and join_eui options are optional, the join_eui option
+// (a START-END range) can be repeated.
+//
+//	NETID=SERVER[;ca_cert=FILE;tls_cert=FILE;tls_key=FILE;client_cn=NAME;join_eui=START-END]
+//
+// Example: 010203=https://ns.example.com:8005
+func ParseAgreementConfig(s string) (AgreementConfig, error) {
+	var ac AgreementConfig
+
+	parts := strings.Split(s, ";")
+	kv := strings.SplitN(parts[0], "=", 2)
+	if len(kv) != 2 || kv[1] == "" {
+		return ac, fmt.Errorf("roaming agreement '%s' must be in the format NETID=SERVER", s)
+	}
+	ac.Server = kv[1]
+
+	if err := ac.NetID.UnmarshalText([]byte(kv[0])); err != nil {
+		return ac, errors.Wrapf(err, "parse netid of roaming agreement '%s' error", s)
+	}
+
+	for _, opt := range parts[1:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			return ac, fmt.Errorf("invalid option '%s' in roaming agreement '%s'", opt, s)
+		}
+
+		switch kv[0] {
+		case "ca_cert":
+			ac.CACert = kv[1]
+		case "tls_cert":
+			ac.TLSCert = kv[1]
+		case "tls_key":
+			ac.TLSKey = kv[1]
+		case "client_cn":
+			ac.ClientCN = kv[1]
+		case "join_eui":
+			r, err := ParseJoinEUIRange(kv[1])
+			if err != nil {
+				return ac, errors.Wrapf(err, "parse join_eui of roaming agreement '%s' error", s)
+			}
+			ac.JoinEUIRanges = append(ac.JoinEUIRanges, r)
+		default:
+			return ac, fmt.Errorf("unknown option '%s' in roaming agreement '%s'", kv[0], s)
+		}
+	}
+
+	return ac, nil
+}
@@ -0,0 +1,150 @@
+package roaming
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestParseAgreementConfig(t *testing.T) {
+	Convey("Given a set of test agreements", t, func() {
+		tests := []struct {
+			Agreement string
+			Expected  AgreementConfig
+			Error     bool
+		}{
+			{
+				Agreement: "010203=https://ns.example.com:8005",
+				Expected: AgreementConfig{
+					NetID:  lorawan.NetID{1, 2, 3},
+					Server: "https://ns.example.com:8005",
+				},
+			},
+			{
+				Agreement: "010203=https://ns.example.com;ca_cert=ca.pem;tls_cert=cert.pem;tls_key=key.pem",
+				Expected: AgreementConfig{
+					NetID:   lorawan.NetID{1, 2, 3},
+					Server:  "https://ns.example.com",
+					CACert:  "ca.pem",
+					TLSCert: "cert.pem",
+					TLSKey:  "key.pem",
+				},
+			},
+			{
+				Agreement: "010203=https://ns.example.com;client_cn=ns.example.com",
+				Expected: AgreementConfig{
+					NetID:    lorawan.NetID{1, 2, 3},
+					Server:   "https://ns.example.com",
+					ClientCN: "ns.example.com",
+				},
+			},
+			{
+				Agreement: "010203=https://ns.example.com;join_eui=0102030400000000-01020304ffffffff;join_eui=0505050505050505-0505050505050505",
+				Expected: AgreementConfig{
+					NetID:  lorawan.NetID{1, 2, 3},
+					Server: "https://ns.example.com",
+					JoinEUIRanges: []JoinEUIRange{
+						{Start: lorawan.EUI64{1, 2, 3, 4, 0, 0, 0, 0}, End: lorawan.EUI64{1, 2, 3, 4, 255, 255, 255, 255}},
+						{Start: lorawan.EUI64{5, 5, 5, 5, 5, 5, 5, 5}, End: lorawan.EUI64{5, 5, 5, 5, 5, 5, 5, 5}},
+					},
+				},
+			},
+			{Agreement: "010203=https://ns.example.com;join_eui=0102030400000000", Error: true},
+			{Agreement: "010203=https://ns.example.com;join_eui=0200000000000000-0100000000000000", Error: true},
+			{Agreement: "010203", Error: true},
+			{Agreement: "0102=https://ns.example.com", Error: true},
+			{Agreement: "010203=https://ns.example.com;foo=bar", Error: true},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Agreement, i), func() {
+				ac, err := ParseAgreementConfig(test.Agreement)
+				if test.Error {
+					So(err, ShouldNotBeNil)
+					return
+				}
+				So(err, ShouldBeNil)
+				So(ac, ShouldResemble, test.Expected)
+			})
+		}
+	})
+}
+
+func TestPool(t *testing.T) {
+	Convey("Given a pool with two roaming clients", t, func() {
+		c1, err := NewClient(AgreementConfig{
+			NetID:  lorawan.NetID{0, 0, 1},
+			Server: "http://ns1",
+			JoinEUIRanges: []JoinEUIRange{
+				{Start: lorawan.EUI64{1, 0, 0, 0, 0, 0, 0, 0}, End: lorawan.EUI64{1, 255, 255, 255, 255, 255, 255, 255}},
+			},
+		}, lorawan.NetID{0, 0, 3}, nil)
+		So(err, ShouldBeNil)
+		c2, err := NewClient(AgreementConfig{NetID: lorawan.NetID{0, 0, 2}, Server: "http://ns2"}, lorawan.NetID{0, 0, 3}, nil)
+		So(err, ShouldBeNil)
+
+		p := NewPool(c1, c2)
+
+		Convey("Then Get returns the client matching the NetID", func() {
+			c, err := p.Get(lorawan.NetID{0, 0, 2})
+			So(err, ShouldBeNil)
+			So(c, ShouldEqual, c2)
+
+			_, err = p.Get(lorawan.NetID{0, 0, 4})
+			So(err, ShouldEqual, ErrNoAgreement)
+		})
+
+		Convey("Then GetForDevAddr returns the client matching the DevAddr prefix", func() {
+			c, err := p.GetForDevAddr(lorawan.DevAddr{0x02, 0x01, 0x02, 0x03})
+			So(err, ShouldBeNil)
+			So(c, ShouldEqual, c1)
+
+			_, err = p.GetForDevAddr(lorawan.DevAddr{0x08, 0x01, 0x02, 0x03})
+			So(err, ShouldEqual, ErrNoAgreement)
+		})
+
+		Convey("Then GetForJoinEUI returns the clients handling the JoinEUI", func() {
+			So(p.GetForJoinEUI(lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}), ShouldResemble, []Client{c1})
+			So(p.GetForJoinEUI(lorawan.EUI64{2, 0, 0, 0, 0, 0, 0, 0}), ShouldHaveLength, 0)
+		})
+
+		Convey("Then ClientCN defaults to the NetID of the roaming partner", func() {
+			So(c1.ClientCN(), ShouldEqual, "000001")
+		})
+
+		Convey("Then Clients returns all the clients", func() {
+			So(p.Clients(), ShouldResemble, []Client{c1, c2})
+		})
+	})
+}
+
+func TestGetResult(t *testing.T) {
+	Convey("Given a set of test errors", t, func() {
+		tests := []struct {
+			Error    error
+			Expected backend.ResultCode
+			OK       bool
+		}{
+			{ErrNoAgreement, backend.NoRoamingAgreement, true},
+			{ErrUnknownDevAddr, backend.UnknownDevAddr, true},
+			{ErrUnknownDevEUI, backend.UnknownDevEUI, true},
+			{ErrRoamingDisallowed, backend.DevRoamingDisallowed, true},
+			{errors.Wrap(ErrMalformedRequest, "invalid data-rate"), backend.MalformedRequest, true},
+			{errors.Wrap(ErrJoinReqFailed, "DevNonce has already been used"), backend.JoinReqFailed, true},
+			{errors.New("redis error"), "", false},
+		}
+
+		for i, test := range tests {
+			Convey(fmt.Sprintf("Testing: %s [%d]", test.Error, i), func() {
+				res, ok := GetResult(test.Error)
+				So(ok, ShouldEqual, test.OK)
+				So(res.ResultCode, ShouldEqual, test.Expected)
+			})
+		}
+	})
+}
@@ -0,0 +1,370 @@
+package roaming
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// Templates used for generating Redis keys
+const (
+	sessionKeyTempl          = "lora:ns:roaming:session:%s"
+	gatewayRouteKeyTempl     = "lora:ns:roaming:gw:%s:%s"
+	handoverSessionKeyTempl  = "lora:ns:roaming:handover:%s"
+	handoverDownlinkKeyTempl = "lora:ns:roaming:handover:dl:%s"
+	ulTokenKeyTempl          = "lora:ns:roaming:ultoken:%s"
+)
+
+// ulTokenTTL defines the TTL of an (fNS) ULToken. It matches the TTL of the
+// gateway route stored by the sNS, so that Class-C downlinks can be sent
+// after the last roamed uplink.
+const ulTokenTTL = gatewayRouteTTL
+
+// gatewayRouteTTL defines the TTL of a gateway route. It must be long
+// enough for sending Class-C downlinks after the last roamed uplink.
+const gatewayRouteTTL = 24 * time.Hour
+
+// handoverDownlinkTTL defines the TTL of a handover-roaming downlink
+// waiting for the next uplink of the device.
+const handoverDownlinkTTL = time.Hour
+
+// Session holds the (fNS) state of a stateful passive-roaming session.
+// While the session is valid, the uplinks of the device are forwarded to
+// the sNS using XmitDataReq messages instead of PRStartReq messages.
+type Session struct {
+	NetID   lorawan.NetID
+	DevAddr lorawan.DevAddr
+	DevEUI  lorawan.EUI64
+	NwkSKey lorawan.AES128Key
+	FCntUp  uint32
+}
+
+// GatewayRoute holds the (sNS) route for sending downlinks through a
+// gateway of the fNS.
+type GatewayRoute struct {
+	NetID      lorawan.NetID
+	ULToken    backend.HEXBytes
+	FNSULToken backend.HEXBytes
+}
+
+// ULToken holds the (fNS) rx-info of a roamed uplink, needed for sending
+// the downlink. Only an opaque reference to the ULToken is sent to the
+// roaming partner (see SaveULToken).
+type ULToken struct {
+	NetID  lorawan.NetID
+	RXInfo gw.RXInfo
+}
+
+// HandoverSession holds the (hNS) state of a device that is served by the
+// sNS of a roaming partner (handover roaming). The frame-counters are
+// synchronized on every uplink received from the sNS.
+type HandoverSession struct {
+	NetID     lorawan.NetID
+	DevEUI    lorawan.EUI64
+	JoinEUI   lorawan.EUI64
+	DevAddr   lorawan.DevAddr
+	FCntUp    uint32
+	FCntDown  uint32
+	ExpiresAt time.Time // zero when the session does not expire
+}
+
+// HandoverDownlink holds a (sNS) downlink payload, received from the hNS,
+// waiting to be sent on the next uplink of the device.
+type HandoverDownlink struct {
+	FPort      uint8
+	FCntDown   uint32
+	Confirmed  bool
+	FRMPayload []byte
+}
+
+// SaveSession saves the given passive-roaming session, the session expires
+// after the given lifetime.
+func SaveSession(p *redis.Pool, s Session, lifetime time.Duration) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(sessionKeyTempl, s.DevAddr), int64(lifetime/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "save session error")
+	}
+	return nil
+}
+
+// updateSessionScript atomically replaces the session (KEYS[1] = session
+// key, ARGV[1] = session) while keeping its remaining lifetime. It returns
+// 0 when the session does not exist (anymore).
+var updateSessionScript = redis.NewScript(1, `
+	local ttl = redis.call("PTTL", KEYS[1])
+	if ttl <= 0 then
+		return 0
+	end
+	redis.call("PSETEX", KEYS[1], ttl, ARGV[1])
+	return 1
+`)
+
+// UpdateSession updates the given passive-roaming session without changing
+// its expiration. It returns ErrDoesNotExist when the session has expired.
+func UpdateSession(p *redis.Pool, s Session) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	ok, err := redis.Int(updateSessionScript.Do(c, fmt.Sprintf(sessionKeyTempl, s.DevAddr), buf.Bytes()))
+	if err != nil {
+		return errors.Wrap(err, "update session error")
+	}
+	if ok == 0 {
+		return ErrDoesNotExist
+	}
+	return nil
+}
+
+// GetSession returns the passive-roaming session for the given DevAddr.
+func GetSession(p *redis.Pool, devAddr lorawan.DevAddr) (Session, error) {
+	var s Session
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(sessionKeyTempl, devAddr)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return s, ErrDoesNotExist
+		}
+		return s, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&s); err != nil {
+		return s, errors.Wrap(err, "gob decode error")
+	}
+	return s, nil
+}
+
+// DeleteSession deletes the passive-roaming session for the given DevAddr.
+func DeleteSession(p *redis.Pool, devAddr lorawan.DevAddr) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(sessionKeyTempl, devAddr)); err != nil {
+		return errors.Wrap(err, "delete session error")
+	}
+	return nil
+}
+
+// SaveGatewayRoute saves the route for sending downlinks to the given
+// DevAddr through the given (fNS) gateway.
+func SaveGatewayRoute(p *redis.Pool, mac lorawan.EUI64, devAddr lorawan.DevAddr, r GatewayRoute) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(r); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(gatewayRouteKeyTempl, mac, devAddr), int64(gatewayRouteTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "save gateway route error")
+	}
+	return nil
+}
+
+// GetGatewayRoute returns the route for sending downlinks to the given
+// DevAddr through the given gateway. ErrDoesNotExist is returned when the
+// gateway is not a (fNS) roaming gateway.
+func GetGatewayRoute(p *redis.Pool, mac lorawan.EUI64, devAddr lorawan.DevAddr) (GatewayRoute, error) {
+	var r GatewayRoute
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(gatewayRouteKeyTempl, mac, devAddr)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return r, ErrDoesNotExist
+		}
+		return r, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&r); err != nil {
+		return r, errors.Wrap(err, "gob decode error")
+	}
+	return r, nil
+}
+
+// SaveHandoverSession saves the given handover-roaming session. The
+// session expires at its ExpiresAt, or after the given TTL when it does not
+// have an expiry. An expired session is not saved.
+func SaveHandoverSession(p *redis.Pool, s HandoverSession, ttl time.Duration) error {
+	if !s.ExpiresAt.IsZero() {
+		ttl = s.ExpiresAt.Sub(time.Now())
+		if ttl <= 0 {
+			return nil
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(s); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(handoverSessionKeyTempl, s.DevEUI), int64(ttl/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "save handover session error")
+	}
+	return nil
+}
+
+// GetHandoverSession returns the handover-roaming session for the given
+// DevEUI.
+func GetHandoverSession(p *redis.Pool, devEUI lorawan.EUI64) (HandoverSession, error) {
+	var s HandoverSession
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(handoverSessionKeyTempl, devEUI)))
+	if err != nil {
+		if err == redis.ErrNil {
+			return s, ErrDoesNotExist
+		}
+		return s, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&s); err != nil {
+		return s, errors.Wrap(err, "gob decode error")
+	}
+	return s, nil
+}
+
+// DeleteHandoverSession deletes the handover-roaming session for the given
+// DevEUI.
+func DeleteHandoverSession(p *redis.Pool, devEUI lorawan.EUI64) error {
+	c := p.Get()
+	defer c.Close()
+
+	if _, err := c.Do("DEL", fmt.Sprintf(handoverSessionKeyTempl, devEUI)); err != nil {
+		return errors.Wrap(err, "delete handover session error")
+	}
+	return nil
+}
+
+// SaveHandoverDownlink saves the given downlink for the given DevEUI. A
+// previously saved downlink is overwritten.
+func SaveHandoverDownlink(p *redis.Pool, devEUI lorawan.EUI64, dl HandoverDownlink) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(dl); err != nil {
+		return errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(handoverDownlinkKeyTempl, devEUI), int64(handoverDownlinkTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "save handover downlink error")
+	}
+	return nil
+}
+
+// GetAndDeleteHandoverDownlink returns and deletes the downlink for the
+// given DevEUI. ErrDoesNotExist is returned when there is no downlink.
+func GetAndDeleteHandoverDownlink(p *redis.Pool, devEUI lorawan.EUI64) (HandoverDownlink, error) {
+	var dl HandoverDownlink
+	key := fmt.Sprintf(handoverDownlinkKeyTempl, devEUI)
+
+	c := p.Get()
+	defer c.Close()
+
+	c.Send("MULTI")
+	c.Send("GET", key)
+	c.Send("DEL", key)
+	values, err := redis.Values(c.Do("EXEC"))
+	if err != nil {
+		return dl, errors.Wrap(err, "get and delete error")
+	}
+
+	val, err := redis.Bytes(values[0], nil)
+	if err != nil {
+		if err == redis.ErrNil {
+			return dl, ErrDoesNotExist
+		}
+		return dl, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&dl); err != nil {
+		return dl, errors.Wrap(err, "gob decode error")
+	}
+	return dl, nil
+}
+
+// SaveULToken saves the given ULToken and returns the (random) reference
+// which must be sent to the roaming partner as ULToken.
+func SaveULToken(p *redis.Pool, t ULToken) ([]byte, error) {
+	ref := make([]byte, 16)
+	if _, err := rand.Read(ref); err != nil {
+		return nil, errors.Wrap(err, "read random bytes error")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(t); err != nil {
+		return nil, errors.Wrap(err, "gob encode error")
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	_, err := c.Do("PSETEX", fmt.Sprintf(ulTokenKeyTempl, hex.EncodeToString(ref)), int64(ulTokenTTL/time.Millisecond), buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "save ul token error")
+	}
+	return ref, nil
+}
+
+// GetULToken returns the ULToken for the given reference. ErrDoesNotExist
+// is returned when the reference is unknown (or expired) or when it was
+// not sent to the roaming partner with the given NetID.
+func GetULToken(p *redis.Pool, netID lorawan.NetID, ref []byte) (ULToken, error) {
+	var t ULToken
+
+	c := p.Get()
+	defer c.Close()
+
+	val, err := redis.Bytes(c.Do("GET", fmt.Sprintf(ulTokenKeyTempl, hex.EncodeToString(ref))))
+	if err != nil {
+		if err == redis.ErrNil {
+			return t, ErrDoesNotExist
+		}
+		return t, errors.Wrap(err, "get error")
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(val)).Decode(&t); err != nil {
+		return t, errors.Wrap(err, "gob decode error")
+	}
+	if t.NetID != netID {
+		return ULToken{}, ErrDoesNotExist
+	}
+	return t, nil
+}
@@ -0,0 +1,476 @@
+package simulator
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/lorawan"
+)
+
+// maxFOptsLen defines the max. number of bytes of the FOpts field.
+const maxFOptsLen = 15
+
+// Activation defines the activation method of a device.
+type Activation string
+
+// Supported activation methods.
+const (
+	OTAA Activation = "otaa"
+	ABP  Activation = "abp"
+)
+
+// Link contains the radio conditions between a device and a gateway.
+type Link struct {
+	RSSI int     // RSSI in dBm
+	SNR  float64 // SNR in dB
+}
+
+// Device implements a virtual LoRaWAN 1.0 device.
+// The exported fields must be set before adding the device to the
+// simulator. After that, the Device methods must be used to read the
+// device state.
+type Device struct {
+	sync.Mutex
+
+	DevEUI     lorawan.EUI64
+	AppEUI     lorawan.EUI64
+	AppKey     lorawan.AES128Key // used for OTAA
+	Activation Activation
+
+	// ABP session (set on join-accept for OTAA devices)
+	DevAddr  lorawan.DevAddr
+	NwkSKey  lorawan.AES128Key
+	AppSKey  lorawan.AES128Key
+	FCntUp   uint32
+	FCntDown uint32
+
+	DR      int  // uplink data-rate, updated by LinkADRReq
+	TXPower int  // TX power index, updated by LinkADRReq
+	ADR     bool // set the ADR bit in the uplink frames
+	NbTrans int  // max. number of transmissions of an unacknowledged confirmed uplink (0 = 1)
+
+	FPort     uint8
+	Payload   []byte
+	Confirmed bool
+
+	// Links defines by which gateways the device is received and under
+	// which radio conditions. When nil, the device is received by all
+	// gateways using the RSSI and SNR of the gateway.
+	Links map[lorawan.EUI64]Link
+
+	// DownlinkFunc is called (when set) for each received application
+	// payload.
+	DownlinkFunc func(d *Device, fPort uint8, data []byte)
+
+	activated     bool
+	joinRequest   *lorawan.JoinRequestPayload // pending join-request
+	macAnswers    []lorawan.MACCommand        // mac-command answers for the next uplink
+	ack           bool                        // acknowledge the last confirmed downlink
+	ackPending    bool                        // the last confirmed uplink has not been acknowledged
+	transmissions int                         // number of transmissions of the pending confirmed uplink
+	lastUplink    lorawan.PHYPayload
+	stats         Stats
+}
+
+// Activated returns if the device is activated (joined for OTAA devices).
+func (d *Device) Activated() bool {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.activated || d.Activation == ABP
+}
+
+// Session returns the device-address and frame-counters of the device.
+func (d *Device) Session() (devAddr lorawan.DevAddr, fCntUp, fCntDown uint32) {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.DevAddr, d.FCntUp, d.FCntDown
+}
+
+// DataRate returns the current uplink data-rate and TX power index.
+func (d *Device) DataRate() (dr, txPower int) {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.DR, d.TXPower
+}
+
+// Stats returns the device counters.
+func (d *Device) Stats() Stats {
+	d.Lock()
+	defer d.Unlock()
+
+	return d.stats
+}
+
+// receivedBy returns the radio conditions of the device for the given
+// gateway and false when the gateway is not in range of the device.
+func (d *Device) receivedBy(g *Gateway) (Link, bool) {
+	if d.Links == nil {
+		return Link{RSSI: g.RSSI, SNR: g.SNR}, true
+	}
+
+	l, ok := d.Links[g.MAC]
+	return l, ok
+}
+
+// awaitingResponse returns true when the device is waiting for a
+// join-accept or for the acknowledgement of a confirmed uplink which can
+// still be retransmitted.
+func (d *Device) awaitingResponse() bool {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.Activation == OTAA && !d.activated {
+		return true
+	}
+	return d.ackPending && d.transmissions < d.nbTrans()
+}
+
+// uplink returns the next uplink frame and the data-rate to use. This is
+// either a join-request, a retransmission of the pending confirmed uplink
+// or a new data uplink.
+func (d *Device) uplink() (lorawan.PHYPayload, int, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.Activation == OTAA && !d.activated {
+		phy, err := d.joinRequestUplink()
+		return phy, d.DR, err
+	}
+
+	if d.ackPending {
+		if d.transmissions < d.nbTrans() {
+			d.transmissions++
+			d.stats.Uplinks++
+			d.stats.Retransmissions++
+			return d.lastUplink, d.DR, nil
+		}
+
+		d.ackPending = false
+		d.stats.AckTimeouts++
+	}
+
+	phy, err := d.dataUplink()
+	return phy, d.DR, err
+}
+
+// joinRequestUplink returns a new join-request using a random DevNonce.
+func (d *Device) joinRequestUplink() (lorawan.PHYPayload, error) {
+	jrPL := lorawan.JoinRequestPayload{
+		AppEUI: d.AppEUI,
+		DevEUI: d.DevEUI,
+	}
+	if _, err := rand.Read(jrPL.DevNonce[:]); err != nil {
+		return lorawan.PHYPayload{}, errors.Wrap(err, "read random bytes error")
+	}
+
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.JoinRequest,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &jrPL,
+	}
+	if err := phy.SetMIC(d.AppKey); err != nil {
+		return phy, errors.Wrap(err, "set mic error")
+	}
+
+	d.joinRequest = &jrPL
+	d.stats.JoinRequests++
+
+	return phy, nil
+}
+
+// dataUplink returns a new data uplink, containing the pending mac-command
+// answers in the FOpts.
+func (d *Device) dataUplink() (lorawan.PHYPayload, error) {
+	mType := lorawan.UnconfirmedDataUp
+	if d.Confirmed {
+		mType = lorawan.ConfirmedDataUp
+	}
+
+	var fOpts []lorawan.MACCommand
+	var fOptsLen int
+	for len(d.macAnswers) > 0 {
+		b, err := d.macAnswers[0].MarshalBinary()
+		if err != nil {
+			return lorawan.PHYPayload{}, errors.Wrap(err, "marshal mac-command error")
+		}
+		if fOptsLen+len(b) > maxFOptsLen {
+			break
+		}
+		fOptsLen += len(b)
+		fOpts = append(fOpts, d.macAnswers[0])
+		d.macAnswers = d.macAnswers[1:]
+	}
+
+	fPort := d.FPort
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: mType,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: d.DevAddr,
+				FCtrl: lorawan.FCtrl{
+					ADR: d.ADR,
+					ACK: d.ack,
+				},
+				FCnt:  d.FCntUp,
+				FOpts: fOpts,
+			},
+			FPort:      &fPort,
+			FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: d.Payload}},
+		},
+	}
+
+	if err := phy.EncryptFRMPayload(d.AppSKey); err != nil {
+		return phy, errors.Wrap(err, "encrypt frmpayload error")
+	}
+	if err := phy.SetMIC(d.NwkSKey); err != nil {
+		return phy, errors.Wrap(err, "set mic error")
+	}
+
+	d.FCntUp++
+	d.ack = false
+	d.stats.Uplinks++
+
+	if d.Confirmed {
+		d.ackPending = true
+		d.transmissions = 1
+		d.lastUplink = phy
+	}
+
+	return phy, nil
+}
+
+// handleJoinAccept handles the given join-accept. It returns false when
+// the device is not waiting for a join-accept or when the join-accept is
+// not intended for the device (the MIC does not match its AppKey).
+func (d *Device) handleJoinAccept(b []byte) (bool, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.Activation != OTAA || d.activated || d.joinRequest == nil {
+		return false, nil
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(b); err != nil {
+		return false, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	// an error means the payload could not be decrypted using the AppKey
+	if err := phy.DecryptJoinAcceptPayload(d.AppKey); err != nil {
+		return false, nil
+	}
+	if ok, err := phy.ValidateMIC(d.AppKey); err != nil || !ok {
+		return false, nil
+	}
+
+	jaPL, ok := phy.MACPayload.(*lorawan.JoinAcceptPayload)
+	if !ok {
+		return false, nil
+	}
+
+	jaBytes, err := jaPL.MarshalBinary()
+	if err != nil {
+		return false, errors.Wrap(err, "marshal join-accept payload error")
+	}
+	jrBytes, err := d.joinRequest.MarshalBinary()
+	if err != nil {
+		return false, errors.Wrap(err, "marshal join-request payload error")
+	}
+
+	if d.NwkSKey, err = getSessionKey(d.AppKey, 0x01, jaBytes, jrBytes); err != nil {
+		return false, err
+	}
+	if d.AppSKey, err = getSessionKey(d.AppKey, 0x02, jaBytes, jrBytes); err != nil {
+		return false, err
+	}
+
+	d.DevAddr = jaPL.DevAddr
+	d.FCntUp = 0
+	d.FCntDown = 0
+	d.activated = true
+	d.joinRequest = nil
+	d.macAnswers = nil
+	d.ack = false
+	d.ackPending = false
+	d.stats.JoinAccepts++
+
+	return true, nil
+}
+
+// handleDataDown handles the given data downlink. It returns false when
+// the downlink is not intended for the device (the DevAddr or MIC does
+// not match).
+func (d *Device) handleDataDown(b []byte) (bool, error) {
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(b); err != nil {
+		return false, errors.Wrap(err, "unmarshal phypayload error")
+	}
+
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return false, nil
+	}
+
+	d.Lock()
+
+	if !(d.activated || d.Activation == ABP) || macPL.FHDR.DevAddr != d.DevAddr {
+		d.Unlock()
+		return false, nil
+	}
+
+	// the frame contains the 16 least-significant bits of the frame-counter
+	fCnt := d.FCntDown&^0xffff | macPL.FHDR.FCnt&0xffff
+	if fCnt < d.FCntDown {
+		fCnt += 1 << 16
+	}
+	macPL.FHDR.FCnt = fCnt
+
+	if ok, err := phy.ValidateMIC(d.NwkSKey); err != nil || !ok {
+		d.Unlock()
+		return false, nil
+	}
+
+	d.FCntDown = fCnt + 1
+	d.stats.Downlinks++
+
+	if macPL.FHDR.FCtrl.ACK && d.ackPending {
+		d.ackPending = false
+		d.stats.Acks++
+	}
+
+	if phy.MHDR.MType == lorawan.ConfirmedDataDown {
+		d.ack = true
+	}
+
+	for i := range macPL.FHDR.FOpts {
+		d.handleMACCommand(macPL.FHDR.FOpts[i])
+	}
+
+	var fPort uint8
+	var data []byte
+
+	if macPL.FPort != nil {
+		fPort = *macPL.FPort
+		key := d.AppSKey
+		if fPort == 0 {
+			key = d.NwkSKey
+		}
+
+		if err := phy.DecryptFRMPayload(key); err != nil {
+			d.Unlock()
+			return true, errors.Wrap(err, "decrypt frmpayload error")
+		}
+
+		for _, pl := range macPL.FRMPayload {
+			switch v := pl.(type) {
+			case *lorawan.MACCommand:
+				d.handleMACCommand(*v)
+			case *lorawan.DataPayload:
+				data = append(data, v.Bytes...)
+			}
+		}
+	}
+
+	downlinkFunc := d.DownlinkFunc
+	d.Unlock()
+
+	if fPort != 0 && downlinkFunc != nil {
+		downlinkFunc(d, fPort, data)
+	}
+
+	return true, nil
+}
+
+// handleMACCommand handles the given mac-command and queues the answer
+// (when required) for the next uplink. All requests are accepted. Note that
+// the device must be locked.
+func (d *Device) handleMACCommand(cmd lorawan.MACCommand) {
+	d.stats.MACCommands++
+
+	var ans *lorawan.MACCommand
+
+	switch cmd.CID {
+	case lorawan.LinkADRReq:
+		if pl, ok := cmd.Payload.(*lorawan.LinkADRReqPayload); ok {
+			d.DR = int(pl.DataRate)
+			d.TXPower = int(pl.TXPower)
+		}
+		ans = &lorawan.MACCommand{
+			CID: lorawan.LinkADRAns,
+			Payload: &lorawan.LinkADRAnsPayload{
+				ChannelMaskACK: true,
+				DataRateACK:    true,
+				PowerACK:       true,
+			},
+		}
+	case lorawan.DevStatusReq:
+		ans = &lorawan.MACCommand{
+			CID: lorawan.DevStatusAns,
+			Payload: &lorawan.DevStatusAnsPayload{
+				Battery: 255, // unable to measure
+			},
+		}
+	case lorawan.RXParamSetupReq:
+		ans = &lorawan.MACCommand{
+			CID: lorawan.RXParamSetupAns,
+			Payload: &lorawan.RX2SetupAnsPayload{
+				ChannelACK:     true,
+				RX2DataRateACK: true,
+				RX1DROffsetACK: true,
+			},
+		}
+	case lorawan.NewChannelReq:
+		ans = &lorawan.MACCommand{
+			CID: lorawan.NewChannelAns,
+			Payload: &lorawan.NewChannelAnsPayload{
+				ChannelFrequencyOK: true,
+				DataRateRangeOK:    true,
+			},
+		}
+	case lorawan.DutyCycleReq, lorawan.RXTimingSetupReq:
+		ans = &lorawan.MACCommand{CID: cmd.CID}
+	}
+
+	if ans != nil {
+		d.macAnswers = append(d.macAnswers, *ans)
+	}
+}
+
+// nbTrans returns the max. number of transmissions of a confirmed uplink.
+func (d *Device) nbTrans() int {
+	if d.NbTrans < 1 {
+		return 1
+	}
+	return d.NbTrans
+}
+
+// getSessionKey returns the session-key of the given type (0x01 =
+// NwkSKey, 0x02 = AppSKey), derived from the AppKey, the join-accept and
+// the join-request payload.
+func getSessionKey(key lorawan.AES128Key, typ byte, jaBytes, jrBytes []byte) (lorawan.AES128Key, error) {
+	var sKey lorawan.AES128Key
+
+	b := make([]byte, 16)
+	b[0] = typ
+	copy(b[1:7], jaBytes[0:6])   // AppNonce + NetID
+	copy(b[7:9], jrBytes[16:18]) // DevNonce
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return sKey, errors.Wrap(err, "new cipher error")
+	}
+	block.Encrypt(sKey[:], b)
+
+	return sKey, nil
+}
@@ -0,0 +1,243 @@
+package simulator
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/lorawan"
+)
+
+// newJoinAccept returns the encrypted join-accept for the given
+// join-request, as it would be sent by the network-server, and the
+// expected session-keys.
+func newJoinAccept(appKey lorawan.AES128Key, jrPHY lorawan.PHYPayload, devAddr lorawan.DevAddr) ([]byte, lorawan.AES128Key, lorawan.AES128Key, error) {
+	var nwkSKey, appSKey lorawan.AES128Key
+
+	jaPL := lorawan.JoinAcceptPayload{
+		AppNonce: [3]byte{1, 2, 3},
+		NetID:    lorawan.NetID{3, 2, 1},
+		DevAddr:  devAddr,
+	}
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: lorawan.JoinAccept,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &jaPL,
+	}
+	if err := phy.SetMIC(appKey); err != nil {
+		return nil, nwkSKey, appSKey, err
+	}
+
+	jaBytes, err := jaPL.MarshalBinary()
+	if err != nil {
+		return nil, nwkSKey, appSKey, err
+	}
+	jrBytes, err := jrPHY.MACPayload.MarshalBinary()
+	if err != nil {
+		return nil, nwkSKey, appSKey, err
+	}
+	if nwkSKey, err = getSessionKey(appKey, 0x01, jaBytes, jrBytes); err != nil {
+		return nil, nwkSKey, appSKey, err
+	}
+	if appSKey, err = getSessionKey(appKey, 0x02, jaBytes, jrBytes); err != nil {
+		return nil, nwkSKey, appSKey, err
+	}
+
+	if err := phy.EncryptJoinAcceptPayload(appKey); err != nil {
+		return nil, nwkSKey, appSKey, err
+	}
+	b, err := phy.MarshalBinary()
+	return b, nwkSKey, appSKey, err
+}
+
+// newDataDown returns a data downlink for the given device session.
+func newDataDown(mType lorawan.MType, devAddr lorawan.DevAddr, nwkSKey lorawan.AES128Key, fCnt uint32, ack bool, fOpts []lorawan.MACCommand) ([]byte, error) {
+	phy := lorawan.PHYPayload{
+		MHDR: lorawan.MHDR{
+			MType: mType,
+			Major: lorawan.LoRaWANR1,
+		},
+		MACPayload: &lorawan.MACPayload{
+			FHDR: lorawan.FHDR{
+				DevAddr: devAddr,
+				FCtrl: lorawan.FCtrl{
+					ACK: ack,
+				},
+				FCnt:  fCnt,
+				FOpts: fOpts,
+			},
+		},
+	}
+	if err := phy.SetMIC(nwkSKey); err != nil {
+		return nil, err
+	}
+	return phy.MarshalBinary()
+}
+
+func TestDevice(t *testing.T) {
+	Convey("Given an OTAA device", t, func() {
+		d := Device{
+			DevEUI:     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppEUI:     lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			AppKey:     lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			Activation: OTAA,
+			FPort:      10,
+			Payload:    []byte{1, 2, 3},
+			Confirmed:  true,
+			NbTrans:    2,
+		}
+
+		Convey("Then the first uplink is a valid join-request", func() {
+			phy, _, err := d.uplink()
+			So(err, ShouldBeNil)
+			So(phy.MHDR.MType, ShouldEqual, lorawan.JoinRequest)
+			ok, err := phy.ValidateMIC(d.AppKey)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(d.awaitingResponse(), ShouldBeTrue)
+
+			jrPL := phy.MACPayload.(*lorawan.JoinRequestPayload)
+			So(jrPL.DevEUI, ShouldEqual, d.DevEUI)
+			So(jrPL.AppEUI, ShouldEqual, d.AppEUI)
+
+			Convey("Then a join-accept for an other AppKey is ignored", func() {
+				b, _, _, err := newJoinAccept(lorawan.AES128Key{16, 15, 14}, phy, lorawan.DevAddr{1, 2, 3, 4})
+				So(err, ShouldBeNil)
+				ok, err := d.handleJoinAccept(b)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeFalse)
+				So(d.Activated(), ShouldBeFalse)
+			})
+
+			Convey("When handling the join-accept", func() {
+				devAddr := lorawan.DevAddr{1, 2, 3, 4}
+				b, nwkSKey, appSKey, err := newJoinAccept(d.AppKey, phy, devAddr)
+				So(err, ShouldBeNil)
+				ok, err := d.handleJoinAccept(b)
+				So(err, ShouldBeNil)
+				So(ok, ShouldBeTrue)
+
+				Convey("Then the device is activated using the derived session-keys", func() {
+					So(d.Activated(), ShouldBeTrue)
+					So(d.DevAddr, ShouldEqual, devAddr)
+					So(d.NwkSKey, ShouldEqual, nwkSKey)
+					So(d.AppSKey, ShouldEqual, appSKey)
+					So(d.Stats().JoinAccepts, ShouldEqual, 1)
+				})
+
+				Convey("Then the next uplink is an encrypted confirmed data uplink", func() {
+					phy, _, err := d.uplink()
+					So(err, ShouldBeNil)
+					So(phy.MHDR.MType, ShouldEqual, lorawan.ConfirmedDataUp)
+					ok, err := phy.ValidateMIC(nwkSKey)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+
+					So(phy.DecryptFRMPayload(appSKey), ShouldBeNil)
+					macPL := phy.MACPayload.(*lorawan.MACPayload)
+					So(macPL.FHDR.FCnt, ShouldEqual, 0)
+					So(*macPL.FPort, ShouldEqual, 10)
+					So(macPL.FRMPayload, ShouldResemble, []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3}}})
+
+					Convey("Then without ack the uplink is retransmitted NbTrans times", func() {
+						So(d.awaitingResponse(), ShouldBeTrue)
+						retry, _, err := d.uplink()
+						So(err, ShouldBeNil)
+						So(retry.MACPayload.(*lorawan.MACPayload).FHDR.FCnt, ShouldEqual, 0)
+						So(d.awaitingResponse(), ShouldBeFalse)
+
+						next, _, err := d.uplink()
+						So(err, ShouldBeNil)
+						So(next.MACPayload.(*lorawan.MACPayload).FHDR.FCnt, ShouldEqual, 1)
+						So(d.Stats(), ShouldResemble, Stats{
+							JoinRequests:    1,
+							JoinAccepts:     1,
+							Uplinks:         3,
+							Retransmissions: 1,
+							AckTimeouts:     1,
+						})
+					})
+
+					Convey("When receiving a confirmed downlink with ack and LinkADRReq", func() {
+						b, err := newDataDown(lorawan.ConfirmedDataDown, devAddr, nwkSKey, 0, true, []lorawan.MACCommand{
+							{
+								CID: lorawan.LinkADRReq,
+								Payload: &lorawan.LinkADRReqPayload{
+									DataRate: 5,
+									TXPower:  2,
+								},
+							},
+							{CID: lorawan.DevStatusReq},
+						})
+						So(err, ShouldBeNil)
+						ok, err := d.handleDataDown(b)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeTrue)
+
+						Convey("Then the uplink is acknowledged and the data-rate is updated", func() {
+							So(d.awaitingResponse(), ShouldBeFalse)
+							So(d.FCntDown, ShouldEqual, 1)
+							dr, txPower := d.DataRate()
+							So(dr, ShouldEqual, 5)
+							So(txPower, ShouldEqual, 2)
+							So(d.Stats().Acks, ShouldEqual, 1)
+						})
+
+						Convey("Then the next uplink contains the ack and mac-command answers", func() {
+							phy, dr, err := d.uplink()
+							So(err, ShouldBeNil)
+							So(dr, ShouldEqual, 5)
+							macPL := phy.MACPayload.(*lorawan.MACPayload)
+							So(macPL.FHDR.FCtrl.ACK, ShouldBeTrue)
+							So(macPL.FHDR.FOpts, ShouldResemble, []lorawan.MACCommand{
+								{
+									CID: lorawan.LinkADRAns,
+									Payload: &lorawan.LinkADRAnsPayload{
+										ChannelMaskACK: true,
+										DataRateACK:    true,
+										PowerACK:       true,
+									},
+								},
+								{
+									CID: lorawan.DevStatusAns,
+									Payload: &lorawan.DevStatusAnsPayload{
+										Battery: 255,
+									},
+								},
+							})
+						})
+					})
+
+					Convey("Then a downlink with an invalid MIC is ignored", func() {
+						b, err := newDataDown(lorawan.UnconfirmedDataDown, devAddr, lorawan.AES128Key{}, 0, true, nil)
+						So(err, ShouldBeNil)
+						ok, err := d.handleDataDown(b)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeFalse)
+						So(d.FCntDown, ShouldEqual, 0)
+					})
+				})
+			})
+		})
+	})
+
+	Convey("Given an ABP device with a downlink frame-counter above 16 bits", t, func() {
+		d := Device{
+			Activation: ABP,
+			DevAddr:    lorawan.DevAddr{1, 2, 3, 4},
+			NwkSKey:    lorawan.AES128Key{1, 2, 3},
+			FCntDown:   65540,
+		}
+
+		Convey("Then the full frame-counter is used to validate the MIC", func() {
+			b, err := newDataDown(lorawan.UnconfirmedDataDown, d.DevAddr, d.NwkSKey, 65541, false, nil)
+			So(err, ShouldBeNil)
+			ok, err := d.handleDataDown(b)
+			So(err, ShouldBeNil)
+			So(ok, ShouldBeTrue)
+			So(d.FCntDown, ShouldEqual, 65542)
+		})
+	})
+}
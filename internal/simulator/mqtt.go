@@ -0,0 +1,122 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/eclipse/paho.mqtt.golang"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+)
+
+// MQTT topics used by the gateways (the defaults of the MQTT gateway
+// backend). The packets are JSON encoded.
+const (
+	mqttRXTopic    = "gateway/%s/rx"
+	mqttStatsTopic = "gateway/%s/stats"
+	mqttTXTopic    = "gateway/+/tx"
+)
+
+// txPacketBufferSize defines the number of buffered downlink packets.
+const txPacketBufferSize = 100
+
+// MQTTBackend implements the gateway side of the MQTT gateway backend, so
+// that the virtual gateways can be connected to a running LoRa Server
+// instance.
+type MQTTBackend struct {
+	conn         mqtt.Client
+	txPacketChan chan gw.TXPacket
+}
+
+// NewMQTTBackend creates a new MQTTBackend.
+func NewMQTTBackend(server, username, password string) (*MQTTBackend, error) {
+	b := MQTTBackend{
+		txPacketChan: make(chan gw.TXPacket, txPacketBufferSize),
+	}
+
+	opts := mqtt.NewClientOptions()
+	opts.AddBroker(server)
+	opts.SetUsername(username)
+	opts.SetPassword(password)
+	opts.SetOnConnectHandler(b.onConnected)
+
+	log.WithField("server", server).Info("simulator: connecting to mqtt broker")
+	b.conn = mqtt.NewClient(opts)
+	if token := b.conn.Connect(); token.Wait() && token.Error() != nil {
+		return nil, errors.Wrap(token.Error(), "connect to mqtt broker error")
+	}
+
+	return &b, nil
+}
+
+// Close closes the backend.
+func (b *MQTTBackend) Close() error {
+	b.conn.Disconnect(250)
+	return nil
+}
+
+// SendRXPacket publishes the given packet on the rx topic of the gateway.
+func (b *MQTTBackend) SendRXPacket(rxPacket gw.RXPacket) error {
+	phyB, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	return b.publish(fmt.Sprintf(mqttRXTopic, rxPacket.RXInfo.MAC), gw.RXPacketBytes{
+		RXInfo:     rxPacket.RXInfo,
+		PHYPayload: phyB,
+	})
+}
+
+// SendStatsPacket publishes the given stats on the stats topic of the
+// gateway.
+func (b *MQTTBackend) SendStatsPacket(statsPacket gw.GatewayStatsPacket) error {
+	return b.publish(fmt.Sprintf(mqttStatsTopic, statsPacket.MAC), statsPacket)
+}
+
+// TXPacketChan returns the channel containing the packets sent by
+// LoRa Server.
+func (b *MQTTBackend) TXPacketChan() chan gw.TXPacket {
+	return b.txPacketChan
+}
+
+func (b *MQTTBackend) publish(topic string, v interface{}) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "marshal json error")
+	}
+
+	if token := b.conn.Publish(topic, 0, false, payload); token.Wait() && token.Error() != nil {
+		return errors.Wrap(token.Error(), "publish error")
+	}
+	return nil
+}
+
+func (b *MQTTBackend) txPacketHandler(c mqtt.Client, msg mqtt.Message) {
+	var txPacketBytes gw.TXPacketBytes
+	if err := json.Unmarshal(msg.Payload(), &txPacketBytes); err != nil {
+		log.WithField("topic", msg.Topic()).Errorf("simulator: unmarshal tx packet error: %s", err)
+		return
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(txPacketBytes.PHYPayload); err != nil {
+		log.WithField("topic", msg.Topic()).Errorf("simulator: unmarshal phypayload error: %s", err)
+		return
+	}
+
+	b.txPacketChan <- gw.TXPacket{
+		TXInfo:     txPacketBytes.TXInfo,
+		PHYPayload: phy,
+	}
+}
+
+func (b *MQTTBackend) onConnected(c mqtt.Client) {
+	log.WithField("topic", mqttTXTopic).Info("simulator: subscribing to tx topic")
+	if token := b.conn.Subscribe(mqttTXTopic, 0, b.txPacketHandler); token.Wait() && token.Error() != nil {
+		log.WithField("topic", mqttTXTopic).Errorf("simulator: subscribe error: %s", token.Error())
+	}
+}
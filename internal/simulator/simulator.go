@@ -0,0 +1,379 @@
+// Package simulator implements virtual LoRaWAN devices and gateways, which
+// can be used to test LoRa Server without radio hardware. The virtual
+// gateways are connected to LoRa Server through a Backend, e.g. the
+// in-process loopback backend or a MQTT broker.
+package simulator
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+// Backend defines the interface of the gateway side of a gateway backend.
+type Backend interface {
+	SendRXPacket(gw.RXPacket) error              // send the given packet to LoRa Server
+	SendStatsPacket(gw.GatewayStatsPacket) error // send the given gateway stats to LoRa Server
+	TXPacketChan() chan gw.TXPacket              // channel containing the packets sent by LoRa Server
+}
+
+// Config contains the simulator configuration.
+type Config struct {
+	Band           band.Band
+	UplinkInterval time.Duration // interval between the uplinks of a device
+	AckTimeout     time.Duration // time to wait for a join-accept or ack before retransmitting
+	StatsInterval  time.Duration // interval of the gateway stats (0 = disabled)
+}
+
+// Stats contains the simulation counters.
+type Stats struct {
+	JoinRequests    int // sent join-requests
+	JoinAccepts     int // received join-accepts
+	Uplinks         int // sent data uplinks (including retransmissions)
+	Retransmissions int // retransmitted confirmed uplinks
+	Acks            int // acknowledged confirmed uplinks
+	AckTimeouts     int // confirmed uplinks which were not acknowledged
+	Downlinks       int // received data downlinks
+	MACCommands     int // received mac-commands
+	Errors          int // downlinks which could not be handled by any device
+}
+
+func (s *Stats) add(o Stats) {
+	s.JoinRequests += o.JoinRequests
+	s.JoinAccepts += o.JoinAccepts
+	s.Uplinks += o.Uplinks
+	s.Retransmissions += o.Retransmissions
+	s.Acks += o.Acks
+	s.AckTimeouts += o.AckTimeouts
+	s.Downlinks += o.Downlinks
+	s.MACCommands += o.MACCommands
+	s.Errors += o.Errors
+}
+
+// Gateway implements a virtual gateway.
+type Gateway struct {
+	MAC  lorawan.EUI64
+	RSSI int     // RSSI of the received uplinks (unless overridden by the device links)
+	SNR  float64 // SNR of the received uplinks (unless overridden by the device links)
+
+	start             time.Time
+	rxPacketsReceived int64
+	txPacketsReceived int64
+}
+
+// timestamp returns the internal timestamp of the gateway (microseconds
+// since the start of the simulation, rolling over every ~72 minutes).
+func (g *Gateway) timestamp() uint32 {
+	return uint32(time.Since(g.start) / time.Microsecond)
+}
+
+// Simulator drives the virtual devices and gateways.
+type Simulator struct {
+	sync.RWMutex
+
+	backend  Backend
+	config   Config
+	gateways []*Gateway
+	devices  []*Device
+	errors   int64
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewSimulator creates a new Simulator.
+func NewSimulator(backend Backend, config Config) *Simulator {
+	return &Simulator{
+		backend: backend,
+		config:  config,
+		done:    make(chan struct{}),
+	}
+}
+
+// AddGateway adds the given gateway to the simulation. Gateways must be
+// added before calling Start.
+func (s *Simulator) AddGateway(g *Gateway) {
+	s.Lock()
+	defer s.Unlock()
+
+	g.start = time.Now()
+	s.gateways = append(s.gateways, g)
+}
+
+// AddDevice adds the given device to the simulation. Devices must be added
+// before calling Start.
+func (s *Simulator) AddDevice(d *Device) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.devices = append(s.devices, d)
+}
+
+// Start starts the simulation. The first uplink of each device is sent
+// at a random moment within the uplink interval.
+func (s *Simulator) Start() {
+	s.RLock()
+	defer s.RUnlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.handleTXPackets()
+	}()
+
+	if s.config.StatsInterval > 0 {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.sendStatsLoop()
+		}()
+	}
+
+	for _, d := range s.devices {
+		s.wg.Add(1)
+		go func(d *Device) {
+			defer s.wg.Done()
+			s.deviceLoop(d)
+		}(d)
+	}
+}
+
+// Stop stops the simulation and waits until all devices are stopped.
+func (s *Simulator) Stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// Stats returns the counters of all devices.
+func (s *Simulator) Stats() Stats {
+	s.RLock()
+	defer s.RUnlock()
+
+	var out Stats
+	for _, d := range s.devices {
+		out.add(d.Stats())
+	}
+	out.Errors += int(atomic.LoadInt64(&s.errors))
+	return out
+}
+
+// deviceLoop sends the uplinks of the given device until the simulation
+// is stopped. When the device is waiting for a join-accept or ack, the
+// next uplink is sent after the ack timeout.
+func (s *Simulator) deviceLoop(d *Device) {
+	var wait time.Duration
+	if s.config.UplinkInterval > 0 {
+		wait = time.Duration(rand.Int63n(int64(s.config.UplinkInterval)))
+	}
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-time.After(wait):
+		}
+
+		if err := s.sendUplink(d); err != nil {
+			log.WithFields(log.Fields{
+				"dev_eui": d.DevEUI,
+			}).Errorf("simulator: send uplink error: %s", err)
+		}
+
+		wait = s.config.UplinkInterval
+		if d.awaitingResponse() {
+			wait = s.config.AckTimeout
+		}
+	}
+}
+
+// sendUplink sends the next uplink of the given device to all gateways in
+// range of the device.
+func (s *Simulator) sendUplink(d *Device) error {
+	phy, dr, err := d.uplink()
+	if err != nil {
+		return errors.Wrap(err, "get uplink error")
+	}
+
+	b, err := phy.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	channel, err := s.getUplinkChannel(dr)
+	if err != nil {
+		return err
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+
+	now := time.Now()
+	for _, g := range s.gateways {
+		link, ok := d.receivedBy(g)
+		if !ok {
+			continue
+		}
+
+		// each gateway gets its own copy of the PHYPayload, as the
+		// receiver might modify it (e.g. on decryption)
+		rxPacket := gw.RXPacket{
+			RXInfo: gw.RXInfo{
+				MAC:       g.MAC,
+				Time:      now,
+				Timestamp: g.timestamp(),
+				Frequency: s.config.Band.UplinkChannels[channel].Frequency,
+				Channel:   channel,
+				CRCStatus: 1,
+				CodeRate:  "4/5",
+				RSSI:      link.RSSI,
+				LoRaSNR:   link.SNR,
+				Size:      len(b),
+				DataRate:  s.config.Band.DataRates[dr],
+			},
+		}
+		if err := rxPacket.PHYPayload.UnmarshalBinary(b); err != nil {
+			return errors.Wrap(err, "unmarshal phypayload error")
+		}
+
+		atomic.AddInt64(&g.rxPacketsReceived, 1)
+		if err := s.backend.SendRXPacket(rxPacket); err != nil {
+			return errors.Wrap(err, "send rx packet error")
+		}
+	}
+
+	return nil
+}
+
+// getUplinkChannel returns a random enabled uplink channel supporting the
+// given data-rate.
+func (s *Simulator) getUplinkChannel(dr int) (int, error) {
+	if dr < 0 || dr >= len(s.config.Band.DataRates) {
+		return 0, fmt.Errorf("invalid data-rate: %d", dr)
+	}
+
+	var channels []int
+	for _, i := range s.config.Band.GetEnabledUplinkChannels() {
+		for _, chDR := range s.config.Band.UplinkChannels[i].DataRates {
+			if chDR == dr {
+				channels = append(channels, i)
+				break
+			}
+		}
+	}
+
+	if len(channels) == 0 {
+		return 0, fmt.Errorf("no uplink channel available for data-rate %d", dr)
+	}
+
+	return channels[rand.Intn(len(channels))], nil
+}
+
+// handleTXPackets dispatches the packets sent by LoRa Server to the devices
+// until the simulation is stopped.
+func (s *Simulator) handleTXPackets() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case txPacket := <-s.backend.TXPacketChan():
+			if err := s.handleTXPacket(txPacket); err != nil {
+				atomic.AddInt64(&s.errors, 1)
+				log.WithFields(log.Fields{
+					"mac": txPacket.TXInfo.MAC,
+				}).Errorf("simulator: handle tx packet error: %s", err)
+			}
+		}
+	}
+}
+
+// handleTXPacket hands the given packet to the devices in range of the
+// gateway, until a device accepts it.
+func (s *Simulator) handleTXPacket(txPacket gw.TXPacket) error {
+	s.RLock()
+	defer s.RUnlock()
+
+	var g *Gateway
+	for i := range s.gateways {
+		if s.gateways[i].MAC == txPacket.TXInfo.MAC {
+			g = s.gateways[i]
+		}
+	}
+	if g == nil {
+		// the packet is intended for a gateway outside the simulation
+		return nil
+	}
+	atomic.AddInt64(&g.txPacketsReceived, 1)
+
+	b, err := txPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	for _, d := range s.devices {
+		if _, ok := d.receivedBy(g); !ok {
+			continue
+		}
+
+		var handled bool
+		switch txPacket.PHYPayload.MHDR.MType {
+		case lorawan.JoinAccept:
+			handled, err = d.handleJoinAccept(b)
+		case lorawan.UnconfirmedDataDown, lorawan.ConfirmedDataDown:
+			handled, err = d.handleDataDown(b)
+		default:
+			return nil
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "handle downlink error")
+		}
+		if handled {
+			return nil
+		}
+	}
+
+	return errors.New("no device found for downlink")
+}
+
+// sendStatsLoop sends the stats of all gateways every stats interval until
+// the simulation is stopped.
+func (s *Simulator) sendStatsLoop() {
+	ticker := time.NewTicker(s.config.StatsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.RLock()
+			gateways := s.gateways
+			s.RUnlock()
+
+			for _, g := range gateways {
+				rx := int(atomic.SwapInt64(&g.rxPacketsReceived, 0))
+				tx := int(atomic.SwapInt64(&g.txPacketsReceived, 0))
+
+				err := s.backend.SendStatsPacket(gw.GatewayStatsPacket{
+					MAC:                 g.MAC,
+					Time:                time.Now(),
+					RXPacketsReceived:   rx,
+					RXPacketsReceivedOK: rx,
+					TXPacketsReceived:   tx,
+					TXPacketsEmitted:    tx,
+				})
+				if err != nil {
+					log.WithField("mac", g.MAC).Errorf("simulator: send stats packet error: %s", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backend/loopback"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestSimulator(t *testing.T) {
+	Convey("Given a simulator with two gateways and an OTAA device connected to a loopback backend", t, func() {
+		b, err := band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+		So(err, ShouldBeNil)
+
+		backend := loopback.NewBackend()
+		defer backend.Close()
+
+		sim := NewSimulator(backend, Config{
+			Band:           b,
+			UplinkInterval: 10 * time.Millisecond,
+			AckTimeout:     time.Second,
+		})
+
+		gw1 := Gateway{MAC: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}, RSSI: -50, SNR: 5}
+		gw2 := Gateway{MAC: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}, RSSI: -100, SNR: -5}
+		sim.AddGateway(&gw1)
+		sim.AddGateway(&gw2)
+
+		devAddr := lorawan.DevAddr{1, 2, 3, 4}
+		d := Device{
+			DevEUI:     lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			AppKey:     lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			Activation: OTAA,
+			FPort:      1,
+			Links: map[lorawan.EUI64]Link{
+				gw2.MAC: {RSSI: -110, SNR: -7.5},
+			},
+		}
+		sim.AddDevice(&d)
+
+		Convey("When the simulation is started", func() {
+			sim.Start()
+
+			Convey("Then the join-request is only received by the gateway in range", func() {
+				rxPacket := <-backend.RXPacketChan()
+				So(rxPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.JoinRequest)
+				So(rxPacket.RXInfo.MAC, ShouldEqual, gw2.MAC)
+				So(rxPacket.RXInfo.RSSI, ShouldEqual, -110)
+				So(rxPacket.RXInfo.LoRaSNR, ShouldEqual, -7.5)
+				So(rxPacket.RXInfo.DataRate, ShouldResemble, b.DataRates[0])
+
+				Convey("When LoRa Server sends the join-accept", func() {
+					ja, nwkSKey, _, err := newJoinAccept(d.AppKey, rxPacket.PHYPayload, devAddr)
+					So(err, ShouldBeNil)
+					var phy lorawan.PHYPayload
+					So(phy.UnmarshalBinary(ja), ShouldBeNil)
+					So(backend.SendTXPacket(gw.TXPacket{
+						TXInfo:     gw.TXInfo{MAC: gw2.MAC},
+						PHYPayload: phy,
+					}), ShouldBeNil)
+
+					Convey("Then the device sends data uplinks using the new session", func() {
+						for {
+							rxPacket = <-backend.RXPacketChan()
+							if rxPacket.PHYPayload.MHDR.MType != lorawan.JoinRequest {
+								break
+							}
+						}
+
+						// drain the uplinks sent while stopping the simulation
+						go func() {
+							for range backend.RXPacketChan() {
+							}
+						}()
+						sim.Stop()
+
+						So(rxPacket.PHYPayload.MHDR.MType, ShouldEqual, lorawan.UnconfirmedDataUp)
+						So(rxPacket.PHYPayload.MACPayload.(*lorawan.MACPayload).FHDR.DevAddr, ShouldEqual, devAddr)
+						ok, err := rxPacket.PHYPayload.ValidateMIC(nwkSKey)
+						So(err, ShouldBeNil)
+						So(ok, ShouldBeTrue)
+
+						stats := sim.Stats()
+						So(stats.JoinAccepts, ShouldEqual, 1)
+						So(stats.Uplinks, ShouldBeGreaterThanOrEqualTo, 1)
+						So(stats.Errors, ShouldEqual, 0)
+					})
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/lorawan"
+)
+
+// DeviceKeys defines the root-keys of a device (used by the embedded
+// join-server). The keys are stored encrypted using the KEK matching
+// common.JoinServerKEKLabel.
+type DeviceKeys struct {
+	DevEUI    lorawan.EUI64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	NwkKey    lorawan.AES128Key
+	AppKey    lorawan.AES128Key
+	JoinNonce int
+}
+
+// deviceKeysRow defines the device_keys row, holding the encrypted keys.
+type deviceKeysRow struct {
+	DevEUI    lorawan.EUI64 `db:"dev_eui"`
+	CreatedAt time.Time     `db:"created_at"`
+	UpdatedAt time.Time     `db:"updated_at"`
+	KEKLabel  string        `db:"kek_label"`
+	NwkKey    []byte        `db:"nwk_key"`
+	AppKey    []byte        `db:"app_key"`
+	JoinNonce int           `db:"join_nonce"`
+}
+
+// CreateDeviceKeys creates the given device-keys.
+func CreateDeviceKeys(db sqlx.Execer, dk *DeviceKeys) error {
+	nwkKey, err := encryptKey(dk.NwkKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt nwk_key error")
+	}
+	appKey, err := encryptKey(dk.AppKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt app_key error")
+	}
+
+	now := time.Now()
+	dk.CreatedAt = now
+	dk.UpdatedAt = now
+
+	_, err = db.Exec(`
+		insert into device_keys (
+			dev_eui,
+			created_at,
+			updated_at,
+			kek_label,
+			nwk_key,
+			app_key,
+			join_nonce
+		) values ($1, $2, $3, $4, $5, $6, $7)`,
+		dk.DevEUI[:],
+		dk.CreatedAt,
+		dk.UpdatedAt,
+		nwkKey.KEKLabel,
+		[]byte(nwkKey.AESKey),
+		[]byte(appKey.AESKey),
+		dk.JoinNonce,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithField("dev_eui", dk.DevEUI).Info("device-keys created")
+	return nil
+}
+
+// GetDeviceKeys returns the device-keys matching the given DevEUI.
+func GetDeviceKeys(db sqlx.Queryer, devEUI lorawan.EUI64) (DeviceKeys, error) {
+	var dk DeviceKeys
+	var row deviceKeysRow
+
+	err := sqlx.Get(db, &row, `
+		select
+			dev_eui,
+			created_at,
+			updated_at,
+			kek_label,
+			nwk_key,
+			app_key,
+			join_nonce
+		from device_keys
+		where
+			dev_eui = $1`,
+		devEUI[:],
+	)
+	if err != nil {
+		return dk, handlePSQLError(err, "select error")
+	}
+
+	dk = DeviceKeys{
+		DevEUI:    row.DevEUI,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+		JoinNonce: row.JoinNonce,
+	}
+
+	dk.NwkKey, err = decryptKey(row.KEKLabel, row.NwkKey)
+	if err != nil {
+		return dk, errors.Wrap(err, "decrypt nwk_key error")
+	}
+	dk.AppKey, err = decryptKey(row.KEKLabel, row.AppKey)
+	if err != nil {
+		return dk, errors.Wrap(err, "decrypt app_key error")
+	}
+
+	return dk, nil
+}
+
+// UpdateDeviceKeys updates the given device-keys. The keys are
+// re-encrypted using the current KEK. As a decreased join-nonce would
+// result in the re-use of AppNonce values (and thus session-keys),
+// ErrJoinNonceDecreased is returned when the given join-nonce is lower than
+// the stored join-nonce.
+func UpdateDeviceKeys(db sqlx.Ext, dk *DeviceKeys) error {
+	nwkKey, err := encryptKey(dk.NwkKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt nwk_key error")
+	}
+	appKey, err := encryptKey(dk.AppKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt app_key error")
+	}
+
+	dk.UpdatedAt = time.Now()
+
+	res, err := db.Exec(`
+		update device_keys set
+			updated_at = $2,
+			kek_label = $3,
+			nwk_key = $4,
+			app_key = $5,
+			join_nonce = $6
+		where
+			dev_eui = $1
+			and join_nonce <= $6`,
+		dk.DevEUI[:],
+		dk.UpdatedAt,
+		nwkKey.KEKLabel,
+		[]byte(nwkKey.AESKey),
+		[]byte(appKey.AESKey),
+		dk.JoinNonce,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		var joinNonce int
+		err := sqlx.Get(db, &joinNonce, "select join_nonce from device_keys where dev_eui = $1", dk.DevEUI[:])
+		if err != nil {
+			return handlePSQLError(err, "select error")
+		}
+		return ErrJoinNonceDecreased
+	}
+
+	log.WithField("dev_eui", dk.DevEUI).Info("device-keys updated")
+	return nil
+}
+
+// DeleteDeviceKeys deletes the device-keys matching the given DevEUI.
+func DeleteDeviceKeys(db sqlx.Execer, devEUI lorawan.EUI64) error {
+	res, err := db.Exec("delete from device_keys where dev_eui = $1", devEUI[:])
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("dev_eui", devEUI).Info("device-keys deleted")
+	return nil
+}
+
+// IncrementJoinNonce increments the join-nonce (24 bit) of the device-keys
+// matching the given DevEUI and returns the new value.
+func IncrementJoinNonce(db sqlx.Queryer, devEUI lorawan.EUI64) (int, error) {
+	var joinNonce int
+	err := sqlx.Get(db, &joinNonce, `
+		update device_keys set
+			join_nonce = (join_nonce + 1) % 16777216
+		where
+			dev_eui = $1
+		returning join_nonce`,
+		devEUI[:],
+	)
+	if err != nil {
+		return 0, handlePSQLError(err, "update error")
+	}
+
+	return joinNonce, nil
+}
+
+// SetDeviceKeysAppSKey stores the AppSKey of the latest activation and its
+// session-key id for the given DevEUI.
+func SetDeviceKeysAppSKey(db sqlx.Execer, devEUI lorawan.EUI64, sessionKeyID []byte, appSKey lorawan.AES128Key) error {
+	ke, err := encryptKey(appSKey)
+	if err != nil {
+		return errors.Wrap(err, "encrypt app_s_key error")
+	}
+
+	res, err := db.Exec(`
+		update device_keys set
+			session_key_id = $2,
+			app_s_key_kek_label = $3,
+			app_s_key = $4
+		where
+			dev_eui = $1`,
+		devEUI[:],
+		sessionKeyID,
+		ke.KEKLabel,
+		[]byte(ke.AESKey),
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	return nil
+}
+
+// GetDeviceKeysAppSKey returns the session-key id and the AppSKey of the
+// latest activation for the given DevEUI.
+func GetDeviceKeysAppSKey(db sqlx.Queryer, devEUI lorawan.EUI64) ([]byte, lorawan.AES128Key, error) {
+	var appSKey lorawan.AES128Key
+	var row struct {
+		SessionKeyID []byte `db:"session_key_id"`
+		KEKLabel     string `db:"app_s_key_kek_label"`
+		AppSKey      []byte `db:"app_s_key"`
+	}
+
+	err := sqlx.Get(db, &row, `
+		select
+			session_key_id,
+			app_s_key_kek_label,
+			app_s_key
+		from device_keys
+		where
+			dev_eui = $1`,
+		devEUI[:],
+	)
+	if err != nil {
+		return nil, appSKey, handlePSQLError(err, "select error")
+	}
+	if row.AppSKey == nil {
+		return nil, appSKey, ErrDoesNotExist
+	}
+
+	appSKey, err = decryptKey(row.KEKLabel, row.AppSKey)
+	if err != nil {
+		return nil, appSKey, errors.Wrap(err, "decrypt app_s_key error")
+	}
+
+	return row.SessionKeyID, appSKey, nil
+}
+
+// encryptKey encrypts the given key using the KEK matching
+// common.JoinServerKEKLabel. ErrNoKEKLabel is returned when no label is
+// configured, as the keys must never be stored in plain-text.
+func encryptKey(key lorawan.AES128Key) (*kek.KeyEnvelope, error) {
+	if common.JoinServerKEKLabel == "" {
+		return nil, ErrNoKEKLabel
+	}
+	return common.KEKStore.WrapAES128Key(common.JoinServerKEKLabel, key)
+}
+
+// decryptKey decrypts the given key using the KEK matching the given label.
+func decryptKey(label string, b []byte) (lorawan.AES128Key, error) {
+	return common.KEKStore.UnwrapKeyEnvelope(kek.KeyEnvelope{
+		KEKLabel: label,
+		AESKey:   b,
+	})
+}
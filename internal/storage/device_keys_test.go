@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+	"github.com/pkg/errors"
+
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeviceKeys(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+
+	common.KEKStore = kek.NewStore()
+	if err := common.KEKStore.Add("js", []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}); err != nil {
+		t.Fatal(err)
+	}
+	common.JoinServerKEKLabel = "js"
+	defer func() {
+		common.KEKStore = nil
+		common.JoinServerKEKLabel = ""
+	}()
+
+	Convey("Given a clean database", t, func() {
+		test.MustResetDB(common.DB)
+
+		Convey("Given a service, device and routing profile and a device", func() {
+			sp := ServiceProfile{}
+			So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+			dp := DeviceProfile{}
+			So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+			rp := RoutingProfile{}
+			So(CreateRoutingProfile(db, &rp), ShouldBeNil)
+
+			d := Device{
+				DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+				DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+				RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+			}
+			So(CreateDevice(db, &d), ShouldBeNil)
+
+			Convey("Given no KEK label is configured", func() {
+				common.JoinServerKEKLabel = ""
+				Reset(func() {
+					common.JoinServerKEKLabel = "js"
+				})
+
+				Convey("Then CreateDeviceKeys returns an error", func() {
+					dk := DeviceKeys{
+						DevEUI: d.DevEUI,
+						NwkKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+					}
+					err := CreateDeviceKeys(db, &dk)
+					So(errors.Cause(err), ShouldEqual, ErrNoKEKLabel)
+				})
+			})
+
+			Convey("When creating the device-keys", func() {
+				dk := DeviceKeys{
+					DevEUI: d.DevEUI,
+					NwkKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+					AppKey: lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1},
+				}
+				So(CreateDeviceKeys(db, &dk), ShouldBeNil)
+				dk.CreatedAt = dk.CreatedAt.UTC().Truncate(time.Millisecond)
+				dk.UpdatedAt = dk.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+				Convey("Then the keys are stored encrypted", func() {
+					var nwkKey []byte
+					So(db.Get(&nwkKey, "select nwk_key from device_keys where dev_eui = $1", d.DevEUI[:]), ShouldBeNil)
+					So(nwkKey, ShouldHaveLength, 24)
+				})
+
+				Convey("Then GetDeviceKeys returns the expected device-keys", func() {
+					dkGet, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldBeNil)
+
+					dkGet.CreatedAt = dkGet.CreatedAt.UTC().Truncate(time.Millisecond)
+					dkGet.UpdatedAt = dkGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+					So(dkGet, ShouldResemble, dk)
+				})
+
+				Convey("Then UpdateDeviceKeys updates the device-keys", func() {
+					dk.NwkKey = lorawan.AES128Key{2, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+					dk.AppKey = lorawan.AES128Key{2, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+					dk.JoinNonce = 10
+					So(UpdateDeviceKeys(db, &dk), ShouldBeNil)
+					dk.UpdatedAt = dk.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+					dkGet, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldBeNil)
+
+					dkGet.CreatedAt = dkGet.CreatedAt.UTC().Truncate(time.Millisecond)
+					dkGet.UpdatedAt = dkGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+					So(dkGet, ShouldResemble, dk)
+				})
+
+				Convey("Then UpdateDeviceKeys does not decrease the join-nonce", func() {
+					dk.JoinNonce = 10
+					So(UpdateDeviceKeys(db, &dk), ShouldBeNil)
+
+					dk.JoinNonce = 9
+					So(UpdateDeviceKeys(db, &dk), ShouldEqual, ErrJoinNonceDecreased)
+
+					dkGet, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(dkGet.JoinNonce, ShouldEqual, 10)
+				})
+
+				Convey("Then IncrementJoinNonce increments the join-nonce", func() {
+					joinNonce, err := IncrementJoinNonce(db, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(joinNonce, ShouldEqual, 1)
+
+					dk.JoinNonce = 16777215
+					So(UpdateDeviceKeys(db, &dk), ShouldBeNil)
+
+					joinNonce, err = IncrementJoinNonce(db, d.DevEUI)
+					So(err, ShouldBeNil)
+					So(joinNonce, ShouldEqual, 0)
+				})
+
+				Convey("Then GetDeviceKeysAppSKey returns ErrDoesNotExist", func() {
+					_, _, err := GetDeviceKeysAppSKey(db, d.DevEUI)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("When setting the AppSKey", func() {
+					appSKey := lorawan.AES128Key{3, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+					So(SetDeviceKeysAppSKey(db, d.DevEUI, []byte{1, 2, 3}, appSKey), ShouldBeNil)
+
+					Convey("Then GetDeviceKeysAppSKey returns the AppSKey", func() {
+						sessionKeyID, key, err := GetDeviceKeysAppSKey(db, d.DevEUI)
+						So(err, ShouldBeNil)
+						So(sessionKeyID, ShouldResemble, []byte{1, 2, 3})
+						So(key, ShouldEqual, appSKey)
+					})
+				})
+
+				Convey("Then DeleteDeviceKeys deletes the device-keys", func() {
+					So(DeleteDeviceKeys(db, d.DevEUI), ShouldBeNil)
+					So(DeleteDeviceKeys(db, d.DevEUI), ShouldEqual, ErrDoesNotExist)
+
+					_, err := GetDeviceKeys(db, d.DevEUI)
+					So(err, ShouldEqual, ErrDoesNotExist)
+				})
+			})
+		})
+	})
+}
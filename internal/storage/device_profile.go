@@ -9,6 +9,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/lorawan/backend"
 )
 
@@ -17,6 +18,48 @@ type DeviceProfile struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	backend.DeviceProfile
+
+	// RXDROffset1Set and RXDataRate2Set indicate that the RXDROffset1 and
+	// RXDataRate2 fields are set. As 0 is a valid value for both, the zero
+	// value can't be used to fall back to the configured defaults.
+	RXDROffset1Set bool `db:"rx_dr_offset_1_set"`
+	RXDataRate2Set bool `db:"rx_data_rate_2_set"`
+}
+
+// GetRXDelay1 returns the RX1 delay of the device-profile, or the configured
+// default when not set.
+func (dp DeviceProfile) GetRXDelay1() int {
+	if dp.DeviceProfile.RXDelay1 != 0 {
+		return dp.DeviceProfile.RXDelay1
+	}
+	return common.RX1Delay
+}
+
+// GetRXDROffset1 returns the RX1 data-rate offset of the device-profile, or
+// the configured default when not set.
+func (dp DeviceProfile) GetRXDROffset1() int {
+	if dp.RXDROffset1Set {
+		return dp.DeviceProfile.RXDROffset1
+	}
+	return common.RX1DROffset
+}
+
+// GetRXDataRate2 returns the RX2 data-rate of the device-profile, or the
+// configured default when not set.
+func (dp DeviceProfile) GetRXDataRate2() int {
+	if dp.RXDataRate2Set {
+		return dp.DeviceProfile.RXDataRate2
+	}
+	return common.RX2DR
+}
+
+// GetRXFreq2 returns the RX2 frequency of the device-profile, or the
+// default RX2 frequency of the band when not set.
+func (dp DeviceProfile) GetRXFreq2() int {
+	if dp.DeviceProfile.RXFreq2 != 0 {
+		return int(dp.DeviceProfile.RXFreq2)
+	}
+	return common.Band.RX2Frequency
 }
 
 // CreateDeviceProfile creates the given device-profile.
@@ -52,8 +95,10 @@ func CreateDeviceProfile(db *sqlx.DB, dp *DeviceProfile) error {
 			max_duty_cycle,
 			supports_join,
 			rf_region,
-			supports_32bit_fcnt
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			supports_32bit_fcnt,
+			rx_dr_offset_1_set,
+			rx_data_rate_2_set
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)`,
 		dp.CreatedAt,
 		dp.UpdatedAt,
 		dp.DeviceProfile.DeviceProfileID,
@@ -76,6 +121,8 @@ func CreateDeviceProfile(db *sqlx.DB, dp *DeviceProfile) error {
 		dp.DeviceProfile.SupportsJoin,
 		dp.DeviceProfile.RFRegion,
 		dp.DeviceProfile.Supports32bitFCnt,
+		dp.RXDROffset1Set,
+		dp.RXDataRate2Set,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -116,7 +163,9 @@ func GetDeviceProfile(db *sqlx.DB, id string) (DeviceProfile, error) {
 			max_duty_cycle,
 			supports_join,
 			rf_region,
-			supports_32bit_fcnt
+			supports_32bit_fcnt,
+			rx_dr_offset_1_set,
+			rx_data_rate_2_set
 		from device_profile
 		where
 			device_profile_id = $1
@@ -147,6 +196,8 @@ func GetDeviceProfile(db *sqlx.DB, id string) (DeviceProfile, error) {
 		&dp.DeviceProfile.SupportsJoin,
 		&dp.DeviceProfile.RFRegion,
 		&dp.DeviceProfile.Supports32bitFCnt,
+		&dp.RXDROffset1Set,
+		&dp.RXDataRate2Set,
 	)
 	if err != nil {
 		return dp, handlePSQLError(err, "select error")
@@ -185,7 +236,9 @@ func UpdateDeviceProfile(db *sqlx.DB, dp *DeviceProfile) error {
 			max_duty_cycle = $18,
 			supports_join = $19,
 			rf_region = $20,
-			supports_32bit_fcnt = $21
+			supports_32bit_fcnt = $21,
+			rx_dr_offset_1_set = $22,
+			rx_data_rate_2_set = $23
 		where
 			device_profile_id = $1`,
 		dp.DeviceProfile.DeviceProfileID,
@@ -209,6 +262,8 @@ func UpdateDeviceProfile(db *sqlx.DB, dp *DeviceProfile) error {
 		dp.DeviceProfile.SupportsJoin,
 		dp.DeviceProfile.RFRegion,
 		dp.DeviceProfile.Supports32bitFCnt,
+		dp.RXDROffset1Set,
+		dp.RXDataRate2Set,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -44,6 +44,8 @@ func TestDeviceProfile(t *testing.T) {
 					RFRegion:           backend.EU868,
 					Supports32bitFCnt:  true,
 				},
+				RXDROffset1Set: true,
+				RXDataRate2Set: true,
 			}
 
 			So(CreateDeviceProfile(db, &dp), ShouldBeNil)
@@ -87,6 +89,7 @@ func TestDeviceProfile(t *testing.T) {
 					RFRegion:           backend.US902,
 					Supports32bitFCnt:  false,
 				}
+				dp.RXDataRate2Set = false
 				So(UpdateDeviceProfile(db, &dp), ShouldBeNil)
 				dp.UpdatedAt = dp.UpdatedAt.UTC().Truncate(time.Millisecond)
 
@@ -100,3 +103,45 @@ func TestDeviceProfile(t *testing.T) {
 		})
 	})
 }
+
+func TestDeviceProfileRXSettings(t *testing.T) {
+	Convey("Given a set of default RX settings", t, func() {
+		common.RX1Delay = 1
+		common.RX1DROffset = 2
+		common.RX2DR = 3
+
+		Convey("Then an empty device-profile returns the defaults", func() {
+			dp := DeviceProfile{}
+			So(dp.GetRXDelay1(), ShouldEqual, 1)
+			So(dp.GetRXDROffset1(), ShouldEqual, 2)
+			So(dp.GetRXDataRate2(), ShouldEqual, 3)
+			So(dp.GetRXFreq2(), ShouldEqual, common.Band.RX2Frequency)
+		})
+
+		Convey("Then a device-profile with RX settings returns its own settings", func() {
+			dp := DeviceProfile{
+				DeviceProfile: backend.DeviceProfile{
+					RXDelay1:    5,
+					RXDROffset1: 1,
+					RXDataRate2: 4,
+					RXFreq2:     868900000,
+				},
+				RXDROffset1Set: true,
+				RXDataRate2Set: true,
+			}
+			So(dp.GetRXDelay1(), ShouldEqual, 5)
+			So(dp.GetRXDROffset1(), ShouldEqual, 1)
+			So(dp.GetRXDataRate2(), ShouldEqual, 4)
+			So(dp.GetRXFreq2(), ShouldEqual, 868900000)
+		})
+
+		Convey("Then a device-profile with RX settings set to 0 overrides the defaults", func() {
+			dp := DeviceProfile{
+				RXDROffset1Set: true,
+				RXDataRate2Set: true,
+			}
+			So(dp.GetRXDROffset1(), ShouldEqual, 0)
+			So(dp.GetRXDataRate2(), ShouldEqual, 0)
+		})
+	})
+}
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"bytes"
-	"crypto/rand"
 	"encoding/gob"
 	"fmt"
 	"time"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/devaddr"
 	"github.com/brocaar/lorawan"
 )
 
@@ -55,6 +55,15 @@ type DeviceSession struct {
 	// Only used by ABP activation
 	SkipFCntValidation bool
 
+	// SessionKeyID holds the SessionKeyID returned by the join-server,
+	// which is forwarded to the application-server so that it can request
+	// the AppSKey (AppSKeyReq).
+	SessionKeyID []byte
+
+	// HomeNetID holds the NetID of the home network-server when the device
+	// is served through handover roaming, nil otherwise.
+	HomeNetID *lorawan.NetID
+
 	RXWindow     RXWindow
 	RXDelay      uint8
 	RX1DROffset  uint8
@@ -137,19 +146,40 @@ func (s DeviceSession) GetPacketLossPercentage() float64 {
 	return float64(lostPackets) / float64(len(s.UplinkHistory)) * 100
 }
 
-// GetRandomDevAddr returns a random free DevAddr. Note that the 7 MSB will be
-// set to the NwkID (based on the configured NetID).
-func GetRandomDevAddr(p *redis.Pool, netID lorawan.NetID) (lorawan.DevAddr, error) {
-	var d lorawan.DevAddr
-	b := make([]byte, len(d))
-	if _, err := rand.Read(b); err != nil {
-		return d, errors.Wrap(err, "read random bytes error")
+// devAddrAllocationAttempts defines the number of random DevAddrs that are
+// tried before giving up.
+const devAddrAllocationAttempts = 10
+
+// GetRandomDevAddr returns a random DevAddr within the given ranges which is
+// not in use by an active device-session. When no ranges are given, the full
+// DevAddr range of the given NetID is used (taking the NetID type into
+// account).
+func GetRandomDevAddr(p *redis.Pool, netID lorawan.NetID, ranges []devaddr.Range) (lorawan.DevAddr, error) {
+	if len(ranges) == 0 {
+		ranges = []devaddr.Range{devaddr.NetIDRange(netID)}
+	}
+
+	c := p.Get()
+	defer c.Close()
+
+	for i := 0; i < devAddrAllocationAttempts; i++ {
+		d, err := devaddr.Random(ranges)
+		if err != nil {
+			return d, errors.Wrap(err, "get random DevAddr error")
+		}
+
+		inUse, err := redis.Bool(c.Do("EXISTS", fmt.Sprintf(devAddrKeyTempl, d)))
+		if err != nil {
+			return d, errors.Wrap(err, "get exists error")
+		}
+		if !inUse {
+			return d, nil
+		}
+
+		log.WithField("dev_addr", d).Debug("random DevAddr is in use, retrying")
 	}
-	copy(d[:], b)
-	d[0] = d[0] & 1                    // zero out 7 msb
-	d[0] = d[0] ^ (netID.NwkID() << 1) // set 7 msb to NwkID
 
-	return d, nil
+	return lorawan.DevAddr{}, ErrNoFreeDevAddr
 }
 
 // ValidateAndGetFullFCntUp validates if the given fCntUp is valid
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/devaddr"
 	"github.com/brocaar/loraserver/internal/test"
 	"github.com/brocaar/lorawan"
 	. "github.com/smartystreets/goconvey/convey"
@@ -21,7 +22,7 @@ func TestGetRandomDevAddr(t *testing.T) {
 		Convey("When calling getRandomDevAddr many times, it should always return an unique DevAddr", func() {
 			log := make(map[lorawan.DevAddr]struct{})
 			for i := 0; i < 1000; i++ {
-				devAddr, err := GetRandomDevAddr(p, netID)
+				devAddr, err := GetRandomDevAddr(p, netID, nil)
 				if err != nil {
 					t.Fatal(err)
 				}
@@ -34,6 +35,36 @@ func TestGetRandomDevAddr(t *testing.T) {
 				log[devAddr] = struct{}{}
 			}
 		})
+
+		Convey("Given a DevAddr range of two addresses of which one is in use", func() {
+			ranges := []devaddr.Range{
+				{Start: lorawan.DevAddr{0x06, 0x00, 0x00, 0x01}, End: lorawan.DevAddr{0x06, 0x00, 0x00, 0x02}},
+			}
+			So(SaveDeviceSession(p, DeviceSession{
+				DevAddr: lorawan.DevAddr{0x06, 0x00, 0x00, 0x01},
+				DevEUI:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			}), ShouldBeNil)
+
+			Convey("Then GetRandomDevAddr always returns the free DevAddr", func() {
+				for i := 0; i < 10; i++ {
+					devAddr, err := GetRandomDevAddr(p, netID, ranges)
+					So(err, ShouldBeNil)
+					So(devAddr, ShouldEqual, lorawan.DevAddr{0x06, 0x00, 0x00, 0x02})
+				}
+			})
+
+			Convey("When the other address is in use too", func() {
+				So(SaveDeviceSession(p, DeviceSession{
+					DevAddr: lorawan.DevAddr{0x06, 0x00, 0x00, 0x02},
+					DevEUI:  lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8},
+				}), ShouldBeNil)
+
+				Convey("Then GetRandomDevAddr returns ErrNoFreeDevAddr", func() {
+					_, err := GetRandomDevAddr(p, netID, ranges)
+					So(err, ShouldEqual, ErrNoFreeDevAddr)
+				})
+			})
+		})
 	})
 }
 
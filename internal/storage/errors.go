@@ -12,6 +12,10 @@ var (
 	ErrAlreadyExists                  = errors.New("object already exists")
 	ErrDoesNotExist                   = errors.New("object does not exist")
 	ErrDoesNotExistOrFCntOrMICInvalid = errors.New("device-session does not exist or invalid fcnt or mic")
+	ErrNoFreeDevAddr                  = errors.New("no free DevAddr available")
+	ErrInvalidFCnt                    = errors.New("invalid frame-counter")
+	ErrNoKEKLabel                     = errors.New("kek label for encrypting the device-keys is not configured")
+	ErrJoinNonceDecreased             = errors.New("join-nonce must not be lower than the current join-nonce")
 )
 
 func handlePSQLError(err error, description string) error {
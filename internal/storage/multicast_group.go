@@ -0,0 +1,325 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/brocaar/lorawan"
+)
+
+// MulticastGroup defines a multicast-group. All devices within the group
+// share the same McAddr and McNwkSKey and must operate in Class-C mode.
+type MulticastGroup struct {
+	ID               string            `db:"id"`
+	CreatedAt        time.Time         `db:"created_at"`
+	UpdatedAt        time.Time         `db:"updated_at"`
+	MCAddr           lorawan.DevAddr   `db:"mc_addr"`
+	MCNwkSKey        lorawan.AES128Key `db:"mc_nwk_s_key"`
+	FCnt             uint32            `db:"f_cnt"`
+	DR               int               `db:"dr"`
+	Frequency        int               `db:"frequency"`
+	ServiceProfileID string            `db:"service_profile_id"`
+}
+
+// CreateMulticastGroup creates the given multicast-group.
+func CreateMulticastGroup(db sqlx.Execer, mg *MulticastGroup) error {
+	now := time.Now()
+	if mg.ID == "" {
+		mg.ID = uuid.NewV4().String()
+	}
+	mg.CreatedAt = now
+	mg.UpdatedAt = now
+
+	_, err := db.Exec(`
+		insert into multicast_group (
+			id,
+			created_at,
+			updated_at,
+			mc_addr,
+			mc_nwk_s_key,
+			f_cnt,
+			dr,
+			frequency,
+			service_profile_id
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		mg.ID,
+		mg.CreatedAt,
+		mg.UpdatedAt,
+		mg.MCAddr[:],
+		mg.MCNwkSKey[:],
+		mg.FCnt,
+		mg.DR,
+		mg.Frequency,
+		mg.ServiceProfileID,
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"id":      mg.ID,
+		"mc_addr": mg.MCAddr,
+	}).Info("multicast-group created")
+
+	return nil
+}
+
+// GetMulticastGroup returns the multicast-group matching the given id.
+func GetMulticastGroup(db sqlx.Queryer, id string) (MulticastGroup, error) {
+	var mg MulticastGroup
+	err := sqlx.Get(db, &mg, "select * from multicast_group where id = $1", id)
+	if err != nil {
+		return mg, handlePSQLError(err, "select error")
+	}
+
+	return mg, nil
+}
+
+// UpdateMulticastGroup updates the given multicast-group. The frame-counter
+// is never decreased, as this would re-use frame-counter values for the same
+// McNwkSKey.
+func UpdateMulticastGroup(db sqlx.Execer, mg *MulticastGroup) error {
+	mg.UpdatedAt = time.Now()
+	res, err := db.Exec(`
+		update multicast_group set
+			updated_at = $2,
+			mc_addr = $3,
+			mc_nwk_s_key = $4,
+			f_cnt = greatest(f_cnt, $5),
+			dr = $6,
+			frequency = $7,
+			service_profile_id = $8
+		where
+			id = $1`,
+		mg.ID,
+		mg.UpdatedAt,
+		mg.MCAddr[:],
+		mg.MCNwkSKey[:],
+		mg.FCnt,
+		mg.DR,
+		mg.Frequency,
+		mg.ServiceProfileID,
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", mg.ID).Info("multicast-group updated")
+	return nil
+}
+
+// IncrementMulticastGroupFCnt increments the frame-counter of the given
+// multicast-group, given its current value is fCnt. As this is done within
+// a single statement (compare-and-swap), concurrent downlinks can never use
+// the same frame-counter. ErrInvalidFCnt is returned when the frame-counter
+// has already been used.
+func IncrementMulticastGroupFCnt(db sqlx.Execer, id string, fCnt uint32) error {
+	res, err := db.Exec(`
+		update multicast_group set
+			f_cnt = f_cnt + 1,
+			updated_at = $3
+		where
+			id = $1
+			and f_cnt = $2`,
+		id,
+		fCnt,
+		time.Now(),
+	)
+	if err != nil {
+		return handlePSQLError(err, "update error")
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrInvalidFCnt
+	}
+	return nil
+}
+
+// DeleteMulticastGroup deletes the multicast-group matching the given id.
+func DeleteMulticastGroup(db sqlx.Execer, id string) error {
+	res, err := db.Exec("delete from multicast_group where id = $1", id)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithField("id", id).Info("multicast-group deleted")
+	return nil
+}
+
+// AddDeviceToMulticastGroup adds the given device to the given
+// multicast-group.
+func AddDeviceToMulticastGroup(db sqlx.Execer, devEUI lorawan.EUI64, multicastGroupID string) error {
+	_, err := db.Exec(`
+		insert into device_multicast_group (
+			dev_eui,
+			multicast_group_id,
+			created_at
+		) values ($1, $2, $3)`,
+		devEUI[:],
+		multicastGroupID,
+		time.Now(),
+	)
+	if err != nil {
+		return handlePSQLError(err, "insert error")
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            devEUI,
+		"multicast_group_id": multicastGroupID,
+	}).Info("device added to multicast-group")
+
+	return nil
+}
+
+// RemoveDeviceFromMulticastGroup removes the given device from the given
+// multicast-group.
+func RemoveDeviceFromMulticastGroup(db sqlx.Execer, devEUI lorawan.EUI64, multicastGroupID string) error {
+	res, err := db.Exec(`
+		delete from device_multicast_group
+		where
+			dev_eui = $1
+			and multicast_group_id = $2`,
+		devEUI[:],
+		multicastGroupID,
+	)
+	if err != nil {
+		return handlePSQLError(err, "delete error")
+	}
+
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return handlePSQLError(err, "get rows affected error")
+	}
+	if ra == 0 {
+		return ErrDoesNotExist
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":            devEUI,
+		"multicast_group_id": multicastGroupID,
+	}).Info("device removed from multicast-group")
+
+	return nil
+}
+
+// GetDevEUIsForMulticastGroup returns the DevEUIs of the devices within the
+// given multicast-group.
+func GetDevEUIsForMulticastGroup(db sqlx.Queryer, multicastGroupID string) ([]lorawan.EUI64, error) {
+	var out []lorawan.EUI64
+	err := sqlx.Select(db, &out, `
+		select
+			dev_eui
+		from
+			device_multicast_group
+		where
+			multicast_group_id = $1
+		order by
+			dev_eui`,
+		multicastGroupID,
+	)
+	if err != nil {
+		return nil, handlePSQLError(err, "select error")
+	}
+
+	return out, nil
+}
+
+// GetGatewaysForMulticastGroup returns the set of gateway MACs to use for
+// transmitting to the given multicast-group. It is based on the LastRXInfoSet
+// of the device-sessions of the group members and returns the minimal set of
+// gateways (greedy) covering all members that have a device-session with
+// RX meta-data.
+func GetGatewaysForMulticastGroup(db sqlx.Queryer, p *redis.Pool, multicastGroupID string) ([]lorawan.EUI64, error) {
+	devEUIs, err := GetDevEUIsForMulticastGroup(db, multicastGroupID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get deveuis for multicast-group error")
+	}
+
+	// gateway mac => set of devices it is able to reach
+	coverage := make(map[lorawan.EUI64]map[lorawan.EUI64]struct{})
+	uncovered := make(map[lorawan.EUI64]struct{})
+
+	for _, devEUI := range devEUIs {
+		ds, err := GetDeviceSession(p, devEUI)
+		if err != nil {
+			if errors.Cause(err) == ErrDoesNotExist {
+				continue
+			}
+			return nil, errors.Wrap(err, "get device-session error")
+		}
+
+		for _, rxInfo := range ds.LastRXInfoSet {
+			if _, ok := coverage[rxInfo.MAC]; !ok {
+				coverage[rxInfo.MAC] = make(map[lorawan.EUI64]struct{})
+			}
+			coverage[rxInfo.MAC][devEUI] = struct{}{}
+			uncovered[devEUI] = struct{}{}
+		}
+	}
+
+	var out []lorawan.EUI64
+	for len(uncovered) > 0 {
+		var bestMAC lorawan.EUI64
+		var bestCount int
+
+		for mac, devices := range coverage {
+			var count int
+			for devEUI := range devices {
+				if _, ok := uncovered[devEUI]; ok {
+					count++
+				}
+			}
+
+			// on equal count, prefer the lowest MAC so that the outcome is
+			// deterministic
+			if count > bestCount || (count == bestCount && count > 0 && lessEUI64(mac, bestMAC)) {
+				bestMAC = mac
+				bestCount = count
+			}
+		}
+
+		if bestCount == 0 {
+			break
+		}
+
+		for devEUI := range coverage[bestMAC] {
+			delete(uncovered, devEUI)
+		}
+		delete(coverage, bestMAC)
+		out = append(out, bestMAC)
+	}
+
+	return out, nil
+}
+
+func lessEUI64(a, b lorawan.EUI64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brocaar/lorawan"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/test"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMulticastGroup(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean database", t, func() {
+		test.MustResetDB(common.DB)
+		test.MustFlushRedis(common.RedisPool)
+
+		Convey("Given a service, device and routing profile", func() {
+			sp := ServiceProfile{}
+			So(CreateServiceProfile(db, &sp), ShouldBeNil)
+
+			dp := DeviceProfile{}
+			So(CreateDeviceProfile(db, &dp), ShouldBeNil)
+
+			rp := RoutingProfile{}
+			So(CreateRoutingProfile(db, &rp), ShouldBeNil)
+
+			Convey("When creating a multicast-group", func() {
+				mg := MulticastGroup{
+					MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+					MCNwkSKey:        lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+					FCnt:             10,
+					DR:               5,
+					Frequency:        869525000,
+					ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+				}
+				So(CreateMulticastGroup(db, &mg), ShouldBeNil)
+				mg.CreatedAt = mg.CreatedAt.UTC().Truncate(time.Millisecond)
+				mg.UpdatedAt = mg.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+				Convey("Then GetMulticastGroup returns the expected multicast-group", func() {
+					mgGet, err := GetMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+
+					mgGet.CreatedAt = mgGet.CreatedAt.UTC().Truncate(time.Millisecond)
+					mgGet.UpdatedAt = mgGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+					So(mgGet, ShouldResemble, mg)
+				})
+
+				Convey("Then UpdateMulticastGroup updates the multicast-group", func() {
+					mg.MCAddr = lorawan.DevAddr{4, 3, 2, 1}
+					mg.MCNwkSKey = lorawan.AES128Key{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}
+					mg.FCnt = 11
+					mg.DR = 3
+					mg.Frequency = 868100000
+					So(UpdateMulticastGroup(db, &mg), ShouldBeNil)
+					mg.UpdatedAt = mg.UpdatedAt.UTC().Truncate(time.Millisecond)
+
+					mgGet, err := GetMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+
+					mgGet.CreatedAt = mgGet.CreatedAt.UTC().Truncate(time.Millisecond)
+					mgGet.UpdatedAt = mgGet.UpdatedAt.UTC().Truncate(time.Millisecond)
+					So(mgGet, ShouldResemble, mg)
+				})
+
+				Convey("Then UpdateMulticastGroup does not decrease the frame-counter", func() {
+					mg.FCnt = 5
+					So(UpdateMulticastGroup(db, &mg), ShouldBeNil)
+
+					mgGet, err := GetMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(mgGet.FCnt, ShouldEqual, 10)
+				})
+
+				Convey("Then IncrementMulticastGroupFCnt increments the frame-counter only once per value", func() {
+					So(IncrementMulticastGroupFCnt(db, mg.ID, 10), ShouldBeNil)
+					So(IncrementMulticastGroupFCnt(db, mg.ID, 10), ShouldEqual, ErrInvalidFCnt)
+
+					mgGet, err := GetMulticastGroup(db, mg.ID)
+					So(err, ShouldBeNil)
+					So(mgGet.FCnt, ShouldEqual, 11)
+				})
+
+				Convey("Then DeleteMulticastGroup deletes the multicast-group", func() {
+					So(DeleteMulticastGroup(db, mg.ID), ShouldBeNil)
+					So(DeleteMulticastGroup(db, mg.ID), ShouldEqual, ErrDoesNotExist)
+				})
+
+				Convey("Given two devices", func() {
+					devices := []Device{
+						{DevEUI: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}},
+						{DevEUI: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+					}
+					for i := range devices {
+						devices[i].ServiceProfileID = sp.ServiceProfile.ServiceProfileID
+						devices[i].DeviceProfileID = dp.DeviceProfile.DeviceProfileID
+						devices[i].RoutingProfileID = rp.RoutingProfile.RoutingProfileID
+						So(CreateDevice(db, &devices[i]), ShouldBeNil)
+					}
+
+					Convey("When adding the devices to the multicast-group", func() {
+						for _, d := range devices {
+							So(AddDeviceToMulticastGroup(db, d.DevEUI, mg.ID), ShouldBeNil)
+						}
+
+						Convey("Then adding the same device again returns an error", func() {
+							So(AddDeviceToMulticastGroup(db, devices[0].DevEUI, mg.ID), ShouldEqual, ErrAlreadyExists)
+						})
+
+						Convey("Then GetDevEUIsForMulticastGroup returns both DevEUIs", func() {
+							devEUIs, err := GetDevEUIsForMulticastGroup(db, mg.ID)
+							So(err, ShouldBeNil)
+							So(devEUIs, ShouldResemble, []lorawan.EUI64{devices[0].DevEUI, devices[1].DevEUI})
+						})
+
+						Convey("Then RemoveDeviceFromMulticastGroup removes the device", func() {
+							So(RemoveDeviceFromMulticastGroup(db, devices[0].DevEUI, mg.ID), ShouldBeNil)
+							So(RemoveDeviceFromMulticastGroup(db, devices[0].DevEUI, mg.ID), ShouldEqual, ErrDoesNotExist)
+
+							devEUIs, err := GetDevEUIsForMulticastGroup(db, mg.ID)
+							So(err, ShouldBeNil)
+							So(devEUIs, ShouldResemble, []lorawan.EUI64{devices[1].DevEUI})
+						})
+
+						Convey("Then GetGatewaysForMulticastGroup returns no gateways when there are no device-sessions", func() {
+							macs, err := GetGatewaysForMulticastGroup(db, common.RedisPool, mg.ID)
+							So(err, ShouldBeNil)
+							So(macs, ShouldHaveLength, 0)
+						})
+
+						Convey("Given device-sessions with a LastRXInfoSet", func() {
+							ds1 := DeviceSession{
+								DevEUI: devices[0].DevEUI,
+								LastRXInfoSet: []gw.RXInfo{
+									{MAC: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}},
+									{MAC: lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}},
+								},
+							}
+							ds2 := DeviceSession{
+								DevEUI: devices[1].DevEUI,
+								LastRXInfoSet: []gw.RXInfo{
+									{MAC: lorawan.EUI64{2, 2, 2, 2, 2, 2, 2, 2}},
+									{MAC: lorawan.EUI64{3, 3, 3, 3, 3, 3, 3, 3}},
+								},
+							}
+							So(SaveDeviceSession(common.RedisPool, ds1), ShouldBeNil)
+							So(SaveDeviceSession(common.RedisPool, ds2), ShouldBeNil)
+
+							Convey("Then GetGatewaysForMulticastGroup returns the gateway covering both devices", func() {
+								macs, err := GetGatewaysForMulticastGroup(db, common.RedisPool, mg.ID)
+								So(err, ShouldBeNil)
+								So(macs, ShouldResemble, []lorawan.EUI64{{3, 3, 3, 3, 3, 3, 3, 3}})
+							})
+						})
+					})
+				})
+			})
+		})
+	})
+}
@@ -1,20 +1,59 @@
 package storage
 
 import (
+	"database/sql/driver"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
 	"github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/brocaar/loraserver/internal/devaddr"
 	"github.com/brocaar/lorawan/backend"
 )
 
+// DevAddrRanges defines a list of DevAddr ranges.
+type DevAddrRanges []devaddr.Range
+
+// Value implements driver.Valuer.
+func (r DevAddrRanges) Value() (driver.Value, error) {
+	a := make(pq.StringArray, len(r))
+	for i := range r {
+		a[i] = r[i].String()
+	}
+	return a.Value()
+}
+
+// Scan implements sql.Scanner.
+func (r *DevAddrRanges) Scan(src interface{}) error {
+	var a pq.StringArray
+	if err := a.Scan(src); err != nil {
+		return errors.Wrap(err, "scan string array error")
+	}
+
+	*r = nil
+	for _, s := range a {
+		dr, err := devaddr.ParseRange(s)
+		if err != nil {
+			return errors.Wrap(err, "parse DevAddr range error")
+		}
+		*r = append(*r, dr)
+	}
+	return nil
+}
+
 // ServiceProfile defines the backend.ServiceProfile with some extra meta-data.
 type ServiceProfile struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	backend.ServiceProfile
+
+	// DevAddrRanges contains the DevAddr ranges used for the activation of
+	// devices using this service-profile. When empty, the network-server
+	// DevAddr ranges are used.
+	DevAddrRanges DevAddrRanges `db:"dev_addr_ranges"`
 }
 
 // CreateServiceProfile creates the given service-profile.
@@ -50,8 +89,9 @@ func CreateServiceProfile(db *sqlx.DB, sp *ServiceProfile) error {
 			ra_allowed,
 			nwk_geo_loc,
 			target_per,
-			min_gw_diversity
-		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			min_gw_diversity,
+			dev_addr_ranges
+		) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
 		sp.CreatedAt,
 		sp.UpdatedAt,
 		sp.ServiceProfile.ServiceProfileID,
@@ -74,6 +114,7 @@ func CreateServiceProfile(db *sqlx.DB, sp *ServiceProfile) error {
 		sp.ServiceProfile.NwkGeoLoc,
 		sp.ServiceProfile.TargetPER,
 		sp.ServiceProfile.MinGWDiversity,
+		sp.DevAddrRanges,
 	)
 	if err != nil {
 		return handlePSQLError(err, "insert error")
@@ -123,7 +164,8 @@ func UpdateServiceProfile(db *sqlx.DB, sp *ServiceProfile) error {
 			ra_allowed = $18,
 			nwk_geo_loc = $19,
 			target_per = $20,
-			min_gw_diversity = $21
+			min_gw_diversity = $21,
+			dev_addr_ranges = $22
 		where
 			service_profile_id = $1`,
 		sp.ServiceProfile.ServiceProfileID,
@@ -147,6 +189,7 @@ func UpdateServiceProfile(db *sqlx.DB, sp *ServiceProfile) error {
 		sp.ServiceProfile.NwkGeoLoc,
 		sp.ServiceProfile.TargetPER,
 		sp.ServiceProfile.MinGWDiversity,
+		sp.DevAddrRanges,
 	)
 	if err != nil {
 		return handlePSQLError(err, "update error")
@@ -6,6 +6,7 @@ import (
 
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -44,6 +45,9 @@ func TestServiceProfile(t *testing.T) {
 					TargetPER:      1,
 					MinGWDiversity: 8,
 				},
+				DevAddrRanges: DevAddrRanges{
+					{Start: lorawan.DevAddr{0x06, 0x00, 0x00, 0x00}, End: lorawan.DevAddr{0x06, 0x00, 0xff, 0xff}},
+				},
 			}
 
 			So(CreateServiceProfile(db, &sp), ShouldBeNil)
@@ -82,6 +86,10 @@ func TestServiceProfile(t *testing.T) {
 					TargetPER:      2,
 					MinGWDiversity: 9,
 				}
+				sp.DevAddrRanges = DevAddrRanges{
+					{Start: lorawan.DevAddr{0x06, 0x01, 0x00, 0x00}, End: lorawan.DevAddr{0x06, 0x01, 0x00, 0xff}},
+					{Start: lorawan.DevAddr{0x06, 0x02, 0x00, 0x00}, End: lorawan.DevAddr{0x06, 0x02, 0x00, 0xff}},
+				}
 				So(UpdateServiceProfile(db, &sp), ShouldBeNil)
 				sp.UpdatedAt = sp.UpdatedAt.UTC().Truncate(time.Millisecond)
 
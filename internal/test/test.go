@@ -111,6 +111,9 @@ type GatewayBackend struct {
 	rxPacketChan    chan gw.RXPacket
 	TXPacketChan    chan gw.TXPacket
 	statsPacketChan chan gw.GatewayStatsPacket
+	txAckChan       chan gw.TXAck
+
+	GatewayConfigPacketChan chan gw.GatewayConfigPacket
 }
 
 // NewGatewayBackend returns a new GatewayBackend.
@@ -118,6 +121,8 @@ func NewGatewayBackend() *GatewayBackend {
 	return &GatewayBackend{
 		rxPacketChan: make(chan gw.RXPacket, 100),
 		TXPacketChan: make(chan gw.TXPacket, 100),
+
+		GatewayConfigPacketChan: make(chan gw.GatewayConfigPacket, 100),
 	}
 }
 
@@ -127,6 +132,12 @@ func (b *GatewayBackend) SendTXPacket(txPacket gw.TXPacket) error {
 	return nil
 }
 
+// SendGatewayConfigPacket method.
+func (b *GatewayBackend) SendGatewayConfigPacket(configPacket gw.GatewayConfigPacket) error {
+	b.GatewayConfigPacketChan <- configPacket
+	return nil
+}
+
 // RXPacketChan method.
 func (b *GatewayBackend) RXPacketChan() chan gw.RXPacket {
 	return b.rxPacketChan
@@ -137,6 +148,11 @@ func (b *GatewayBackend) StatsPacketChan() chan gw.GatewayStatsPacket {
 	return b.statsPacketChan
 }
 
+// TXAckChan method.
+func (b *GatewayBackend) TXAckChan() chan gw.TXAck {
+	return b.txAckChan
+}
+
 // Close method.
 func (b *GatewayBackend) Close() error {
 	if b.rxPacketChan != nil {
@@ -179,7 +195,7 @@ func NewJoinServerClient() *JoinServerClient {
 }
 
 // JoinReq method.
-func (c *JoinServerClient) JoinReq(pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
+func (c *JoinServerClient) JoinReq(ctx context.Context, pl backend.JoinReqPayload) (backend.JoinAnsPayload, error) {
 	c.JoinReqPayloadChan <- pl
 	return c.JoinAnsPayload, c.JoinReqError
 }
@@ -275,18 +291,21 @@ func (t *ApplicationClient) HandleError(ctx context.Context, in *as.HandleErrorR
 
 // NetworkControllerClient is a network-controller client for testing.
 type NetworkControllerClient struct {
-	HandleRXInfoChan           chan nc.HandleRXInfoRequest
-	HandleDataUpMACCommandChan chan nc.HandleDataUpMACCommandRequest
+	HandleRXInfoChan             chan nc.HandleRXInfoRequest
+	HandleDataUpMACCommandChan   chan nc.HandleDataUpMACCommandRequest
+	HandleGatewayStateChangeChan chan nc.HandleGatewayStateChangeRequest
 
-	HandleRXInfoResponse           nc.HandleRXInfoResponse
-	HandleDataUpMACCommandResponse nc.HandleDataUpMACCommandResponse
+	HandleRXInfoResponse             nc.HandleRXInfoResponse
+	HandleDataUpMACCommandResponse   nc.HandleDataUpMACCommandResponse
+	HandleGatewayStateChangeResponse nc.HandleGatewayStateChangeResponse
 }
 
 // NewNetworkControllerClient returns a new NetworkControllerClient.
 func NewNetworkControllerClient() *NetworkControllerClient {
 	return &NetworkControllerClient{
-		HandleRXInfoChan:           make(chan nc.HandleRXInfoRequest, 100),
-		HandleDataUpMACCommandChan: make(chan nc.HandleDataUpMACCommandRequest, 100),
+		HandleRXInfoChan:             make(chan nc.HandleRXInfoRequest, 100),
+		HandleDataUpMACCommandChan:   make(chan nc.HandleDataUpMACCommandRequest, 100),
+		HandleGatewayStateChangeChan: make(chan nc.HandleGatewayStateChangeRequest, 100),
 	}
 }
 
@@ -301,3 +320,9 @@ func (t *NetworkControllerClient) HandleDataUpMACCommand(ctx context.Context, in
 	t.HandleDataUpMACCommandChan <- *in
 	return &t.HandleDataUpMACCommandResponse, nil
 }
+
+// HandleGatewayStateChange method.
+func (t *NetworkControllerClient) HandleGatewayStateChange(ctx context.Context, in *nc.HandleGatewayStateChangeRequest, opts ...grpc.CallOption) (*nc.HandleGatewayStateChangeResponse, error) {
+	t.HandleGatewayStateChangeChan <- *in
+	return &t.HandleGatewayStateChangeResponse, nil
+}
@@ -0,0 +1,551 @@
+package testsuite
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backendapi"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/roaming"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/loraserver/internal/uplink"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestHandoverRoamingScenarios(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+	common.NetID = lorawan.NetID{3, 2, 1}
+	defer func() {
+		common.RoamingPool = nil
+		common.RoamingHandoverLifetime = 0
+	}()
+
+	Convey("Given a clean state and a roaming partner", t, func() {
+		test.MustResetDB(common.DB)
+		test.MustFlushRedis(common.RedisPool)
+
+		// the roaming partner (hNS or sNS, depending on the test)
+		peerRequests := make(chan []byte, 10)
+		peerResponses := make(map[backend.MessageType]interface{})
+		peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			peerRequests <- b
+
+			var basePL backend.BasePayload
+			json.Unmarshal(b, &basePL)
+			json.NewEncoder(w).Encode(peerResponses[basePL.MessageType])
+		}))
+		defer peer.Close()
+
+		peerNetID := lorawan.NetID{1, 2, 3}
+		client, err := roaming.NewClient(roaming.AgreementConfig{
+			NetID:  peerNetID,
+			Server: peer.URL,
+			JoinEUIRanges: []roaming.JoinEUIRange{
+				{Start: lorawan.EUI64{8, 7, 6, 5, 0, 0, 0, 0}, End: lorawan.EUI64{8, 7, 6, 5, 255, 255, 255, 255}},
+			},
+		}, common.NetID, nil)
+		So(err, ShouldBeNil)
+		common.RoamingPool = roaming.NewPool(client)
+		common.RoamingHandoverLifetime = time.Hour
+
+		asClient := test.NewApplicationClient()
+		jsClient := test.NewJoinServerClient()
+		common.ApplicationServerPool = test.NewApplicationServerPool(asClient)
+		common.JoinServerPool = test.NewJoinServerPool(jsClient)
+		common.Controller = test.NewNetworkControllerClient()
+		gwBackend := test.NewGatewayBackend()
+		common.Gateway = gwBackend
+
+		apiServer := httptest.NewServer(withClientCertificate(peerNetID.String(), backendapi.NewAPI(backendapi.Config{Roaming: true})))
+		defer apiServer.Close()
+
+		appKey := lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+		nwkSKey := lorawan.AES128Key{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+		devEUI := lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8}
+		joinEUI := lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}
+
+		jrPHY := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinRequest,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinRequestPayload{
+				AppEUI:   joinEUI,
+				DevEUI:   devEUI,
+				DevNonce: lorawan.DevNonce{1, 2},
+			},
+		}
+		So(jrPHY.SetMIC(appKey), ShouldBeNil)
+		jrBytes, err := jrPHY.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		jaPHY := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinAccept,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinAcceptPayload{
+				AppNonce: [3]byte{3, 2, 1},
+				NetID:    peerNetID,
+				DevAddr:  lorawan.DevAddr{0x06, 2, 3, 4},
+			},
+		}
+		So(jaPHY.SetMIC(appKey), ShouldBeNil)
+		So(jaPHY.EncryptJoinAcceptPayload(appKey), ShouldBeNil)
+		jaBytes, err := jaPHY.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		Convey("Given a device of this network (hNS)", func() {
+			sp := storage.ServiceProfile{
+				ServiceProfile: backend.ServiceProfile{
+					HRAllowed: true,
+					RAAllowed: true,
+				},
+			}
+			So(storage.CreateServiceProfile(common.DB, &sp), ShouldBeNil)
+			dp := storage.DeviceProfile{
+				DeviceProfile: backend.DeviceProfile{
+					SupportsJoin: true,
+					MACVersion:   "1.0.2",
+				},
+			}
+			So(storage.CreateDeviceProfile(common.DB, &dp), ShouldBeNil)
+			rp := storage.RoutingProfile{}
+			So(storage.CreateRoutingProfile(common.DB, &rp), ShouldBeNil)
+			d := storage.Device{
+				DevEUI:           devEUI,
+				DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+				ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+				RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+			}
+			So(storage.CreateDevice(common.DB, &d), ShouldBeNil)
+
+			jsClient.JoinAnsPayload = backend.JoinAnsPayload{
+				PHYPayload: backend.HEXBytes(jaBytes),
+				Result:     backend.Result{ResultCode: backend.Success},
+				NwkSKey:    &backend.KeyEnvelope{AESKey: nwkSKey},
+			}
+
+			basePL := backend.BasePayload{
+				ProtocolVersion: backend.ProtocolVersion1_0,
+				SenderID:        peerNetID.String(),
+				ReceiverID:      common.NetID.String(),
+				TransactionID:   1234,
+			}
+
+			Convey("When the sNS sends a ProfileReq", func() {
+				basePL.MessageType = backend.ProfileReq
+				var ans backend.ProfileAnsPayload
+				So(postBackendAPI(apiServer.URL, backend.ProfileReqPayload{
+					BasePayload: basePL,
+					DevEUI:      devEUI,
+				}, &ans), ShouldBeNil)
+
+				Convey("Then the device-profile and the Handover activation type are returned", func() {
+					So(ans.MessageType, ShouldEqual, backend.ProfileAns)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					So(ans.DeviceProfile.DeviceProfileID, ShouldEqual, dp.DeviceProfile.DeviceProfileID)
+					So(*ans.RoamingActivationType, ShouldEqual, backend.Handover)
+				})
+			})
+
+			Convey("When the sNS sends a ProfileReq for a device not allowed to roam", func() {
+				sp.ServiceProfile.RAAllowed = false
+				So(storage.UpdateServiceProfile(common.DB, &sp), ShouldBeNil)
+
+				basePL.MessageType = backend.ProfileReq
+				var ans backend.ProfileAnsPayload
+				So(postBackendAPI(apiServer.URL, backend.ProfileReqPayload{
+					BasePayload: basePL,
+					DevEUI:      devEUI,
+				}, &ans), ShouldBeNil)
+
+				Convey("Then a DevRoamingDisallowed result is returned", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.DevRoamingDisallowed)
+				})
+			})
+
+			Convey("When the sNS sends a ProfileReq for an unknown device", func() {
+				basePL.MessageType = backend.ProfileReq
+				var ans backend.ProfileAnsPayload
+				So(postBackendAPI(apiServer.URL, backend.ProfileReqPayload{
+					BasePayload: basePL,
+					DevEUI:      lorawan.EUI64{8, 8, 8, 8, 8, 8, 8, 8},
+				}, &ans), ShouldBeNil)
+
+				Convey("Then an UnknownDevEUI result is returned", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.UnknownDevEUI)
+				})
+			})
+
+			Convey("When the sNS sends a HRStartReq for a device without HRAllowed", func() {
+				sp.ServiceProfile.HRAllowed = false
+				So(storage.UpdateServiceProfile(common.DB, &sp), ShouldBeNil)
+
+				basePL.MessageType = backend.HRStartReq
+				var ans roaming.HRStartAnsPayload
+				So(postBackendAPI(apiServer.URL, backend.HRStartReqPayload{
+					BasePayload: basePL,
+					PHYPayload:  backend.HEXBytes(jrBytes),
+					DevAddr:     lorawan.DevAddr{0x06, 2, 3, 4},
+				}, &ans), ShouldBeNil)
+
+				Convey("Then a DevRoamingDisallowed result is returned", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.DevRoamingDisallowed)
+					So(jsClient.JoinReqPayloadChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("Given the device has a device-session", func() {
+				So(storage.SaveDeviceSession(common.RedisPool, storage.DeviceSession{
+					DevEUI:  devEUI,
+					DevAddr: lorawan.DevAddr{0x02, 2, 3, 4},
+				}), ShouldBeNil)
+
+				Convey("When the sNS sends a HRStartReq", func() {
+					basePL.MessageType = backend.HRStartReq
+					var ans roaming.HRStartAnsPayload
+					So(postBackendAPI(apiServer.URL, backend.HRStartReqPayload{
+						BasePayload: basePL,
+						PHYPayload:  backend.HEXBytes(jrBytes),
+						DevAddr:     lorawan.DevAddr{0x06, 2, 3, 4},
+						RxDelay:     1,
+					}, &ans), ShouldBeNil)
+
+					Convey("Then the join-request was sent to the join-server using the DevAddr of the sNS", func() {
+						So(jsClient.JoinReqPayloadChan, ShouldHaveLength, 1)
+						req := <-jsClient.JoinReqPayloadChan
+						So(req.DevEUI, ShouldEqual, devEUI)
+						So(req.DevAddr, ShouldEqual, lorawan.DevAddr{0x06, 2, 3, 4})
+						So(req.MACVersion, ShouldEqual, "1.0.2")
+					})
+
+					Convey("Then the join-accept, NwkSKey and service-profile are returned", func() {
+						So(ans.MessageType, ShouldEqual, backend.HRStartAns)
+						So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+						So(ans.PHYPayload, ShouldResemble, backend.HEXBytes(jaBytes))
+						So(*ans.Lifetime, ShouldEqual, 3600)
+						So(ans.NwkSKey, ShouldResemble, &kek.KeyEnvelope{AESKey: backend.HEXBytes(nwkSKey[:])})
+						So(ans.ServiceProfile.ServiceProfileID, ShouldEqual, sp.ServiceProfile.ServiceProfileID)
+					})
+
+					Convey("Then the local device-session has been removed", func() {
+						_, err := storage.GetDeviceSession(common.RedisPool, devEUI)
+						So(err, ShouldEqual, storage.ErrDoesNotExist)
+					})
+
+					Convey("Then the handover session has been stored", func() {
+						hs, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+						So(err, ShouldBeNil)
+						So(hs.NetID, ShouldEqual, peerNetID)
+						So(hs.JoinEUI, ShouldEqual, joinEUI)
+						So(hs.DevAddr, ShouldEqual, lorawan.DevAddr{0x06, 2, 3, 4})
+					})
+
+					Convey("When the sNS forwards an uplink payload using a XmitDataReq", func() {
+						asClient.GetDataDownResponse = as.GetDataDownResponse{
+							FPort: 2,
+							Data:  []byte{4, 3, 2, 1},
+						}
+						peerResponses[backend.XmitDataReq] = backend.XmitDataAnsPayload{
+							Result: backend.Result{ResultCode: backend.Success},
+						}
+
+						basePL.MessageType = backend.XmitDataReq
+						var ans backend.XmitDataAnsPayload
+						So(postBackendAPI(apiServer.URL, backend.XmitDataReqPayload{
+							BasePayload: basePL,
+							FRMPayload:  backend.HEXBytes{1, 2, 3, 4},
+							ULMetaData: &backend.ULMetaData{
+								DevEUI:   devEUI,
+								DevAddr:  lorawan.DevAddr{0x06, 2, 3, 4},
+								FPort:    1,
+								FCntUp:   10,
+								FCntDown: 5,
+								DataRate: 0,
+								ULFreq:   868.1,
+							},
+						}, &ans), ShouldBeNil)
+
+						Convey("Then a Success result is returned", func() {
+							So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+						})
+
+						Convey("Then the payload was sent to the application-server", func() {
+							So(asClient.HandleDataUpChan, ShouldHaveLength, 1)
+							req := <-asClient.HandleDataUpChan
+							So(req.AppEUI, ShouldResemble, joinEUI[:])
+							So(req.DevEUI, ShouldResemble, devEUI[:])
+							So(req.FCnt, ShouldEqual, 10)
+							So(req.FPort, ShouldEqual, 1)
+							So(req.Data, ShouldResemble, []byte{1, 2, 3, 4})
+						})
+
+						Convey("Then the frame-counters of the session are synchronized", func() {
+							hs, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+							So(err, ShouldBeNil)
+							So(hs.FCntUp, ShouldEqual, 11)
+							So(hs.FCntDown, ShouldEqual, 5)
+						})
+
+						Convey("Then the downlink payload of the application-server is sent to the sNS", func() {
+							So(peerRequests, ShouldHaveLength, 1)
+							var req backend.XmitDataReqPayload
+							So(json.Unmarshal(<-peerRequests, &req), ShouldBeNil)
+							So(req.FRMPayload, ShouldResemble, backend.HEXBytes{4, 3, 2, 1})
+							So(req.DLMetaData.DevEUI, ShouldEqual, devEUI)
+							So(req.DLMetaData.FPort, ShouldEqual, 2)
+							So(req.DLMetaData.FCntDown, ShouldEqual, 5)
+							So(req.DLMetaData.ClassMode, ShouldEqual, "A")
+						})
+
+						Convey("When the sNS replays the XmitDataReq", func() {
+							<-asClient.HandleDataUpChan
+
+							basePL.TransactionID = 1235
+							var ans backend.XmitDataAnsPayload
+							So(postBackendAPI(apiServer.URL, backend.XmitDataReqPayload{
+								BasePayload: basePL,
+								FRMPayload:  backend.HEXBytes{1, 2, 3, 4},
+								ULMetaData: &backend.ULMetaData{
+									DevEUI:   devEUI,
+									DevAddr:  lorawan.DevAddr{0x06, 2, 3, 4},
+									FPort:    1,
+									FCntUp:   10,
+									FCntDown: 3,
+									DataRate: 0,
+									ULFreq:   868.1,
+								},
+							}, &ans), ShouldBeNil)
+
+							Convey("Then a MalformedRequest result is returned", func() {
+								So(ans.Result.ResultCode, ShouldEqual, backend.MalformedRequest)
+							})
+
+							Convey("Then the payload was not sent to the application-server", func() {
+								So(asClient.HandleDataUpChan, ShouldHaveLength, 0)
+							})
+
+							Convey("Then the frame-counters of the session are unchanged", func() {
+								hs, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+								So(err, ShouldBeNil)
+								So(hs.FCntUp, ShouldEqual, 11)
+								So(hs.FCntDown, ShouldEqual, 5)
+							})
+						})
+					})
+
+					Convey("When the sNS sends a HRStopReq", func() {
+						basePL.MessageType = backend.HRStopReq
+						var ans backend.HRStopAnsPayload
+						So(postBackendAPI(apiServer.URL, backend.HRStopReqPayload{
+							BasePayload: basePL,
+							DevEUI:      devEUI,
+						}, &ans), ShouldBeNil)
+
+						Convey("Then the handover session has been removed", func() {
+							So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+							_, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+							So(err, ShouldEqual, roaming.ErrDoesNotExist)
+						})
+					})
+
+					Convey("When the device rejoins through this network", func() {
+						peerResponses[backend.HRStopReq] = backend.HRStopAnsPayload{
+							Result: backend.Result{ResultCode: backend.Success},
+						}
+
+						jrPHY.MACPayload.(*lorawan.JoinRequestPayload).DevNonce = lorawan.DevNonce{2, 3}
+						So(jrPHY.SetMIC(appKey), ShouldBeNil)
+						So(uplink.HandleRXPacket(gw.RXPacket{
+							RXInfo: gw.RXInfo{
+								MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+								Frequency: common.Band.UplinkChannels[0].Frequency,
+								DataRate:  common.Band.DataRates[common.Band.UplinkChannels[0].DataRates[0]],
+							},
+							PHYPayload: jrPHY,
+						}), ShouldBeNil)
+
+						Convey("Then a HRStopReq was sent to the sNS", func() {
+							So(peerRequests, ShouldHaveLength, 1)
+							var req backend.HRStopReqPayload
+							So(json.Unmarshal(<-peerRequests, &req), ShouldBeNil)
+							So(req.MessageType, ShouldEqual, backend.HRStopReq)
+							So(req.DevEUI, ShouldEqual, devEUI)
+						})
+
+						Convey("Then the handover session has been removed", func() {
+							_, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+							So(err, ShouldEqual, roaming.ErrDoesNotExist)
+						})
+					})
+				})
+			})
+		})
+
+		Convey("Given a device of the roaming partner (sNS)", func() {
+			handover := backend.Handover
+			timestamp := time.Now().Format(time.RFC3339Nano)
+
+			peerResponses[backend.ProfileReq] = backend.ProfileAnsPayload{
+				Result: backend.Result{ResultCode: backend.Success},
+				DeviceProfile: &backend.DeviceProfile{
+					DeviceProfileID: "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d",
+					SupportsJoin:    true,
+					MACVersion:      "1.0.2",
+				},
+				DeviceProfileTimestamp: &timestamp,
+				RoamingActivationType:  &handover,
+			}
+
+			lifetime := 0
+			peerResponses[backend.HRStartReq] = roaming.HRStartAnsPayload{
+				HRStartAnsPayload: backend.HRStartAnsPayload{
+					Result:     backend.Result{ResultCode: backend.Success},
+					PHYPayload: backend.HEXBytes(jaBytes),
+					Lifetime:   &lifetime,
+					ServiceProfile: &backend.ServiceProfile{
+						ServiceProfileID: "b0c1d2e3-f4a5-4b6c-8d7e-9f0a1b2c3d4e",
+						HRAllowed:        true,
+						RAAllowed:        true,
+					},
+				},
+				NwkSKey: &kek.KeyEnvelope{AESKey: backend.HEXBytes(nwkSKey[:])},
+			}
+
+			Convey("When the device joins through this network", func() {
+				So(uplink.HandleRXPacket(gw.RXPacket{
+					RXInfo: gw.RXInfo{
+						MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+						Frequency: common.Band.UplinkChannels[0].Frequency,
+						DataRate:  common.Band.DataRates[common.Band.UplinkChannels[0].DataRates[0]],
+					},
+					PHYPayload: jrPHY,
+				}), ShouldBeNil)
+
+				Convey("Then a ProfileReq and HRStartReq were sent to the hNS", func() {
+					So(peerRequests, ShouldHaveLength, 2)
+
+					var profileReq backend.ProfileReqPayload
+					So(json.Unmarshal(<-peerRequests, &profileReq), ShouldBeNil)
+					So(profileReq.MessageType, ShouldEqual, backend.ProfileReq)
+					So(profileReq.DevEUI, ShouldEqual, devEUI)
+
+					var hrStartReq backend.HRStartReqPayload
+					So(json.Unmarshal(<-peerRequests, &hrStartReq), ShouldBeNil)
+					So(hrStartReq.MessageType, ShouldEqual, backend.HRStartReq)
+					So(hrStartReq.PHYPayload, ShouldResemble, backend.HEXBytes(jrBytes))
+					So(hrStartReq.DevAddr.NwkID(), ShouldEqual, common.NetID.NwkID())
+				})
+
+				Convey("Then the join-accept of the hNS was sent to the gateway", func() {
+					So(gwBackend.TXPacketChan, ShouldHaveLength, 1)
+					txPacket := <-gwBackend.TXPacketChan
+					b, err := txPacket.PHYPayload.MarshalBinary()
+					So(err, ShouldBeNil)
+					So(b, ShouldResemble, jaBytes)
+				})
+
+				Convey("Then a device-session with the home NetID was created", func() {
+					ds, err := storage.GetDeviceSession(common.RedisPool, devEUI)
+					So(err, ShouldBeNil)
+					So(*ds.HomeNetID, ShouldEqual, peerNetID)
+					So(ds.NwkSKey, ShouldEqual, nwkSKey)
+					So(ds.ServiceProfileID, ShouldNotEqual, "b0c1d2e3-f4a5-4b6c-8d7e-9f0a1b2c3d4e")
+					So(ds.DeviceProfileID, ShouldNotEqual, "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d")
+
+					Convey("Then the profiles of the hNS are stored under IDs namespaced by its NetID", func() {
+						sp, err := storage.GetServiceProfile(common.DB, ds.ServiceProfileID)
+						So(err, ShouldBeNil)
+						So(sp.ServiceProfile.HRAllowed, ShouldBeTrue)
+
+						dp, err := storage.GetDeviceProfile(common.DB, ds.DeviceProfileID)
+						So(err, ShouldBeNil)
+						So(dp.DeviceProfile.MACVersion, ShouldEqual, "1.0.2")
+					})
+				})
+
+				Convey("When the hNS sends a HRStopReq", func() {
+					var ans backend.HRStopAnsPayload
+					So(postBackendAPI(apiServer.URL, backend.HRStopReqPayload{
+						BasePayload: backend.BasePayload{
+							SenderID:    peerNetID.String(),
+							ReceiverID:  common.NetID.String(),
+							MessageType: backend.HRStopReq,
+						},
+						DevEUI: devEUI,
+					}, &ans), ShouldBeNil)
+
+					Convey("Then the device-session has been removed", func() {
+						So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+						_, err := storage.GetDeviceSession(common.RedisPool, devEUI)
+						So(err, ShouldEqual, storage.ErrDoesNotExist)
+					})
+				})
+			})
+
+			Convey("Given a local service-profile with the ID of the service-profile of the hNS", func() {
+				localSP := storage.ServiceProfile{
+					ServiceProfile: backend.ServiceProfile{
+						ServiceProfileID: "b0c1d2e3-f4a5-4b6c-8d7e-9f0a1b2c3d4e",
+					},
+				}
+				So(storage.CreateServiceProfile(common.DB, &localSP), ShouldBeNil)
+
+				Convey("When the device joins through this network", func() {
+					So(uplink.HandleRXPacket(gw.RXPacket{
+						RXInfo: gw.RXInfo{
+							MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+							Frequency: common.Band.UplinkChannels[0].Frequency,
+							DataRate:  common.Band.DataRates[common.Band.UplinkChannels[0].DataRates[0]],
+						},
+						PHYPayload: jrPHY,
+					}), ShouldBeNil)
+
+					Convey("Then the local service-profile has not been updated", func() {
+						sp, err := storage.GetServiceProfile(common.DB, localSP.ServiceProfile.ServiceProfileID)
+						So(err, ShouldBeNil)
+						So(sp.ServiceProfile.HRAllowed, ShouldBeFalse)
+					})
+				})
+			})
+
+			Convey("When a device joins with a JoinEUI not handled by the roaming partner", func() {
+				jrPHY.MACPayload.(*lorawan.JoinRequestPayload).AppEUI = lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1}
+				So(jrPHY.SetMIC(appKey), ShouldBeNil)
+
+				So(uplink.HandleRXPacket(gw.RXPacket{
+					RXInfo: gw.RXInfo{
+						MAC:       lorawan.EUI64{1, 1, 1, 1, 1, 1, 1, 1},
+						Frequency: common.Band.UplinkChannels[0].Frequency,
+						DataRate:  common.Band.DataRates[common.Band.UplinkChannels[0].DataRates[0]],
+					},
+					PHYPayload: jrPHY,
+				}), ShouldBeNil)
+
+				Convey("Then no ProfileReq was sent to the roaming partner", func() {
+					So(peerRequests, ShouldHaveLength, 0)
+					So(gwBackend.TXPacketChan, ShouldHaveLength, 0)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package testsuite
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/joinserver"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/loraserver/internal/uplink"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+	"github.com/brocaar/lorawan/band"
+)
+
+func TestEmbeddedJoinServer(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+	common.NetID = [3]byte{3, 2, 1}
+	common.Band, err = band.GetConfig(band.EU_863_870, false, lorawan.DwellTimeNoLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	common.KEKStore = kek.NewStore()
+	if err := common.KEKStore.Add("as", []byte{8, 7, 6, 5, 4, 3, 2, 1, 8, 7, 6, 5, 4, 3, 2, 1}); err != nil {
+		t.Fatal(err)
+	}
+	common.JoinServerASKEKLabel = "as"
+	defer func() {
+		common.KEKStore = nil
+		common.JoinServerASKEKLabel = ""
+	}()
+
+	Convey("Given a clean database with a device, its root-keys and the embedded join-server", t, func() {
+		test.MustResetDB(common.DB)
+		test.MustFlushRedis(common.RedisPool)
+
+		asClient := test.NewApplicationClient()
+		common.ApplicationServerPool = test.NewApplicationServerPool(asClient)
+		common.JoinServerPool = test.NewJoinServerPool(joinserver.NewClient())
+		common.Gateway = test.NewGatewayBackend()
+		common.Controller = test.NewNetworkControllerClient()
+
+		g := gateway.Gateway{
+			MAC:  lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			Name: "test-gateway",
+		}
+		So(gateway.CreateGateway(common.DB, &g), ShouldBeNil)
+
+		sp := storage.ServiceProfile{}
+		So(storage.CreateServiceProfile(common.DB, &sp), ShouldBeNil)
+		dp := storage.DeviceProfile{
+			DeviceProfile: backend.DeviceProfile{
+				SupportsJoin: true,
+			},
+		}
+		So(storage.CreateDeviceProfile(common.DB, &dp), ShouldBeNil)
+		rp := storage.RoutingProfile{}
+		So(storage.CreateRoutingProfile(common.DB, &rp), ShouldBeNil)
+
+		d := storage.Device{
+			DevEUI:           lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8},
+			DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+			RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateDevice(common.DB, &d), ShouldBeNil)
+
+		dk := storage.DeviceKeys{
+			DevEUI: d.DevEUI,
+			NwkKey: lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		}
+		So(storage.CreateDeviceKeys(common.DB, &dk), ShouldBeNil)
+
+		rxInfo := gw.RXInfo{
+			MAC:       g.MAC,
+			Frequency: common.Band.UplinkChannels[0].Frequency,
+			DataRate:  common.Band.DataRates[common.Band.UplinkChannels[0].DataRates[0]],
+		}
+
+		jrPHY := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.JoinRequest,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.JoinRequestPayload{
+				AppEUI:   lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				DevEUI:   d.DevEUI,
+				DevNonce: lorawan.DevNonce{1, 2},
+			},
+		}
+		So(jrPHY.SetMIC(dk.NwkKey), ShouldBeNil)
+
+		Convey("When the device joins and sends an uplink", func() {
+			So(uplink.HandleRXPacket(gw.RXPacket{
+				RXInfo:     rxInfo,
+				PHYPayload: jrPHY,
+			}), ShouldBeNil)
+			So(common.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+			<-common.Gateway.(*test.GatewayBackend).TXPacketChan
+
+			ds, err := storage.GetDeviceSession(common.RedisPool, d.DevEUI)
+			So(err, ShouldBeNil)
+			So(ds.SessionKeyID, ShouldHaveLength, 16)
+
+			fPort := uint8(1)
+			upPHY := lorawan.PHYPayload{
+				MHDR: lorawan.MHDR{
+					MType: lorawan.UnconfirmedDataUp,
+					Major: lorawan.LoRaWANR1,
+				},
+				MACPayload: &lorawan.MACPayload{
+					FHDR: lorawan.FHDR{
+						DevAddr: ds.DevAddr,
+					},
+					FPort:      &fPort,
+					FRMPayload: []lorawan.Payload{&lorawan.DataPayload{Bytes: []byte{1, 2, 3, 4}}},
+				},
+			}
+			So(upPHY.SetMIC(ds.NwkSKey), ShouldBeNil)
+			So(uplink.HandleRXPacket(gw.RXPacket{
+				RXInfo:     rxInfo,
+				PHYPayload: upPHY,
+			}), ShouldBeNil)
+
+			Convey("Then the SessionKeyID is forwarded to the application-server", func() {
+				So(asClient.HandleDataUpChan, ShouldHaveLength, 1)
+				req := <-asClient.HandleDataUpChan
+				So(req.SessionKeyID, ShouldResemble, ds.SessionKeyID)
+
+				Convey("Then the application-server can request the AppSKey using the SessionKeyID", func() {
+					ans, err := joinserver.HandleAppSKeyReq(backend.AppSKeyReqPayload{
+						BasePayload: backend.BasePayload{
+							MessageType: backend.AppSKeyReq,
+						},
+						DevEUI:       d.DevEUI,
+						SessionKeyID: backend.HEXBytes(req.SessionKeyID),
+					})
+					So(err, ShouldBeNil)
+					So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+					So(ans.AppSKey.KEKLabel, ShouldEqual, "as")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,191 @@
+package testsuite
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/api/ns"
+	"github.com/brocaar/loraserver/internal/api"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestMulticastScenarios(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+
+	Convey("Given a clean state with a multicast-group containing a device", t, func() {
+		test.MustResetDB(common.DB)
+		test.MustFlushRedis(common.RedisPool)
+
+		common.Gateway = test.NewGatewayBackend()
+		api := api.NewNetworkServerAPI()
+
+		sp := storage.ServiceProfile{}
+		So(storage.CreateServiceProfile(common.DB, &sp), ShouldBeNil)
+		dp := storage.DeviceProfile{
+			DeviceProfile: backend.DeviceProfile{
+				SupportsClassC: true,
+			},
+		}
+		So(storage.CreateDeviceProfile(common.DB, &dp), ShouldBeNil)
+		rp := storage.RoutingProfile{}
+		So(storage.CreateRoutingProfile(common.DB, &rp), ShouldBeNil)
+
+		d := storage.Device{
+			DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+			RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+		}
+		So(storage.CreateDevice(common.DB, &d), ShouldBeNil)
+
+		mg := storage.MulticastGroup{
+			MCAddr:           lorawan.DevAddr{1, 2, 3, 4},
+			MCNwkSKey:        lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			FCnt:             10,
+			DR:               3,
+			Frequency:        869525000,
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+		}
+		So(storage.CreateMulticastGroup(common.DB, &mg), ShouldBeNil)
+		So(storage.AddDeviceToMulticastGroup(common.DB, d.DevEUI, mg.ID), ShouldBeNil)
+
+		Convey("When the device has no device-session", func() {
+			Convey("Then EnqueueMulticastQueueItem returns an error and does not use the frame-counter", func() {
+				_, err := api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+					MulticastGroupID: mg.ID,
+					FCnt:             10,
+					FPort:            2,
+					Data:             []byte{1, 2, 3},
+				})
+				So(err, ShouldNotBeNil)
+
+				mgGet, err := storage.GetMulticastGroup(common.DB, mg.ID)
+				So(err, ShouldBeNil)
+				So(mgGet.FCnt, ShouldEqual, 10)
+			})
+		})
+
+		Convey("Given a device-session with a LastRXInfoSet", func() {
+			So(storage.SaveDeviceSession(common.RedisPool, storage.DeviceSession{
+				DevEUI: d.DevEUI,
+				LastRXInfoSet: []gw.RXInfo{
+					{MAC: lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1}},
+				},
+			}), ShouldBeNil)
+
+			Convey("When calling EnqueueMulticastQueueItem", func() {
+				_, err := api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+					MulticastGroupID: mg.ID,
+					FCnt:             10,
+					FPort:            2,
+					Data:             []byte{1, 2, 3},
+				})
+				So(err, ShouldBeNil)
+
+				Convey("Then the frame was sent using the multicast-group frame-counter", func() {
+					So(common.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 1)
+					txPacket := <-common.Gateway.(*test.GatewayBackend).TXPacketChan
+					So(txPacket.TXInfo.MAC, ShouldEqual, lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1})
+					So(txPacket.TXInfo.Frequency, ShouldEqual, 869525000)
+					So(txPacket.TXInfo.DataRate, ShouldResemble, common.Band.DataRates[3])
+
+					macPL, ok := txPacket.PHYPayload.MACPayload.(*lorawan.MACPayload)
+					So(ok, ShouldBeTrue)
+					So(macPL.FHDR.DevAddr, ShouldEqual, mg.MCAddr)
+					So(macPL.FHDR.FCnt, ShouldEqual, 10)
+
+					ok, err = txPacket.PHYPayload.ValidateMIC(mg.MCNwkSKey)
+					So(err, ShouldBeNil)
+					So(ok, ShouldBeTrue)
+				})
+
+				Convey("Then the frame-counter has been incremented", func() {
+					mgGet, err := storage.GetMulticastGroup(common.DB, mg.ID)
+					So(err, ShouldBeNil)
+					So(mgGet.FCnt, ShouldEqual, 11)
+				})
+
+				Convey("Then enqueueing with the same frame-counter returns an error", func() {
+					_, err := api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+						MulticastGroupID: mg.ID,
+						FCnt:             10,
+						FPort:            2,
+						Data:             []byte{1, 2, 3},
+					})
+					So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "invalid FCnt (expected: 11)"))
+				})
+			})
+
+			Convey("Then FPort 0 is rejected", func() {
+				_, err := api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+					MulticastGroupID: mg.ID,
+					FCnt:             10,
+					FPort:            0,
+					Data:             []byte{1, 2, 3},
+				})
+				So(err, ShouldNotBeNil)
+				So(common.Gateway.(*test.GatewayBackend).TXPacketChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("Then an FPort above 224 is rejected", func() {
+			_, err := api.EnqueueMulticastQueueItem(context.Background(), &ns.EnqueueMulticastQueueItemRequest{
+				MulticastGroupID: mg.ID,
+				FCnt:             10,
+				FPort:            257,
+				Data:             []byte{1, 2, 3},
+			})
+			So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "fPort must be between 1 and 224"))
+		})
+
+		Convey("Then UpdateMulticastGroup rejects a lower FCnt", func() {
+			_, err := api.UpdateMulticastGroup(context.Background(), &ns.UpdateMulticastGroupRequest{
+				MulticastGroup: &ns.MulticastGroup{
+					Id:               mg.ID,
+					McAddr:           mg.MCAddr[:],
+					McNwkSKey:        mg.MCNwkSKey[:],
+					FCnt:             9,
+					Dr:               uint32(mg.DR),
+					Frequency:        uint32(mg.Frequency),
+					ServiceProfileID: mg.ServiceProfileID,
+				},
+			})
+			So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "fCnt must not be lower than the current fCnt (10)"))
+		})
+
+		Convey("Given a device of which the device-profile does not support Class-C", func() {
+			dpA := storage.DeviceProfile{}
+			So(storage.CreateDeviceProfile(common.DB, &dpA), ShouldBeNil)
+			dA := storage.Device{
+				DevEUI:           lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8},
+				ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+				DeviceProfileID:  dpA.DeviceProfile.DeviceProfileID,
+				RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+			}
+			So(storage.CreateDevice(common.DB, &dA), ShouldBeNil)
+
+			Convey("Then AddDeviceToMulticastGroup rejects the device", func() {
+				_, err := api.AddDeviceToMulticastGroup(context.Background(), &ns.AddDeviceToMulticastGroupRequest{
+					DevEUI:           dA.DevEUI[:],
+					MulticastGroupID: mg.ID,
+				})
+				So(err, ShouldResemble, grpc.Errorf(codes.InvalidArgument, "device-profile of device does not support Class-C"))
+			})
+		})
+	})
+}
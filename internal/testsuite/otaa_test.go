@@ -180,10 +180,10 @@ func TestOTAAScenarios(t *testing.T) {
 						PHYPayload: backend.HEXBytes(jrBytes),
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
-							RX2DataRate: uint8(common.RX2DR),
-							RX1DROffset: uint8(common.RX1DROffset),
+							RX2DataRate: 5,
+							RX1DROffset: 1,
 						},
-						RxDelay: common.RX1Delay,
+						RxDelay: 3,
 					},
 					ExpectedTXInfo: gw.TXInfo{
 						MAC:       rxInfo.MAC,
@@ -202,6 +202,10 @@ func TestOTAAScenarios(t *testing.T) {
 						DevEUI:           lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8},
 						NwkSKey:          lorawan.AES128Key{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
 						RXWindow:         storage.RX1,
+						RXDelay:          3,
+						RX1DROffset:      1,
+						RX2DR:            5,
+						RX2Frequency:     869525000,
 						EnabledChannels:  []int{0, 1, 2},
 						LastRXInfoSet:    []gw.RXInfo{rxInfo},
 					},
@@ -233,10 +237,10 @@ func TestOTAAScenarios(t *testing.T) {
 						PHYPayload: backend.HEXBytes(jrBytes),
 						DevEUI:     d.DevEUI,
 						DLSettings: lorawan.DLSettings{
-							RX2DataRate: uint8(common.RX2DR),
-							RX1DROffset: uint8(common.RX1DROffset),
+							RX2DataRate: 5,
+							RX1DROffset: 1,
 						},
-						RxDelay: common.RX1Delay,
+						RxDelay: 3,
 						CFList:  &lorawan.CFList{868400000, 868500000, 868600000},
 					},
 					ExpectedTXInfo: gw.TXInfo{
@@ -256,6 +260,10 @@ func TestOTAAScenarios(t *testing.T) {
 						DevEUI:           lorawan.EUI64{2, 2, 3, 4, 5, 6, 7, 8},
 						NwkSKey:          lorawan.AES128Key{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
 						RXWindow:         storage.RX1,
+						RXDelay:          3,
+						RX1DROffset:      1,
+						RX2DR:            5,
+						RX2Frequency:     869525000,
 						EnabledChannels:  []int{0, 1, 2, 3, 4, 5},
 						LastRXInfoSet:    []gw.RXInfo{rxInfo},
 					},
@@ -0,0 +1,478 @@
+package testsuite
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/backendapi"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/kek"
+	"github.com/brocaar/loraserver/internal/roaming"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/loraserver/internal/test"
+	"github.com/brocaar/loraserver/internal/uplink"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+func TestPassiveRoamingScenarios(t *testing.T) {
+	conf := test.GetConfig()
+	db, err := common.OpenDatabase(conf.PostgresDSN)
+	if err != nil {
+		t.Fatal(err)
+	}
+	common.DB = db
+	common.RedisPool = common.NewRedisPool(conf.RedisURL)
+	common.NetID = lorawan.NetID{3, 2, 1}
+	defer func() {
+		common.RoamingPool = nil
+		common.RoamingLifetime = 0
+	}()
+
+	Convey("Given a clean state, a roaming partner and a device", t, func() {
+		test.MustResetDB(common.DB)
+		test.MustFlushRedis(common.RedisPool)
+
+		// the roaming partner (fNS or sNS, depending on the test)
+		peerRequests := make(chan []byte, 10)
+		var peerResponse interface{}
+		peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			peerRequests <- b
+			json.NewEncoder(w).Encode(peerResponse)
+		}))
+		defer peer.Close()
+
+		peerNetID := lorawan.NetID{1, 2, 3}
+		client, err := roaming.NewClient(roaming.AgreementConfig{NetID: peerNetID, Server: peer.URL}, common.NetID, nil)
+		So(err, ShouldBeNil)
+		common.RoamingPool = roaming.NewPool(client)
+		common.RoamingLifetime = 0
+
+		asClient := test.NewApplicationClient()
+		common.ApplicationServerPool = test.NewApplicationServerPool(asClient)
+		common.Controller = test.NewNetworkControllerClient()
+		gwBackend := test.NewGatewayBackend()
+		common.Gateway = roaming.NewBackend(gwBackend, common.RedisPool, common.RoamingPool, common.Band)
+
+		api := backendapi.NewAPI(backendapi.Config{Roaming: true})
+		apiServer := httptest.NewServer(withClientCertificate(peerNetID.String(), api))
+		defer apiServer.Close()
+
+		sp := storage.ServiceProfile{
+			ServiceProfile: backend.ServiceProfile{
+				PRAllowed: true,
+			},
+		}
+		So(storage.CreateServiceProfile(common.DB, &sp), ShouldBeNil)
+		dp := storage.DeviceProfile{}
+		So(storage.CreateDeviceProfile(common.DB, &dp), ShouldBeNil)
+		rp := storage.RoutingProfile{}
+		So(storage.CreateRoutingProfile(common.DB, &rp), ShouldBeNil)
+
+		// the NwkID of the DevAddr matches the NwkID of common.NetID
+		ds := storage.DeviceSession{
+			ServiceProfileID: sp.ServiceProfile.ServiceProfileID,
+			DeviceProfileID:  dp.DeviceProfile.DeviceProfileID,
+			RoutingProfileID: rp.RoutingProfile.RoutingProfileID,
+			DevAddr:          lorawan.DevAddr{0x02, 2, 3, 4},
+			DevEUI:           lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+			JoinEUI:          lorawan.EUI64{8, 7, 6, 5, 4, 3, 2, 1},
+			NwkSKey:          lorawan.AES128Key{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8},
+			FCntUp:           10,
+			EnabledChannels:  []int{0, 1, 2},
+		}
+		So(storage.SaveDeviceSession(common.RedisPool, ds), ShouldBeNil)
+
+		fPort := uint8(1)
+		phy := lorawan.PHYPayload{
+			MHDR: lorawan.MHDR{
+				MType: lorawan.ConfirmedDataUp,
+				Major: lorawan.LoRaWANR1,
+			},
+			MACPayload: &lorawan.MACPayload{
+				FHDR: lorawan.FHDR{
+					DevAddr: ds.DevAddr,
+					FCnt:    10,
+				},
+				FPort: &fPort,
+			},
+		}
+		So(phy.SetMIC(ds.NwkSKey), ShouldBeNil)
+		phyB, err := phy.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		ulToken := backend.HEXBytes{1, 2, 3, 4}
+		prStartReq := backend.PRStartReqPayload{
+			BasePayload: backend.BasePayload{
+				ProtocolVersion: backend.ProtocolVersion1_0,
+				SenderID:        peerNetID.String(),
+				ReceiverID:      common.NetID.String(),
+				TransactionID:   1234,
+				MessageType:     backend.PRStartReq,
+			},
+			PHYPayload: backend.HEXBytes(phyB),
+			ULMetaData: backend.ULMetaData{
+				DevAddr:  ds.DevAddr,
+				FCntUp:   10,
+				DataRate: 0,
+				ULFreq:   868.1,
+				RecvTime: time.Now().Format(time.RFC3339Nano),
+				GWCnt:    1,
+				GWInfo: []backend.GWInfoElement{
+					{
+						ID:        backend.HEXBytes{1, 2, 3, 4, 5, 6, 7, 8},
+						RSSI:      -50,
+						SNR:       5.5,
+						ULToken:   ulToken,
+						DLAllowed: true,
+					},
+				},
+			},
+		}
+
+		peerResponse = backend.XmitDataAnsPayload{
+			Result: backend.Result{ResultCode: backend.Success},
+		}
+
+		Convey("When a PRStartReq is sent without client certificate", func() {
+			noCertServer := httptest.NewServer(api)
+			defer noCertServer.Close()
+
+			Convey("Then it is rejected", func() {
+				So(postBackendAPIStatus(noCertServer.URL, prStartReq), ShouldEqual, http.StatusUnauthorized)
+				So(asClient.HandleDataUpChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a PRStartReq is sent using the client certificate of an other partner", func() {
+			otherCertServer := httptest.NewServer(withClientCertificate("010101", api))
+			defer otherCertServer.Close()
+
+			Convey("Then it is rejected", func() {
+				So(postBackendAPIStatus(otherCertServer.URL, prStartReq), ShouldEqual, http.StatusForbidden)
+				So(asClient.HandleDataUpChan, ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When the fNS sends a PRStartReq for a device of this network", func() {
+			var ans roaming.PRStartAnsPayload
+			So(postBackendAPI(apiServer.URL, prStartReq, &ans), ShouldBeNil)
+
+			Convey("Then a Success result is returned (stateless)", func() {
+				So(ans.MessageType, ShouldEqual, backend.PRStartAns)
+				So(ans.TransactionID, ShouldEqual, 1234)
+				So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				So(*ans.DevEUI, ShouldEqual, ds.DevEUI)
+				So(*ans.FCntUp, ShouldEqual, 10)
+				So(*ans.Lifetime, ShouldEqual, 0)
+				So(ans.NwkSKey, ShouldBeNil)
+			})
+
+			Convey("Then the uplink is sent to the application-server", func() {
+				var req as.HandleDataUpRequest
+				select {
+				case req = <-asClient.HandleDataUpChan:
+				case <-time.After(time.Second):
+				}
+				So(req.DevEUI, ShouldResemble, ds.DevEUI[:])
+				So(req.FCnt, ShouldEqual, 10)
+			})
+
+			Convey("Then the ACK is sent to the fNS using a XmitDataReq", func() {
+				var b []byte
+				select {
+				case b = <-peerRequests:
+				case <-time.After(time.Second):
+				}
+
+				var req backend.XmitDataReqPayload
+				So(json.Unmarshal(b, &req), ShouldBeNil)
+				So(req.MessageType, ShouldEqual, backend.XmitDataReq)
+				So(req.ReceiverID, ShouldEqual, peerNetID.String())
+				So(req.DLMetaData, ShouldNotBeNil)
+				So(req.DLMetaData.ClassMode, ShouldEqual, "A")
+				So(req.DLMetaData.RXDelay1, ShouldEqual, 1)
+				So(req.DLMetaData.DLFreq1, ShouldEqual, 868.1)
+				So(req.DLMetaData.DataRate1, ShouldEqual, 0)
+				So(req.DLMetaData.GWInfo, ShouldHaveLength, 1)
+				So(req.DLMetaData.GWInfo[0].ULToken, ShouldResemble, ulToken)
+			})
+		})
+
+		Convey("When the fNS sends the same PRStartReq twice (e.g. a retry)", func() {
+			var ans roaming.PRStartAnsPayload
+			So(postBackendAPI(apiServer.URL, prStartReq, &ans), ShouldBeNil)
+			So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+
+			prStartReq.TransactionID = 1235
+			prStartReq.ULMetaData.GWInfo[0].ID = backend.HEXBytes{8, 7, 6, 5, 4, 3, 2, 1}
+			So(postBackendAPI(apiServer.URL, prStartReq, &ans), ShouldBeNil)
+			So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+
+			Convey("Then the uplink is sent only once to the application-server", func() {
+				So(asClient.HandleDataUpChan, ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When the fNS sends a PRStartReq and stateful roaming is enabled", func() {
+			common.RoamingLifetime = time.Minute
+
+			var ans roaming.PRStartAnsPayload
+			So(postBackendAPI(apiServer.URL, prStartReq, &ans), ShouldBeNil)
+
+			Convey("Then the lifetime and the NwkSKey are returned", func() {
+				So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				So(*ans.Lifetime, ShouldEqual, 60)
+				So(ans.NwkSKey, ShouldNotBeNil)
+				So(ans.NwkSKey.AESKey, ShouldResemble, backend.HEXBytes(ds.NwkSKey[:]))
+			})
+		})
+
+		Convey("When the fNS sends a PRStartReq for a device not allowed to roam", func() {
+			sp2 := storage.ServiceProfile{}
+			So(storage.CreateServiceProfile(common.DB, &sp2), ShouldBeNil)
+			ds.ServiceProfileID = sp2.ServiceProfile.ServiceProfileID
+			So(storage.SaveDeviceSession(common.RedisPool, ds), ShouldBeNil)
+
+			var ans roaming.PRStartAnsPayload
+			So(postBackendAPI(apiServer.URL, prStartReq, &ans), ShouldBeNil)
+
+			Convey("Then a DevRoamingDisallowed result is returned", func() {
+				So(ans.Result.ResultCode, ShouldEqual, backend.DevRoamingDisallowed)
+			})
+		})
+
+		Convey("When a PRStartReq is received from a network without agreement", func() {
+			prStartReq.SenderID = "090909"
+			otherServer := httptest.NewServer(withClientCertificate("090909", api))
+			defer otherServer.Close()
+
+			Convey("Then it is rejected as the sender is not a roaming partner", func() {
+				So(postBackendAPIStatus(otherServer.URL, prStartReq), ShouldEqual, http.StatusForbidden)
+			})
+		})
+
+		Convey("When the fNS sends a PRStartReq for a DevAddr of an other network", func() {
+			phy.MACPayload.(*lorawan.MACPayload).FHDR.DevAddr = lorawan.DevAddr{0x08, 2, 3, 4}
+			phyB, err := phy.MarshalBinary()
+			So(err, ShouldBeNil)
+			prStartReq.PHYPayload = backend.HEXBytes(phyB)
+
+			var ans roaming.PRStartAnsPayload
+			So(postBackendAPI(apiServer.URL, prStartReq, &ans), ShouldBeNil)
+
+			Convey("Then an UnknownDevAddr result is returned", func() {
+				So(ans.Result.ResultCode, ShouldEqual, backend.UnknownDevAddr)
+			})
+		})
+
+		Convey("When the sNS sends a XmitDataReq with a downlink", func() {
+			rxInfo := gw.RXInfo{
+				MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Timestamp: 1000000,
+				CodeRate:  "4/5",
+			}
+			ulToken, err := roaming.SaveULToken(common.RedisPool, roaming.ULToken{
+				NetID:  peerNetID,
+				RXInfo: rxInfo,
+			})
+			So(err, ShouldBeNil)
+
+			xmitDataReq := backend.XmitDataReqPayload{
+				BasePayload: backend.BasePayload{
+					SenderID:    peerNetID.String(),
+					ReceiverID:  common.NetID.String(),
+					MessageType: backend.XmitDataReq,
+				},
+				PHYPayload: backend.HEXBytes(phyB),
+				DLMetaData: &backend.DLMetaData{
+					DLFreq1:   868.1,
+					DataRate1: 5,
+					RXDelay1:  1,
+					ClassMode: "A",
+					GWInfo: []backend.GWInfoElement{
+						{ULToken: backend.HEXBytes(ulToken)},
+					},
+				},
+			}
+
+			Convey("Given the ULToken holds the rx-info instead of a reference", func() {
+				rxInfoB, err := json.Marshal(rxInfo)
+				So(err, ShouldBeNil)
+				xmitDataReq.DLMetaData.GWInfo[0].ULToken = backend.HEXBytes(rxInfoB)
+
+				var ans backend.XmitDataAnsPayload
+				So(postBackendAPI(apiServer.URL, xmitDataReq, &ans), ShouldBeNil)
+
+				Convey("Then the downlink is rejected", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.MalformedRequest)
+					So(gwBackend.TXPacketChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("Given the ULToken was issued to an other roaming partner", func() {
+				ulToken, err := roaming.SaveULToken(common.RedisPool, roaming.ULToken{
+					NetID:  lorawan.NetID{3, 3, 3},
+					RXInfo: rxInfo,
+				})
+				So(err, ShouldBeNil)
+				xmitDataReq.DLMetaData.GWInfo[0].ULToken = backend.HEXBytes(ulToken)
+
+				var ans backend.XmitDataAnsPayload
+				So(postBackendAPI(apiServer.URL, xmitDataReq, &ans), ShouldBeNil)
+
+				Convey("Then the downlink is rejected", func() {
+					So(ans.Result.ResultCode, ShouldEqual, backend.MalformedRequest)
+					So(gwBackend.TXPacketChan, ShouldHaveLength, 0)
+				})
+			})
+
+			Convey("Then the downlink is sent to the gateway", func() {
+				var ans backend.XmitDataAnsPayload
+				So(postBackendAPI(apiServer.URL, xmitDataReq, &ans), ShouldBeNil)
+
+				So(ans.Result.ResultCode, ShouldEqual, backend.Success)
+				So(gwBackend.TXPacketChan, ShouldHaveLength, 1)
+
+				txPacket := <-gwBackend.TXPacketChan
+				So(txPacket.TXInfo, ShouldResemble, gw.TXInfo{
+					MAC:       rxInfo.MAC,
+					Timestamp: 2000000,
+					Frequency: 868100000,
+					Power:     common.Band.DefaultTXPower,
+					DataRate:  common.Band.DataRates[5],
+					CodeRate:  "4/5",
+				})
+			})
+		})
+
+		Convey("When an uplink is received for a DevAddr of the roaming partner", func() {
+			// the NwkID of the DevAddr matches the NwkID of the peerNetID
+			phy.MACPayload.(*lorawan.MACPayload).FHDR.DevAddr = lorawan.DevAddr{0x06, 2, 3, 4}
+			So(phy.SetMIC(ds.NwkSKey), ShouldBeNil)
+
+			lifetime := 60
+			fCntUp := 10
+			peerResponse = roaming.PRStartAnsPayload{
+				PRStartAnsPayload: backend.PRStartAnsPayload{
+					Result:   backend.Result{ResultCode: backend.Success},
+					DevEUI:   &ds.DevEUI,
+					Lifetime: &lifetime,
+					FCntUp:   &fCntUp,
+				},
+				NwkSKey: &kek.KeyEnvelope{AESKey: backend.HEXBytes(ds.NwkSKey[:])},
+			}
+
+			rxInfo := gw.RXInfo{
+				MAC:       lorawan.EUI64{1, 2, 3, 4, 5, 6, 7, 8},
+				Timestamp: 1000000,
+				Frequency: common.Band.UplinkChannels[0].Frequency,
+				DataRate:  common.Band.DataRates[0],
+			}
+			So(uplink.HandleRXPacket(gw.RXPacket{RXInfo: rxInfo, PHYPayload: phy}), ShouldBeNil)
+
+			Convey("Then the uplink is forwarded to the sNS using a PRStartReq", func() {
+				So(peerRequests, ShouldHaveLength, 1)
+
+				var req backend.PRStartReqPayload
+				So(json.Unmarshal(<-peerRequests, &req), ShouldBeNil)
+				So(req.MessageType, ShouldEqual, backend.PRStartReq)
+				So(req.ULMetaData.DevAddr, ShouldEqual, lorawan.DevAddr{0x06, 2, 3, 4})
+				So(req.ULMetaData.GWInfo, ShouldHaveLength, 1)
+
+				token, err := roaming.GetULToken(common.RedisPool, peerNetID, req.ULMetaData.GWInfo[0].ULToken)
+				So(err, ShouldBeNil)
+				So(token.RXInfo.Timestamp, ShouldEqual, rxInfo.Timestamp)
+
+				Convey("Then the next uplink is forwarded using a XmitDataReq", func() {
+					phy.MACPayload.(*lorawan.MACPayload).FHDR.FCnt = 11
+					So(phy.SetMIC(ds.NwkSKey), ShouldBeNil)
+
+					peerResponse = backend.XmitDataAnsPayload{
+						Result: backend.Result{ResultCode: backend.Success},
+					}
+					So(uplink.HandleRXPacket(gw.RXPacket{RXInfo: rxInfo, PHYPayload: phy}), ShouldBeNil)
+					So(peerRequests, ShouldHaveLength, 1)
+
+					var req backend.XmitDataReqPayload
+					So(json.Unmarshal(<-peerRequests, &req), ShouldBeNil)
+					So(req.MessageType, ShouldEqual, backend.XmitDataReq)
+					So(req.ULMetaData, ShouldNotBeNil)
+					So(req.ULMetaData.DevEUI, ShouldEqual, ds.DevEUI)
+					So(req.ULMetaData.FCntUp, ShouldEqual, 11)
+
+					Convey("Then the frame-counter of the roaming session is updated", func() {
+						s, err := roaming.GetSession(common.RedisPool, lorawan.DevAddr{0x06, 2, 3, 4})
+						So(err, ShouldBeNil)
+						So(s.FCntUp, ShouldEqual, 11)
+					})
+				})
+			})
+
+			Convey("Then the uplink is not handled locally", func() {
+				So(asClient.HandleDataUpChan, ShouldHaveLength, 0)
+			})
+		})
+	})
+}
+
+// postBackendAPI posts the given payload to the backend api and decodes
+// the answer.
+func postBackendAPI(url string, pl, ans interface{}) error {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(ans)
+}
+
+// postBackendAPIStatus posts the given payload to the backend api and
+// returns the http status code.
+func postBackendAPIStatus(url string, pl interface{}) int {
+	b, err := json.Marshal(pl)
+	if err != nil {
+		return 0
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return 0
+	}
+	resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+// withClientCertificate wraps the given handler, setting a verified client
+// certificate with the given common-name on each request (as if presented
+// by the roaming partner using mutual TLS).
+func withClientCertificate(cn string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.TLS = &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{
+				{{Subject: pkix.Name{CommonName: cn}}},
+			},
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -678,6 +678,7 @@ func TestUplinkScenarios(t *testing.T) {
 						Frequency: common.Band.RX2Frequency,
 						Power:     14,
 						DataRate:  common.Band.DataRates[3],
+						RXWindow:  gw.RXWindow2,
 					},
 					ExpectedPHYPayload: &lorawan.PHYPayload{
 						MHDR: lorawan.MHDR{
@@ -733,6 +734,7 @@ func TestUplinkScenarios(t *testing.T) {
 						Frequency: common.Band.RX2Frequency,
 						Power:     14,
 						DataRate:  common.Band.DataRates[0],
+						RXWindow:  gw.RXWindow2,
 					},
 					ExpectedPHYPayload: &lorawan.PHYPayload{
 						MHDR: lorawan.MHDR{
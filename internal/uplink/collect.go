@@ -13,6 +13,7 @@ import (
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/lorawan"
 )
 
 // Templates used for generating Redis keys
@@ -28,13 +29,28 @@ const (
 // only once.
 // It is safe to collect the same packet received by the same gateway twice.
 // Since the underlying storage type is a set, the result will always be a
-// unique set per gateway MAC and packet MIC.
+// unique set per gateway MAC (and antenna) and packet MIC.
 func collectAndCallOnce(p *redis.Pool, rxPacket gw.RXPacket, callback func(packet models.RXPacket) error) error {
-	var buf bytes.Buffer
-	enc := gob.NewEncoder(&buf)
-	if err := enc.Encode(rxPacket); err != nil {
-		return fmt.Errorf("encode rx packet error: %s", err)
+	return collectRXInfoSetAndCallOnce(p, rxPacket.PHYPayload, models.RXInfoSet{rxPacket.RXInfo}, callback)
+}
+
+// collectRXInfoSetAndCallOnce is similar to collectAndCallOnce, but collects
+// the packet for all the given rx-info elements at once (e.g. in case of a
+// roamed uplink, received by multiple gateways of the roaming partner).
+func collectRXInfoSetAndCallOnce(p *redis.Pool, phy lorawan.PHYPayload, rxInfoSet models.RXInfoSet, callback func(packet models.RXPacket) error) error {
+	args := redis.Args{}
+	for _, rxInfo := range rxInfoSet {
+		var buf bytes.Buffer
+		enc := gob.NewEncoder(&buf)
+		if err := enc.Encode(gw.RXPacket{RXInfo: rxInfo, PHYPayload: phy}); err != nil {
+			return fmt.Errorf("encode rx packet error: %s", err)
+		}
+		args = args.Add(buf.Bytes())
 	}
+	if len(args) == 0 {
+		return errors.New("rx-info expected")
+	}
+
 	c := p.Get()
 	defer c.Close()
 
@@ -42,7 +58,7 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.RXPacket, callback func(packe
 	// in case the packet is received by multiple gateways, the set will contain
 	// each packet.
 	// The text representation of the PHYPayload is used as key.
-	phyB, err := rxPacket.PHYPayload.MarshalText()
+	phyB, err := phy.MarshalText()
 	if err != nil {
 		return errors.Wrap(err, "marshal to text error")
 	}
@@ -58,7 +74,7 @@ func collectAndCallOnce(p *redis.Pool, rxPacket gw.RXPacket, callback func(packe
 	}
 
 	c.Send("MULTI")
-	c.Send("SADD", key, buf.Bytes())
+	c.Send("SADD", redis.Args{}.Add(key).Add(args...)...)
 	c.Send("PEXPIRE", key, int64(deduplicationTTL)/int64(time.Millisecond))
 	_, err = c.Do("EXEC")
 	if err != nil {
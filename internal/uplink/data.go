@@ -70,6 +70,11 @@ func logDataFramesCollected(ctx *DataUpContext) error {
 }
 
 func getApplicationServerClientForDataUp(ctx *DataUpContext) error {
+	// the application-server is reached through the hNS (handover roaming)
+	if ctx.DeviceSession.HomeNetID != nil {
+		return nil
+	}
+
 	rp, err := storage.GetRoutingProfile(common.DB, ctx.DeviceSession.RoutingProfileID)
 	if err != nil {
 		return errors.Wrap(err, "get routing-profile error")
@@ -145,6 +150,9 @@ func handleFRMPayloadMACCommands(ctx *DataUpContext) error {
 
 func sendFRMPayloadToApplicationServer(ctx *DataUpContext) error {
 	if ctx.MACPayload.FPort != nil && *ctx.MACPayload.FPort > 0 {
+		if ctx.DeviceSession.HomeNetID != nil {
+			return sendFRMPayloadToHomeNS(ctx.DeviceSession, ctx.RXPacket, ctx.MACPayload)
+		}
 		return publishDataUp(ctx.ApplicationServerClient, ctx.DeviceSession, ctx.ServiceProfile, ctx.RXPacket, *ctx.MACPayload)
 	}
 
@@ -198,6 +206,12 @@ func handleUplinkACK(ctx *DataUpContext) error {
 		return nil
 	}
 
+	// the backend-interfaces do not provide a way to forward the ACK to the
+	// hNS (handover roaming)
+	if ctx.DeviceSession.HomeNetID != nil {
+		return nil
+	}
+
 	_, err := ctx.ApplicationServerClient.HandleDataDownACK(context.Background(), &as.HandleDataDownACKRequest{
 		AppEUI: ctx.DeviceSession.JoinEUI[:],
 		DevEUI: ctx.DeviceSession.DevEUI[:],
@@ -254,12 +268,27 @@ func sendRXInfoPayload(ds storage.DeviceSession, rxPacket models.RXPacket) error
 		mac := make([]byte, 8)
 		copy(mac, rxInfo.MAC[:])
 
-		rxInfoReq.RxInfo = append(rxInfoReq.RxInfo, &nc.RXInfo{
-			Mac:     mac,
-			Time:    rxInfo.Time.Format(time.RFC3339Nano),
-			Rssi:    int32(rxInfo.RSSI),
-			LoRaSNR: rxInfo.LoRaSNR,
-		})
+		ncRxInfo := nc.RXInfo{
+			Mac:                    mac,
+			Time:                   rxInfo.Time.Format(time.RFC3339Nano),
+			Rssi:                   int32(rxInfo.RSSI),
+			LoRaSNR:                rxInfo.LoRaSNR,
+			Board:                  uint32(rxInfo.Board),
+			Antenna:                uint32(rxInfo.Antenna),
+			FineTimestampType:      rxInfo.FineTimestampType,
+			EncryptedFineTimestamp: rxInfo.EncryptedFineTimestamp,
+			FineTimestampKeyID:     uint32(rxInfo.FineTimestampKeyID),
+		}
+
+		if rxInfo.FineTimestamp != nil {
+			ncRxInfo.FineTimestamp = uint32(*rxInfo.FineTimestamp)
+		}
+
+		if rxInfo.SignalRSSI != nil {
+			ncRxInfo.SignalRSSI = int32(*rxInfo.SignalRSSI)
+		}
+
+		rxInfoReq.RxInfo = append(rxInfoReq.RxInfo, &ncRxInfo)
 	}
 
 	_, err := common.Controller.HandleRXInfo(context.Background(), &rxInfoReq)
@@ -274,9 +303,10 @@ func sendRXInfoPayload(ds storage.DeviceSession, rxPacket models.RXPacket) error
 
 func publishDataUp(asClient as.ApplicationServerClient, ds storage.DeviceSession, sp storage.ServiceProfile, rxPacket models.RXPacket, macPL lorawan.MACPayload) error {
 	publishDataUpReq := as.HandleDataUpRequest{
-		AppEUI: ds.JoinEUI[:],
-		DevEUI: ds.DevEUI[:],
-		FCnt:   macPL.FHDR.FCnt,
+		AppEUI:       ds.JoinEUI[:],
+		DevEUI:       ds.DevEUI[:],
+		FCnt:         macPL.FHDR.FCnt,
+		SessionKeyID: ds.SessionKeyID,
 		TxInfo: &as.TXInfo{
 			Frequency: int64(rxPacket.RXInfoSet[0].Frequency),
 			Adr:       macPL.FHDR.FCtrl.ADR,
@@ -319,10 +349,23 @@ func publishDataUp(asClient as.ApplicationServerClient, ds storage.DeviceSession
 			copy(mac, rxInfo.MAC[:])
 
 			asRxInfo := as.RXInfo{
-				Mac:     mac,
-				Time:    rxInfo.Time.Format(time.RFC3339Nano),
-				Rssi:    int32(rxInfo.RSSI),
-				LoRaSNR: rxInfo.LoRaSNR,
+				Mac:                    mac,
+				Time:                   rxInfo.Time.Format(time.RFC3339Nano),
+				Rssi:                   int32(rxInfo.RSSI),
+				LoRaSNR:                rxInfo.LoRaSNR,
+				Board:                  uint32(rxInfo.Board),
+				Antenna:                uint32(rxInfo.Antenna),
+				FineTimestampType:      rxInfo.FineTimestampType,
+				EncryptedFineTimestamp: rxInfo.EncryptedFineTimestamp,
+				FineTimestampKeyID:     uint32(rxInfo.FineTimestampKeyID),
+			}
+
+			if rxInfo.FineTimestamp != nil {
+				asRxInfo.FineTimestamp = uint32(*rxInfo.FineTimestamp)
+			}
+
+			if rxInfo.SignalRSSI != nil {
+				asRxInfo.SignalRSSI = int32(*rxInfo.SignalRSSI)
 			}
 
 			if gw, ok := gws[rxInfo.MAC]; ok {
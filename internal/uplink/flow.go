@@ -1,24 +1,40 @@
 package uplink
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
+
 	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/channels"
+	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/roaming"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
 )
 
+// ErrAbort is returned by a task to stop the flow without returning an
+// error (e.g. the error has already been logged).
+var ErrAbort = errors.New("nothing to do")
+
 // JoinRequestContext holds the context of a join-request.
 type JoinRequestContext struct {
+	ReceivedAt         time.Time
 	RXPacket           models.RXPacket
 	JoinRequestPayload *lorawan.JoinRequestPayload
 	Device             storage.Device
 	ServiceProfile     storage.ServiceProfile
 	DeviceProfile      storage.DeviceProfile
 	DevAddr            lorawan.DevAddr
-	CFList             []uint32
+	ActivationChannels channels.ActivationChannels
 	JoinAnsPayload     backend.JoinAnsPayload
 	DeviceSession      storage.DeviceSession
+
+	// RoamingClient is set to the client of the hNS when the device is
+	// activated through handover roaming.
+	RoamingClient roaming.Client
 }
 
 // DataUpContext holds the context of an uplink data.
@@ -34,6 +50,7 @@ type DataUpContext struct {
 type ProprietaryUpContext struct {
 	RXPacket    models.RXPacket
 	DataPayload *lorawan.DataPayload
+	LinkTestID  string // set when the frame is a gateway link test frame
 }
 
 // JoinRequestTask is the signature of a join-request task.
@@ -91,11 +108,16 @@ func (f *Flow) ProprietaryUp(tasks ...ProprietaryUpTask) *Flow {
 
 func (f *Flow) runJoinRequestTasks(rxPacket models.RXPacket) error {
 	ctx := JoinRequestContext{
-		RXPacket: rxPacket,
+		// the flow is started after the de-duplication delay
+		ReceivedAt: time.Now().Add(-common.DeduplicationDelay),
+		RXPacket:   rxPacket,
 	}
 
 	for _, t := range f.joinRequestTasks {
 		if err := t(&ctx); err != nil {
+			if errors.Cause(err) == ErrAbort {
+				return nil
+			}
 			return err
 		}
 	}
@@ -110,6 +132,9 @@ func (f *Flow) runDataUpTasks(rxPacket models.RXPacket) error {
 
 	for _, t := range f.dataUpTasks {
 		if err := t(&ctx); err != nil {
+			if errors.Cause(err) == ErrAbort {
+				return nil
+			}
 			return err
 		}
 	}
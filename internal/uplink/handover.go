@@ -0,0 +1,654 @@
+package uplink
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/api/as"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/jsclient"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/roaming"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// handoverRoamingProfileNamespace is the namespace of the (UUID v5) IDs
+// under which the profiles received from the hNS are stored.
+var handoverRoamingProfileNamespace = uuid.NewV5(uuid.NamespaceURL, "urn:loraserver:roaming:profile")
+
+// getHandoverRoamingDeviceProfile requests the device-profile of the
+// (unknown) device from the roaming partners (sNS) handling the JoinEUI of
+// the device. It returns true when a roaming partner (hNS) allows the
+// activation of the device through handover roaming.
+func getHandoverRoamingDeviceProfile(ctx *JoinRequestContext) bool {
+	if common.RoamingPool == nil {
+		return false
+	}
+
+	deadline := ctx.ReceivedAt.Add(common.Band.JoinAcceptDelay1 - joinAcceptSchedulingMargin)
+
+	for _, client := range common.RoamingPool.GetForJoinEUI(ctx.JoinRequestPayload.AppEUI) {
+		logFields := log.Fields{
+			"net_id":  client.NetID(),
+			"dev_eui": ctx.JoinRequestPayload.DevEUI,
+		}
+
+		reqCtx, cancel := context.WithDeadline(context.Background(), deadline)
+		ans, err := client.ProfileReq(reqCtx, backend.ProfileReqPayload{
+			DevEUI: ctx.JoinRequestPayload.DevEUI,
+		})
+		cancel()
+		if err != nil {
+			if resErr, ok := errors.Cause(err).(*roaming.ResultError); !ok || resErr.ResultCode != backend.UnknownDevEUI {
+				log.WithFields(logFields).Warningf("roaming: profile request error: %s", err)
+			}
+			continue
+		}
+
+		if ans.DeviceProfile == nil || ans.RoamingActivationType == nil || *ans.RoamingActivationType != backend.Handover {
+			log.WithFields(logFields).Info("roaming: handover roaming is not allowed by hNS")
+			continue
+		}
+
+		if !ans.DeviceProfile.SupportsJoin {
+			log.WithFields(logFields).Warning("roaming: device does not support join")
+			continue
+		}
+
+		ctx.RoamingClient = client
+		ctx.Device = storage.Device{
+			DevEUI:          ctx.JoinRequestPayload.DevEUI,
+			DeviceProfileID: ans.DeviceProfile.DeviceProfileID,
+		}
+		// the backend interfaces device-profile can't express an explicit
+		// RXDROffset1 or RXDataRate2 of 0
+		ctx.DeviceProfile = storage.DeviceProfile{
+			DeviceProfile:  *ans.DeviceProfile,
+			RXDROffset1Set: ans.DeviceProfile.RXDROffset1 != 0,
+			RXDataRate2Set: ans.DeviceProfile.RXDataRate2 != 0,
+		}
+		if ans.DeviceProfileTimestamp != nil {
+			ctx.DeviceProfile.UpdatedAt, _ = time.Parse(time.RFC3339Nano, *ans.DeviceProfileTimestamp)
+		}
+
+		log.WithFields(logFields).Info("roaming: device is activated through handover roaming")
+		return true
+	}
+
+	return false
+}
+
+// getJoinAcceptFromHomeNS requests the join-accept and session-keys from
+// the hNS when the device is activated through handover roaming (sNS).
+func getJoinAcceptFromHomeNS(ctx *JoinRequestContext) error {
+	if ctx.RoamingClient == nil {
+		return nil
+	}
+
+	b, err := ctx.RXPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "PHYPayload marshal binary error")
+	}
+
+	ulMetaData, err := getULMetaData(ctx.RoamingClient.NetID(), ctx.RXPacket, nil)
+	if err != nil {
+		return errors.Wrap(err, "get uplink meta-data error")
+	}
+	ulMetaData.DevEUI = ctx.JoinRequestPayload.DevEUI
+	ulMetaData.DevAddr = ctx.DevAddr
+
+	// the join-server of the hNS might not handle the CFListType
+	ctx.ActivationChannels = ctx.ActivationChannels.WithoutChannelMask()
+
+	deadline := ctx.ReceivedAt.Add(common.Band.JoinAcceptDelay1 - joinAcceptSchedulingMargin)
+	reqCtx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	ans, err := ctx.RoamingClient.HRStartReq(reqCtx, backend.HRStartReqPayload{
+		MACVersion:    ctx.DeviceProfile.MACVersion,
+		PHYPayload:    backend.HEXBytes(b),
+		DevAddr:       ctx.DevAddr,
+		DeviceProfile: ctx.DeviceProfile.DeviceProfile,
+		ULMetaData:    ulMetaData,
+		DLSettings: lorawan.DLSettings{
+			RX2DataRate: uint8(ctx.DeviceProfile.GetRXDataRate2()),
+			RX1DROffset: uint8(ctx.DeviceProfile.GetRXDROffset1()),
+		},
+		RxDelay:                ctx.DeviceProfile.GetRXDelay1(),
+		CFList:                 ctx.ActivationChannels.CFList,
+		CFListType:             ctx.ActivationChannels.CFListType,
+		DeviceProfileTimestamp: ctx.DeviceProfile.UpdatedAt.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		if resErr, ok := errors.Cause(err).(*roaming.ResultError); ok {
+			log.WithFields(log.Fields{
+				"net_id":      ctx.RoamingClient.NetID(),
+				"dev_eui":     ctx.JoinRequestPayload.DevEUI,
+				"result_code": resErr.ResultCode,
+			}).Warningf("join-request rejected by hNS: %s", resErr.Description)
+			return ErrAbort
+		}
+		return errors.Wrap(err, "handover-roaming start request error")
+	}
+
+	if ans.NwkSKey == nil || ans.ServiceProfile == nil {
+		return errors.New("hNS did not return NwkSKey and ServiceProfile")
+	}
+
+	ctx.JoinAnsPayload = backend.JoinAnsPayload{
+		PHYPayload: ans.PHYPayload,
+		NwkSKey:    ans.NwkSKey,
+	}
+	ctx.ServiceProfile = storage.ServiceProfile{
+		ServiceProfile: *ans.ServiceProfile,
+	}
+
+	// the profiles are used by the uplink and downlink flows
+	if err := saveHandoverRoamingProfiles(ctx.RoamingClient.NetID(), &ctx.DeviceProfile, &ctx.ServiceProfile); err != nil {
+		return errors.Wrap(err, "save handover-roaming profiles error")
+	}
+	ctx.Device.DeviceProfileID = ctx.DeviceProfile.DeviceProfile.DeviceProfileID
+	ctx.Device.ServiceProfileID = ctx.ServiceProfile.ServiceProfile.ServiceProfileID
+
+	return nil
+}
+
+// getHandoverRoamingProfileID returns the ID under which the profile with
+// the given ID, received from the hNS with the given NetID, is stored. As
+// the ID is namespaced by the NetID, a roaming partner is not able to
+// overwrite the local profiles or the profiles of an other partner.
+func getHandoverRoamingProfileID(netID lorawan.NetID, id string) string {
+	return uuid.NewV5(handoverRoamingProfileNamespace, netID.String()+"/"+id).String()
+}
+
+// saveHandoverRoamingProfiles creates or updates the given profiles,
+// received from the hNS with the given NetID. The IDs of the profiles are
+// replaced by the IDs returned by getHandoverRoamingProfileID.
+func saveHandoverRoamingProfiles(netID lorawan.NetID, dp *storage.DeviceProfile, sp *storage.ServiceProfile) error {
+	dp.DeviceProfile.DeviceProfileID = getHandoverRoamingProfileID(netID, dp.DeviceProfile.DeviceProfileID)
+	sp.ServiceProfile.ServiceProfileID = getHandoverRoamingProfileID(netID, sp.ServiceProfile.ServiceProfileID)
+
+	_, err := storage.GetDeviceProfile(common.DB, dp.DeviceProfile.DeviceProfileID)
+	switch errors.Cause(err) {
+	case nil:
+		err = storage.UpdateDeviceProfile(common.DB, dp)
+	case storage.ErrDoesNotExist:
+		err = storage.CreateDeviceProfile(common.DB, dp)
+	}
+	if err != nil {
+		return errors.Wrap(err, "save device-profile error")
+	}
+
+	_, err = storage.GetServiceProfile(common.DB, sp.ServiceProfile.ServiceProfileID)
+	switch errors.Cause(err) {
+	case nil:
+		err = storage.UpdateServiceProfile(common.DB, sp)
+	case storage.ErrDoesNotExist:
+		err = storage.CreateServiceProfile(common.DB, sp)
+	}
+	if err != nil {
+		return errors.Wrap(err, "save service-profile error")
+	}
+
+	return nil
+}
+
+// stopHandoverRoaming terminates the handover-roaming session of the device
+// when it (re)joins through this network-server (hNS). It is called after
+// sending the join-accept, as the HRStopReq might block until its timeout.
+func stopHandoverRoaming(ctx *JoinRequestContext) error {
+	if common.RoamingPool == nil || ctx.RoamingClient != nil {
+		return nil
+	}
+
+	if err := stopHandoverSession(ctx.DeviceSession.DevEUI, nil); err != nil {
+		log.WithField("dev_eui", ctx.DeviceSession.DevEUI).Errorf("roaming: stop handover session error: %s", err)
+	}
+
+	return nil
+}
+
+// stopHandoverSession terminates the handover-roaming session of the given
+// device (hNS). The sNS is notified using a HRStopReq, unless it has the
+// given NetID (e.g. the sNS starting a new session).
+func stopHandoverSession(devEUI lorawan.EUI64, except *lorawan.NetID) error {
+	hs, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+	if err != nil {
+		if errors.Cause(err) == roaming.ErrDoesNotExist {
+			return nil
+		}
+		return errors.Wrap(err, "get handover session error")
+	}
+
+	if err := roaming.DeleteHandoverSession(common.RedisPool, devEUI); err != nil {
+		return errors.Wrap(err, "delete handover session error")
+	}
+
+	if except != nil && *except == hs.NetID {
+		return nil
+	}
+
+	client, err := getRoamingClient(hs.NetID)
+	if err != nil {
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	_, err = client.HRStopReq(context.Background(), backend.HRStopReqPayload{
+		DevEUI: devEUI,
+	})
+	if err != nil {
+		return errors.Wrap(err, "handover-roaming stop request error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":  hs.NetID,
+		"dev_eui": devEUI,
+	}).Info("roaming: handover session stopped")
+
+	return nil
+}
+
+// sendFRMPayloadToHomeNS forwards the application payload of the given
+// uplink to the hNS (handover-roaming sNS).
+func sendFRMPayloadToHomeNS(ds storage.DeviceSession, rxPacket models.RXPacket, macPL *lorawan.MACPayload) error {
+	client, err := getRoamingClient(*ds.HomeNetID)
+	if err != nil {
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	ulMetaData, err := getULMetaData(client.NetID(), rxPacket, macPL)
+	if err != nil {
+		return errors.Wrap(err, "get uplink meta-data error")
+	}
+	ulMetaData.DevEUI = ds.DevEUI
+	ulMetaData.FCntDown = int(ds.FCntDown)
+	ulMetaData.Battery = int(ds.LastDevStatusBattery)
+	ulMetaData.Margin = int(ds.LastDevStatusMargin)
+
+	var frmPayload []byte
+	if len(macPL.FRMPayload) == 1 {
+		dataPL, ok := macPL.FRMPayload[0].(*lorawan.DataPayload)
+		if !ok {
+			return errors.Errorf("expected type *lorawan.DataPayload, got %T", macPL.FRMPayload[0])
+		}
+		frmPayload = dataPL.Bytes
+	}
+
+	_, err = client.XmitDataReq(context.Background(), backend.XmitDataReqPayload{
+		FRMPayload: backend.HEXBytes(frmPayload),
+		ULMetaData: &ulMetaData,
+	})
+	if err != nil {
+		return errors.Wrap(err, "xmit data request error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":  *ds.HomeNetID,
+		"dev_eui": ds.DevEUI,
+	}).Info("roaming: uplink payload forwarded to hNS")
+
+	return nil
+}
+
+// HandleProfileReq handles the profile request of the roaming partner with
+// the given NetID (hNS). It returns the device-profile of the device and
+// the roaming activation type allowed by its service-profile.
+func HandleProfileReq(netID lorawan.NetID, devEUI lorawan.EUI64) (storage.DeviceProfile, backend.RoamingType, error) {
+	var dp storage.DeviceProfile
+
+	if _, err := getRoamingClient(netID); err != nil {
+		return dp, "", err
+	}
+
+	d, sp, err := getHandoverRoamingDevice(devEUI)
+	if err != nil {
+		return dp, "", err
+	}
+
+	var rt backend.RoamingType
+	switch {
+	case !sp.ServiceProfile.RAAllowed:
+		return dp, "", roaming.ErrRoamingDisallowed
+	case sp.ServiceProfile.HRAllowed:
+		rt = backend.Handover
+	case sp.ServiceProfile.PRAllowed:
+		rt = backend.Passive
+	default:
+		return dp, "", roaming.ErrRoamingDisallowed
+	}
+
+	dp, err = storage.GetDeviceProfile(common.DB, d.DeviceProfileID)
+	if err != nil {
+		return dp, "", errors.Wrap(err, "get device-profile error")
+	}
+
+	return dp, rt, nil
+}
+
+// HandleHRStartReq handles the handover-roaming start request of the
+// roaming partner with the given NetID (hNS). The join-request is sent to
+// the join-server, a previous session of the device is terminated and the
+// handover-roaming session is stored. It returns the join-server answer
+// and the service-profile of the device.
+func HandleHRStartReq(netID lorawan.NetID, pl backend.HRStartReqPayload) (backend.JoinAnsPayload, storage.ServiceProfile, error) {
+	var joinAns backend.JoinAnsPayload
+	var sp storage.ServiceProfile
+
+	if _, err := getRoamingClient(netID); err != nil {
+		return joinAns, sp, err
+	}
+
+	var phy lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(pl.PHYPayload); err != nil {
+		return joinAns, sp, errors.Wrap(roaming.ErrMalformedRequest, err.Error())
+	}
+	jrPL, ok := phy.MACPayload.(*lorawan.JoinRequestPayload)
+	if !ok {
+		return joinAns, sp, errors.Wrap(roaming.ErrMalformedRequest, "join-request payload expected")
+	}
+
+	d, sp, err := getHandoverRoamingDevice(jrPL.DevEUI)
+	if err != nil {
+		return joinAns, sp, err
+	}
+	if !sp.ServiceProfile.RAAllowed || !sp.ServiceProfile.HRAllowed {
+		return joinAns, sp, roaming.ErrRoamingDisallowed
+	}
+
+	dp, err := storage.GetDeviceProfile(common.DB, d.DeviceProfileID)
+	if err != nil {
+		return joinAns, sp, errors.Wrap(err, "get device-profile error")
+	}
+
+	err = storage.ValidateDevNonce(common.DB, jrPL.AppEUI, jrPL.DevEUI, jrPL.DevNonce)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrAlreadyExists {
+			return joinAns, sp, errors.Wrap(roaming.ErrJoinReqFailed, "DevNonce has already been used")
+		}
+		return joinAns, sp, errors.Wrap(err, "validate dev-nonce error")
+	}
+
+	basePL, err := getJoinReqBasePayload(jrPL.AppEUI)
+	if err != nil {
+		return joinAns, sp, err
+	}
+
+	jsClient, err := common.JoinServerPool.Get(jrPL.AppEUI)
+	if err != nil {
+		return joinAns, sp, errors.Wrap(err, "get join-server client error")
+	}
+
+	// the join-accept must be returned in time for the sNS to schedule it
+	// for the first receive window
+	reqCtx, cancel := context.WithTimeout(context.Background(), common.Band.JoinAcceptDelay1-joinAcceptSchedulingMargin)
+	defer cancel()
+
+	joinAns, err = jsClient.JoinReq(reqCtx, backend.JoinReqPayload{
+		BasePayload: basePL,
+		MACVersion:  dp.MACVersion,
+		PHYPayload:  pl.PHYPayload,
+		DevEUI:      jrPL.DevEUI,
+		DevAddr:     pl.DevAddr,
+		DLSettings:  pl.DLSettings,
+		RxDelay:     pl.RxDelay,
+		CFList:      pl.CFList,
+		CFListType:  pl.CFListType,
+	})
+	if err != nil {
+		if resErr, ok := errors.Cause(err).(*jsclient.ResultError); ok {
+			return joinAns, sp, errors.Wrapf(roaming.ErrJoinReqFailed, "join-server returned result %s: %s", resErr.ResultCode, resErr.Description)
+		}
+		return joinAns, sp, errors.Wrap(err, "join-request to join-server error")
+	}
+	if joinAns.NwkSKey == nil {
+		return joinAns, sp, errors.New("join-server did not return NwkSKey")
+	}
+
+	// terminate the previous session of the device, the device-session
+	// is now owned by the sNS
+	if err := stopHandoverSession(jrPL.DevEUI, &netID); err != nil {
+		log.WithField("dev_eui", jrPL.DevEUI).Errorf("roaming: stop handover session error: %s", err)
+	}
+	if err := storage.DeleteDeviceSession(common.RedisPool, jrPL.DevEUI); err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return joinAns, sp, errors.Wrap(err, "delete device-session error")
+	}
+
+	err = storage.CreateDeviceActivation(common.DB, &storage.DeviceActivation{
+		DevEUI:   jrPL.DevEUI,
+		JoinEUI:  jrPL.AppEUI,
+		DevAddr:  pl.DevAddr,
+		NwkSKey:  joinAns.NwkSKey.AESKey,
+		DevNonce: jrPL.DevNonce,
+	})
+	if err != nil {
+		return joinAns, sp, errors.Wrap(err, "create device-activation error")
+	}
+
+	hs := roaming.HandoverSession{
+		NetID:   netID,
+		DevEUI:  jrPL.DevEUI,
+		JoinEUI: jrPL.AppEUI,
+		DevAddr: pl.DevAddr,
+	}
+	if common.RoamingHandoverLifetime > 0 {
+		hs.ExpiresAt = time.Now().Add(common.RoamingHandoverLifetime)
+	}
+	if err := roaming.SaveHandoverSession(common.RedisPool, hs, common.NodeSessionTTL); err != nil {
+		return joinAns, sp, errors.Wrap(err, "save handover session error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":   netID,
+		"dev_eui":  jrPL.DevEUI,
+		"dev_addr": pl.DevAddr,
+	}).Info("roaming: handover session started")
+
+	return joinAns, sp, nil
+}
+
+// HandleHRStopReq handles the handover-roaming stop request of the roaming
+// partner with the given NetID. As hNS, the sNS terminates the
+// handover-roaming session. As sNS, the hNS terminates the device-session.
+func HandleHRStopReq(netID lorawan.NetID, devEUI lorawan.EUI64) error {
+	if _, err := getRoamingClient(netID); err != nil {
+		return err
+	}
+
+	hs, err := roaming.GetHandoverSession(common.RedisPool, devEUI)
+	if err != nil && errors.Cause(err) != roaming.ErrDoesNotExist {
+		return errors.Wrap(err, "get handover session error")
+	}
+	if err == nil && hs.NetID == netID {
+		if err := roaming.DeleteHandoverSession(common.RedisPool, devEUI); err != nil {
+			return errors.Wrap(err, "delete handover session error")
+		}
+		log.WithFields(log.Fields{
+			"net_id":  netID,
+			"dev_eui": devEUI,
+		}).Info("roaming: handover session stopped by sNS")
+		return nil
+	}
+
+	ds, err := storage.GetDeviceSession(common.RedisPool, devEUI)
+	if err != nil && errors.Cause(err) != storage.ErrDoesNotExist {
+		return errors.Wrap(err, "get device-session error")
+	}
+	if err == nil && ds.HomeNetID != nil && *ds.HomeNetID == netID {
+		if err := storage.DeleteDeviceSession(common.RedisPool, devEUI); err != nil {
+			return errors.Wrap(err, "delete device-session error")
+		}
+		log.WithFields(log.Fields{
+			"net_id":  netID,
+			"dev_eui": devEUI,
+		}).Info("roaming: handover session stopped by hNS")
+		return nil
+	}
+
+	return roaming.ErrUnknownDevEUI
+}
+
+// HandleHandoverDataUp handles the application payload forwarded by the
+// sNS of the roaming partner with the given NetID (handover-roaming hNS).
+// The payload is sent to the application-server and the frame-counters of
+// the session are synchronized. Payloads with a frame-counter below the
+// synchronized frame-counter are rejected. A pending downlink payload of the
+// application-server is sent to the sNS.
+func HandleHandoverDataUp(netID lorawan.NetID, frmPayload []byte, ulMetaData backend.ULMetaData) error {
+	client, err := getRoamingClient(netID)
+	if err != nil {
+		return err
+	}
+
+	hs, err := roaming.GetHandoverSession(common.RedisPool, ulMetaData.DevEUI)
+	if err != nil {
+		if errors.Cause(err) == roaming.ErrDoesNotExist {
+			return roaming.ErrUnknownDevEUI
+		}
+		return errors.Wrap(err, "get handover session error")
+	}
+	if hs.NetID != netID {
+		return roaming.ErrUnknownDevEUI
+	}
+
+	if ulMetaData.DataRate < 0 || ulMetaData.DataRate > len(common.Band.DataRates)-1 {
+		return errors.Wrapf(roaming.ErrMalformedRequest, "invalid data-rate: %d", ulMetaData.DataRate)
+	}
+	dr := common.Band.DataRates[ulMetaData.DataRate]
+
+	// the frame-counters must only increase, a replayed or stale payload
+	// would otherwise be sent to the application-server again
+	if ulMetaData.FCntUp < 0 || uint32(ulMetaData.FCntUp) < hs.FCntUp {
+		return errors.Wrapf(roaming.ErrMalformedRequest, "invalid frame-counter: %d (expected >= %d)", ulMetaData.FCntUp, hs.FCntUp)
+	}
+
+	d, sp, err := getHandoverRoamingDevice(hs.DevEUI)
+	if err != nil {
+		return err
+	}
+
+	rp, err := storage.GetRoutingProfile(common.DB, d.RoutingProfileID)
+	if err != nil {
+		return errors.Wrap(err, "get routing-profile error")
+	}
+
+	asClient, err := common.ApplicationServerPool.Get(rp.ASID)
+	if err != nil {
+		return errors.Wrap(err, "get application-server client error")
+	}
+
+	publishDataUpReq := as.HandleDataUpRequest{
+		AppEUI: hs.JoinEUI[:],
+		DevEUI: hs.DevEUI[:],
+		FCnt:   uint32(ulMetaData.FCntUp),
+		FPort:  uint32(ulMetaData.FPort),
+		Data:   frmPayload,
+		TxInfo: &as.TXInfo{
+			Frequency: int64(ulMetaData.ULFreq*1000000 + 0.5),
+			DataRate: &as.DataRate{
+				Modulation:   string(dr.Modulation),
+				BandWidth:    uint32(dr.Bandwidth),
+				SpreadFactor: uint32(dr.SpreadFactor),
+				Bitrate:      uint32(dr.BitRate),
+			},
+		},
+	}
+
+	if sp.ServiceProfile.DevStatusReqFreq != 0 {
+		if sp.ServiceProfile.ReportDevStatusBattery {
+			publishDataUpReq.DeviceStatusBattery = uint32(ulMetaData.Battery)
+		}
+		if sp.ServiceProfile.ReportDevStatusMargin {
+			publishDataUpReq.DeviceStatusMargin = int32(ulMetaData.Margin)
+		}
+	}
+
+	if sp.ServiceProfile.AddGWMetadata {
+		for _, gwInfo := range ulMetaData.GWInfo {
+			publishDataUpReq.RxInfo = append(publishDataUpReq.RxInfo, &as.RXInfo{
+				Mac:     gwInfo.ID,
+				Time:    ulMetaData.RecvTime,
+				Rssi:    int32(gwInfo.RSSI),
+				LoRaSNR: gwInfo.SNR,
+			})
+		}
+	}
+
+	if _, err := asClient.HandleDataUp(context.Background(), &publishDataUpReq); err != nil {
+		return errors.Wrap(err, "publish data up to application-server error")
+	}
+
+	// synchronize the session state with the sNS
+	hs.FCntUp = uint32(ulMetaData.FCntUp) + 1
+	if ulMetaData.FCntDown > 0 && uint32(ulMetaData.FCntDown) > hs.FCntDown {
+		hs.FCntDown = uint32(ulMetaData.FCntDown)
+	}
+	if err := roaming.SaveHandoverSession(common.RedisPool, hs, common.NodeSessionTTL); err != nil {
+		return errors.Wrap(err, "save handover session error")
+	}
+
+	resp, err := asClient.GetDataDown(context.Background(), &as.GetDataDownRequest{
+		AppEUI:         hs.JoinEUI[:],
+		DevEUI:         hs.DevEUI[:],
+		MaxPayloadSize: uint32(common.Band.MaxPayloadSize[ulMetaData.DataRate].N),
+		FCnt:           hs.FCntDown,
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"dev_eui": hs.DevEUI,
+			"fcnt":    hs.FCntDown,
+		}).Errorf("get data down from application error: %s", err)
+		return nil
+	}
+	if resp == nil || resp.FPort == 0 {
+		return nil
+	}
+
+	_, err = client.XmitDataReq(context.Background(), backend.XmitDataReqPayload{
+		FRMPayload: backend.HEXBytes(resp.Data),
+		DLMetaData: &backend.DLMetaData{
+			DevEUI:    hs.DevEUI,
+			FPort:     int(resp.FPort),
+			FCntDown:  int(hs.FCntDown),
+			Confirmed: resp.Confirmed,
+			ClassMode: "A",
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "xmit data request error")
+	}
+
+	log.WithFields(log.Fields{
+		"net_id":  netID,
+		"dev_eui": hs.DevEUI,
+		"fcnt":    hs.FCntDown,
+	}).Info("roaming: downlink payload sent to sNS")
+
+	return nil
+}
+
+// getHandoverRoamingDevice returns the device and its service-profile.
+// ErrUnknownDevEUI is returned when the device does not exist.
+func getHandoverRoamingDevice(devEUI lorawan.EUI64) (storage.Device, storage.ServiceProfile, error) {
+	var sp storage.ServiceProfile
+
+	d, err := storage.GetDevice(common.DB, devEUI)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			return d, sp, roaming.ErrUnknownDevEUI
+		}
+		return d, sp, errors.Wrap(err, "get device error")
+	}
+
+	sp, err = storage.GetServiceProfile(common.DB, d.ServiceProfileID)
+	if err != nil {
+		return d, sp, errors.Wrap(err, "get service-profile error")
+	}
+
+	return d, sp, nil
+}
@@ -5,18 +5,28 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
 
+	"github.com/brocaar/loraserver/internal/channels"
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/downlink"
+	"github.com/brocaar/loraserver/internal/joinlimit"
+	"github.com/brocaar/loraserver/internal/jsclient"
 	"github.com/brocaar/loraserver/internal/maccommand"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 	"github.com/brocaar/lorawan/backend"
 )
 
+// joinAcceptSchedulingMargin defines the time reserved for completing the
+// join-request flow and scheduling the join-accept at the gateway after the
+// join-server has responded.
+const joinAcceptSchedulingMargin = 500 * time.Millisecond
+
 func setContextFromJoinRequestPHYPayload(ctx *JoinRequestContext) error {
 	jrPL, ok := ctx.RXPacket.PHYPayload.MACPayload.(*lorawan.JoinRequestPayload)
 	if !ok {
@@ -43,11 +53,114 @@ func logJoinRequestFramesCollected(ctx *JoinRequestContext) error {
 	return nil
 }
 
+func checkJoinRateLimits(ctx *JoinRequestContext) error {
+	unknown, err := joinlimit.IsUnknownDevEUI(common.RedisPool, ctx.JoinRequestPayload.DevEUI)
+	if err != nil {
+		return errors.Wrap(err, "get unknown DevEUI error")
+	}
+	if unknown {
+		return rejectJoinRequest(ctx, joinlimit.UnknownDevEUI)
+	}
+
+	// as the join-request has not been authenticated yet, it is only
+	// checked if a back-off is active (see countJoinRequest) and counted
+	// against the (higher) attempt limit, to protect the database and the
+	// join-server against forged join-requests
+	if common.JoinAttemptLimitDevEUI > 0 {
+		allowed, err := joinlimit.AllowAttempt(common.RedisPool, joinlimit.DevEUIAttempt, ctx.JoinRequestPayload.DevEUI, common.JoinAttemptLimitDevEUI, common.JoinRateLimitInterval)
+		if err != nil {
+			return errors.Wrap(err, "DevEUI attempt limit error")
+		}
+		if !allowed {
+			return rejectJoinRequest(ctx, joinlimit.DevEUIAttemptsExceeded)
+		}
+	}
+
+	if common.JoinRateLimitDevEUI > 0 {
+		backoff, err := joinlimit.InBackoff(common.RedisPool, joinlimit.DevEUI, ctx.JoinRequestPayload.DevEUI)
+		if err != nil {
+			return errors.Wrap(err, "DevEUI rate limit error")
+		}
+		if backoff {
+			return rejectJoinRequest(ctx, joinlimit.DevEUIRateLimited)
+		}
+	}
+
+	if common.JoinRateLimitJoinEUI > 0 {
+		backoff, err := joinlimit.InBackoff(common.RedisPool, joinlimit.JoinEUI, ctx.JoinRequestPayload.AppEUI)
+		if err != nil {
+			return errors.Wrap(err, "JoinEUI rate limit error")
+		}
+		if backoff {
+			return rejectJoinRequest(ctx, joinlimit.JoinEUIRateLimited)
+		}
+	}
+
+	return nil
+}
+
+// countJoinRequest counts the join-request for the rate limits. This is
+// done after the join-request has been authenticated by the join-server
+// (or hNS), so that forged join-requests can't consume the limits of a
+// device.
+func countJoinRequest(ctx *JoinRequestContext) error {
+	if common.JoinRateLimitDevEUI > 0 {
+		allowed, err := joinlimit.Allow(common.RedisPool, joinlimit.DevEUI, ctx.JoinRequestPayload.DevEUI, common.JoinRateLimitDevEUI, common.JoinRateLimitInterval, common.JoinRateLimitMaxBackoff)
+		if err != nil {
+			return errors.Wrap(err, "DevEUI rate limit error")
+		}
+		if !allowed {
+			return rejectJoinRequest(ctx, joinlimit.DevEUIRateLimited)
+		}
+	}
+
+	if common.JoinRateLimitJoinEUI > 0 {
+		allowed, err := joinlimit.Allow(common.RedisPool, joinlimit.JoinEUI, ctx.JoinRequestPayload.AppEUI, common.JoinRateLimitJoinEUI, common.JoinRateLimitInterval, common.JoinRateLimitMaxBackoff)
+		if err != nil {
+			return errors.Wrap(err, "JoinEUI rate limit error")
+		}
+		if !allowed {
+			return rejectJoinRequest(ctx, joinlimit.JoinEUIRateLimited)
+		}
+	}
+
+	return nil
+}
+
+// rejectJoinRequest logs and counts the rejected join-request and returns
+// ErrAbort.
+func rejectJoinRequest(ctx *JoinRequestContext, reason joinlimit.Reason) error {
+	if err := joinlimit.IncrementRejected(common.RedisPool, reason); err != nil {
+		log.Errorf("increment rejected join-requests error: %s", err)
+	}
+
+	log.WithFields(log.Fields{
+		"dev_eui":  ctx.JoinRequestPayload.DevEUI,
+		"join_eui": ctx.JoinRequestPayload.AppEUI,
+		"reason":   reason,
+	}).Warning("join-request rejected")
+
+	return ErrAbort
+}
+
 func getDeviceAndDeviceProfile(ctx *JoinRequestContext) error {
 	var err error
 
 	ctx.Device, err = storage.GetDevice(common.DB, ctx.JoinRequestPayload.DevEUI)
 	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExist {
+			// the device might be activated through handover roaming
+			if getHandoverRoamingDeviceProfile(ctx) {
+				return nil
+			}
+
+			if common.UnknownDevEUICacheTTL > 0 {
+				if err := joinlimit.SetUnknownDevEUI(common.RedisPool, ctx.JoinRequestPayload.DevEUI, common.UnknownDevEUICacheTTL); err != nil {
+					log.Errorf("set unknown DevEUI error: %s", err)
+				}
+			}
+			return rejectJoinRequest(ctx, joinlimit.UnknownDevEUI)
+		}
 		return errors.Wrap(err, "get device error")
 	}
 
@@ -69,6 +182,11 @@ func getDeviceAndDeviceProfile(ctx *JoinRequestContext) error {
 }
 
 func validateNonce(ctx *JoinRequestContext) error {
+	// the nonce is validated by the hNS (handover roaming)
+	if ctx.RoamingClient != nil {
+		return nil
+	}
+
 	// validate that the nonce has not been used yet
 	err := storage.ValidateDevNonce(common.DB, ctx.JoinRequestPayload.AppEUI, ctx.JoinRequestPayload.DevEUI, ctx.JoinRequestPayload.DevNonce)
 	if err != nil {
@@ -79,7 +197,12 @@ func validateNonce(ctx *JoinRequestContext) error {
 }
 
 func getRandomDevAddr(ctx *JoinRequestContext) error {
-	devAddr, err := storage.GetRandomDevAddr(common.RedisPool, common.NetID)
+	ranges := common.DevAddrRanges
+	if len(ctx.ServiceProfile.DevAddrRanges) != 0 {
+		ranges = ctx.ServiceProfile.DevAddrRanges
+	}
+
+	devAddr, err := storage.GetRandomDevAddr(common.RedisPool, common.NetID, ranges)
 	if err != nil {
 		return errors.Wrap(err, "get random DevAddr error")
 	}
@@ -88,60 +211,106 @@ func getRandomDevAddr(ctx *JoinRequestContext) error {
 	return nil
 }
 
+func getActivationChannels(ctx *JoinRequestContext) error {
+	var err error
+	ctx.ActivationChannels, err = channels.GetActivationChannels(common.DB, ctx.DeviceProfile.MACVersion, ctx.RXPacket.RXInfoSet)
+	if err != nil {
+		return errors.Wrap(err, "get activation channels error")
+	}
+
+	return nil
+}
+
 func getJoinAcceptFromAS(ctx *JoinRequestContext) error {
+	// the join-accept is obtained through the hNS (handover roaming)
+	if ctx.RoamingClient != nil {
+		return nil
+	}
+
 	b, err := ctx.RXPacket.PHYPayload.MarshalBinary()
 	if err != nil {
 		return errors.Wrap(err, "PHYPayload marshal binary error")
 	}
 
-	randomBytes := make([]byte, 4)
-	_, err = rand.Read(randomBytes)
+	basePL, err := getJoinReqBasePayload(ctx.JoinRequestPayload.AppEUI)
+	if err != nil {
+		return err
+	}
+
+	jsClient, err := common.JoinServerPool.Get(ctx.JoinRequestPayload.AppEUI)
 	if err != nil {
-		return errors.Wrap(err, "read random bytes error")
+		return errors.Wrap(err, "get join-server client error")
+	}
+
+	// a join-server not handling the CFListType would encode the
+	// channel-mask as a list of frequencies
+	if !jsclient.SupportsCFListType(jsClient) {
+		ctx.ActivationChannels = ctx.ActivationChannels.WithoutChannelMask()
 	}
-	transactionID := binary.LittleEndian.Uint32(randomBytes)
 
 	joinReqPL := backend.JoinReqPayload{
-		BasePayload: backend.BasePayload{
-			ProtocolVersion: backend.ProtocolVersion1_0,
-			SenderID:        common.NetID.String(),
-			ReceiverID:      ctx.JoinRequestPayload.AppEUI.String(),
-			TransactionID:   transactionID,
-			MessageType:     backend.JoinReq,
-		},
-		MACVersion: ctx.DeviceProfile.MACVersion,
-		PHYPayload: backend.HEXBytes(b),
-		DevEUI:     ctx.JoinRequestPayload.DevEUI,
-		DevAddr:    ctx.DevAddr,
+		BasePayload: basePL,
+		MACVersion:  ctx.DeviceProfile.MACVersion,
+		PHYPayload:  backend.HEXBytes(b),
+		DevEUI:      ctx.JoinRequestPayload.DevEUI,
+		DevAddr:     ctx.DevAddr,
 		DLSettings: lorawan.DLSettings{
-			RX2DataRate: uint8(common.RX2DR),
-			RX1DROffset: uint8(common.RX1DROffset),
+			RX2DataRate: uint8(ctx.DeviceProfile.GetRXDataRate2()),
+			RX1DROffset: uint8(ctx.DeviceProfile.GetRXDROffset1()),
 		},
-		RxDelay: common.RX1Delay,
-		CFList:  common.Band.GetCFList(),
+		RxDelay:    ctx.DeviceProfile.GetRXDelay1(),
+		CFList:     ctx.ActivationChannels.CFList,
+		CFListType: ctx.ActivationChannels.CFListType,
 	}
 
-	jsClient, err := common.JoinServerPool.Get(ctx.JoinRequestPayload.AppEUI)
-	if err != nil {
-		return errors.Wrap(err, "get join-server client error")
-	}
+	// the join-server must respond in time for the join-accept to be
+	// scheduled for the first receive window
+	deadline := ctx.ReceivedAt.Add(common.Band.JoinAcceptDelay1 - joinAcceptSchedulingMargin)
+	reqCtx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
 
-	ctx.JoinAnsPayload, err = jsClient.JoinReq(joinReqPL)
+	ctx.JoinAnsPayload, err = jsClient.JoinReq(reqCtx, joinReqPL)
 	if err != nil {
+		if resErr, ok := errors.Cause(err).(*jsclient.ResultError); ok {
+			log.WithFields(log.Fields{
+				"dev_eui":     ctx.JoinRequestPayload.DevEUI,
+				"join_eui":    ctx.JoinRequestPayload.AppEUI,
+				"result_code": resErr.ResultCode,
+			}).Warningf("join-request rejected by join-server: %s", resErr.Description)
+			return ErrAbort
+		}
 		return errors.Wrap(err, "join-request to join-server error")
 	}
 
 	return nil
 }
 
+// getJoinReqBasePayload returns the base payload of a join-request to the
+// join-server of the given JoinEUI.
+func getJoinReqBasePayload(joinEUI lorawan.EUI64) (backend.BasePayload, error) {
+	randomBytes := make([]byte, 4)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return backend.BasePayload{}, errors.Wrap(err, "read random bytes error")
+	}
+
+	return backend.BasePayload{
+		ProtocolVersion: backend.ProtocolVersion1_0,
+		SenderID:        common.NetID.String(),
+		ReceiverID:      joinEUI.String(),
+		TransactionID:   binary.LittleEndian.Uint32(randomBytes),
+		MessageType:     backend.JoinReq,
+	}, nil
+}
+
 func logJoinRequestFrame(ctx *JoinRequestContext) error {
 	logUplink(common.DB, ctx.JoinRequestPayload.DevEUI, ctx.RXPacket)
 	return nil
 }
 
 func createNodeSession(ctx *JoinRequestContext) error {
-	if ctx.JoinAnsPayload.NwkSKey.KEKLabel != "" {
-		return errors.New("NwkSKey KEKLabel unsupported")
+	// the join-server client returns the session-keys unwrapped
+	if ctx.JoinAnsPayload.NwkSKey == nil {
+		return errors.New("join-server did not return NwkSKey")
 	}
 
 	ctx.DeviceSession = storage.DeviceSession{
@@ -153,17 +322,24 @@ func createNodeSession(ctx *JoinRequestContext) error {
 		JoinEUI:         ctx.JoinRequestPayload.AppEUI,
 		DevEUI:          ctx.JoinRequestPayload.DevEUI,
 		NwkSKey:         ctx.JoinAnsPayload.NwkSKey.AESKey,
+		SessionKeyID:    []byte(ctx.JoinAnsPayload.SessionKeyID),
 		FCntUp:          0,
 		FCntDown:        0,
 		RXWindow:        storage.RX1,
-		RXDelay:         uint8(common.RX1Delay),
-		RX1DROffset:     uint8(common.RX1DROffset),
-		RX2DR:           uint8(common.RX2DR),
-		EnabledChannels: common.Band.GetUplinkChannels(),
+		RXDelay:         uint8(ctx.DeviceProfile.GetRXDelay1()),
+		RX1DROffset:     uint8(ctx.DeviceProfile.GetRXDROffset1()),
+		RX2DR:           uint8(ctx.DeviceProfile.GetRXDataRate2()),
+		RX2Frequency:    ctx.DeviceProfile.GetRXFreq2(),
+		EnabledChannels: ctx.ActivationChannels.EnabledChannels,
 		LastRXInfoSet:   ctx.RXPacket.RXInfoSet,
 		MaxSupportedDR:  ctx.ServiceProfile.ServiceProfile.DRMax,
 	}
 
+	if ctx.RoamingClient != nil {
+		homeNetID := ctx.RoamingClient.NetID()
+		ctx.DeviceSession.HomeNetID = &homeNetID
+	}
+
 	if err := storage.SaveDeviceSession(common.RedisPool, ctx.DeviceSession); err != nil {
 		return errors.Wrap(err, "save node-session error")
 	}
@@ -176,6 +352,11 @@ func createNodeSession(ctx *JoinRequestContext) error {
 }
 
 func createDeviceActivation(ctx *JoinRequestContext) error {
+	// the device-activation is stored by the hNS (handover roaming)
+	if ctx.RoamingClient != nil {
+		return nil
+	}
+
 	da := storage.DeviceActivation{
 		DevEUI:   ctx.DeviceSession.DevEUI,
 		JoinEUI:  ctx.DeviceSession.JoinEUI,
@@ -196,7 +377,7 @@ func sendJoinAcceptDownlink(ctx *JoinRequestContext) error {
 		return errors.Wrap(err, "unmarshal downlink phypayload error")
 	}
 
-	if err := downlink.Flow.RunJoinResponse(ctx.DeviceSession, phy); err != nil {
+	if err := downlink.Flow.RunJoinResponse(ctx.DeviceProfile, ctx.DeviceSession, phy); err != nil {
 		return errors.Wrap(err, "run join-response flow error")
 	}
 
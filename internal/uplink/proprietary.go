@@ -11,6 +11,7 @@ import (
 	"github.com/brocaar/loraserver/api/as"
 	"github.com/brocaar/loraserver/internal/common"
 	"github.com/brocaar/loraserver/internal/gateway"
+	"github.com/brocaar/loraserver/internal/linktest"
 	"github.com/brocaar/loraserver/internal/storage"
 	"github.com/brocaar/lorawan"
 )
@@ -24,7 +25,25 @@ func setContextFromProprietaryPHYPayload(ctx *ProprietaryUpContext) error {
 	return nil
 }
 
+func handleLinkTestFrame(ctx *ProprietaryUpContext) error {
+	id, ok := linktest.GetLinkTestID(ctx.DataPayload.Bytes)
+	if !ok {
+		return nil
+	}
+	ctx.LinkTestID = id
+
+	if err := linktest.HandleRXInfoSet(common.DB, id, ctx.RXPacket.RXInfoSet); err != nil {
+		return errors.Wrap(err, "handle link test rx-info set error")
+	}
+	return nil
+}
+
 func sendProprietaryPayloadToApplicationServer(ctx *ProprietaryUpContext) error {
+	// link test frames are handled by LoRa Server itself
+	if ctx.LinkTestID != "" {
+		return nil
+	}
+
 	handleReq := as.HandleProprietaryUpRequest{
 		MacPayload: ctx.DataPayload.Bytes,
 		Mic:        ctx.RXPacket.PHYPayload.MIC[:],
@@ -59,10 +78,23 @@ func sendProprietaryPayloadToApplicationServer(ctx *ProprietaryUpContext) error
 		copy(mac, rxInfo.MAC[:])
 
 		asRxInfo := as.RXInfo{
-			Mac:     mac,
-			Time:    rxInfo.Time.Format(time.RFC3339Nano),
-			Rssi:    int32(rxInfo.RSSI),
-			LoRaSNR: rxInfo.LoRaSNR,
+			Mac:                    mac,
+			Time:                   rxInfo.Time.Format(time.RFC3339Nano),
+			Rssi:                   int32(rxInfo.RSSI),
+			LoRaSNR:                rxInfo.LoRaSNR,
+			Board:                  uint32(rxInfo.Board),
+			Antenna:                uint32(rxInfo.Antenna),
+			FineTimestampType:      rxInfo.FineTimestampType,
+			EncryptedFineTimestamp: rxInfo.EncryptedFineTimestamp,
+			FineTimestampKeyID:     uint32(rxInfo.FineTimestampKeyID),
+		}
+
+		if rxInfo.FineTimestamp != nil {
+			asRxInfo.FineTimestamp = uint32(*rxInfo.FineTimestamp)
+		}
+
+		if rxInfo.SignalRSSI != nil {
+			asRxInfo.SignalRSSI = int32(*rxInfo.SignalRSSI)
 		}
 
 		if gw, ok := gws[rxInfo.MAC]; ok {
@@ -0,0 +1,325 @@
+package uplink
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+
+	"github.com/brocaar/loraserver/api/gw"
+	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/devaddr"
+	"github.com/brocaar/loraserver/internal/models"
+	"github.com/brocaar/loraserver/internal/roaming"
+	"github.com/brocaar/loraserver/internal/storage"
+	"github.com/brocaar/lorawan"
+	"github.com/brocaar/lorawan/backend"
+)
+
+// forwardRoamingDataUp forwards the uplink to the sNS when the DevAddr
+// belongs to a roaming partner (passive-roaming fNS).
+func forwardRoamingDataUp(ctx *DataUpContext) error {
+	devAddr := ctx.MACPayload.FHDR.DevAddr
+	if common.RoamingPool == nil || devaddr.IsNetIDMember(common.NetID, devAddr) {
+		return nil
+	}
+
+	client, err := common.RoamingPool.GetForDevAddr(devAddr)
+	if err != nil {
+		if errors.Cause(err) == roaming.ErrNoAgreement {
+			return nil
+		}
+		return errors.Wrap(err, "get roaming client error")
+	}
+
+	if err := forwardDataUp(client, ctx.RXPacket, ctx.MACPayload); err != nil {
+		return errors.Wrap(err, "forward uplink to sNS error")
+	}
+
+	return ErrAbort
+}
+
+// forwardDataUp forwards the given uplink to the sNS. When a (stateful)
+// passive-roaming session exists and the MIC is valid, the uplink is
+// forwarded using a XmitDataReq, else using a PRStartReq.
+func forwardDataUp(client roaming.Client, rxPacket models.RXPacket, macPL *lorawan.MACPayload) error {
+	phyB, err := rxPacket.PHYPayload.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "marshal phypayload error")
+	}
+
+	ulMetaData, err := getULMetaData(client.NetID(), rxPacket, macPL)
+	if err != nil {
+		return errors.Wrap(err, "get uplink meta-data error")
+	}
+
+	logFields := log.Fields{
+		"net_id":   client.NetID(),
+		"dev_addr": macPL.FHDR.DevAddr,
+	}
+
+	s, err := roaming.GetSession(common.RedisPool, macPL.FHDR.DevAddr)
+	if err != nil && errors.Cause(err) != roaming.ErrDoesNotExist {
+		return errors.Wrap(err, "get roaming session error")
+	}
+
+	if err == nil && s.NetID == client.NetID() {
+		fullFCnt, ok := storage.ValidateAndGetFullFCntUp(storage.DeviceSession{FCntUp: s.FCntUp}, macPL.FHDR.FCnt)
+		if ok {
+			// the MIC is validated using a copy, so that the uplink is left
+			// unchanged when it does not belong to the session
+			fullMACPL := *macPL
+			fullMACPL.FHDR.FCnt = fullFCnt
+			phy := rxPacket.PHYPayload
+			phy.MACPayload = &fullMACPL
+
+			ok, err = phy.ValidateMIC(s.NwkSKey)
+			if err != nil {
+				return errors.Wrap(err, "validate mic error")
+			}
+		}
+
+		if ok {
+			macPL.FHDR.FCnt = fullFCnt
+			ulMetaData.DevEUI = s.DevEUI
+			ulMetaData.FCntUp = int(fullFCnt)
+
+			_, err := client.XmitDataReq(context.Background(), backend.XmitDataReqPayload{
+				PHYPayload: backend.HEXBytes(phyB),
+				ULMetaData: &ulMetaData,
+			})
+			if err != nil {
+				return errors.Wrap(err, "xmit data request error")
+			}
+
+			// the frame-counter must be persisted, else the session would
+			// not be able to validate the uplinks once the gap with the
+			// frame-counter returned by the sNS exceeds the max. FCnt gap
+			s.FCntUp = fullFCnt
+			if err := roaming.UpdateSession(common.RedisPool, s); err != nil && errors.Cause(err) != roaming.ErrDoesNotExist {
+				return errors.Wrap(err, "update roaming session error")
+			}
+
+			log.WithFields(logFields).Info("roaming: uplink forwarded to sNS")
+			return nil
+		}
+	}
+
+	ans, err := client.PRStartReq(context.Background(), backend.PRStartReqPayload{
+		PHYPayload: backend.HEXBytes(phyB),
+		ULMetaData: ulMetaData,
+	})
+	if err != nil {
+		return errors.Wrap(err, "passive-roaming start request error")
+	}
+
+	log.WithFields(logFields).Info("roaming: uplink forwarded to sNS")
+
+	// the sNS returns a lifetime (and the NwkSKey) in case of stateful
+	// passive-roaming
+	if ans.Lifetime == nil || *ans.Lifetime == 0 || ans.NwkSKey == nil {
+		return nil
+	}
+
+	s = roaming.Session{
+		NetID:   client.NetID(),
+		DevAddr: macPL.FHDR.DevAddr,
+		NwkSKey: ans.NwkSKey.AESKey,
+		FCntUp:  uint32(macPL.FHDR.FCnt),
+	}
+	if ans.DevEUI != nil {
+		s.DevEUI = *ans.DevEUI
+	}
+	if ans.FCntUp != nil {
+		s.FCntUp = uint32(*ans.FCntUp)
+	}
+
+	if err := roaming.SaveSession(common.RedisPool, s, time.Duration(*ans.Lifetime)*time.Second); err != nil {
+		return errors.Wrap(err, "save roaming session error")
+	}
+
+	return nil
+}
+
+// getULMetaData returns the uplink meta-data for the given uplink, sent to
+// the roaming partner with the given NetID. The ULToken of each gateway
+// references its rx-info, which is needed for sending the downlink (see
+// downlink.HandleRoamingDataDown). The rx-info itself is never sent to the
+// roaming partner. The macPL is nil in case of a join-request.
+func getULMetaData(netID lorawan.NetID, rxPacket models.RXPacket, macPL *lorawan.MACPayload) (backend.ULMetaData, error) {
+	ulMetaData := backend.ULMetaData{
+		Confirmed: rxPacket.PHYPayload.MHDR.MType == lorawan.ConfirmedDataUp,
+		GWCnt:     len(rxPacket.RXInfoSet),
+	}
+	if macPL != nil {
+		ulMetaData.DevAddr = macPL.FHDR.DevAddr
+		ulMetaData.FCntUp = int(macPL.FHDR.FCnt)
+		if macPL.FPort != nil {
+			ulMetaData.FPort = int(*macPL.FPort)
+		}
+	}
+
+	for i, rxInfo := range rxPacket.RXInfoSet {
+		if i == 0 {
+			dr, err := common.Band.GetDataRate(rxInfo.DataRate)
+			if err != nil {
+				return ulMetaData, errors.Wrap(err, "get data-rate error")
+			}
+
+			ulMetaData.DataRate = dr
+			ulMetaData.ULFreq = float64(rxInfo.Frequency) / 1000000
+			ulMetaData.RecvTime = rxInfo.Time.Format(time.RFC3339Nano)
+		}
+
+		ulToken, err := roaming.SaveULToken(common.RedisPool, roaming.ULToken{
+			NetID:  netID,
+			RXInfo: rxInfo,
+		})
+		if err != nil {
+			return ulMetaData, errors.Wrap(err, "save ul token error")
+		}
+
+		ulMetaData.GWInfo = append(ulMetaData.GWInfo, backend.GWInfoElement{
+			ID:        backend.HEXBytes(rxInfo.MAC[:]),
+			RSSI:      rxInfo.RSSI,
+			SNR:       rxInfo.LoRaSNR,
+			ULToken:   backend.HEXBytes(ulToken),
+			DLAllowed: true,
+		})
+	}
+
+	return ulMetaData, nil
+}
+
+// HandleRoamingDataUp handles the given uplink, forwarded by the fNS of the
+// roaming partner with the given NetID (passive-roaming sNS). The uplink is
+// validated before it is collected (and de-duplicated) together with the
+// copies received by other gateways and handled by the uplink flow. It
+// returns the device-session matching the uplink.
+func HandleRoamingDataUp(netID lorawan.NetID, phyB []byte, ulMetaData backend.ULMetaData) (storage.DeviceSession, error) {
+	var ds storage.DeviceSession
+
+	if _, err := getRoamingClient(netID); err != nil {
+		return ds, err
+	}
+
+	// the PHYPayload is unmarshaled for validation and for the uplink flow
+	// as GetDeviceSessionForPHYPayload modifies the FCnt
+	var phy, flowPHY lorawan.PHYPayload
+	if err := phy.UnmarshalBinary(phyB); err != nil {
+		return ds, errors.Wrap(roaming.ErrMalformedRequest, err.Error())
+	}
+	if err := flowPHY.UnmarshalBinary(phyB); err != nil {
+		return ds, errors.Wrap(roaming.ErrMalformedRequest, err.Error())
+	}
+
+	if phy.MHDR.MType != lorawan.UnconfirmedDataUp && phy.MHDR.MType != lorawan.ConfirmedDataUp {
+		return ds, errors.Wrap(roaming.ErrMalformedRequest, "uplink data payload expected")
+	}
+	macPL, ok := phy.MACPayload.(*lorawan.MACPayload)
+	if !ok {
+		return ds, errors.Wrap(roaming.ErrMalformedRequest, "uplink data payload expected")
+	}
+	devAddr := macPL.FHDR.DevAddr
+
+	if !devaddr.IsNetIDMember(common.NetID, devAddr) {
+		return ds, roaming.ErrUnknownDevAddr
+	}
+
+	ds, err := storage.GetDeviceSessionForPHYPayload(common.RedisPool, phy)
+	if err != nil {
+		if errors.Cause(err) == storage.ErrDoesNotExistOrFCntOrMICInvalid {
+			return ds, roaming.ErrUnknownDevAddr
+		}
+		return ds, errors.Wrap(err, "get device-session error")
+	}
+
+	sp, err := storage.GetServiceProfile(common.DB, ds.ServiceProfileID)
+	if err != nil {
+		return ds, errors.Wrap(err, "get service-profile error")
+	}
+	if !sp.ServiceProfile.PRAllowed {
+		return ds, roaming.ErrRoamingDisallowed
+	}
+
+	rxInfoSet, err := getRoamingRXInfoSet(netID, devAddr, len(phyB), ulMetaData)
+	if err != nil {
+		return ds, err
+	}
+
+	err = collectRXInfoSetAndCallOnce(common.RedisPool, flowPHY, rxInfoSet, func(rxPacket models.RXPacket) error {
+		return flow.Run(rxPacket)
+	})
+	if err != nil {
+		return ds, errors.Wrap(err, "handle roamed rx packet error")
+	}
+
+	return ds, nil
+}
+
+// getRoamingRXInfoSet returns the RXInfoSet for the given uplink meta-data.
+// For the gateways allowing downlink, the route for sending the downlink
+// through the fNS is stored (see roaming.Backend).
+func getRoamingRXInfoSet(netID lorawan.NetID, devAddr lorawan.DevAddr, size int, ulMetaData backend.ULMetaData) (models.RXInfoSet, error) {
+	var rxInfoSet models.RXInfoSet
+
+	if ulMetaData.DataRate < 0 || ulMetaData.DataRate > len(common.Band.DataRates)-1 {
+		return nil, errors.Wrapf(roaming.ErrMalformedRequest, "invalid data-rate: %d", ulMetaData.DataRate)
+	}
+
+	// the receive time is optional
+	recvTime, _ := time.Parse(time.RFC3339Nano, ulMetaData.RecvTime)
+
+	for _, gwInfo := range ulMetaData.GWInfo {
+		var mac lorawan.EUI64
+		if len(gwInfo.ID) != len(mac) {
+			return nil, errors.Wrapf(roaming.ErrMalformedRequest, "invalid gateway id: %s", gwInfo.ID)
+		}
+		copy(mac[:], gwInfo.ID)
+
+		// the gateway timestamp is unknown to the sNS, it is part of the
+		// ULToken
+		rxInfoSet = append(rxInfoSet, gw.RXInfo{
+			MAC:       mac,
+			Time:      recvTime,
+			Frequency: int(ulMetaData.ULFreq*1000000 + 0.5),
+			CRCStatus: 1,
+			RSSI:      gwInfo.RSSI,
+			LoRaSNR:   gwInfo.SNR,
+			Size:      size,
+			DataRate:  common.Band.DataRates[ulMetaData.DataRate],
+		})
+
+		if !gwInfo.DLAllowed {
+			continue
+		}
+
+		err := roaming.SaveGatewayRoute(common.RedisPool, mac, devAddr, roaming.GatewayRoute{
+			NetID:      netID,
+			ULToken:    gwInfo.ULToken,
+			FNSULToken: ulMetaData.FNSULToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "save gateway route error")
+		}
+	}
+
+	if len(rxInfoSet) == 0 {
+		return nil, errors.Wrap(roaming.ErrMalformedRequest, "gateway info expected")
+	}
+	sort.Sort(rxInfoSet)
+
+	return rxInfoSet, nil
+}
+
+// getRoamingClient returns the roaming client for the given NetID.
+// ErrNoAgreement is returned when roaming is disabled or when there is no
+// agreement with the given NetID.
+func getRoamingClient(netID lorawan.NetID) (roaming.Client, error) {
+	if common.RoamingPool == nil {
+		return nil, roaming.ErrNoAgreement
+	}
+	return common.RoamingPool.Get(netID)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/brocaar/loraserver/api/gw"
 	"github.com/brocaar/loraserver/internal/common"
+	"github.com/brocaar/loraserver/internal/gateway"
 	"github.com/brocaar/loraserver/internal/models"
 	"github.com/brocaar/loraserver/internal/node"
 	"github.com/brocaar/lorawan"
@@ -18,16 +19,22 @@ import (
 var flow = NewFlow().JoinRequest(
 	setContextFromJoinRequestPHYPayload,
 	logJoinRequestFramesCollected,
+	checkJoinRateLimits,
 	getDeviceAndDeviceProfile,
 	validateNonce,
 	getRandomDevAddr,
+	getActivationChannels,
 	getJoinAcceptFromAS,
+	getJoinAcceptFromHomeNS,
+	countJoinRequest,
 	logJoinRequestFrame,
 	createNodeSession,
 	createDeviceActivation,
 	sendJoinAcceptDownlink,
+	stopHandoverRoaming,
 ).DataUp(
 	setContextFromDataPHYPayload,
+	forwardRoamingDataUp,
 	getNodeSessionForDataUp,
 	getServiceProfile,
 	logDataFramesCollected,
@@ -46,6 +53,7 @@ var flow = NewFlow().JoinRequest(
 	handleDownlink,
 ).ProprietaryUp(
 	setContextFromProprietaryPHYPayload,
+	handleLinkTestFrame,
 	sendProprietaryPayloadToApplicationServer,
 )
 
@@ -97,6 +105,13 @@ func HandleRXPackets(wg *sync.WaitGroup) {
 
 // HandleRXPacket handles a single rxpacket.
 func HandleRXPacket(rxPacket gw.RXPacket) error {
+	gateway.QueueRXPacketStats(rxPacket)
+
+	// packets with an invalid CRC are only used for the gateway stats
+	if rxPacket.RXInfo.CRCStatus == gw.CRCStatusError {
+		return nil
+	}
+
 	return collectPackets(rxPacket)
 }
 
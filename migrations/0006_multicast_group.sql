@@ -0,0 +1,35 @@
+-- +migrate Up
+create table multicast_group (
+    id uuid primary key,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    mc_addr bytea not null,
+    mc_nwk_s_key bytea not null,
+    f_cnt integer not null,
+    dr integer not null,
+    frequency integer not null,
+    service_profile_id uuid not null references service_profile on delete cascade
+);
+
+create index idx_multicast_group_created_at on multicast_group(created_at);
+create index idx_multicast_group_updated_at on multicast_group(updated_at);
+create index idx_multicast_group_service_profile_id on multicast_group(service_profile_id);
+
+create table device_multicast_group (
+    dev_eui bytea not null references device on delete cascade,
+    multicast_group_id uuid not null references multicast_group on delete cascade,
+    created_at timestamp with time zone not null,
+
+    primary key(dev_eui, multicast_group_id)
+);
+
+create index idx_device_multicast_group_multicast_group_id on device_multicast_group(multicast_group_id);
+
+-- +migrate Down
+drop index idx_device_multicast_group_multicast_group_id;
+drop table device_multicast_group;
+
+drop index idx_multicast_group_service_profile_id;
+drop index idx_multicast_group_updated_at;
+drop index idx_multicast_group_created_at;
+drop table multicast_group;
@@ -0,0 +1,21 @@
+-- +migrate Up
+create table device_keys (
+    dev_eui bytea primary key references device on delete cascade,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    kek_label varchar(100) not null default '',
+    nwk_key bytea not null,
+    app_key bytea not null,
+    join_nonce integer not null default 0,
+    session_key_id bytea,
+    app_s_key_kek_label varchar(100) not null default '',
+    app_s_key bytea
+);
+
+create index idx_device_keys_created_at on device_keys(created_at);
+create index idx_device_keys_updated_at on device_keys(updated_at);
+
+-- +migrate Down
+drop index idx_device_keys_updated_at;
+drop index idx_device_keys_created_at;
+drop table device_keys;
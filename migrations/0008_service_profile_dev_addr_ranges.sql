@@ -0,0 +1,7 @@
+-- +migrate Up
+alter table service_profile
+    add column dev_addr_ranges text[] not null default '{}';
+
+-- +migrate Down
+alter table service_profile
+    drop column dev_addr_ranges;
@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table gateway
+    add column state varchar(20) not null default 'NEVER_SEEN',
+    add column state_changed_at timestamp with time zone;
+
+create index idx_gateway_state on gateway (state);
+
+-- +migrate Down
+drop index idx_gateway_state;
+
+alter table gateway
+    drop column state_changed_at,
+    drop column state;
@@ -0,0 +1,66 @@
+-- +migrate Up
+alter table gateway_stats
+    add column rx_frames int not null default 0,
+    add column rx_frames_crc_error int not null default 0,
+    add column rx_rssi_sum bigint not null default 0,
+    add column rx_snr_sum double precision not null default 0,
+    add column tx_frames_scheduled int not null default 0,
+    add column tx_frames_acked int not null default 0;
+
+create table gateway_stats_frame (
+    id bigserial primary key,
+    mac bytea not null references gateway on delete cascade,
+    "timestamp" timestamp with time zone not null,
+    "interval" varchar(10) not null,
+    frequency int not null,
+    dr smallint not null,
+    rx_frames int not null,
+
+    unique (mac, "timestamp", "interval", frequency, dr)
+);
+
+create index idx_gateway_stats_frame_timestamp on gateway_stats_frame ("timestamp");
+
+create table gateway_stats_signal (
+    id bigserial primary key,
+    mac bytea not null references gateway on delete cascade,
+    "timestamp" timestamp with time zone not null,
+    "interval" varchar(10) not null,
+    metric varchar(10) not null,
+    value smallint not null,
+    count int not null,
+
+    unique (mac, "timestamp", "interval", metric, value)
+);
+
+create index idx_gateway_stats_signal_timestamp on gateway_stats_signal ("timestamp");
+
+create table gateway_stats_device (
+    id bigserial primary key,
+    mac bytea not null references gateway on delete cascade,
+    "timestamp" timestamp with time zone not null,
+    "interval" varchar(10) not null,
+    device bytea not null,
+
+    unique (mac, "timestamp", "interval", device)
+);
+
+create index idx_gateway_stats_device_timestamp on gateway_stats_device ("timestamp");
+
+-- +migrate Down
+drop index idx_gateway_stats_device_timestamp;
+drop table gateway_stats_device;
+
+drop index idx_gateway_stats_signal_timestamp;
+drop table gateway_stats_signal;
+
+drop index idx_gateway_stats_frame_timestamp;
+drop table gateway_stats_frame;
+
+alter table gateway_stats
+    drop column tx_frames_acked,
+    drop column tx_frames_scheduled,
+    drop column rx_snr_sum,
+    drop column rx_rssi_sum,
+    drop column rx_frames_crc_error,
+    drop column rx_frames;
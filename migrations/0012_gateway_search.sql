@@ -0,0 +1,28 @@
+-- +migrate Up
+-- the pg_trgm extension requires superuser permissions, when it can't be
+-- created the gateway search works without the trigram indexes
+-- +migrate StatementBegin
+do $$
+begin
+    if not exists (select 1 from pg_extension where extname = 'pg_trgm') then
+        begin
+            create extension pg_trgm;
+        exception when insufficient_privilege then
+            raise warning 'pg_trgm extension could not be created, gateway search indexes are skipped';
+        end;
+    end if;
+
+    if exists (select 1 from pg_extension where extname = 'pg_trgm') then
+        create index idx_gateway_name_trgm on gateway using gin (name gin_trgm_ops);
+        create index idx_gateway_description_trgm on gateway using gin (description gin_trgm_ops);
+    end if;
+end
+$$;
+-- +migrate StatementEnd
+
+create index idx_gateway_location on gateway using gist (location);
+
+-- +migrate Down
+drop index idx_gateway_location;
+drop index if exists idx_gateway_description_trgm;
+drop index if exists idx_gateway_name_trgm;
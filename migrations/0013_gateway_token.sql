@@ -0,0 +1,17 @@
+-- +migrate Up
+create table gateway_token (
+    id uuid primary key,
+    mac bytea not null references gateway on delete cascade,
+    key_id varchar(100) not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+create index idx_gateway_token_mac on gateway_token(mac);
+create index idx_gateway_token_created_at on gateway_token(created_at);
+
+-- +migrate Down
+drop index idx_gateway_token_created_at;
+drop index idx_gateway_token_mac;
+drop table gateway_token;
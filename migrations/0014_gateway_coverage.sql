@@ -0,0 +1,19 @@
+-- +migrate Up
+create table gateway_coverage (
+    mac bytea not null references gateway on delete cascade,
+    geohash varchar(12) not null,
+    location point not null,
+    rssi smallint not null,
+    snr double precision not null,
+    rx_count bigint not null,
+    first_seen_at timestamp with time zone not null,
+    last_seen_at timestamp with time zone not null,
+
+    primary key (mac, geohash)
+);
+
+create index idx_gateway_coverage_location on gateway_coverage using gist (location);
+
+-- +migrate Down
+drop index idx_gateway_coverage_location;
+drop table gateway_coverage;
@@ -0,0 +1,28 @@
+-- +migrate Up
+create table gateway_link_test (
+    id uuid primary key,
+    mac bytea not null references gateway on delete cascade,
+    created_at timestamp with time zone not null,
+    frequency int not null,
+    dr smallint not null
+);
+
+create index idx_gateway_link_test_mac_created_at on gateway_link_test(mac, created_at);
+
+create table gateway_link_test_rx (
+    link_test_id uuid not null references gateway_link_test on delete cascade,
+    mac bytea not null references gateway on delete cascade,
+    rssi smallint not null,
+    snr double precision not null,
+    received_at timestamp with time zone not null,
+
+    primary key (link_test_id, mac)
+);
+
+create index idx_gateway_link_test_rx_mac on gateway_link_test_rx(mac);
+
+-- +migrate Down
+drop index idx_gateway_link_test_rx_mac;
+drop table gateway_link_test_rx;
+drop index idx_gateway_link_test_mac_created_at;
+drop table gateway_link_test;
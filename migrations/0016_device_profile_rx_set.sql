@@ -0,0 +1,13 @@
+-- +migrate Up
+alter table device_profile
+    add column rx_dr_offset_1_set boolean not null default false,
+    add column rx_data_rate_2_set boolean not null default false;
+
+-- before, a non-zero value indicated that the value was set
+update device_profile set rx_dr_offset_1_set = true where rx_dr_offset_1 != 0;
+update device_profile set rx_data_rate_2_set = true where rx_data_rate_2 != 0;
+
+-- +migrate Down
+alter table device_profile
+    drop column rx_data_rate_2_set,
+    drop column rx_dr_offset_1_set;
@@ -0,0 +1,10 @@
+-- +migrate Up
+create table device_coverage_report (
+    dev_eui bytea primary key references device on delete cascade,
+    dev_addr bytea not null,
+    f_cnt bigint not null,
+    reported_at timestamp with time zone not null
+);
+
+-- +migrate Down
+drop table device_coverage_report;